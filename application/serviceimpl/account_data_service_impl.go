@@ -0,0 +1,756 @@
+package serviceimpl
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/storage"
+	"gofiber-template/pkg/database"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	dataExportCooldown    = 24 * time.Hour     // one export request per day
+	dataExportTTL         = 7 * 24 * time.Hour // archive is deleted after this
+	dataExportLinkTTL     = 15 * time.Minute   // presigned download URL lifetime
+	dataExportListLimit   = 10
+	dataExportBatchSize   = 5   // archives built per job run
+	dataExportPageSize    = 200 // rows read per query while building an archive
+	accountDeletionDelay  = 14 * 24 * time.Hour
+	accountDeletionBatch  = 20
+	accountMediaBatchSize = 100
+
+	accountDeletionReferenceType = "account_deletion"
+)
+
+type AccountDataServiceImpl struct {
+	txManager       *database.TransactionManager
+	accountDataRepo repositories.AccountDataRepository
+	userRepo        repositories.UserRepository
+	postRepo        repositories.PostRepository
+	commentRepo     repositories.CommentRepository
+	voteRepo        repositories.VoteRepository
+	mediaRepo       repositories.MediaRepository
+	notifRepo       repositories.NotificationRepository
+	walletRepo      repositories.WalletRepository
+	walletService   services.WalletService
+	sessionService  services.SessionService
+	notifService    services.NotificationService
+	r2Storage       storage.R2Storage // nil when R2 is not configured (exports unavailable)
+	bunnyStorage    storage.BunnyStorage
+	bunnyStream     *storage.BunnyStreamService
+}
+
+func NewAccountDataService(
+	txManager *database.TransactionManager,
+	accountDataRepo repositories.AccountDataRepository,
+	userRepo repositories.UserRepository,
+	postRepo repositories.PostRepository,
+	commentRepo repositories.CommentRepository,
+	voteRepo repositories.VoteRepository,
+	mediaRepo repositories.MediaRepository,
+	notifRepo repositories.NotificationRepository,
+	walletRepo repositories.WalletRepository,
+	walletService services.WalletService,
+	sessionService services.SessionService,
+	notifService services.NotificationService,
+	r2Storage storage.R2Storage,
+	bunnyStorage storage.BunnyStorage,
+	bunnyStream *storage.BunnyStreamService,
+) services.AccountDataService {
+	return &AccountDataServiceImpl{
+		txManager:       txManager,
+		accountDataRepo: accountDataRepo,
+		userRepo:        userRepo,
+		postRepo:        postRepo,
+		commentRepo:     commentRepo,
+		voteRepo:        voteRepo,
+		mediaRepo:       mediaRepo,
+		notifRepo:       notifRepo,
+		walletRepo:      walletRepo,
+		walletService:   walletService,
+		sessionService:  sessionService,
+		notifService:    notifService,
+		r2Storage:       r2Storage,
+		bunnyStorage:    bunnyStorage,
+		bunnyStream:     bunnyStream,
+	}
+}
+
+// ==================== Data Export ====================
+
+func (s *AccountDataServiceImpl) RequestExport(ctx context.Context, userID uuid.UUID) (*dto.DataExportResponse, error) {
+	if s.r2Storage == nil {
+		return nil, services.ErrDataExportUnavailable
+	}
+
+	latest, err := s.accountDataRepo.GetLatestExport(ctx, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if latest != nil {
+		switch {
+		case latest.Status == models.DataExportStatusPending || latest.Status == models.DataExportStatusProcessing:
+			return nil, services.ErrDataExportInProgress
+		case latest.Status != models.DataExportStatusFailed && time.Since(latest.CreatedAt) < dataExportCooldown:
+			return nil, services.ErrDataExportTooSoon
+		}
+	}
+
+	export := &models.DataExport{
+		UserID: userID,
+		Status: models.DataExportStatusPending,
+	}
+	if err := s.accountDataRepo.CreateExport(ctx, export); err != nil {
+		return nil, err
+	}
+
+	return dto.DataExportToResponse(export), nil
+}
+
+func (s *AccountDataServiceImpl) ListExports(ctx context.Context, userID uuid.UUID) (*dto.DataExportListResponse, error) {
+	exports, err := s.accountDataRepo.ListExportsByUser(ctx, userID, dataExportListLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.DataExportListResponse{Exports: make([]dto.DataExportResponse, 0, len(exports))}
+	for _, export := range exports {
+		item := dto.DataExportToResponse(export)
+		if export.Status == models.DataExportStatusReady && export.FileKey != nil && s.r2Storage != nil {
+			url, err := s.r2Storage.GeneratePresignedDownloadURL(ctx, *export.FileKey, dataExportLinkTTL)
+			if err != nil {
+				log.Printf("[EXPORT] Failed to sign download URL of export %s: %v", export.ID, err)
+			} else {
+				item.DownloadURL = &url
+			}
+		}
+		resp.Exports = append(resp.Exports, *item)
+	}
+
+	return resp, nil
+}
+
+func (s *AccountDataServiceImpl) ProcessExports(ctx context.Context) (int, error) {
+	if s.r2Storage == nil {
+		return 0, nil
+	}
+
+	s.deleteExpiredExports(ctx)
+
+	exports, err := s.accountDataRepo.ListPendingExports(ctx, dataExportBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	built := 0
+	for _, export := range exports {
+		claimed, err := s.accountDataRepo.MarkExportProcessing(ctx, export.ID)
+		if err != nil {
+			log.Printf("[EXPORT] Failed to claim export %s: %v", export.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := s.buildExport(ctx, export); err != nil {
+			log.Printf("[EXPORT] Failed to build export %s: %v", export.ID, err)
+			if err := s.accountDataRepo.MarkExportFailed(ctx, export.ID, err.Error()); err != nil {
+				log.Printf("[EXPORT] Failed to mark export %s as failed: %v", export.ID, err)
+			}
+			_ = s.notifService.CreateNotification(ctx, export.UserID, export.UserID, "account", "ไม่สามารถเตรียมไฟล์ข้อมูลของคุณได้ กรุณาลองใหม่อีกครั้ง", nil, nil)
+			continue
+		}
+		built++
+
+		_ = s.notifService.CreateNotification(ctx, export.UserID, export.UserID, "account", "ไฟล์ข้อมูลของคุณพร้อมดาวน์โหลดแล้ว (ดาวน์โหลดได้ภายใน 7 วัน)", nil, nil)
+	}
+
+	return built, nil
+}
+
+// buildExport writes the archive to a temp file, uploads it to R2 and marks the export ready
+func (s *AccountDataServiceImpl) buildExport(ctx context.Context, export *models.DataExport) error {
+	user, err := s.userRepo.GetByID(ctx, export.UserID)
+	if err != nil {
+		return fmt.Errorf("load user: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := s.writeArchive(ctx, tmp, user); err != nil {
+		return err
+	}
+
+	size, err := tmp.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("exports/%s/%s.zip", export.UserID, export.ID)
+	if _, err := s.r2Storage.UploadFile(ctx, tmp, key, "application/zip"); err != nil {
+		return fmt.Errorf("upload archive: %w", err)
+	}
+
+	now := time.Now()
+	return s.accountDataRepo.MarkExportReady(ctx, export.ID, key, size, now, now.Add(dataExportTTL))
+}
+
+func (s *AccountDataServiceImpl) writeArchive(ctx context.Context, file *os.File, user *models.User) error {
+	zw := zip.NewWriter(file)
+	userID := user.ID
+
+	if err := writeExportJSON(zw, "profile.json", profileToExport(user)); err != nil {
+		return err
+	}
+	if err := writeExportSection(zw, "posts.json", func(offset int) ([]*models.Post, error) {
+		return s.postRepo.ListByAuthor(ctx, userID, offset, dataExportPageSize, &userID)
+	}, postToExport); err != nil {
+		return err
+	}
+	if err := writeExportSection(zw, "comments.json", func(offset int) ([]*models.Comment, error) {
+		return s.commentRepo.ListByAuthor(ctx, userID, offset, dataExportPageSize, &userID)
+	}, commentToExport); err != nil {
+		return err
+	}
+	if err := writeExportSection(zw, "votes.json", func(offset int) ([]*models.Vote, error) {
+		return s.voteRepo.ListByUser(ctx, userID, "", offset, dataExportPageSize)
+	}, voteToExport); err != nil {
+		return err
+	}
+	if err := writeExportSection(zw, "messages.json", func(offset int) ([]*models.Message, error) {
+		return s.accountDataRepo.ListMessagesByParticipant(ctx, userID, offset, dataExportPageSize)
+	}, func(m *models.Message) exportMessage { return messageToExport(m, userID) }); err != nil {
+		return err
+	}
+	if err := writeExportSection(zw, "media.json", func(offset int) ([]*models.Media, error) {
+		return s.mediaRepo.ListByUser(ctx, userID, offset, dataExportPageSize)
+	}, mediaToExport); err != nil {
+		return err
+	}
+	if err := writeExportSection(zw, "notifications.json", func(offset int) ([]*models.Notification, error) {
+		return s.notifRepo.ListByUser(ctx, userID, offset, dataExportPageSize)
+	}, notificationToExport); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// deleteExpiredExports removes archives past their expiry from R2 (retried next run on failure)
+func (s *AccountDataServiceImpl) deleteExpiredExports(ctx context.Context) {
+	exports, err := s.accountDataRepo.ListExpiredExports(ctx, time.Now(), dataExportListLimit*dataExportBatchSize)
+	if err != nil {
+		log.Printf("[EXPORT] Failed to list expired exports: %v", err)
+		return
+	}
+
+	for _, export := range exports {
+		if export.FileKey != nil {
+			if err := s.r2Storage.DeleteFile(ctx, *export.FileKey); err != nil {
+				log.Printf("[EXPORT] Failed to delete archive of export %s (will retry): %v", export.ID, err)
+				continue
+			}
+		}
+		if err := s.accountDataRepo.MarkExportExpired(ctx, export.ID); err != nil {
+			log.Printf("[EXPORT] Failed to expire export %s: %v", export.ID, err)
+		}
+	}
+}
+
+// ==================== Account Deletion ====================
+
+func (s *AccountDataServiceImpl) RequestDeletion(ctx context.Context, userID uuid.UUID, req *dto.RequestAccountDeletionRequest) (*dto.AccountDeletionResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletionScheduledAt != nil {
+		return nil, services.ErrDeletionAlreadyPending
+	}
+
+	// OAuth-only accounts have no password to confirm with
+	if user.Password != "" {
+		if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)) != nil {
+			return nil, services.ErrDeletionPasswordInvalid
+		}
+	}
+
+	// Money left in the wallet would be lost with the account, unless the user agrees to give it up
+	if err := s.requireEmptyWallet(ctx, userID, req.ForfeitBalance); err != nil {
+		return nil, err
+	}
+
+	scheduledAt := time.Now().Add(accountDeletionDelay)
+	if err := s.accountDataRepo.ScheduleDeletion(ctx, userID, &scheduledAt, req.ForfeitBalance); err != nil {
+		return nil, err
+	}
+
+	_ = s.notifService.CreateNotification(ctx, userID, userID, "account",
+		fmt.Sprintf("บัญชีของคุณจะถูกลบในวันที่ %s หากไม่ต้องการลบ สามารถยกเลิกได้ก่อนถึงวันดังกล่าว", scheduledAt.Format("02/01/2006")),
+		nil, nil)
+
+	return &dto.AccountDeletionResponse{IsScheduled: true, ScheduledAt: &scheduledAt, ForfeitsBalance: req.ForfeitBalance}, nil
+}
+
+func (s *AccountDataServiceImpl) GetDeletionStatus(ctx context.Context, userID uuid.UUID) (*dto.AccountDeletionResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AccountDeletionResponse{
+		IsScheduled:     user.DeletionScheduledAt != nil,
+		ScheduledAt:     user.DeletionScheduledAt,
+		ForfeitsBalance: user.DeletionScheduledAt != nil && user.DeletionForfeitsBalance,
+	}, nil
+}
+
+func (s *AccountDataServiceImpl) CancelDeletion(ctx context.Context, userID uuid.UUID) (*dto.AccountDeletionResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.DeletionScheduledAt == nil {
+		return nil, services.ErrDeletionNotPending
+	}
+
+	if err := s.accountDataRepo.ScheduleDeletion(ctx, userID, nil, false); err != nil {
+		return nil, err
+	}
+
+	return &dto.AccountDeletionResponse{IsScheduled: false}, nil
+}
+
+func (s *AccountDataServiceImpl) ProcessDeletions(ctx context.Context) (int, error) {
+	now := time.Now()
+	users, err := s.accountDataRepo.ListDueDeletions(ctx, now, accountDeletionBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, user := range users {
+		// Money may have come in since the request (gifts, subscriptions, unlocks)
+		if err := s.requireEmptyWallet(ctx, user.ID, user.DeletionForfeitsBalance); err != nil {
+			s.abortDeletion(ctx, user.ID, err)
+			continue
+		}
+
+		// The media rows go with the anonymization, so the files to delete are listed first
+		var media []*models.Media
+		err := s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+			// Checked again under the wallet row lock, so nothing is credited to a deleted account
+			if err := s.requireEmptyWallet(ctx, user.ID, user.DeletionForfeitsBalance); err != nil {
+				return err
+			}
+			if err := s.forfeitBalance(ctx, user.ID); err != nil {
+				return err
+			}
+			var err error
+			if media, err = s.listRemovableMedia(ctx, user.ID); err != nil {
+				return err
+			}
+			return s.accountDataRepo.AnonymizeUser(ctx, user.ID, time.Now())
+		})
+		if err != nil {
+			s.abortDeletion(ctx, user.ID, err)
+			continue
+		}
+
+		// Files last: a failed anonymization must not leave the user's content pointing at deleted files
+		s.deleteMediaFiles(ctx, user.ID, media)
+
+		if _, err := s.sessionService.RevokeAllSessions(ctx, user.ID, nil, models.SessionRevokeAccountDelete); err != nil {
+			log.Printf("[ACCOUNT] Failed to revoke sessions of user %s: %v", user.ID, err)
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// requireEmptyWallet returns services.ErrDeletionWalletHeld while holds reserve part of the user's wallet and
+// services.ErrDeletionWalletNotEmpty while it has a balance the user did not agree to forfeit;
+// inside a transaction the wallet row stays locked until it ends
+func (s *AccountDataServiceImpl) requireEmptyWallet(ctx context.Context, userID uuid.UUID, forfeitBalance bool) error {
+	return s.walletRepo.Transaction(ctx, func(repo repositories.WalletRepository) error {
+		wallet, err := repo.GetByUserID(ctx, userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		wallet, err = repo.GetForUpdate(ctx, wallet.ID)
+		if err != nil {
+			return err
+		}
+		if wallet.HeldAmount > 0 {
+			return services.ErrDeletionWalletHeld
+		}
+		if wallet.Balance > 0 && !forfeitBalance {
+			return services.ErrDeletionWalletNotEmpty
+		}
+		return nil
+	})
+}
+
+// forfeitBalance moves what is left in the user's wallet to the forfeited system wallet (the user consented
+// when asking for deletion); runs in the anonymization transaction, after requireEmptyWallet locked the row
+func (s *AccountDataServiceImpl) forfeitBalance(ctx context.Context, userID uuid.UUID) error {
+	wallet, err := s.walletRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if wallet.Balance <= 0 {
+		return nil
+	}
+
+	forfeited, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletForfeited)
+	if err != nil {
+		return err
+	}
+
+	refType := accountDeletionReferenceType
+	_, err = s.walletService.Transfer(ctx, &dto.WalletTransferRequest{
+		IdempotencyKey: "account_forfeit:" + userID.String(),
+		FromWalletID:   wallet.ID,
+		ToWalletID:     forfeited.ID,
+		Amount:         wallet.Balance,
+		Description:    "Balance forfeited on account deletion",
+		ReferenceType:  &refType,
+		ReferenceID:    &userID,
+	})
+	return err
+}
+
+// abortDeletion handles a deletion that could not run: a funded wallet cancels it (the user has to
+// withdraw or consent to forfeit and ask again), anything else is retried by the next job run
+func (s *AccountDataServiceImpl) abortDeletion(ctx context.Context, userID uuid.UUID, err error) {
+	if !errors.Is(err, services.ErrDeletionWalletNotEmpty) && !errors.Is(err, services.ErrDeletionWalletHeld) {
+		log.Printf("[ACCOUNT] Failed to anonymize user %s: %v", userID, err)
+		return
+	}
+
+	if err := s.accountDataRepo.ScheduleDeletion(ctx, userID, nil, false); err != nil {
+		log.Printf("[ACCOUNT] Failed to cancel deletion of user %s: %v", userID, err)
+		return
+	}
+	_ = s.notifService.CreateNotification(ctx, userID, userID, "account",
+		"การลบบัญชีของคุณถูกยกเลิก เนื่องจากยังมียอดเงินคงเหลือในกระเป๋า กรุณาถอนหรือใช้เงินให้หมด หรือยินยอมสละยอดเงินคงเหลือ ก่อนขอลบบัญชีอีกครั้ง",
+		nil, nil)
+}
+
+func (s *AccountDataServiceImpl) listRemovableMedia(ctx context.Context, userID uuid.UUID) ([]*models.Media, error) {
+	var all []*models.Media
+	for offset := 0; ; offset += accountMediaBatchSize {
+		media, err := s.accountDataRepo.ListRemovableMedia(ctx, userID, offset, accountMediaBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, media...)
+
+		if len(media) < accountMediaBatchSize {
+			return all, nil
+		}
+	}
+}
+
+// deleteMediaFiles removes the files of an anonymized user from Bunny Storage, Bunny Stream and R2;
+// their rows are already gone, so a failure is logged with the URL for manual cleanup
+func (s *AccountDataServiceImpl) deleteMediaFiles(ctx context.Context, userID uuid.UUID, media []*models.Media) {
+	for _, m := range media {
+		if err := s.deleteMediaFile(ctx, m); err != nil {
+			log.Printf("[ACCOUNT] Failed to delete media %s (%s) of user %s: %v", m.ID, m.URL, userID, err)
+		}
+	}
+}
+
+func (s *AccountDataServiceImpl) deleteMediaFile(ctx context.Context, media *models.Media) error {
+	if media.VideoID != nil && s.bunnyStream != nil {
+		return s.bunnyStream.DeleteVideo(*media.VideoID)
+	}
+
+	if s.bunnyStorage != nil {
+		if prefix := s.bunnyStorage.GetFileURL(""); strings.HasPrefix(media.URL, prefix) {
+			return s.bunnyStorage.DeleteFile(strings.TrimPrefix(media.URL, prefix))
+		}
+	}
+
+	if s.r2Storage != nil {
+		if prefix := s.r2Storage.GetPublicURL(""); strings.HasPrefix(media.URL, prefix) {
+			return s.r2Storage.DeleteFile(ctx, strings.TrimPrefix(media.URL, prefix))
+		}
+	}
+
+	// Hosted elsewhere (e.g. an external URL), nothing to delete
+	return nil
+}
+
+// ==================== Archive Contents ====================
+
+// Export rows are explicit structs so internal fields (password hash, moderation notes) never leak
+
+type exportProfile struct {
+	ID               uuid.UUID `json:"id"`
+	Email            string    `json:"email"`
+	Username         string    `json:"username"`
+	DisplayName      string    `json:"displayName"`
+	Avatar           string    `json:"avatar"`
+	Bio              string    `json:"bio"`
+	Location         string    `json:"location"`
+	Website          string    `json:"website"`
+	IsPrivate        bool      `json:"isPrivate"`
+	ChatPrivacy      string    `json:"chatPrivacy"`
+	EmailVerified    bool      `json:"emailVerified"`
+	TwoFactorEnabled bool      `json:"twoFactorEnabled"`
+	Karma            int       `json:"karma"`
+	FollowersCount   int       `json:"followersCount"`
+	FollowingCount   int       `json:"followingCount"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+type exportPost struct {
+	ID           uuid.UUID  `json:"id"`
+	Title        string     `json:"title"`
+	Content      string     `json:"content"`
+	Type         string     `json:"type"`
+	CommunityID  *uuid.UUID `json:"communityId,omitempty"`
+	SourcePostID *uuid.UUID `json:"sourcePostId,omitempty"`
+	Status       string     `json:"status"`
+	Votes        int        `json:"votes"`
+	CommentCount int        `json:"commentCount"`
+	MediaURLs    []string   `json:"mediaUrls"`
+	Tags         []string   `json:"tags"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+type exportComment struct {
+	ID        uuid.UUID  `json:"id"`
+	PostID    uuid.UUID  `json:"postId"`
+	ParentID  *uuid.UUID `json:"parentId,omitempty"`
+	Content   string     `json:"content"`
+	Votes     int        `json:"votes"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type exportVote struct {
+	TargetType string    `json:"targetType"`
+	TargetID   uuid.UUID `json:"targetId"`
+	VoteType   string    `json:"voteType"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type exportMessage struct {
+	ID             uuid.UUID       `json:"id"`
+	ConversationID uuid.UUID       `json:"conversationId"`
+	Direction      string          `json:"direction"` // sent, received
+	Type           string          `json:"type"`
+	Content        *string         `json:"content,omitempty"`
+	Media          json.RawMessage `json:"media,omitempty"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+type exportMedia struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	FileName  string    `json:"fileName"`
+	MimeType  string    `json:"mimeType"`
+	Size      int64     `json:"size"`
+	URL       string    `json:"url"`
+	Thumbnail string    `json:"thumbnail,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type exportNotification struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	Message   string    `json:"message"`
+	IsRead    bool      `json:"isRead"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func profileToExport(user *models.User) exportProfile {
+	return exportProfile{
+		ID:               user.ID,
+		Email:            user.Email,
+		Username:         user.Username,
+		DisplayName:      user.DisplayName,
+		Avatar:           user.Avatar,
+		Bio:              user.Bio,
+		Location:         user.Location,
+		Website:          user.Website,
+		IsPrivate:        user.IsPrivate,
+		ChatPrivacy:      user.ChatPrivacy,
+		EmailVerified:    user.EmailVerified,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+		Karma:            user.Karma,
+		FollowersCount:   user.FollowersCount,
+		FollowingCount:   user.FollowingCount,
+		CreatedAt:        user.CreatedAt,
+	}
+}
+
+func postToExport(post *models.Post) exportPost {
+	item := exportPost{
+		ID:           post.ID,
+		Title:        post.Title,
+		Content:      post.Content,
+		Type:         post.Type,
+		CommunityID:  post.CommunityID,
+		SourcePostID: post.SourcePostID,
+		Status:       post.Status,
+		Votes:        post.Votes,
+		CommentCount: post.CommentCount,
+		MediaURLs:    make([]string, 0, len(post.Media)),
+		Tags:         make([]string, 0, len(post.Tags)),
+		CreatedAt:    post.CreatedAt,
+	}
+	for _, m := range post.Media {
+		item.MediaURLs = append(item.MediaURLs, m.URL)
+	}
+	for _, t := range post.Tags {
+		item.Tags = append(item.Tags, t.Name)
+	}
+	return item
+}
+
+func commentToExport(comment *models.Comment) exportComment {
+	return exportComment{
+		ID:        comment.ID,
+		PostID:    comment.PostID,
+		ParentID:  comment.ParentID,
+		Content:   comment.Content,
+		Votes:     comment.Votes,
+		CreatedAt: comment.CreatedAt,
+	}
+}
+
+func voteToExport(vote *models.Vote) exportVote {
+	return exportVote{
+		TargetType: vote.TargetType,
+		TargetID:   vote.TargetID,
+		VoteType:   vote.VoteType,
+		CreatedAt:  vote.CreatedAt,
+	}
+}
+
+func messageToExport(message *models.Message, userID uuid.UUID) exportMessage {
+	direction := "received"
+	if message.SenderID == userID {
+		direction = "sent"
+	}
+	item := exportMessage{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		Direction:      direction,
+		Type:           string(message.Type),
+		Content:        message.Content,
+		CreatedAt:      message.CreatedAt,
+	}
+	if len(message.Media) > 0 {
+		item.Media = json.RawMessage(message.Media)
+	}
+	return item
+}
+
+func mediaToExport(media *models.Media) exportMedia {
+	return exportMedia{
+		ID:        media.ID,
+		Type:      media.Type,
+		FileName:  media.FileName,
+		MimeType:  media.MimeType,
+		Size:      media.Size,
+		URL:       media.URL,
+		Thumbnail: media.Thumbnail,
+		CreatedAt: media.CreatedAt,
+	}
+}
+
+func notificationToExport(notification *models.Notification) exportNotification {
+	return exportNotification{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Message:   notification.Message,
+		IsRead:    notification.IsRead,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+func writeExportJSON(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeExportSection streams a JSON array into the archive one page at a time
+func writeExportSection[T any, R any](zw *zip.Writer, name string, fetch func(offset int) ([]T, error), convert func(T) R) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte("[\n")); err != nil {
+		return err
+	}
+
+	first := true
+	for offset := 0; ; offset += dataExportPageSize {
+		rows, err := fetch(offset)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		for _, row := range rows {
+			data, err := json.Marshal(convert(row))
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := w.Write([]byte(",\n")); err != nil {
+					return err
+				}
+			}
+			first = false
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < dataExportPageSize {
+			break
+		}
+	}
+
+	_, err = w.Write([]byte("\n]\n"))
+	return err
+}
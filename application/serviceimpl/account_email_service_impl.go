@@ -0,0 +1,231 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/mail"
+	"gofiber-template/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	verifyEmailTokenTTL   = 24 * time.Hour
+	resetPasswordTokenTTL = time.Hour
+
+	// Per account and purpose, further requests are dropped silently
+	accountEmailMaxPerHour  = 3
+	accountEmailSendTimeout = 30 * time.Second
+)
+
+type AccountEmailServiceImpl struct {
+	userRepo       repositories.UserRepository
+	tokenRepo      repositories.UserTokenRepository
+	sessionService services.SessionService
+	mailer         mail.Mailer
+	secret         string
+	frontendURL    string
+}
+
+func NewAccountEmailService(
+	userRepo repositories.UserRepository,
+	tokenRepo repositories.UserTokenRepository,
+	sessionService services.SessionService,
+	mailer mail.Mailer,
+	secret string,
+	frontendURL string,
+) services.AccountEmailService {
+	return &AccountEmailServiceImpl{
+		userRepo:       userRepo,
+		tokenRepo:      tokenRepo,
+		sessionService: sessionService,
+		mailer:         mailer,
+		secret:         secret,
+		frontendURL:    strings.TrimRight(frontendURL, "/"),
+	}
+}
+
+func (s *AccountEmailServiceImpl) SendVerificationEmail(ctx context.Context, user *models.User) error {
+	if user.EmailVerified {
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user, models.UserTokenVerifyEmail, verifyEmailTokenTTL)
+	if err != nil || token == "" {
+		return err
+	}
+
+	s.send(verificationEmail(user.Email, user.DisplayName, s.link("/auth/verify-email", token)))
+	return nil
+}
+
+func (s *AccountEmailServiceImpl) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil || !user.IsActive {
+		return nil // don't reveal whether the address is registered
+	}
+	return s.SendVerificationEmail(ctx, user)
+}
+
+func (s *AccountEmailServiceImpl) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	row, user, err := s.redeemToken(ctx, token, models.UserTokenVerifyEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.EmailVerified {
+		now := time.Now()
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+		user.UpdatedAt = now
+		if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
+			return nil, err
+		}
+	}
+
+	// Older verification links are pointless now
+	if err := s.tokenRepo.InvalidateByUser(ctx, row.UserID, models.UserTokenVerifyEmail); err != nil {
+		log.Printf("⚠️  Failed to invalidate verification tokens for user %s: %v", row.UserID, err)
+	}
+
+	return user, nil
+}
+
+func (s *AccountEmailServiceImpl) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil || !user.IsActive {
+		return nil // don't reveal whether the address is registered
+	}
+
+	token, err := s.issueToken(ctx, user, models.UserTokenResetPassword, resetPasswordTokenTTL)
+	if err != nil || token == "" {
+		return err
+	}
+
+	s.send(passwordResetEmail(user.Email, user.DisplayName, s.link("/auth/reset-password", token)))
+	return nil
+}
+
+func (s *AccountEmailServiceImpl) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	row, user, err := s.redeemToken(ctx, token, models.UserTokenResetPassword)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.Password = string(hashedPassword)
+	user.UpdatedAt = now
+	// The link proves control of the mailbox
+	if !user.EmailVerified {
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.InvalidateByUser(ctx, row.UserID, models.UserTokenResetPassword); err != nil {
+		log.Printf("⚠️  Failed to invalidate reset tokens for user %s: %v", row.UserID, err)
+	}
+
+	// Sign out every device, the old password may have been compromised
+	if _, err := s.sessionService.RevokeAllSessions(ctx, user.ID, nil, models.SessionRevokePasswordReset); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// issueToken stores a token row and returns the signed link token.
+// An empty token means the per-account throttle dropped the request.
+func (s *AccountEmailServiceImpl) issueToken(ctx context.Context, user *models.User, purpose string, ttl time.Duration) (string, error) {
+	sent, err := s.tokenRepo.CountCreatedSince(ctx, user.ID, purpose, time.Now().Add(-time.Hour))
+	if err != nil {
+		return "", err
+	}
+	if sent >= accountEmailMaxPerHour {
+		log.Printf("⚠️  %s email throttled for user %s", purpose, user.ID)
+		return "", nil
+	}
+
+	row := &models.UserToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Purpose:   purpose,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.tokenRepo.Create(ctx, row); err != nil {
+		return "", err
+	}
+
+	return utils.SignToken(utils.SignedToken{ID: row.ID, Purpose: purpose, ExpiresAt: row.ExpiresAt}, s.secret), nil
+}
+
+// redeemToken checks the signature and the stored row, then marks the row used (single use)
+func (s *AccountEmailServiceImpl) redeemToken(ctx context.Context, token string, purpose string) (*models.UserToken, *models.User, error) {
+	signed, err := utils.ParseSignedToken(token, purpose, s.secret)
+	if err != nil {
+		if errors.Is(err, utils.ErrExpiredSignedToken) {
+			return nil, nil, services.ErrEmailTokenExpired
+		}
+		return nil, nil, services.ErrInvalidEmailToken
+	}
+
+	row, err := s.tokenRepo.GetByID(ctx, signed.ID)
+	if err != nil || row.Purpose != purpose {
+		return nil, nil, services.ErrInvalidEmailToken
+	}
+	if !row.IsUsable() {
+		if row.UsedAt == nil {
+			return nil, nil, services.ErrEmailTokenExpired
+		}
+		return nil, nil, services.ErrInvalidEmailToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, row.UserID)
+	if err != nil || !user.IsActive || !strings.EqualFold(user.Email, row.Email) {
+		// Account gone or disabled, or the address changed since the link was sent
+		return nil, nil, services.ErrInvalidEmailToken
+	}
+
+	ok, err := s.tokenRepo.MarkUsed(ctx, row.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, services.ErrInvalidEmailToken
+	}
+
+	return row, user, nil
+}
+
+func (s *AccountEmailServiceImpl) link(path, token string) string {
+	return s.frontendURL + path + "?token=" + url.QueryEscape(token)
+}
+
+// send delivers in the background so response times don't reveal whether an address is registered
+func (s *AccountEmailServiceImpl) send(msg *mail.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), accountEmailSendTimeout)
+		defer cancel()
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			log.Printf("⚠️  Failed to send email to %s: %v", msg.To, err)
+		}
+	}()
+}
+
+var _ services.AccountEmailService = (*AccountEmailServiceImpl)(nil)
@@ -0,0 +1,895 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/redis"
+)
+
+const (
+	adReferenceType            = "ad"
+	adFirstTimeDiscountPercent = 10 // first paid campaign of an advertiser
+	adLongTermDiscountPercent  = 20 // campaigns of 30 days or more (discounts don't stack)
+	adLongTermDays             = 30
+	adFrequencyCap             = 3 // impressions per ad per user per day
+	adMaxServedPerRequest      = 5
+	adSchedulerBatchSize       = 100
+	adTrackingDedupWindow      = 30 * time.Minute // one impression and one click per ad per viewer
+	adTrackingRateLimit        = 60               // tracking events per viewer per window, across all ads
+	adTrackingRateWindow       = time.Minute
+	adEventImpression          = "impression"
+	adEventClick               = "click"
+)
+
+// adTimezone - Campaign dates are Thai calendar days
+var adTimezone = time.FixedZone("Asia/Bangkok", 7*60*60)
+
+type AdServiceImpl struct {
+	adRepo        repositories.AdRepository
+	mediaRepo     repositories.MediaRepository
+	walletService services.WalletService
+	notifService  services.NotificationService
+	redisService  *redis.RedisService
+}
+
+func NewAdService(
+	adRepo repositories.AdRepository,
+	mediaRepo repositories.MediaRepository,
+	walletService services.WalletService,
+	notifService services.NotificationService,
+	redisService *redis.RedisService,
+) services.AdService {
+	return &AdServiceImpl{
+		adRepo:        adRepo,
+		mediaRepo:     mediaRepo,
+		walletService: walletService,
+		notifService:  notifService,
+		redisService:  redisService,
+	}
+}
+
+// ==================== Placements ====================
+
+func (s *AdServiceImpl) ListPlacements(ctx context.Context) (*dto.AdPlacementListResponse, error) {
+	placements, err := s.adRepo.ListPlacements(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.AdPlacementListResponse{
+		Placements: make([]dto.AdPlacementResponse, len(placements)),
+	}
+	for i, placement := range placements {
+		resp.Placements[i] = *dto.AdPlacementToAdPlacementResponse(placement)
+	}
+	return resp, nil
+}
+
+func (s *AdServiceImpl) UpdatePlacement(ctx context.Context, code string, req *dto.UpdateAdPlacementRequest) (*dto.AdPlacementResponse, error) {
+	placement, err := s.adRepo.GetPlacementByCode(ctx, code)
+	if err != nil {
+		return nil, services.ErrAdPlacementNotFound
+	}
+
+	if req.Name != nil {
+		placement.Name = *req.Name
+	}
+	if req.Description != nil {
+		placement.Description = *req.Description
+	}
+	if req.PricePerDay != nil {
+		// Only new bookings use the new price
+		placement.PricePerDay = *req.PricePerDay
+	}
+	if req.MaxActiveAds != nil {
+		placement.MaxActiveAds = *req.MaxActiveAds
+	}
+	if req.FeedInterval != nil {
+		placement.FeedInterval = *req.FeedInterval
+	}
+	if req.IsActive != nil {
+		placement.IsActive = *req.IsActive
+	}
+
+	if err := s.adRepo.UpdatePlacement(ctx, placement); err != nil {
+		return nil, err
+	}
+
+	return dto.AdPlacementToAdPlacementResponse(placement), nil
+}
+
+// ==================== Advertiser Side ====================
+
+func (s *AdServiceImpl) CreateAd(ctx context.Context, userID uuid.UUID, req *dto.CreateAdRequest) (*dto.AdResponse, error) {
+	placement, err := s.adRepo.GetPlacementByCode(ctx, req.PlacementCode)
+	if err != nil || !placement.IsActive {
+		return nil, services.ErrAdPlacementNotFound
+	}
+
+	if err := s.validateImage(ctx, req.MediaID, userID); err != nil {
+		return nil, services.ErrAdInvalidCreative
+	}
+
+	startsAt, err := time.ParseInLocation("2006-01-02", req.StartDate, adTimezone)
+	if err != nil {
+		return nil, services.ErrAdInvalidStartDate
+	}
+	if startsAt.Before(startOfDay(time.Now())) {
+		return nil, services.ErrAdInvalidStartDate
+	}
+	endsAt := startsAt.AddDate(0, 0, req.Days)
+
+	booked, err := s.adRepo.CountBookedInPeriod(ctx, placement.ID, startsAt, endsAt, nil)
+	if err != nil {
+		return nil, err
+	}
+	if booked >= int64(placement.MaxActiveAds) {
+		return nil, services.ErrAdPlacementFull
+	}
+
+	// Pricing is locked in at booking time
+	subtotal := placement.PricePerDay * int64(req.Days)
+	discountPercent := int64(0)
+	if req.Days >= adLongTermDays {
+		discountPercent = adLongTermDiscountPercent
+	}
+	if discountPercent < adFirstTimeDiscountPercent {
+		paid, err := s.adRepo.CountPaidByAdvertiser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if paid == 0 {
+			discountPercent = adFirstTimeDiscountPercent
+		}
+	}
+	discount := subtotal * discountPercent / 100
+
+	ad := &models.Ad{
+		AdvertiserID:   userID,
+		PlacementID:    placement.ID,
+		CampaignName:   req.CampaignName,
+		Description:    req.Description,
+		DestinationURL: req.DestinationURL,
+		MediaID:        req.MediaID,
+		Status:         models.AdStatusPendingPayment,
+		Days:           req.Days,
+		PricePerDay:    placement.PricePerDay,
+		Discount:       discount,
+		TotalCost:      subtotal - discount,
+		StartsAt:       startsAt,
+		EndsAt:         endsAt,
+	}
+
+	if err := s.adRepo.Create(ctx, ad); err != nil {
+		return nil, err
+	}
+
+	return s.getAdResponse(ctx, ad.ID)
+}
+
+func (s *AdServiceImpl) UpdateAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID, req *dto.UpdateAdRequest) (*dto.AdResponse, error) {
+	ad, err := s.getOwnedAd(ctx, adID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ad.Status != models.AdStatusPendingPayment {
+		return nil, services.ErrAdInvalidStatus
+	}
+
+	if req.CampaignName != nil {
+		ad.CampaignName = *req.CampaignName
+	}
+	if req.Description != nil {
+		ad.Description = *req.Description
+	}
+	if req.DestinationURL != nil {
+		ad.DestinationURL = *req.DestinationURL
+	}
+	if req.MediaID != nil {
+		if err := s.validateImage(ctx, *req.MediaID, userID); err != nil {
+			return nil, services.ErrAdInvalidCreative
+		}
+		ad.MediaID = *req.MediaID
+	}
+
+	if err := s.adRepo.Update(ctx, ad); err != nil {
+		return nil, err
+	}
+
+	return s.getAdResponse(ctx, ad.ID)
+}
+
+func (s *AdServiceImpl) GetMyAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID) (*dto.AdResponse, error) {
+	ad, err := s.getOwnedAd(ctx, adID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.AdToAdResponse(ad)
+	if payment, err := s.adRepo.GetLatestPayment(ctx, ad.ID); err == nil {
+		resp.LatestPayment = dto.AdPaymentToAdPaymentResponse(payment)
+	}
+	return resp, nil
+}
+
+func (s *AdServiceImpl) ListMyAds(ctx context.Context, userID uuid.UUID, status string, offset, limit int) (*dto.AdListResponse, error) {
+	ads, err := s.adRepo.ListByAdvertiser(ctx, userID, status, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.adRepo.CountByAdvertiser(ctx, userID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.AdListResponse{
+		Ads: make([]dto.AdResponse, len(ads)),
+		Meta: dto.PaginationMeta{
+			Total:  &total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	for i, ad := range ads {
+		resp.Ads[i] = *dto.AdToAdResponse(ad)
+	}
+	return resp, nil
+}
+
+func (s *AdServiceImpl) GetAdStats(ctx context.Context, adID uuid.UUID, userID uuid.UUID) (*dto.AdStatsResponse, error) {
+	ad, err := s.getOwnedAd(ctx, adID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reach, err := s.adRepo.CountReach(ctx, ad.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyCounts, err := s.adRepo.GetDailyCounts(ctx, ad.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &dto.AdStatsResponse{
+		AdID:        ad.ID,
+		Impressions: ad.ImpressionCount,
+		Clicks:      ad.ClickCount,
+		Reach:       reach,
+		Daily:       make([]dto.AdDailyStats, len(dailyCounts)),
+	}
+	if ad.ImpressionCount > 0 {
+		stats.CTR = math.Round(float64(ad.ClickCount)/float64(ad.ImpressionCount)*10000) / 100
+	}
+	if ad.ClickCount > 0 {
+		stats.CPC = ad.TotalCost / ad.ClickCount
+	}
+	for i, count := range dailyCounts {
+		stats.Daily[i] = dto.AdDailyStats{
+			Date:        count.Date.Format("2006-01-02"),
+			Impressions: count.Impressions,
+			Clicks:      count.Clicks,
+		}
+	}
+
+	return stats, nil
+}
+
+func (s *AdServiceImpl) CancelAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID) error {
+	ad, err := s.getOwnedAd(ctx, adID, userID)
+	if err != nil {
+		return err
+	}
+
+	// Once a slip is submitted the money may already be transferred
+	if ad.Status != models.AdStatusPendingPayment {
+		return services.ErrAdInvalidStatus
+	}
+
+	ad.Status = models.AdStatusCancelled
+	return s.adRepo.Update(ctx, ad)
+}
+
+func (s *AdServiceImpl) PauseAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID) (*dto.AdResponse, error) {
+	ad, err := s.getOwnedAd(ctx, adID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ad.Status != models.AdStatusActive {
+		return nil, services.ErrAdInvalidStatus
+	}
+
+	ad.Status = models.AdStatusPaused
+	if err := s.adRepo.Update(ctx, ad); err != nil {
+		return nil, err
+	}
+	return dto.AdToAdResponse(ad), nil
+}
+
+func (s *AdServiceImpl) ResumeAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID) (*dto.AdResponse, error) {
+	ad, err := s.getOwnedAd(ctx, adID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Paused time is not added back, ended ads are completed by the scheduler
+	if ad.Status != models.AdStatusPaused || !time.Now().Before(ad.EndsAt) {
+		return nil, services.ErrAdInvalidStatus
+	}
+
+	ad.Status = models.AdStatusActive
+	if err := s.adRepo.Update(ctx, ad); err != nil {
+		return nil, err
+	}
+	return dto.AdToAdResponse(ad), nil
+}
+
+func (s *AdServiceImpl) SubmitPayment(ctx context.Context, adID uuid.UUID, userID uuid.UUID, req *dto.SubmitAdPaymentRequest) (*dto.AdPaymentResponse, error) {
+	ad, err := s.getOwnedAd(ctx, adID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ad.Status {
+	case models.AdStatusPendingPayment:
+	case models.AdStatusPaymentReview:
+		return nil, services.ErrAdPaymentUnderReview
+	default:
+		return nil, services.ErrAdInvalidStatus
+	}
+
+	if err := s.validateImage(ctx, req.SlipMediaID, userID); err != nil {
+		return nil, services.ErrAdInvalidSlip
+	}
+
+	payment := &models.AdPayment{
+		AdID:          ad.ID,
+		AdvertiserID:  userID,
+		Amount:        ad.TotalCost,
+		Method:        models.AdPaymentMethodBankTransfer,
+		SlipMediaID:   req.SlipMediaID,
+		BankReference: req.BankReference,
+		TransferredAt: req.TransferredAt,
+		Status:        models.AdPaymentPending,
+	}
+	if err := s.adRepo.CreatePayment(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	ad.Status = models.AdStatusPaymentReview
+	if err := s.adRepo.Update(ctx, ad); err != nil {
+		return nil, err
+	}
+
+	payment, err = s.adRepo.GetPaymentByID(ctx, payment.ID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.AdPaymentToAdPaymentResponse(payment), nil
+}
+
+// ==================== Admin Side ====================
+
+func (s *AdServiceImpl) ListPayments(ctx context.Context, status string, offset, limit int) (*dto.AdPaymentListResponse, error) {
+	if status == "" {
+		status = models.AdPaymentPending
+	}
+
+	payments, err := s.adRepo.ListPaymentsByStatus(ctx, status, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.adRepo.CountPaymentsByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.AdPaymentListResponse{
+		Payments: make([]dto.AdPaymentResponse, len(payments)),
+		Meta: dto.PaginationMeta{
+			Total:  &total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	for i, payment := range payments {
+		resp.Payments[i] = *dto.AdPaymentToAdPaymentResponse(payment)
+	}
+	return resp, nil
+}
+
+func (s *AdServiceImpl) VerifyPayment(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID) (*dto.AdPaymentResponse, error) {
+	payment, err := s.adRepo.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return nil, services.ErrAdNotFound
+	}
+	if payment.Status != models.AdPaymentPending {
+		return nil, services.ErrAdPaymentNotPending
+	}
+
+	ad, err := s.adRepo.GetByID(ctx, payment.AdID)
+	if err != nil {
+		return nil, services.ErrAdNotFound
+	}
+	if ad.Status != models.AdStatusPaymentReview {
+		return nil, services.ErrAdInvalidStatus
+	}
+
+	// Late verification never shortens the campaign - the paid days start now
+	now := time.Now()
+	if ad.StartsAt.Before(now) {
+		ad.StartsAt = now
+		ad.EndsAt = now.AddDate(0, 0, ad.Days)
+	}
+
+	booked, err := s.adRepo.CountBookedInPeriod(ctx, ad.PlacementID, ad.StartsAt, ad.EndsAt, &ad.ID)
+	if err != nil {
+		return nil, err
+	}
+	if ad.Placement != nil && booked >= int64(ad.Placement.MaxActiveAds) {
+		return nil, services.ErrAdPlacementFull
+	}
+
+	// Bank transfer received: external money becomes platform revenue
+	clearingWallet, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletTopUpClearing)
+	if err != nil {
+		return nil, err
+	}
+	feeWallet, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+	if err != nil {
+		return nil, err
+	}
+
+	referenceType := adReferenceType
+	tx, err := s.walletService.PostTransaction(ctx, &dto.LedgerTransactionRequest{
+		IdempotencyKey: "ad_payment:" + payment.ID.String(),
+		Type:           models.LedgerTxTypeTopUp,
+		Description:    "Ad payment (bank transfer)",
+		ReferenceType:  &referenceType,
+		ReferenceID:    &ad.ID,
+		Postings: []dto.LedgerPosting{
+			{WalletID: clearingWallet.ID, Amount: -payment.Amount},
+			{WalletID: feeWallet.ID, Amount: payment.Amount},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payment.Status = models.AdPaymentVerified
+	payment.ReviewedBy = &adminID
+	payment.ReviewedAt = &now
+	payment.LedgerTxID = &tx.ID
+	if err := s.adRepo.UpdatePayment(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	// Goes live by itself: now, or at the start date through the scheduler
+	ad.Status = models.AdStatusScheduled
+	if !ad.StartsAt.After(now) {
+		ad.Status = models.AdStatusActive
+		ad.ActivatedAt = &now
+	}
+	if err := s.adRepo.Update(ctx, ad); err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("ยืนยันการชำระเงินโฆษณา \"%s\" แล้ว โฆษณาจะเริ่มแสดง %s",
+		ad.CampaignName, ad.StartsAt.In(adTimezone).Format("02/01/2006 15:04"))
+	if ad.Status == models.AdStatusActive {
+		message = fmt.Sprintf("ยืนยันการชำระเงินโฆษณา \"%s\" แล้ว โฆษณาของคุณเริ่มแสดงแล้ว", ad.CampaignName)
+	}
+	_ = s.notifService.CreateNotification(ctx, ad.AdvertiserID, adminID, "ad", message, nil, nil)
+
+	payment.Ad = ad
+	return dto.AdPaymentToAdPaymentResponse(payment), nil
+}
+
+func (s *AdServiceImpl) RejectPayment(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID, reason string) (*dto.AdPaymentResponse, error) {
+	payment, err := s.adRepo.GetPaymentByID(ctx, paymentID)
+	if err != nil {
+		return nil, services.ErrAdNotFound
+	}
+	if payment.Status != models.AdPaymentPending {
+		return nil, services.ErrAdPaymentNotPending
+	}
+
+	now := time.Now()
+	payment.Status = models.AdPaymentRejected
+	payment.ReviewedBy = &adminID
+	payment.ReviewedAt = &now
+	payment.RejectionReason = &reason
+	if err := s.adRepo.UpdatePayment(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	// The advertiser may submit a new slip
+	ad, err := s.adRepo.GetByID(ctx, payment.AdID)
+	if err != nil {
+		return nil, services.ErrAdNotFound
+	}
+	if ad.Status == models.AdStatusPaymentReview {
+		ad.Status = models.AdStatusPendingPayment
+		if err := s.adRepo.Update(ctx, ad); err != nil {
+			return nil, err
+		}
+	}
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		ad.AdvertiserID,
+		adminID,
+		"ad",
+		fmt.Sprintf("สลิปการชำระเงินโฆษณา \"%s\" ไม่ผ่านการตรวจสอบ: %s", ad.CampaignName, reason),
+		nil,
+		nil,
+	)
+
+	payment.Ad = ad
+	return dto.AdPaymentToAdPaymentResponse(payment), nil
+}
+
+func (s *AdServiceImpl) RemoveAd(ctx context.Context, adID uuid.UUID, adminID uuid.UUID, reason string) error {
+	ad, err := s.adRepo.GetByID(ctx, adID)
+	if err != nil {
+		return services.ErrAdNotFound
+	}
+
+	switch ad.Status {
+	case models.AdStatusCompleted, models.AdStatusCancelled, models.AdStatusRemoved:
+		return services.ErrAdInvalidStatus
+	}
+
+	// Refunds of bank transfers are handled manually by the finance team
+	ad.Status = models.AdStatusRemoved
+	ad.RemovalReason = &reason
+	if err := s.adRepo.Update(ctx, ad); err != nil {
+		return err
+	}
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		ad.AdvertiserID,
+		adminID,
+		"ad",
+		fmt.Sprintf("โฆษณา \"%s\" ถูกนำออกโดยผู้ดูแลระบบ: %s", ad.CampaignName, reason),
+		nil,
+		nil,
+	)
+	return nil
+}
+
+// ==================== Serving ====================
+
+func (s *AdServiceImpl) ServeAds(ctx context.Context, placement string, userID *uuid.UUID, limit int) (*dto.ServedAdListResponse, error) {
+	if limit <= 0 || limit > adMaxServedPerRequest {
+		limit = 1
+	}
+
+	adPlacement, err := s.adRepo.GetPlacementByCode(ctx, placement)
+	if err != nil || !adPlacement.IsActive {
+		return nil, services.ErrAdPlacementNotFound
+	}
+
+	ads, err := s.pickAds(ctx, adPlacement, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ServedAdListResponse{
+		Ads: make([]dto.ServedAdResponse, len(ads)),
+	}
+	for i, ad := range ads {
+		resp.Ads[i] = *dto.AdToServedAdResponse(ad, placement)
+	}
+	return resp, nil
+}
+
+func (s *AdServiceImpl) InterleaveFeedAds(ctx context.Context, userID *uuid.UUID, postCount int) []dto.FeedAdSlot {
+	placement, err := s.adRepo.GetPlacementByCode(ctx, models.AdPlacementFeed)
+	if err != nil || !placement.IsActive || placement.FeedInterval <= 0 || postCount <= placement.FeedInterval {
+		return nil
+	}
+
+	slotCount := (postCount - 1) / placement.FeedInterval
+	ads, err := s.pickAds(ctx, placement, userID, slotCount)
+	if err != nil {
+		// Ads never break the feed
+		log.Printf("[AD] Failed to pick feed ads: %v", err)
+		return nil
+	}
+
+	slots := make([]dto.FeedAdSlot, len(ads))
+	for i, ad := range ads {
+		slots[i] = dto.FeedAdSlot{
+			Position: (i + 1) * placement.FeedInterval,
+			Ad:       *dto.AdToServedAdResponse(ad, models.AdPlacementFeed),
+		}
+	}
+	return slots
+}
+
+func (s *AdServiceImpl) RecordImpression(ctx context.Context, adID uuid.UUID, userID *uuid.UUID, req *dto.RecordAdImpressionRequest, ipAddress, userAgent string) (*dto.AdImpressionResponse, error) {
+	ad, err := s.adRepo.GetByID(ctx, adID)
+	if err != nil || !ad.IsServable(time.Now()) {
+		return nil, services.ErrAdNotFound
+	}
+
+	viewerKey := adViewerKey(userID, ipAddress)
+	if !s.allowTracking(ctx, viewerKey) {
+		return nil, services.ErrAdTrackingLimited
+	}
+
+	// Repeated views within the window count once and reuse the first impression
+	impressionID := uuid.New()
+	first, existing := s.claimTrackingEvent(ctx, adEventImpression, ad.ID, viewerKey, impressionID.String())
+	if !first {
+		if existingID, err := uuid.Parse(existing); err == nil {
+			return &dto.AdImpressionResponse{ImpressionID: existingID}, nil
+		}
+	}
+
+	if len(userAgent) > 500 {
+		userAgent = userAgent[:500]
+	}
+
+	impression := &models.AdImpression{
+		ID:             impressionID,
+		AdID:           ad.ID,
+		UserID:         userID,
+		Placement:      req.Placement,
+		ViewDurationMs: req.ViewDurationMs,
+		DeviceType:     req.DeviceType,
+		IPAddress:      ipAddress,
+		UserAgent:      userAgent,
+		ViewedAt:       time.Now(),
+	}
+	if err := s.adRepo.CreateImpression(ctx, impression); err != nil {
+		s.releaseTrackingEvent(ctx, adEventImpression, ad.ID, viewerKey)
+		return nil, err
+	}
+
+	return &dto.AdImpressionResponse{ImpressionID: impression.ID}, nil
+}
+
+func (s *AdServiceImpl) RecordClick(ctx context.Context, adID uuid.UUID, userID *uuid.UUID, req *dto.RecordAdClickRequest, ipAddress string) (*dto.AdClickResponse, error) {
+	ad, err := s.adRepo.GetByID(ctx, adID)
+	if err != nil || ad.ActivatedAt == nil {
+		return nil, services.ErrAdNotFound
+	}
+
+	// A click can only point at an impression of the same ad
+	if req.ImpressionID != nil {
+		impression, err := s.adRepo.GetImpressionByID(ctx, *req.ImpressionID)
+		if err != nil || impression.AdID != ad.ID {
+			return nil, services.ErrAdImpressionMismatch
+		}
+	}
+
+	// Late, repeated or throttled clicks still redirect but are not billed as clicks
+	viewerKey := adViewerKey(userID, ipAddress)
+	if ad.IsServable(time.Now()) && s.allowTracking(ctx, viewerKey) {
+		if first, _ := s.claimTrackingEvent(ctx, adEventClick, ad.ID, viewerKey, "1"); !first {
+			return &dto.AdClickResponse{DestinationURL: ad.DestinationURL}, nil
+		}
+
+		click := &models.AdClick{
+			AdID:         ad.ID,
+			UserID:       userID,
+			ImpressionID: req.ImpressionID,
+			Placement:    req.Placement,
+			ReferrerURL:  req.ReferrerURL,
+			DeviceType:   req.DeviceType,
+			IPAddress:    ipAddress,
+			ClickedAt:    time.Now(),
+		}
+		if err := s.adRepo.CreateClick(ctx, click); err != nil {
+			s.releaseTrackingEvent(ctx, adEventClick, ad.ID, viewerKey)
+			return nil, err
+		}
+	}
+
+	return &dto.AdClickResponse{DestinationURL: ad.DestinationURL}, nil
+}
+
+// adViewerKey identifies a viewer for dedup and rate limiting (user when logged in, IP for guests)
+func adViewerKey(userID *uuid.UUID, ipAddress string) string {
+	if userID != nil {
+		return "user:" + userID.String()
+	}
+	return "ip:" + ipAddress
+}
+
+// allowTracking applies the per-viewer tracking rate limit (fails open when Redis is unavailable)
+func (s *AdServiceImpl) allowTracking(ctx context.Context, viewerKey string) bool {
+	if s.redisService == nil {
+		return true
+	}
+	count, err := s.redisService.IncrementAdEvents(ctx, viewerKey, adTrackingRateWindow)
+	if err != nil {
+		log.Printf("[AD] Tracking rate limit check failed: %v", err)
+		return true
+	}
+	return count <= adTrackingRateLimit
+}
+
+// claimTrackingEvent reports whether this is the viewer's first event of the kind within the dedup window,
+// otherwise it returns the value stored by the first event (fails open when Redis is unavailable)
+func (s *AdServiceImpl) claimTrackingEvent(ctx context.Context, kind string, adID uuid.UUID, viewerKey, value string) (bool, string) {
+	if s.redisService == nil {
+		return true, value
+	}
+	first, existing, err := s.redisService.ClaimAdEvent(ctx, kind, adID, viewerKey, value, adTrackingDedupWindow)
+	if err != nil {
+		log.Printf("[AD] Tracking dedup check failed: %v", err)
+		return true, value
+	}
+	return first, existing
+}
+
+// releaseTrackingEvent frees a claim whose event could not be stored, so a retry is counted
+func (s *AdServiceImpl) releaseTrackingEvent(ctx context.Context, kind string, adID uuid.UUID, viewerKey string) {
+	if s.redisService == nil {
+		return
+	}
+	if err := s.redisService.ReleaseAdEvent(ctx, kind, adID, viewerKey); err != nil {
+		log.Printf("[AD] Failed to release tracking claim: %v", err)
+	}
+}
+
+// ==================== Scheduler ====================
+
+func (s *AdServiceImpl) ProcessSchedule(ctx context.Context) (int, int, error) {
+	now := time.Now()
+
+	toActivate, err := s.adRepo.ListDueForActivation(ctx, now, adSchedulerBatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	activated := 0
+	for _, ad := range toActivate {
+		ad.Status = models.AdStatusActive
+		if ad.ActivatedAt == nil {
+			ad.ActivatedAt = &now
+		}
+		if err := s.adRepo.Update(ctx, ad); err != nil {
+			log.Printf("[AD] Failed to activate ad %s: %v", ad.ID, err)
+			continue
+		}
+		activated++
+
+		_ = s.notifService.CreateNotification(
+			ctx,
+			ad.AdvertiserID,
+			ad.AdvertiserID,
+			"ad",
+			fmt.Sprintf("โฆษณา \"%s\" เริ่มแสดงแล้ว", ad.CampaignName),
+			nil,
+			nil,
+		)
+	}
+
+	toComplete, err := s.adRepo.ListDueForCompletion(ctx, now, adSchedulerBatchSize)
+	if err != nil {
+		return activated, 0, err
+	}
+
+	completed := 0
+	for _, ad := range toComplete {
+		ad.Status = models.AdStatusCompleted
+		if err := s.adRepo.Update(ctx, ad); err != nil {
+			log.Printf("[AD] Failed to complete ad %s: %v", ad.ID, err)
+			continue
+		}
+		completed++
+
+		_ = s.notifService.CreateNotification(
+			ctx,
+			ad.AdvertiserID,
+			ad.AdvertiserID,
+			"ad",
+			fmt.Sprintf("โฆษณา \"%s\" สิ้นสุดแล้ว: แสดง %d ครั้ง คลิก %d ครั้ง", ad.CampaignName, ad.ImpressionCount, ad.ClickCount),
+			nil,
+			nil,
+		)
+	}
+
+	return activated, completed, nil
+}
+
+// ==================== Helpers ====================
+
+// pickAds returns up to limit servable ads of a placement in random order (round-robin over requests),
+// skipping ads the user has already seen adFrequencyCap times today
+func (s *AdServiceImpl) pickAds(ctx context.Context, placement *models.AdPlacement, userID *uuid.UUID, limit int) ([]*models.Ad, error) {
+	now := time.Now()
+	ads, err := s.adRepo.ListServable(ctx, placement.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(ads) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	if userID != nil {
+		capped, err := s.adRepo.GetFrequencyCappedAdIDs(ctx, *userID, startOfDay(now), adFrequencyCap)
+		if err != nil {
+			return nil, err
+		}
+		if len(capped) > 0 {
+			cappedSet := make(map[uuid.UUID]bool, len(capped))
+			for _, id := range capped {
+				cappedSet[id] = true
+			}
+			filtered := ads[:0]
+			for _, ad := range ads {
+				if !cappedSet[ad.ID] {
+					filtered = append(filtered, ad)
+				}
+			}
+			ads = filtered
+		}
+	}
+
+	rand.Shuffle(len(ads), func(i, j int) {
+		ads[i], ads[j] = ads[j], ads[i]
+	})
+	if len(ads) > limit {
+		ads = ads[:limit]
+	}
+	return ads, nil
+}
+
+func (s *AdServiceImpl) getOwnedAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID) (*models.Ad, error) {
+	ad, err := s.adRepo.GetByID(ctx, adID)
+	if err != nil || ad.AdvertiserID != userID {
+		return nil, services.ErrAdNotFound
+	}
+	return ad, nil
+}
+
+// validateImage checks that the media is an image uploaded by the user
+func (s *AdServiceImpl) validateImage(ctx context.Context, mediaID uuid.UUID, userID uuid.UUID) error {
+	media, err := s.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if media.UserID != userID || media.Type != "image" {
+		return errors.New("invalid media")
+	}
+	return nil
+}
+
+func (s *AdServiceImpl) getAdResponse(ctx context.Context, id uuid.UUID) (*dto.AdResponse, error) {
+	ad, err := s.adRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.AdToAdResponse(ad), nil
+}
+
+// startOfDay returns 00:00 Thai time of the given day
+func startOfDay(t time.Time) time.Time {
+	local := t.In(adTimezone)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, adTimezone)
+}
+
+// Ensure interface compliance
+var _ services.AdService = (*AdServiceImpl)(nil)
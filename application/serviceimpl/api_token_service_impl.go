@@ -0,0 +1,292 @@
+package serviceimpl
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/redis"
+	"gofiber-template/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	apiTokenSecretLen     = 40 // base64url, 240 bits
+	apiTokenPrefixLen     = 12 // "pat_" + 8 characters of the secret, shown in token lists
+	apiTokenMaxLen        = 64
+	apiTokenDefaultTTL    = 90 * 24 * time.Hour
+	apiTokenDefaultRate   = 60 // requests per minute
+	apiTokenRateWindow    = time.Minute
+	apiTokenMaxPerUser    = 20
+	apiTokenMaxBotPerUser = 5
+	apiTokenLastUsedEvery = time.Minute // last-used tracking granularity
+	apiTokenTouchTimeout  = 5 * time.Second
+)
+
+type APITokenServiceImpl struct {
+	tokenRepo    repositories.APITokenRepository
+	userRepo     repositories.UserRepository
+	roleRepo     repositories.RoleRepository
+	redisService *redis.RedisService
+}
+
+func NewAPITokenService(
+	tokenRepo repositories.APITokenRepository,
+	userRepo repositories.UserRepository,
+	roleRepo repositories.RoleRepository,
+	redisService *redis.RedisService,
+) services.APITokenService {
+	return &APITokenServiceImpl{
+		tokenRepo:    tokenRepo,
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		redisService: redisService,
+	}
+}
+
+// ==================== Personal Access Tokens ====================
+
+func (s *APITokenServiceImpl) CreateToken(ctx context.Context, userID uuid.UUID, req *dto.CreateAPITokenRequest) (*dto.CreatedAPITokenResponse, error) {
+	count, err := s.tokenRepo.CountActiveByUser(ctx, userID, models.APITokenKindPersonal)
+	if err != nil {
+		return nil, err
+	}
+	if count >= apiTokenMaxPerUser {
+		return nil, services.ErrAPITokenLimit
+	}
+
+	// Personal tokens always expire
+	ttl := apiTokenDefaultTTL
+	if req.ExpiresInDays > 0 {
+		ttl = time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	return s.issueToken(ctx, userID, models.APITokenKindPersonal, nil, req, &expiresAt)
+}
+
+func (s *APITokenServiceImpl) ListTokens(ctx context.Context, userID uuid.UUID) (*dto.APITokenListResponse, error) {
+	tokens, err := s.tokenRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.APITokenListResponse{Tokens: make([]dto.APITokenResponse, 0, len(tokens))}
+	for _, token := range tokens {
+		resp.Tokens = append(resp.Tokens, *dto.APITokenToResponse(token))
+	}
+	return resp, nil
+}
+
+func (s *APITokenServiceImpl) RevokeToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	token, err := s.tokenRepo.GetByID(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return services.ErrAPITokenNotFound
+		}
+		return err
+	}
+	if token.UserID != userID {
+		return services.ErrAPITokenNotFound
+	}
+
+	return s.revoke(ctx, token.ID)
+}
+
+// ==================== Bot Keys ====================
+
+func (s *APITokenServiceImpl) SetBotAccount(ctx context.Context, userID uuid.UUID, isBot bool) error {
+	if _, err := s.getBotCandidate(ctx, userID); err != nil {
+		return err
+	}
+	// Existing bot keys stop working once the flag is cleared (checked on every request)
+	return s.userRepo.SetBot(ctx, userID, isBot)
+}
+
+func (s *APITokenServiceImpl) CreateBotToken(ctx context.Context, staffID, botUserID uuid.UUID, req *dto.CreateAPITokenRequest) (*dto.CreatedAPITokenResponse, error) {
+	user, err := s.getBotCandidate(ctx, botUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsBot {
+		return nil, services.ErrAPITokenNotBot
+	}
+
+	count, err := s.tokenRepo.CountActiveByUser(ctx, botUserID, models.APITokenKindBot)
+	if err != nil {
+		return nil, err
+	}
+	if count >= apiTokenMaxBotPerUser {
+		return nil, services.ErrAPITokenLimit
+	}
+
+	// Bot keys run unattended, they only expire when asked to
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		at := time.Now().Add(time.Duration(req.ExpiresInDays) * 24 * time.Hour)
+		expiresAt = &at
+	}
+
+	return s.issueToken(ctx, botUserID, models.APITokenKindBot, &staffID, req, expiresAt)
+}
+
+func (s *APITokenServiceImpl) RevokeBotToken(ctx context.Context, tokenID uuid.UUID) error {
+	return s.revoke(ctx, tokenID)
+}
+
+// getBotCandidate loads an account that may be a bot: a bot key must never carry staff permissions
+func (s *APITokenServiceImpl) getBotCandidate(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, services.ErrAPITokenUserNotFound
+		}
+		return nil, err
+	}
+	if user.DeletedAt != nil {
+		return nil, services.ErrAPITokenUserNotFound
+	}
+
+	grants, err := s.roleRepo.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range grants {
+		if grant.CommunityID == nil {
+			return nil, services.ErrAPITokenStaffAccount
+		}
+	}
+	return user, nil
+}
+
+func (s *APITokenServiceImpl) issueToken(ctx context.Context, userID uuid.UUID, kind string, createdByID *uuid.UUID, req *dto.CreateAPITokenRequest, expiresAt *time.Time) (*dto.CreatedAPITokenResponse, error) {
+	raw := kind + "_" + utils.GenerateRandomString(apiTokenSecretLen)
+
+	scopes, err := json.Marshal(uniqueScopes(req.Scopes))
+	if err != nil {
+		return nil, err
+	}
+
+	rate := req.RateLimitPerMinute
+	if rate <= 0 {
+		rate = apiTokenDefaultRate
+	}
+
+	token := &models.APIToken{
+		UserID:             userID,
+		Kind:               kind,
+		Name:               strings.TrimSpace(req.Name),
+		CreatedByID:        createdByID,
+		Prefix:             raw[:apiTokenPrefixLen],
+		TokenHash:          hashAPIToken(raw),
+		Scopes:             scopes,
+		RateLimitPerMinute: rate,
+		ExpiresAt:          expiresAt,
+	}
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreatedAPITokenResponse{
+		APITokenResponse: *dto.APITokenToResponse(token),
+		Token:            raw,
+	}, nil
+}
+
+func (s *APITokenServiceImpl) revoke(ctx context.Context, tokenID uuid.UUID) error {
+	revoked, err := s.tokenRepo.Revoke(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return services.ErrAPITokenNotFound
+	}
+	return nil
+}
+
+// ==================== Authentication ====================
+
+func (s *APITokenServiceImpl) AuthenticateAPIToken(ctx context.Context, raw, ip string) (*utils.UserContext, error) {
+	if len(raw) > apiTokenMaxLen {
+		return nil, services.ErrAPITokenInvalid
+	}
+
+	token, err := s.tokenRepo.GetByHash(ctx, hashAPIToken(raw))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, services.ErrAPITokenInvalid
+		}
+		return nil, err
+	}
+	if token.RevokedAt != nil || !token.User.IsActive {
+		return nil, services.ErrAPITokenInvalid
+	}
+	// Bot keys die with the account's bot flag
+	if token.Kind == models.APITokenKindBot && !token.User.IsBot {
+		return nil, services.ErrAPITokenInvalid
+	}
+	if !token.IsActive() {
+		return nil, services.ErrAPITokenExpired
+	}
+
+	if !s.allowRequest(ctx, token) {
+		return nil, services.ErrAPITokenRateLimited
+	}
+
+	// Last-used tracking must not slow down or fail the request
+	go func(id uuid.UUID) {
+		touchCtx, cancel := context.WithTimeout(context.Background(), apiTokenTouchTimeout)
+		defer cancel()
+		if err := s.tokenRepo.TouchLastUsed(touchCtx, id, time.Now(), ip, apiTokenLastUsedEvery); err != nil {
+			log.Printf("⚠️  Failed to record use of API token %s: %v", id, err)
+		}
+	}(token.ID)
+
+	return &utils.UserContext{
+		ID:       token.UserID,
+		Username: token.User.Username,
+		Email:    token.User.Email,
+		TokenID:  token.ID,
+		Scopes:   token.ScopeList(),
+	}, nil
+}
+
+// allowRequest applies the per-token rate limit (fails open when Redis is unavailable, like the session denylist)
+func (s *APITokenServiceImpl) allowRequest(ctx context.Context, token *models.APIToken) bool {
+	if s.redisService == nil {
+		return true
+	}
+	count, err := s.redisService.IncrementAPITokenRequests(ctx, token.ID, apiTokenRateWindow)
+	if err != nil {
+		log.Printf("⚠️  API token rate limit check failed: %v", err)
+		return true
+	}
+	return count <= int64(token.RateLimitPerMinute)
+}
+
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result
+}
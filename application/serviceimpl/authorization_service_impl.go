@@ -0,0 +1,293 @@
+package serviceimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/redis"
+	"gorm.io/gorm"
+)
+
+// permissionCacheTTL bounds staleness if an invalidation is lost (grants drop the cache right away)
+const permissionCacheTTL = 10 * time.Minute
+
+// permissionSet - Effective permissions of a user, as cached in Redis
+type permissionSet struct {
+	Global      []string            `json:"global"`
+	Communities map[string][]string `json:"communities,omitempty"` // community ID -> permissions
+}
+
+func (p *permissionSet) has(permission string, communityID *uuid.UUID) bool {
+	if containsString(p.Global, permission) {
+		return true
+	}
+	if communityID == nil {
+		return false
+	}
+	return containsString(p.Communities[communityID.String()], permission)
+}
+
+type AuthorizationServiceImpl struct {
+	roleRepo      repositories.RoleRepository
+	userRepo      repositories.UserRepository
+	communityRepo repositories.CommunityRepository
+	redisService  *redis.RedisService
+}
+
+func NewAuthorizationService(
+	roleRepo repositories.RoleRepository,
+	userRepo repositories.UserRepository,
+	communityRepo repositories.CommunityRepository,
+	redisService *redis.RedisService,
+) services.AuthorizationService {
+	return &AuthorizationServiceImpl{
+		roleRepo:      roleRepo,
+		userRepo:      userRepo,
+		communityRepo: communityRepo,
+		redisService:  redisService,
+	}
+}
+
+func (s *AuthorizationServiceImpl) HasPermission(ctx context.Context, userID uuid.UUID, permission string, communityID *uuid.UUID) (bool, error) {
+	set, err := s.getPermissionSet(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return set.has(permission, communityID), nil
+}
+
+func (s *AuthorizationServiceImpl) GetMyPermissions(ctx context.Context, userID uuid.UUID) (*dto.UserPermissionsResponse, error) {
+	userRoles, err := s.roleRepo.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	set := buildPermissionSet(userRoles)
+
+	roles := make([]dto.UserRoleResponse, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		roles = append(roles, *dto.UserRoleToResponse(userRole))
+	}
+
+	return &dto.UserPermissionsResponse{
+		Roles:                roles,
+		Permissions:          set.Global,
+		CommunityPermissions: set.Communities,
+	}, nil
+}
+
+// ==================== Catalog ====================
+
+func (s *AuthorizationServiceImpl) ListRoles(ctx context.Context) ([]dto.RoleResponse, error) {
+	roles, err := s.roleRepo.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		responses = append(responses, *dto.RoleToResponse(role))
+	}
+	return responses, nil
+}
+
+func (s *AuthorizationServiceImpl) ListPermissions(ctx context.Context) ([]dto.PermissionResponse, error) {
+	permissions, err := s.roleRepo.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.PermissionResponse, 0, len(permissions))
+	for _, p := range permissions {
+		responses = append(responses, dto.PermissionResponse{Name: p.Name, Description: p.Description})
+	}
+	return responses, nil
+}
+
+// ==================== Grants ====================
+
+func (s *AuthorizationServiceImpl) ListUserRoles(ctx context.Context, userID uuid.UUID) ([]dto.UserRoleResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, services.ErrRoleUserNotFound
+	}
+
+	userRoles, err := s.roleRepo.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.UserRoleResponse, 0, len(userRoles))
+	for _, userRole := range userRoles {
+		responses = append(responses, *dto.UserRoleToResponse(userRole))
+	}
+	return responses, nil
+}
+
+func (s *AuthorizationServiceImpl) AssignRole(ctx context.Context, actorID, userID uuid.UUID, req *dto.AssignRoleRequest) (*dto.UserRoleResponse, error) {
+	if actorID == userID {
+		return nil, services.ErrRoleSelfChange
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, services.ErrRoleUserNotFound
+	}
+
+	role, err := s.getRole(ctx, req.Role, req.CommunityID)
+	if err != nil {
+		return nil, err
+	}
+	if req.CommunityID != nil {
+		if _, err := s.communityRepo.GetByID(ctx, *req.CommunityID); err != nil {
+			return nil, services.ErrCommunityNotFound
+		}
+	}
+
+	userRole := &models.UserRole{
+		UserID:      userID,
+		RoleID:      role.ID,
+		Role:        *role,
+		CommunityID: req.CommunityID,
+		GrantedBy:   &actorID,
+		CreatedAt:   time.Now(),
+	}
+	created, err := s.roleRepo.AssignRole(ctx, userRole)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, services.ErrRoleAlreadyGranted
+	}
+
+	s.invalidate(ctx, userID)
+	return dto.UserRoleToResponse(userRole), nil
+}
+
+func (s *AuthorizationServiceImpl) RevokeRole(ctx context.Context, actorID, userID uuid.UUID, roleName string, communityID *uuid.UUID) error {
+	if actorID == userID {
+		return services.ErrRoleSelfChange
+	}
+
+	role, err := s.getRole(ctx, roleName, communityID)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := s.roleRepo.RevokeRole(ctx, userID, role.ID, communityID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return services.ErrRoleNotGranted
+	}
+
+	s.invalidate(ctx, userID)
+	return nil
+}
+
+// getRole loads a role and checks that the community matches its scope
+func (s *AuthorizationServiceImpl) getRole(ctx context.Context, name string, communityID *uuid.UUID) (*models.Role, error) {
+	role, err := s.roleRepo.GetRoleByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, services.ErrRoleNotFound
+		}
+		return nil, err
+	}
+	if (role.Scope == models.RoleScopeCommunity) != (communityID != nil) {
+		return nil, services.ErrRoleScopeMismatch
+	}
+	return role, nil
+}
+
+// ==================== Cache ====================
+
+// getPermissionSet reads the cached permissions, loading them from the database on a miss (or a Redis outage)
+func (s *AuthorizationServiceImpl) getPermissionSet(ctx context.Context, userID uuid.UUID) (*permissionSet, error) {
+	if s.redisService != nil {
+		if data, err := s.redisService.GetUserPermissions(ctx, userID); err == nil {
+			var set permissionSet
+			if err := json.Unmarshal(data, &set); err == nil {
+				return &set, nil
+			}
+		}
+	}
+
+	userRoles, err := s.roleRepo.ListUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	set := buildPermissionSet(userRoles)
+
+	if s.redisService != nil {
+		if err := s.redisService.SetUserPermissions(ctx, userID, set, permissionCacheTTL); err != nil {
+			log.Printf("⚠️  Failed to cache permissions of user %s: %v", userID, err)
+		}
+	}
+	return set, nil
+}
+
+func (s *AuthorizationServiceImpl) invalidate(ctx context.Context, userID uuid.UUID) {
+	if s.redisService == nil {
+		return
+	}
+	if err := s.redisService.InvalidateUserPermissions(ctx, userID); err != nil {
+		log.Printf("⚠️  Failed to drop cached permissions of user %s: %v", userID, err)
+	}
+}
+
+// buildPermissionSet merges the permissions of every grant, sorted and without duplicates
+func buildPermissionSet(userRoles []*models.UserRole) *permissionSet {
+	global := map[string]bool{}
+	communities := map[string]map[string]bool{}
+
+	for _, userRole := range userRoles {
+		target := global
+		if userRole.CommunityID != nil {
+			key := userRole.CommunityID.String()
+			if communities[key] == nil {
+				communities[key] = map[string]bool{}
+			}
+			target = communities[key]
+		}
+		for _, p := range userRole.Role.Permissions {
+			target[p.Name] = true
+		}
+	}
+
+	set := &permissionSet{Global: sortedKeys(global)}
+	if len(communities) > 0 {
+		set.Communities = make(map[string][]string, len(communities))
+		for id, perms := range communities {
+			set.Communities[id] = sortedKeys(perms)
+		}
+	}
+	return set
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure interface compliance
+var _ services.AuthorizationService = (*AuthorizationServiceImpl)(nil)
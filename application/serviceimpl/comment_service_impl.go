@@ -11,13 +11,16 @@ import (
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/utils"
+	"gorm.io/gorm"
 )
 
 type CommentServiceImpl struct {
-	commentRepo  repositories.CommentRepository
-	postRepo     repositories.PostRepository
-	voteRepo     repositories.VoteRepository
-	notifService services.NotificationService
+	commentRepo     repositories.CommentRepository
+	postRepo        repositories.PostRepository
+	voteRepo        repositories.VoteRepository
+	notifService    services.NotificationService
+	authzService    services.AuthorizationService
+	sanctionService services.SanctionService
 }
 
 func NewCommentService(
@@ -25,16 +28,25 @@ func NewCommentService(
 	postRepo repositories.PostRepository,
 	voteRepo repositories.VoteRepository,
 	notifService services.NotificationService,
+	authzService services.AuthorizationService,
+	sanctionService services.SanctionService,
 ) services.CommentService {
 	return &CommentServiceImpl{
-		commentRepo:  commentRepo,
-		postRepo:     postRepo,
-		voteRepo:     voteRepo,
-		notifService: notifService,
+		commentRepo:     commentRepo,
+		postRepo:        postRepo,
+		voteRepo:        voteRepo,
+		notifService:    notifService,
+		authzService:    authzService,
+		sanctionService: sanctionService,
 	}
 }
 
 func (s *CommentServiceImpl) CreateComment(ctx context.Context, userID uuid.UUID, req *dto.CreateCommentRequest) (*dto.CommentResponse, error) {
+	shadowed, err := s.sanctionService.CheckWriteAccess(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Verify post exists
 	post, err := s.postRepo.GetByID(ctx, req.PostID)
 	if err != nil {
@@ -61,16 +73,17 @@ func (s *CommentServiceImpl) CreateComment(ctx context.Context, userID uuid.UUID
 
 	// Create comment
 	comment := &models.Comment{
-		ID:        uuid.New(),
-		PostID:    req.PostID,
-		AuthorID:  userID,
-		ParentID:  req.ParentID,
-		Content:   req.Content,
-		Votes:     0,
-		Depth:     depth,
-		IsDeleted: false,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:         uuid.New(),
+		PostID:     req.PostID,
+		AuthorID:   userID,
+		ParentID:   req.ParentID,
+		Content:    req.Content,
+		Votes:      0,
+		Depth:      depth,
+		IsDeleted:  false,
+		IsShadowed: shadowed,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 
 	err = s.commentRepo.Create(ctx, comment)
@@ -81,7 +94,10 @@ func (s *CommentServiceImpl) CreateComment(ctx context.Context, userID uuid.UUID
 	// Increment post comment count
 	_ = s.postRepo.IncrementCommentCount(ctx, req.PostID)
 
-	// Send notification to post author or parent comment author
+	// Send notification to post author or parent comment author (shadowed comments stay silent)
+	if shadowed {
+		return s.GetComment(ctx, comment.ID, &userID)
+	}
 	if req.ParentID != nil && parentComment != nil {
 		// Reply notification
 		if parentComment.AuthorID != userID {
@@ -118,6 +134,10 @@ func (s *CommentServiceImpl) GetComment(ctx context.Context, commentID uuid.UUID
 	if err != nil {
 		return nil, err
 	}
+	// Shadowed comments only exist for their author
+	if comment.IsShadowed && (userID == nil || *userID != comment.AuthorID) {
+		return nil, gorm.ErrRecordNotFound
+	}
 
 	resp := dto.CommentToCommentResponse(comment)
 
@@ -130,7 +150,7 @@ func (s *CommentServiceImpl) GetComment(ctx context.Context, commentID uuid.UUID
 		}
 
 		// Get reply count
-		replyCount, _ := s.commentRepo.CountReplies(ctx, commentID)
+		replyCount, _ := s.commentRepo.CountReplies(ctx, commentID, userID)
 		replyCountInt := int(replyCount)
 		resp.ReplyCount = &replyCountInt
 	}
@@ -139,6 +159,10 @@ func (s *CommentServiceImpl) GetComment(ctx context.Context, commentID uuid.UUID
 }
 
 func (s *CommentServiceImpl) UpdateComment(ctx context.Context, commentID uuid.UUID, userID uuid.UUID, req *dto.UpdateCommentRequest) (*dto.CommentResponse, error) {
+	if _, err := s.sanctionService.CheckWriteAccess(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	// Get existing comment
 	comment, err := s.commentRepo.GetByID(ctx, commentID)
 	if err != nil {
@@ -169,13 +193,46 @@ func (s *CommentServiceImpl) DeleteComment(ctx context.Context, commentID uuid.U
 		return err
 	}
 
-	// Check ownership
-	if comment.AuthorID != userID {
+	// Check ownership (staff with comment.remove.any, site-wide or in the post's community, can remove it)
+	if comment.AuthorID != userID && !s.canRemoveComment(ctx, comment, userID) {
 		return errors.New("unauthorized: not comment owner")
 	}
 
+	return s.deleteComment(ctx, comment, nil)
+}
+
+// canRemoveComment checks comment.remove.any, scoped to the community of the comment's post
+func (s *CommentServiceImpl) canRemoveComment(ctx context.Context, comment *models.Comment, userID uuid.UUID) bool {
+	if s.authzService == nil {
+		return false
+	}
+	var communityID *uuid.UUID
+	if post, err := s.postRepo.GetByID(ctx, comment.PostID); err == nil {
+		communityID = post.CommunityID
+	}
+	allowed, _ := s.authzService.HasPermission(ctx, userID, models.PermCommentRemoveAny, communityID)
+	return allowed
+}
+
+// RemoveComment deletes a comment on behalf of a site moderator (report resolution)
+func (s *CommentServiceImpl) RemoveComment(ctx context.Context, commentID uuid.UUID, moderatorID uuid.UUID, reason string) error {
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteComment(ctx, comment, &contentRemoval{moderatorID: moderatorID, reason: reason})
+}
+
+// deleteComment soft deletes a comment and updates the post's comment count
+func (s *CommentServiceImpl) deleteComment(ctx context.Context, comment *models.Comment, removal *contentRemoval) error {
 	// Soft delete
-	err = s.commentRepo.Delete(ctx, commentID)
+	var err error
+	if removal != nil {
+		err = s.commentRepo.Remove(ctx, comment.ID, removal.moderatorID, removal.reason)
+	} else {
+		err = s.commentRepo.Delete(ctx, comment.ID)
+	}
 	if err != nil {
 		return err
 	}
@@ -187,12 +244,12 @@ func (s *CommentServiceImpl) DeleteComment(ctx context.Context, commentID uuid.U
 }
 
 func (s *CommentServiceImpl) ListCommentsByPost(ctx context.Context, postID uuid.UUID, offset, limit int, sortBy repositories.CommentSortBy, userID *uuid.UUID) (*dto.CommentListResponse, error) {
-	comments, err := s.commentRepo.ListByPost(ctx, postID, offset, limit, sortBy)
+	comments, err := s.commentRepo.ListByPost(ctx, postID, offset, limit, sortBy, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	count, err := s.commentRepo.CountByPost(ctx, postID)
+	count, err := s.commentRepo.CountByPost(ctx, postID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -201,12 +258,12 @@ func (s *CommentServiceImpl) ListCommentsByPost(ctx context.Context, postID uuid
 }
 
 func (s *CommentServiceImpl) ListCommentsByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int, userID *uuid.UUID) (*dto.CommentListResponse, error) {
-	comments, err := s.commentRepo.ListByAuthor(ctx, authorID, offset, limit)
+	comments, err := s.commentRepo.ListByAuthor(ctx, authorID, offset, limit, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	count, err := s.commentRepo.CountByAuthor(ctx, authorID)
+	count, err := s.commentRepo.CountByAuthor(ctx, authorID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -215,12 +272,12 @@ func (s *CommentServiceImpl) ListCommentsByAuthor(ctx context.Context, authorID
 }
 
 func (s *CommentServiceImpl) ListReplies(ctx context.Context, parentID uuid.UUID, offset, limit int, sortBy repositories.CommentSortBy, userID *uuid.UUID) (*dto.CommentListResponse, error) {
-	comments, err := s.commentRepo.ListReplies(ctx, parentID, offset, limit, sortBy)
+	comments, err := s.commentRepo.ListReplies(ctx, parentID, offset, limit, sortBy, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	count, err := s.commentRepo.CountReplies(ctx, parentID)
+	count, err := s.commentRepo.CountReplies(ctx, parentID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -233,7 +290,7 @@ func (s *CommentServiceImpl) GetCommentTree(ctx context.Context, postID uuid.UUI
 		maxDepth = 10
 	}
 
-	comments, err := s.commentRepo.GetCommentTree(ctx, postID, maxDepth)
+	comments, err := s.commentRepo.GetCommentTree(ctx, postID, maxDepth, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -355,7 +412,7 @@ func (s *CommentServiceImpl) buildCommentListResponse(ctx context.Context, comme
 			}
 
 			// Get reply count
-			replyCount, _ := s.commentRepo.CountReplies(ctx, comment.ID)
+			replyCount, _ := s.commentRepo.CountReplies(ctx, comment.ID, userID)
 			replyCountInt := int(replyCount)
 			resp.ReplyCount = &replyCountInt
 		}
@@ -392,7 +449,7 @@ func (s *CommentServiceImpl) ListCommentsByPostWithCursor(ctx context.Context, p
 	}
 
 	// Fetch limit+1 to check if there are more
-	comments, err := s.commentRepo.ListByPostWithCursor(ctx, postID, decodedCursor, limit+1, sortBy)
+	comments, err := s.commentRepo.ListByPostWithCursor(ctx, postID, decodedCursor, limit+1, sortBy, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -408,7 +465,7 @@ func (s *CommentServiceImpl) ListCommentsByAuthorWithCursor(ctx context.Context,
 	}
 
 	// Fetch limit+1 to check if there are more
-	comments, err := s.commentRepo.ListByAuthorWithCursor(ctx, authorID, decodedCursor, limit+1)
+	comments, err := s.commentRepo.ListByAuthorWithCursor(ctx, authorID, decodedCursor, limit+1, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -424,7 +481,7 @@ func (s *CommentServiceImpl) ListRepliesWithCursor(ctx context.Context, parentID
 	}
 
 	// Fetch limit+1 to check if there are more
-	comments, err := s.commentRepo.ListRepliesWithCursor(ctx, parentID, decodedCursor, limit+1, sortBy)
+	comments, err := s.commentRepo.ListRepliesWithCursor(ctx, parentID, decodedCursor, limit+1, sortBy, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -464,7 +521,7 @@ func (s *CommentServiceImpl) buildCommentListCursorResponse(ctx context.Context,
 			}
 
 			// Get reply count
-			replyCount, _ := s.commentRepo.CountReplies(ctx, comment.ID)
+			replyCount, _ := s.commentRepo.CountReplies(ctx, comment.ID, userID)
 			replyCountInt := int(replyCount)
 			resp.ReplyCount = &replyCountInt
 		}
@@ -0,0 +1,605 @@
+package serviceimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const communityNotificationType = "community"
+
+// communitySlugPattern - lowercase URL name (keep in sync with communities_slug_format)
+var communitySlugPattern = regexp.MustCompile(`^[a-z0-9_]{3,50}$`)
+
+type CommunityServiceImpl struct {
+	communityRepo repositories.CommunityRepository
+	mediaRepo     repositories.MediaRepository
+	notifService  services.NotificationService
+	authzService  services.AuthorizationService
+}
+
+func NewCommunityService(
+	communityRepo repositories.CommunityRepository,
+	mediaRepo repositories.MediaRepository,
+	notifService services.NotificationService,
+	authzService services.AuthorizationService,
+) services.CommunityService {
+	return &CommunityServiceImpl{
+		communityRepo: communityRepo,
+		mediaRepo:     mediaRepo,
+		notifService:  notifService,
+		authzService:  authzService,
+	}
+}
+
+// ==================== Communities ====================
+
+func (s *CommunityServiceImpl) CreateCommunity(ctx context.Context, userID uuid.UUID, req *dto.CreateCommunityRequest) (*dto.CommunityResponse, error) {
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !communitySlugPattern.MatchString(slug) {
+		return nil, services.ErrCommunitySlugInvalid
+	}
+
+	exists, err := s.communityRepo.ExistsBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, services.ErrCommunitySlugTaken
+	}
+
+	if err := s.validateBrandingMedia(ctx, userID, req.IconMediaID, req.BannerMediaID); err != nil {
+		return nil, err
+	}
+
+	rules, err := marshalCommunityRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	community := &models.Community{
+		Slug:          slug,
+		Name:          req.Name,
+		Description:   req.Description,
+		Rules:         rules,
+		IconMediaID:   req.IconMediaID,
+		BannerMediaID: req.BannerMediaID,
+		Visibility:    models.CommunityVisibilityPublic,
+		PostingPolicy: models.CommunityPostingMembers,
+		OwnerID:       userID,
+		MemberCount:   1,
+	}
+	if req.Visibility != "" {
+		community.Visibility = req.Visibility
+	}
+	if req.PostingPolicy != "" {
+		community.PostingPolicy = req.PostingPolicy
+	}
+
+	now := time.Now()
+	owner := &models.CommunityMember{
+		UserID:   userID,
+		Role:     models.CommunityRoleOwner,
+		Status:   models.CommunityMemberStatusActive,
+		JoinedAt: &now,
+	}
+
+	if err := s.communityRepo.Create(ctx, community, owner); err != nil {
+		// Lost a race on the unique slug
+		if strings.Contains(err.Error(), "unique") || strings.Contains(err.Error(), "duplicate") {
+			return nil, services.ErrCommunitySlugTaken
+		}
+		return nil, err
+	}
+
+	return s.GetCommunity(ctx, community.Slug, &userID)
+}
+
+func (s *CommunityServiceImpl) UpdateCommunity(ctx context.Context, slug string, userID uuid.UUID, req *dto.UpdateCommunityRequest) (*dto.CommunityResponse, error) {
+	community, err := s.getCommunity(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.requireModerator(ctx, community.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Access settings change who can see and post - owner only
+	if (req.Visibility != nil || req.PostingPolicy != nil) && member.Role != models.CommunityRoleOwner {
+		return nil, services.ErrCommunityOwnerOnly
+	}
+
+	if err := s.validateBrandingMedia(ctx, userID, req.IconMediaID, req.BannerMediaID); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		community.Name = *req.Name
+	}
+	if req.Description != nil {
+		community.Description = *req.Description
+	}
+	if req.Rules != nil {
+		rules, err := marshalCommunityRules(req.Rules)
+		if err != nil {
+			return nil, err
+		}
+		community.Rules = rules
+	}
+	if req.IconMediaID != nil {
+		community.IconMediaID = req.IconMediaID
+	}
+	if req.BannerMediaID != nil {
+		community.BannerMediaID = req.BannerMediaID
+	}
+	if req.Visibility != nil {
+		community.Visibility = *req.Visibility
+	}
+	if req.PostingPolicy != nil {
+		community.PostingPolicy = *req.PostingPolicy
+	}
+	community.UpdatedAt = time.Now()
+
+	if err := s.communityRepo.Update(ctx, community); err != nil {
+		return nil, err
+	}
+
+	return s.GetCommunity(ctx, community.Slug, &userID)
+}
+
+func (s *CommunityServiceImpl) GetCommunity(ctx context.Context, slug string, userID *uuid.UUID) (*dto.CommunityResponse, error) {
+	community, err := s.getCommunity(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.CommunityToCommunityResponse(community)
+	if userID != nil {
+		member, err := s.communityRepo.GetMember(ctx, community.ID, *userID)
+		if err == nil {
+			resp.Membership = communityMembershipInfo(community, member)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *CommunityServiceImpl) ListCommunities(ctx context.Context, query string, offset, limit int, userID *uuid.UUID) (*dto.CommunityListResponse, error) {
+	query = strings.TrimSpace(query)
+
+	communities, err := s.communityRepo.List(ctx, query, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.communityRepo.Count(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildCommunityListResponse(ctx, communities, count, offset, limit, userID), nil
+}
+
+func (s *CommunityServiceImpl) ListMyCommunities(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.CommunityListResponse, error) {
+	communities, err := s.communityRepo.ListByMember(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.communityRepo.CountByMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildCommunityListResponse(ctx, communities, count, offset, limit, &userID), nil
+}
+
+// ==================== Membership ====================
+
+func (s *CommunityServiceImpl) Join(ctx context.Context, slug string, userID uuid.UUID) (*dto.CommunityResponse, error) {
+	community, err := s.getCommunity(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.communityRepo.GetMember(ctx, community.ID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.Status == models.CommunityMemberStatusBanned {
+			return nil, services.ErrCommunityBanned
+		}
+		return nil, services.ErrCommunityAlreadyMember
+	}
+
+	member := &models.CommunityMember{
+		CommunityID: community.ID,
+		UserID:      userID,
+		Role:        models.CommunityRoleMember,
+		Status:      models.CommunityMemberStatusActive,
+	}
+	if community.RequiresApproval() {
+		member.Status = models.CommunityMemberStatusPending
+	} else {
+		now := time.Now()
+		member.JoinedAt = &now
+	}
+
+	if err := s.communityRepo.CreateMember(ctx, member); err != nil {
+		if strings.Contains(err.Error(), "unique") || strings.Contains(err.Error(), "duplicate") {
+			return nil, services.ErrCommunityAlreadyMember
+		}
+		return nil, err
+	}
+
+	if member.IsActive() {
+		_ = s.communityRepo.SyncMemberCount(ctx, community.ID)
+	} else {
+		_ = s.notifService.CreateNotification(
+			ctx,
+			community.OwnerID,
+			userID,
+			communityNotificationType,
+			fmt.Sprintf("มีคำขอเข้าร่วมชุมชน %s ใหม่", community.Name),
+			nil,
+			nil,
+		)
+	}
+
+	return s.GetCommunity(ctx, community.Slug, &userID)
+}
+
+func (s *CommunityServiceImpl) Leave(ctx context.Context, slug string, userID uuid.UUID) error {
+	community, err := s.getCommunity(ctx, slug)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.communityRepo.GetMember(ctx, community.ID, userID)
+	if err != nil {
+		return services.ErrCommunityNotMember
+	}
+	if member.Role == models.CommunityRoleOwner {
+		return services.ErrCommunityOwnerCannotLeave
+	}
+	// Leaving would let a banned user rejoin
+	if member.Status == models.CommunityMemberStatusBanned {
+		return services.ErrCommunityBanned
+	}
+
+	if err := s.communityRepo.DeleteMember(ctx, community.ID, userID); err != nil {
+		return err
+	}
+
+	if member.IsActive() {
+		_ = s.communityRepo.SyncMemberCount(ctx, community.ID)
+	}
+	return nil
+}
+
+func (s *CommunityServiceImpl) ListMembers(ctx context.Context, slug string, status string, offset, limit int, userID *uuid.UUID) (*dto.CommunityMemberListResponse, error) {
+	community, err := s.getCommunity(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == "" {
+		status = models.CommunityMemberStatusActive
+	}
+
+	var viewer *models.CommunityMember
+	if userID != nil {
+		viewer, _ = s.communityRepo.GetMember(ctx, community.ID, *userID)
+	}
+
+	// Join requests and bans are moderator business, private member lists are for members
+	if status != models.CommunityMemberStatusActive {
+		if userID == nil {
+			return nil, services.ErrCommunityModeratorOnly
+		}
+		if _, err := s.requireModerator(ctx, community.ID, *userID); err != nil {
+			return nil, err
+		}
+	}
+	if community.Visibility == models.CommunityVisibilityPrivate && (viewer == nil || !viewer.IsActive()) {
+		return nil, services.ErrCommunityPrivate
+	}
+
+	members, err := s.communityRepo.ListMembers(ctx, community.ID, status, nil, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.communityRepo.CountMembers(ctx, community.ID, status, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.CommunityMemberListResponse{
+		Members: make([]dto.CommunityMemberResponse, len(members)),
+		Meta: dto.PaginationMeta{
+			Total:  &count,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	for i, member := range members {
+		resp.Members[i] = *dto.CommunityMemberToCommunityMemberResponse(member)
+	}
+	return resp, nil
+}
+
+// ==================== Moderation ====================
+
+func (s *CommunityServiceImpl) ApproveMember(ctx context.Context, slug string, moderatorID, memberID uuid.UUID) (*dto.CommunityMemberResponse, error) {
+	community, member, err := s.getModeratedMember(ctx, slug, moderatorID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if member.Status != models.CommunityMemberStatusPending {
+		return nil, services.ErrCommunityMemberNotFound
+	}
+
+	now := time.Now()
+	member.Status = models.CommunityMemberStatusActive
+	member.JoinedAt = &now
+	member.UpdatedAt = now
+	if err := s.communityRepo.UpdateMember(ctx, member); err != nil {
+		return nil, err
+	}
+	_ = s.communityRepo.SyncMemberCount(ctx, community.ID)
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		member.UserID,
+		moderatorID,
+		communityNotificationType,
+		fmt.Sprintf("คำขอเข้าร่วมชุมชน %s ได้รับการอนุมัติแล้ว", community.Name),
+		nil,
+		nil,
+	)
+
+	return dto.CommunityMemberToCommunityMemberResponse(member), nil
+}
+
+func (s *CommunityServiceImpl) RejectMember(ctx context.Context, slug string, moderatorID, memberID uuid.UUID) error {
+	community, member, err := s.getModeratedMember(ctx, slug, moderatorID, memberID)
+	if err != nil {
+		return err
+	}
+	if member.Status != models.CommunityMemberStatusPending {
+		return services.ErrCommunityMemberNotFound
+	}
+
+	return s.communityRepo.DeleteMember(ctx, community.ID, member.UserID)
+}
+
+func (s *CommunityServiceImpl) BanMember(ctx context.Context, slug string, moderatorID, memberID uuid.UUID) (*dto.CommunityMemberResponse, error) {
+	community, err := s.getCommunity(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	moderator, err := s.requireModerator(ctx, community.ID, moderatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.communityRepo.GetMember(ctx, community.ID, memberID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Users that never joined can be banned too (keeps them from joining)
+	if member == nil {
+		member = &models.CommunityMember{
+			CommunityID: community.ID,
+			UserID:      memberID,
+			Role:        models.CommunityRoleMember,
+			Status:      models.CommunityMemberStatusBanned,
+		}
+		if err := s.communityRepo.CreateMember(ctx, member); err != nil {
+			return nil, err
+		}
+		return dto.CommunityMemberToCommunityMemberResponse(member), nil
+	}
+
+	// Moderators can only be removed by the owner, the owner can't be banned
+	if member.Role == models.CommunityRoleOwner ||
+		(member.Role == models.CommunityRoleModerator && moderator.Role != models.CommunityRoleOwner) {
+		return nil, services.ErrCommunityOwnerOnly
+	}
+
+	wasActive := member.IsActive()
+	member.Role = models.CommunityRoleMember
+	member.Status = models.CommunityMemberStatusBanned
+	member.UpdatedAt = time.Now()
+	if err := s.communityRepo.UpdateMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	if wasActive {
+		_ = s.communityRepo.SyncMemberCount(ctx, community.ID)
+	}
+
+	return dto.CommunityMemberToCommunityMemberResponse(member), nil
+}
+
+func (s *CommunityServiceImpl) UnbanMember(ctx context.Context, slug string, moderatorID, memberID uuid.UUID) error {
+	community, member, err := s.getModeratedMember(ctx, slug, moderatorID, memberID)
+	if err != nil {
+		return err
+	}
+	if member.Status != models.CommunityMemberStatusBanned {
+		return services.ErrCommunityMemberNotFound
+	}
+
+	// Unbanned users have to join again
+	return s.communityRepo.DeleteMember(ctx, community.ID, member.UserID)
+}
+
+func (s *CommunityServiceImpl) SetMemberRole(ctx context.Context, slug string, ownerID, memberID uuid.UUID, req *dto.SetCommunityMemberRoleRequest) (*dto.CommunityMemberResponse, error) {
+	community, err := s.getCommunity(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if community.OwnerID != ownerID {
+		return nil, services.ErrCommunityOwnerOnly
+	}
+
+	member, err := s.communityRepo.GetMember(ctx, community.ID, memberID)
+	if err != nil || !member.IsActive() || member.Role == models.CommunityRoleOwner {
+		return nil, services.ErrCommunityMemberNotFound
+	}
+
+	member.Role = req.Role
+	member.UpdatedAt = time.Now()
+	if err := s.communityRepo.UpdateMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return dto.CommunityMemberToCommunityMemberResponse(member), nil
+}
+
+// ==================== Helpers ====================
+
+// getCommunity loads a community by slug (slugs are stored lowercase)
+func (s *CommunityServiceImpl) getCommunity(ctx context.Context, slug string) (*models.Community, error) {
+	community, err := s.communityRepo.GetBySlug(ctx, strings.ToLower(slug))
+	if err != nil {
+		return nil, services.ErrCommunityNotFound
+	}
+	return community, nil
+}
+
+// requireModerator returns the active owner/moderator membership of the user
+// Staff granted community.moderate (site-wide or for this community) act as moderators
+func (s *CommunityServiceImpl) requireModerator(ctx context.Context, communityID, userID uuid.UUID) (*models.CommunityMember, error) {
+	member, err := s.communityRepo.GetMember(ctx, communityID, userID)
+	if err == nil && member.IsModerator() {
+		return member, nil
+	}
+
+	if s.authzService != nil {
+		if allowed, _ := s.authzService.HasPermission(ctx, userID, models.PermCommunityModerate, &communityID); allowed {
+			return &models.CommunityMember{
+				CommunityID: communityID,
+				UserID:      userID,
+				Role:        models.CommunityRoleModerator,
+				Status:      models.CommunityMemberStatusActive,
+			}, nil
+		}
+	}
+	return nil, services.ErrCommunityModeratorOnly
+}
+
+// getModeratedMember loads the community and a member of it on behalf of a moderator
+func (s *CommunityServiceImpl) getModeratedMember(ctx context.Context, slug string, moderatorID, memberID uuid.UUID) (*models.Community, *models.CommunityMember, error) {
+	community, err := s.getCommunity(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.requireModerator(ctx, community.ID, moderatorID); err != nil {
+		return nil, nil, err
+	}
+
+	member, err := s.communityRepo.GetMember(ctx, community.ID, memberID)
+	if err != nil {
+		return nil, nil, services.ErrCommunityMemberNotFound
+	}
+	return community, member, nil
+}
+
+// validateBrandingMedia checks icon and banner media exist, are images and belong to the user
+func (s *CommunityServiceImpl) validateBrandingMedia(ctx context.Context, userID uuid.UUID, mediaIDs ...*uuid.UUID) error {
+	for _, mediaID := range mediaIDs {
+		if mediaID == nil {
+			continue
+		}
+		media, err := s.mediaRepo.GetByID(ctx, *mediaID)
+		if err != nil {
+			return errors.New("media not found")
+		}
+		if media.UserID != userID {
+			return errors.New("media not owned by you")
+		}
+		if media.Type != "image" {
+			return errors.New("community icon and banner must be images")
+		}
+	}
+	return nil
+}
+
+func (s *CommunityServiceImpl) buildCommunityListResponse(ctx context.Context, communities []*models.Community, count int64, offset, limit int, userID *uuid.UUID) *dto.CommunityListResponse {
+	resp := &dto.CommunityListResponse{
+		Communities: make([]dto.CommunityResponse, len(communities)),
+		Meta: dto.PaginationMeta{
+			Total:  &count,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	for i, community := range communities {
+		item := dto.CommunityToCommunityResponse(community)
+		if userID != nil {
+			if member, err := s.communityRepo.GetMember(ctx, community.ID, *userID); err == nil {
+				item.Membership = communityMembershipInfo(community, member)
+			}
+		}
+		resp.Communities[i] = *item
+	}
+	return resp
+}
+
+// communityMembershipInfo maps the viewer's membership (including whether they may post)
+func communityMembershipInfo(community *models.Community, member *models.CommunityMember) *dto.CommunityMembershipInfo {
+	return &dto.CommunityMembershipInfo{
+		Role:     member.Role,
+		Status:   member.Status,
+		JoinedAt: member.JoinedAt,
+		CanPost:  checkCommunityPosting(community, member) == nil,
+	}
+}
+
+// checkCommunityPosting enforces membership and the posting policy of a community
+func checkCommunityPosting(community *models.Community, member *models.CommunityMember) error {
+	if member == nil {
+		return services.ErrCommunityNotMember
+	}
+	if member.Status == models.CommunityMemberStatusBanned {
+		return services.ErrCommunityBanned
+	}
+	if !member.IsActive() {
+		return services.ErrCommunityNotMember
+	}
+	if community.PostingPolicy == models.CommunityPostingModerators && !member.IsModerator() {
+		return services.ErrCommunityPostingRestricted
+	}
+	return nil
+}
+
+func marshalCommunityRules(rules []dto.CommunityRule) (datatypes.JSON, error) {
+	if rules == nil {
+		rules = []dto.CommunityRule{}
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(data), nil
+}
@@ -3,6 +3,8 @@ package serviceimpl
 import (
 	"context"
 	"errors"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +14,14 @@ import (
 	"gofiber-template/domain/services"
 	redisInfra "gofiber-template/infrastructure/redis"
 	"gofiber-template/pkg/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxGroupParticipants caps group size (including the owner)
+	maxGroupParticipants = 100
+	// maxSyncEvents caps a sync replay per conversation, beyond that the client reloads
+	maxSyncEvents = 500
 )
 
 type ConversationServiceImpl struct {
@@ -21,6 +31,7 @@ type ConversationServiceImpl struct {
 	userRepo         repositories.UserRepository
 	followRepo       repositories.FollowRepository
 	redisService     *redisInfra.RedisService
+	blockService     services.BlockService
 }
 
 func NewConversationService(
@@ -30,6 +41,7 @@ func NewConversationService(
 	userRepo repositories.UserRepository,
 	followRepo repositories.FollowRepository,
 	redisService *redisInfra.RedisService,
+	blockService services.BlockService,
 ) services.ConversationService {
 	return &ConversationServiceImpl{
 		conversationRepo: conversationRepo,
@@ -38,6 +50,7 @@ func NewConversationService(
 		userRepo:         userRepo,
 		followRepo:       followRepo,
 		redisService:     redisService,
+		blockService:     blockService,
 	}
 }
 
@@ -63,10 +76,22 @@ func (s *ConversationServiceImpl) GetOrCreateConversation(ctx context.Context, u
 		return nil, errors.New("cannot start conversation: user is blocked")
 	}
 
-	// Get or create conversation
-	conversation, _, err := s.conversationRepo.GetOrCreateByUsers(ctx, userID, otherUser.ID)
+	// Existing conversations keep working whatever the privacy settings are now
+	conversation, err := s.conversationRepo.GetByUsers(ctx, userID, otherUser.ID)
 	if err != nil {
-		return nil, err
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		requestedByID, err := s.newChatRequester(ctx, userID, otherUser)
+		if err != nil {
+			return nil, err
+		}
+
+		conversation, _, err = s.conversationRepo.GetOrCreateByUsers(ctx, userID, otherUser.ID, requestedByID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Convert to DTO
@@ -86,12 +111,12 @@ func (s *ConversationServiceImpl) GetOrCreateConversation(ctx context.Context, u
 func (s *ConversationServiceImpl) GetConversation(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) (*dto.ConversationResponse, error) {
 	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
 	if err != nil {
-		return nil, errors.New("conversation not found")
+		return nil, services.ErrConversationNotFound
 	}
 
 	// Check if user is participant
-	if conversation.User1ID != userID && conversation.User2ID != userID {
-		return nil, errors.New("access denied: not a participant")
+	if findParticipant(conversation, userID) == nil {
+		return nil, services.ErrConversationNotParticipant
 	}
 
 	// Convert to DTO
@@ -109,6 +134,11 @@ func (s *ConversationServiceImpl) GetConversation(ctx context.Context, conversat
 }
 
 func (s *ConversationServiceImpl) ListConversations(ctx context.Context, userID uuid.UUID, cursorStr *string, limit int) (*dto.ConversationListResponse, error) {
+	return s.listConversations(ctx, userID, cursorStr, limit, s.conversationRepo.ListByUser)
+}
+
+// listConversations pages through the conversations returned by list (newest message first)
+func (s *ConversationServiceImpl) listConversations(ctx context.Context, userID uuid.UUID, cursorStr *string, limit int, list func(ctx context.Context, userID uuid.UUID, cursor *time.Time, limit int) ([]*models.Conversation, error)) (*dto.ConversationListResponse, error) {
 	// Decode cursor if provided
 	var cursor *time.Time
 	if cursorStr != nil && *cursorStr != "" {
@@ -125,7 +155,7 @@ func (s *ConversationServiceImpl) ListConversations(ctx context.Context, userID
 	}
 
 	// Fetch conversations (limit + 1 to check for more)
-	conversations, err := s.conversationRepo.ListByUser(ctx, userID, cursor, limit+1)
+	conversations, err := list(ctx, userID, cursor, limit+1)
 	if err != nil {
 		return nil, err
 	}
@@ -174,11 +204,18 @@ func (s *ConversationServiceImpl) ListConversations(ctx context.Context, userID
 }
 
 func (s *ConversationServiceImpl) GetUnreadCount(ctx context.Context, userID uuid.UUID) (*dto.UnreadCountResponse, error) {
+	// Message requests have their own counter
+	requestCount, err := s.conversationRepo.CountRequests(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Try Redis first
 	count, err := s.redisService.GetTotalUnreadCount(ctx, userID)
 	if err == nil && count >= 0 {
 		return &dto.UnreadCountResponse{
-			TotalUnread: count,
+			TotalUnread:  count,
+			RequestCount: requestCount,
 		}, nil
 	}
 
@@ -192,45 +229,286 @@ func (s *ConversationServiceImpl) GetUnreadCount(ctx context.Context, userID uui
 	// Redis will be populated on next message send or mark as read
 
 	return &dto.UnreadCountResponse{
-		TotalUnread: dbCount,
+		TotalUnread:  dbCount,
+		RequestCount: requestCount,
 	}, nil
 }
 
-func (s *ConversationServiceImpl) MarkAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
-	// Verify user is participant
-	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+func (s *ConversationServiceImpl) MarkAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, upToSeq int64) (*dto.ReadStateResponse, error) {
+	conversation, err := s.getParticipatingConversation(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The requester is not told a message request was seen before it is accepted
+	if conversation.IsRequestFor(userID) {
+		return s.readState(ctx, conversationID, userID)
+	}
+
+	upToSeq = clampSeq(upToSeq, conversation.LastSeq)
+	advanced, err := s.conversationRepo.MarkReadUpTo(ctx, conversationID, userID, upToSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	if advanced {
+		// Direct messages also keep their per-message read flag
+		if err := s.messageRepo.MarkAsReadUpTo(ctx, conversationID, userID, upToSeq); err != nil {
+			return nil, err
+		}
+		s.appendReceiptEvent(ctx, conversationID, userID, models.ConversationEventRead, upToSeq)
+	}
+
+	return s.readState(ctx, conversationID, userID)
+}
+
+func (s *ConversationServiceImpl) MarkAsDelivered(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, upToSeq int64) (*dto.ReadStateResponse, error) {
+	conversation, err := s.getParticipatingConversation(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The requester is not told a message request was seen before it is accepted
+	if conversation.IsRequestFor(userID) {
+		return s.readState(ctx, conversationID, userID)
+	}
+
+	upToSeq = clampSeq(upToSeq, conversation.LastSeq)
+	advanced, err := s.conversationRepo.MarkDeliveredUpTo(ctx, conversationID, userID, upToSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	if advanced {
+		s.appendReceiptEvent(ctx, conversationID, userID, models.ConversationEventDelivered, upToSeq)
+	}
+
+	return s.readState(ctx, conversationID, userID)
+}
+
+func (s *ConversationServiceImpl) SyncConversation(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, afterSeq int64) (*dto.ConversationSyncResponse, error) {
+	conversation, err := s.getParticipatingConversation(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ConversationSyncResponse{
+		ConversationID: conversationID,
+		Events:         []dto.ConversationEventResponse{},
+		LastSeq:        conversation.LastSeq,
+	}
+	if participant := findParticipant(conversation, userID); participant != nil {
+		resp.UnreadCount = participant.UnreadCount
+	}
+
+	if afterSeq < 0 || afterSeq >= conversation.LastSeq {
+		return resp, nil
+	}
+
+	// Too far behind: a full reload is cheaper than the replay
+	events, err := s.conversationRepo.ListEvents(ctx, conversationID, afterSeq, maxSyncEvents+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > maxSyncEvents {
+		resp.Reset = true
+		return resp, nil
+	}
+
+	// New and edited messages are replayed in their current version
+	var messageIDs []uuid.UUID
+	for _, event := range events {
+		if event.MessageID != nil && event.Type != models.ConversationEventMessageUnsent {
+			messageIDs = append(messageIDs, *event.MessageID)
+		}
+	}
+	messages, err := s.messageRepo.ListByIDs(ctx, messageIDs)
+	if err != nil {
+		return nil, err
+	}
+	messagesByID := make(map[uuid.UUID]*models.Message, len(messages))
+	for _, message := range messages {
+		messagesByID[message.ID] = message
+	}
+
+	for _, event := range events {
+		var message *models.Message
+		if event.MessageID != nil && event.Type != models.ConversationEventMessageUnsent {
+			message = messagesByID[*event.MessageID]
+			if message == nil {
+				// Unsent since, its message.unsent event follows
+				continue
+			}
+		}
+
+		eventResp := dto.ConversationEventToResponse(event, message)
+		if eventResp.Message != nil && message.SenderID == userID {
+			eventResp.Message.Status = dto.MessageStatus(message.Seq, userID, conversation.Participants)
+		}
+		resp.Events = append(resp.Events, *eventResp)
+	}
+
+	return resp, nil
+}
+
+// ==================== Message Requests ====================
+
+func (s *ConversationServiceImpl) ListMessageRequests(ctx context.Context, userID uuid.UUID, cursorStr *string, limit int) (*dto.ConversationListResponse, error) {
+	return s.listConversations(ctx, userID, cursorStr, limit, s.conversationRepo.ListRequests)
+}
+
+func (s *ConversationServiceImpl) AcceptMessageRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) (*dto.ConversationResponse, error) {
+	if _, err := s.getReceivedRequest(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+
+	accepted, err := s.conversationRepo.AcceptRequest(ctx, conversationID, userID)
 	if err != nil {
-		return errors.New("conversation not found")
+		return nil, err
+	}
+	if !accepted {
+		return nil, services.ErrConversationNotRequest
 	}
 
-	if conversation.User1ID != userID && conversation.User2ID != userID {
-		return errors.New("access denied: not a participant")
+	// The request was read to be accepted, the receipts catch up now
+	if _, err := s.MarkAsRead(ctx, conversationID, userID, 0); err != nil {
+		log.Printf("⚠️  Failed to mark accepted message request %s as read: %v", conversationID, err)
 	}
 
-	// Get current unread count from Redis before marking
-	unreadCount, _ := s.redisService.GetConversationUnreadCount(ctx, userID, conversationID)
+	return s.GetConversation(ctx, conversationID, userID)
+}
 
-	// Mark all messages as read
-	if err := s.messageRepo.MarkAllAsRead(ctx, conversationID, userID); err != nil {
+func (s *ConversationServiceImpl) DeleteMessageRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.getReceivedRequest(ctx, conversationID, userID); err != nil {
 		return err
 	}
+	return s.deleteRequest(ctx, conversationID)
+}
 
-	// Reset unread count in conversation
-	if err := s.conversationRepo.ResetUnreadCount(ctx, conversationID, userID); err != nil {
+func (s *ConversationServiceImpl) BlockMessageRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
+	conversation, err := s.getReceivedRequest(ctx, conversationID, userID)
+	if err != nil {
 		return err
 	}
 
-	// Update Redis
-	if unreadCount > 0 {
-		// Decrement total unread
-		_ = s.redisService.DecrementTotalUnread(ctx, userID, unreadCount)
-		// Reset conversation unread (returns count but we ignore it)
-		_, _ = s.redisService.ResetConversationUnread(ctx, userID, conversationID)
+	requesterID := *conversation.RequestedByID
+	blocked, err := s.blockRepo.IsBlocked(ctx, userID, requesterID)
+	if err != nil {
+		return err
+	}
+	if !blocked {
+		requester, err := s.userRepo.GetByID(ctx, requesterID)
+		if err != nil {
+			return services.ErrConversationUserNotFound
+		}
+		if err := s.blockService.BlockUser(ctx, userID, requester.Username); err != nil {
+			return err
+		}
 	}
 
+	return s.deleteRequest(ctx, conversationID)
+}
+
+// getReceivedRequest loads a pending message request sent to the user
+func (s *ConversationServiceImpl) getReceivedRequest(ctx context.Context, conversationID, userID uuid.UUID) (*models.Conversation, error) {
+	conversation, err := s.getParticipatingConversation(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !conversation.IsRequestFor(userID) {
+		return nil, services.ErrConversationNotRequest
+	}
+	return conversation, nil
+}
+
+// deleteRequest removes a declined request with its messages (unread counters were never raised for it)
+func (s *ConversationServiceImpl) deleteRequest(ctx context.Context, conversationID uuid.UUID) error {
+	if err := s.conversationRepo.Delete(ctx, conversationID); err != nil {
+		return err
+	}
+	_ = s.redisService.InvalidateLastMessage(ctx, conversationID)
 	return nil
 }
 
+// newChatRequester decides how a new direct chat with recipient starts: straight away (nil),
+// as a message request from userID, or not at all. Accounts the recipient follows always get through.
+func (s *ConversationServiceImpl) newChatRequester(ctx context.Context, userID uuid.UUID, recipient *models.User) (*uuid.UUID, error) {
+	if recipient.ChatPrivacy == models.ChatPrivacyEveryone {
+		return nil, nil
+	}
+
+	follows, err := s.followRepo.IsFollowing(ctx, recipient.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if follows {
+		return nil, nil
+	}
+
+	if recipient.ChatPrivacy == models.ChatPrivacyNobody {
+		return nil, services.ErrConversationChatNotAllowed
+	}
+	return &userID, nil
+}
+
+// getParticipatingConversation loads a conversation (with its participants) the user is part of
+func (s *ConversationServiceImpl) getParticipatingConversation(ctx context.Context, conversationID, userID uuid.UUID) (*models.Conversation, error) {
+	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+	if err != nil {
+		return nil, services.ErrConversationNotFound
+	}
+	if findParticipant(conversation, userID) == nil {
+		return nil, services.ErrConversationNotParticipant
+	}
+	return conversation, nil
+}
+
+// appendReceiptEvent logs a receipt for sync (a missing event only costs other devices a refresh)
+func (s *ConversationServiceImpl) appendReceiptEvent(ctx context.Context, conversationID, userID uuid.UUID, eventType string, upToSeq int64) {
+	event := &models.ConversationEvent{
+		ConversationID: conversationID,
+		Type:           eventType,
+		UserID:         userID,
+		UpToSeq:        &upToSeq,
+	}
+	if err := s.conversationRepo.AppendEvent(ctx, event); err != nil {
+		log.Printf("⚠️  Failed to log %s for conversation %s: %v", eventType, conversationID, err)
+	}
+}
+
+// readState reports the user's receipt pointers and unread counts, bringing the Redis counters
+// in line with the database so every device of the user shows the same numbers
+func (s *ConversationServiceImpl) readState(ctx context.Context, conversationID, userID uuid.UUID) (*dto.ReadStateResponse, error) {
+	participant, err := s.conversationRepo.GetParticipant(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.redisService.SetConversationUnread(ctx, userID, conversationID, participant.UnreadCount)
+
+	total, err := s.conversationRepo.GetTotalUnreadCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ReadStateResponse{
+		ConversationID:    conversationID,
+		LastDeliveredSeq:  participant.LastDeliveredSeq,
+		LastReadSeq:       participant.LastReadSeq,
+		LastReadMessageID: participant.LastReadMessageID,
+		UnreadCount:       participant.UnreadCount,
+		TotalUnread:       total,
+	}, nil
+}
+
+// clampSeq keeps a receipt within the conversation (0 = up to the latest message)
+func clampSeq(seq, lastSeq int64) int64 {
+	if seq <= 0 || seq > lastSeq {
+		return lastSeq
+	}
+	return seq
+}
+
 func (s *ConversationServiceImpl) SearchUsersForChat(ctx context.Context, userID uuid.UUID, query string, limit int) (*dto.ChatUserSearchResponse, error) {
 	// Set default limit
 	if limit <= 0 || limit > 50 {
@@ -322,5 +600,287 @@ func (s *ConversationServiceImpl) SearchUsersForChat(ctx context.Context, userID
 	}, nil
 }
 
+// ==================== Group Conversations ====================
+
+func (s *ConversationServiceImpl) CreateGroup(ctx context.Context, userID uuid.UUID, req *dto.CreateGroupConversationRequest) (*dto.ConversationResponse, error) {
+	members, err := s.resolveNewMembers(ctx, userID, req.Usernames, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(members)+1 > maxGroupParticipants {
+		return nil, services.ErrConversationGroupFull
+	}
+
+	name := strings.TrimSpace(req.Name)
+	now := time.Now()
+	conversation := &models.Conversation{
+		Type:          models.ConversationTypeGroup,
+		Name:          &name,
+		AvatarURL:     req.AvatarURL,
+		CreatedByID:   &userID,
+		LastMessageAt: now,
+	}
+
+	participants := []*models.ConversationParticipant{
+		{UserID: userID, Role: models.ConversationRoleOwner, JoinedAt: now},
+	}
+	for _, memberID := range members {
+		participants = append(participants, &models.ConversationParticipant{
+			UserID:   memberID,
+			Role:     models.ConversationRoleMember,
+			JoinedAt: now,
+		})
+	}
+
+	if err := s.conversationRepo.CreateGroup(ctx, conversation, participants); err != nil {
+		return nil, err
+	}
+
+	return s.GetConversation(ctx, conversation.ID, userID)
+}
+
+func (s *ConversationServiceImpl) UpdateGroup(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, req *dto.UpdateGroupConversationRequest) (*dto.ConversationResponse, error) {
+	if _, _, err := s.requireGroupManager(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+
+	update := &models.Conversation{AvatarURL: req.AvatarURL}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		update.Name = &name
+	}
+	if err := s.conversationRepo.Update(ctx, conversationID, update); err != nil {
+		return nil, err
+	}
+
+	return s.GetConversation(ctx, conversationID, userID)
+}
+
+func (s *ConversationServiceImpl) AddParticipants(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, req *dto.AddParticipantsRequest) (*dto.ConversationResponse, error) {
+	conversation, _, err := s.requireGroupManager(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.resolveNewMembers(ctx, userID, req.Usernames, conversation)
+	if err != nil {
+		return nil, err
+	}
+	if len(conversation.Participants)+len(members) > maxGroupParticipants {
+		return nil, services.ErrConversationGroupFull
+	}
+
+	// New members start caught up, the history is not unread for them
+	now := time.Now()
+	participants := make([]*models.ConversationParticipant, 0, len(members))
+	for _, memberID := range members {
+		participants = append(participants, &models.ConversationParticipant{
+			ConversationID:   conversationID,
+			UserID:           memberID,
+			Role:             models.ConversationRoleMember,
+			LastDeliveredSeq: conversation.LastSeq,
+			LastReadSeq:      conversation.LastSeq,
+			JoinedAt:         now,
+		})
+	}
+	if err := s.conversationRepo.AddParticipants(ctx, participants); err != nil {
+		return nil, err
+	}
+
+	return s.GetConversation(ctx, conversationID, userID)
+}
+
+func (s *ConversationServiceImpl) RemoveParticipant(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, targetUserID uuid.UUID) error {
+	if targetUserID == userID {
+		return s.LeaveGroup(ctx, conversationID, userID)
+	}
+
+	conversation, actor, err := s.requireGroupManager(ctx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+
+	target := findParticipant(conversation, targetUserID)
+	if target == nil {
+		return services.ErrConversationMemberNotFound
+	}
+
+	// Admins can only remove members; the owner can remove anyone
+	if target.Role != models.ConversationRoleMember && actor.Role != models.ConversationRoleOwner {
+		return services.ErrConversationNotOwner
+	}
+
+	return s.removeMember(ctx, conversationID, targetUserID)
+}
+
+func (s *ConversationServiceImpl) UpdateParticipantRole(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, targetUserID uuid.UUID, req *dto.UpdateParticipantRoleRequest) (*dto.ConversationResponse, error) {
+	conversation, actor, err := s.requireGroupManager(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != models.ConversationRoleOwner {
+		return nil, services.ErrConversationNotOwner
+	}
+
+	if targetUserID == userID || findParticipant(conversation, targetUserID) == nil {
+		return nil, services.ErrConversationMemberNotFound
+	}
+
+	if req.Role == models.ConversationRoleOwner {
+		// Ownership transfer: the previous owner stays on as an admin
+		if _, err := s.conversationRepo.UpdateParticipantRole(ctx, conversationID, targetUserID, models.ConversationRoleOwner); err != nil {
+			return nil, err
+		}
+		if _, err := s.conversationRepo.UpdateParticipantRole(ctx, conversationID, userID, models.ConversationRoleAdmin); err != nil {
+			return nil, err
+		}
+	} else if _, err := s.conversationRepo.UpdateParticipantRole(ctx, conversationID, targetUserID, req.Role); err != nil {
+		return nil, err
+	}
+
+	return s.GetConversation(ctx, conversationID, userID)
+}
+
+func (s *ConversationServiceImpl) LeaveGroup(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
+	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+	if err != nil {
+		return services.ErrConversationNotFound
+	}
+	if !conversation.IsGroup() {
+		return services.ErrConversationNotGroup
+	}
+
+	participant := findParticipant(conversation, userID)
+	if participant == nil {
+		return services.ErrConversationNotParticipant
+	}
+
+	if err := s.removeMember(ctx, conversationID, userID); err != nil {
+		return err
+	}
+
+	if participant.Role != models.ConversationRoleOwner {
+		return nil
+	}
+
+	// The owner left: hand the group to the longest-standing admin (or member)
+	next, err := s.conversationRepo.NextOwnerCandidate(ctx, conversationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Nobody left in the group
+			return s.conversationRepo.Delete(ctx, conversationID)
+		}
+		return err
+	}
+	_, err = s.conversationRepo.UpdateParticipantRole(ctx, conversationID, next.UserID, models.ConversationRoleOwner)
+	return err
+}
+
+// removeMember deletes the membership and clears the member's cached unread count
+func (s *ConversationServiceImpl) removeMember(ctx context.Context, conversationID, userID uuid.UUID) error {
+	removed, err := s.conversationRepo.RemoveParticipant(ctx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return services.ErrConversationMemberNotFound
+	}
+
+	if unread, _ := s.redisService.ResetConversationUnread(ctx, userID, conversationID); unread > 0 {
+		_ = s.redisService.DecrementTotalUnread(ctx, userID, unread)
+	}
+	return nil
+}
+
+// requireParticipant returns the user's membership of the conversation
+func (s *ConversationServiceImpl) requireParticipant(ctx context.Context, conversationID, userID uuid.UUID) (*models.ConversationParticipant, error) {
+	participant, err := s.conversationRepo.GetParticipant(ctx, conversationID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if _, err := s.conversationRepo.GetByID(ctx, conversationID); err != nil {
+				return nil, services.ErrConversationNotFound
+			}
+			return nil, services.ErrConversationNotParticipant
+		}
+		return nil, err
+	}
+	return participant, nil
+}
+
+// requireGroupManager loads a group conversation the user owns or administers
+func (s *ConversationServiceImpl) requireGroupManager(ctx context.Context, conversationID, userID uuid.UUID) (*models.Conversation, *models.ConversationParticipant, error) {
+	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+	if err != nil {
+		return nil, nil, services.ErrConversationNotFound
+	}
+
+	participant := findParticipant(conversation, userID)
+	if participant == nil {
+		return nil, nil, services.ErrConversationNotParticipant
+	}
+	if !conversation.IsGroup() {
+		return nil, nil, services.ErrConversationNotGroup
+	}
+	if !participant.CanManage() {
+		return nil, nil, services.ErrConversationNotManager
+	}
+
+	return conversation, participant, nil
+}
+
+// resolveNewMembers looks up the users to add to a group, skipping the actor and existing members.
+// Each new member's chat privacy must let the actor start a chat with them directly.
+func (s *ConversationServiceImpl) resolveNewMembers(ctx context.Context, userID uuid.UUID, usernames []string, conversation *models.Conversation) ([]uuid.UUID, error) {
+	seen := map[uuid.UUID]bool{userID: true}
+	if conversation != nil {
+		for _, participant := range conversation.Participants {
+			seen[participant.UserID] = true
+		}
+	}
+
+	members := make([]uuid.UUID, 0, len(usernames))
+	for _, username := range usernames {
+		user, err := s.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return nil, services.ErrConversationUserNotFound
+		}
+		if seen[user.ID] {
+			continue
+		}
+		seen[user.ID] = true
+
+		blocked, blockedBy, err := s.blockRepo.GetBlockStatus(ctx, userID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked || blockedBy {
+			return nil, services.ErrConversationUserBlocked
+		}
+
+		// Groups have no request step, so anyone who would only get a request is refused
+		requester, err := s.newChatRequester(ctx, userID, user)
+		if err != nil {
+			return nil, err
+		}
+		if requester != nil {
+			return nil, services.ErrConversationChatNotAllowed
+		}
+
+		members = append(members, user.ID)
+	}
+
+	return members, nil
+}
+
+// findParticipant returns the user's membership from the loaded participants (nil if not a member)
+func findParticipant(conversation *models.Conversation, userID uuid.UUID) *models.ConversationParticipant {
+	for i := range conversation.Participants {
+		if conversation.Participants[i].UserID == userID {
+			return &conversation.Participants[i]
+		}
+	}
+	return nil
+}
+
 // Ensure interface compliance
 var _ services.ConversationService = (*ConversationServiceImpl)(nil)
@@ -0,0 +1,66 @@
+package serviceimpl
+
+import (
+	"fmt"
+	"html"
+
+	"gofiber-template/infrastructure/mail"
+)
+
+// Outbound email content (Thai copy, plain text plus a minimal HTML version)
+
+func verificationEmail(to, displayName, link string) *mail.Message {
+	return buildEmail(to,
+		"ยืนยันอีเมลของคุณ - VOOBIZE",
+		fmt.Sprintf("สวัสดีคุณ %s", displayName),
+		"กรุณายืนยันอีเมลของคุณเพื่อเริ่มใช้งาน VOOBIZE ลิงก์นี้ใช้ได้ภายใน 24 ชั่วโมง",
+		"ยืนยันอีเมล", link,
+		"หากคุณไม่ได้สมัครสมาชิก VOOBIZE สามารถเพิกเฉยต่ออีเมลนี้ได้",
+	)
+}
+
+func passwordResetEmail(to, displayName, link string) *mail.Message {
+	return buildEmail(to,
+		"ตั้งรหัสผ่านใหม่ - VOOBIZE",
+		fmt.Sprintf("สวัสดีคุณ %s", displayName),
+		"เราได้รับคำขอตั้งรหัสผ่านใหม่สำหรับบัญชีของคุณ ลิงก์นี้ใช้ได้ภายใน 1 ชั่วโมงและใช้ได้เพียงครั้งเดียว",
+		"ตั้งรหัสผ่านใหม่", link,
+		"หากคุณไม่ได้ขอตั้งรหัสผ่านใหม่ สามารถเพิกเฉยต่ออีเมลนี้ได้ รหัสผ่านเดิมยังใช้งานได้ตามปกติ",
+	)
+}
+
+func notificationEmail(to, displayName, message, link string) *mail.Message {
+	return buildEmail(to,
+		"การแจ้งเตือนใหม่ - VOOBIZE",
+		fmt.Sprintf("สวัสดีคุณ %s", displayName),
+		message,
+		"ดูการแจ้งเตือน", link,
+		"ปิดการแจ้งเตือนทางอีเมลได้ที่หน้าตั้งค่าการแจ้งเตือน",
+	)
+}
+
+func buildEmail(to, subject, greeting, body, action, link, footer string) *mail.Message {
+	text := fmt.Sprintf("%s\n\n%s\n\n%s: %s\n\n%s\n\n— VOOBIZE", greeting, body, action, link, footer)
+
+	htmlBody := fmt.Sprintf(`<!DOCTYPE html>
+<html><body style="font-family:sans-serif;color:#222;max-width:560px;margin:0 auto;padding:24px">
+<h2 style="margin-top:0">VOOBIZE</h2>
+<p>%s</p>
+<p>%s</p>
+<p><a href="%s" style="display:inline-block;padding:10px 20px;background:#111;color:#fff;text-decoration:none;border-radius:6px">%s</a></p>
+<p style="color:#777;font-size:13px">%s</p>
+</body></html>`,
+		html.EscapeString(greeting),
+		html.EscapeString(body),
+		html.EscapeString(link),
+		html.EscapeString(action),
+		html.EscapeString(footer),
+	)
+
+	return &mail.Message{
+		To:      to,
+		Subject: subject,
+		Text:    text,
+		HTML:    htmlBody,
+	}
+}
@@ -7,11 +7,48 @@ import (
 
 	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/utils"
+	"gorm.io/gorm"
 )
 
+// canViewAccount reports whether the viewer may see the posts and follower lists of owner
+// (private accounts: the owner and approved followers only)
+func canViewAccount(ctx context.Context, followRepo repositories.FollowRepository, owner *models.User, viewerID *uuid.UUID) (bool, error) {
+	if !owner.IsPrivate {
+		return true, nil
+	}
+	if viewerID == nil {
+		return false, nil
+	}
+	if *viewerID == owner.ID {
+		return true, nil
+	}
+	return followRepo.IsFollowing(ctx, *viewerID, owner.ID)
+}
+
+// requireAccountVisible returns services.ErrPrivateAccount when the viewer may not see ownerID's content
+// (unknown users are left to the caller, which lists nothing for them)
+func requireAccountVisible(ctx context.Context, userRepo repositories.UserRepository, followRepo repositories.FollowRepository, ownerID uuid.UUID, viewerID *uuid.UUID) error {
+	owner, err := userRepo.GetByID(ctx, ownerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	visible, err := canViewAccount(ctx, followRepo, owner, viewerID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return services.ErrPrivateAccount
+	}
+	return nil
+}
+
 type FollowServiceImpl struct {
 	followRepo   repositories.FollowRepository
 	userRepo     repositories.UserRepository
@@ -37,7 +74,7 @@ func (s *FollowServiceImpl) Follow(ctx context.Context, followerID uuid.UUID, fo
 	}
 
 	// Check if user exists
-	_, err := s.userRepo.GetByID(ctx, followingID)
+	following, err := s.userRepo.GetByID(ctx, followingID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
@@ -48,15 +85,19 @@ func (s *FollowServiceImpl) Follow(ctx context.Context, followerID uuid.UUID, fo
 		return nil, errors.New("already following")
 	}
 
+	// Private accounts approve their followers
+	if following.IsPrivate {
+		return s.requestFollow(ctx, followerID, followingID)
+	}
+
 	// Create follow relationship
-	err = s.followRepo.Follow(ctx, followerID, followingID)
+	err = s.createFollow(ctx, followerID, followingID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Update follower/following counts
-	_ = s.followRepo.UpdateFollowerCount(ctx, followingID, 1)
-	_ = s.followRepo.UpdateFollowingCount(ctx, followerID, 1)
+	// A request sent while the account was private is no longer needed
+	_, _ = s.followRepo.DeleteFollowRequest(ctx, followerID, followingID)
 
 	// Send notification
 	_ = s.notifService.CreateNotification(
@@ -72,14 +113,66 @@ func (s *FollowServiceImpl) Follow(ctx context.Context, followerID uuid.UUID, fo
 	return &dto.FollowResponse{
 		FollowerID:  followerID,
 		FollowingID: followingID,
+		Status:      models.FollowStatusFollowing,
 		CreatedAt:   time.Now(),
 	}, nil
 }
 
+func (s *FollowServiceImpl) requestFollow(ctx context.Context, followerID uuid.UUID, followingID uuid.UUID) (*dto.FollowResponse, error) {
+	isRequested, err := s.followRepo.HasFollowRequest(ctx, followerID, followingID)
+	if err != nil {
+		return nil, err
+	}
+	if isRequested {
+		return nil, services.ErrFollowRequestPending
+	}
+
+	if err := s.followRepo.CreateFollowRequest(ctx, followerID, followingID); err != nil {
+		return nil, err
+	}
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		followingID,
+		followerID,
+		"follow_request",
+		"ขอติดตามคุณ",
+		nil,
+		nil,
+	)
+
+	return &dto.FollowResponse{
+		FollowerID:  followerID,
+		FollowingID: followingID,
+		Status:      models.FollowStatusRequested,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// createFollow creates the follow relationship and updates both counts
+func (s *FollowServiceImpl) createFollow(ctx context.Context, followerID uuid.UUID, followingID uuid.UUID) error {
+	if err := s.followRepo.Follow(ctx, followerID, followingID); err != nil {
+		return err
+	}
+
+	// Update follower/following counts
+	_ = s.followRepo.UpdateFollowerCount(ctx, followingID, 1)
+	_ = s.followRepo.UpdateFollowingCount(ctx, followerID, 1)
+	return nil
+}
+
 func (s *FollowServiceImpl) Unfollow(ctx context.Context, followerID uuid.UUID, followingID uuid.UUID) error {
 	// Check if following
 	isFollowing, _ := s.followRepo.IsFollowing(ctx, followerID, followingID)
 	if !isFollowing {
+		// Unfollowing a private account before approval cancels the request
+		cancelled, err := s.followRepo.DeleteFollowRequest(ctx, followerID, followingID)
+		if err != nil {
+			return err
+		}
+		if cancelled {
+			return nil
+		}
 		return errors.New("not following")
 	}
 
@@ -104,17 +197,94 @@ func (s *FollowServiceImpl) IsFollowing(ctx context.Context, followerID uuid.UUI
 
 	// Check if mutual (both follow each other)
 	isMutual := false
+	isRequested := false
 	if isFollowing {
 		isMutual, _ = s.followRepo.IsFollowing(ctx, followingID, followerID)
+	} else {
+		isRequested, _ = s.followRepo.HasFollowRequest(ctx, followerID, followingID)
 	}
 
 	return &dto.FollowStatusResponse{
 		IsFollowing: isFollowing,
 		IsMutual:    isMutual,
+		IsRequested: isRequested,
 	}, nil
 }
 
+func (s *FollowServiceImpl) ListFollowRequests(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.FollowRequestListResponse, error) {
+	requests, err := s.followRepo.ListFollowRequests(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.followRepo.CountFollowRequests(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.FollowRequestResponse, len(requests))
+	for i, request := range requests {
+		responses[i] = *dto.FollowRequestToResponse(request)
+	}
+
+	return &dto.FollowRequestListResponse{
+		Requests: responses,
+		Meta: dto.PaginationMeta{
+			Total:  &count,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func (s *FollowServiceImpl) AcceptFollowRequest(ctx context.Context, userID uuid.UUID, requesterID uuid.UUID) error {
+	// Deleting first makes concurrent accepts follow only once
+	deleted, err := s.followRepo.DeleteFollowRequest(ctx, requesterID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return services.ErrFollowRequestNotFound
+	}
+
+	isFollowing, _ := s.followRepo.IsFollowing(ctx, requesterID, userID)
+	if isFollowing {
+		return nil
+	}
+
+	if err := s.createFollow(ctx, requesterID, userID); err != nil {
+		return err
+	}
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		requesterID,
+		userID,
+		"follow_accepted",
+		"อนุมัติคำขอติดตามของคุณแล้ว",
+		nil,
+		nil,
+	)
+
+	return nil
+}
+
+func (s *FollowServiceImpl) RejectFollowRequest(ctx context.Context, userID uuid.UUID, requesterID uuid.UUID) error {
+	deleted, err := s.followRepo.DeleteFollowRequest(ctx, requesterID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return services.ErrFollowRequestNotFound
+	}
+	return nil
+}
+
 func (s *FollowServiceImpl) GetFollowers(ctx context.Context, userID uuid.UUID, offset, limit int, currentUserID *uuid.UUID) (*dto.FollowerListResponse, error) {
+	if err := requireAccountVisible(ctx, s.userRepo, s.followRepo, userID, currentUserID); err != nil {
+		return nil, err
+	}
+
 	users, err := s.followRepo.GetFollowers(ctx, userID, offset, limit)
 	if err != nil {
 		return nil, err
@@ -149,6 +319,10 @@ func (s *FollowServiceImpl) GetFollowers(ctx context.Context, userID uuid.UUID,
 }
 
 func (s *FollowServiceImpl) GetFollowing(ctx context.Context, userID uuid.UUID, offset, limit int, currentUserID *uuid.UUID) (*dto.FollowingListResponse, error) {
+	if err := requireAccountVisible(ctx, s.userRepo, s.followRepo, userID, currentUserID); err != nil {
+		return nil, err
+	}
+
 	users, err := s.followRepo.GetFollowing(ctx, userID, offset, limit)
 	if err != nil {
 		return nil, err
@@ -211,6 +385,10 @@ var _ services.FollowService = (*FollowServiceImpl)(nil)
 
 // Cursor-based methods
 func (s *FollowServiceImpl) GetFollowersWithCursor(ctx context.Context, userID uuid.UUID, cursor string, limit int, currentUserID *uuid.UUID) (*dto.FollowerListCursorResponse, error) {
+	if err := requireAccountVisible(ctx, s.userRepo, s.followRepo, userID, currentUserID); err != nil {
+		return nil, err
+	}
+
 	// Decode cursor
 	decodedCursor, err := utils.DecodePostCursor(cursor)
 	if err != nil && cursor != "" {
@@ -265,6 +443,10 @@ func (s *FollowServiceImpl) GetFollowersWithCursor(ctx context.Context, userID u
 }
 
 func (s *FollowServiceImpl) GetFollowingWithCursor(ctx context.Context, userID uuid.UUID, cursor string, limit int, currentUserID *uuid.UUID) (*dto.FollowingListCursorResponse, error) {
+	if err := requireAccountVisible(ctx, s.userRepo, s.followRepo, userID, currentUserID); err != nil {
+		return nil, err
+	}
+
 	// Decode cursor
 	decodedCursor, err := utils.DecodePostCursor(cursor)
 	if err != nil && cursor != "" {
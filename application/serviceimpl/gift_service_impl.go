@@ -0,0 +1,516 @@
+package serviceimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/redis"
+	"gofiber-template/pkg/database"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	giftExpiryDuration      = 7 * 24 * time.Hour
+	giftExpiryRefundPercent = 80 // refunded to sender when not opened in time, rest is platform fee
+	giftDailySendLimit      = 20
+	giftReferenceType       = "gift"
+	giftBatchSize           = 100
+)
+
+type GiftServiceImpl struct {
+	txManager        *database.TransactionManager
+	giftRepo         repositories.GiftRepository
+	messageRepo      repositories.MessageRepository
+	conversationRepo repositories.ConversationRepository
+	blockRepo        repositories.BlockRepository
+	mediaRepo        repositories.MediaRepository
+	walletService    services.WalletService
+	notifService     services.NotificationService
+	sanctionService  services.SanctionService
+	redisService     *redis.RedisService
+}
+
+func NewGiftService(
+	txManager *database.TransactionManager,
+	giftRepo repositories.GiftRepository,
+	messageRepo repositories.MessageRepository,
+	conversationRepo repositories.ConversationRepository,
+	blockRepo repositories.BlockRepository,
+	mediaRepo repositories.MediaRepository,
+	walletService services.WalletService,
+	notifService services.NotificationService,
+	sanctionService services.SanctionService,
+	redisService *redis.RedisService,
+) services.GiftService {
+	return &GiftServiceImpl{
+		txManager:        txManager,
+		giftRepo:         giftRepo,
+		messageRepo:      messageRepo,
+		conversationRepo: conversationRepo,
+		blockRepo:        blockRepo,
+		mediaRepo:        mediaRepo,
+		walletService:    walletService,
+		notifService:     notifService,
+		sanctionService:  sanctionService,
+		redisService:     redisService,
+	}
+}
+
+func (s *GiftServiceImpl) ListPackages(ctx context.Context) (*dto.GiftPackageListResponse, error) {
+	packages, err := s.giftRepo.ListActivePackages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.GiftPackageListResponse{
+		Packages: make([]dto.GiftPackageResponse, len(packages)),
+	}
+	for i, pkg := range packages {
+		resp.Packages[i] = *dto.GiftPackageToGiftPackageResponse(pkg)
+	}
+	return resp, nil
+}
+
+func (s *GiftServiceImpl) SendGift(ctx context.Context, senderID uuid.UUID, req *dto.SendGiftRequest) (*dto.MessageResponse, error) {
+	// Suspended and banned accounts cannot write; a paid gift always reaches the receiver,
+	// so shadowbanned accounts (whose content nobody else may see) cannot send one
+	shadowbanned, err := s.sanctionService.CheckWriteAccess(ctx, senderID)
+	if err != nil {
+		return nil, err
+	}
+	if shadowbanned {
+		return nil, services.ErrGiftSenderRestricted
+	}
+
+	// Get conversation
+	conversation, err := s.conversationRepo.GetByID(ctx, req.ConversationID)
+	if err != nil {
+		return nil, errors.New("conversation not found")
+	}
+
+	// Check if user is participant
+	if findParticipant(conversation, senderID) == nil {
+		return nil, errors.New("access denied: not a participant")
+	}
+
+	// Gift boxes are paid to one receiver
+	if conversation.IsGroup() {
+		return nil, errors.New("gifts can only be sent in direct conversations")
+	}
+
+	// Gifts wait until a message request is accepted
+	if conversation.IsPendingRequest() {
+		return nil, services.ErrConversationRequestPending
+	}
+
+	// Determine receiver
+	receiverID := conversation.OtherUserID(senderID)
+
+	// Check block status
+	blocked, blockedBy, err := s.blockRepo.GetBlockStatus(ctx, senderID, receiverID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked || blockedBy {
+		return nil, errors.New("cannot send gift: user is blocked")
+	}
+
+	// Spam prevention
+	sentToday, err := s.giftRepo.CountSentSince(ctx, senderID, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if sentToday >= giftDailySendLimit {
+		return nil, services.ErrGiftDailyLimit
+	}
+
+	pkg, err := s.giftRepo.GetPackageByID(ctx, req.PackageID)
+	if err != nil || !pkg.IsActive {
+		return nil, services.ErrGiftPackageUnavailable
+	}
+
+	if req.Content != nil && len([]rune(*req.Content)) > pkg.MaxMessageLength {
+		return nil, errors.New("gift message is too long for this package")
+	}
+
+	media, err := s.buildGiftMedia(ctx, senderID, pkg, req.MediaIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var mediaJSON datatypes.JSON
+	if len(media) > 0 {
+		mediaBytes, err := json.Marshal(media)
+		if err != nil {
+			return nil, errors.New("failed to process media")
+		}
+		mediaJSON = datatypes.JSON(mediaBytes)
+	}
+
+	// Pay into escrow - released to the receiver on open, refunded on expiry
+	senderWallet, err := s.walletService.GetOrCreateUserWallet(ctx, senderID)
+	if err != nil {
+		return nil, err
+	}
+	escrowWallet, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletEscrow)
+	if err != nil {
+		return nil, err
+	}
+
+	giftID := uuid.New()
+	referenceType := giftReferenceType
+	now := time.Now()
+	message := &models.Message{
+		ConversationID: req.ConversationID,
+		SenderID:       senderID,
+		ReceiverID:     &receiverID,
+		Type:           models.MessageTypeGift,
+		Content:        req.Content,
+		Media:          mediaJSON,
+		IsRead:         false,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	receiverAmount := pkg.ReceiverAmount()
+	gift := &models.Gift{
+		ID:             giftID,
+		ConversationID: req.ConversationID,
+		SenderID:       senderID,
+		ReceiverID:     receiverID,
+		PackageID:      pkg.ID,
+		AmountPaid:     pkg.Price,
+		ReceiverAmount: receiverAmount,
+		PlatformFee:    pkg.Price - receiverAmount,
+		Status:         models.GiftStatusPending,
+		ExpiresAt:      now.Add(giftExpiryDuration),
+	}
+
+	// Payment and delivery commit together - no paid gift without a message, no message without payment
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if _, err := s.walletService.Transfer(ctx, &dto.WalletTransferRequest{
+			IdempotencyKey: "gift_send:" + giftID.String(),
+			FromWalletID:   senderWallet.ID,
+			ToWalletID:     escrowWallet.ID,
+			Amount:         pkg.Price,
+			Type:           models.LedgerTxTypeTransfer,
+			Description:    "Gift: " + pkg.Name,
+			ReferenceType:  &referenceType,
+			ReferenceID:    &giftID,
+		}); err != nil {
+			return err
+		}
+		return s.giftRepo.CreateWithMessage(ctx, message, gift)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Update conversation last message and increment unread count
+	_ = s.conversationRepo.UpdateLastMessage(ctx, req.ConversationID, message.ID, now)
+	_ = s.conversationRepo.IncrementUnreadCounts(ctx, req.ConversationID, senderID)
+
+	// Increment unread counts in Redis
+	_ = s.redisService.IncrementTotalUnread(ctx, receiverID)
+	_ = s.redisService.IncrementConversationUnread(ctx, receiverID, req.ConversationID)
+
+	// Cache last message in Redis (content stays sealed)
+	_ = s.redisService.CacheLastMessage(ctx, req.ConversationID, message.ID, message.SenderID, nil, string(message.Type), message.CreatedAt)
+
+	// Load message with full relations
+	fullMessage, err := s.messageRepo.GetByID(ctx, message.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.MessageToMessageResponse(fullMessage)
+	if req.TempID != nil {
+		resp.TempID = req.TempID
+	}
+
+	return resp, nil
+}
+
+func (s *GiftServiceImpl) OpenGift(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.MessageResponse, error) {
+	gift, err := s.giftRepo.GetByMessageID(ctx, messageID)
+	if err != nil {
+		return nil, errors.New("gift not found")
+	}
+
+	if gift.ReceiverID != userID {
+		return nil, errors.New("access denied: only the receiver can open this gift")
+	}
+
+	switch gift.Status {
+	case models.GiftStatusOpened:
+		// Opening twice just shows the gift again
+	case models.GiftStatusPending:
+		if time.Now().After(gift.ExpiresAt) {
+			return nil, services.ErrGiftNotOpenable
+		}
+
+		opened, err := s.giftRepo.UpdateStatus(ctx, gift.ID, models.GiftStatusPending, models.GiftStatusOpened)
+		if err != nil {
+			return nil, err
+		}
+		if !opened {
+			// Expired (or refunded) in the meantime
+			current, err := s.giftRepo.GetByID(ctx, gift.ID)
+			if err != nil || current.Status != models.GiftStatusOpened {
+				return nil, services.ErrGiftNotOpenable
+			}
+			break
+		}
+
+		gift.Status = models.GiftStatusOpened
+		if err := s.settle(ctx, gift); err != nil {
+			// Retried by the settlement job
+			log.Printf("[GIFT] Failed to pay out gift %s: %v", gift.ID, err)
+		}
+
+		_ = s.notifService.CreateNotification(
+			ctx,
+			gift.SenderID,
+			userID,
+			"gift",
+			"เปิดของขวัญของคุณแล้ว! 🎉",
+			nil,
+			nil,
+		)
+	default:
+		return nil, services.ErrGiftNotOpenable
+	}
+
+	message, err := s.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.MessageToMessageResponse(message), nil
+}
+
+func (s *GiftServiceImpl) ProcessExpiredGifts(ctx context.Context) (int, error) {
+	gifts, err := s.giftRepo.ListExpiredPending(ctx, time.Now(), giftBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, gift := range gifts {
+		ok, err := s.giftRepo.UpdateStatus(ctx, gift.ID, models.GiftStatusPending, models.GiftStatusExpired)
+		if err != nil {
+			log.Printf("[GIFT] Failed to expire gift %s: %v", gift.ID, err)
+			continue
+		}
+		if !ok {
+			continue // opened in the meantime
+		}
+		expired++
+
+		gift.Status = models.GiftStatusExpired
+		if err := s.settle(ctx, gift); err != nil {
+			// Retried by the settlement job
+			log.Printf("[GIFT] Failed to refund gift %s: %v", gift.ID, err)
+		}
+
+		_ = s.notifService.CreateNotification(
+			ctx,
+			gift.SenderID,
+			gift.ReceiverID,
+			"gift",
+			"ของขวัญของคุณหมดอายุโดยยังไม่ถูกเปิด ระบบได้คืนเงินบางส่วนเข้ากระเป๋าของคุณแล้ว",
+			nil,
+			nil,
+		)
+		_ = s.notifService.CreateNotification(
+			ctx,
+			gift.ReceiverID,
+			gift.SenderID,
+			"gift",
+			"ของขวัญที่คุณได้รับหมดอายุแล้ว",
+			nil,
+			nil,
+		)
+	}
+
+	return expired, nil
+}
+
+func (s *GiftServiceImpl) RefundRemovedGift(ctx context.Context, messageID uuid.UUID) error {
+	gift, err := s.giftRepo.GetByMessageID(ctx, messageID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil // not a gift message
+	}
+	if err != nil {
+		return err
+	}
+
+	ok, err := s.giftRepo.UpdateStatus(ctx, gift.ID, models.GiftStatusPending, models.GiftStatusRefunded)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil // already opened or expired, its escrow has been settled
+	}
+
+	gift.Status = models.GiftStatusRefunded
+	if err := s.settle(ctx, gift); err != nil {
+		// Retried by the settlement job
+		log.Printf("[GIFT] Failed to refund removed gift %s: %v", gift.ID, err)
+	}
+	return nil
+}
+
+func (s *GiftServiceImpl) SettlePendingGifts(ctx context.Context) (int, error) {
+	gifts, err := s.giftRepo.ListUnsettled(ctx, giftBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, gift := range gifts {
+		if err := s.settle(ctx, gift); err != nil {
+			log.Printf("[GIFT] Failed to settle gift %s: %v", gift.ID, err)
+			continue
+		}
+		settled++
+	}
+
+	return settled, nil
+}
+
+// settle moves the escrowed payment according to the gift's final status (idempotent per gift)
+func (s *GiftServiceImpl) settle(ctx context.Context, gift *models.Gift) error {
+	escrowWallet, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletEscrow)
+	if err != nil {
+		return err
+	}
+	feeWallet, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+	if err != nil {
+		return err
+	}
+
+	var payee uuid.UUID
+	var payeeAmount int64
+	var idempotencyKey, txType, description string
+
+	switch gift.Status {
+	case models.GiftStatusOpened:
+		payee = gift.ReceiverID
+		payeeAmount = gift.ReceiverAmount
+		idempotencyKey = "gift_open:" + gift.ID.String()
+		txType = models.LedgerTxTypeTransfer
+		description = "Gift opened"
+	case models.GiftStatusExpired:
+		payee = gift.SenderID
+		payeeAmount = gift.AmountPaid * giftExpiryRefundPercent / 100
+		idempotencyKey = "gift_expire:" + gift.ID.String()
+		txType = models.LedgerTxTypeRefund
+		description = "Gift expired"
+	case models.GiftStatusRefunded:
+		payee = gift.SenderID
+		payeeAmount = gift.AmountPaid
+		idempotencyKey = "gift_refund:" + gift.ID.String()
+		txType = models.LedgerTxTypeRefund
+		description = "Gift refunded"
+	default:
+		return errors.New("gift is not settleable")
+	}
+
+	payeeWallet, err := s.walletService.GetOrCreateUserWallet(ctx, payee)
+	if err != nil {
+		return err
+	}
+
+	postings := []dto.LedgerPosting{
+		{WalletID: escrowWallet.ID, Amount: -gift.AmountPaid},
+		{WalletID: payeeWallet.ID, Amount: payeeAmount},
+	}
+	if fee := gift.AmountPaid - payeeAmount; fee > 0 {
+		postings = append(postings, dto.LedgerPosting{WalletID: feeWallet.ID, Amount: fee})
+	}
+
+	referenceType := giftReferenceType
+	_, err = s.walletService.PostTransaction(ctx, &dto.LedgerTransactionRequest{
+		IdempotencyKey: idempotencyKey,
+		Type:           txType,
+		Description:    description,
+		ReferenceType:  &referenceType,
+		ReferenceID:    &gift.ID,
+		Postings:       postings,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.giftRepo.MarkSettled(ctx, gift.ID)
+}
+
+// buildGiftMedia validates media ownership and package limits, returning message media metadata
+func (s *GiftServiceImpl) buildGiftMedia(ctx context.Context, senderID uuid.UUID, pkg *models.GiftPackage, mediaIDs []uuid.UUID) ([]dto.MessageMedia, error) {
+	media := make([]dto.MessageMedia, 0, len(mediaIDs))
+	images, videos := 0, 0
+
+	for _, mediaID := range mediaIDs {
+		m, err := s.mediaRepo.GetByID(ctx, mediaID)
+		if err != nil {
+			return nil, errors.New("some media files not found")
+		}
+		if m.UserID != senderID {
+			return nil, errors.New("some media files not owned by you")
+		}
+
+		switch m.Type {
+		case "image":
+			images++
+		case "video":
+			videos++
+		default:
+			return nil, errors.New("gifts can only contain images and videos")
+		}
+
+		item := dto.MessageMedia{
+			URL:  m.URL,
+			Type: m.Type,
+		}
+		mediaIDStr := m.ID.String()
+		item.MediaID = &mediaIDStr
+		if m.Thumbnail != "" {
+			thumbnail := m.Thumbnail
+			item.Thumbnail = &thumbnail
+		}
+		if m.MimeType != "" {
+			mimeType := m.MimeType
+			item.MimeType = &mimeType
+		}
+		size := m.Size
+		item.Size = &size
+		if m.Width > 0 && m.Height > 0 {
+			width, height := m.Width, m.Height
+			item.Width = &width
+			item.Height = &height
+		}
+		if m.Duration > 0 {
+			duration := int(m.Duration)
+			item.Duration = &duration
+		}
+		media = append(media, item)
+	}
+
+	if images > pkg.MaxImages || videos > pkg.MaxVideos {
+		return nil, services.ErrGiftMediaLimit
+	}
+
+	return media, nil
+}
+
+// Ensure interface compliance
+var _ services.GiftService = (*GiftServiceImpl)(nil)
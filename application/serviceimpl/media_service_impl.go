@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"mime"
 	"mime/multipart"
 	"path/filepath"
 	"strings"
@@ -235,10 +237,29 @@ func (s *MediaServiceImpl) CleanupUnusedMedia(ctx context.Context, olderThanDays
 	return deletedCount, nil
 }
 
-// UpdateVideoEncodingStatus updates the encoding status of a video
+// UpdateVideoEncodingStatus updates the encoding status of a Bunny Stream video (from the webhook)
 func (s *MediaServiceImpl) UpdateVideoEncodingStatus(ctx context.Context, videoID string, status string, progress int, width int, height int, duration int) error {
-	// NOTE: DEPRECATED - No longer used as we migrated from Bunny Stream to R2
-	return fmt.Errorf("UpdateVideoEncodingStatus is deprecated - Bunny Stream is no longer used")
+	media, err := s.mediaRepo.GetByVideoID(ctx, videoID)
+	if err != nil {
+		return fmt.Errorf("failed to get media: %w", err)
+	}
+
+	media.EncodingStatus = status
+	if progress > 0 {
+		media.EncodingProgress = progress
+	}
+	if status == "completed" {
+		media.EncodingProgress = 100
+	}
+	if width > 0 && height > 0 {
+		media.Width = width
+		media.Height = height
+	}
+	if duration > 0 {
+		media.Duration = float64(duration)
+	}
+
+	return s.mediaRepo.Update(ctx, media)
 }
 
 // GetEncodingStatus retrieves encoding status for a video
@@ -264,13 +285,17 @@ func (s *MediaServiceImpl) GetEncodingStatus(ctx context.Context, mediaID uuid.U
 }
 
 // GetMediaByVideoID retrieves media by Bunny Stream video ID
-// NOTE: DEPRECATED - Bunny Stream is no longer used
 func (s *MediaServiceImpl) GetMediaByVideoID(ctx context.Context, videoID string) (*dto.MediaResponse, error) {
-	return nil, fmt.Errorf("GetMediaByVideoID is deprecated - Bunny Stream video IDs are no longer used")
+	media, err := s.mediaRepo.GetByVideoID(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.MediaToMediaResponse(media), nil
 }
 
-// CreateVideo creates a video media record with source tracking (polymorphic)
-// NOTE: DEPRECATED - Use R2 presigned upload instead
+// CreateVideo uploads a video to Bunny Stream and creates its media record with source tracking (polymorphic)
+// NOTE: Only used for sources that need HLS streaming (reels) - posts and chat use R2 presigned upload
 func (s *MediaServiceImpl) CreateVideo(
 	ctx context.Context,
 	userID uuid.UUID,
@@ -279,7 +304,61 @@ func (s *MediaServiceImpl) CreateVideo(
 	file multipart.File,
 	filename string,
 ) (*dto.MediaResponse, error) {
-	return nil, fmt.Errorf("CreateVideo is deprecated - please use R2 presigned upload instead")
+	if s.bunnyStream == nil {
+		return nil, errors.New("video streaming is not configured")
+	}
+
+	// Validate file extension
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !s.contains(s.allowedVideos, ext) {
+		return nil, errors.New("invalid video format. Allowed: mp4, mov, avi, webm")
+	}
+
+	// Upload to Bunny Stream (encoding starts right after upload)
+	video, err := s.bunnyStream.CreateVideo(file, filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload to Bunny Stream: %w", err)
+	}
+
+	mimeType := mime.TypeByExtension(ext)
+	if mimeType == "" {
+		mimeType = "video/mp4"
+	}
+
+	videoID := video.VideoID
+	media := &models.Media{
+		UserID:         userID,
+		Type:           "video",
+		FileName:       filename,
+		Extension:      strings.TrimPrefix(ext, "."),
+		MimeType:       mimeType,
+		Size:           video.StorageSize,
+		URL:            s.bunnyStream.GetHLSURL(videoID),
+		Thumbnail:      s.bunnyStream.GetThumbnailURL(videoID),
+		Width:          video.Width,
+		Height:         video.Height,
+		Duration:       float64(video.Length),
+		VideoID:        &videoID,
+		HLSURL:         s.bunnyStream.GetHLSURL(videoID),
+		EncodingStatus: "processing",
+		SourceType:     &sourceType,
+		SourceID:       sourceID,
+	}
+
+	if err := s.mediaRepo.Create(ctx, media); err != nil {
+		// Don't leave an orphaned video behind
+		_ = s.bunnyStream.DeleteVideo(videoID)
+		return nil, err
+	}
+
+	// Poll encoding progress in the VideoEncoderWorker (webhook may also update it)
+	if s.redisService != nil {
+		if err := s.redisService.EnqueueVideoEncoding(ctx, media.ID, videoID); err != nil {
+			log.Printf("❌ Failed to enqueue video encoding for media %s: %v", media.ID, err)
+		}
+	}
+
+	return dto.MediaToMediaResponse(media), nil
 }
 
 // UpdateSourceID updates the source_id of a media record
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
@@ -18,12 +21,18 @@ import (
 	"gorm.io/datatypes"
 )
 
+const (
+	messageEditWindow   = 15 * time.Minute
+	messageUnsendWindow = 24 * time.Hour
+)
+
 type MessageServiceImpl struct {
 	messageRepo      repositories.MessageRepository
 	conversationRepo repositories.ConversationRepository
 	blockRepo        repositories.BlockRepository
 	userRepo         repositories.UserRepository
 	redisService     *redis.RedisService
+	sanctionService  services.SanctionService
 	chatHub          *websocket.ChatHub
 }
 
@@ -33,6 +42,7 @@ func NewMessageService(
 	blockRepo repositories.BlockRepository,
 	userRepo repositories.UserRepository,
 	redisService *redis.RedisService,
+	sanctionService services.SanctionService,
 ) services.MessageService {
 	return &MessageServiceImpl{
 		messageRepo:      messageRepo,
@@ -40,6 +50,7 @@ func NewMessageService(
 		blockRepo:        blockRepo,
 		userRepo:         userRepo,
 		redisService:     redisService,
+		sanctionService:  sanctionService,
 		chatHub:          nil, // Will be set later via SetChatHub
 	}
 }
@@ -50,38 +61,59 @@ func (s *MessageServiceImpl) SetChatHub(chatHub *websocket.ChatHub) {
 }
 
 func (s *MessageServiceImpl) SendMessage(ctx context.Context, userID uuid.UUID, req *dto.SendMessageRequest) (*dto.MessageResponse, error) {
+	if _, err := s.sanctionService.CheckWriteAccess(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	// Validate: Content OR Media must be provided
 	if (req.Content == nil || *req.Content == "") && len(req.Media) == 0 {
 		return nil, errors.New("either content or media must be provided")
 	}
 
-	// Get conversation
-	conversation, err := s.conversationRepo.GetByID(ctx, req.ConversationID)
-	if err != nil {
-		return nil, errors.New("conversation not found")
+	// Gift messages are paid and must go through GiftService
+	if models.MessageType(req.Type) == models.MessageTypeGift {
+		return nil, errors.New("gift messages must be sent via gift.send")
 	}
 
-	// Check if user is participant
-	if conversation.User1ID != userID && conversation.User2ID != userID {
-		return nil, errors.New("access denied: not a participant")
+	// Get conversation and check that the user is a participant
+	conversation, err := s.verifyAccess(ctx, req.ConversationID, userID, false)
+	if err != nil {
+		return nil, err
 	}
 
-	// Determine sender and receiver
-	var receiverID uuid.UUID
-	if conversation.User1ID == userID {
-		receiverID = conversation.User2ID
-	} else {
-		receiverID = conversation.User1ID
+	// Direct conversations have a receiver; group messages go to every participant
+	var receiverID *uuid.UUID
+	if !conversation.IsGroup() {
+		otherUserID := conversation.OtherUserID(userID)
+		receiverID = &otherUserID
+
+		// Check block status
+		blocked, blockedBy, err := s.blockRepo.GetBlockStatus(ctx, userID, otherUserID)
+		if err != nil {
+			return nil, err
+		}
+
+		if blocked || blockedBy {
+			return nil, errors.New("cannot send message: user is blocked")
+		}
 	}
 
-	// Check block status
-	blocked, blockedBy, err := s.blockRepo.GetBlockStatus(ctx, userID, receiverID)
-	if err != nil {
-		return nil, err
+	// Message requests: the requester gets one message, a reply from the recipient accepts the request
+	pendingRequest := false
+	if conversation.IsRequestFor(userID) {
+		if _, err := s.conversationRepo.AcceptRequest(ctx, conversation.ID, userID); err != nil {
+			return nil, err
+		}
+	} else if conversation.IsPendingRequest() {
+		pendingRequest = true
 	}
 
-	if blocked || blockedBy {
-		return nil, errors.New("cannot send message: user is blocked")
+	// A quoted reply must point at a message of the same conversation
+	if req.ReplyToID != nil {
+		original, err := s.messageRepo.GetByID(ctx, *req.ReplyToID)
+		if err != nil || original.ConversationID != req.ConversationID {
+			return nil, services.ErrMessageReplyNotFound
+		}
 	}
 
 	// Convert MessageType string to enum
@@ -100,28 +132,47 @@ func (s *MessageServiceImpl) SendMessage(ctx context.Context, userID uuid.UUID,
 	// Create message
 	now := time.Now()
 	message := &models.Message{
-		ConversationID: req.ConversationID,
-		SenderID:       userID,
-		ReceiverID:     receiverID,
-		Type:           messageType,
-		Content:        req.Content,
-		Media:          mediaJSON,
-		IsRead:         false,
-		CreatedAt:      now,
-		UpdatedAt:      now,
-	}
-
-	if err := s.messageRepo.Create(ctx, message); err != nil {
+		ConversationID:   req.ConversationID,
+		SenderID:         userID,
+		ReceiverID:       receiverID,
+		Type:             messageType,
+		Content:          req.Content,
+		Media:            mediaJSON,
+		ReplyToMessageID: req.ReplyToID,
+		IsRead:           false,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if pendingRequest {
+		// Checked under a lock on the conversation so parallel sends cannot both become the one message
+		created, err := s.messageRepo.CreateFirstMessage(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+		if !created {
+			return nil, services.ErrConversationRequestPending
+		}
+	} else if err := s.messageRepo.Create(ctx, message); err != nil {
 		return nil, err
 	}
 
-	// Update conversation last message and increment unread count
+	// Update conversation last message
 	_ = s.conversationRepo.UpdateLastMessage(ctx, req.ConversationID, message.ID, now)
-	_ = s.conversationRepo.IncrementUnreadCount(ctx, req.ConversationID, receiverID)
 
-	// Increment unread counts in Redis
-	_ = s.redisService.IncrementTotalUnread(ctx, receiverID)
-	_ = s.redisService.IncrementConversationUnread(ctx, receiverID, req.ConversationID)
+	// Increment unread counts (message requests are counted separately, see GetUnreadCount)
+	if !pendingRequest {
+		_ = s.conversationRepo.IncrementUnreadCounts(ctx, req.ConversationID, userID)
+
+		// Increment unread counts in Redis
+		for _, participant := range conversation.Participants {
+			if participant.UserID == userID {
+				continue
+			}
+			_ = s.redisService.IncrementTotalUnread(ctx, participant.UserID)
+			_ = s.redisService.IncrementConversationUnread(ctx, participant.UserID, req.ConversationID)
+		}
+	}
 
 	// Cache last message in Redis
 	_ = s.redisService.CacheLastMessage(ctx, req.ConversationID, message.ID, message.SenderID, message.Content, string(message.Type), message.CreatedAt)
@@ -134,6 +185,7 @@ func (s *MessageServiceImpl) SendMessage(ctx context.Context, userID uuid.UUID,
 
 	// Convert to DTO
 	resp := dto.MessageToMessageResponse(fullMessage)
+	resp.Status = dto.MessageStatusSent
 	if req.TempID != nil {
 		resp.TempID = req.TempID
 	}
@@ -148,7 +200,7 @@ func (s *MessageServiceImpl) GetMessage(ctx context.Context, messageID uuid.UUID
 	}
 
 	// Check if user is participant
-	if message.SenderID != userID && message.ReceiverID != userID {
+	if _, err := s.conversationRepo.GetParticipant(ctx, message.ConversationID, userID); err != nil {
 		return nil, errors.New("access denied")
 	}
 
@@ -157,13 +209,9 @@ func (s *MessageServiceImpl) GetMessage(ctx context.Context, messageID uuid.UUID
 
 func (s *MessageServiceImpl) ListMessages(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, cursorStr *string, limit int) (*dto.MessageListResponse, error) {
 	// Verify user is participant
-	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+	conversation, err := s.verifyAccess(ctx, conversationID, userID, false)
 	if err != nil {
-		return nil, errors.New("conversation not found")
-	}
-
-	if conversation.User1ID != userID && conversation.User2ID != userID {
-		return nil, errors.New("access denied: not a participant")
+		return nil, err
 	}
 
 	// Decode cursor if provided
@@ -198,6 +246,7 @@ func (s *MessageServiceImpl) ListMessages(ctx context.Context, conversationID uu
 	for i, msg := range messages {
 		messageResponses[i] = *dto.MessageToMessageResponse(msg)
 	}
+	applyMessageStatus(messageResponses, conversation, userID)
 
 	// Generate next cursor
 	var nextCursor *string
@@ -224,7 +273,8 @@ func (s *MessageServiceImpl) GetMessageContext(ctx context.Context, messageID uu
 	}
 
 	// Verify user is participant
-	if targetMessage.SenderID != userID && targetMessage.ReceiverID != userID {
+	conversation, err := s.verifyAccess(ctx, targetMessage.ConversationID, userID, false)
+	if err != nil {
 		return nil, errors.New("access denied")
 	}
 
@@ -251,6 +301,11 @@ func (s *MessageServiceImpl) GetMessageContext(ctx context.Context, messageID uu
 		afterDTOs[i] = *dto.MessageToMessageResponse(msg)
 	}
 
+	targetDTO := []dto.MessageResponse{*dto.MessageToMessageResponse(targetMessage)}
+	applyMessageStatus(beforeDTOs, conversation, userID)
+	applyMessageStatus(targetDTO, conversation, userID)
+	applyMessageStatus(afterDTOs, conversation, userID)
+
 	// Generate cursors
 	var beforeCursor, afterCursor *string
 	if len(messagesBefore) > 0 {
@@ -267,7 +322,7 @@ func (s *MessageServiceImpl) GetMessageContext(ctx context.Context, messageID uu
 	}
 
 	return &dto.MessageContextResponse{
-		TargetMessage: *dto.MessageToMessageResponse(targetMessage),
+		TargetMessage: targetDTO[0],
 		Before:        beforeDTOs,
 		After:         afterDTOs,
 		BeforeCursor:  beforeCursor,
@@ -277,15 +332,300 @@ func (s *MessageServiceImpl) GetMessageContext(ctx context.Context, messageID uu
 	}, nil
 }
 
-func (s *MessageServiceImpl) MarkAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
-	// Verify user is participant
-	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+func (s *MessageServiceImpl) EditMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, req *dto.EditMessageRequest) (*dto.MessageResponse, error) {
+	if _, err := s.sanctionService.CheckWriteAccess(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	message, conversation, err := s.getOwnMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if time.Since(message.CreatedAt) > messageEditWindow {
+		return nil, services.ErrMessageEditWindowClosed
+	}
+
+	// Nothing changed, nothing to keep in the history
+	if message.Content != nil && *message.Content == req.Content {
+		return dto.MessageToMessageResponse(message), nil
+	}
+
+	now := time.Now()
+	revision := &models.MessageRevision{
+		MessageID: message.ID,
+		Content:   message.Content,
+		CreatedAt: now,
+	}
+	content := req.Content
+	message.Content = &content
+	message.EditedAt = &now
+	message.UpdatedAt = now
+
+	if err := s.messageRepo.UpdateContentWithRevision(ctx, message, revision); err != nil {
+		return nil, err
+	}
+	s.appendMessageEvent(ctx, message, models.ConversationEventMessageEdited)
+
+	// Keep the conversation preview in sync
+	if conversation.LastMessageID != nil && *conversation.LastMessageID == message.ID {
+		_ = s.redisService.CacheLastMessage(ctx, conversation.ID, message.ID, message.SenderID, message.Content, string(message.Type), message.CreatedAt)
+	}
+
+	return dto.MessageToMessageResponse(message), nil
+}
+
+func (s *MessageServiceImpl) UnsendMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.UnsendMessageResponse, error) {
+	message, conversation, err := s.getOwnMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if time.Since(message.CreatedAt) > messageUnsendWindow {
+		return nil, services.ErrMessageUnsendWindowClosed
+	}
+
+	// Revisions go with the message (ON DELETE CASCADE)
+	if err := s.messageRepo.Delete(ctx, message.ID); err != nil {
+		return nil, err
+	}
+	s.appendMessageEvent(ctx, message, models.ConversationEventMessageUnsent)
+
+	// Participants who had not read it yet get one unread less
+	unreadBy, err := s.conversationRepo.DecrementUnreadCounts(ctx, conversation.ID, userID, message.Seq)
+	if err == nil {
+		for _, participantID := range unreadBy {
+			_ = s.redisService.DecrementTotalUnread(ctx, participantID, 1)
+			_ = s.redisService.DecrementConversationUnread(ctx, participantID, conversation.ID)
+		}
+	}
+
+	resp := &dto.UnsendMessageResponse{
+		MessageID:      message.ID,
+		ConversationID: conversation.ID,
+	}
+
+	// The conversation's last message is now the one before it
+	if conversation.LastMessageID != nil && *conversation.LastMessageID == message.ID {
+		previous, err := s.messageRepo.GetLatestByConversation(ctx, conversation.ID)
+		if err != nil {
+			// No messages left (last_message_id was cleared by ON DELETE SET NULL)
+			_ = s.redisService.InvalidateLastMessage(ctx, conversation.ID)
+			return resp, nil
+		}
+
+		_ = s.conversationRepo.UpdateLastMessage(ctx, conversation.ID, previous.ID, previous.CreatedAt)
+		_ = s.redisService.CacheLastMessage(ctx, conversation.ID, previous.ID, previous.SenderID, previous.Content, string(previous.Type), previous.CreatedAt)
+
+		if fullMessage, err := s.messageRepo.GetByID(ctx, previous.ID); err == nil {
+			resp.LastMessage = dto.MessageToMessageResponse(fullMessage)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *MessageServiceImpl) ListMessageRevisions(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.MessageRevisionListResponse, error) {
+	message, err := s.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, services.ErrMessageNotFound
+	}
+
+	if _, err := s.conversationRepo.GetParticipant(ctx, message.ConversationID, userID); err != nil {
+		return nil, services.ErrConversationNotParticipant
+	}
+
+	revisions, err := s.messageRepo.ListRevisions(ctx, message.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.MessageRevisionListResponse{
+		MessageID: message.ID,
+		Revisions: make([]dto.MessageRevisionResponse, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		resp.Revisions = append(resp.Revisions, *dto.MessageRevisionToResponse(revision))
+	}
+
+	return resp, nil
+}
+
+func (s *MessageServiceImpl) GetMessageReceipts(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.MessageReceiptsResponse, error) {
+	message, err := s.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, services.ErrMessageNotFound
+	}
+
+	conversation, err := s.verifyAccess(ctx, message.ConversationID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like the "info" view of a sent message, receipts are for its sender
+	if message.SenderID != userID {
+		return nil, services.ErrMessageNotSender
+	}
+
+	resp := &dto.MessageReceiptsResponse{
+		MessageID: message.ID,
+		Seq:       message.Seq,
+		Receipts:  []dto.MessageReceiptResponse{},
+	}
+	for i := range conversation.Participants {
+		participant := &conversation.Participants[i]
+		if participant.UserID == message.SenderID {
+			continue
+		}
+
+		status := dto.MessageStatusSent
+		if participant.HasRead(message.Seq) {
+			status = dto.MessageStatusRead
+		} else if participant.HasReceived(message.Seq) {
+			status = dto.MessageStatusDelivered
+		}
+		resp.Receipts = append(resp.Receipts, dto.MessageReceiptResponse{
+			User:   *dto.UserToUserResponse(&participant.User),
+			Status: status,
+		})
+	}
+
+	return resp, nil
+}
+
+// appendMessageEvent logs an edit or unsend for sync (a missing event only costs other devices a refresh)
+func (s *MessageServiceImpl) appendMessageEvent(ctx context.Context, message *models.Message, eventType string) {
+	event := &models.ConversationEvent{
+		ConversationID: message.ConversationID,
+		Type:           eventType,
+		MessageID:      &message.ID,
+		UserID:         message.SenderID,
+	}
+	if err := s.conversationRepo.AppendEvent(ctx, event); err != nil {
+		log.Printf("⚠️  Failed to log %s for message %s: %v", eventType, message.ID, err)
+	}
+}
+
+// applyMessageStatus fills in the delivered/read status of the viewer's own messages
+func applyMessageStatus(messages []dto.MessageResponse, conversation *models.Conversation, viewerID uuid.UUID) {
+	for i := range messages {
+		if messages[i].SenderId == viewerID {
+			messages[i].Status = dto.MessageStatus(messages[i].Seq, viewerID, conversation.Participants)
+		}
+	}
+}
+
+// getOwnMessage loads a message the user sent (and its conversation) for editing or unsending
+func (s *MessageServiceImpl) getOwnMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*models.Message, *models.Conversation, error) {
+	message, err := s.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, nil, services.ErrMessageNotFound
+	}
+
+	conversation, err := s.verifyAccess(ctx, message.ConversationID, userID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if message.SenderID != userID {
+		return nil, nil, services.ErrMessageNotSender
+	}
+
+	// Gifts are paid for, they stay as sent; removed messages stay removed
+	if message.Type == models.MessageTypeGift || message.RemovedAt != nil {
+		return nil, nil, services.ErrMessageNotEditable
+	}
+
+	return message, conversation, nil
+}
+
+// GetQuotedMessageContext jumps from a reply to the message it quotes
+func (s *MessageServiceImpl) GetQuotedMessageContext(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.MessageContextResponse, error) {
+	reply, err := s.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		return nil, services.ErrMessageNotFound
+	}
+
+	if _, err := s.conversationRepo.GetParticipant(ctx, reply.ConversationID, userID); err != nil {
+		return nil, services.ErrConversationNotParticipant
+	}
+
+	// Not a reply, or the original was unsent
+	if reply.ReplyToMessageID == nil {
+		return nil, services.ErrMessageReplyNotFound
+	}
+
+	return s.GetMessageContext(ctx, *reply.ReplyToMessageID, userID)
+}
+
+func (s *MessageServiceImpl) AddReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) (*dto.MessageReactionsResponse, error) {
+	if !models.IsMessageReactionEmoji(emoji) {
+		return nil, services.ErrReactionEmojiNotAllowed
+	}
+
+	if _, err := s.sanctionService.CheckWriteAccess(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	message, err := s.getReactableMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adding the same emoji twice is a no-op
+	if _, err := s.messageRepo.AddReaction(ctx, &models.MessageReaction{
+		MessageID: message.ID,
+		UserID:    userID,
+		Emoji:     emoji,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.reactionsResponse(ctx, message)
+}
+
+func (s *MessageServiceImpl) RemoveReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) (*dto.MessageReactionsResponse, error) {
+	message, err := s.getReactableMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.messageRepo.RemoveReaction(ctx, message.ID, userID, emoji); err != nil {
+		return nil, err
+	}
+
+	return s.reactionsResponse(ctx, message)
+}
+
+// getReactableMessage loads a message of a conversation the user can still write to
+func (s *MessageServiceImpl) getReactableMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*models.Message, error) {
+	message, err := s.messageRepo.GetByID(ctx, messageID)
 	if err != nil {
-		return errors.New("conversation not found")
+		return nil, services.ErrMessageNotFound
 	}
 
-	if conversation.User1ID != userID && conversation.User2ID != userID {
-		return errors.New("access denied: not a participant")
+	if _, err := s.verifyAccess(ctx, message.ConversationID, userID, true); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func (s *MessageServiceImpl) reactionsResponse(ctx context.Context, message *models.Message) (*dto.MessageReactionsResponse, error) {
+	reactions, err := s.messageRepo.ListReactions(ctx, message.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MessageReactionsResponse{
+		MessageID:      message.ID,
+		ConversationID: message.ConversationID,
+		Reactions:      dto.MessageReactionsToReactionInfos(reactions),
+	}, nil
+}
+
+func (s *MessageServiceImpl) MarkAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
+	// Verify user is participant
+	if _, err := s.verifyAccess(ctx, conversationID, userID, false); err != nil {
+		return err
 	}
 
 	// Mark all messages as read
@@ -297,27 +637,96 @@ func (s *MessageServiceImpl) MarkAsRead(ctx context.Context, conversationID uuid
 	return s.conversationRepo.ResetUnreadCount(ctx, conversationID, userID)
 }
 
-// Phase 2: Media/Links/Files Queries
+func (s *MessageServiceImpl) SearchConversationMessages(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, query string, cursor *string, limit int) (*dto.MessageSearchResponse, error) {
+	query, err := normalizeSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
 
-func (s *MessageServiceImpl) ListMediaMessages(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, mediaType *string, cursor *string, limit int) (*dto.MessageListResponse, error) {
-	// Verify access
-	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+	if _, err := s.verifyAccess(ctx, conversationID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return s.searchMessages(query, cursor, limit, func(beforeCursor *time.Time, beforeID uuid.UUID, limit int) ([]*models.Message, error) {
+		return s.messageRepo.SearchInConversation(ctx, conversationID, query, beforeCursor, beforeID, limit)
+	})
+}
+
+func (s *MessageServiceImpl) SearchMessages(ctx context.Context, userID uuid.UUID, query string, cursor *string, limit int) (*dto.MessageSearchResponse, error) {
+	query, err := normalizeSearchQuery(query)
 	if err != nil {
-		return nil, errors.New("conversation not found")
+		return nil, err
 	}
 
-	if conversation.User1ID != userID && conversation.User2ID != userID {
-		return nil, errors.New("access denied: not a participant")
+	return s.searchMessages(query, cursor, limit, func(beforeCursor *time.Time, beforeID uuid.UUID, limit int) ([]*models.Message, error) {
+		return s.messageRepo.SearchByParticipant(ctx, userID, query, beforeCursor, beforeID, limit)
+	})
+}
+
+// searchMessages pages through the matches (cursor is the created_at and id of the last result)
+func (s *MessageServiceImpl) searchMessages(query string, cursor *string, limit int, search func(beforeCursor *time.Time, beforeID uuid.UUID, limit int) ([]*models.Message, error)) (*dto.MessageSearchResponse, error) {
+	var beforeCursor *time.Time
+	beforeID := uuid.Nil
+	if cursor != nil && *cursor != "" {
+		decoded, id, err := utils.DecodeCursorWithID(*cursor)
+		if err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		beforeCursor, beforeID = decoded, id
 	}
 
-	// Check if blocked
-	isBlocked, err := s.blockRepo.IsBlocked(ctx, userID, conversation.User1ID)
-	if err == nil && isBlocked {
-		return nil, errors.New("access denied: blocked")
+	if limit <= 0 || limit > 50 {
+		limit = 20
 	}
-	isBlocked, err = s.blockRepo.IsBlocked(ctx, userID, conversation.User2ID)
-	if err == nil && isBlocked {
-		return nil, errors.New("access denied: blocked")
+
+	// Fetch limit + 1 to check for more
+	messages, err := search(beforeCursor, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+
+	results := make([]dto.MessageSearchResult, len(messages))
+	for i, msg := range messages {
+		results[i] = dto.MessageToSearchResult(msg, query)
+	}
+
+	var nextCursor *string
+	if hasMore && len(messages) > 0 {
+		last := messages[len(messages)-1]
+		encoded, err := utils.EncodeCursorWithID(last.CreatedAt, last.ID)
+		if err == nil {
+			nextCursor = &encoded
+		}
+	}
+
+	return &dto.MessageSearchResponse{
+		Query:      query,
+		Results:    results,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+// normalizeSearchQuery trims the query and checks its length (in characters)
+func normalizeSearchQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if length := utf8.RuneCountInString(query); length < 2 || length > 100 {
+		return "", services.ErrMessageSearchQueryInvalid
+	}
+	return query, nil
+}
+
+// Phase 2: Media/Links/Files Queries
+
+func (s *MessageServiceImpl) ListMediaMessages(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, mediaType *string, cursor *string, limit int) (*dto.MessageListResponse, error) {
+	// Verify access
+	if _, err := s.verifyAccess(ctx, conversationID, userID, true); err != nil {
+		return nil, err
 	}
 
 	// Parse cursor
@@ -359,23 +768,8 @@ func (s *MessageServiceImpl) ListMediaMessages(ctx context.Context, conversation
 
 func (s *MessageServiceImpl) ListMessagesWithLinks(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, cursor *string, limit int) (*dto.MessageListResponse, error) {
 	// Verify access
-	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
-	if err != nil {
-		return nil, errors.New("conversation not found")
-	}
-
-	if conversation.User1ID != userID && conversation.User2ID != userID {
-		return nil, errors.New("access denied: not a participant")
-	}
-
-	// Check if blocked
-	isBlocked, err := s.blockRepo.IsBlocked(ctx, userID, conversation.User1ID)
-	if err == nil && isBlocked {
-		return nil, errors.New("access denied: blocked")
-	}
-	isBlocked, err = s.blockRepo.IsBlocked(ctx, userID, conversation.User2ID)
-	if err == nil && isBlocked {
-		return nil, errors.New("access denied: blocked")
+	if _, err := s.verifyAccess(ctx, conversationID, userID, true); err != nil {
+		return nil, err
 	}
 
 	// Parse cursor
@@ -417,23 +811,8 @@ func (s *MessageServiceImpl) ListMessagesWithLinks(ctx context.Context, conversa
 
 func (s *MessageServiceImpl) ListFileMessages(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, cursor *string, limit int) (*dto.MessageListResponse, error) {
 	// Verify access
-	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
-	if err != nil {
-		return nil, errors.New("conversation not found")
-	}
-
-	if conversation.User1ID != userID && conversation.User2ID != userID {
-		return nil, errors.New("access denied: not a participant")
-	}
-
-	// Check if blocked
-	isBlocked, err := s.blockRepo.IsBlocked(ctx, userID, conversation.User1ID)
-	if err == nil && isBlocked {
-		return nil, errors.New("access denied: blocked")
-	}
-	isBlocked, err = s.blockRepo.IsBlocked(ctx, userID, conversation.User2ID)
-	if err == nil && isBlocked {
-		return nil, errors.New("access denied: blocked")
+	if _, err := s.verifyAccess(ctx, conversationID, userID, true); err != nil {
+		return nil, err
 	}
 
 	// Parse cursor
@@ -473,6 +852,28 @@ func (s *MessageServiceImpl) ListFileMessages(ctx context.Context, conversationI
 	}, nil
 }
 
+// verifyAccess loads the conversation and checks that the user is a participant
+// (checkBlock also refuses direct conversations where the user blocked the other participant)
+func (s *MessageServiceImpl) verifyAccess(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, checkBlock bool) (*models.Conversation, error) {
+	conversation, err := s.conversationRepo.GetByID(ctx, conversationID)
+	if err != nil {
+		return nil, services.ErrConversationNotFound
+	}
+
+	if findParticipant(conversation, userID) == nil {
+		return nil, services.ErrConversationNotParticipant
+	}
+
+	if checkBlock && !conversation.IsGroup() {
+		isBlocked, err := s.blockRepo.IsBlocked(ctx, userID, conversation.OtherUserID(userID))
+		if err == nil && isBlocked {
+			return nil, errors.New("access denied: blocked")
+		}
+	}
+
+	return conversation, nil
+}
+
 // UpdateMessageVideoStatus updates video encoding fields in message.media JSONB
 // NOTE: DEPRECATED - No longer used as we migrated from Bunny Stream to R2
 // Videos are now uploaded directly to R2 and don't require encoding status updates
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/mail"
 	"gofiber-template/infrastructure/websocket"
 	"gofiber-template/pkg/utils"
 )
@@ -20,6 +22,8 @@ type NotificationServiceImpl struct {
 	notifSettingsRepo repositories.NotificationSettingsRepository
 	userRepo          repositories.UserRepository
 	pushService       services.PushService
+	mailer            mail.Mailer
+	frontendURL       string
 }
 
 func NewNotificationService(
@@ -32,6 +36,7 @@ func NewNotificationService(
 		notifSettingsRepo: notifSettingsRepo,
 		userRepo:          userRepo,
 		pushService:       nil, // Will be set later via SetPushService
+		mailer:            nil, // Will be set later via SetMailer
 	}
 }
 
@@ -40,6 +45,12 @@ func (s *NotificationServiceImpl) SetPushService(pushService services.PushServic
 	s.pushService = pushService
 }
 
+// SetMailer enables email delivery for users with EmailNotifications turned on
+func (s *NotificationServiceImpl) SetMailer(mailer mail.Mailer, frontendURL string) {
+	s.mailer = mailer
+	s.frontendURL = strings.TrimRight(frontendURL, "/")
+}
+
 func (s *NotificationServiceImpl) GetNotifications(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.NotificationListResponse, error) {
 	notifications, err := s.notifRepo.ListByUser(ctx, userID, offset, limit)
 	if err != nil {
@@ -301,9 +312,35 @@ func (s *NotificationServiceImpl) CreateNotification(ctx context.Context, userID
 		}()
 	}
 
+	// Send email notification (non-blocking, opt-in per user)
+	if s.mailer != nil {
+		go s.sendEmailNotification(userID, message, s.buildNotificationURL(postID, commentID))
+	}
+
 	return nil
 }
 
+// sendEmailNotification mails the notification if the user opted in and has a verified address
+func (s *NotificationServiceImpl) sendEmailNotification(userID uuid.UUID, message string, path string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	settings, err := s.notifSettingsRepo.GetByUserID(ctx, userID)
+	if err != nil || !settings.EmailNotifications {
+		return // no settings row means defaults (email off)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || !user.IsActive || !user.EmailVerified {
+		return
+	}
+
+	msg := notificationEmail(user.Email, user.DisplayName, message, s.frontendURL+path)
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		log.Printf("⚠️  Failed to send email notification: %v", err)
+	}
+}
+
 // Helper function to get unread count
 func (s *NotificationServiceImpl) getUnreadCount(ctx context.Context, userID uuid.UUID) int64 {
 	count, err := s.notifRepo.CountUnreadByUser(ctx, userID)
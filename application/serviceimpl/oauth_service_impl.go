@@ -2,202 +2,399 @@ package serviceimpl
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/oauth"
+	"gofiber-template/infrastructure/redis"
 	"gofiber-template/pkg/config"
 	"gofiber-template/pkg/utils"
 	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
+	"gorm.io/gorm"
 )
 
-type OAuthServiceImpl struct {
-	userRepo     repositories.UserRepository
-	config       *config.Config
-	googleConfig *oauth2.Config
+const oauthStateTTL = 10 * time.Minute // time allowed on the provider consent page
+
+// oauthState - Kept in Redis between the redirect and the callback
+type oauthState struct {
+	Provider     string     `json:"provider"`
+	Nonce        string     `json:"nonce"`
+	CodeVerifier string     `json:"codeVerifier"`
+	LinkUserID   *uuid.UUID `json:"linkUserId,omitempty"` // set when a signed-in user links the provider
 }
 
-func NewOAuthService(userRepo repositories.UserRepository, cfg *config.Config) services.OAuthService {
-	googleConfig := &oauth2.Config{
-		ClientID:     cfg.OAuth.Google.ClientID,
-		ClientSecret: cfg.OAuth.Google.ClientSecret,
-		RedirectURL:  cfg.OAuth.Google.RedirectURL,
-		Scopes: []string{
-			"https://www.googleapis.com/auth/userinfo.email",
-			"https://www.googleapis.com/auth/userinfo.profile",
-		},
-		Endpoint: google.Endpoint,
-	}
+type OAuthServiceImpl struct {
+	providers        *oauth.Registry
+	identityRepo     repositories.UserIdentityRepository
+	userRepo         repositories.UserRepository
+	sessionService   services.SessionService
+	twoFactorService services.TwoFactorService
+	redisService     *redis.RedisService
+	config           *config.Config
+}
 
+func NewOAuthService(
+	providers *oauth.Registry,
+	identityRepo repositories.UserIdentityRepository,
+	userRepo repositories.UserRepository,
+	sessionService services.SessionService,
+	twoFactorService services.TwoFactorService,
+	redisService *redis.RedisService,
+	cfg *config.Config,
+) services.OAuthService {
 	return &OAuthServiceImpl{
-		userRepo:     userRepo,
-		config:       cfg,
-		googleConfig: googleConfig,
+		providers:        providers,
+		identityRepo:     identityRepo,
+		userRepo:         userRepo,
+		sessionService:   sessionService,
+		twoFactorService: twoFactorService,
+		redisService:     redisService,
+		config:           cfg,
 	}
 }
 
-func (s *OAuthServiceImpl) GetGoogleAuthURL(state string) string {
-	return s.googleConfig.AuthCodeURL(state, oauth2.AccessTypeOffline)
+func (s *OAuthServiceImpl) ListProviders() []dto.OAuthProviderResponse {
+	providers := s.providers.List()
+	responses := make([]dto.OAuthProviderResponse, 0, len(providers))
+	for _, p := range providers {
+		responses = append(responses, dto.OAuthProviderResponse{
+			Name:        p.Name(),
+			DisplayName: p.DisplayName(),
+		})
+	}
+	return responses
 }
 
-func (s *OAuthServiceImpl) HandleGoogleCallback(ctx context.Context, code string) (*dto.OAuthLoginResponse, error) {
-	// Debug logging
-	fmt.Printf("\n=== OAuth Service Debug ===\n")
-	fmt.Printf("Exchanging code: %s\n", code)
-	fmt.Printf("Code length: %d\n", len(code))
-	fmt.Printf("Client ID: %s\n", s.googleConfig.ClientID)
-	fmt.Printf("Redirect URL: %s\n", s.googleConfig.RedirectURL)
-	fmt.Printf("===========================\n\n")
+func (s *OAuthServiceImpl) GetAuthURL(ctx context.Context, providerName string, linkUserID *uuid.UUID) (string, string, error) {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return "", "", services.ErrOAuthProviderNotFound
+	}
 
-	// Exchange code for token
-	token, err := s.googleConfig.Exchange(ctx, code)
+	stateValue, err := randomOAuthValue()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomOAuthValue()
 	if err != nil {
-		fmt.Printf("ERROR: Failed to exchange code: %v\n", err)
-		return nil, fmt.Errorf("failed to exchange code: %w", err)
+		return "", "", err
+	}
+	req := oauth.AuthRequest{
+		State:        stateValue,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
 	}
 
-	// Get user info from Google
-	userInfo, err := s.GetUserInfoFromGoogle(ctx, token.AccessToken)
+	authURL, err := provider.AuthCodeURL(ctx, req)
 	if err != nil {
-		return nil, err
+		return "", "", fmt.Errorf("failed to build %s login URL: %w", providerName, err)
 	}
 
-	// Check if user already exists by OAuth ID
-	existingUser, err := s.userRepo.GetByOAuth(ctx, "google", userInfo.OAuthID)
-	if err == nil && existingUser != nil {
-		// User exists - login
-		jwtToken, err := utils.GenerateToken(existingUser.ID, s.config.JWT.Secret)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate JWT: %w", err)
-		}
+	state := oauthState{
+		Provider:     providerName,
+		Nonce:        req.Nonce,
+		CodeVerifier: req.CodeVerifier,
+		LinkUserID:   linkUserID,
+	}
+	if err := s.redisService.SaveOAuthState(ctx, stateValue, state, oauthStateTTL); err != nil {
+		return "", "", fmt.Errorf("failed to save login state: %w", err)
+	}
 
-		return &dto.OAuthLoginResponse{
-			Token:        jwtToken,
-			User:         *dto.UserToUserResponse(existingUser),
-			IsNewUser:    false,
-			NeedsProfile: false,
-		}, nil
+	return authURL, stateValue, nil
+}
+
+func (s *OAuthServiceImpl) HandleCallback(ctx context.Context, providerName, code, stateValue string, client dto.SessionClient) (*dto.OAuthCallbackResult, error) {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return nil, services.ErrOAuthProviderNotFound
 	}
 
-	// Check if email already exists (user registered with email/password)
-	existingEmailUser, err := s.userRepo.GetByEmail(ctx, userInfo.Email)
-	if err == nil && existingEmailUser != nil {
-		// Email exists but not linked to Google
-		// Link Google account to existing user
-		existingEmailUser.OAuthProvider = "google"
-		existingEmailUser.OAuthID = userInfo.OAuthID
-		existingEmailUser.IsOAuthUser = true
-		existingEmailUser.UpdatedAt = time.Now()
+	// The state is single-use: a replayed callback finds nothing
+	data, err := s.redisService.TakeOAuthState(ctx, stateValue)
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, services.ErrOAuthInvalidState
+		}
+		return nil, fmt.Errorf("failed to read login state: %w", err)
+	}
+	var state oauthState
+	if err := json.Unmarshal(data, &state); err != nil || state.Provider != providerName {
+		return nil, services.ErrOAuthInvalidState
+	}
+
+	profile, err := provider.Exchange(ctx, code, oauth.AuthRequest{
+		State:        stateValue,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s login failed: %w", providerName, err)
+	}
 
-		if err := s.userRepo.Update(ctx, existingEmailUser.ID, existingEmailUser); err != nil {
-			return nil, fmt.Errorf("failed to link Google account: %w", err)
+	if state.LinkUserID != nil {
+		linked, err := s.linkIdentity(ctx, *state.LinkUserID, profile)
+		if err != nil {
+			return nil, err
 		}
+		return &dto.OAuthCallbackResult{Linked: linked}, nil
+	}
+
+	login, err := s.login(ctx, profile, client)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.OAuthCallbackResult{Login: login}, nil
+}
 
-		jwtToken, err := utils.GenerateToken(existingEmailUser.ID, s.config.JWT.Secret)
+// login signs in the owner of the identity, links it by verified email, or registers a new user
+func (s *OAuthServiceImpl) login(ctx context.Context, profile *oauth.Profile, client dto.SessionClient) (*dto.OAuthLoginResponse, error) {
+	// 1. Known identity
+	identity, err := s.identityRepo.GetByProviderSubject(ctx, profile.Provider, profile.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if identity != nil {
+		user, err := s.userRepo.GetByID(ctx, identity.UserID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate JWT: %w", err)
+			return nil, err
 		}
+		if err := s.identityRepo.TouchLastLogin(ctx, identity.ID); err != nil {
+			log.Printf("Warning: failed to record %s login for user %s: %v", profile.Provider, user.ID, err)
+		}
+		return s.createLoginSession(ctx, user, false, client)
+	}
 
-		return &dto.OAuthLoginResponse{
-			Token:        jwtToken,
-			User:         *dto.UserToUserResponse(existingEmailUser),
-			IsNewUser:    false,
-			NeedsProfile: false,
-		}, nil
+	if profile.Email == "" {
+		return nil, services.ErrOAuthEmailRequired
+	}
+
+	// 2. Existing account with the same address: only linked when the provider verified it
+	existingUser, err := s.userRepo.GetByEmail(ctx, profile.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if existingUser != nil {
+		if !profile.EmailVerified {
+			return nil, services.ErrOAuthEmailInUse
+		}
+		if _, err := s.createIdentity(ctx, existingUser, profile); err != nil {
+			if errors.Is(err, services.ErrIdentityAlreadyLinked) {
+				return nil, services.ErrOAuthEmailInUse
+			}
+			return nil, err
+		}
+		return s.createLoginSession(ctx, existingUser, false, client)
 	}
 
-	// Create new user
-	username, err := s.generateUniqueUsername(ctx, userInfo.Email, userInfo.Name)
+	// 3. New account
+	username, err := s.generateUniqueUsername(ctx, profile.Email, profile.Name)
 	if err != nil {
 		return nil, err
 	}
+	displayName := profile.Name
+	if displayName == "" {
+		displayName = username
+	}
 
+	now := time.Now()
 	newUser := &models.User{
 		ID:            uuid.New(),
-		Email:         userInfo.Email,
+		Email:         profile.Email,
 		Username:      username,
-		DisplayName:   userInfo.Name,
-		Avatar:        userInfo.Picture,
-		OAuthProvider: "google",
-		OAuthID:       userInfo.OAuthID,
+		DisplayName:   displayName,
+		Avatar:        profile.Picture,
 		IsOAuthUser:   true,
-		Role:          "user",
 		IsActive:      true,
+		EmailVerified: profile.EmailVerified,
 		Karma:         0,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if profile.EmailVerified {
+		newUser.EmailVerifiedAt = &now
 	}
 
 	if err := s.userRepo.Create(ctx, newUser); err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	if _, err := s.createIdentity(ctx, newUser, profile); err != nil {
+		// Do not leave an account nobody can sign in to
+		if delErr := s.userRepo.Delete(ctx, newUser.ID); delErr != nil {
+			log.Printf("Warning: failed to remove user %s after identity error: %v", newUser.ID, delErr)
+		}
+		return nil, err
+	}
+
+	return s.createLoginSession(ctx, newUser, true, client)
+}
+
+// linkIdentity attaches the provider account to a signed-in user
+func (s *OAuthServiceImpl) linkIdentity(ctx context.Context, userID uuid.UUID, profile *oauth.Profile) (*dto.UserIdentityResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.createIdentity(ctx, user, profile)
+	if err != nil {
+		return nil, err
+	}
+	return dto.UserIdentityToResponse(identity), nil
+}
+
+// createIdentity links the profile to the user (the provider email also verifies a matching account address)
+func (s *OAuthServiceImpl) createIdentity(ctx context.Context, user *models.User, profile *oauth.Profile) (*models.UserIdentity, error) {
+	existing, err := s.identityRepo.GetByProviderSubject(ctx, profile.Provider, profile.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.UserID != user.ID {
+			return nil, services.ErrIdentityLinkedElsewhere
+		}
+		return existing, nil
+	}
+
+	identities, err := s.identityRepo.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, linked := range identities {
+		if linked.Provider == profile.Provider {
+			return nil, services.ErrIdentityAlreadyLinked
+		}
+	}
+
+	identity := &models.UserIdentity{
+		UserID:   user.ID,
+		Provider: profile.Provider,
+		Subject:  profile.Subject,
+		Email:    profile.Email,
+	}
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		return nil, fmt.Errorf("failed to link %s account: %w", profile.Provider, err)
+	}
+
+	if !user.EmailVerified && profile.EmailVerified && profile.Email == user.Email {
+		now := time.Now()
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
+			log.Printf("Warning: failed to mark email verified for user %s: %v", user.ID, err)
+		}
+	}
+
+	return identity, nil
+}
+
+func (s *OAuthServiceImpl) createLoginSession(ctx context.Context, user *models.User, isNewUser bool, client dto.SessionClient) (*dto.OAuthLoginResponse, error) {
+	if !user.IsActive {
+		return nil, errors.New("account is disabled")
+	}
+	if err := loginRestriction(user); err != nil {
+		return nil, err
+	}
+
+	// Second factor: same as password login, no tokens until the challenge is exchanged with a valid code
+	if user.TwoFactorEnabled {
+		challenge, err := s.twoFactorService.CreateChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.OAuthLoginResponse{
+			User:      *dto.UserToUserResponse(user),
+			IsNewUser: isNewUser,
+			MFA:       challenge,
+		}, nil
+	}
 
-	jwtToken, err := utils.GenerateToken(newUser.ID, s.config.JWT.Secret)
+	tokens, err := s.sessionService.CreateSession(ctx, user, client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
 	return &dto.OAuthLoginResponse{
-		Token:        jwtToken,
-		User:         *dto.UserToUserResponse(newUser),
-		IsNewUser:    true,
-		NeedsProfile: false, // Google provides all necessary info
+		AuthTokens:   *tokens,
+		User:         *dto.UserToUserResponse(user),
+		IsNewUser:    isNewUser,
+		NeedsProfile: false,
 	}, nil
 }
 
-func (s *OAuthServiceImpl) GetUserInfoFromGoogle(ctx context.Context, accessToken string) (*dto.OAuthUserInfo, error) {
-	// Get user info from Google
-	resp, err := oauth2.NewClient(ctx, oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: accessToken},
-	)).Get("https://www.googleapis.com/oauth2/v2/userinfo")
-
+func (s *OAuthServiceImpl) ListIdentities(ctx context.Context, userID uuid.UUID) (*dto.UserIdentityListResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user info: %w", err)
+		return nil, err
+	}
+	identities, err := s.identityRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	responses := make([]dto.UserIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		responses = append(responses, *dto.UserIdentityToResponse(identity))
+	}
+
+	return &dto.UserIdentityListResponse{
+		Identities:  responses,
+		HasPassword: user.Password != "",
+	}, nil
+}
+
+func (s *OAuthServiceImpl) UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	identities, err := s.identityRepo.ListByUser(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return err
 	}
 
-	var googleUser struct {
-		ID            string `json:"id"`
-		Email         string `json:"email"`
-		VerifiedEmail bool   `json:"verified_email"`
-		Name          string `json:"name"`
-		GivenName     string `json:"given_name"`
-		FamilyName    string `json:"family_name"`
-		Picture       string `json:"picture"`
-		Locale        string `json:"locale"`
+	found := false
+	for _, identity := range identities {
+		if identity.Provider == provider {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return services.ErrIdentityNotFound
 	}
 
-	if err := json.Unmarshal(body, &googleUser); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal user info: %w", err)
+	// The account must keep at least one way to sign in
+	if user.Password == "" && len(identities) == 1 {
+		return services.ErrLastLoginMethod
 	}
 
-	if googleUser.Email == "" {
-		return nil, errors.New("email not provided by Google")
+	if err := s.identityRepo.Delete(ctx, userID, provider); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return services.ErrIdentityNotFound
+		}
+		return err
 	}
+	return nil
+}
 
-	return &dto.OAuthUserInfo{
-		Provider:   "google",
-		OAuthID:    googleUser.ID,
-		Email:      googleUser.Email,
-		Name:       googleUser.Name,
-		Picture:    googleUser.Picture,
-		GivenName:  googleUser.GivenName,
-		FamilyName: googleUser.FamilyName,
-		Verified:   googleUser.VerifiedEmail,
-	}, nil
+// randomOAuthValue returns an unguessable URL-safe value for state and nonce parameters
+func randomOAuthValue() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
 // generateUniqueUsername generates a unique username from email or name
@@ -243,3 +440,6 @@ func (s *OAuthServiceImpl) generateUniqueUsername(ctx context.Context, email, na
 	// Generate random username as last resort
 	return fmt.Sprintf("user_%s", uuid.New().String()[:8]), nil
 }
+
+// Ensure interface compliance
+var _ services.OAuthService = (*OAuthServiceImpl)(nil)
@@ -0,0 +1,612 @@
+package serviceimpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/payment"
+	"gofiber-template/pkg/database"
+)
+
+const (
+	payoutReferenceType = "payout"
+	payoutMinAmount     = 50000 // 500 THB
+	payoutFeePercent    = 3     // platform fee deducted from every payout
+	payoutExportBatch   = 500
+)
+
+type PayoutServiceImpl struct {
+	txManager     *database.TransactionManager
+	payoutRepo    repositories.PayoutRepository
+	userRepo      repositories.UserRepository
+	walletService services.WalletService
+	notifService  services.NotificationService
+}
+
+func NewPayoutService(
+	txManager *database.TransactionManager,
+	payoutRepo repositories.PayoutRepository,
+	userRepo repositories.UserRepository,
+	walletService services.WalletService,
+	notifService services.NotificationService,
+) services.PayoutService {
+	return &PayoutServiceImpl{
+		txManager:     txManager,
+		payoutRepo:    payoutRepo,
+		userRepo:      userRepo,
+		walletService: walletService,
+		notifService:  notifService,
+	}
+}
+
+// ==================== Creator side ====================
+
+func (s *PayoutServiceImpl) GetPayoutSummary(ctx context.Context, userID uuid.UUID) (*dto.PayoutSummaryResponse, error) {
+	wallet, err := s.walletService.GetOrCreateUserWallet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawable, err := s.withdrawableAmount(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.PayoutSummaryResponse{
+		AvailableBalance:   wallet.AvailableBalance(),
+		WithdrawableAmount: withdrawable,
+		MinAmount:          payoutMinAmount,
+		FeePercent:         payoutFeePercent,
+	}
+	if pending, err := s.payoutRepo.GetPendingByUser(ctx, userID); err == nil {
+		resp.PendingPayoutID = &pending.ID
+	}
+	return resp, nil
+}
+
+func (s *PayoutServiceImpl) RequestPayout(ctx context.Context, userID uuid.UUID, req *dto.RequestPayoutRequest) (*dto.PayoutResponse, error) {
+	if err := s.requireTwoFactor(ctx, userID); err != nil {
+		return nil, err
+	}
+	if req.Amount < payoutMinAmount {
+		return nil, services.ErrPayoutBelowMinimum
+	}
+	accountNumber, err := normalizePayoutAccount(req.DestinationType, req.AccountNumber)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.payoutRepo.GetPendingByUser(ctx, userID); err == nil {
+		return nil, services.ErrPayoutAlreadyPending
+	}
+
+	wallet, err := s.walletService.GetOrCreateUserWallet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	withdrawable, err := s.withdrawableAmount(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+	if req.Amount > withdrawable {
+		return nil, services.ErrPayoutExceedsEarnings
+	}
+
+	fee := req.Amount * payoutFeePercent / 100
+	payout := &models.Payout{
+		ID:              uuid.New(),
+		UserID:          userID,
+		Amount:          req.Amount,
+		Fee:             fee,
+		NetAmount:       req.Amount - fee,
+		DestinationType: req.DestinationType,
+		AccountNumber:   accountNumber,
+		AccountName:     strings.TrimSpace(req.AccountName),
+		Status:          models.PayoutStatusPending,
+	}
+	if req.DestinationType == models.PayoutDestinationBankAccount {
+		bankCode := req.BankCode
+		payout.BankCode = &bankCode
+	}
+
+	// Reserve the amount until an admin reviews the request.
+	// Hold and payout commit together, so losing the one-pending-payout race leaves no stray hold.
+	refType := payoutReferenceType
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		hold, err := s.walletService.PlaceHold(ctx, &dto.WalletHoldRequest{
+			IdempotencyKey: "payout_hold:" + payout.ID.String(),
+			WalletID:       wallet.ID,
+			Amount:         payout.Amount,
+			Reason:         "Payout request",
+			ReferenceType:  &refType,
+			ReferenceID:    &payout.ID,
+		})
+		if err != nil {
+			return err
+		}
+		payout.HoldID = &hold.ID
+
+		return s.payoutRepo.Create(ctx, payout)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PayoutToPayoutResponse(payout), nil
+}
+
+func (s *PayoutServiceImpl) GetMyPayout(ctx context.Context, payoutID uuid.UUID, userID uuid.UUID) (*dto.PayoutResponse, error) {
+	payout, err := s.getOwnPayout(ctx, payoutID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.PayoutToPayoutResponse(payout), nil
+}
+
+func (s *PayoutServiceImpl) ListMyPayouts(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.PayoutListResponse, error) {
+	payouts, err := s.payoutRepo.ListByUser(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.payoutRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPayoutListResponse(payouts, total, offset, limit), nil
+}
+
+func (s *PayoutServiceImpl) CancelPayout(ctx context.Context, payoutID uuid.UUID, userID uuid.UUID) (*dto.PayoutResponse, error) {
+	payout, err := s.getOwnPayout(ctx, payoutID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if payout.Status != models.PayoutStatusPending {
+		return nil, services.ErrPayoutInvalidStatus
+	}
+
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.releasePayoutHold(ctx, payout); err != nil {
+			return err
+		}
+		payout.Status = models.PayoutStatusCancelled
+		return s.transitionPayout(ctx, payout, models.PayoutStatusPending)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.PayoutToPayoutResponse(payout), nil
+}
+
+// ==================== Admin side ====================
+
+func (s *PayoutServiceImpl) ListPayouts(ctx context.Context, status string, offset, limit int) (*dto.PayoutListResponse, error) {
+	if status == "" {
+		status = models.PayoutStatusPending
+	}
+
+	payouts, err := s.payoutRepo.ListByStatus(ctx, status, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.payoutRepo.CountByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPayoutListResponse(payouts, total, offset, limit), nil
+}
+
+func (s *PayoutServiceImpl) ApprovePayout(ctx context.Context, payoutID uuid.UUID, adminID uuid.UUID) (*dto.PayoutResponse, error) {
+	if err := s.requireTwoFactor(ctx, adminID); err != nil {
+		return nil, err
+	}
+	payout, err := s.payoutRepo.GetByID(ctx, payoutID)
+	if err != nil {
+		return nil, services.ErrPayoutNotFound
+	}
+	if payout.Status != models.PayoutStatusPending || payout.HoldID == nil {
+		return nil, services.ErrPayoutInvalidStatus
+	}
+
+	clearing, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletPayoutClearing)
+	if err != nil {
+		return nil, err
+	}
+
+	// Held amount leaves the wallet: net to payout clearing, fee to platform revenue
+	postings := []dto.LedgerPosting{{WalletID: clearing.ID, Amount: payout.NetAmount}}
+	if payout.Fee > 0 {
+		platformFee, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+		if err != nil {
+			return nil, err
+		}
+		postings = append(postings, dto.LedgerPosting{WalletID: platformFee.ID, Amount: payout.Fee})
+	}
+
+	// Capture and status change commit together, a payout cancelled or rejected meanwhile rolls the capture back
+	refType := payoutReferenceType
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		tx, err := s.walletService.CaptureHold(ctx, *payout.HoldID, &dto.LedgerTransactionRequest{
+			IdempotencyKey: "payout:" + payout.ID.String(),
+			Type:           models.LedgerTxTypePayout,
+			Description:    "Payout to " + payout.AccountName,
+			ReferenceType:  &refType,
+			ReferenceID:    &payout.ID,
+			Postings:       postings,
+		})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		payout.Status = models.PayoutStatusApproved
+		payout.LedgerTxID = &tx.ID
+		payout.ReviewedBy = &adminID
+		payout.ReviewedAt = &now
+		return s.transitionPayout(ctx, payout, models.PayoutStatusPending)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		payout.UserID,
+		adminID,
+		"payout",
+		fmt.Sprintf("คำขอถอนเงิน %s บาท ได้รับการอนุมัติแล้ว จะโอนเข้าบัญชีภายใน 1-3 วันทำการ", formatSatang(payout.NetAmount)),
+		nil,
+		nil,
+	)
+
+	return dto.PayoutToPayoutResponse(payout), nil
+}
+
+func (s *PayoutServiceImpl) RejectPayout(ctx context.Context, payoutID uuid.UUID, adminID uuid.UUID, reason string) (*dto.PayoutResponse, error) {
+	if err := s.requireTwoFactor(ctx, adminID); err != nil {
+		return nil, err
+	}
+	payout, err := s.payoutRepo.GetByID(ctx, payoutID)
+	if err != nil {
+		return nil, services.ErrPayoutNotFound
+	}
+	if payout.Status != models.PayoutStatusPending {
+		return nil, services.ErrPayoutInvalidStatus
+	}
+
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.releasePayoutHold(ctx, payout); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		payout.Status = models.PayoutStatusRejected
+		payout.ReviewedBy = &adminID
+		payout.ReviewedAt = &now
+		payout.RejectionReason = &reason
+		return s.transitionPayout(ctx, payout, models.PayoutStatusPending)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		payout.UserID,
+		adminID,
+		"payout",
+		fmt.Sprintf("คำขอถอนเงิน %s บาท ถูกปฏิเสธ: %s", formatSatang(payout.Amount), reason),
+		nil,
+		nil,
+	)
+
+	return dto.PayoutToPayoutResponse(payout), nil
+}
+
+func (s *PayoutServiceImpl) MarkPayoutsPaid(ctx context.Context, adminID uuid.UUID, req *dto.MarkPayoutsPaidRequest) (*dto.MarkPayoutsPaidResponse, error) {
+	if err := s.requireTwoFactor(ctx, adminID); err != nil {
+		return nil, err
+	}
+	resp := &dto.MarkPayoutsPaidResponse{}
+	now := time.Now()
+
+	for _, payoutID := range req.PayoutIDs {
+		payout, err := s.payoutRepo.GetByID(ctx, payoutID)
+		if err != nil || payout.Status != models.PayoutStatusApproved {
+			resp.Skipped = append(resp.Skipped, payoutID)
+			continue
+		}
+
+		// Money already left the wallet on approval, payout clearing keeps the total sent to banks
+		bankReference := req.BankReference
+		payout.Status = models.PayoutStatusPaid
+		payout.BankReference = &bankReference
+		payout.PaidAt = &now
+		ok, err := s.payoutRepo.UpdateStatus(ctx, payout, models.PayoutStatusApproved)
+		if err != nil {
+			return resp, err
+		}
+		if !ok {
+			// Failed by another admin since it was read
+			resp.Skipped = append(resp.Skipped, payoutID)
+			continue
+		}
+		resp.Paid++
+
+		_ = s.notifService.CreateNotification(
+			ctx,
+			payout.UserID,
+			adminID,
+			"payout",
+			fmt.Sprintf("โอนเงิน %s บาท เข้าบัญชี %s แล้ว", formatSatang(payout.NetAmount), maskAccountNumber(payout.AccountNumber)),
+			nil,
+			nil,
+		)
+	}
+
+	return resp, nil
+}
+
+func (s *PayoutServiceImpl) FailPayout(ctx context.Context, payoutID uuid.UUID, adminID uuid.UUID, reason string) (*dto.PayoutResponse, error) {
+	if err := s.requireTwoFactor(ctx, adminID); err != nil {
+		return nil, err
+	}
+	payout, err := s.payoutRepo.GetByID(ctx, payoutID)
+	if err != nil {
+		return nil, services.ErrPayoutNotFound
+	}
+	if payout.Status != models.PayoutStatusApproved {
+		return nil, services.ErrPayoutInvalidStatus
+	}
+
+	clearing, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletPayoutClearing)
+	if err != nil {
+		return nil, err
+	}
+	userWallet, err := s.walletService.GetOrCreateUserWallet(ctx, payout.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reverse the approval posting, the fee is refunded as well
+	postings := []dto.LedgerPosting{
+		{WalletID: clearing.ID, Amount: -payout.NetAmount},
+		{WalletID: userWallet.ID, Amount: payout.Amount},
+	}
+	if payout.Fee > 0 {
+		platformFee, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+		if err != nil {
+			return nil, err
+		}
+		postings = append(postings, dto.LedgerPosting{WalletID: platformFee.ID, Amount: -payout.Fee})
+	}
+
+	// Refund and status change commit together, a payout marked paid meanwhile rolls the refund back
+	refType := payoutReferenceType
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		tx, err := s.walletService.PostTransaction(ctx, &dto.LedgerTransactionRequest{
+			IdempotencyKey: "payout_refund:" + payout.ID.String(),
+			Type:           models.LedgerTxTypeRefund,
+			Description:    "Payout transfer failed",
+			ReferenceType:  &refType,
+			ReferenceID:    &payout.ID,
+			Postings:       postings,
+		})
+		if err != nil {
+			return err
+		}
+
+		payout.Status = models.PayoutStatusFailed
+		payout.RefundTxID = &tx.ID
+		payout.FailureReason = &reason
+		return s.transitionPayout(ctx, payout, models.PayoutStatusApproved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		payout.UserID,
+		adminID,
+		"payout",
+		fmt.Sprintf("โอนเงินถอน %s บาท ไม่สำเร็จ: %s (คืนเงินเข้ากระเป๋าแล้ว)", formatSatang(payout.Amount), reason),
+		nil,
+		nil,
+	)
+
+	return dto.PayoutToPayoutResponse(payout), nil
+}
+
+func (s *PayoutServiceImpl) ExportApprovedCSV(ctx context.Context) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	_ = w.Write([]string{
+		"payout_id", "requested_at", "username", "account_name", "destination_type",
+		"bank_code", "account_number", "net_amount", "fee", "amount",
+	})
+
+	for offset := 0; ; offset += payoutExportBatch {
+		payouts, err := s.payoutRepo.ListByStatus(ctx, models.PayoutStatusApproved, offset, payoutExportBatch)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, payout := range payouts {
+			bankCode := ""
+			if payout.BankCode != nil {
+				bankCode = *payout.BankCode
+			}
+			_ = w.Write([]string{
+				payout.ID.String(),
+				payout.CreatedAt.Format(time.RFC3339),
+				csvSafeCell(payout.User.Username),
+				csvSafeCell(payout.AccountName),
+				csvSafeCell(payout.DestinationType),
+				csvSafeCell(bankCode),
+				csvSafeCell(payout.AccountNumber),
+				formatSatang(payout.NetAmount),
+				formatSatang(payout.Fee),
+				formatSatang(payout.Amount),
+			})
+		}
+
+		if len(payouts) < payoutExportBatch {
+			break
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ==================== Helpers ====================
+
+// requireTwoFactor guards every step that moves money (creator request, admin approval, rejection and settlement);
+// the routes additionally require a session signed in with the second factor (middleware.RequireMFA)
+func (s *PayoutServiceImpl) requireTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return services.ErrTwoFactorRequired
+	}
+	return nil
+}
+
+// transitionPayout saves the payout only if it is still in fromStatus, otherwise another request moved it first
+func (s *PayoutServiceImpl) transitionPayout(ctx context.Context, payout *models.Payout, fromStatus string) error {
+	ok, err := s.payoutRepo.UpdateStatus(ctx, payout, fromStatus)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return services.ErrPayoutInvalidStatus
+	}
+	return nil
+}
+
+func (s *PayoutServiceImpl) getOwnPayout(ctx context.Context, payoutID uuid.UUID, userID uuid.UUID) (*models.Payout, error) {
+	payout, err := s.payoutRepo.GetByID(ctx, payoutID)
+	if err != nil || payout.UserID != userID {
+		return nil, services.ErrPayoutNotFound
+	}
+	return payout, nil
+}
+
+// withdrawableAmount - Earnings not yet withdrawn, capped by the spendable balance
+func (s *PayoutServiceImpl) withdrawableAmount(ctx context.Context, wallet *models.Wallet) (int64, error) {
+	earned, err := s.payoutRepo.SumEarnings(ctx, wallet.ID)
+	if err != nil {
+		return 0, err
+	}
+	withdrawn, err := s.payoutRepo.SumWithdrawn(ctx, *wallet.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	withdrawable := earned - withdrawn
+	if available := wallet.AvailableBalance(); withdrawable > available {
+		withdrawable = available
+	}
+	if withdrawable < 0 {
+		withdrawable = 0
+	}
+	return withdrawable, nil
+}
+
+func (s *PayoutServiceImpl) releasePayoutHold(ctx context.Context, payout *models.Payout) error {
+	if payout.HoldID == nil {
+		return nil
+	}
+	if err := s.walletService.ReleaseHold(ctx, *payout.HoldID); err != nil && !errors.Is(err, services.ErrHoldNotActive) {
+		return err
+	}
+	return nil
+}
+
+// normalizePayoutAccount strips separators and validates the destination account
+func normalizePayoutAccount(destinationType string, accountNumber string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, accountNumber)
+
+	switch destinationType {
+	case models.PayoutDestinationPromptPay:
+		if err := payment.ValidatePromptPayID(digits); err != nil {
+			return "", services.ErrPayoutInvalidAccount
+		}
+	case models.PayoutDestinationBankAccount:
+		if len(digits) < 10 || len(digits) > 15 {
+			return "", services.ErrPayoutInvalidAccount
+		}
+	default:
+		return "", services.ErrPayoutInvalidAccount
+	}
+	return digits, nil
+}
+
+func buildPayoutListResponse(payouts []*models.Payout, total int64, offset, limit int) *dto.PayoutListResponse {
+	resp := &dto.PayoutListResponse{
+		Payouts: make([]dto.PayoutResponse, len(payouts)),
+		Meta: dto.PaginationMeta{
+			Total:  &total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	for i, payout := range payouts {
+		resp.Payouts[i] = *dto.PayoutToPayoutResponse(payout)
+	}
+	return resp
+}
+
+// formatSatang renders satang as baht with two decimals (12345 -> "123.45")
+func formatSatang(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// csvSafeCell stops spreadsheet apps from running user-supplied text as a formula (CSV injection)
+func csvSafeCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// maskAccountNumber keeps the last 4 digits (notifications only)
+func maskAccountNumber(accountNumber string) string {
+	if len(accountNumber) <= 4 {
+		return accountNumber
+	}
+	return strings.Repeat("x", len(accountNumber)-4) + accountNumber[len(accountNumber)-4:]
+}
+
+// Ensure interface compliance
+var _ services.PayoutService = (*PayoutServiceImpl)(nil)
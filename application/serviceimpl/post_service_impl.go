@@ -15,20 +15,30 @@ import (
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
 	"gofiber-template/infrastructure/redis"
+	"gofiber-template/infrastructure/storage"
 	"gofiber-template/infrastructure/websocket"
 	"gofiber-template/pkg/utils"
+	"gorm.io/gorm"
 )
 
 type PostServiceImpl struct {
-	postRepo        repositories.PostRepository
-	userRepo        repositories.UserRepository
-	voteRepo        repositories.VoteRepository
-	savedPostRepo   repositories.SavedPostRepository
-	tagService      services.TagService
-	mediaRepo       repositories.MediaRepository
-	notificationHub *websocket.NotificationHub
-	redisService    *redis.RedisService
-	feedCache       *redis.FeedCacheService
+	postRepo         repositories.PostRepository
+	userRepo         repositories.UserRepository
+	voteRepo         repositories.VoteRepository
+	savedPostRepo    repositories.SavedPostRepository
+	tagService       services.TagService
+	mediaRepo        repositories.MediaRepository
+	notificationHub  *websocket.NotificationHub
+	redisService     *redis.RedisService
+	feedCache        *redis.FeedCacheService
+	postUnlockRepo   repositories.PostUnlockRepository
+	mediaUpload      *storage.MediaUploadService
+	subscriptionRepo repositories.SubscriptionRepository
+	adService        services.AdService
+	communityRepo    repositories.CommunityRepository
+	authzService     services.AuthorizationService
+	sanctionService  services.SanctionService
+	followRepo       repositories.FollowRepository
 }
 
 func NewPostService(
@@ -41,21 +51,43 @@ func NewPostService(
 	notificationHub *websocket.NotificationHub,
 	redisService *redis.RedisService,
 	feedCache *redis.FeedCacheService,
+	postUnlockRepo repositories.PostUnlockRepository,
+	mediaUpload *storage.MediaUploadService,
+	subscriptionRepo repositories.SubscriptionRepository,
+	adService services.AdService,
+	communityRepo repositories.CommunityRepository,
+	authzService services.AuthorizationService,
+	sanctionService services.SanctionService,
+	followRepo repositories.FollowRepository,
 ) services.PostService {
 	return &PostServiceImpl{
-		postRepo:        postRepo,
-		userRepo:        userRepo,
-		voteRepo:        voteRepo,
-		savedPostRepo:   savedPostRepo,
-		tagService:      tagService,
-		mediaRepo:       mediaRepo,
-		notificationHub: notificationHub,
-		redisService:    redisService,
-		feedCache:       feedCache,
+		postRepo:         postRepo,
+		userRepo:         userRepo,
+		voteRepo:         voteRepo,
+		savedPostRepo:    savedPostRepo,
+		tagService:       tagService,
+		mediaRepo:        mediaRepo,
+		notificationHub:  notificationHub,
+		redisService:     redisService,
+		feedCache:        feedCache,
+		postUnlockRepo:   postUnlockRepo,
+		mediaUpload:      mediaUpload,
+		subscriptionRepo: subscriptionRepo,
+		adService:        adService,
+		communityRepo:    communityRepo,
+		authzService:     authzService,
+		sanctionService:  sanctionService,
+		followRepo:       followRepo,
 	}
 }
 
 func (s *PostServiceImpl) CreatePost(ctx context.Context, userID uuid.UUID, req *dto.CreatePostRequest) (*dto.PostResponse, error) {
+	// Suspended and banned accounts cannot post; posts of shadowbanned accounts are only visible to them
+	shadowed, err := s.sanctionService.CheckWriteAccess(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	// ============================================
 	// STEP 1: Generate or use provided idempotency keys
 	// ============================================
@@ -129,6 +161,33 @@ func (s *PostServiceImpl) CreatePost(ctx context.Context, userID uuid.UUID, req
 		}
 	}
 
+	// Premium unlock posts can't be drafts or crossposts (nothing to unlock)
+	if req.UnlockTargetAmount != nil {
+		if req.IsDraft || req.SourcePostID != nil {
+			return nil, errors.New("premium unlock posts cannot be drafts or crossposts")
+		}
+		if req.UnlockDeadline != nil && !req.UnlockDeadline.After(time.Now()) {
+			return nil, errors.New("unlock deadline must be in the future")
+		}
+	}
+
+	// Subscriber-only posts need a matching tier (and can't mix with premium unlock)
+	if req.MinTierLevel != nil {
+		if req.UnlockTargetAmount != nil || req.SourcePostID != nil {
+			return nil, errors.New("subscriber-only posts cannot be premium unlock posts or crossposts")
+		}
+		if err := s.validateMinTierLevel(ctx, userID, *req.MinTierLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	// Community posts need an active membership that passes the posting policy
+	if req.CommunityID != nil {
+		if err := s.validateCommunityPosting(ctx, *req.CommunityID, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	// ============================================
 	// STEP 5: Create new post
 	// ============================================
@@ -168,6 +227,7 @@ func (s *PostServiceImpl) CreatePost(ctx context.Context, userID uuid.UUID, req
 		Status:       status,
 		ClientPostID: &clientPostID, // ✅ Set clientPostID
 		IsDeleted:    false,
+		IsShadowed:   shadowed,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -177,6 +237,20 @@ func (s *PostServiceImpl) CreatePost(ctx context.Context, userID uuid.UUID, req
 		post.SourcePostID = req.SourcePostID
 	}
 
+	// Premium unlock (ค่าเสือก)
+	if req.UnlockTargetAmount != nil {
+		unlockStatus := models.PostUnlockStatusLocked
+		post.UnlockTargetAmount = req.UnlockTargetAmount
+		post.UnlockStatus = &unlockStatus
+		post.UnlockDeadline = req.UnlockDeadline
+	}
+
+	// Subscriber-only content
+	post.MinTierLevel = req.MinTierLevel
+
+	// Community (nil = personal post)
+	post.CommunityID = req.CommunityID
+
 	// ============================================
 	// STEP 6: Create post in database with race condition handling
 	// ============================================
@@ -205,6 +279,12 @@ func (s *PostServiceImpl) CreatePost(ctx context.Context, userID uuid.UUID, req
 		return nil, err
 	}
 
+	if post.CommunityID != nil {
+		if err := s.communityRepo.IncrementPostCount(ctx, *post.CommunityID, 1); err != nil {
+			log.Printf("[COMMUNITY] Failed to update post count of community %s: %v", *post.CommunityID, err)
+		}
+	}
+
 	// Handle tags
 	if len(req.Tags) > 0 {
 		tagIDs, err := s.tagService.GetOrCreateTags(ctx, req.Tags)
@@ -227,6 +307,11 @@ func (s *PostServiceImpl) CreatePost(ctx context.Context, userID uuid.UUID, req
 		for _, mediaID := range req.MediaIDs {
 			_ = s.mediaRepo.IncrementUsageCount(ctx, mediaID)
 		}
+
+		// Blurred teaser for premium unlock posts (from first media)
+		if post.IsPremiumUnlock() {
+			s.createUnlockPreview(ctx, post, req.MediaIDs[0])
+		}
 	}
 
 	// ============================================
@@ -313,13 +398,230 @@ func (s *PostServiceImpl) determinePostType(ctx context.Context, mediaIDs []uuid
 	return "text", nil
 }
 
+// createUnlockPreview generates the blurred teaser thumbnail shown while a premium post is locked
+func (s *PostServiceImpl) createUnlockPreview(ctx context.Context, post *models.Post, mediaID uuid.UUID) {
+	if s.mediaUpload == nil {
+		return
+	}
+
+	media, err := s.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		return
+	}
+
+	// Videos only have a thumbnail, images prefer the (smaller) thumbnail too
+	sourceURL := media.Thumbnail
+	if sourceURL == "" && media.Type == "image" {
+		sourceURL = media.URL
+	}
+	if sourceURL == "" {
+		return
+	}
+
+	previewURL, err := s.mediaUpload.CreateBlurredPreview(ctx, sourceURL)
+	if err != nil {
+		log.Printf("[UNLOCK] Failed to create preview for post %s: %v", post.ID, err)
+		return
+	}
+
+	if err := s.postUnlockRepo.SetPreviewURL(ctx, post.ID, previewURL); err != nil {
+		log.Printf("[UNLOCK] Failed to save preview for post %s: %v", post.ID, err)
+	}
+}
+
+// canViewPremium reports whether the viewer may see the hidden content of a premium unlock post
+// (the author always, contributors once the target is reached)
+func canViewPremium(post *models.Post, userID *uuid.UUID, contributed bool) bool {
+	if userID == nil {
+		return false
+	}
+	if post.AuthorID == *userID {
+		return true
+	}
+	return post.IsUnlocked() && contributed
+}
+
+// postViewerAccess holds what the viewer may see across a batch of posts
+type postViewerAccess struct {
+	contributed map[uuid.UUID]bool // premium unlock posts the viewer contributed to
+	tierLevels  map[uuid.UUID]int  // author ID -> subscription tier level the viewer has access to
+}
+
+// toPostResponse maps a post for the given viewer (premium unlock and subscriber-only posts stay a teaser unless allowed)
+func (s *PostServiceImpl) toPostResponse(post *models.Post, userID *uuid.UUID, access *postViewerAccess) *dto.PostResponse {
+	return postResponseForViewer(post, userID, access)
+}
+
+// postResponseForViewer maps a post revealing only the gated content the viewer's access covers
+func postResponseForViewer(post *models.Post, userID *uuid.UUID, access *postViewerAccess) *dto.PostResponse {
+	return dto.PostToPostResponseWithAccess(post, dto.PostViewerAccess{
+		Premium:    post.IsPremiumUnlock() && canViewPremium(post, userID, access.contributed[post.ID]),
+		Subscriber: post.IsSubscriberOnly() && canViewSubscriberOnly(post, userID, access.tierLevels),
+	})
+}
+
+// canViewSubscriberOnly reports whether the viewer's subscription covers the post's minimum tier
+func canViewSubscriberOnly(post *models.Post, userID *uuid.UUID, tierLevels map[uuid.UUID]int) bool {
+	if userID == nil {
+		return false
+	}
+	if post.AuthorID == *userID {
+		return true
+	}
+	level, ok := tierLevels[post.AuthorID]
+	return ok && level >= *post.MinTierLevel
+}
+
+// loadViewerAccess batch-loads premium contributions and subscription tiers of the viewer
+func (s *PostServiceImpl) loadViewerAccess(ctx context.Context, posts []*models.Post, userID *uuid.UUID) *postViewerAccess {
+	return loadPostViewerAccess(ctx, s.postUnlockRepo, s.subscriptionRepo, posts, userID)
+}
+
+// loadPostViewerAccess batch-loads what the viewer may see of posts (either repository may be nil)
+func loadPostViewerAccess(
+	ctx context.Context,
+	postUnlockRepo repositories.PostUnlockRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	posts []*models.Post,
+	userID *uuid.UUID,
+) *postViewerAccess {
+	access := &postViewerAccess{}
+	if userID == nil {
+		return access
+	}
+
+	var unlockedPostIDs []uuid.UUID
+	var authorIDs []uuid.UUID
+	seenAuthors := make(map[uuid.UUID]bool)
+	for _, post := range posts {
+		if post.AuthorID == *userID {
+			continue
+		}
+		if post.IsUnlocked() {
+			unlockedPostIDs = append(unlockedPostIDs, post.ID)
+		}
+		if post.IsSubscriberOnly() && !seenAuthors[post.AuthorID] {
+			seenAuthors[post.AuthorID] = true
+			authorIDs = append(authorIDs, post.AuthorID)
+		}
+	}
+
+	if len(unlockedPostIDs) > 0 && postUnlockRepo != nil {
+		contributed, err := postUnlockRepo.GetContributedPostIDs(ctx, *userID, unlockedPostIDs)
+		if err != nil {
+			log.Printf("[UNLOCK] Failed to load contributions: %v", err)
+		} else {
+			access.contributed = contributed
+		}
+	}
+
+	if len(authorIDs) > 0 && subscriptionRepo != nil {
+		tierLevels, err := subscriptionRepo.GetAccessTierLevels(ctx, *userID, authorIDs)
+		if err != nil {
+			log.Printf("[SUBSCRIPTION] Failed to load subscriptions: %v", err)
+		} else {
+			access.tierLevels = tierLevels
+		}
+	}
+
+	return access
+}
+
+// validateMinTierLevel checks the author has an active tier that can see the post
+func (s *PostServiceImpl) validateMinTierLevel(ctx context.Context, userID uuid.UUID, minTierLevel int) error {
+	if s.subscriptionRepo == nil {
+		return errors.New("subscriptions are not available")
+	}
+	tiers, err := s.subscriptionRepo.ListTiersByCreator(ctx, userID, true)
+	if err != nil {
+		return err
+	}
+	for _, tier := range tiers {
+		if tier.TierLevel >= minTierLevel {
+			return nil
+		}
+	}
+	return errors.New("no active subscription tier at or above this level")
+}
+
+// validateCommunityPosting loads the community and enforces the author's membership and its posting policy
+func (s *PostServiceImpl) validateCommunityPosting(ctx context.Context, communityID uuid.UUID, userID uuid.UUID) error {
+	if s.communityRepo == nil {
+		return services.ErrCommunityNotFound
+	}
+	community, err := s.communityRepo.GetByID(ctx, communityID)
+	if err != nil {
+		return services.ErrCommunityNotFound
+	}
+	member, _ := s.communityRepo.GetMember(ctx, communityID, userID) // nil = not a member
+	return checkCommunityPosting(community, member)
+}
+
+// checkCommunityViewByID loads the community and checks the viewer may see its posts
+func (s *PostServiceImpl) checkCommunityViewByID(ctx context.Context, communityID uuid.UUID, userID *uuid.UUID) error {
+	if s.communityRepo == nil {
+		return services.ErrCommunityNotFound
+	}
+	community, err := s.communityRepo.GetByID(ctx, communityID)
+	if err != nil {
+		return services.ErrCommunityNotFound
+	}
+	return s.checkCommunityView(ctx, community, userID)
+}
+
+// checkCommunityView hides posts of private communities from everyone but active members
+func (s *PostServiceImpl) checkCommunityView(ctx context.Context, community *models.Community, userID *uuid.UUID) error {
+	if community.Visibility != models.CommunityVisibilityPrivate {
+		return nil
+	}
+	if userID != nil && s.communityRepo != nil {
+		member, err := s.communityRepo.GetMember(ctx, community.ID, *userID)
+		if err == nil && member.IsActive() {
+			return nil
+		}
+	}
+	return services.ErrCommunityPrivate
+}
+
+// canRemovePost reports whether the user moderates the community of a post,
+// or holds post.remove.any site-wide or for that community
+func (s *PostServiceImpl) canRemovePost(ctx context.Context, post *models.Post, userID uuid.UUID) bool {
+	if post.CommunityID != nil && s.communityRepo != nil {
+		member, err := s.communityRepo.GetMember(ctx, *post.CommunityID, userID)
+		if err == nil && member.IsModerator() {
+			return true
+		}
+	}
+	if s.authzService == nil {
+		return false
+	}
+	allowed, _ := s.authzService.HasPermission(ctx, userID, models.PermPostRemoveAny, post.CommunityID)
+	return allowed
+}
+
 func (s *PostServiceImpl) GetPost(ctx context.Context, postID uuid.UUID, userID *uuid.UUID) (*dto.PostResponse, error) {
 	post, err := s.postRepo.GetByID(ctx, postID)
 	if err != nil {
 		return nil, err
 	}
+	// Shadowed posts only exist for their author
+	if post.IsShadowed && (userID == nil || *userID != post.AuthorID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	// Private accounts only show their posts to followers
+	if userID == nil || *userID != post.AuthorID {
+		if err := requireAccountVisible(ctx, s.userRepo, s.followRepo, post.AuthorID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if post.Community != nil {
+		if err := s.checkCommunityView(ctx, post.Community, userID); err != nil {
+			return nil, err
+		}
+	}
 
-	resp := dto.PostToPostResponse(post)
+	resp := s.toPostResponse(post, userID, s.loadViewerAccess(ctx, []*models.Post{post}, userID))
 
 	// Add user-specific data if authenticated
 	if userID != nil {
@@ -338,6 +640,10 @@ func (s *PostServiceImpl) GetPost(ctx context.Context, postID uuid.UUID, userID
 }
 
 func (s *PostServiceImpl) UpdatePost(ctx context.Context, postID uuid.UUID, userID uuid.UUID, req *dto.UpdatePostRequest) (*dto.PostResponse, error) {
+	if _, err := s.sanctionService.CheckWriteAccess(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	// Get existing post
 	post, err := s.postRepo.GetByID(ctx, postID)
 	if err != nil {
@@ -349,16 +655,16 @@ func (s *PostServiceImpl) UpdatePost(ctx context.Context, postID uuid.UUID, user
 		return nil, errors.New("unauthorized: not post owner")
 	}
 
-	// Update fields
+	// Update fields (only edited ones - counters like votes and unlock progress change concurrently)
+	changes := &models.Post{UpdatedAt: time.Now()}
 	if req.Title != "" {
-		post.Title = req.Title
+		changes.Title = req.Title
 	}
 	if req.Content != "" {
-		post.Content = req.Content
+		changes.Content = req.Content
 	}
-	post.UpdatedAt = time.Now()
 
-	err = s.postRepo.Update(ctx, postID, post)
+	err = s.postRepo.Update(ctx, postID, changes)
 	if err != nil {
 		return nil, err
 	}
@@ -394,17 +700,58 @@ func (s *PostServiceImpl) DeletePost(ctx context.Context, postID uuid.UUID, user
 		return err
 	}
 
-	// Check ownership
-	if post.AuthorID != userID {
+	// Check ownership (community moderators and staff with post.remove.any can remove posts)
+	if post.AuthorID != userID && !s.canRemovePost(ctx, post, userID) {
 		return errors.New("unauthorized: not post owner")
 	}
 
+	return s.deletePost(ctx, post, nil)
+}
+
+// RemovePost deletes a post on behalf of a site moderator (report resolution)
+func (s *PostServiceImpl) RemovePost(ctx context.Context, postID uuid.UUID, moderatorID uuid.UUID, reason string) error {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	return s.deletePost(ctx, post, &contentRemoval{moderatorID: moderatorID, reason: reason})
+}
+
+// contentRemoval records who removed content and why (nil = deleted by its author)
+type contentRemoval struct {
+	moderatorID uuid.UUID
+	reason      string
+}
+
+// deletePost soft deletes a post and releases what hangs off it (community count, unlock holds, feed caches)
+func (s *PostServiceImpl) deletePost(ctx context.Context, post *models.Post, removal *contentRemoval) error {
+	postID := post.ID
+
 	// Soft delete
-	err = s.postRepo.Delete(ctx, postID)
+	var err error
+	if removal != nil {
+		err = s.postRepo.Remove(ctx, postID, removal.moderatorID, removal.reason)
+	} else {
+		err = s.postRepo.Delete(ctx, postID)
+	}
 	if err != nil {
 		return err
 	}
 
+	if post.CommunityID != nil && s.communityRepo != nil {
+		if err := s.communityRepo.IncrementPostCount(ctx, *post.CommunityID, -1); err != nil {
+			log.Printf("[COMMUNITY] Failed to update post count of community %s: %v", *post.CommunityID, err)
+		}
+	}
+
+	// Locked premium post - fail it so held contributions get refunded by the settlement job
+	if post.IsPremiumUnlock() && s.postUnlockRepo != nil {
+		if _, err := s.postUnlockRepo.MarkFailed(ctx, postID); err != nil {
+			log.Printf("[UNLOCK] Failed to close premium post %s: %v", postID, err)
+		}
+	}
+
 	// Invalidate feed caches (post deleted)
 	if s.feedCache != nil {
 		if err := s.feedCache.InvalidateAllFeeds(ctx); err != nil {
@@ -444,7 +791,7 @@ func (s *PostServiceImpl) ListPosts(ctx context.Context, offset, limit int, sort
 	}
 
 	// STEP 3: Cache miss - query database
-	posts, err := s.postRepo.List(ctx, offset, limit, sortBy)
+	posts, err := s.postRepo.List(ctx, offset, limit, sortBy, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -471,12 +818,17 @@ func (s *PostServiceImpl) ListPosts(ctx context.Context, offset, limit int, sort
 }
 
 func (s *PostServiceImpl) ListPostsByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int, userID *uuid.UUID) (*dto.PostListResponse, error) {
-	posts, err := s.postRepo.ListByAuthor(ctx, authorID, offset, limit)
+	// Posts of private accounts are only listed for approved followers
+	if err := requireAccountVisible(ctx, s.userRepo, s.followRepo, authorID, userID); err != nil {
+		return nil, err
+	}
+
+	posts, err := s.postRepo.ListByAuthor(ctx, authorID, offset, limit, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	count, err := s.postRepo.CountByAuthor(ctx, authorID)
+	count, err := s.postRepo.CountByAuthor(ctx, authorID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -486,7 +838,7 @@ func (s *PostServiceImpl) ListPostsByAuthor(ctx context.Context, authorID uuid.U
 
 func (s *PostServiceImpl) ListPostsByTag(ctx context.Context, tagName string, offset, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListResponse, error) {
 	// Fetch limit+1 to determine if there are more results
-	posts, err := s.postRepo.ListByTag(ctx, tagName, offset, limit+1, sortBy)
+	posts, err := s.postRepo.ListByTag(ctx, tagName, offset, limit+1, sortBy, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -502,7 +854,27 @@ func (s *PostServiceImpl) ListPostsByTag(ctx context.Context, tagName string, of
 
 func (s *PostServiceImpl) ListPostsByTagID(ctx context.Context, tagID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListResponse, error) {
 	// Fetch limit+1 to determine if there are more results
-	posts, err := s.postRepo.ListByTagID(ctx, tagID, offset, limit+1, sortBy)
+	posts, err := s.postRepo.ListByTagID(ctx, tagID, offset, limit+1, sortBy, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine hasMore by checking if we got more than requested limit
+	hasMore := len(posts) > limit
+	if hasMore {
+		posts = posts[:limit] // Trim to actual limit
+	}
+
+	return s.buildPostListResponseWithHasMore(ctx, posts, hasMore, offset, limit, userID)
+}
+
+func (s *PostServiceImpl) ListPostsByCommunity(ctx context.Context, communityID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListResponse, error) {
+	if err := s.checkCommunityViewByID(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+
+	// Fetch limit+1 to determine if there are more results
+	posts, err := s.postRepo.ListByCommunity(ctx, communityID, offset, limit+1, sortBy, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -518,7 +890,7 @@ func (s *PostServiceImpl) ListPostsByTagID(ctx context.Context, tagID uuid.UUID,
 
 func (s *PostServiceImpl) SearchPosts(ctx context.Context, query string, offset, limit int, userID *uuid.UUID) (*dto.PostListResponse, error) {
 	// Fetch limit+1 to determine if there are more results
-	posts, err := s.postRepo.Search(ctx, query, offset, limit+1)
+	posts, err := s.postRepo.Search(ctx, query, offset, limit+1, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -541,7 +913,7 @@ func (s *PostServiceImpl) SearchPostsWithCursor(ctx context.Context, query strin
 	}
 
 	// Fetch limit+1 to determine if there are more pages
-	posts, err := s.postRepo.SearchWithCursor(ctx, query, cursor, limit+1)
+	posts, err := s.postRepo.SearchWithCursor(ctx, query, cursor, limit+1, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -582,7 +954,7 @@ func (s *PostServiceImpl) GetCrossposts(ctx context.Context, postID uuid.UUID, o
 func (s *PostServiceImpl) GetFeed(ctx context.Context, userID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy) (*dto.PostFeedResponse, error) {
 	// For now, just return all posts sorted by the requested method
 	// TODO: Implement personalized feed based on followed users
-	posts, err := s.postRepo.List(ctx, offset, limit, sortBy)
+	posts, err := s.postRepo.List(ctx, offset, limit, sortBy, &userID)
 	if err != nil {
 		return nil, err
 	}
@@ -612,16 +984,27 @@ func (s *PostServiceImpl) ListPostsWithCursor(ctx context.Context, cursorStr str
 	}
 
 	// Fetch limit+1 to determine if there are more pages
-	posts, err := s.postRepo.ListWithCursor(ctx, cursor, limit+1, sortBy)
+	posts, err := s.postRepo.ListWithCursor(ctx, cursor, limit+1, sortBy, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.buildPostListCursorResponse(ctx, posts, limit, sortBy, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.buildPostListCursorResponse(ctx, posts, limit, sortBy, userID)
+	resp.Ads = s.interleaveFeedAds(ctx, userID, len(resp.Posts))
+	return resp, nil
 }
 
 // ListPostsByAuthorWithCursor returns posts by author with cursor pagination
 func (s *PostServiceImpl) ListPostsByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursorStr string, limit int, userID *uuid.UUID) (*dto.PostListCursorResponse, error) {
+	// Posts of private accounts are only listed for approved followers
+	if err := requireAccountVisible(ctx, s.userRepo, s.followRepo, authorID, userID); err != nil {
+		return nil, err
+	}
+
 	// Decode cursor
 	cursor, err := utils.DecodePostCursor(cursorStr)
 	if err != nil {
@@ -629,7 +1012,7 @@ func (s *PostServiceImpl) ListPostsByAuthorWithCursor(ctx context.Context, autho
 	}
 
 	// Fetch limit+1 to determine if there are more pages
-	posts, err := s.postRepo.ListByAuthorWithCursor(ctx, authorID, cursor, limit+1)
+	posts, err := s.postRepo.ListByAuthorWithCursor(ctx, authorID, cursor, limit+1, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -646,12 +1029,45 @@ func (s *PostServiceImpl) ListPostsByTagWithCursor(ctx context.Context, tagName
 	}
 
 	// Fetch limit+1 to determine if there are more pages
-	posts, err := s.postRepo.ListByTagWithCursor(ctx, tagName, cursor, limit+1, sortBy)
+	posts, err := s.postRepo.ListByTagWithCursor(ctx, tagName, cursor, limit+1, sortBy, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.buildPostListCursorResponse(ctx, posts, limit, sortBy, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Ads = s.interleaveFeedAds(ctx, userID, len(resp.Posts))
+	return resp, nil
+}
+
+// ListPostsByCommunityWithCursor returns posts of a community with cursor pagination
+func (s *PostServiceImpl) ListPostsByCommunityWithCursor(ctx context.Context, communityID uuid.UUID, cursorStr string, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListCursorResponse, error) {
+	if err := s.checkCommunityViewByID(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+
+	// Decode cursor
+	cursor, err := utils.DecodePostCursor(cursorStr)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	// Fetch limit+1 to determine if there are more pages
+	posts, err := s.postRepo.ListByCommunityWithCursor(ctx, communityID, cursor, limit+1, sortBy, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.buildPostListCursorResponse(ctx, posts, limit, sortBy, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.buildPostListCursorResponse(ctx, posts, limit, sortBy, userID)
+	resp.Ads = s.interleaveFeedAds(ctx, userID, len(resp.Posts))
+	return resp, nil
 }
 
 // GetFollowingFeedWithCursor returns posts from followed users with cursor pagination
@@ -676,6 +1092,7 @@ func (s *PostServiceImpl) GetFollowingFeedWithCursor(ctx context.Context, userID
 	return &dto.PostFeedCursorResponse{
 		Posts: listResp.Posts,
 		Meta:  listResp.Meta,
+		Ads:   s.interleaveFeedAds(ctx, &userID, len(listResp.Posts)),
 	}, nil
 }
 
@@ -764,10 +1181,11 @@ func (s *PostServiceImpl) buildPostListResponse(ctx context.Context, posts []*mo
 		voteMap, _ = s.voteRepo.GetUserVotesForTargets(ctx, *userID, postIDs, "post")
 		savedMap, _ = s.savedPostRepo.GetSavedStatus(ctx, *userID, postIDs)
 	}
+	viewerAccess := s.loadViewerAccess(ctx, posts, userID)
 
 	// Build responses
 	for i, post := range posts {
-		resp := dto.PostToPostResponse(post)
+		resp := s.toPostResponse(post, userID, viewerAccess)
 
 		// Calculate hot score
 		hoursSinceCreation := time.Since(post.CreatedAt).Hours()
@@ -817,10 +1235,11 @@ func (s *PostServiceImpl) buildPostListResponseWithHasMore(ctx context.Context,
 		voteMap, _ = s.voteRepo.GetUserVotesForTargets(ctx, *userID, postIDs, "post")
 		savedMap, _ = s.savedPostRepo.GetSavedStatus(ctx, *userID, postIDs)
 	}
+	viewerAccess := s.loadViewerAccess(ctx, posts, userID)
 
 	// Build responses
 	for i, post := range posts {
-		resp := dto.PostToPostResponse(post)
+		resp := s.toPostResponse(post, userID, viewerAccess)
 
 		// Calculate hot score
 		hoursSinceCreation := time.Since(post.CreatedAt).Hours()
@@ -854,6 +1273,14 @@ func (s *PostServiceImpl) buildPostListResponseWithHasMore(ctx context.Context,
 }
 
 // Helper function to build cursor-based post list response
+// interleaveFeedAds returns the sponsored slots for a feed page (nil when ads are disabled)
+func (s *PostServiceImpl) interleaveFeedAds(ctx context.Context, userID *uuid.UUID, postCount int) []dto.FeedAdSlot {
+	if s.adService == nil {
+		return nil
+	}
+	return s.adService.InterleaveFeedAds(ctx, userID, postCount)
+}
+
 func (s *PostServiceImpl) buildPostListCursorResponse(ctx context.Context, posts []*models.Post, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListCursorResponse, error) {
 	// Determine if there are more pages (limit+1 pattern)
 	hasMore := len(posts) > limit
@@ -874,11 +1301,12 @@ func (s *PostServiceImpl) buildPostListCursorResponse(ctx context.Context, posts
 		voteMap, _ = s.voteRepo.GetUserVotesForTargets(ctx, *userID, postIDs, "post")
 		savedMap, _ = s.savedPostRepo.GetSavedStatus(ctx, *userID, postIDs)
 	}
+	viewerAccess := s.loadViewerAccess(ctx, posts, userID)
 
 	// Build responses
 	responses := make([]dto.PostResponse, len(posts))
 	for i, post := range posts {
-		resp := dto.PostToPostResponse(post)
+		resp := s.toPostResponse(post, userID, viewerAccess)
 
 		// Calculate hot score
 		hoursSinceCreation := time.Since(post.CreatedAt).Hours()
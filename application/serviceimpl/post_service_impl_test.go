@@ -11,10 +11,20 @@ import (
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/models"
 	repomocks "gofiber-template/domain/repositories/mocks"
+	"gofiber-template/domain/services"
 	servicemocks "gofiber-template/domain/services/mocks"
 	"gofiber-template/pkg/testutil"
 )
 
+// allowWrites is a SanctionService for accounts without sanctions (only CheckWriteAccess is used by PostService)
+type allowWrites struct {
+	services.SanctionService
+}
+
+func (allowWrites) CheckWriteAccess(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
 func setupPostService() (
 	*PostServiceImpl,
 	*repomocks.MockPostRepository,
@@ -39,8 +49,12 @@ func setupPostService() (
 		tagService:      mockTagService,
 		mediaRepo:       mockMediaRepo,
 		notificationHub: nil,
+		sanctionService: allowWrites{},
 	}
 
+	// New posts never collide with an earlier client post ID
+	mockPostRepo.On("GetByClientPostID", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("not found")).Maybe()
+
 	return service, mockPostRepo, mockUserRepo, mockVoteRepo, mockSavedPostRepo, mockTagService, mockMediaRepo
 }
 
@@ -124,6 +138,10 @@ func TestCreatePost_WithMedia(t *testing.T) {
 
 	createdPost := testutil.CreateTestPostWithData(userID, req.Title, req.Content)
 
+	// Media must belong to the author
+	for _, mediaID := range mediaIDs {
+		mockMediaRepo.On("GetByID", ctx, mediaID).Return(&models.Media{ID: mediaID, UserID: userID, Type: "image"}, nil)
+	}
 	mockPostRepo.On("Create", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
 	mockPostRepo.On("AttachMedia", ctx, mock.AnythingOfType("uuid.UUID"), mediaIDs).Return(nil)
 	mockMediaRepo.On("IncrementUsageCount", ctx, mediaIDs[0]).Return(nil)
@@ -174,12 +192,13 @@ func TestCreatePost_AsDraft(t *testing.T) {
 
 func TestGetPost_Success(t *testing.T) {
 	// Arrange
-	service, mockPostRepo, _, _, _, _, _ := setupPostService()
+	service, mockPostRepo, mockUserRepo, _, _, _, _ := setupPostService()
 	ctx := context.Background()
 
 	post := testutil.CreateTestPost(uuid.New())
 
 	mockPostRepo.On("GetByID", ctx, post.ID).Return(post, nil)
+	mockUserRepo.On("GetByID", ctx, post.AuthorID).Return(&models.User{ID: post.AuthorID}, nil)
 
 	// Act
 	result, err := service.GetPost(ctx, post.ID, nil)
@@ -194,7 +213,7 @@ func TestGetPost_Success(t *testing.T) {
 
 func TestGetPost_WithUserContext(t *testing.T) {
 	// Arrange
-	service, mockPostRepo, _, mockVoteRepo, mockSavedPostRepo, _, _ := setupPostService()
+	service, mockPostRepo, mockUserRepo, mockVoteRepo, mockSavedPostRepo, _, _ := setupPostService()
 	ctx := context.Background()
 	userID := uuid.New()
 
@@ -207,6 +226,7 @@ func TestGetPost_WithUserContext(t *testing.T) {
 	}
 
 	mockPostRepo.On("GetByID", ctx, post.ID).Return(post, nil)
+	mockUserRepo.On("GetByID", ctx, post.AuthorID).Return(&models.User{ID: post.AuthorID}, nil)
 	mockVoteRepo.On("GetVote", ctx, userID, post.ID, "post").Return(vote, nil)
 	mockSavedPostRepo.On("IsSaved", ctx, userID, post.ID).Return(true, nil)
 
@@ -225,6 +245,32 @@ func TestGetPost_WithUserContext(t *testing.T) {
 	mockSavedPostRepo.AssertExpectations(t)
 }
 
+func TestGetPost_PrivateAccount(t *testing.T) {
+	// Arrange
+	service, mockPostRepo, mockUserRepo, _, _, _, _ := setupPostService()
+	mockFollowRepo := new(repomocks.MockFollowRepository)
+	service.followRepo = mockFollowRepo
+	ctx := context.Background()
+	viewerID := uuid.New()
+
+	post := testutil.CreateTestPost(uuid.New())
+
+	mockPostRepo.On("GetByID", ctx, post.ID).Return(post, nil)
+	mockUserRepo.On("GetByID", ctx, post.AuthorID).Return(&models.User{ID: post.AuthorID, IsPrivate: true}, nil)
+	mockFollowRepo.On("IsFollowing", ctx, viewerID, post.AuthorID).Return(false, nil)
+
+	// Act
+	anonymous, anonErr := service.GetPost(ctx, post.ID, nil)
+	stranger, strangerErr := service.GetPost(ctx, post.ID, &viewerID)
+
+	// Assert
+	assert.ErrorIs(t, anonErr, services.ErrPrivateAccount)
+	assert.Nil(t, anonymous)
+	assert.ErrorIs(t, strangerErr, services.ErrPrivateAccount)
+	assert.Nil(t, stranger)
+	mockFollowRepo.AssertExpectations(t)
+}
+
 func TestGetPost_NotFound(t *testing.T) {
 	// Arrange
 	service, mockPostRepo, _, _, _, _, _ := setupPostService()
@@ -0,0 +1,448 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+)
+
+const (
+	postUnlockMaxContributionsPerUser = 3
+	postUnlockPlatformFeePercent      = 15 // creator receives the rest
+	postUnlockReferenceType           = "post_unlock"
+	postUnlockBatchSize               = 100
+)
+
+type PostUnlockServiceImpl struct {
+	txManager      *database.TransactionManager
+	postUnlockRepo repositories.PostUnlockRepository
+	postRepo       repositories.PostRepository
+	userRepo       repositories.UserRepository
+	walletService  services.WalletService
+	notifService   services.NotificationService
+}
+
+func NewPostUnlockService(
+	txManager *database.TransactionManager,
+	postUnlockRepo repositories.PostUnlockRepository,
+	postRepo repositories.PostRepository,
+	userRepo repositories.UserRepository,
+	walletService services.WalletService,
+	notifService services.NotificationService,
+) services.PostUnlockService {
+	return &PostUnlockServiceImpl{
+		txManager:      txManager,
+		postUnlockRepo: postUnlockRepo,
+		postRepo:       postRepo,
+		userRepo:       userRepo,
+		walletService:  walletService,
+		notifService:   notifService,
+	}
+}
+
+func (s *PostUnlockServiceImpl) Contribute(ctx context.Context, postID uuid.UUID, userID uuid.UUID, req *dto.ContributeUnlockRequest) (*dto.ContributeUnlockResponse, error) {
+	// Scope the client key to post + user so it can't collide with other contributions
+	idempotencyKey := fmt.Sprintf("post_unlock:%s:%s:%s", postID, userID, req.IdempotencyKey)
+
+	// Retried request - return the original contribution
+	if existing, err := s.postUnlockRepo.GetContributionByIdempotencyKey(ctx, idempotencyKey); err == nil {
+		return s.buildContributeResponse(ctx, existing, false)
+	}
+
+	// Reserve the money - it only leaves the wallet once the target is reached
+	wallet, err := s.walletService.GetOrCreateUserWallet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hold and contribution commit together while the campaign row is locked,
+	// so a closing or unlocking campaign can't race a new contribution
+	var contribution *models.PostUnlockContribution
+	var updated *models.Post
+	err = s.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		post, err := s.postUnlockRepo.GetPostForUpdate(ctx, postID)
+		if err != nil {
+			return err
+		}
+
+		if !post.IsPremiumUnlock() {
+			return services.ErrPostNotPremium
+		}
+		if post.AuthorID == userID {
+			return services.ErrCannotContributeOwnPost
+		}
+		if post.UnlockStatus == nil || *post.UnlockStatus != models.PostUnlockStatusLocked {
+			return services.ErrPostUnlockClosed
+		}
+		if post.UnlockDeadline != nil && time.Now().After(*post.UnlockDeadline) {
+			return services.ErrPostUnlockClosed
+		}
+
+		count, err := s.postUnlockRepo.CountUserContributions(ctx, postID, userID)
+		if err != nil {
+			return err
+		}
+		if count >= postUnlockMaxContributionsPerUser {
+			return services.ErrUnlockContributionLimit
+		}
+
+		// The hold lapses with the campaign, so money is never reserved past the deadline
+		referenceType := postUnlockReferenceType
+		hold, err := s.walletService.PlaceHold(ctx, &dto.WalletHoldRequest{
+			IdempotencyKey: idempotencyKey,
+			WalletID:       wallet.ID,
+			Amount:         req.Amount,
+			Reason:         "Post unlock contribution",
+			ReferenceType:  &referenceType,
+			ReferenceID:    &postID,
+			ExpiresAt:      post.UnlockDeadline,
+		})
+		if err != nil {
+			return err
+		}
+
+		contribution = &models.PostUnlockContribution{
+			PostID:         postID,
+			UserID:         userID,
+			Amount:         req.Amount,
+			Status:         models.PostUnlockContributionHeld,
+			HoldID:         hold.ID,
+			IdempotencyKey: idempotencyKey,
+		}
+
+		updated, err = s.postUnlockRepo.CreateContribution(ctx, contribution)
+		return err
+	})
+	if err != nil {
+		// Concurrent request with the same key won the race
+		if database.IsUniqueViolation(err) {
+			if existing, getErr := s.postUnlockRepo.GetContributionByIdempotencyKey(ctx, idempotencyKey); getErr == nil {
+				return s.buildContributeResponse(ctx, existing, false)
+			}
+		}
+		return nil, err
+	}
+
+	// Target reached - unlock and pay out
+	justUnlocked := false
+	if updated.UnlockCurrentAmount >= *updated.UnlockTargetAmount {
+		unlocked, err := s.postUnlockRepo.MarkUnlocked(ctx, postID)
+		if err != nil {
+			log.Printf("[UNLOCK] Failed to mark post %s unlocked: %v", postID, err)
+		}
+		if unlocked {
+			justUnlocked = true
+			if _, err := s.settle(ctx, updated); err != nil {
+				// Retried by the settlement job
+				log.Printf("[UNLOCK] Failed to settle post %s: %v", postID, err)
+			}
+			s.notifyUnlocked(ctx, updated, userID)
+		}
+	}
+
+	return s.buildContributeResponse(ctx, contribution, justUnlocked)
+}
+
+func (s *PostUnlockServiceImpl) GetUnlockStatus(ctx context.Context, postID uuid.UUID, userID *uuid.UUID) (*dto.PostUnlockStatusResponse, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPremiumUnlock() {
+		return nil, services.ErrPostNotPremium
+	}
+
+	resp := &dto.PostUnlockStatusResponse{
+		PostID: post.ID,
+		Unlock: dto.PostToPostUnlockInfo(post),
+	}
+
+	isOpen := post.UnlockStatus != nil && *post.UnlockStatus == models.PostUnlockStatusLocked &&
+		(post.UnlockDeadline == nil || time.Now().Before(*post.UnlockDeadline))
+
+	if userID == nil {
+		resp.CanContribute = isOpen
+		return resp, nil
+	}
+
+	mine, err := s.postUnlockRepo.GetContributorTotal(ctx, postID, *userID)
+	if err != nil {
+		return nil, err
+	}
+	resp.MyContribution = &mine.TotalAmount
+	resp.MyContributionCount = &mine.ContributionCount
+
+	// Author and contributors see the content once unlocked
+	if post.AuthorID == *userID || (post.IsUnlocked() && mine.ContributionCount > 0) {
+		resp.Unlock.IsLocked = false
+	}
+
+	resp.CanContribute = isOpen && post.AuthorID != *userID && mine.ContributionCount < postUnlockMaxContributionsPerUser
+
+	return resp, nil
+}
+
+func (s *PostUnlockServiceImpl) ListContributors(ctx context.Context, postID uuid.UUID, offset, limit int) (*dto.UnlockContributorListResponse, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !post.IsPremiumUnlock() {
+		return nil, services.ErrPostNotPremium
+	}
+
+	totals, err := s.postUnlockRepo.ListContributors(ctx, postID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	contributors := make([]dto.UnlockContributorResponse, 0, len(totals))
+	for _, total := range totals {
+		user, err := s.userRepo.GetByID(ctx, total.UserID)
+		if err != nil {
+			continue
+		}
+		contributors = append(contributors, dto.UnlockContributorResponse{
+			User:              *dto.UserToUserResponse(user),
+			TotalAmount:       total.TotalAmount,
+			ContributionCount: total.ContributionCount,
+			FirstContributed:  total.FirstContributed,
+		})
+	}
+
+	count := int64(post.UnlockContributorCount)
+	return &dto.UnlockContributorListResponse{
+		Contributors: contributors,
+		Meta: dto.PaginationMeta{
+			Total:  &count,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func (s *PostUnlockServiceImpl) ProcessExpiredUnlocks(ctx context.Context) (int, error) {
+	posts, err := s.postUnlockRepo.ListExpiredLockedPosts(ctx, time.Now(), postUnlockBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	failed := 0
+	for _, post := range posts {
+		ok, err := s.postUnlockRepo.MarkFailed(ctx, post.ID)
+		if err != nil {
+			log.Printf("[UNLOCK] Failed to mark post %s failed: %v", post.ID, err)
+			continue
+		}
+		if !ok {
+			continue // unlocked in the meantime
+		}
+		failed++
+
+		if _, err := s.refund(ctx, post); err != nil {
+			// Retried by the settlement job
+			log.Printf("[UNLOCK] Failed to refund post %s: %v", post.ID, err)
+		}
+
+		_ = s.notifService.CreateNotification(
+			ctx,
+			post.AuthorID,
+			post.AuthorID,
+			"unlock",
+			"โพสต์ของคุณไม่ถึงเป้าหมายในเวลาที่กำหนด ระบบได้คืนเงินให้ผู้ร่วมสนับสนุนแล้ว",
+			&post.ID,
+			nil,
+		)
+	}
+
+	return failed, nil
+}
+
+func (s *PostUnlockServiceImpl) SettlePendingUnlocks(ctx context.Context) (int, error) {
+	postIDs, err := s.postUnlockRepo.ListUnsettledPostIDs(ctx, postUnlockBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, postID := range postIDs {
+		// Deleted posts are not found anymore - their contributions are refunded
+		post, err := s.postRepo.GetByID(ctx, postID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("[UNLOCK] Failed to load post %s: %v", postID, err)
+			continue
+		}
+
+		var n int
+		if post != nil && post.IsUnlocked() {
+			n, err = s.settle(ctx, post)
+		} else {
+			n, err = s.refund(ctx, &models.Post{ID: postID})
+		}
+		if err != nil {
+			log.Printf("[UNLOCK] Failed to settle post %s: %v", postID, err)
+			continue
+		}
+		settled += n
+	}
+
+	return settled, nil
+}
+
+// settle captures all held contributions of an unlocked post: creator gets the net amount, platform the fee
+func (s *PostUnlockServiceImpl) settle(ctx context.Context, post *models.Post) (int, error) {
+	contributions, err := s.postUnlockRepo.ListContributionsByStatus(ctx, post.ID, models.PostUnlockContributionHeld)
+	if err != nil {
+		return 0, err
+	}
+	if len(contributions) == 0 {
+		return 0, nil
+	}
+
+	creatorWallet, err := s.walletService.GetOrCreateUserWallet(ctx, post.AuthorID)
+	if err != nil {
+		return 0, err
+	}
+	feeWallet, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+	if err != nil {
+		return 0, err
+	}
+
+	referenceType := postUnlockReferenceType
+	settled := 0
+	for _, contribution := range contributions {
+		fee := contribution.Amount * postUnlockPlatformFeePercent / 100
+		postings := []dto.LedgerPosting{{WalletID: creatorWallet.ID, Amount: contribution.Amount - fee}}
+		if fee > 0 {
+			postings = append(postings, dto.LedgerPosting{WalletID: feeWallet.ID, Amount: fee})
+		}
+
+		_, err := s.walletService.CaptureHold(ctx, contribution.HoldID, &dto.LedgerTransactionRequest{
+			IdempotencyKey: "post_unlock_capture:" + contribution.ID.String(),
+			Type:           models.LedgerTxTypeCapture,
+			Description:    "Post unlock",
+			ReferenceType:  &referenceType,
+			ReferenceID:    &post.ID,
+			Postings:       postings,
+		})
+		if errors.Is(err, services.ErrHoldNotActive) {
+			// Hold lapsed at the campaign deadline before settlement - the money went back to the contributor
+			log.Printf("[UNLOCK] Hold %s expired before settlement of post %s", contribution.HoldID, post.ID)
+			if err := s.postUnlockRepo.UpdateContributionStatus(ctx, contribution.ID, models.PostUnlockContributionReleased); err != nil {
+				return settled, err
+			}
+			continue
+		}
+		if err != nil {
+			return settled, err
+		}
+
+		if err := s.postUnlockRepo.UpdateContributionStatus(ctx, contribution.ID, models.PostUnlockContributionCaptured); err != nil {
+			return settled, err
+		}
+		settled++
+	}
+
+	return settled, nil
+}
+
+// refund releases all held contributions of a failed (or deleted) post and notifies contributors
+func (s *PostUnlockServiceImpl) refund(ctx context.Context, post *models.Post) (int, error) {
+	contributions, err := s.postUnlockRepo.ListContributionsByStatus(ctx, post.ID, models.PostUnlockContributionHeld)
+	if err != nil {
+		return 0, err
+	}
+
+	refunded := 0
+	notified := make(map[uuid.UUID]bool)
+	for _, contribution := range contributions {
+		if err := s.walletService.ReleaseHold(ctx, contribution.HoldID); err != nil {
+			return refunded, err
+		}
+		if err := s.postUnlockRepo.UpdateContributionStatus(ctx, contribution.ID, models.PostUnlockContributionReleased); err != nil {
+			return refunded, err
+		}
+		refunded++
+
+		if !notified[contribution.UserID] {
+			notified[contribution.UserID] = true
+			senderID := contribution.UserID
+			if post.AuthorID != uuid.Nil {
+				senderID = post.AuthorID
+			}
+			_ = s.notifService.CreateNotification(
+				ctx,
+				contribution.UserID,
+				senderID,
+				"unlock",
+				"โพสต์ที่คุณร่วมสนับสนุนไม่ถึงเป้าหมาย ระบบได้คืนเงินเข้ากระเป๋าของคุณแล้ว",
+				&contribution.PostID,
+				nil,
+			)
+		}
+	}
+
+	return refunded, nil
+}
+
+// notifyUnlocked tells the creator and every contributor that the post is now unlocked
+func (s *PostUnlockServiceImpl) notifyUnlocked(ctx context.Context, post *models.Post, lastContributorID uuid.UUID) {
+	_ = s.notifService.CreateNotification(
+		ctx,
+		post.AuthorID,
+		lastContributorID,
+		"unlock",
+		"โพสต์ของคุณถึงเป้าหมายแล้ว! รายได้เข้ากระเป๋าของคุณเรียบร้อย",
+		&post.ID,
+		nil,
+	)
+
+	totals, err := s.postUnlockRepo.ListContributors(ctx, post.ID, 0, post.UnlockContributorCount)
+	if err != nil {
+		log.Printf("[UNLOCK] Failed to list contributors for post %s: %v", post.ID, err)
+		return
+	}
+	for _, total := range totals {
+		_ = s.notifService.CreateNotification(
+			ctx,
+			total.UserID,
+			post.AuthorID,
+			"unlock",
+			"โพสต์ที่คุณร่วมสนับสนุนปลดล็อกแล้ว! เข้าไปดูได้เลย",
+			&post.ID,
+			nil,
+		)
+	}
+}
+
+func (s *PostUnlockServiceImpl) buildContributeResponse(ctx context.Context, contribution *models.PostUnlockContribution, justUnlocked bool) (*dto.ContributeUnlockResponse, error) {
+	post, err := s.postRepo.GetByID(ctx, contribution.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := dto.PostToPostUnlockInfo(post)
+	if unlock != nil && post.IsUnlocked() {
+		unlock.IsLocked = false
+	}
+
+	return &dto.ContributeUnlockResponse{
+		ContributionID: contribution.ID,
+		Amount:         contribution.Amount,
+		Unlock:         unlock,
+		JustUnlocked:   justUnlocked,
+	}, nil
+}
+
+// Ensure interface compliance
+var _ services.PostUnlockService = (*PostUnlockServiceImpl)(nil)
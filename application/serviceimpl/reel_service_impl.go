@@ -0,0 +1,458 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/storage"
+)
+
+const (
+	reelDailyLimit   = 5   // reels per creator per 24 hours
+	reelFeedLimit    = 500 // max reels loaded for the following feed
+	reelBatchSize    = 100
+	reelDeleteGiveUp = 24 * time.Hour // stop retrying a failing Bunny delete after this long past expiry
+)
+
+type ReelServiceImpl struct {
+	reelRepo     repositories.ReelRepository
+	mediaRepo    repositories.MediaRepository
+	followRepo   repositories.FollowRepository
+	mediaService services.MediaService
+	bunnyStream  *storage.BunnyStreamService
+	notifService services.NotificationService
+}
+
+func NewReelService(
+	reelRepo repositories.ReelRepository,
+	mediaRepo repositories.MediaRepository,
+	followRepo repositories.FollowRepository,
+	mediaService services.MediaService,
+	bunnyStream *storage.BunnyStreamService,
+	notifService services.NotificationService,
+) services.ReelService {
+	return &ReelServiceImpl{
+		reelRepo:     reelRepo,
+		mediaRepo:    mediaRepo,
+		followRepo:   followRepo,
+		mediaService: mediaService,
+		bunnyStream:  bunnyStream,
+		notifService: notifService,
+	}
+}
+
+// ==================== Creator Side ====================
+
+func (s *ReelServiceImpl) CreateReel(ctx context.Context, userID uuid.UUID, req *dto.CreateReelRequest, file multipart.File, filename string) (*dto.ReelResponse, error) {
+	now := time.Now()
+
+	count, err := s.reelRepo.CountCreatedSince(ctx, userID, now.Add(-models.ReelTTL))
+	if err != nil {
+		return nil, err
+	}
+	if count >= reelDailyLimit {
+		return nil, services.ErrReelDailyLimit
+	}
+
+	visibility := req.Visibility
+	if visibility == "" {
+		visibility = models.ReelVisibilityPublic
+	}
+
+	reel := &models.Reel{
+		ID:         uuid.New(),
+		CreatorID:  userID,
+		Caption:    req.Caption,
+		Status:     models.ReelStatusProcessing,
+		Visibility: visibility,
+		ExpiresAt:  now.Add(models.ReelTTL),
+	}
+
+	// Upload to Bunny Stream - the VideoEncoderWorker activates the reel once encoded
+	media, err := s.mediaService.CreateVideo(ctx, userID, models.ReelMediaSourceType, &reel.ID, file, filename)
+	if err != nil {
+		return nil, err
+	}
+	reel.MediaID = &media.ID
+
+	if err := s.reelRepo.Create(ctx, reel); err != nil {
+		if delErr := s.deleteVideo(ctx, reel); delErr != nil {
+			log.Printf("[REEL] Failed to delete video of unsaved reel %s: %v", reel.ID, delErr)
+		}
+		return nil, err
+	}
+
+	return s.getReelResponse(ctx, reel.ID)
+}
+
+func (s *ReelServiceImpl) ListMyReels(ctx context.Context, userID uuid.UUID) (*dto.ReelListResponse, error) {
+	reels, err := s.reelRepo.ListCurrentByCreator(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ReelListResponse{
+		Reels: make([]dto.ReelResponse, len(reels)),
+	}
+	for i, reel := range reels {
+		resp.Reels[i] = *dto.ReelToReelResponse(reel)
+	}
+	return resp, nil
+}
+
+func (s *ReelServiceImpl) ListViewers(ctx context.Context, reelID uuid.UUID, userID uuid.UUID, offset, limit int) (*dto.ReelViewerListResponse, error) {
+	reel, err := s.reelRepo.GetByID(ctx, reelID)
+	if err != nil {
+		return nil, services.ErrReelNotFound
+	}
+
+	if reel.CreatorID != userID {
+		return nil, errors.New("unauthorized: not reel owner")
+	}
+
+	views, err := s.reelRepo.ListViewers(ctx, reelID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total := int64(reel.ViewCount)
+	resp := &dto.ReelViewerListResponse{
+		Viewers: make([]dto.ReelViewerResponse, len(views)),
+		Meta: dto.PaginationMeta{
+			Total:  &total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	for i, view := range views {
+		resp.Viewers[i] = *dto.ReelViewToReelViewerResponse(view)
+	}
+	return resp, nil
+}
+
+func (s *ReelServiceImpl) DeleteReel(ctx context.Context, reelID uuid.UUID, userID uuid.UUID) error {
+	reel, err := s.reelRepo.GetByID(ctx, reelID)
+	if err != nil {
+		return services.ErrReelNotFound
+	}
+
+	if reel.CreatorID != userID {
+		return errors.New("unauthorized: not reel owner")
+	}
+
+	if reel.Status == models.ReelStatusDeleted || reel.Status == models.ReelStatusExpired {
+		return services.ErrReelNotFound
+	}
+
+	if err := s.deleteVideo(ctx, reel); err != nil {
+		return fmt.Errorf("failed to delete reel video: %w", err)
+	}
+
+	reel.Status = models.ReelStatusDeleted
+	reel.MediaID = nil
+	reel.Media = nil
+	if err := s.reelRepo.Update(ctx, reel); err != nil {
+		return err
+	}
+
+	return s.reelRepo.DeleteViews(ctx, reel.ID)
+}
+
+// ==================== Viewer Side ====================
+
+func (s *ReelServiceImpl) GetReel(ctx context.Context, reelID uuid.UUID, userID *uuid.UUID) (*dto.ReelResponse, error) {
+	reel, err := s.reelRepo.GetByID(ctx, reelID)
+	if err != nil {
+		return nil, services.ErrReelNotFound
+	}
+
+	if !s.canView(ctx, reel, userID) {
+		return nil, services.ErrReelNotFound
+	}
+
+	resp := dto.ReelToReelResponse(reel)
+	if userID != nil {
+		viewed, _ := s.reelRepo.GetViewedReelIDs(ctx, *userID, []uuid.UUID{reel.ID})
+		hasViewed := viewed[reel.ID]
+		resp.HasViewed = &hasViewed
+	}
+	return resp, nil
+}
+
+func (s *ReelServiceImpl) ListFollowingReels(ctx context.Context, userID uuid.UUID) (*dto.ReelFeedResponse, error) {
+	// Newest first, so the limit keeps the latest reels
+	reels, err := s.reelRepo.ListActiveFromFollowing(ctx, userID, time.Now(), reelFeedLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	reelIDs := make([]uuid.UUID, len(reels))
+	for i, reel := range reels {
+		reelIDs[i] = reel.ID
+	}
+	viewedMap, err := s.reelRepo.GetViewedReelIDs(ctx, userID, reelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group by creator (story rings)
+	groups := make([]*dto.ReelCreatorGroup, 0)
+	groupByCreator := make(map[uuid.UUID]*dto.ReelCreatorGroup)
+	for _, reel := range reels {
+		group, ok := groupByCreator[reel.CreatorID]
+		if !ok {
+			group = &dto.ReelCreatorGroup{
+				Creator:  dto.UserToUserResponse(&reel.Creator),
+				Reels:    []dto.ReelResponse{},
+				LatestAt: reel.CreatedAt,
+			}
+			groupByCreator[reel.CreatorID] = group
+			groups = append(groups, group)
+		}
+
+		resp := dto.ReelToReelResponse(reel)
+		hasViewed := viewedMap[reel.ID]
+		resp.HasViewed = &hasViewed
+		if !hasViewed {
+			group.HasUnviewed = true
+		}
+
+		// Reels inside a ring play oldest first
+		group.Reels = append([]dto.ReelResponse{*resp}, group.Reels...)
+	}
+
+	// Rings with unviewed reels first, then most recent
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].HasUnviewed != groups[j].HasUnviewed {
+			return groups[i].HasUnviewed
+		}
+		return groups[i].LatestAt.After(groups[j].LatestAt)
+	})
+
+	resp := &dto.ReelFeedResponse{
+		Creators: make([]dto.ReelCreatorGroup, len(groups)),
+	}
+	for i, group := range groups {
+		resp.Creators[i] = *group
+	}
+	return resp, nil
+}
+
+func (s *ReelServiceImpl) ListUserReels(ctx context.Context, creatorID uuid.UUID, userID *uuid.UUID) (*dto.ReelListResponse, error) {
+	reels, err := s.reelRepo.ListActiveByCreator(ctx, creatorID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Followers-only reels need a follow relationship
+	isFollowing := false
+	if userID != nil && *userID != creatorID {
+		isFollowing, _ = s.followRepo.IsFollowing(ctx, *userID, creatorID)
+	}
+
+	visible := make([]*models.Reel, 0, len(reels))
+	for _, reel := range reels {
+		if reel.Visibility == models.ReelVisibilityFollowers && !isFollowing &&
+			(userID == nil || *userID != creatorID) {
+			continue
+		}
+		visible = append(visible, reel)
+	}
+
+	var viewedMap map[uuid.UUID]bool
+	if userID != nil {
+		reelIDs := make([]uuid.UUID, len(visible))
+		for i, reel := range visible {
+			reelIDs[i] = reel.ID
+		}
+		viewedMap, _ = s.reelRepo.GetViewedReelIDs(ctx, *userID, reelIDs)
+	}
+
+	resp := &dto.ReelListResponse{
+		Reels: make([]dto.ReelResponse, len(visible)),
+	}
+	for i, reel := range visible {
+		reelResp := dto.ReelToReelResponse(reel)
+		if userID != nil {
+			hasViewed := viewedMap[reel.ID]
+			reelResp.HasViewed = &hasViewed
+		}
+		resp.Reels[i] = *reelResp
+	}
+	return resp, nil
+}
+
+func (s *ReelServiceImpl) RecordView(ctx context.Context, reelID uuid.UUID, userID uuid.UUID, req *dto.RecordReelViewRequest) (*dto.ReelResponse, error) {
+	reel, err := s.reelRepo.GetByID(ctx, reelID)
+	if err != nil {
+		return nil, services.ErrReelNotFound
+	}
+
+	if !reel.IsViewable(time.Now()) || !s.canView(ctx, reel, &userID) {
+		return nil, services.ErrReelNotViewable
+	}
+
+	// Creators watching their own reel don't count as viewers
+	if reel.CreatorID != userID {
+		firstView, err := s.reelRepo.RecordView(ctx, &models.ReelView{
+			ReelID:        reel.ID,
+			ViewerID:      userID,
+			WatchDuration: req.WatchDuration,
+			Completed:     req.Completed,
+			ViewedAt:      time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if firstView {
+			reel.ViewCount++
+		}
+	}
+
+	resp := dto.ReelToReelResponse(reel)
+	hasViewed := true
+	resp.HasViewed = &hasViewed
+	return resp, nil
+}
+
+// ==================== Encoding & Scheduler ====================
+
+func (s *ReelServiceImpl) HandleVideoEncoded(ctx context.Context, mediaID uuid.UUID, status string) error {
+	reel, err := s.reelRepo.GetByMediaID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+
+	// Worker and webhook may both report the same video
+	if reel.Status != models.ReelStatusProcessing {
+		return nil
+	}
+
+	switch status {
+	case "completed":
+		// The 24 hours start when the reel goes live
+		reel.Status = models.ReelStatusActive
+		reel.ExpiresAt = time.Now().Add(models.ReelTTL)
+	case "failed":
+		reel.Status = models.ReelStatusFailed
+	default:
+		return nil
+	}
+
+	return s.reelRepo.Update(ctx, reel)
+}
+
+func (s *ReelServiceImpl) ProcessExpiredReels(ctx context.Context) (int, error) {
+	now := time.Now()
+	reels, err := s.reelRepo.ListExpired(ctx, now, reelBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, reel := range reels {
+		if err := s.deleteVideo(ctx, reel); err != nil {
+			if now.Sub(reel.ExpiresAt) < reelDeleteGiveUp {
+				log.Printf("[REEL] Failed to delete video of reel %s (will retry): %v", reel.ID, err)
+				continue
+			}
+			log.Printf("[REEL] Giving up deleting video of reel %s: %v", reel.ID, err)
+		}
+
+		wasActive := reel.Status == models.ReelStatusActive
+		reel.Status = models.ReelStatusExpired
+		reel.MediaID = nil
+		reel.Media = nil
+		if err := s.reelRepo.Update(ctx, reel); err != nil {
+			log.Printf("[REEL] Failed to expire reel %s: %v", reel.ID, err)
+			continue
+		}
+
+		// Viewer lists are not kept after expiry (view count stays for analytics)
+		if err := s.reelRepo.DeleteViews(ctx, reel.ID); err != nil {
+			log.Printf("[REEL] Failed to delete views of reel %s: %v", reel.ID, err)
+		}
+		expired++
+
+		if wasActive {
+			_ = s.notifService.CreateNotification(
+				ctx,
+				reel.CreatorID,
+				reel.CreatorID,
+				"reel",
+				fmt.Sprintf("Reel ของคุณหมดอายุแล้ว มีผู้ชม %d คน", reel.ViewCount),
+				nil,
+				nil,
+			)
+		}
+	}
+
+	return expired, nil
+}
+
+// deleteVideo removes the reel video from Bunny Stream and its media record
+func (s *ReelServiceImpl) deleteVideo(ctx context.Context, reel *models.Reel) error {
+	if reel.MediaID == nil {
+		return nil
+	}
+
+	media := reel.Media
+	if media == nil {
+		var err error
+		media, err = s.mediaRepo.GetByID(ctx, *reel.MediaID)
+		if err != nil {
+			// Already gone
+			return nil
+		}
+	}
+
+	if media.VideoID != nil && s.bunnyStream != nil {
+		if err := s.bunnyStream.DeleteVideo(*media.VideoID); err != nil {
+			return err
+		}
+	}
+
+	return s.mediaRepo.Delete(ctx, media.ID)
+}
+
+// canView checks reel status and visibility for the viewer (creators always see their own reels)
+func (s *ReelServiceImpl) canView(ctx context.Context, reel *models.Reel, userID *uuid.UUID) bool {
+	if userID != nil && reel.CreatorID == *userID {
+		return reel.Status != models.ReelStatusDeleted && reel.Status != models.ReelStatusExpired
+	}
+
+	if !reel.IsViewable(time.Now()) {
+		return false
+	}
+
+	if reel.Visibility == models.ReelVisibilityFollowers {
+		if userID == nil {
+			return false
+		}
+		isFollowing, err := s.followRepo.IsFollowing(ctx, *userID, reel.CreatorID)
+		return err == nil && isFollowing
+	}
+
+	return true
+}
+
+func (s *ReelServiceImpl) getReelResponse(ctx context.Context, id uuid.UUID) (*dto.ReelResponse, error) {
+	reel, err := s.reelRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ReelToReelResponse(reel), nil
+}
+
+// Ensure interface compliance
+var _ services.ReelService = (*ReelServiceImpl)(nil)
@@ -0,0 +1,361 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gorm.io/gorm"
+)
+
+const reportSnapshotMaxLen = 2000 // runes
+
+const reportSuspensionHours = 7 * 24 // default length of a suspension applied from a report
+
+// reportTargetLabels names report targets in notifications
+var reportTargetLabels = map[string]string{
+	models.ReportTargetPost:    "โพสต์",
+	models.ReportTargetComment: "ความคิดเห็น",
+	models.ReportTargetMessage: "ข้อความ",
+	models.ReportTargetUser:    "บัญชีผู้ใช้",
+}
+
+type ReportServiceImpl struct {
+	reportRepo       repositories.ReportRepository
+	postRepo         repositories.PostRepository
+	commentRepo      repositories.CommentRepository
+	messageRepo      repositories.MessageRepository
+	conversationRepo repositories.ConversationRepository
+	userRepo         repositories.UserRepository
+	postService      services.PostService
+	commentService   services.CommentService
+	giftService      services.GiftService
+	notifService     services.NotificationService
+	sanctionService  services.SanctionService
+}
+
+func NewReportService(
+	reportRepo repositories.ReportRepository,
+	postRepo repositories.PostRepository,
+	commentRepo repositories.CommentRepository,
+	messageRepo repositories.MessageRepository,
+	conversationRepo repositories.ConversationRepository,
+	userRepo repositories.UserRepository,
+	postService services.PostService,
+	commentService services.CommentService,
+	giftService services.GiftService,
+	notifService services.NotificationService,
+	sanctionService services.SanctionService,
+) services.ReportService {
+	return &ReportServiceImpl{
+		reportRepo:       reportRepo,
+		postRepo:         postRepo,
+		commentRepo:      commentRepo,
+		messageRepo:      messageRepo,
+		conversationRepo: conversationRepo,
+		userRepo:         userRepo,
+		postService:      postService,
+		commentService:   commentService,
+		giftService:      giftService,
+		notifService:     notifService,
+		sanctionService:  sanctionService,
+	}
+}
+
+// ==================== Reporter side ====================
+
+func (s *ReportServiceImpl) CreateReport(ctx context.Context, reporterID uuid.UUID, req *dto.CreateReportRequest) (*dto.ReportResponse, error) {
+	targetUserID, snapshot, err := s.resolveTarget(ctx, reporterID, req.TargetType, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if targetUserID == reporterID {
+		return nil, services.ErrReportOwnContent
+	}
+
+	exists, err := s.reportRepo.HasOpenReport(ctx, reporterID, req.TargetType, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, services.ErrReportDuplicate
+	}
+
+	report := &models.Report{
+		ID:           uuid.New(),
+		ReporterID:   reporterID,
+		TargetType:   req.TargetType,
+		TargetID:     req.TargetID,
+		TargetUserID: targetUserID,
+		Snapshot:     truncateRunes(snapshot, reportSnapshotMaxLen),
+		ReasonCode:   req.ReasonCode,
+		Details:      strings.TrimSpace(req.Details),
+		Status:       models.ReportStatusOpen,
+	}
+	if err := s.reportRepo.Create(ctx, report); err != nil {
+		// Lost a race with a double submit (one open report per reporter per target)
+		if exists, _ := s.reportRepo.HasOpenReport(ctx, reporterID, req.TargetType, req.TargetID); exists {
+			return nil, services.ErrReportDuplicate
+		}
+		return nil, err
+	}
+
+	resp := dto.ReportToReportResponse(report)
+	resp.Snapshot = ""
+	return resp, nil
+}
+
+func (s *ReportServiceImpl) ListMyReports(ctx context.Context, reporterID uuid.UUID, offset, limit int) (*dto.ReportListResponse, error) {
+	reports, err := s.reportRepo.ListByReporter(ctx, reporterID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.reportRepo.CountByReporter(ctx, reporterID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := buildReportListResponse(reports, total, offset, limit)
+	for i := range resp.Reports {
+		resp.Reports[i].Snapshot = ""
+	}
+	return resp, nil
+}
+
+// ==================== Moderator side ====================
+
+func (s *ReportServiceImpl) ListReports(ctx context.Context, status, targetType string, offset, limit int) (*dto.ReportListResponse, error) {
+	if status == "" {
+		status = models.ReportStatusOpen
+	}
+
+	reports, err := s.reportRepo.ListByStatus(ctx, status, targetType, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.reportRepo.CountByStatus(ctx, status, targetType)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := buildReportListResponse(reports, total, offset, limit)
+	if status == models.ReportStatusOpen {
+		for i, report := range reports {
+			resp.Reports[i].OpenReports, _ = s.reportRepo.CountOpenByTarget(ctx, report.TargetType, report.TargetID)
+		}
+	}
+	return resp, nil
+}
+
+func (s *ReportServiceImpl) GetReport(ctx context.Context, reportID uuid.UUID) (*dto.ReportResponse, error) {
+	report, err := s.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, services.ErrReportNotFound
+	}
+
+	resp := dto.ReportToReportResponse(report)
+	if report.IsOpen() {
+		resp.OpenReports, _ = s.reportRepo.CountOpenByTarget(ctx, report.TargetType, report.TargetID)
+	}
+	return resp, nil
+}
+
+func (s *ReportServiceImpl) ResolveReport(ctx context.Context, reportID uuid.UUID, moderatorID uuid.UUID, req *dto.ResolveReportRequest) (*dto.ReportResponse, error) {
+	report, err := s.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, services.ErrReportNotFound
+	}
+	if !report.IsOpen() {
+		return nil, services.ErrReportClosed
+	}
+
+	// Reporters to notify once the target is closed
+	openReports, err := s.reportRepo.ListOpenByTarget(ctx, report.TargetType, report.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if err := s.applyAction(ctx, report, moderatorID, req.Action, note, req.DurationHours); err != nil {
+		return nil, err
+	}
+
+	status := models.ReportStatusResolved
+	if req.Action == models.ReportActionDismiss {
+		status = models.ReportStatusDismissed
+	}
+	var notePtr *string
+	if note != "" {
+		notePtr = &note
+	}
+	if err := s.reportRepo.ResolveOpenByTarget(ctx, report.TargetType, report.TargetID, status, req.Action, moderatorID, notePtr); err != nil {
+		return nil, err
+	}
+
+	label := reportTargetLabels[report.TargetType]
+	message := fmt.Sprintf("เราได้ตรวจสอบ%sที่คุณรายงานแล้วและดำเนินการเรียบร้อย ขอบคุณที่ช่วยดูแลชุมชน", label)
+	if status == models.ReportStatusDismissed {
+		message = fmt.Sprintf("เราได้ตรวจสอบ%sที่คุณรายงานแล้ว ไม่พบการละเมิดกฎของชุมชน", label)
+	}
+	for _, open := range openReports {
+		_ = s.notifService.CreateNotification(ctx, open.ReporterID, moderatorID, "report", message, nil, nil)
+	}
+
+	resolved, err := s.reportRepo.GetByID(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ReportToReportResponse(resolved), nil
+}
+
+// applyAction carries out a moderator decision on the reported target
+func (s *ReportServiceImpl) applyAction(ctx context.Context, report *models.Report, moderatorID uuid.UUID, action, note string, durationHours int) error {
+	reason := note
+	if reason == "" {
+		reason = "reported: " + report.ReasonCode
+	}
+
+	switch action {
+	case models.ReportActionDismiss:
+		return nil
+
+	case models.ReportActionRemove:
+		var err error
+		switch report.TargetType {
+		case models.ReportTargetPost:
+			err = s.postService.RemovePost(ctx, report.TargetID, moderatorID, reason)
+		case models.ReportTargetComment:
+			err = s.commentService.RemoveComment(ctx, report.TargetID, moderatorID, reason)
+		case models.ReportTargetMessage:
+			// Keep the row: a gift's escrow record hangs off it
+			_, err = s.messageRepo.Tombstone(ctx, report.TargetID)
+			if err == nil {
+				err = s.giftService.RefundRemovedGift(ctx, report.TargetID)
+			}
+		default:
+			return services.ErrReportInvalidAction
+		}
+		// Already deleted by its author
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		_ = s.notifService.CreateNotification(
+			ctx,
+			report.TargetUserID,
+			moderatorID,
+			"moderation",
+			fmt.Sprintf("%sของคุณถูกลบโดยผู้ดูแลระบบ: %s", reportTargetLabels[report.TargetType], reason),
+			nil,
+			nil,
+		)
+		return nil
+
+	case models.ReportActionWarn:
+		_ = s.notifService.CreateNotification(
+			ctx,
+			report.TargetUserID,
+			moderatorID,
+			"moderation",
+			fmt.Sprintf("คุณได้รับคำเตือนจากผู้ดูแลระบบ: %s", reason),
+			nil,
+			nil,
+		)
+		return nil
+
+	case models.ReportActionSuspend:
+		if durationHours == 0 {
+			durationHours = reportSuspensionHours
+		}
+		_, err := s.sanctionService.ApplySanction(ctx, moderatorID, report.TargetUserID, &dto.ApplySanctionRequest{
+			Type:          models.SanctionTypeSuspension,
+			Reason:        reason,
+			DurationHours: durationHours,
+		})
+		switch {
+		case errors.Is(err, services.ErrSanctionUserNotFound):
+			return services.ErrReportTargetNotFound
+		// Moderators and staff cannot be suspended through a report
+		case errors.Is(err, services.ErrSanctionSelf), errors.Is(err, services.ErrSanctionProtected):
+			return services.ErrReportInvalidAction
+		}
+		return err
+	}
+
+	return services.ErrReportInvalidAction
+}
+
+// resolveTarget returns the user behind a report target and a snapshot of its content
+func (s *ReportServiceImpl) resolveTarget(ctx context.Context, reporterID uuid.UUID, targetType string, targetID uuid.UUID) (uuid.UUID, string, error) {
+	switch targetType {
+	case models.ReportTargetPost:
+		post, err := s.postRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return uuid.Nil, "", services.ErrReportTargetNotFound
+		}
+		return post.AuthorID, post.Title + "\n\n" + post.Content, nil
+
+	case models.ReportTargetComment:
+		comment, err := s.commentRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return uuid.Nil, "", services.ErrReportTargetNotFound
+		}
+		return comment.AuthorID, comment.Content, nil
+
+	case models.ReportTargetMessage:
+		// Only participants of the conversation can report a message
+		message, err := s.messageRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return uuid.Nil, "", services.ErrReportTargetNotFound
+		}
+		if _, err := s.conversationRepo.GetParticipant(ctx, message.ConversationID, reporterID); err != nil {
+			return uuid.Nil, "", services.ErrReportTargetNotFound
+		}
+		snapshot := "[" + string(message.Type) + "]"
+		if message.Content != nil {
+			snapshot = *message.Content
+		}
+		return message.SenderID, snapshot, nil
+
+	case models.ReportTargetUser:
+		user, err := s.userRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return uuid.Nil, "", services.ErrReportTargetNotFound
+		}
+		return user.ID, fmt.Sprintf("@%s (%s)\n%s", user.Username, user.DisplayName, user.Bio), nil
+	}
+
+	return uuid.Nil, "", services.ErrReportTargetNotFound
+}
+
+func buildReportListResponse(reports []*models.Report, total int64, offset, limit int) *dto.ReportListResponse {
+	resp := &dto.ReportListResponse{
+		Reports: make([]dto.ReportResponse, len(reports)),
+		Meta: dto.PaginationMeta{
+			Total:  &total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	for i, report := range reports {
+		resp.Reports[i] = *dto.ReportToReportResponse(report)
+	}
+	return resp
+}
+
+// truncateRunes cuts s to at most max runes
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
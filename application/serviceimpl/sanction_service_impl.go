@@ -0,0 +1,343 @@
+package serviceimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/redis"
+	"gofiber-template/infrastructure/websocket"
+	"gorm.io/gorm"
+)
+
+// accountStatusCacheTTL bounds staleness if an invalidation is lost (sanction changes drop the cache right away)
+const accountStatusCacheTTL = 10 * time.Minute
+
+// accountStatus - Sanctions in force on a user, as cached in Redis
+type accountStatus struct {
+	SuspendedUntil   *time.Time `json:"suspendedUntil,omitempty"`
+	SuspensionReason string     `json:"suspensionReason,omitempty"`
+	Banned           bool       `json:"banned,omitempty"`
+	BanReason        string     `json:"banReason,omitempty"`
+	Shadowbanned     bool       `json:"shadowbanned,omitempty"`
+}
+
+// restriction returns the error write paths report for a suspended or banned account (nil = allowed)
+func (st *accountStatus) restriction(now time.Time) error {
+	if st.Banned {
+		return fmt.Errorf("%w: %s", services.ErrAccountBanned, st.BanReason)
+	}
+	if st.SuspendedUntil != nil && st.SuspendedUntil.After(now) {
+		return fmt.Errorf("%w until %s: %s", services.ErrAccountSuspended, st.SuspendedUntil.UTC().Format(time.RFC3339), st.SuspensionReason)
+	}
+	return nil
+}
+
+// cacheTTL ends the cache entry with the suspension so an expired suspension is not served from cache
+func (st *accountStatus) cacheTTL(now time.Time) time.Duration {
+	if st.SuspendedUntil != nil && st.SuspendedUntil.After(now) {
+		if remaining := st.SuspendedUntil.Sub(now); remaining < accountStatusCacheTTL {
+			return remaining
+		}
+	}
+	return accountStatusCacheTTL
+}
+
+// loginRestriction refuses new sessions for suspended and banned accounts (from the denormalized user columns)
+func loginRestriction(user *models.User) error {
+	if user.IsBanned {
+		return services.ErrAccountBanned
+	}
+	if user.IsRestricted() {
+		return fmt.Errorf("%w until %s", services.ErrAccountSuspended, user.SuspendedUntil.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+type SanctionServiceImpl struct {
+	sanctionRepo repositories.UserSanctionRepository
+	userRepo     repositories.UserRepository
+	postRepo     repositories.PostRepository
+	commentRepo  repositories.CommentRepository
+	authzService services.AuthorizationService
+	notifService services.NotificationService
+	redisService *redis.RedisService
+	chatHub      *websocket.ChatHub
+}
+
+func NewSanctionService(
+	sanctionRepo repositories.UserSanctionRepository,
+	userRepo repositories.UserRepository,
+	postRepo repositories.PostRepository,
+	commentRepo repositories.CommentRepository,
+	authzService services.AuthorizationService,
+	notifService services.NotificationService,
+	redisService *redis.RedisService,
+) services.SanctionService {
+	return &SanctionServiceImpl{
+		sanctionRepo: sanctionRepo,
+		userRepo:     userRepo,
+		postRepo:     postRepo,
+		commentRepo:  commentRepo,
+		authzService: authzService,
+		notifService: notifService,
+		redisService: redisService,
+		chatHub:      nil, // Will be set later via SetChatHub
+	}
+}
+
+// SetChatHub sets the ChatHub dependency (to avoid circular dependency)
+func (s *SanctionServiceImpl) SetChatHub(chatHub *websocket.ChatHub) {
+	s.chatHub = chatHub
+}
+
+// ==================== Enforcement ====================
+
+func (s *SanctionServiceImpl) AccountRestriction(ctx context.Context, userID uuid.UUID) (string, error) {
+	status, err := s.getAccountStatus(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if restriction := status.restriction(time.Now()); restriction != nil {
+		return restriction.Error(), nil
+	}
+	return "", nil
+}
+
+func (s *SanctionServiceImpl) CheckWriteAccess(ctx context.Context, userID uuid.UUID) (bool, error) {
+	status, err := s.getAccountStatus(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if restriction := status.restriction(time.Now()); restriction != nil {
+		return false, restriction
+	}
+	return status.Shadowbanned, nil
+}
+
+// ==================== Moderation ====================
+
+func (s *SanctionServiceImpl) ApplySanction(ctx context.Context, moderatorID, userID uuid.UUID, req *dto.ApplySanctionRequest) (*dto.UserSanctionResponse, error) {
+	if moderatorID == userID {
+		return nil, services.ErrSanctionSelf
+	}
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, services.ErrSanctionUserNotFound
+	}
+
+	// Staff who can sanction cannot be sanctioned
+	isStaff, err := s.authzService.HasPermission(ctx, userID, models.PermUserSuspend, nil)
+	if err != nil {
+		return nil, err
+	}
+	if isStaff {
+		return nil, services.ErrSanctionProtected
+	}
+
+	now := time.Now()
+	sanction := &models.UserSanction{
+		UserID:     userID,
+		Type:       req.Type,
+		Reason:     req.Reason,
+		IssuedByID: moderatorID,
+		CreatedAt:  now,
+	}
+	if (req.Type == models.SanctionTypeSuspension) != (req.DurationHours > 0) {
+		return nil, services.ErrSanctionDuration
+	}
+	if req.DurationHours > 0 {
+		expiresAt := now.Add(time.Duration(req.DurationHours) * time.Hour)
+		sanction.ExpiresAt = &expiresAt
+	}
+
+	if err := s.sanctionRepo.Create(ctx, sanction); err != nil {
+		return nil, err
+	}
+
+	status, err := s.refreshAccountStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shadowbans stay silent; suspensions and bans end the live chat connection
+	if restriction := status.restriction(now); restriction != nil {
+		if s.chatHub != nil {
+			s.chatHub.DisconnectUser(userID, restriction.Error())
+		}
+		message := fmt.Sprintf("บัญชีของคุณถูกแบนโดยผู้ดูแลระบบ: %s", req.Reason)
+		if sanction.ExpiresAt != nil {
+			message = fmt.Sprintf("บัญชีของคุณถูกระงับการใช้งานถึง %s: %s", sanction.ExpiresAt.Format("02/01/2006 15:04"), req.Reason)
+		}
+		_ = s.notifService.CreateNotification(ctx, userID, moderatorID, "moderation", message, nil, nil)
+	}
+
+	return s.getSanctionResponse(ctx, sanction.ID)
+}
+
+func (s *SanctionServiceImpl) LiftSanction(ctx context.Context, moderatorID, sanctionID uuid.UUID, req *dto.LiftSanctionRequest) (*dto.UserSanctionResponse, error) {
+	sanction, err := s.sanctionRepo.GetByID(ctx, sanctionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, services.ErrSanctionNotFound
+		}
+		return nil, err
+	}
+	if !sanction.IsActive(time.Now()) {
+		return nil, services.ErrSanctionInactive
+	}
+
+	lifted, err := s.sanctionRepo.Lift(ctx, sanction.ID, moderatorID, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if !lifted {
+		return nil, services.ErrSanctionInactive
+	}
+
+	status, err := s.refreshAccountStatus(ctx, sanction.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case sanction.Type == models.SanctionTypeShadowban && !status.Shadowbanned:
+		// Content written while shadowbanned becomes visible again
+		if err := s.postRepo.SetShadowedByAuthor(ctx, sanction.UserID, false); err != nil {
+			return nil, err
+		}
+		if err := s.commentRepo.SetShadowedByAuthor(ctx, sanction.UserID, false); err != nil {
+			return nil, err
+		}
+	case sanction.Type != models.SanctionTypeShadowban && status.restriction(time.Now()) == nil:
+		_ = s.notifService.CreateNotification(ctx, sanction.UserID, moderatorID, "moderation", "บัญชีของคุณได้รับการปลดการระงับแล้ว", nil, nil)
+	}
+
+	return s.getSanctionResponse(ctx, sanction.ID)
+}
+
+func (s *SanctionServiceImpl) ListSanctions(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.UserSanctionListResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, services.ErrSanctionUserNotFound
+	}
+
+	sanctions, err := s.sanctionRepo.ListByUser(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.sanctionRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.UserSanctionResponse, 0, len(sanctions))
+	for _, sanction := range sanctions {
+		responses = append(responses, *dto.UserSanctionToResponse(sanction))
+	}
+
+	resp := &dto.UserSanctionListResponse{
+		IsBanned:       user.IsBanned,
+		IsShadowbanned: user.IsShadowbanned,
+		Sanctions:      responses,
+		Meta: dto.PaginationMeta{
+			Total:  &total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	if user.SuspendedUntil != nil && user.SuspendedUntil.After(time.Now()) {
+		resp.SuspendedUntil = user.SuspendedUntil
+	}
+	return resp, nil
+}
+
+func (s *SanctionServiceImpl) getSanctionResponse(ctx context.Context, sanctionID uuid.UUID) (*dto.UserSanctionResponse, error) {
+	sanction, err := s.sanctionRepo.GetByID(ctx, sanctionID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.UserSanctionToResponse(sanction), nil
+}
+
+// ==================== Account status ====================
+
+// loadAccountStatus merges the sanctions in force (the newest reason of each kind is shown to the user)
+func (s *SanctionServiceImpl) loadAccountStatus(ctx context.Context, userID uuid.UUID, now time.Time) (*accountStatus, error) {
+	sanctions, err := s.sanctionRepo.ListActiveByUser(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &accountStatus{}
+	for _, sanction := range sanctions {
+		switch sanction.Type {
+		case models.SanctionTypeBan:
+			if !status.Banned {
+				status.Banned = true
+				status.BanReason = sanction.Reason
+			}
+		case models.SanctionTypeSuspension:
+			if status.SuspendedUntil == nil || sanction.ExpiresAt.After(*status.SuspendedUntil) {
+				status.SuspendedUntil = sanction.ExpiresAt
+				status.SuspensionReason = sanction.Reason
+			}
+		case models.SanctionTypeShadowban:
+			status.Shadowbanned = true
+		}
+	}
+	return status, nil
+}
+
+// refreshAccountStatus stores the merged state on the user after a sanction changes and drops the cache
+func (s *SanctionServiceImpl) refreshAccountStatus(ctx context.Context, userID uuid.UUID) (*accountStatus, error) {
+	status, err := s.loadAccountStatus(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.SetModerationStatus(ctx, userID, status.SuspendedUntil, status.Banned, status.Shadowbanned); err != nil {
+		return nil, err
+	}
+
+	if s.redisService != nil {
+		if err := s.redisService.InvalidateAccountStatus(ctx, userID); err != nil {
+			log.Printf("⚠️  Failed to drop cached account status of user %s: %v", userID, err)
+		}
+	}
+	return status, nil
+}
+
+// getAccountStatus reads the cached state, loading it from the database on a miss (or a Redis outage)
+func (s *SanctionServiceImpl) getAccountStatus(ctx context.Context, userID uuid.UUID) (*accountStatus, error) {
+	if s.redisService != nil {
+		if data, err := s.redisService.GetAccountStatus(ctx, userID); err == nil {
+			var status accountStatus
+			if err := json.Unmarshal(data, &status); err == nil {
+				return &status, nil
+			}
+		}
+	}
+
+	now := time.Now()
+	status, err := s.loadAccountStatus(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.redisService != nil {
+		if err := s.redisService.SetAccountStatus(ctx, userID, status, status.cacheTTL(now)); err != nil {
+			log.Printf("⚠️  Failed to cache account status of user %s: %v", userID, err)
+		}
+	}
+	return status, nil
+}
+
+// Ensure interface compliance
+var _ services.SanctionService = (*SanctionServiceImpl)(nil)
@@ -15,20 +15,26 @@ import (
 )
 
 type SavedPostServiceImpl struct {
-	savedPostRepo repositories.SavedPostRepository
-	postRepo      repositories.PostRepository
-	voteRepo      repositories.VoteRepository
+	savedPostRepo    repositories.SavedPostRepository
+	postRepo         repositories.PostRepository
+	voteRepo         repositories.VoteRepository
+	postUnlockRepo   repositories.PostUnlockRepository
+	subscriptionRepo repositories.SubscriptionRepository
 }
 
 func NewSavedPostService(
 	savedPostRepo repositories.SavedPostRepository,
 	postRepo repositories.PostRepository,
 	voteRepo repositories.VoteRepository,
+	postUnlockRepo repositories.PostUnlockRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
 ) services.SavedPostService {
 	return &SavedPostServiceImpl{
-		savedPostRepo: savedPostRepo,
-		postRepo:      postRepo,
-		voteRepo:      voteRepo,
+		savedPostRepo:    savedPostRepo,
+		postRepo:         postRepo,
+		voteRepo:         voteRepo,
+		postUnlockRepo:   postUnlockRepo,
+		subscriptionRepo: subscriptionRepo,
 	}
 }
 
@@ -100,8 +106,11 @@ func (s *SavedPostServiceImpl) GetSavedPosts(ctx context.Context, userID uuid.UU
 	// Batch get user votes
 	voteMap, _ := s.voteRepo.GetUserVotesForTargets(ctx, userID, postIDs, "post")
 
+	// Unlocked premium and subscriber-only posts are shown in full to entitled viewers
+	viewerAccess := loadPostViewerAccess(ctx, s.postUnlockRepo, s.subscriptionRepo, posts, &userID)
+
 	for i, post := range posts {
-		resp := dto.PostToPostResponse(post)
+		resp := postResponseForViewer(post, &userID, viewerAccess)
 
 		// Calculate hot score
 		hoursSinceCreation := time.Since(post.CreatedAt).Hours()
@@ -165,8 +174,11 @@ func (s *SavedPostServiceImpl) GetSavedPostsWithCursor(ctx context.Context, user
 		voteMap, _ = s.voteRepo.GetUserVotesForTargets(ctx, userID, postIDs, "post")
 	}
 
+	// Unlocked premium and subscriber-only posts are shown in full to entitled viewers
+	viewerAccess := loadPostViewerAccess(ctx, s.postUnlockRepo, s.subscriptionRepo, posts, &userID)
+
 	for i, post := range posts {
-		resp := dto.PostToPostResponse(post)
+		resp := postResponseForViewer(post, &userID, viewerAccess)
 
 		// Calculate hot score
 		hoursSinceCreation := time.Since(post.CreatedAt).Hours()
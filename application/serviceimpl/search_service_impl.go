@@ -67,7 +67,7 @@ func (s *SearchServiceImpl) Search(ctx context.Context, userID *uuid.UUID, req *
 
 	// Search posts
 	if searchType == "post" || searchType == "all" {
-		posts, err := s.postRepo.Search(ctx, req.Query, 0, limit)
+		posts, err := s.postRepo.Search(ctx, req.Query, 0, limit, userID)
 		if err == nil {
 			postResponses := make([]dto.PostResponse, len(posts))
 			postIDs := make([]uuid.UUID, len(posts))
@@ -84,7 +84,8 @@ func (s *SearchServiceImpl) Search(ctx context.Context, userID *uuid.UUID, req *
 			}
 
 			for i, post := range posts {
-				resp := dto.PostToPostResponse(post)
+				// Subscriber-only posts are already filtered to what the viewer may see
+				resp := dto.PostToPostResponseWithAccess(post, dto.PostViewerAccess{Subscriber: true})
 
 				// Calculate hot score
 				hoursSinceCreation := time.Since(post.CreatedAt).Hours()
@@ -166,7 +167,7 @@ func (s *SearchServiceImpl) SearchWithCursor(ctx context.Context, userID *uuid.U
 	}
 
 	// Search posts with cursor (limit+1 pattern)
-	posts, err := s.postRepo.SearchWithCursor(ctx, query, cursor, limit+1)
+	posts, err := s.postRepo.SearchWithCursor(ctx, query, cursor, limit+1, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +194,8 @@ func (s *SearchServiceImpl) SearchWithCursor(ctx context.Context, userID *uuid.U
 
 	postResponses := make([]dto.PostResponse, len(posts))
 	for i, post := range posts {
-		resp := dto.PostToPostResponse(post)
+		// Subscriber-only posts are already filtered to what the viewer may see
+		resp := dto.PostToPostResponseWithAccess(post, dto.PostViewerAccess{Subscriber: true})
 
 		// Calculate hot score
 		hoursSinceCreation := time.Since(post.CreatedAt).Hours()
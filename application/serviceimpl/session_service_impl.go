@@ -0,0 +1,232 @@
+package serviceimpl
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/redis"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/utils"
+)
+
+const refreshTokenSecretLen = 43 // 256 bits, base64url
+
+type SessionServiceImpl struct {
+	sessionRepo  repositories.SessionRepository
+	userRepo     repositories.UserRepository
+	redisService *redis.RedisService
+	jwtConfig    config.JWTConfig
+}
+
+func NewSessionService(
+	sessionRepo repositories.SessionRepository,
+	userRepo repositories.UserRepository,
+	redisService *redis.RedisService,
+	jwtConfig config.JWTConfig,
+) services.SessionService {
+	return &SessionServiceImpl{
+		sessionRepo:  sessionRepo,
+		userRepo:     userRepo,
+		redisService: redisService,
+		jwtConfig:    jwtConfig,
+	}
+}
+
+func (s *SessionServiceImpl) CreateSession(ctx context.Context, user *models.User, client dto.SessionClient) (*dto.AuthTokens, error) {
+	return s.createSession(ctx, user, client, nil)
+}
+
+func (s *SessionServiceImpl) CreateMFASession(ctx context.Context, user *models.User, client dto.SessionClient) (*dto.AuthTokens, error) {
+	now := time.Now()
+	return s.createSession(ctx, user, client, &now)
+}
+
+func (s *SessionServiceImpl) Refresh(ctx context.Context, refreshToken string, client dto.SessionClient) (*dto.AuthTokens, error) {
+	sessionID, secret, ok := parseRefreshToken(refreshToken)
+	if !ok {
+		return nil, services.ErrInvalidRefreshToken
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.RevokedAt != nil {
+		return nil, services.ErrInvalidRefreshToken
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, services.ErrSessionExpired
+	}
+
+	// A rotated token presented again means two parties hold it, kill the session for both
+	oldHash := hashRefreshSecret(secret)
+	if subtle.ConstantTimeCompare([]byte(oldHash), []byte(session.RefreshTokenHash)) != 1 {
+		s.revoke(ctx, session.ID, models.SessionRevokeTokenReuse)
+		return nil, services.ErrRefreshTokenReused
+	}
+
+	user, err := s.userRepo.GetByID(ctx, session.UserID)
+	if err != nil || !user.IsActive || user.IsRestricted() {
+		s.revoke(ctx, session.ID, models.SessionRevokeAccountState)
+		return nil, services.ErrInvalidRefreshToken
+	}
+
+	newSecret := utils.GenerateRandomString(refreshTokenSecretLen)
+	now := time.Now()
+	session.RefreshTokenHash = hashRefreshSecret(newSecret)
+	session.UserAgent = truncateRunes(client.UserAgent, 500)
+	session.IPAddress = client.IPAddress
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(s.jwtConfig.RefreshTokenTTL)
+
+	rotated, err := s.sessionRepo.Rotate(ctx, session, oldHash)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// Same token rotated concurrently by another request
+		s.revoke(ctx, session.ID, models.SessionRevokeTokenReuse)
+		return nil, services.ErrRefreshTokenReused
+	}
+
+	return s.issueTokens(user, session, newSecret)
+}
+
+func (s *SessionServiceImpl) ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID) (*dto.SessionListResponse, error) {
+	sessions, err := s.sessionRepo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.SessionListResponse{
+		Sessions: make([]dto.SessionResponse, len(sessions)),
+	}
+	for i, session := range sessions {
+		resp.Sessions[i] = *dto.SessionToSessionResponse(session)
+		resp.Sessions[i].Current = session.ID == currentSessionID
+	}
+	return resp, nil
+}
+
+func (s *SessionServiceImpl) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, reason string) error {
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return services.ErrSessionNotFound
+	}
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, session.ID, reason); err != nil {
+		return err
+	}
+	s.denySession(ctx, session.ID)
+	return nil
+}
+
+func (s *SessionServiceImpl) RevokeAllSessions(ctx context.Context, userID uuid.UUID, exceptSessionID *uuid.UUID, reason string) (int, error) {
+	sessions, err := s.sessionRepo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if exceptSessionID != nil && session.ID == *exceptSessionID {
+			continue
+		}
+		if err := s.sessionRepo.Revoke(ctx, session.ID, reason); err != nil {
+			return revoked, err
+		}
+		s.denySession(ctx, session.ID)
+		revoked++
+	}
+	return revoked, nil
+}
+
+// createSession stores a new session (mfaVerifiedAt set when a second factor was checked) and issues its tokens
+func (s *SessionServiceImpl) createSession(ctx context.Context, user *models.User, client dto.SessionClient, mfaVerifiedAt *time.Time) (*dto.AuthTokens, error) {
+	secret := utils.GenerateRandomString(refreshTokenSecretLen)
+	now := time.Now()
+
+	session := &models.Session{
+		ID:               uuid.New(),
+		UserID:           user.ID,
+		RefreshTokenHash: hashRefreshSecret(secret),
+		UserAgent:        truncateRunes(client.UserAgent, 500),
+		IPAddress:        client.IPAddress,
+		LastSeenAt:       now,
+		MFAVerifiedAt:    mfaVerifiedAt,
+		ExpiresAt:        now.Add(s.jwtConfig.RefreshTokenTTL),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(user, session, secret)
+}
+
+// issueTokens signs an access token for the session and pairs it with the refresh token
+func (s *SessionServiceImpl) issueTokens(user *models.User, session *models.Session, secret string) (*dto.AuthTokens, error) {
+	accessToken, expiresAt, err := utils.GenerateAccessToken(&utils.UserContext{
+		ID:          user.ID,
+		Username:    user.Username,
+		Email:       user.Email,
+		SessionID:   session.ID,
+		MFAVerified: session.MFAVerifiedAt != nil,
+	}, s.jwtConfig.AccessTokenTTL, s.jwtConfig.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthTokens{
+		Token:        accessToken,
+		RefreshToken: session.ID.String() + "." + secret,
+		ExpiresAt:    expiresAt,
+		SessionID:    session.ID,
+	}, nil
+}
+
+// revoke revokes a session on the refresh path, failures only leave the session to expire
+func (s *SessionServiceImpl) revoke(ctx context.Context, sessionID uuid.UUID, reason string) {
+	if err := s.sessionRepo.Revoke(ctx, sessionID, reason); err != nil {
+		log.Printf("⚠️  Failed to revoke session %s (%s): %v", sessionID, reason, err)
+		return
+	}
+	s.denySession(ctx, sessionID)
+}
+
+// denySession rejects the session's outstanding access tokens until they expire
+func (s *SessionServiceImpl) denySession(ctx context.Context, sessionID uuid.UUID) {
+	if s.redisService == nil {
+		return
+	}
+	if err := s.redisService.DenySession(ctx, sessionID, s.jwtConfig.AccessTokenTTL); err != nil {
+		log.Printf("⚠️  Failed to deny session %s: %v", sessionID, err)
+	}
+}
+
+// parseRefreshToken splits "<sessionID>.<secret>"
+func parseRefreshToken(token string) (uuid.UUID, string, bool) {
+	idPart, secret, found := strings.Cut(token, ".")
+	if !found || secret == "" {
+		return uuid.Nil, "", false
+	}
+	sessionID, err := uuid.Parse(idPart)
+	if err != nil {
+		return uuid.Nil, "", false
+	}
+	return sessionID, secret, true
+}
+
+func hashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
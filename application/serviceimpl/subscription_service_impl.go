@@ -0,0 +1,840 @@
+package serviceimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	subscriptionMaxTiers           = 5
+	subscriptionPlatformFeePercent = 20 // creator receives the rest
+	subscriptionRenewalWindow      = 3 * 24 * time.Hour
+	subscriptionRetryInterval      = 24 * time.Hour
+	subscriptionMaxPaymentAttempts = 3
+	subscriptionGracePeriod        = 7 * 24 * time.Hour
+	subscriptionRefundWindow       = 48 * time.Hour // cancel within 48h of the first charge = full refund
+	subscriptionReferenceType      = "subscription"
+	subscriptionBatchSize          = 100
+)
+
+type SubscriptionServiceImpl struct {
+	subscriptionRepo repositories.SubscriptionRepository
+	userRepo         repositories.UserRepository
+	walletService    services.WalletService
+	notifService     services.NotificationService
+}
+
+func NewSubscriptionService(
+	subscriptionRepo repositories.SubscriptionRepository,
+	userRepo repositories.UserRepository,
+	walletService services.WalletService,
+	notifService services.NotificationService,
+) services.SubscriptionService {
+	return &SubscriptionServiceImpl{
+		subscriptionRepo: subscriptionRepo,
+		userRepo:         userRepo,
+		walletService:    walletService,
+		notifService:     notifService,
+	}
+}
+
+// ==================== Creator Side ====================
+
+func (s *SubscriptionServiceImpl) CreateTier(ctx context.Context, creatorID uuid.UUID, req *dto.CreateSubscriptionTierRequest) (*dto.SubscriptionTierResponse, error) {
+	tiers, err := s.subscriptionRepo.ListTiersByCreator(ctx, creatorID, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTierSlot(tiers, uuid.Nil, req.TierLevel); err != nil {
+		return nil, err
+	}
+
+	perks, err := marshalPerks(req.Perks)
+	if err != nil {
+		return nil, err
+	}
+
+	tier := &models.SubscriptionTier{
+		CreatorID:    creatorID,
+		Name:         req.Name,
+		Description:  req.Description,
+		TierLevel:    req.TierLevel,
+		PriceMonthly: req.PriceMonthly,
+		PriceYearly:  req.PriceYearly,
+		Perks:        perks,
+		TrialDays:    req.TrialDays,
+		IsActive:     true,
+	}
+
+	if err := s.subscriptionRepo.CreateTier(ctx, tier); err != nil {
+		return nil, err
+	}
+
+	return dto.SubscriptionTierToSubscriptionTierResponse(tier), nil
+}
+
+func (s *SubscriptionServiceImpl) UpdateTier(ctx context.Context, tierID uuid.UUID, creatorID uuid.UUID, req *dto.UpdateSubscriptionTierRequest) (*dto.SubscriptionTierResponse, error) {
+	tier, err := s.subscriptionRepo.GetTierByID(ctx, tierID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tier.CreatorID != creatorID {
+		return nil, errors.New("unauthorized: not tier owner")
+	}
+
+	// Reactivating must still fit the tier limits
+	if req.IsActive != nil && *req.IsActive && !tier.IsActive {
+		tiers, err := s.subscriptionRepo.ListTiersByCreator(ctx, creatorID, true)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkTierSlot(tiers, tier.ID, tier.TierLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	// Existing subscribers keep the price they signed up with (grandfather pricing)
+	if req.Name != nil {
+		tier.Name = *req.Name
+	}
+	if req.Description != nil {
+		tier.Description = *req.Description
+	}
+	if req.PriceMonthly != nil {
+		tier.PriceMonthly = *req.PriceMonthly
+	}
+	if req.PriceYearly != nil {
+		tier.PriceYearly = req.PriceYearly
+	}
+	if req.Perks != nil {
+		perks, err := marshalPerks(req.Perks)
+		if err != nil {
+			return nil, err
+		}
+		tier.Perks = perks
+	}
+	if req.TrialDays != nil {
+		tier.TrialDays = *req.TrialDays
+	}
+	if req.IsActive != nil {
+		tier.IsActive = *req.IsActive
+	}
+
+	if err := s.subscriptionRepo.UpdateTier(ctx, tier); err != nil {
+		return nil, err
+	}
+
+	return dto.SubscriptionTierToSubscriptionTierResponse(tier), nil
+}
+
+func (s *SubscriptionServiceImpl) ListSubscribers(ctx context.Context, creatorID uuid.UUID, offset, limit int) (*dto.SubscriptionListResponse, error) {
+	subscriptions, err := s.subscriptionRepo.ListActiveByCreator(ctx, creatorID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.subscriptionRepo.CountActiveByCreator(ctx, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildSubscriptionListResponse(subscriptions, count, offset, limit), nil
+}
+
+func (s *SubscriptionServiceImpl) GetCreatorStats(ctx context.Context, creatorID uuid.UUID) (*dto.CreatorSubscriptionStatsResponse, error) {
+	tiers, err := s.subscriptionRepo.ListTiersByCreator(ctx, creatorID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.subscriptionRepo.GetTierStats(ctx, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.subscriptionRepo.CountActiveByCreator(ctx, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	statsByTier := make(map[uuid.UUID]*repositories.SubscriptionTierStats, len(stats))
+	for _, stat := range stats {
+		statsByTier[stat.TierID] = stat
+	}
+
+	resp := &dto.CreatorSubscriptionStatsResponse{
+		ActiveSubscribers: active,
+		Tiers:             make([]dto.SubscriptionTierStatsResponse, 0, len(tiers)),
+	}
+	for _, tier := range tiers {
+		tierStats := dto.SubscriptionTierStatsResponse{
+			TierID:    tier.ID,
+			Name:      tier.Name,
+			TierLevel: tier.TierLevel,
+		}
+		if stat, ok := statsByTier[tier.ID]; ok {
+			tierStats.Subscribers = stat.Subscribers
+			tierStats.MonthlyRevenue = stat.MonthlyRevenue
+		}
+		resp.MonthlyRecurringRevenue += tierStats.MonthlyRevenue
+		resp.Tiers = append(resp.Tiers, tierStats)
+	}
+	resp.PlatformFee = resp.MonthlyRecurringRevenue * subscriptionPlatformFeePercent / 100
+	resp.NetMonthlyRevenue = resp.MonthlyRecurringRevenue - resp.PlatformFee
+
+	return resp, nil
+}
+
+// ==================== Subscriber Side ====================
+
+func (s *SubscriptionServiceImpl) ListTiers(ctx context.Context, creatorID uuid.UUID, userID *uuid.UUID) (*dto.SubscriptionTierListResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, creatorID); err != nil {
+		return nil, err
+	}
+
+	// Creators also see their inactive tiers
+	activeOnly := userID == nil || *userID != creatorID
+	tiers, err := s.subscriptionRepo.ListTiersByCreator(ctx, creatorID, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.SubscriptionTierListResponse{
+		Tiers: make([]dto.SubscriptionTierResponse, len(tiers)),
+	}
+	for i, tier := range tiers {
+		resp.Tiers[i] = *dto.SubscriptionTierToSubscriptionTierResponse(tier)
+	}
+
+	if userID != nil && *userID != creatorID {
+		if subscription, err := s.subscriptionRepo.GetBySubscriberAndCreator(ctx, *userID, creatorID); err == nil {
+			resp.MySubscription = dto.SubscriptionToSubscriptionResponse(subscription)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *SubscriptionServiceImpl) Subscribe(ctx context.Context, subscriberID uuid.UUID, req *dto.SubscribeRequest) (*dto.SubscriptionResponse, error) {
+	billingCycle := req.BillingCycle
+	if billingCycle == "" {
+		billingCycle = models.BillingCycleMonthly
+	}
+
+	tier, err := s.subscriptionRepo.GetTierByID(ctx, req.TierID)
+	if err != nil || !tier.IsActive {
+		return nil, services.ErrSubscriptionTierInactive
+	}
+	if tier.CreatorID == subscriberID {
+		return nil, services.ErrCannotSubscribeSelf
+	}
+
+	existing, err := s.subscriptionRepo.GetBySubscriberAndCreator(ctx, subscriberID, tier.CreatorID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	if existing != nil && existing.HasAccess(now) {
+		// Changed their mind before the period ended - resume instead of charging again
+		if existing.Status == models.SubscriptionStatusCancelled && existing.TierID == tier.ID {
+			return s.resume(ctx, existing, now)
+		}
+		return nil, services.ErrAlreadySubscribed
+	}
+
+	// One row per subscriber/creator pair, reused when coming back
+	subscription := existing
+	if subscription == nil {
+		subscription = &models.Subscription{
+			ID:           uuid.New(),
+			SubscriberID: subscriberID,
+			CreatorID:    tier.CreatorID,
+		}
+	}
+	subscription.TierID = tier.ID
+	subscription.BillingCycle = billingCycle
+	subscription.Price = tier.Price(billingCycle)
+	subscription.CancelAtPeriodEnd = false
+	subscription.CancelledAt = nil
+	subscription.CancelReason = ""
+	subscription.PendingTierID = nil
+	subscription.FailedPaymentCount = 0
+	subscription.NextRetryAt = nil
+	subscription.GraceUntil = nil
+	subscription.CurrentPeriodStart = now
+
+	var payment *models.SubscriptionPayment
+	if tier.TrialDays > 0 && !subscription.HasUsedTrial {
+		// Free trial - first charge when the trial ends
+		trialEnd := now.AddDate(0, 0, tier.TrialDays)
+		subscription.Status = models.SubscriptionStatusTrial
+		subscription.CurrentPeriodEnd = trialEnd
+		subscription.TrialEnd = &trialEnd
+		subscription.HasUsedTrial = true
+	} else {
+		subscription.Status = models.SubscriptionStatusActive
+		subscription.CurrentPeriodEnd = addBillingPeriod(now, billingCycle)
+		subscription.TrialEnd = nil
+
+		// Scope the client key to subscriber + creator so it can't collide with other payments
+		idempotencyKey := fmt.Sprintf("subscription:%s:%s:%s", subscriberID, tier.CreatorID, req.IdempotencyKey)
+		payment, err = s.charge(ctx, subscription, subscription.Price, idempotencyKey, models.BillingReasonCreate,
+			subscription.CurrentPeriodStart, subscription.CurrentPeriodEnd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if existing == nil {
+		err = s.subscriptionRepo.Create(ctx, subscription)
+	} else {
+		err = s.subscriptionRepo.Update(ctx, subscription)
+	}
+	if err != nil {
+		// Give the money back, the subscription was not saved
+		if payment != nil {
+			if refundErr := s.refund(ctx, payment); refundErr != nil {
+				log.Printf("[SUBSCRIPTION] Failed to refund payment %s: %v", payment.ID, refundErr)
+			}
+		}
+		return nil, err
+	}
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		tier.CreatorID,
+		subscriberID,
+		"subscription",
+		fmt.Sprintf("สมัครสมาชิกระดับ %s ของคุณแล้ว! 🎉", tier.Name),
+		nil,
+		nil,
+	)
+
+	return s.getSubscriptionResponse(ctx, subscription.ID)
+}
+
+func (s *SubscriptionServiceImpl) ChangeTier(ctx context.Context, subscriberID uuid.UUID, creatorID uuid.UUID, req *dto.ChangeSubscriptionTierRequest) (*dto.SubscriptionResponse, error) {
+	subscription, err := s.subscriptionRepo.GetBySubscriberAndCreator(ctx, subscriberID, creatorID)
+	if err != nil {
+		return nil, services.ErrSubscriptionNotActive
+	}
+
+	now := time.Now()
+	if !subscription.HasAccess(now) ||
+		(subscription.Status != models.SubscriptionStatusActive && subscription.Status != models.SubscriptionStatusTrial) {
+		return nil, services.ErrSubscriptionNotActive
+	}
+
+	newTier, err := s.subscriptionRepo.GetTierByID(ctx, req.TierID)
+	if err != nil || !newTier.IsActive || newTier.CreatorID != creatorID {
+		return nil, services.ErrSubscriptionTierInactive
+	}
+
+	newPrice := newTier.Price(subscription.BillingCycle)
+
+	switch {
+	case newTier.ID == subscription.TierID:
+		// Staying on the current tier drops a scheduled downgrade
+		subscription.PendingTierID = nil
+
+	case subscription.Status == models.SubscriptionStatusTrial:
+		// Nothing charged yet - switch right away
+		subscription.TierID = newTier.ID
+		subscription.Price = newPrice
+		subscription.PendingTierID = nil
+
+	case subscription.Tier != nil && newTier.TierLevel > subscription.Tier.TierLevel:
+		// Upgrade: pay the prorated difference now, new perks immediately
+		if amount := proratedAmount(newPrice-subscription.Price, subscription.CurrentPeriodStart, subscription.CurrentPeriodEnd, now); amount > 0 {
+			idempotencyKey := fmt.Sprintf("subscription_upgrade:%s:%s:%d", subscription.ID, newTier.ID, subscription.CurrentPeriodStart.Unix())
+			if _, err := s.charge(ctx, subscription, amount, idempotencyKey, models.BillingReasonUpdate, now, subscription.CurrentPeriodEnd); err != nil {
+				return nil, err
+			}
+		}
+		subscription.TierID = newTier.ID
+		subscription.Price = newPrice
+		subscription.PendingTierID = nil
+
+	default:
+		// Downgrade: keep the current tier until the period ends
+		subscription.PendingTierID = &newTier.ID
+	}
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return s.getSubscriptionResponse(ctx, subscription.ID)
+}
+
+func (s *SubscriptionServiceImpl) Cancel(ctx context.Context, subscriberID uuid.UUID, creatorID uuid.UUID, req *dto.CancelSubscriptionRequest) (*dto.SubscriptionResponse, error) {
+	subscription, err := s.subscriptionRepo.GetBySubscriberAndCreator(ctx, subscriberID, creatorID)
+	if err != nil {
+		return nil, services.ErrSubscriptionNotActive
+	}
+
+	switch subscription.Status {
+	case models.SubscriptionStatusTrial, models.SubscriptionStatusActive, models.SubscriptionStatusPaymentFailed:
+	default:
+		return nil, services.ErrSubscriptionNotActive
+	}
+
+	now := time.Now()
+
+	// Cancelled within 48 hours of the first charge - refund, no questions asked
+	refunded := false
+	if subscription.Status == models.SubscriptionStatusActive {
+		payment, err := s.subscriptionRepo.GetLatestPayment(ctx, subscription.ID, models.BillingReasonCreate)
+		if err == nil && payment.Status == models.SubscriptionPaymentSuccess && now.Sub(payment.CreatedAt) <= subscriptionRefundWindow {
+			if err := s.refund(ctx, payment); err != nil {
+				log.Printf("[SUBSCRIPTION] Failed to refund payment %s: %v", payment.ID, err)
+			} else {
+				refunded = true
+			}
+		}
+	}
+
+	subscription.CancelAtPeriodEnd = true
+	subscription.CancelledAt = &now
+	subscription.CancelReason = req.Reason
+	subscription.PendingTierID = nil
+	subscription.NextRetryAt = nil
+
+	switch {
+	case refunded:
+		subscription.Status = models.SubscriptionStatusExpired
+		subscription.CurrentPeriodEnd = now
+	case subscription.Status == models.SubscriptionStatusPaymentFailed:
+		// Nothing paid for the current period
+		subscription.Status = models.SubscriptionStatusExpired
+		subscription.GraceUntil = nil
+	default:
+		// Keeps access until the end of the paid (or trial) period
+		subscription.Status = models.SubscriptionStatusCancelled
+	}
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	message := "ยกเลิกการสมัครสมาชิกของคุณ"
+	if req.Reason != "" {
+		message = fmt.Sprintf("%s: %s", message, req.Reason)
+	}
+	_ = s.notifService.CreateNotification(
+		ctx,
+		creatorID,
+		subscriberID,
+		"subscription",
+		message,
+		nil,
+		nil,
+	)
+
+	return s.getSubscriptionResponse(ctx, subscription.ID)
+}
+
+func (s *SubscriptionServiceImpl) ListMySubscriptions(ctx context.Context, subscriberID uuid.UUID, offset, limit int) (*dto.SubscriptionListResponse, error) {
+	subscriptions, err := s.subscriptionRepo.ListBySubscriber(ctx, subscriberID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.subscriptionRepo.CountBySubscriber(ctx, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildSubscriptionListResponse(subscriptions, count, offset, limit), nil
+}
+
+// ==================== Scheduler Jobs ====================
+
+func (s *SubscriptionServiceImpl) ProcessRenewals(ctx context.Context) (int, error) {
+	now := time.Now()
+	subscriptions, err := s.subscriptionRepo.ListDueForRenewal(ctx, now, now.Add(subscriptionRenewalWindow), subscriptionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	renewed := 0
+	for _, subscription := range subscriptions {
+		ok, err := s.renew(ctx, subscription, now)
+		if err != nil {
+			log.Printf("[SUBSCRIPTION] Failed to renew subscription %s: %v", subscription.ID, err)
+			continue
+		}
+		if ok {
+			renewed++
+		}
+	}
+
+	return renewed, nil
+}
+
+func (s *SubscriptionServiceImpl) ProcessExpirations(ctx context.Context) (int, error) {
+	subscriptions, err := s.subscriptionRepo.ListToExpire(ctx, time.Now(), subscriptionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, subscription := range subscriptions {
+		if err := s.expire(ctx, subscription, "การสมัครสมาชิกของคุณหมดอายุแล้ว"); err != nil {
+			log.Printf("[SUBSCRIPTION] Failed to expire subscription %s: %v", subscription.ID, err)
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// expire ends the subscription without charging and tells the subscriber why
+func (s *SubscriptionServiceImpl) expire(ctx context.Context, subscription *models.Subscription, message string) error {
+	subscription.Status = models.SubscriptionStatusExpired
+	subscription.PendingTierID = nil
+	subscription.NextRetryAt = nil
+	subscription.GraceUntil = nil
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return err
+	}
+
+	_ = s.notifService.CreateNotification(
+		ctx,
+		subscription.SubscriberID,
+		subscription.CreatorID,
+		"subscription",
+		message,
+		nil,
+		nil,
+	)
+	return nil
+}
+
+// renew charges the next period (or converts a trial). Returns false when the payment failed.
+func (s *SubscriptionServiceImpl) renew(ctx context.Context, subscription *models.Subscription, now time.Time) (bool, error) {
+	// Never bill for a creator who left or was suspended
+	creator, err := s.userRepo.GetByID(ctx, subscription.CreatorID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+	if creator == nil || !creator.IsActive || creator.IsRestricted() {
+		return false, s.expire(ctx, subscription, "ครีเอเตอร์ไม่สามารถรับสมาชิกได้ในขณะนี้ การสมัครสมาชิกของคุณสิ้นสุดแล้วและจะไม่มีการเรียกเก็บเงิน")
+	}
+
+	tierID := subscription.TierID
+	price := subscription.Price
+	tierAvailable := false
+
+	// Scheduled downgrade takes effect with the new period
+	if subscription.PendingTierID != nil {
+		if tier, err := s.subscriptionRepo.GetTierByID(ctx, *subscription.PendingTierID); err == nil && tier.IsActive {
+			tierID = tier.ID
+			price = tier.Price(subscription.BillingCycle)
+			tierAvailable = true
+		}
+	}
+
+	// Current tier must still be offered to be renewed
+	if !tierAvailable {
+		tier, err := s.subscriptionRepo.GetTierByID(ctx, tierID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, err
+		}
+		if tier == nil || !tier.IsActive {
+			return false, s.expire(ctx, subscription, "ระดับสมาชิกนี้ปิดให้บริการแล้ว การสมัครสมาชิกของคุณสิ้นสุดแล้วและจะไม่มีการเรียกเก็บเงิน")
+		}
+	}
+
+	billingReason := models.BillingReasonCycle
+	if subscription.Status == models.SubscriptionStatusTrial {
+		billingReason = models.BillingReasonCreate
+	}
+
+	periodStart := subscription.CurrentPeriodEnd
+	periodEnd := addBillingPeriod(periodStart, subscription.BillingCycle)
+	idempotencyKey := fmt.Sprintf("subscription_renewal:%s:%d", subscription.ID, periodStart.Unix())
+
+	_, err = s.charge(ctx, &models.Subscription{
+		ID:           subscription.ID,
+		SubscriberID: subscription.SubscriberID,
+		CreatorID:    subscription.CreatorID,
+		TierID:       tierID,
+	}, price, idempotencyKey, billingReason, periodStart, periodEnd)
+	if err != nil {
+		if !errors.Is(err, services.ErrInsufficientBalance) {
+			return false, err
+		}
+		return false, s.recordFailedRenewal(ctx, subscription, tierID, price, billingReason, periodStart, periodEnd, err, now)
+	}
+
+	subscription.TierID = tierID
+	subscription.Price = price
+	subscription.PendingTierID = nil
+	subscription.Status = models.SubscriptionStatusActive
+	subscription.CurrentPeriodStart = periodStart
+	subscription.CurrentPeriodEnd = periodEnd
+	subscription.FailedPaymentCount = 0
+	subscription.NextRetryAt = nil
+	subscription.GraceUntil = nil
+
+	return true, s.subscriptionRepo.Update(ctx, subscription)
+}
+
+// recordFailedRenewal schedules the next retry and starts the grace period after the last attempt
+func (s *SubscriptionServiceImpl) recordFailedRenewal(ctx context.Context, subscription *models.Subscription, tierID uuid.UUID, price int64, billingReason string, periodStart, periodEnd time.Time, cause error, now time.Time) error {
+	creatorAmount := price * (100 - subscriptionPlatformFeePercent) / 100
+	_ = s.subscriptionRepo.CreatePayment(ctx, &models.SubscriptionPayment{
+		SubscriptionID: subscription.ID,
+		SubscriberID:   subscription.SubscriberID,
+		CreatorID:      subscription.CreatorID,
+		TierID:         tierID,
+		Amount:         price,
+		CreatorAmount:  creatorAmount,
+		PlatformFee:    price - creatorAmount,
+		Status:         models.SubscriptionPaymentFailed,
+		BillingReason:  billingReason,
+		FailureReason:  cause.Error(),
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+	})
+
+	subscription.FailedPaymentCount++
+	nextRetry := now.Add(subscriptionRetryInterval)
+	subscription.NextRetryAt = &nextRetry
+
+	if subscription.FailedPaymentCount >= subscriptionMaxPaymentAttempts && subscription.Status != models.SubscriptionStatusPaymentFailed {
+		graceStart := subscription.CurrentPeriodEnd
+		if now.After(graceStart) {
+			graceStart = now
+		}
+		graceUntil := graceStart.Add(subscriptionGracePeriod)
+		subscription.Status = models.SubscriptionStatusPaymentFailed
+		subscription.GraceUntil = &graceUntil
+
+		_ = s.notifService.CreateNotification(
+			ctx,
+			subscription.SubscriberID,
+			subscription.CreatorID,
+			"subscription",
+			"ต่ออายุการสมัครสมาชิกไม่สำเร็จ กรุณาเติมเงินในกระเป๋าภายใน 7 วันเพื่อใช้งานต่อ",
+			nil,
+			nil,
+		)
+	}
+
+	return s.subscriptionRepo.Update(ctx, subscription)
+}
+
+// charge moves a subscription payment from the subscriber to the creator, minus the platform fee
+func (s *SubscriptionServiceImpl) charge(ctx context.Context, subscription *models.Subscription, amount int64, idempotencyKey, billingReason string, periodStart, periodEnd time.Time) (*models.SubscriptionPayment, error) {
+	subscriberWallet, err := s.walletService.GetOrCreateUserWallet(ctx, subscription.SubscriberID)
+	if err != nil {
+		return nil, err
+	}
+	creatorWallet, err := s.walletService.GetOrCreateUserWallet(ctx, subscription.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	feeWallet, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+	if err != nil {
+		return nil, err
+	}
+
+	creatorAmount := amount * (100 - subscriptionPlatformFeePercent) / 100
+	platformFee := amount - creatorAmount
+
+	postings := []dto.LedgerPosting{
+		{WalletID: subscriberWallet.ID, Amount: -amount},
+		{WalletID: creatorWallet.ID, Amount: creatorAmount},
+	}
+	if platformFee > 0 {
+		postings = append(postings, dto.LedgerPosting{WalletID: feeWallet.ID, Amount: platformFee})
+	}
+
+	referenceType := subscriptionReferenceType
+	tx, err := s.walletService.PostTransaction(ctx, &dto.LedgerTransactionRequest{
+		IdempotencyKey: idempotencyKey,
+		Type:           models.LedgerTxTypeTransfer,
+		Description:    "Subscription payment",
+		ReferenceType:  &referenceType,
+		ReferenceID:    &subscription.ID,
+		Postings:       postings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payment := &models.SubscriptionPayment{
+		SubscriptionID: subscription.ID,
+		SubscriberID:   subscription.SubscriberID,
+		CreatorID:      subscription.CreatorID,
+		TierID:         subscription.TierID,
+		Amount:         amount,
+		CreatorAmount:  creatorAmount,
+		PlatformFee:    platformFee,
+		Status:         models.SubscriptionPaymentSuccess,
+		BillingReason:  billingReason,
+		LedgerTxID:     &tx.ID,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+	}
+
+	// A replayed ledger transaction already has its payment row (unique ledger_tx_id)
+	if err := s.subscriptionRepo.CreatePayment(ctx, payment); err != nil {
+		log.Printf("[SUBSCRIPTION] Payment record for ledger transaction %s not created: %v", tx.ID, err)
+	}
+
+	return payment, nil
+}
+
+// refund reverses a successful payment (subscriber gets the full amount back)
+func (s *SubscriptionServiceImpl) refund(ctx context.Context, payment *models.SubscriptionPayment) error {
+	subscriberWallet, err := s.walletService.GetOrCreateUserWallet(ctx, payment.SubscriberID)
+	if err != nil {
+		return err
+	}
+	creatorWallet, err := s.walletService.GetOrCreateUserWallet(ctx, payment.CreatorID)
+	if err != nil {
+		return err
+	}
+	feeWallet, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+	if err != nil {
+		return err
+	}
+
+	postings := []dto.LedgerPosting{
+		{WalletID: subscriberWallet.ID, Amount: payment.Amount},
+		{WalletID: creatorWallet.ID, Amount: -payment.CreatorAmount},
+	}
+	if payment.PlatformFee > 0 {
+		postings = append(postings, dto.LedgerPosting{WalletID: feeWallet.ID, Amount: -payment.PlatformFee})
+	}
+
+	referenceType := subscriptionReferenceType
+	_, err = s.walletService.PostTransaction(ctx, &dto.LedgerTransactionRequest{
+		IdempotencyKey: "subscription_refund:" + payment.ID.String(),
+		Type:           models.LedgerTxTypeRefund,
+		Description:    "Subscription refund",
+		ReferenceType:  &referenceType,
+		ReferenceID:    &payment.SubscriptionID,
+		Postings:       postings,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.subscriptionRepo.UpdatePaymentStatus(ctx, payment.ID, models.SubscriptionPaymentRefunded)
+}
+
+// resume undoes a pending cancellation
+func (s *SubscriptionServiceImpl) resume(ctx context.Context, subscription *models.Subscription, now time.Time) (*dto.SubscriptionResponse, error) {
+	subscription.Status = models.SubscriptionStatusActive
+	if subscription.TrialEnd != nil && now.Before(*subscription.TrialEnd) {
+		subscription.Status = models.SubscriptionStatusTrial
+	}
+	subscription.CancelAtPeriodEnd = false
+	subscription.CancelledAt = nil
+	subscription.CancelReason = ""
+
+	if err := s.subscriptionRepo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return s.getSubscriptionResponse(ctx, subscription.ID)
+}
+
+func (s *SubscriptionServiceImpl) getSubscriptionResponse(ctx context.Context, id uuid.UUID) (*dto.SubscriptionResponse, error) {
+	subscription, err := s.subscriptionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.SubscriptionToSubscriptionResponse(subscription), nil
+}
+
+func buildSubscriptionListResponse(subscriptions []*models.Subscription, count int64, offset, limit int) *dto.SubscriptionListResponse {
+	resp := &dto.SubscriptionListResponse{
+		Subscriptions: make([]dto.SubscriptionResponse, len(subscriptions)),
+		Meta: dto.PaginationMeta{
+			Total:  &count,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}
+	for i, subscription := range subscriptions {
+		resp.Subscriptions[i] = *dto.SubscriptionToSubscriptionResponse(subscription)
+	}
+	return resp
+}
+
+// checkTierSlot enforces the tier limit and unique levels among a creator's active tiers
+func checkTierSlot(activeTiers []*models.SubscriptionTier, exceptID uuid.UUID, tierLevel int) error {
+	count := 0
+	for _, tier := range activeTiers {
+		if tier.ID == exceptID {
+			continue
+		}
+		count++
+		if tier.TierLevel == tierLevel {
+			return services.ErrSubscriptionTierLevelTaken
+		}
+	}
+	if count >= subscriptionMaxTiers {
+		return services.ErrSubscriptionTierLimit
+	}
+	return nil
+}
+
+func marshalPerks(perks []string) (datatypes.JSON, error) {
+	if perks == nil {
+		perks = []string{}
+	}
+	data, err := json.Marshal(perks)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(data), nil
+}
+
+// addBillingPeriod returns the end of a billing period starting at start
+func addBillingPeriod(start time.Time, billingCycle string) time.Time {
+	if billingCycle == models.BillingCycleYearly {
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// proratedAmount returns the share of a price difference for the rest of the period
+func proratedAmount(diff int64, periodStart, periodEnd, now time.Time) int64 {
+	if diff <= 0 || !now.Before(periodEnd) {
+		return 0
+	}
+	total := int64(periodEnd.Sub(periodStart).Seconds())
+	remaining := int64(periodEnd.Sub(now).Seconds())
+	if total <= 0 {
+		return diff
+	}
+	if remaining > total {
+		remaining = total
+	}
+	return diff * remaining / total
+}
+
+// Ensure interface compliance
+var _ services.SubscriptionService = (*SubscriptionServiceImpl)(nil)
@@ -0,0 +1,292 @@
+package serviceimpl
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/payment"
+)
+
+const (
+	topUpReferenceType = "topup"
+	topUpQRLifetime    = 15 * time.Minute
+	topUpBatchSize     = 100
+)
+
+type TopUpServiceImpl struct {
+	topUpRepo     repositories.TopUpRepository
+	walletService services.WalletService
+	notifService  services.NotificationService
+	provider      payment.PaymentProvider // nil = no gateway configured, top-ups are disabled
+
+	// Simulated payments mint real wallet balance, never enable in production
+	allowSimulation bool
+}
+
+func NewTopUpService(
+	topUpRepo repositories.TopUpRepository,
+	walletService services.WalletService,
+	notifService services.NotificationService,
+	provider payment.PaymentProvider,
+	allowSimulation bool,
+) services.TopUpService {
+	return &TopUpServiceImpl{
+		topUpRepo:       topUpRepo,
+		walletService:   walletService,
+		notifService:    notifService,
+		provider:        provider,
+		allowSimulation: allowSimulation,
+	}
+}
+
+// ==================== Current user ====================
+
+func (s *TopUpServiceImpl) CreateTopUp(ctx context.Context, userID uuid.UUID, req *dto.CreateTopUpRequest) (*dto.TopUpIntentResponse, error) {
+	if s.provider == nil {
+		return nil, services.ErrTopUpUnavailable
+	}
+
+	// Make sure the wallet exists before money can arrive
+	if _, err := s.walletService.GetOrCreateUserWallet(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	intent := &models.TopUpIntent{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Amount:    req.Amount,
+		Currency:  "THB",
+		Provider:  s.provider.Name(),
+		Status:    models.TopUpStatusPending,
+		ExpiresAt: time.Now().Add(topUpQRLifetime),
+	}
+
+	charge, err := s.provider.CreateCharge(ctx, &payment.ChargeRequest{
+		Reference:   intent.ID.String(),
+		Amount:      intent.Amount,
+		Currency:    intent.Currency,
+		Description: "Wallet top-up",
+		ExpiresAt:   intent.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create charge: %w", err)
+	}
+
+	intent.ProviderChargeID = charge.ID
+	intent.QRPayload = charge.QRPayload
+	if !charge.ExpiresAt.IsZero() {
+		intent.ExpiresAt = charge.ExpiresAt
+	}
+
+	if err := s.topUpRepo.Create(ctx, intent); err != nil {
+		return nil, err
+	}
+
+	return dto.TopUpIntentToTopUpIntentResponse(intent), nil
+}
+
+func (s *TopUpServiceImpl) GetTopUp(ctx context.Context, intentID uuid.UUID, userID uuid.UUID) (*dto.TopUpIntentResponse, error) {
+	intent, err := s.getOwnIntent(ctx, intentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.TopUpIntentToTopUpIntentResponse(intent), nil
+}
+
+func (s *TopUpServiceImpl) ListMyTopUps(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.TopUpIntentListResponse, error) {
+	intents, err := s.topUpRepo.ListByUser(ctx, userID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.topUpRepo.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	topUps := make([]dto.TopUpIntentResponse, len(intents))
+	for i, intent := range intents {
+		topUps[i] = *dto.TopUpIntentToTopUpIntentResponse(intent)
+	}
+
+	return &dto.TopUpIntentListResponse{
+		TopUps: topUps,
+		Meta: dto.PaginationMeta{
+			Total:  &total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func (s *TopUpServiceImpl) SimulatePayment(ctx context.Context, intentID uuid.UUID, userID uuid.UUID) (*dto.TopUpIntentResponse, error) {
+	if s.provider == nil {
+		return nil, services.ErrTopUpUnavailable
+	}
+
+	simulator, ok := s.provider.(payment.Simulator)
+	if !ok || !s.allowSimulation {
+		return nil, services.ErrTopUpSimulationUnavailable
+	}
+
+	intent, err := s.getOwnIntent(ctx, intentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if intent.Status != models.TopUpStatusPending {
+		return nil, services.ErrTopUpNotPending
+	}
+
+	// Goes through the same signed webhook path as a real gateway
+	payload, signature, err := simulator.SimulatePaid(intent.ProviderChargeID, intent.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.HandleWebhook(ctx, s.provider.Name(), payload, signature); err != nil {
+		return nil, err
+	}
+
+	return s.GetTopUp(ctx, intentID, userID)
+}
+
+// ==================== Webhook ====================
+
+func (s *TopUpServiceImpl) HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) error {
+	if s.provider == nil {
+		return services.ErrTopUpUnavailable
+	}
+	if provider != s.provider.Name() {
+		return services.ErrPaymentProviderUnknown
+	}
+
+	event, err := s.provider.ParseWebhook(payload, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", services.ErrPaymentWebhookInvalid, err)
+	}
+
+	intent, err := s.topUpRepo.GetByProviderCharge(ctx, provider, event.ChargeID)
+	if err != nil {
+		return services.ErrTopUpNotFound
+	}
+
+	switch event.Type {
+	case payment.EventChargePaid:
+		if event.Amount != intent.Amount {
+			log.Printf("[TOPUP] Amount mismatch for top-up %s: expected %d, got %d", intent.ID, intent.Amount, event.Amount)
+			return services.ErrTopUpAmountMismatch
+		}
+		if intent.Status == models.TopUpStatusCredited {
+			return nil // duplicate delivery
+		}
+
+		// An expired QR paid late is still the customer's money, so it is credited too
+		paidAt := event.OccurredAt
+		if paidAt.IsZero() {
+			paidAt = time.Now()
+		}
+		if _, err := s.topUpRepo.MarkPaid(ctx, intent.ID, event.ID, paidAt); err != nil {
+			return err
+		}
+		return s.creditTopUp(ctx, intent)
+
+	case payment.EventChargeExpired, payment.EventChargeFailed:
+		_, err := s.topUpRepo.MarkExpired(ctx, intent.ID)
+		return err
+	}
+
+	// Other event types are acknowledged and ignored
+	return nil
+}
+
+// creditTopUp posts a paid top-up to the user's wallet (idempotent by intent ID)
+func (s *TopUpServiceImpl) creditTopUp(ctx context.Context, intent *models.TopUpIntent) error {
+	clearing, err := s.walletService.GetSystemWallet(ctx, models.SystemWalletTopUpClearing)
+	if err != nil {
+		return err
+	}
+	userWallet, err := s.walletService.GetOrCreateUserWallet(ctx, intent.UserID)
+	if err != nil {
+		return err
+	}
+
+	refType := topUpReferenceType
+	tx, err := s.walletService.PostTransaction(ctx, &dto.LedgerTransactionRequest{
+		IdempotencyKey: "topup:" + intent.ID.String(),
+		Type:           models.LedgerTxTypeTopUp,
+		Description:    "Wallet top-up via " + intent.Provider,
+		ReferenceType:  &refType,
+		ReferenceID:    &intent.ID,
+		Postings: []dto.LedgerPosting{
+			{WalletID: clearing.ID, Amount: -intent.Amount},
+			{WalletID: userWallet.ID, Amount: intent.Amount},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	credited, err := s.topUpRepo.MarkCredited(ctx, intent.ID, tx.ID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if credited {
+		_ = s.notifService.CreateNotification(
+			ctx,
+			intent.UserID,
+			intent.UserID,
+			"topup",
+			fmt.Sprintf("เติมเงินเข้ากระเป๋าสำเร็จ %s บาท", formatSatang(intent.Amount)),
+			nil,
+			nil,
+		)
+	}
+
+	return nil
+}
+
+// ==================== Scheduler ====================
+
+func (s *TopUpServiceImpl) ProcessTopUps(ctx context.Context) (int, int, error) {
+	expired, err := s.topUpRepo.ExpirePending(ctx, time.Now())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Paid intents whose wallet posting failed during the webhook
+	paid, err := s.topUpRepo.ListPaidUncredited(ctx, topUpBatchSize)
+	if err != nil {
+		return int(expired), 0, err
+	}
+
+	credited := 0
+	for _, intent := range paid {
+		if err := s.creditTopUp(ctx, intent); err != nil {
+			log.Printf("[TOPUP] Failed to credit top-up %s: %v", intent.ID, err)
+			continue
+		}
+		credited++
+	}
+
+	return int(expired), credited, nil
+}
+
+// ==================== Helpers ====================
+
+func (s *TopUpServiceImpl) getOwnIntent(ctx context.Context, intentID uuid.UUID, userID uuid.UUID) (*models.TopUpIntent, error) {
+	intent, err := s.topUpRepo.GetByID(ctx, intentID)
+	if err != nil || intent.UserID != userID {
+		return nil, services.ErrTopUpNotFound
+	}
+	return intent, nil
+}
+
+// Ensure interface compliance
+var _ services.TopUpService = (*TopUpServiceImpl)(nil)
@@ -0,0 +1,26 @@
+package serviceimpl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+)
+
+// Without a payment gateway (production before a real adapter ships) top-ups are refused up front
+func TestTopUpService_NoProvider(t *testing.T) {
+	service := NewTopUpService(nil, nil, nil, nil, true)
+	ctx := context.Background()
+
+	_, err := service.CreateTopUp(ctx, uuid.New(), &dto.CreateTopUpRequest{Amount: 10000})
+	assert.ErrorIs(t, err, services.ErrTopUpUnavailable)
+
+	_, err = service.SimulatePayment(ctx, uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, services.ErrTopUpUnavailable)
+
+	err = service.HandleWebhook(ctx, "fake", []byte(`{}`), "")
+	assert.ErrorIs(t, err, services.ErrTopUpUnavailable)
+}
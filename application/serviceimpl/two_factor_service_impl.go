@@ -0,0 +1,348 @@
+package serviceimpl
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/utils"
+)
+
+const (
+	totpPeriod      = 30 // seconds
+	totpSkew        = 1  // accept the previous and the next code too (clock drift)
+	totpQRCodeSize  = 256
+	recoveryCodeNum = 10
+	recoveryCodeLen = 10 // base32 characters, shown as xxxxx-xxxxx
+
+	mfaChallengeTTL         = 5 * time.Minute
+	mfaChallengeMaxAttempts = 5 // wrong codes before the challenge is burned
+)
+
+var totpOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1, // the only algorithm every authenticator app supports
+}
+
+type TwoFactorServiceImpl struct {
+	twoFactorRepo  repositories.TwoFactorRepository
+	tokenRepo      repositories.UserTokenRepository
+	userRepo       repositories.UserRepository
+	sessionService services.SessionService
+	mfaConfig      config.MFAConfig
+	tokenSecret    string
+}
+
+func NewTwoFactorService(
+	twoFactorRepo repositories.TwoFactorRepository,
+	tokenRepo repositories.UserTokenRepository,
+	userRepo repositories.UserRepository,
+	sessionService services.SessionService,
+	mfaConfig config.MFAConfig,
+	tokenSecret string,
+) services.TwoFactorService {
+	return &TwoFactorServiceImpl{
+		twoFactorRepo:  twoFactorRepo,
+		tokenRepo:      tokenRepo,
+		userRepo:       userRepo,
+		sessionService: sessionService,
+		mfaConfig:      mfaConfig,
+		tokenSecret:    tokenSecret,
+	}
+}
+
+// ==================== Enrollment ====================
+
+func (s *TwoFactorServiceImpl) GetStatus(ctx context.Context, userID uuid.UUID) (*dto.TwoFactorStatusResponse, error) {
+	resp := &dto.TwoFactorStatusResponse{}
+
+	secret, err := s.twoFactorRepo.GetTOTP(ctx, userID)
+	if err != nil || !secret.IsEnabled() {
+		return resp, nil
+	}
+
+	remaining, err := s.twoFactorRepo.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp.Enabled = true
+	resp.EnabledAt = secret.EnabledAt
+	resp.RecoveryCodesRemaining = remaining
+	return resp, nil
+}
+
+func (s *TwoFactorServiceImpl) BeginSetup(ctx context.Context, userID uuid.UUID) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, services.ErrTwoFactorAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.mfaConfig.Issuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := utils.EncryptSecret(key.Secret(), s.mfaConfig.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.SavePendingTOTP(ctx, &models.UserTOTP{
+		UserID:          userID,
+		SecretEncrypted: encrypted,
+	}); err != nil {
+		return nil, err
+	}
+
+	img, err := key.Image(totpQRCodeSize, totpQRCodeSize)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     key.Secret(),
+		OTPAuthURI: key.URL(),
+		QRCodePNG:  "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+func (s *TwoFactorServiceImpl) Enable(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error) {
+	pending, err := s.twoFactorRepo.GetTOTP(ctx, userID)
+	if err != nil {
+		return nil, services.ErrTwoFactorSetupMissing
+	}
+	if pending.IsEnabled() {
+		return nil, services.ErrTwoFactorAlreadyEnabled
+	}
+
+	// Proves the authenticator app was set up correctly (recovery codes don't exist yet)
+	step, ok, err := s.matchTOTP(pending, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, services.ErrInvalidTwoFactorCode
+	}
+
+	codes, rows, err := generateRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.Enable(ctx, userID, step, rows); err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *TwoFactorServiceImpl) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.verifyCode(ctx, userID, code); err != nil {
+		return err
+	}
+	return s.twoFactorRepo.Disable(ctx, userID)
+}
+
+func (s *TwoFactorServiceImpl) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error) {
+	if err := s.verifyCode(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, rows, err := generateRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, rows); err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// ==================== Login challenge ====================
+
+func (s *TwoFactorServiceImpl) CreateChallenge(ctx context.Context, user *models.User) (*dto.MFAChallengeResponse, error) {
+	row := &models.UserToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		Purpose:   models.UserTokenMFAChallenge,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(mfaChallengeTTL),
+	}
+	if err := s.tokenRepo.Create(ctx, row); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAChallengeResponse{
+		MFARequired:    true,
+		ChallengeToken: utils.SignToken(utils.SignedToken{ID: row.ID, Purpose: row.Purpose, ExpiresAt: row.ExpiresAt}, s.tokenSecret),
+		ExpiresAt:      row.ExpiresAt,
+	}, nil
+}
+
+func (s *TwoFactorServiceImpl) VerifyChallenge(ctx context.Context, challengeToken string, code string, client dto.SessionClient) (*dto.AuthTokens, *models.User, error) {
+	signed, err := utils.ParseSignedToken(challengeToken, models.UserTokenMFAChallenge, s.tokenSecret)
+	if err != nil {
+		return nil, nil, services.ErrInvalidMFAChallenge
+	}
+	row, err := s.tokenRepo.GetByID(ctx, signed.ID)
+	if err != nil || row.Purpose != models.UserTokenMFAChallenge || !row.IsUsable() || row.Attempts >= mfaChallengeMaxAttempts {
+		return nil, nil, services.ErrInvalidMFAChallenge
+	}
+
+	user, err := s.userRepo.GetByID(ctx, row.UserID)
+	if err != nil || !user.IsActive || user.IsRestricted() || !user.TwoFactorEnabled {
+		return nil, nil, services.ErrInvalidMFAChallenge
+	}
+
+	if err := s.verifyCode(ctx, user.ID, code); err != nil {
+		if !errors.Is(err, services.ErrInvalidTwoFactorCode) {
+			return nil, nil, err
+		}
+		// Too many wrong codes: the password has to be entered again
+		attempts, incErr := s.tokenRepo.IncrementAttempts(ctx, row.ID)
+		if incErr == nil && attempts >= mfaChallengeMaxAttempts {
+			_, _ = s.tokenRepo.MarkUsed(ctx, row.ID)
+		}
+		return nil, nil, err
+	}
+
+	ok, err := s.tokenRepo.MarkUsed(ctx, row.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, services.ErrInvalidMFAChallenge
+	}
+
+	tokens, err := s.sessionService.CreateMFASession(ctx, user, client)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+// ==================== Helpers ====================
+
+// verifyCode accepts a current authenticator code or an unused recovery code of an enabled user
+func (s *TwoFactorServiceImpl) verifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	secret, err := s.twoFactorRepo.GetTOTP(ctx, userID)
+	if err != nil || !secret.IsEnabled() {
+		return services.ErrTwoFactorNotEnabled
+	}
+
+	code = normalizeTwoFactorCode(code)
+	if len(code) == int(totpOpts.Digits) {
+		step, ok, err := s.matchTOTP(secret, code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return services.ErrInvalidTwoFactorCode
+		}
+		// A code is valid for ~90s; each one is accepted once
+		used, err := s.twoFactorRepo.UseStep(ctx, userID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return services.ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	used, err := s.twoFactorRepo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return services.ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// matchTOTP checks a code against the time steps around now and returns the matching step
+func (s *TwoFactorServiceImpl) matchTOTP(secret *models.UserTOTP, code string) (int64, bool, error) {
+	key, err := utils.DecryptSecret(secret.SecretEncrypted, s.mfaConfig.EncryptionKey)
+	if err != nil {
+		return 0, false, err
+	}
+
+	code = normalizeTwoFactorCode(code)
+	now := time.Now()
+	for i := -totpSkew; i <= totpSkew; i++ {
+		at := now.Add(time.Duration(i*totpPeriod) * time.Second)
+		expected, err := totp.GenerateCodeCustom(key, at, totpOpts)
+		if err != nil {
+			return 0, false, err
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / totpPeriod, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// generateRecoveryCodes returns the codes to show once and the hashed rows to store
+func generateRecoveryCodes(userID uuid.UUID) ([]string, []*models.RecoveryCode, error) {
+	codes := make([]string, 0, recoveryCodeNum)
+	rows := make([]*models.RecoveryCode, 0, recoveryCodeNum)
+
+	for len(codes) < recoveryCodeNum {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:recoveryCodeLen]
+
+		codes = append(codes, raw[:recoveryCodeLen/2]+"-"+raw[recoveryCodeLen/2:])
+		rows = append(rows, &models.RecoveryCode{
+			ID:       uuid.New(),
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(raw),
+		})
+	}
+	return codes, rows, nil
+}
+
+// normalizeTwoFactorCode drops the separators users type or paste along with a code
+func normalizeTwoFactorCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ services.TwoFactorService = (*TwoFactorServiceImpl)(nil)
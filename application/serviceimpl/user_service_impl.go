@@ -15,16 +15,20 @@ import (
 )
 
 type UserServiceImpl struct {
-	userRepo   repositories.UserRepository
-	followRepo repositories.FollowRepository
-	jwtSecret  string
+	userRepo       repositories.UserRepository
+	followRepo     repositories.FollowRepository
+	sessionService   services.SessionService
+	twoFactorService services.TwoFactorService
+	jwtSecret        string
 }
 
-func NewUserService(userRepo repositories.UserRepository, followRepo repositories.FollowRepository, jwtSecret string) services.UserService {
+func NewUserService(userRepo repositories.UserRepository, followRepo repositories.FollowRepository, sessionService services.SessionService, twoFactorService services.TwoFactorService, jwtSecret string) services.UserService {
 	return &UserServiceImpl{
-		userRepo:   userRepo,
-		followRepo: followRepo,
-		jwtSecret:  jwtSecret,
+		userRepo:         userRepo,
+		followRepo:       followRepo,
+		sessionService:   sessionService,
+		twoFactorService: twoFactorService,
+		jwtSecret:        jwtSecret,
 	}
 }
 
@@ -50,7 +54,6 @@ func (s *UserServiceImpl) Register(ctx context.Context, req *dto.CreateUserReque
 		Username:    req.Username,
 		Password:    string(hashedPassword),
 		DisplayName: req.DisplayName,
-		Role:        "user",
 		IsActive:    true,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -64,27 +67,44 @@ func (s *UserServiceImpl) Register(ctx context.Context, req *dto.CreateUserReque
 	return user, nil
 }
 
-func (s *UserServiceImpl) Login(ctx context.Context, req *dto.LoginRequest) (string, *models.User, error) {
+func (s *UserServiceImpl) Login(ctx context.Context, req *dto.LoginRequest, client dto.SessionClient) (*dto.AuthTokens, *models.User, error) {
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		return "", nil, errors.New("invalid email or password")
+		return nil, nil, errors.New("invalid email or password")
 	}
 
 	if !user.IsActive {
-		return "", nil, errors.New("account is disabled")
+		return nil, nil, errors.New("account is disabled")
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		return "", nil, errors.New("invalid email or password")
+		return nil, nil, errors.New("invalid email or password")
 	}
 
-	token, err := s.GenerateJWT(user)
+	// Checked after the password so the account state is not revealed to guessers
+	if err := loginRestriction(user); err != nil {
+		return nil, nil, err
+	}
+	if !user.EmailVerified {
+		return nil, nil, services.ErrEmailNotVerified
+	}
+
+	// Second factor: no tokens until the challenge is exchanged with a valid code
+	if user.TwoFactorEnabled {
+		challenge, err := s.twoFactorService.CreateChallenge(ctx, user)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, &services.MFAChallengeError{Challenge: challenge}
+	}
+
+	tokens, err := s.sessionService.CreateSession(ctx, user, client)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
-	return token, user, nil
+	return tokens, user, nil
 }
 
 func (s *UserServiceImpl) GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
@@ -118,6 +138,23 @@ func (s *UserServiceImpl) GetPublicProfile(ctx context.Context, username string,
 		}
 	}
 
+	// Private accounts only show their name, avatar and counts until the viewer is approved
+	visible, err := canViewAccount(ctx, s.followRepo, user, currentUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		userResponse.Bio = ""
+		userResponse.Location = ""
+		userResponse.Website = ""
+		if currentUserID != nil {
+			isRequested, err := s.followRepo.HasFollowRequest(ctx, *currentUserID, user.ID)
+			if err == nil {
+				userResponse.IsRequested = &isRequested
+			}
+		}
+	}
+
 	return userResponse, nil
 }
 
@@ -142,6 +179,9 @@ func (s *UserServiceImpl) UpdateProfile(ctx context.Context, userID uuid.UUID, r
 	if req.Avatar != "" {
 		user.Avatar = req.Avatar
 	}
+	if req.ChatPrivacy != nil {
+		user.ChatPrivacy = *req.ChatPrivacy
+	}
 
 	user.UpdatedAt = time.Now()
 
@@ -150,6 +190,14 @@ func (s *UserServiceImpl) UpdateProfile(ctx context.Context, userID uuid.UUID, r
 		return nil, err
 	}
 
+	// Updates skips false, so the privacy mode is stored on its own
+	if req.IsPrivate != nil && *req.IsPrivate != user.IsPrivate {
+		if err := s.userRepo.SetPrivate(ctx, userID, *req.IsPrivate); err != nil {
+			return nil, err
+		}
+		user.IsPrivate = *req.IsPrivate
+	}
+
 	return user, nil
 }
 
@@ -171,25 +219,6 @@ func (s *UserServiceImpl) ListUsers(ctx context.Context, offset, limit int) ([]*
 	return users, count, nil
 }
 
-func (s *UserServiceImpl) GenerateJWT(user *models.User) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id":  user.ID.String(),
-		"username": user.Username,
-		"email":    user.Email,
-		"role":     user.Role,
-		"exp":      time.Now().Add(time.Hour * 24 * 7).Unix(),
-		"iat":      time.Now().Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.jwtSecret))
-	if err != nil {
-		return "", err
-	}
-
-	return tokenString, nil
-}
-
 func (s *UserServiceImpl) ValidateJWT(tokenString string) (*models.User, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -3,6 +3,7 @@ package serviceimpl
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,22 +14,36 @@ import (
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories/mocks"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
 	"gofiber-template/pkg/testutil"
+	"gofiber-template/pkg/utils"
 	"golang.org/x/crypto/bcrypt"
 )
 
 func setupUserService() (*UserServiceImpl, *mocks.MockUserRepository, *mocks.MockFollowRepository) {
+	service, mockUserRepo, mockFollowRepo, _ := setupUserServiceWithSessions()
+	return service, mockUserRepo, mockFollowRepo
+}
+
+func setupUserServiceWithSessions() (*UserServiceImpl, *mocks.MockUserRepository, *mocks.MockFollowRepository, *mocks.MockSessionRepository) {
 	mockUserRepo := new(mocks.MockUserRepository)
 	mockFollowRepo := new(mocks.MockFollowRepository)
-	jwtSecret := "test-secret-key"
+	mockSessionRepo := new(mocks.MockSessionRepository)
+	jwtConfig := config.JWTConfig{
+		Secret:          "test-secret-key",
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: 30 * 24 * time.Hour,
+	}
 
 	service := &UserServiceImpl{
-		userRepo:   mockUserRepo,
-		followRepo: mockFollowRepo,
-		jwtSecret:  jwtSecret,
+		userRepo:       mockUserRepo,
+		followRepo:     mockFollowRepo,
+		sessionService: NewSessionService(mockSessionRepo, mockUserRepo, nil, jwtConfig),
+		jwtSecret:      jwtConfig.Secret,
 	}
 
-	return service, mockUserRepo, mockFollowRepo
+	return service, mockUserRepo, mockFollowRepo, mockSessionRepo
 }
 
 func TestRegister_Success(t *testing.T) {
@@ -57,7 +72,6 @@ func TestRegister_Success(t *testing.T) {
 	assert.Equal(t, req.Email, user.Email)
 	assert.Equal(t, req.Username, user.Username)
 	assert.Equal(t, req.DisplayName, user.DisplayName)
-	assert.Equal(t, "user", user.Role)
 	assert.True(t, user.IsActive)
 	assert.NotEmpty(t, user.Password)
 	mockUserRepo.AssertExpectations(t)
@@ -116,18 +130,18 @@ func TestRegister_UsernameAlreadyExists(t *testing.T) {
 
 func TestLogin_Success(t *testing.T) {
 	// Arrange
-	service, mockUserRepo, _ := setupUserService()
+	service, mockUserRepo, _, mockSessionRepo := setupUserServiceWithSessions()
 	ctx := context.Background()
 
 	password := "password123"
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	user := &models.User{
-		ID:       uuid.New(),
-		Email:    "test@example.com",
-		Username: "testuser",
-		Password: string(hashedPassword),
-		IsActive: true,
-		Role:     "user",
+		ID:            uuid.New(),
+		Email:         "test@example.com",
+		Username:      "testuser",
+		Password:      string(hashedPassword),
+		IsActive:      true,
+		EmailVerified: true,
 	}
 
 	req := &dto.LoginRequest{
@@ -136,16 +150,26 @@ func TestLogin_Success(t *testing.T) {
 	}
 
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(user, nil)
+	mockSessionRepo.On("Create", ctx, mock.AnythingOfType("*models.Session")).Return(nil)
 
 	// Act
-	token, returnedUser, err := service.Login(ctx, req)
+	tokens, returnedUser, err := service.Login(ctx, req, dto.SessionClient{UserAgent: "test-agent", IPAddress: "127.0.0.1"})
 
 	// Assert
 	assert.NoError(t, err)
-	assert.NotEmpty(t, token)
+	assert.NotNil(t, tokens)
+	assert.NotEmpty(t, tokens.Token)
+	assert.True(t, strings.HasPrefix(tokens.RefreshToken, tokens.SessionID.String()+"."))
 	assert.NotNil(t, returnedUser)
 	assert.Equal(t, user.Email, returnedUser.Email)
+
+	// Access token is bound to the new session
+	userCtx, err := utils.ValidateTokenStringToUUID(tokens.Token, service.jwtSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, userCtx.ID)
+	assert.Equal(t, tokens.SessionID, userCtx.SessionID)
 	mockUserRepo.AssertExpectations(t)
+	mockSessionRepo.AssertExpectations(t)
 }
 
 func TestLogin_InvalidEmail(t *testing.T) {
@@ -161,11 +185,11 @@ func TestLogin_InvalidEmail(t *testing.T) {
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(nil, errors.New("not found"))
 
 	// Act
-	token, user, err := service.Login(ctx, req)
+	tokens, user, err := service.Login(ctx, req, dto.SessionClient{})
 
 	// Assert
 	assert.Error(t, err)
-	assert.Empty(t, token)
+	assert.Nil(t, tokens)
 	assert.Nil(t, user)
 	assert.Equal(t, "invalid email or password", err.Error())
 	mockUserRepo.AssertExpectations(t)
@@ -192,11 +216,11 @@ func TestLogin_InvalidPassword(t *testing.T) {
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(user, nil)
 
 	// Act
-	token, returnedUser, err := service.Login(ctx, req)
+	tokens, returnedUser, err := service.Login(ctx, req, dto.SessionClient{})
 
 	// Assert
 	assert.Error(t, err)
-	assert.Empty(t, token)
+	assert.Nil(t, tokens)
 	assert.Nil(t, returnedUser)
 	assert.Equal(t, "invalid email or password", err.Error())
 	mockUserRepo.AssertExpectations(t)
@@ -222,16 +246,47 @@ func TestLogin_InactiveAccount(t *testing.T) {
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(user, nil)
 
 	// Act
-	token, returnedUser, err := service.Login(ctx, req)
+	tokens, returnedUser, err := service.Login(ctx, req, dto.SessionClient{})
 
 	// Assert
 	assert.Error(t, err)
-	assert.Empty(t, token)
+	assert.Nil(t, tokens)
 	assert.Nil(t, returnedUser)
 	assert.Equal(t, "account is disabled", err.Error())
 	mockUserRepo.AssertExpectations(t)
 }
 
+func TestLogin_EmailNotVerified(t *testing.T) {
+	// Arrange
+	service, mockUserRepo, _ := setupUserService()
+	ctx := context.Background()
+
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	user := &models.User{
+		ID:       uuid.New(),
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+		IsActive: true,
+	}
+
+	req := &dto.LoginRequest{
+		Email:    user.Email,
+		Password: password,
+	}
+
+	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(user, nil)
+
+	// Act
+	tokens, returnedUser, err := service.Login(ctx, req, dto.SessionClient{})
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrEmailNotVerified)
+	assert.Nil(t, tokens)
+	assert.Nil(t, returnedUser)
+	mockUserRepo.AssertExpectations(t)
+}
+
 func TestGetProfile_Success(t *testing.T) {
 	// Arrange
 	service, mockUserRepo, _ := setupUserService()
@@ -341,41 +396,18 @@ func TestListUsers_Success(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
-func TestGenerateJWT_Success(t *testing.T) {
-	// Arrange
-	service, _, _ := setupUserService()
-	user := testutil.CreateTestUser()
-
-	// Act
-	token, err := service.GenerateJWT(user)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.NotEmpty(t, token)
-
-	// Verify token can be parsed
-	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		return []byte(service.jwtSecret), nil
-	})
-	assert.NoError(t, err)
-	assert.True(t, parsedToken.Valid)
-
-	// Verify claims
-	claims, ok := parsedToken.Claims.(jwt.MapClaims)
-	assert.True(t, ok)
-	assert.Equal(t, user.ID.String(), claims["user_id"])
-	assert.Equal(t, user.Username, claims["username"])
-	assert.Equal(t, user.Email, claims["email"])
-	assert.Equal(t, user.Role, claims["role"])
-}
-
 func TestValidateJWT_Success(t *testing.T) {
 	// Arrange
 	service, mockUserRepo, _ := setupUserService()
 	user := testutil.CreateTestUser()
 
 	// Generate a valid token
-	token, _ := service.GenerateJWT(user)
+	token, _, _ := utils.GenerateAccessToken(&utils.UserContext{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		SessionID: uuid.New(),
+	}, time.Minute, service.jwtSecret)
 
 	mockUserRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
 
@@ -412,7 +444,6 @@ func TestValidateJWT_ExpiredToken(t *testing.T) {
 		"user_id":  user.ID.String(),
 		"username": user.Username,
 		"email":    user.Email,
-		"role":     user.Role,
 		"exp":      time.Now().Add(-time.Hour).Unix(), // Expired 1 hour ago
 		"iat":      time.Now().Add(-2 * time.Hour).Unix(),
 	}
@@ -12,11 +12,12 @@ import (
 )
 
 type VoteServiceImpl struct {
-	voteRepo     repositories.VoteRepository
-	postRepo     repositories.PostRepository
-	commentRepo  repositories.CommentRepository
-	userRepo     repositories.UserRepository
-	notifService services.NotificationService
+	voteRepo        repositories.VoteRepository
+	postRepo        repositories.PostRepository
+	commentRepo     repositories.CommentRepository
+	userRepo        repositories.UserRepository
+	notifService    services.NotificationService
+	sanctionService services.SanctionService
 }
 
 func NewVoteService(
@@ -25,17 +26,23 @@ func NewVoteService(
 	commentRepo repositories.CommentRepository,
 	userRepo repositories.UserRepository,
 	notifService services.NotificationService,
+	sanctionService services.SanctionService,
 ) services.VoteService {
 	return &VoteServiceImpl{
-		voteRepo:     voteRepo,
-		postRepo:     postRepo,
-		commentRepo:  commentRepo,
-		userRepo:     userRepo,
-		notifService: notifService,
+		voteRepo:        voteRepo,
+		postRepo:        postRepo,
+		commentRepo:     commentRepo,
+		userRepo:        userRepo,
+		notifService:    notifService,
+		sanctionService: sanctionService,
 	}
 }
 
 func (s *VoteServiceImpl) Vote(ctx context.Context, userID uuid.UUID, req *dto.VoteRequest) (*dto.VoteResponse, error) {
+	if _, err := s.sanctionService.CheckWriteAccess(ctx, userID); err != nil {
+		return nil, err
+	}
+
 	// Check if user already voted
 	existingVote, _ := s.voteRepo.GetVote(ctx, userID, req.TargetID, req.TargetType)
 
@@ -121,6 +128,10 @@ func (s *VoteServiceImpl) Vote(ctx context.Context, userID uuid.UUID, req *dto.V
 }
 
 func (s *VoteServiceImpl) Unvote(ctx context.Context, userID uuid.UUID, req *dto.UnvoteRequest) error {
+	if _, err := s.sanctionService.CheckWriteAccess(ctx, userID); err != nil {
+		return err
+	}
+
 	// Get existing vote
 	existingVote, err := s.voteRepo.GetVote(ctx, userID, req.TargetID, req.TargetType)
 	if err != nil || existingVote == nil {
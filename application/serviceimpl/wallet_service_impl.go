@@ -0,0 +1,467 @@
+package serviceimpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	"gorm.io/gorm"
+)
+
+// System wallets that mirror money outside the platform and may go negative
+var negativeSystemWallets = map[string]bool{
+	models.SystemWalletTopUpClearing:  true,
+	models.SystemWalletPayoutClearing: true,
+}
+
+type WalletServiceImpl struct {
+	walletRepo repositories.WalletRepository
+}
+
+func NewWalletService(walletRepo repositories.WalletRepository) services.WalletService {
+	return &WalletServiceImpl{
+		walletRepo: walletRepo,
+	}
+}
+
+func (s *WalletServiceImpl) GetOrCreateUserWallet(ctx context.Context, userID uuid.UUID) (*models.Wallet, error) {
+	wallet, err := s.walletRepo.GetByUserID(ctx, userID)
+	if err == nil {
+		return wallet, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	wallet = &models.Wallet{
+		UserID:   &userID,
+		Type:     models.WalletTypeUser,
+		Currency: "THB",
+	}
+	if err := s.walletRepo.Create(ctx, wallet); err != nil {
+		// Lost a creation race - the wallet exists now
+		if existing, getErr := s.walletRepo.GetByUserID(ctx, userID); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+func (s *WalletServiceImpl) GetSystemWallet(ctx context.Context, code string) (*models.Wallet, error) {
+	wallet, err := s.walletRepo.GetByCode(ctx, code)
+	if err == nil {
+		return wallet, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	walletCode := code
+	wallet = &models.Wallet{
+		Code:          &walletCode,
+		Type:          models.WalletTypeSystem,
+		Currency:      "THB",
+		AllowNegative: negativeSystemWallets[code],
+	}
+	if err := s.walletRepo.Create(ctx, wallet); err != nil {
+		// Lost a creation race - the wallet exists now
+		if existing, getErr := s.walletRepo.GetByCode(ctx, code); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+func (s *WalletServiceImpl) GetMyWallet(ctx context.Context, userID uuid.UUID) (*dto.WalletResponse, error) {
+	wallet, err := s.GetOrCreateUserWallet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.WalletToWalletResponse(wallet), nil
+}
+
+func (s *WalletServiceImpl) ListMyLedgerEntries(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.LedgerEntryListResponse, error) {
+	// Set default pagination
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	wallet, err := s.GetOrCreateUserWallet(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.walletRepo.ListEntriesByWallet(ctx, wallet.ID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.walletRepo.CountEntriesByWallet(ctx, wallet.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.LedgerEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = *dto.LedgerEntryToLedgerEntryResponse(entry)
+	}
+
+	return &dto.LedgerEntryListResponse{
+		Entries: responses,
+		Meta: dto.PaginationMeta{
+			Total:  &total,
+			Offset: offset,
+			Limit:  limit,
+		},
+	}, nil
+}
+
+func (s *WalletServiceImpl) PostTransaction(ctx context.Context, req *dto.LedgerTransactionRequest) (*models.LedgerTransaction, error) {
+	if err := validatePostings(req); err != nil {
+		return nil, err
+	}
+
+	var result *models.LedgerTransaction
+	err := s.walletRepo.Transaction(ctx, func(repo repositories.WalletRepository) error {
+		tx, err := postInTx(ctx, repo, req, nil)
+		if err != nil {
+			return err
+		}
+		result = tx
+		return nil
+	})
+	if err != nil {
+		return s.resolveIdempotencyConflict(ctx, req.IdempotencyKey, err)
+	}
+
+	return result, nil
+}
+
+func (s *WalletServiceImpl) Transfer(ctx context.Context, req *dto.WalletTransferRequest) (*models.LedgerTransaction, error) {
+	if req.Amount <= 0 {
+		return nil, services.ErrInvalidAmount
+	}
+	if req.FromWalletID == req.ToWalletID {
+		return nil, errors.New("cannot transfer to the same wallet")
+	}
+
+	txType := req.Type
+	if txType == "" {
+		txType = models.LedgerTxTypeTransfer
+	}
+
+	return s.PostTransaction(ctx, &dto.LedgerTransactionRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		Type:           txType,
+		Description:    req.Description,
+		ReferenceType:  req.ReferenceType,
+		ReferenceID:    req.ReferenceID,
+		Postings: []dto.LedgerPosting{
+			{WalletID: req.FromWalletID, Amount: -req.Amount},
+			{WalletID: req.ToWalletID, Amount: req.Amount},
+		},
+	})
+}
+
+func (s *WalletServiceImpl) PlaceHold(ctx context.Context, req *dto.WalletHoldRequest) (*models.WalletHold, error) {
+	if req.IdempotencyKey == "" {
+		return nil, errors.New("idempotency key is required")
+	}
+	if req.Amount <= 0 {
+		return nil, services.ErrInvalidAmount
+	}
+
+	var result *models.WalletHold
+	err := s.walletRepo.Transaction(ctx, func(repo repositories.WalletRepository) error {
+		// Idempotency: same key returns the existing hold
+		if existing, err := repo.GetHoldByIdempotencyKey(ctx, req.IdempotencyKey); err == nil {
+			result = existing
+			return nil
+		}
+
+		wallet, err := repo.GetForUpdate(ctx, req.WalletID)
+		if err != nil {
+			return err
+		}
+
+		if !wallet.AllowNegative && wallet.AvailableBalance() < req.Amount {
+			return services.ErrInsufficientBalance
+		}
+
+		hold := &models.WalletHold{
+			WalletID:       wallet.ID,
+			Amount:         req.Amount,
+			Status:         models.WalletHoldStatusActive,
+			Reason:         req.Reason,
+			IdempotencyKey: req.IdempotencyKey,
+			ReferenceType:  req.ReferenceType,
+			ReferenceID:    req.ReferenceID,
+			ExpiresAt:      req.ExpiresAt,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}
+		if err := repo.CreateHold(ctx, hold); err != nil {
+			return err
+		}
+
+		if err := repo.UpdateBalances(ctx, wallet.ID, wallet.Balance, wallet.HeldAmount+req.Amount); err != nil {
+			return err
+		}
+
+		result = hold
+		return nil
+	})
+	if err != nil {
+		// Concurrent request with the same key won the race
+		if existing, getErr := s.walletRepo.GetHoldByIdempotencyKey(ctx, req.IdempotencyKey); getErr == nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *WalletServiceImpl) ReleaseHold(ctx context.Context, holdID uuid.UUID) error {
+	return s.walletRepo.Transaction(ctx, func(repo repositories.WalletRepository) error {
+		hold, err := repo.GetHoldForUpdate(ctx, holdID)
+		if err != nil {
+			return err
+		}
+
+		// Releasing twice is a no-op
+		if hold.Status == models.WalletHoldStatusReleased {
+			return nil
+		}
+		if hold.Status != models.WalletHoldStatusActive {
+			return services.ErrHoldNotActive
+		}
+
+		wallet, err := repo.GetForUpdate(ctx, hold.WalletID)
+		if err != nil {
+			return err
+		}
+
+		if err := repo.UpdateBalances(ctx, wallet.ID, wallet.Balance, wallet.HeldAmount-hold.Amount); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		hold.Status = models.WalletHoldStatusReleased
+		hold.ResolvedAt = &now
+		hold.UpdatedAt = now
+		return repo.UpdateHold(ctx, hold)
+	})
+}
+
+func (s *WalletServiceImpl) CaptureHold(ctx context.Context, holdID uuid.UUID, req *dto.LedgerTransactionRequest) (*models.LedgerTransaction, error) {
+	if req.IdempotencyKey == "" {
+		return nil, errors.New("idempotency key is required")
+	}
+
+	var result *models.LedgerTransaction
+	err := s.walletRepo.Transaction(ctx, func(repo repositories.WalletRepository) error {
+		hold, err := repo.GetHoldForUpdate(ctx, holdID)
+		if err != nil {
+			return err
+		}
+
+		// Capturing twice returns the original transaction
+		if hold.Status == models.WalletHoldStatusCaptured && hold.CaptureTransactionID != nil {
+			tx, err := repo.GetTransactionByID(ctx, *hold.CaptureTransactionID)
+			if err != nil {
+				return err
+			}
+			result = tx
+			return nil
+		}
+		if hold.Status != models.WalletHoldStatusActive {
+			return services.ErrHoldNotActive
+		}
+
+		// Credits must add up to the held amount
+		var credited int64
+		for _, posting := range req.Postings {
+			if posting.Amount <= 0 {
+				return services.ErrInvalidAmount
+			}
+			credited += posting.Amount
+		}
+		if credited != hold.Amount {
+			return services.ErrUnbalancedPosting
+		}
+
+		captureReq := *req
+		if captureReq.Type == "" {
+			captureReq.Type = models.LedgerTxTypeCapture
+		}
+		captureReq.Postings = append([]dto.LedgerPosting{{WalletID: hold.WalletID, Amount: -hold.Amount}}, req.Postings...)
+		if err := validatePostings(&captureReq); err != nil {
+			return err
+		}
+
+		tx, err := postInTx(ctx, repo, &captureReq, map[uuid.UUID]int64{hold.WalletID: hold.Amount})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		hold.Status = models.WalletHoldStatusCaptured
+		hold.CaptureTransactionID = &tx.ID
+		hold.ResolvedAt = &now
+		hold.UpdatedAt = now
+		if err := repo.UpdateHold(ctx, hold); err != nil {
+			return err
+		}
+
+		result = tx
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *WalletServiceImpl) ReleaseExpiredHolds(ctx context.Context) (int, error) {
+	holds, err := s.walletRepo.ListExpiredHolds(ctx, time.Now(), 100)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, hold := range holds {
+		if err := s.ReleaseHold(ctx, hold.ID); err != nil {
+			log.Printf("Warning: Failed to release expired hold %s: %v", hold.ID, err)
+			continue
+		}
+		released++
+	}
+
+	return released, nil
+}
+
+// resolveIdempotencyConflict returns the already-applied transaction when a posting failed
+// because a concurrent request with the same idempotency key committed first
+func (s *WalletServiceImpl) resolveIdempotencyConflict(ctx context.Context, key string, postErr error) (*models.LedgerTransaction, error) {
+	if existing, err := s.walletRepo.GetTransactionByIdempotencyKey(ctx, key); err == nil {
+		return existing, nil
+	}
+	return nil, postErr
+}
+
+// validatePostings checks that a ledger transaction request is well-formed and balanced
+func validatePostings(req *dto.LedgerTransactionRequest) error {
+	if req.IdempotencyKey == "" {
+		return errors.New("idempotency key is required")
+	}
+	if req.Type == "" {
+		return errors.New("transaction type is required")
+	}
+	if len(req.Postings) < 2 {
+		return errors.New("a ledger transaction needs at least two postings")
+	}
+
+	var sum int64
+	seen := make(map[uuid.UUID]bool, len(req.Postings))
+	for _, posting := range req.Postings {
+		if posting.Amount == 0 {
+			return errors.New("posting amount cannot be zero")
+		}
+		if seen[posting.WalletID] {
+			return fmt.Errorf("wallet %s appears more than once in postings", posting.WalletID)
+		}
+		seen[posting.WalletID] = true
+		sum += posting.Amount
+	}
+
+	if sum != 0 {
+		return services.ErrUnbalancedPosting
+	}
+
+	return nil
+}
+
+// postInTx applies a validated ledger transaction using a transaction-bound repository.
+// releasedHolds lists held amounts that are consumed by this posting (hold capture).
+func postInTx(ctx context.Context, repo repositories.WalletRepository, req *dto.LedgerTransactionRequest, releasedHolds map[uuid.UUID]int64) (*models.LedgerTransaction, error) {
+	// Idempotency: same key returns the existing transaction
+	if existing, err := repo.GetTransactionByIdempotencyKey(ctx, req.IdempotencyKey); err == nil {
+		return existing, nil
+	}
+
+	// Lock wallets in a stable order to avoid deadlocks between concurrent postings
+	postings := make([]dto.LedgerPosting, len(req.Postings))
+	copy(postings, req.Postings)
+	sort.Slice(postings, func(i, j int) bool {
+		return postings[i].WalletID.String() < postings[j].WalletID.String()
+	})
+
+	now := time.Now()
+	entries := make([]models.LedgerEntry, 0, len(postings))
+	balances := make(map[uuid.UUID][2]int64, len(postings))
+
+	for _, posting := range postings {
+		wallet, err := repo.GetForUpdate(ctx, posting.WalletID)
+		if err != nil {
+			return nil, fmt.Errorf("wallet %s: %w", posting.WalletID, err)
+		}
+
+		heldAmount := wallet.HeldAmount - releasedHolds[wallet.ID]
+		newBalance := wallet.Balance + posting.Amount
+
+		// Debits may only spend money that is not reserved by other holds
+		if posting.Amount < 0 && !wallet.AllowNegative && newBalance-heldAmount < 0 {
+			return nil, services.ErrInsufficientBalance
+		}
+
+		entries = append(entries, models.LedgerEntry{
+			WalletID:     wallet.ID,
+			Amount:       posting.Amount,
+			BalanceAfter: newBalance,
+			CreatedAt:    now,
+		})
+		balances[wallet.ID] = [2]int64{newBalance, heldAmount}
+	}
+
+	tx := &models.LedgerTransaction{
+		IdempotencyKey: req.IdempotencyKey,
+		Type:           req.Type,
+		Description:    req.Description,
+		ReferenceType:  req.ReferenceType,
+		ReferenceID:    req.ReferenceID,
+		Entries:        entries,
+		CreatedAt:      now,
+	}
+	if err := repo.CreateTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	for walletID, balance := range balances {
+		if err := repo.UpdateBalances(ctx, walletID, balance[0], balance[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
+// Ensure interface compliance
+var _ services.WalletService = (*WalletServiceImpl)(nil)
@@ -71,6 +71,18 @@ func main() {
 	app.Use(pkgMiddleware.NewCORS())
 	app.Use(pkgMiddleware.NewGlobalRateLimiter())
 
+	// Revoked sessions lose their access tokens immediately
+	middleware.UseSessionDenylist(container.RedisService)
+
+	// Role-based permission checks (RequirePermission)
+	middleware.UsePermissionChecker(container.AuthorizationService)
+
+	// Suspended and banned accounts are refused on every authenticated route
+	middleware.UseAccountStatusChecker(container.SanctionService)
+
+	// Personal access tokens and bot keys are accepted alongside JWTs
+	middleware.UseAPITokenAuthenticator(container.APITokenService)
+
 	// Create handlers from services
 	services := container.GetHandlerServices()
 
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// ============================================================================
+// Account data requests
+// ============================================================================
+
+// RequestAccountDeletionRequest - Schedule the account for deletion (password required when the account has one)
+type RequestAccountDeletionRequest struct {
+	Password       string `json:"password" validate:"omitempty,max=72"`
+	ForfeitBalance bool   `json:"forfeitBalance"` // consent to lose the wallet balance instead of withdrawing it first
+}
+
+// ============================================================================
+// Account data responses
+// ============================================================================
+
+// DataExportResponse - A personal data export (downloadUrl is presigned and short-lived, only while ready)
+type DataExportResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Status      string     `json:"status"`
+	FileSize    int64      `json:"fileSize"`
+	DownloadURL *string    `json:"downloadUrl,omitempty"`
+	Error       *string    `json:"error,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// DataExportListResponse - The user's recent exports, newest first
+type DataExportListResponse struct {
+	Exports []DataExportResponse `json:"exports"`
+}
+
+// AccountDeletionResponse - Deletion state of the current account
+type AccountDeletionResponse struct {
+	IsScheduled     bool       `json:"isScheduled"`
+	ScheduledAt     *time.Time `json:"scheduledAt,omitempty"` // account is anonymized at this time unless cancelled
+	ForfeitsBalance bool       `json:"forfeitsBalance"`       // the remaining wallet balance is given up on deletion
+}
+
+func DataExportToResponse(export *models.DataExport) *DataExportResponse {
+	if export == nil {
+		return nil
+	}
+	return &DataExportResponse{
+		ID:          export.ID,
+		Status:      export.Status,
+		FileSize:    export.FileSize,
+		Error:       export.Error,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+		CreatedAt:   export.CreatedAt,
+	}
+}
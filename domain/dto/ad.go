@@ -0,0 +1,193 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// All amounts are in satang (1 THB = 100 satang)
+
+// AdPlacementResponse - Placement an advertiser can book
+type AdPlacementResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	PricePerDay  int64     `json:"pricePerDay"`
+	MaxActiveAds int       `json:"maxActiveAds"`
+	FeedInterval int       `json:"feedInterval,omitempty"`
+	IsActive     bool      `json:"isActive"`
+}
+
+// AdPlacementListResponse - Bookable placements
+type AdPlacementListResponse struct {
+	Placements []AdPlacementResponse `json:"placements"`
+}
+
+// UpdateAdPlacementRequest - Admin changes price, capacity or feed interval
+type UpdateAdPlacementRequest struct {
+	Name         *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description  *string `json:"description" validate:"omitempty,max=500"`
+	PricePerDay  *int64  `json:"pricePerDay" validate:"omitempty,min=100,max=100000000"`
+	MaxActiveAds *int    `json:"maxActiveAds" validate:"omitempty,min=1,max=1000"`
+	FeedInterval *int    `json:"feedInterval" validate:"omitempty,min=3,max=50"`
+	IsActive     *bool   `json:"isActive"`
+}
+
+// CreateAdRequest - Book a placement (the creative is uploaded first via /upload/presigned-url + /upload/confirm)
+type CreateAdRequest struct {
+	PlacementCode  string    `json:"placementCode" validate:"required,oneof=feed sidebar post_detail"`
+	CampaignName   string    `json:"campaignName" validate:"required,min=1,max=100"`
+	Description    string    `json:"description" validate:"omitempty,max=300"`
+	DestinationURL string    `json:"destinationUrl" validate:"required,url,max=2048"`
+	MediaID        uuid.UUID `json:"mediaId" validate:"required"`
+	StartDate      string    `json:"startDate" validate:"required,datetime=2006-01-02"` // Thai date, ad starts at 00:00
+	Days           int       `json:"days" validate:"required,min=1,max=90"`
+}
+
+// UpdateAdRequest - Edit the creative before the ad is paid
+type UpdateAdRequest struct {
+	CampaignName   *string    `json:"campaignName" validate:"omitempty,min=1,max=100"`
+	Description    *string    `json:"description" validate:"omitempty,max=300"`
+	DestinationURL *string    `json:"destinationUrl" validate:"omitempty,url,max=2048"`
+	MediaID        *uuid.UUID `json:"mediaId"`
+}
+
+// SubmitAdPaymentRequest - Bank slip for an ad (slip image uploaded via the presigned upload flow)
+type SubmitAdPaymentRequest struct {
+	SlipMediaID   uuid.UUID `json:"slipMediaId" validate:"required"`
+	BankReference string    `json:"bankReference" validate:"omitempty,max=100"`
+	TransferredAt time.Time `json:"transferredAt" validate:"required"`
+}
+
+// RejectAdPaymentRequest - Admin rejects a slip
+type RejectAdPaymentRequest struct {
+	Reason string `json:"reason" validate:"required,min=1,max=500"`
+}
+
+// RemoveAdRequest - Admin takes down an ad
+type RemoveAdRequest struct {
+	Reason string `json:"reason" validate:"required,min=1,max=500"`
+}
+
+// RecordAdImpressionRequest - Sent by the client once the ad was on screen for over 1 second
+type RecordAdImpressionRequest struct {
+	Placement      string `json:"placement" validate:"required,oneof=feed sidebar post_detail"`
+	ViewDurationMs int    `json:"viewDurationMs" validate:"omitempty,min=0"`
+	DeviceType     string `json:"deviceType" validate:"omitempty,oneof=mobile desktop"`
+}
+
+// RecordAdClickRequest - Sent by the client when the ad is clicked
+type RecordAdClickRequest struct {
+	Placement    string     `json:"placement" validate:"required,oneof=feed sidebar post_detail"`
+	ImpressionID *uuid.UUID `json:"impressionId"`
+	ReferrerURL  string     `json:"referrerUrl" validate:"omitempty,max=2048"`
+	DeviceType   string     `json:"deviceType" validate:"omitempty,oneof=mobile desktop"`
+}
+
+// AdImpressionResponse - Recorded impression (the ID can be sent with the click)
+type AdImpressionResponse struct {
+	ImpressionID uuid.UUID `json:"impressionId"`
+}
+
+// AdClickResponse - Where to send the user after a click
+type AdClickResponse struct {
+	DestinationURL string `json:"destinationUrl"`
+}
+
+// AdResponse - Ad as seen by its advertiser (or an admin)
+type AdResponse struct {
+	ID             uuid.UUID            `json:"id"`
+	Advertiser     *UserResponse        `json:"advertiser,omitempty"`
+	Placement      *AdPlacementResponse `json:"placement,omitempty"`
+	CampaignName   string               `json:"campaignName"`
+	Description    string               `json:"description"`
+	DestinationURL string               `json:"destinationUrl"`
+	Media          *MediaResponse       `json:"media,omitempty"`
+	Status         string               `json:"status"`
+	Days           int                  `json:"days"`
+	PricePerDay    int64                `json:"pricePerDay"`
+	Discount       int64                `json:"discount"`
+	TotalCost      int64                `json:"totalCost"`
+	StartsAt       time.Time            `json:"startsAt"`
+	EndsAt         time.Time            `json:"endsAt"`
+	Impressions    int64                `json:"impressions"`
+	Clicks         int64                `json:"clicks"`
+	RemovalReason  *string              `json:"removalReason,omitempty"`
+	CreatedAt      time.Time            `json:"createdAt"`
+
+	// Latest bank slip (advertiser view)
+	LatestPayment *AdPaymentResponse `json:"latestPayment,omitempty"`
+}
+
+// AdListResponse - Advertiser's ads with pagination
+type AdListResponse struct {
+	Ads  []AdResponse   `json:"ads"`
+	Meta PaginationMeta `json:"meta"`
+}
+
+// AdPaymentResponse - A submitted bank slip
+type AdPaymentResponse struct {
+	ID              uuid.UUID      `json:"id"`
+	AdID            uuid.UUID      `json:"adId"`
+	Ad              *AdResponse    `json:"ad,omitempty"` // admin queue
+	Advertiser      *UserResponse  `json:"advertiser,omitempty"`
+	Amount          int64          `json:"amount"`
+	Method          string         `json:"method"`
+	SlipMedia       *MediaResponse `json:"slipMedia,omitempty"`
+	BankReference   string         `json:"bankReference,omitempty"`
+	TransferredAt   time.Time      `json:"transferredAt"`
+	Status          string         `json:"status"`
+	ReviewedAt      *time.Time     `json:"reviewedAt,omitempty"`
+	RejectionReason *string        `json:"rejectionReason,omitempty"`
+	CreatedAt       time.Time      `json:"createdAt"`
+}
+
+// AdPaymentListResponse - Admin slip verification queue
+type AdPaymentListResponse struct {
+	Payments []AdPaymentResponse `json:"payments"`
+	Meta     PaginationMeta      `json:"meta"`
+}
+
+// AdDailyStats - Performance of one day
+type AdDailyStats struct {
+	Date        string `json:"date"` // 2006-01-02
+	Impressions int64  `json:"impressions"`
+	Clicks      int64  `json:"clicks"`
+}
+
+// AdStatsResponse - Campaign performance for the advertiser
+type AdStatsResponse struct {
+	AdID        uuid.UUID      `json:"adId"`
+	Impressions int64          `json:"impressions"`
+	Clicks      int64          `json:"clicks"`
+	CTR         float64        `json:"ctr"`   // percent
+	CPC         int64          `json:"cpc"`   // satang per click (0 without clicks)
+	Reach       int64          `json:"reach"` // unique signed-in viewers
+	Daily       []AdDailyStats `json:"daily"`
+}
+
+// ServedAdResponse - Public creative shown to users
+type ServedAdResponse struct {
+	ID             uuid.UUID `json:"id"`
+	Placement      string    `json:"placement"`
+	CampaignName   string    `json:"campaignName"`
+	Description    string    `json:"description"`
+	DestinationURL string    `json:"destinationUrl"`
+	ImageURL       string    `json:"imageUrl"`
+	Width          int       `json:"width,omitempty"`
+	Height         int       `json:"height,omitempty"`
+	AdvertiserName string    `json:"advertiserName"`
+}
+
+// ServedAdListResponse - Ads for a sidebar or post-detail slot
+type ServedAdListResponse struct {
+	Ads []ServedAdResponse `json:"ads"`
+}
+
+// FeedAdSlot - Sponsored item interleaved in a feed page
+type FeedAdSlot struct {
+	Position int              `json:"position"` // index in posts the ad is shown before
+	Ad       ServedAdResponse `json:"ad"`
+}
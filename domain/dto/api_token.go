@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// ============================================================================
+// API token requests
+// ============================================================================
+
+// CreateAPITokenRequest - Create a personal access token or bot key
+type CreateAPITokenRequest struct {
+	Name               string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes             []string `json:"scopes" validate:"required,min=1,dive,oneof=posts:read posts:write comments:write votes:write media:write chat:read chat:write notifications:read profile:read"`
+	ExpiresInDays      int      `json:"expiresInDays" validate:"omitempty,min=1,max=365"`      // personal tokens default to 90 days, bot keys never expire when omitted
+	RateLimitPerMinute int      `json:"rateLimitPerMinute" validate:"omitempty,min=1,max=600"` // default 60
+}
+
+// SetBotAccountRequest - Flag or unflag an account as a bot (staff)
+type SetBotAccountRequest struct {
+	IsBot *bool `json:"isBot" validate:"required"`
+}
+
+// ============================================================================
+// API token responses
+// ============================================================================
+
+// APITokenResponse - A token as shown in token lists (the secret is never returned again)
+type APITokenResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	Kind               string     `json:"kind"`
+	Name               string     `json:"name"`
+	Prefix             string     `json:"prefix"`
+	Scopes             []string   `json:"scopes"`
+	RateLimitPerMinute int        `json:"rateLimitPerMinute"`
+	ExpiresAt          *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt         *time.Time `json:"lastUsedAt,omitempty"`
+	LastUsedIP         string     `json:"lastUsedIp,omitempty"`
+	CreatedAt          time.Time  `json:"createdAt"`
+}
+
+// CreatedAPITokenResponse - A new token with its secret (shown only once)
+type CreatedAPITokenResponse struct {
+	APITokenResponse
+	Token string `json:"token"`
+}
+
+// APITokenListResponse - Active and expired tokens of a user (revoked ones are hidden)
+type APITokenListResponse struct {
+	Tokens []APITokenResponse `json:"tokens"`
+}
+
+func APITokenToResponse(token *models.APIToken) *APITokenResponse {
+	if token == nil {
+		return nil
+	}
+	return &APITokenResponse{
+		ID:                 token.ID,
+		Kind:               token.Kind,
+		Name:               token.Name,
+		Prefix:             token.Prefix,
+		Scopes:             token.ScopeList(),
+		RateLimitPerMinute: token.RateLimitPerMinute,
+		ExpiresAt:          token.ExpiresAt,
+		LastUsedAt:         token.LastUsedAt,
+		LastUsedIP:         token.LastUsedIP,
+		CreatedAt:          token.CreatedAt,
+	}
+}
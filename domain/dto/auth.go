@@ -1,13 +1,19 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email,max=255"`
 	Password string `json:"password" validate:"required,min=1"`
 }
 
 type LoginResponse struct {
-	Token string       `json:"token"`
-	User  UserResponse `json:"user"`
+	AuthTokens
+	User UserResponse `json:"user"`
 }
 
 type RegisterRequest struct {
@@ -27,9 +33,12 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refreshToken" validate:"required"`
 }
 
-type RefreshTokenResponse struct {
-	Token        string `json:"token"`
-	RefreshToken string `json:"refreshToken"`
+// AuthTokens - Access token plus the rotating refresh token of a session
+type AuthTokens struct {
+	Token        string    `json:"token"`        // access token (short-lived)
+	RefreshToken string    `json:"refreshToken"` // single use, replaced on every refresh
+	ExpiresAt    time.Time `json:"expiresAt"`    // access token expiry
+	SessionID    uuid.UUID `json:"sessionId"`
 }
 
 type ForgotPasswordRequest struct {
@@ -42,6 +51,14 @@ type ResetPasswordRequest struct {
 	ConfirmPassword string `json:"confirmPassword" validate:"required,eqfield=NewPassword"`
 }
 
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
 type LogoutResponse struct {
 	Message string `json:"message"`
 }
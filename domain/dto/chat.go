@@ -12,13 +12,41 @@ import (
 
 // ConversationResponse - Single conversation
 type ConversationResponse struct {
-	ID            uuid.UUID        `json:"id"`
-	OtherUser     UserResponse     `json:"otherUser"` // The other participant
-	LastMessage   *MessageResponse `json:"lastMessage,omitempty"`
-	LastMessageAt time.Time        `json:"lastMessageAt"`
-	UnreadCount   int              `json:"unreadCount"`
-	CreatedAt     time.Time        `json:"createdAt"`
-	UpdatedAt     time.Time        `json:"updatedAt"`
+	ID                uuid.UUID                         `json:"id"`
+	Type              string                            `json:"type"`                // "direct", "group"
+	OtherUser         *UserResponse                     `json:"otherUser,omitempty"` // The other participant (direct only)
+	Name              *string                           `json:"name,omitempty"`      // Group only
+	AvatarURL         *string                           `json:"avatarUrl,omitempty"` // Group only
+	MyRole            string                            `json:"myRole,omitempty"`    // Group only: "owner", "admin", "member"
+	ParticipantCount  int                               `json:"participantCount"`
+	Participants      []ConversationParticipantResponse `json:"participants,omitempty"` // Group details only
+	LastMessage       *MessageResponse                  `json:"lastMessage,omitempty"`
+	LastMessageAt     time.Time                         `json:"lastMessageAt"`
+	UnreadCount       int                               `json:"unreadCount"`
+	LastReadMessageID *uuid.UUID                        `json:"lastReadMessageId,omitempty"` // Current user's read pointer
+	LastReadAt        *time.Time                        `json:"lastReadAt,omitempty"`
+	LastSeq           int64                             `json:"lastSeq"` // Sync cursor: sequence of the latest change
+	LastReadSeq       int64                             `json:"lastReadSeq"`
+	LastDeliveredSeq  int64                             `json:"lastDeliveredSeq"`
+	OtherReadSeq      *int64                            `json:"otherReadSeq,omitempty"`      // Direct only: the other user's read pointer
+	OtherDeliveredSeq *int64                            `json:"otherDeliveredSeq,omitempty"` // Direct only: the other user's delivery pointer
+	CreatedAt         time.Time                         `json:"createdAt"`
+	UpdatedAt         time.Time                         `json:"updatedAt"`
+
+	// Message request (direct only): set until the other participant accepts
+	IsRequest     bool       `json:"isRequest"`
+	RequestedByID *uuid.UUID `json:"requestedById,omitempty"`
+}
+
+// ConversationParticipantResponse - Member of a group conversation
+type ConversationParticipantResponse struct {
+	User              UserResponse `json:"user"`
+	Role              string       `json:"role"`
+	LastReadMessageID *uuid.UUID   `json:"lastReadMessageId,omitempty"` // For "seen by" indicators
+	LastReadAt        *time.Time   `json:"lastReadAt,omitempty"`
+	LastReadSeq       int64        `json:"lastReadSeq"`
+	LastDeliveredSeq  int64        `json:"lastDeliveredSeq"`
+	JoinedAt          time.Time    `json:"joinedAt"`
 }
 
 // ConversationListResponse - List of conversations with cursor pagination
@@ -30,7 +58,8 @@ type ConversationListResponse struct {
 
 // UnreadCountResponse - Total unread message count
 type UnreadCountResponse struct {
-	TotalUnread int `json:"totalUnread"`
+	TotalUnread  int   `json:"totalUnread"`
+	RequestCount int64 `json:"requestCount"` // Pending message requests (not part of the unread total)
 }
 
 // CreateConversationRequest - Request to create/get a conversation
@@ -38,6 +67,29 @@ type CreateConversationRequest struct {
 	Username string `json:"username" validate:"required,min=3,max=20"`
 }
 
+// CreateGroupConversationRequest - Request to create a group conversation (the creator becomes its owner)
+type CreateGroupConversationRequest struct {
+	Name      string   `json:"name" validate:"required,min=1,max=100"`
+	AvatarURL *string  `json:"avatarUrl,omitempty" validate:"omitempty,url,max=500"`
+	Usernames []string `json:"usernames" validate:"required,min=1,max=99,dive,min=3,max=20"`
+}
+
+// UpdateGroupConversationRequest - Request to rename a group or change its avatar
+type UpdateGroupConversationRequest struct {
+	Name      *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	AvatarURL *string `json:"avatarUrl,omitempty" validate:"omitempty,url,max=500"`
+}
+
+// AddParticipantsRequest - Request to add members to a group conversation
+type AddParticipantsRequest struct {
+	Usernames []string `json:"usernames" validate:"required,min=1,max=99,dive,min=3,max=20"`
+}
+
+// UpdateParticipantRoleRequest - Request to change a member's role ("owner" transfers ownership)
+type UpdateParticipantRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=owner admin member"`
+}
+
 // ============================================================================
 // Message DTOs
 // ============================================================================
@@ -62,20 +114,29 @@ type SendMessageRequest struct {
 	Type           string         `json:"type" validate:"required,oneof=text image video file"`
 	Content        *string        `json:"content,omitempty" validate:"omitempty,min=1,max=5000"`
 	Media          []MessageMedia `json:"media,omitempty"`
-	TempID         *string        `json:"tempId,omitempty"` // Client-generated ID for optimistic updates
+	ReplyToID      *uuid.UUID     `json:"replyToMessageId,omitempty"` // Quoted message (same conversation)
+	TempID         *string        `json:"tempId,omitempty"`           // Client-generated ID for optimistic updates
 }
 
 // MessageResponse - Single message
 type MessageResponse struct {
 	ID             uuid.UUID      `json:"id"`
 	ConversationID uuid.UUID      `json:"conversationId"`
+	Seq            int64          `json:"seq"`
 	Sender         UserResponse   `json:"sender"`
-	Receiver       UserResponse   `json:"receiver"`
-	Type           string         `json:"type"` // "text", "image", "video", "file"
+	Receiver       *UserResponse  `json:"receiver,omitempty"` // Direct conversations only
+	Type           string         `json:"type"`               // "text", "image", "video", "file", "gift"
 	Content        *string        `json:"content,omitempty"`
 	Media          []MessageMedia `json:"media,omitempty"`
+	Gift           *GiftInfo      `json:"gift,omitempty"` // Only for gift messages
+	ReplyTo        *QuotedMessage `json:"replyTo,omitempty"`
+	Reactions      []ReactionInfo `json:"reactions,omitempty"` // Grouped by emoji
 	IsRead         bool           `json:"isRead"`
 	ReadAt         *time.Time     `json:"readAt,omitempty"`
+	Status         string         `json:"status,omitempty"` // Own messages only: "sent", "delivered", "read" (by every other participant)
+	IsEdited       bool           `json:"isEdited"`
+	EditedAt       *time.Time     `json:"editedAt,omitempty"`
+	IsRemoved      bool           `json:"isRemoved"` // Removed by a moderator, content and media are gone
 	CreatedAt      time.Time      `json:"createdAt"`
 	UpdatedAt      time.Time      `json:"updatedAt"`
 	TempID         *string        `json:"tempId,omitempty"` // Echo back client's tempId if provided
@@ -84,6 +145,55 @@ type MessageResponse struct {
 	SenderId uuid.UUID `json:"senderId"` // Same as Sender.ID, for easier access
 }
 
+// MessageSearchResult - A message matching a search (jump to it with GET /messages/:id/context)
+type MessageSearchResult struct {
+	Message    MessageResponse `json:"message"`
+	Snippet    string          `json:"snippet"`    // Part of the text around the first match
+	Highlights []TextRange     `json:"highlights"` // Matches within the snippet
+}
+
+// TextRange - A range of characters (Unicode code points, not bytes)
+type TextRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// MessageSearchResponse - Search results with cursor pagination (newest first)
+type MessageSearchResponse struct {
+	Query      string                `json:"query"`
+	Results    []MessageSearchResult `json:"results"`
+	NextCursor *string               `json:"nextCursor,omitempty"` // Base64 encoded timestamp
+	HasMore    bool                  `json:"hasMore"`
+}
+
+// QuotedMessage - Preview of the message a reply quotes (jump to it with GET /messages/:id/context)
+type QuotedMessage struct {
+	ID       uuid.UUID    `json:"id"`
+	Sender   UserResponse `json:"sender"`
+	Type     string       `json:"type"`
+	Snippet  *string      `json:"snippet,omitempty"` // Start of the text (hidden for sealed gifts)
+	HasMedia bool         `json:"hasMedia"`
+}
+
+// ReactionInfo - Reactions to a message with the same emoji
+type ReactionInfo struct {
+	Emoji   string      `json:"emoji"`
+	Count   int         `json:"count"`
+	UserIDs []uuid.UUID `json:"userIds"`
+}
+
+// AddReactionRequest - Request to react to a message
+type AddReactionRequest struct {
+	Emoji string `json:"emoji" validate:"required,max=16"`
+}
+
+// MessageReactionsResponse - All reactions to a message (sent after every change)
+type MessageReactionsResponse struct {
+	MessageID      uuid.UUID      `json:"messageId"`
+	ConversationID uuid.UUID      `json:"conversationId"`
+	Reactions      []ReactionInfo `json:"reactions"`
+}
+
 // MessageListResponse - List of messages with cursor pagination
 type MessageListResponse struct {
 	Messages   []MessageResponse `json:"messages"`
@@ -102,6 +212,86 @@ type MessageContextResponse struct {
 	HasMoreAfter  bool              `json:"hasMoreAfter"`
 }
 
+// EditMessageRequest - Request to edit the text of a message (the previous text is kept as a revision)
+type EditMessageRequest struct {
+	Content string `json:"content" validate:"required,min=1,max=5000"`
+}
+
+// MessageRevisionResponse - Text of a message before one of its edits
+type MessageRevisionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Content    *string   `json:"content,omitempty"`
+	ReplacedAt time.Time `json:"replacedAt"`
+}
+
+// MessageRevisionListResponse - Edit history of a message (oldest first)
+type MessageRevisionListResponse struct {
+	MessageID uuid.UUID                 `json:"messageId"`
+	Revisions []MessageRevisionResponse `json:"revisions"`
+}
+
+// UnsendMessageResponse - Result of unsending a message
+type UnsendMessageResponse struct {
+	MessageID      uuid.UUID        `json:"messageId"`
+	ConversationID uuid.UUID        `json:"conversationId"`
+	LastMessage    *MessageResponse `json:"lastMessage,omitempty"` // New last message when the unsent one was the last
+}
+
+// Message statuses (from the sender's point of view)
+const (
+	MessageStatusSent      = "sent"
+	MessageStatusDelivered = "delivered"
+	MessageStatusRead      = "read"
+)
+
+// MessageReceiptResponse - Delivery/read state of a message for one participant
+type MessageReceiptResponse struct {
+	User   UserResponse `json:"user"`
+	Status string       `json:"status"` // "sent", "delivered", "read"
+}
+
+// MessageReceiptsResponse - Delivery/read state of a message for every other participant
+type MessageReceiptsResponse struct {
+	MessageID uuid.UUID                `json:"messageId"`
+	Seq       int64                    `json:"seq"`
+	Receipts  []MessageReceiptResponse `json:"receipts"`
+}
+
+// UpdateReceiptRequest - Request to move the delivered/read pointer (0 = up to the latest message)
+type UpdateReceiptRequest struct {
+	Seq int64 `json:"seq" validate:"omitempty,min=0"`
+}
+
+// ReadStateResponse - The current user's receipt pointers and unread counts after an update
+type ReadStateResponse struct {
+	ConversationID    uuid.UUID  `json:"conversationId"`
+	LastDeliveredSeq  int64      `json:"lastDeliveredSeq"`
+	LastReadSeq       int64      `json:"lastReadSeq"`
+	LastReadMessageID *uuid.UUID `json:"lastReadMessageId,omitempty"`
+	UnreadCount       int        `json:"unreadCount"`
+	TotalUnread       int        `json:"totalUnread"`
+}
+
+// ConversationEventResponse - A change replayed by sync
+type ConversationEventResponse struct {
+	Seq       int64            `json:"seq"`
+	Type      string           `json:"type"` // "message.new", "message.edited", "message.unsent", "receipt.delivered", "receipt.read"
+	UserID    uuid.UUID        `json:"userId"`
+	MessageID *uuid.UUID       `json:"messageId,omitempty"`
+	Message   *MessageResponse `json:"message,omitempty"` // Current version of the message (new/edited)
+	UpToSeq   *int64           `json:"upToSeq,omitempty"` // Receipts
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// ConversationSyncResponse - Changes after the client's last seen sequence
+type ConversationSyncResponse struct {
+	ConversationID uuid.UUID                   `json:"conversationId"`
+	Events         []ConversationEventResponse `json:"events"`
+	LastSeq        int64                       `json:"lastSeq"`
+	Reset          bool                        `json:"reset"` // Too far behind: reload the conversation instead of replaying
+	UnreadCount    int                         `json:"unreadCount"`
+}
+
 // MarkMessagesAsReadRequest - Request to mark messages as read
 type MarkMessagesAsReadRequest struct {
 	ConversationID uuid.UUID `json:"conversationId" validate:"required,uuid"`
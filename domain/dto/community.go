@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Community requests
+// ============================================================================
+
+// CommunityRule - One rule shown in the community sidebar (stored in order)
+type CommunityRule struct {
+	Title       string `json:"title" validate:"required,min=1,max=100"`
+	Description string `json:"description" validate:"omitempty,max=500"`
+}
+
+// CreateCommunityRequest - Create a community (the creator becomes its owner)
+type CreateCommunityRequest struct {
+	Slug          string          `json:"slug" validate:"required,min=3,max=50"` // lowercase letters, digits and underscores
+	Name          string          `json:"name" validate:"required,min=1,max=100"`
+	Description   string          `json:"description" validate:"omitempty,max=5000"`
+	Rules         []CommunityRule `json:"rules" validate:"omitempty,max=15,dive"`
+	IconMediaID   *uuid.UUID      `json:"iconMediaId"`
+	BannerMediaID *uuid.UUID      `json:"bannerMediaId"`
+	Visibility    string          `json:"visibility" validate:"omitempty,oneof=public restricted private"` // default public
+	PostingPolicy string          `json:"postingPolicy" validate:"omitempty,oneof=members moderators"`     // default members
+}
+
+// UpdateCommunityRequest - Moderators edit the community (slug can't change)
+type UpdateCommunityRequest struct {
+	Name          *string         `json:"name" validate:"omitempty,min=1,max=100"`
+	Description   *string         `json:"description" validate:"omitempty,max=5000"`
+	Rules         []CommunityRule `json:"rules" validate:"omitempty,max=15,dive"`
+	IconMediaID   *uuid.UUID      `json:"iconMediaId"`
+	BannerMediaID *uuid.UUID      `json:"bannerMediaId"`
+	Visibility    *string         `json:"visibility" validate:"omitempty,oneof=public restricted private"` // owner only
+	PostingPolicy *string         `json:"postingPolicy" validate:"omitempty,oneof=members moderators"`     // owner only
+}
+
+// SetCommunityMemberRoleRequest - Owner promotes or demotes a moderator
+type SetCommunityMemberRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=moderator member"`
+}
+
+// ============================================================================
+// Community responses
+// ============================================================================
+
+// CommunityResponse - Community details
+type CommunityResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	Slug          string          `json:"slug"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	Rules         []CommunityRule `json:"rules"`
+	IconURL       string          `json:"iconUrl,omitempty"`
+	BannerURL     string          `json:"bannerUrl,omitempty"`
+	Visibility    string          `json:"visibility"`    // public, restricted, private
+	PostingPolicy string          `json:"postingPolicy"` // members, moderators
+	Owner         *UserResponse   `json:"owner,omitempty"`
+	MemberCount   int             `json:"memberCount"`
+	PostCount     int             `json:"postCount"`
+	CreatedAt     time.Time       `json:"createdAt"`
+
+	// User-specific fields (when authenticated)
+	Membership *CommunityMembershipInfo `json:"membership,omitempty"`
+}
+
+// CommunityMembershipInfo - The viewer's membership in a community
+type CommunityMembershipInfo struct {
+	Role     string     `json:"role"`   // owner, moderator, member
+	Status   string     `json:"status"` // pending, active, banned
+	JoinedAt *time.Time `json:"joinedAt,omitempty"`
+	CanPost  bool       `json:"canPost"`
+}
+
+// CommunitySummaryResponse - Lightweight community info for nested responses (posts)
+type CommunitySummaryResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Slug       string    `json:"slug"`
+	Name       string    `json:"name"`
+	IconURL    string    `json:"iconUrl,omitempty"`
+	Visibility string    `json:"visibility"`
+}
+
+// CommunityListResponse - Communities (most members first)
+type CommunityListResponse struct {
+	Communities []CommunityResponse `json:"communities"`
+	Meta        PaginationMeta      `json:"meta"`
+}
+
+// CommunityMemberResponse - A member of a community
+type CommunityMemberResponse struct {
+	User     UserResponse `json:"user"`
+	Role     string       `json:"role"`
+	Status   string       `json:"status"`
+	JoinedAt *time.Time   `json:"joinedAt,omitempty"`
+}
+
+// CommunityMemberListResponse - Members of a community (owner and moderators first)
+type CommunityMemberListResponse struct {
+	Members []CommunityMemberResponse `json:"members"`
+	Meta    PaginationMeta            `json:"meta"`
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gofiber-template/domain/models"
 )
 
 // FollowRequest - Request for following a user
@@ -20,6 +21,7 @@ type UnfollowRequest struct {
 type FollowResponse struct {
 	FollowerID  uuid.UUID `json:"followerId"`
 	FollowingID uuid.UUID `json:"followingId"`
+	Status      string    `json:"status"` // "following" or "requested" (private account)
 	CreatedAt   time.Time `json:"createdAt"`
 }
 
@@ -50,5 +52,31 @@ type FollowingListCursorResponse struct {
 // FollowStatusResponse - Response for checking follow status
 type FollowStatusResponse struct {
 	IsFollowing bool `json:"isFollowing"`
-	IsMutual    bool `json:"isMutual,omitempty"` // Both users follow each other
+	IsMutual    bool `json:"isMutual,omitempty"`    // Both users follow each other
+	IsRequested bool `json:"isRequested,omitempty"` // Follow request pending (private account)
+}
+
+// FollowRequestResponse - A pending follow request of a private account
+type FollowRequestResponse struct {
+	ID        uuid.UUID    `json:"id"`
+	Requester UserResponse `json:"requester"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// FollowRequestListResponse - Response for listing pending follow requests
+type FollowRequestListResponse struct {
+	Requests []FollowRequestResponse `json:"requests"`
+	Meta     PaginationMeta          `json:"meta"`
+}
+
+func FollowRequestToResponse(request *models.FollowRequest) *FollowRequestResponse {
+	resp := &FollowRequestResponse{
+		ID:        request.ID,
+		CreatedAt: request.CreatedAt,
+	}
+	if requester := UserToUserResponse(&request.Requester); requester != nil {
+		requester.Email = ""
+		resp.Requester = *requester
+	}
+	return resp
 }
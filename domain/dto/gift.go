@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Gift box DTOs
+// All amounts are in satang (1 THB = 100 satang)
+// ============================================================================
+
+// SendGiftRequest - Buy a gift from the catalog and send it as a chat message
+type SendGiftRequest struct {
+	ConversationID uuid.UUID   `json:"conversationId" validate:"required,uuid"`
+	PackageID      uuid.UUID   `json:"packageId" validate:"required,uuid"`
+	Content        *string     `json:"content,omitempty" validate:"omitempty,min=1,max=500"`
+	MediaIDs       []uuid.UUID `json:"mediaIds,omitempty" validate:"omitempty,max=10,dive,uuid"` // Uploaded via /media
+	TempID         *string     `json:"tempId,omitempty"`                                         // Client-generated ID for optimistic updates
+}
+
+// GiftPackageResponse - Gift catalog entry
+type GiftPackageResponse struct {
+	ID               uuid.UUID `json:"id"`
+	Code             string    `json:"code"`
+	Name             string    `json:"name"`
+	Price            int64     `json:"price"`          // satang
+	ReceiverAmount   int64     `json:"receiverAmount"` // satang, credited on open
+	ReceiverPercent  int       `json:"receiverPercent"`
+	MaxImages        int       `json:"maxImages"`
+	MaxVideos        int       `json:"maxVideos"`
+	MaxMessageLength int       `json:"maxMessageLength"`
+	Icon             string    `json:"icon,omitempty"`
+	Color            string    `json:"color,omitempty"`
+}
+
+// GiftPackageListResponse - Gift catalog
+type GiftPackageListResponse struct {
+	Packages []GiftPackageResponse `json:"packages"`
+}
+
+// GiftInfo - Gift box state embedded in MessageResponse
+type GiftInfo struct {
+	ID             uuid.UUID            `json:"id"`
+	Package        *GiftPackageResponse `json:"package,omitempty"`
+	ReceiverAmount int64                `json:"receiverAmount"` // satang
+	Status         string               `json:"status"`         // pending, opened, expired, refunded
+	IsSealed       bool                 `json:"isSealed"`       // content and media hidden until opened
+	ExpiresAt      time.Time            `json:"expiresAt"`
+	OpenedAt       *time.Time           `json:"openedAt,omitempty"`
+}
@@ -2,6 +2,8 @@ package dto
 
 import (
 	"encoding/json"
+	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"gofiber-template/domain/models"
@@ -23,8 +25,10 @@ func UserToUserResponse(user *models.User) *UserResponse {
 		Karma:          user.Karma,
 		FollowersCount: user.FollowersCount,
 		FollowingCount: user.FollowingCount,
-		Role:           user.Role,
 		IsActive:       user.IsActive,
+		IsPrivate:      user.IsPrivate,
+		ChatPrivacy:    user.ChatPrivacy,
+		EmailVerified:  user.EmailVerified,
 		CreatedAt:      user.CreatedAt,
 		UpdatedAt:      user.UpdatedAt,
 	}
@@ -142,7 +146,18 @@ func FileToFileResponse(file *models.File) *FileResponse {
 }
 
 // Post mappers
+// PostToPostResponse maps a post for the public; premium unlock and subscriber-only posts are returned as a teaser
 func PostToPostResponse(post *models.Post) *PostResponse {
+	return PostToPostResponseWithAccess(post, PostViewerAccess{})
+}
+
+// PostToUnlockedPostResponse maps a post with all gated content revealed (author / entitled viewers)
+func PostToUnlockedPostResponse(post *models.Post) *PostResponse {
+	return PostToPostResponseWithAccess(post, PostViewerAccess{Premium: true, Subscriber: true})
+}
+
+// PostToPostResponseWithAccess maps a post revealing only the gated content the viewer is entitled to
+func PostToPostResponseWithAccess(post *models.Post, access PostViewerAccess) *PostResponse {
 	if post == nil {
 		return nil
 	}
@@ -181,9 +196,60 @@ func PostToPostResponse(post *models.Post) *PostResponse {
 		resp.SourcePost = PostToPostResponse(post.SourcePost)
 	}
 
+	// Map community (nil for personal posts)
+	if post.Community != nil {
+		resp.Community = CommunityToCommunitySummaryResponse(post.Community)
+	}
+
+	// Premium unlock: hide content and media behind a blurred teaser
+	if post.IsPremiumUnlock() {
+		resp.Unlock = PostToPostUnlockInfo(post)
+		resp.Unlock.IsLocked = !access.Premium
+		if !access.Premium {
+			resp.Content = ""
+			resp.Media = nil
+		}
+	}
+
+	// Subscriber-only: hide content and media until the viewer subscribes to the required tier
+	if post.IsSubscriberOnly() {
+		resp.Subscription = &PostSubscriptionInfo{
+			MinTierLevel: *post.MinTierLevel,
+			MediaCount:   len(post.Media),
+			IsLocked:     !access.Subscriber,
+		}
+		if !access.Subscriber {
+			resp.Content = ""
+			resp.Media = nil
+		}
+	}
+
 	return resp
 }
 
+// PostToPostUnlockInfo maps the premium unlock progress of a post
+func PostToPostUnlockInfo(post *models.Post) *PostUnlockInfo {
+	if post == nil || !post.IsPremiumUnlock() {
+		return nil
+	}
+
+	info := &PostUnlockInfo{
+		TargetAmount:     *post.UnlockTargetAmount,
+		CurrentAmount:    post.UnlockCurrentAmount,
+		ContributorCount: post.UnlockContributorCount,
+		Progress:         post.UnlockProgress(),
+		Deadline:         post.UnlockDeadline,
+		UnlockedAt:       post.UnlockedAt,
+		BlurredThumbnail: post.UnlockPreviewURL,
+		MediaCount:       len(post.Media),
+		IsLocked:         true,
+	}
+	if post.UnlockStatus != nil {
+		info.Status = *post.UnlockStatus
+	}
+	return info
+}
+
 // Comment mappers
 func CommentToCommentResponse(comment *models.Comment) *CommentResponse {
 	if comment == nil {
@@ -281,7 +347,7 @@ func MediaToMediaResponse(media *models.Media) *MediaResponse {
 		return nil
 	}
 
-	return &MediaResponse{
+	resp := &MediaResponse{
 		ID:         media.ID,
 		UserID:     media.UserID,
 		Type:       media.Type,
@@ -297,6 +363,15 @@ func MediaToMediaResponse(media *models.Media) *MediaResponse {
 		SourceID:   media.SourceID,
 		CreatedAt:  media.CreatedAt,
 	}
+
+	// Encoding status only matters for Bunny Stream videos
+	if media.VideoID != nil {
+		resp.HLSURL = media.HLSURL
+		resp.EncodingStatus = media.EncodingStatus
+		resp.EncodingProgress = media.EncodingProgress
+	}
+
+	return resp
 }
 
 func MediaToMediaUploadResponse(media *models.Media) *MediaUploadResponse {
@@ -381,12 +456,15 @@ func MessageToMessageResponse(message *models.Message) *MessageResponse {
 	resp := &MessageResponse{
 		ID:             message.ID,
 		ConversationID: message.ConversationID,
+		Seq:            message.Seq,
 		Sender:         *UserToUserResponse(&message.Sender),
-		Receiver:       *UserToUserResponse(&message.Receiver),
 		Type:           string(message.Type),
 		Content:        message.Content,
 		IsRead:         message.IsRead,
 		ReadAt:         message.ReadAt,
+		IsEdited:       message.EditedAt != nil,
+		EditedAt:       message.EditedAt,
+		IsRemoved:      message.RemovedAt != nil,
 		CreatedAt:      message.CreatedAt,
 		UpdatedAt:      message.UpdatedAt,
 
@@ -394,6 +472,10 @@ func MessageToMessageResponse(message *models.Message) *MessageResponse {
 		SenderId: message.SenderID,
 	}
 
+	if message.Receiver != nil {
+		resp.Receiver = UserToUserResponse(message.Receiver)
+	}
+
 	// Unmarshal Media JSONB to []MessageMedia
 	if message.Media != nil && len(message.Media) > 0 {
 		var mediaList []MessageMedia
@@ -402,9 +484,203 @@ func MessageToMessageResponse(message *models.Message) *MessageResponse {
 		}
 	}
 
+	// Gift box: keep content and media sealed until the receiver opens it
+	if message.Type == models.MessageTypeGift {
+		resp.Gift = GiftToGiftInfo(message.Gift)
+		if message.Gift == nil || message.Gift.IsSealed() {
+			resp.Content = nil
+			resp.Media = nil
+		}
+	}
+
+	resp.ReplyTo = MessageToQuotedMessage(message.ReplyTo)
+	if len(message.Reactions) > 0 {
+		resp.Reactions = MessageReactionsToReactionInfos(message.Reactions)
+	}
+
 	return resp
 }
 
+// quotedSnippetLength - Characters of the original text shown in a quoted reply
+const quotedSnippetLength = 100
+
+// MessageToQuotedMessage converts the original of a reply to its QuotedMessage preview
+func MessageToQuotedMessage(message *models.Message) *QuotedMessage {
+	if message == nil {
+		return nil
+	}
+
+	quoted := &QuotedMessage{
+		ID:       message.ID,
+		Sender:   *UserToUserResponse(&message.Sender),
+		Type:     string(message.Type),
+		HasMedia: len(message.Media) > 0,
+	}
+
+	// A sealed gift box must not leak its content through a quote
+	sealed := message.Type == models.MessageTypeGift && (message.Gift == nil || message.Gift.IsSealed())
+	if message.Content != nil && !sealed {
+		snippet := []rune(*message.Content)
+		if len(snippet) > quotedSnippetLength {
+			snippet = append(snippet[:quotedSnippetLength], '…')
+		}
+		text := string(snippet)
+		quoted.Snippet = &text
+	}
+	if sealed {
+		quoted.HasMedia = false
+	}
+
+	return quoted
+}
+
+// Search snippets - Characters of the text shown around the first match
+const (
+	searchSnippetLength  = 160
+	searchSnippetLeading = 40 // characters kept before the first match
+)
+
+// MessageToSearchResult builds a search result with the matches of query highlighted (case-insensitive)
+func MessageToSearchResult(message *models.Message, query string) MessageSearchResult {
+	result := MessageSearchResult{
+		Message:    *MessageToMessageResponse(message),
+		Highlights: []TextRange{},
+	}
+	if message.Content == nil {
+		return result
+	}
+
+	text := []rune(*message.Content)
+	matches := findMatches(text, []rune(query))
+
+	// Window of the text around the first match
+	start := 0
+	if len(matches) > 0 && matches[0].Start > searchSnippetLeading {
+		start = matches[0].Start - searchSnippetLeading
+	}
+	end := start + searchSnippetLength
+	if end > len(text) {
+		end = len(text)
+		if start = end - searchSnippetLength; start < 0 {
+			start = 0
+		}
+	}
+
+	offset := -start
+	snippet := string(text[start:end])
+	if start > 0 {
+		snippet = "…" + snippet
+		offset++
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	result.Snippet = snippet
+
+	for _, match := range matches {
+		if match.Start >= start && match.Start+match.Length <= end {
+			result.Highlights = append(result.Highlights, TextRange{Start: match.Start + offset, Length: match.Length})
+		}
+	}
+
+	return result
+}
+
+// findMatches returns the non-overlapping case-insensitive occurrences of query in text
+func findMatches(text, query []rune) []TextRange {
+	if len(query) == 0 {
+		return nil
+	}
+
+	lowerText := make([]rune, len(text))
+	for i, r := range text {
+		lowerText[i] = unicode.ToLower(r)
+	}
+	lowerQuery := make([]rune, len(query))
+	for i, r := range query {
+		lowerQuery[i] = unicode.ToLower(r)
+	}
+
+	var matches []TextRange
+	for i := 0; i+len(lowerQuery) <= len(lowerText); {
+		if string(lowerText[i:i+len(lowerQuery)]) == string(lowerQuery) {
+			matches = append(matches, TextRange{Start: i, Length: len(lowerQuery)})
+			i += len(lowerQuery)
+			continue
+		}
+		i++
+	}
+	return matches
+}
+
+// MessageReactionsToReactionInfos groups reactions by emoji (in order of first use)
+func MessageReactionsToReactionInfos(reactions []models.MessageReaction) []ReactionInfo {
+	infos := make([]ReactionInfo, 0)
+	index := make(map[string]int)
+	for _, reaction := range reactions {
+		i, ok := index[reaction.Emoji]
+		if !ok {
+			i = len(infos)
+			index[reaction.Emoji] = i
+			infos = append(infos, ReactionInfo{Emoji: reaction.Emoji, UserIDs: []uuid.UUID{}})
+		}
+		infos[i].Count++
+		infos[i].UserIDs = append(infos[i].UserIDs, reaction.UserID)
+	}
+	return infos
+}
+
+// MessageRevisionToResponse converts MessageRevision model to MessageRevisionResponse DTO
+func MessageRevisionToResponse(revision *models.MessageRevision) *MessageRevisionResponse {
+	if revision == nil {
+		return nil
+	}
+
+	return &MessageRevisionResponse{
+		ID:         revision.ID,
+		Content:    revision.Content,
+		ReplacedAt: revision.CreatedAt,
+	}
+}
+
+// GiftToGiftInfo converts Gift model to GiftInfo DTO
+func GiftToGiftInfo(gift *models.Gift) *GiftInfo {
+	if gift == nil {
+		return nil
+	}
+
+	return &GiftInfo{
+		ID:             gift.ID,
+		Package:        GiftPackageToGiftPackageResponse(gift.Package),
+		ReceiverAmount: gift.ReceiverAmount,
+		Status:         gift.Status,
+		IsSealed:       gift.IsSealed(),
+		ExpiresAt:      gift.ExpiresAt,
+		OpenedAt:       gift.OpenedAt,
+	}
+}
+
+// GiftPackageToGiftPackageResponse converts GiftPackage model to GiftPackageResponse DTO
+func GiftPackageToGiftPackageResponse(pkg *models.GiftPackage) *GiftPackageResponse {
+	if pkg == nil {
+		return nil
+	}
+
+	return &GiftPackageResponse{
+		ID:               pkg.ID,
+		Code:             pkg.Code,
+		Name:             pkg.Name,
+		Price:            pkg.Price,
+		ReceiverAmount:   pkg.ReceiverAmount(),
+		ReceiverPercent:  pkg.ReceiverPercent,
+		MaxImages:        pkg.MaxImages,
+		MaxVideos:        pkg.MaxVideos,
+		MaxMessageLength: pkg.MaxMessageLength,
+		Icon:             pkg.Icon,
+		Color:            pkg.Color,
+	}
+}
+
 // ConversationToConversationResponse converts Conversation model to ConversationResponse DTO
 // currentUserID is needed to determine who the "other user" is and which unread count to show
 func ConversationToConversationResponse(conversation *models.Conversation, currentUserID uuid.UUID) *ConversationResponse {
@@ -412,30 +688,107 @@ func ConversationToConversationResponse(conversation *models.Conversation, curre
 		return nil
 	}
 
-	// Determine who is the "other user" and get their unread count
-	var otherUser models.User
-	var unreadCount int
+	resp := &ConversationResponse{
+		ID:               conversation.ID,
+		Type:             conversation.Type,
+		ParticipantCount: len(conversation.Participants),
+		LastMessageAt:    conversation.LastMessageAt,
+		LastSeq:          conversation.LastSeq,
+		CreatedAt:        conversation.CreatedAt,
+		UpdatedAt:        conversation.UpdatedAt,
+		IsRequest:        conversation.IsPendingRequest(),
+		RequestedByID:    conversation.RequestedByID,
+	}
 
-	if conversation.User1ID == currentUserID {
-		otherUser = conversation.User2
-		unreadCount = conversation.User1UnreadCount
-	} else {
-		otherUser = conversation.User1
-		unreadCount = conversation.User2UnreadCount
+	// Unread count and read pointer of the current user
+	for i := range conversation.Participants {
+		participant := &conversation.Participants[i]
+		if participant.UserID == currentUserID {
+			resp.UnreadCount = participant.UnreadCount
+			resp.LastReadMessageID = participant.LastReadMessageID
+			resp.LastReadAt = participant.LastReadAt
+			resp.LastReadSeq = participant.LastReadSeq
+			resp.LastDeliveredSeq = participant.LastDeliveredSeq
+			if conversation.IsGroup() {
+				resp.MyRole = participant.Role
+			}
+		} else if !conversation.IsGroup() {
+			resp.OtherReadSeq = &participant.LastReadSeq
+			resp.OtherDeliveredSeq = &participant.LastDeliveredSeq
+		}
 	}
 
-	resp := &ConversationResponse{
-		ID:            conversation.ID,
-		OtherUser:     *UserToUserResponse(&otherUser),
-		LastMessageAt: conversation.LastMessageAt,
-		UnreadCount:   unreadCount,
-		CreatedAt:     conversation.CreatedAt,
-		UpdatedAt:     conversation.UpdatedAt,
+	if !conversation.IsGroup() {
+		// Determine who is the "other user"
+		otherUser := conversation.User1
+		if conversation.User1ID != nil && *conversation.User1ID == currentUserID {
+			otherUser = conversation.User2
+		}
+		if otherUser != nil {
+			resp.OtherUser = UserToUserResponse(otherUser)
+		}
+		return resp
+	}
+
+	resp.Name = conversation.Name
+	resp.AvatarURL = conversation.AvatarURL
+
+	// Member list (only when the participants were loaded with their users)
+	for _, participant := range conversation.Participants {
+		if participant.User.ID == uuid.Nil {
+			continue
+		}
+		resp.Participants = append(resp.Participants, ConversationParticipantResponse{
+			User:              *UserToUserResponse(&participant.User),
+			Role:              participant.Role,
+			LastReadMessageID: participant.LastReadMessageID,
+			LastReadAt:        participant.LastReadAt,
+			LastReadSeq:       participant.LastReadSeq,
+			LastDeliveredSeq:  participant.LastDeliveredSeq,
+			JoinedAt:          participant.JoinedAt,
+		})
 	}
 
 	return resp
 }
 
+// MessageStatus is the status of a message with this sequence from its sender's point of view
+// (read or delivered once every other participant got that far)
+func MessageStatus(seq int64, senderID uuid.UUID, participants []models.ConversationParticipant) string {
+	status := MessageStatusRead
+	for i := range participants {
+		participant := &participants[i]
+		if participant.UserID == senderID {
+			continue
+		}
+		if !participant.HasReceived(seq) {
+			return MessageStatusSent
+		}
+		if !participant.HasRead(seq) {
+			status = MessageStatusDelivered
+		}
+	}
+	return status
+}
+
+// ConversationEventToResponse converts ConversationEvent model to ConversationEventResponse DTO
+// (message is the current version of the event's message, nil when it was unsent)
+func ConversationEventToResponse(event *models.ConversationEvent, message *models.Message) *ConversationEventResponse {
+	if event == nil {
+		return nil
+	}
+
+	return &ConversationEventResponse{
+		Seq:       event.Seq,
+		Type:      event.Type,
+		UserID:    event.UserID,
+		MessageID: event.MessageID,
+		Message:   MessageToMessageResponse(message),
+		UpToSeq:   event.UpToSeq,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
 // BlockToBlockedUserResponse converts Block model to BlockedUserResponse DTO
 func BlockToBlockedUserResponse(block *models.Block) *BlockedUserResponse {
 	if block == nil {
@@ -447,3 +800,459 @@ func BlockToBlockedUserResponse(block *models.Block) *BlockedUserResponse {
 		BlockedAt: block.CreatedAt,
 	}
 }
+
+// ============================================================================
+// Wallet mappers
+// ============================================================================
+
+// WalletToWalletResponse converts Wallet model to WalletResponse DTO
+func WalletToWalletResponse(wallet *models.Wallet) *WalletResponse {
+	if wallet == nil {
+		return nil
+	}
+
+	return &WalletResponse{
+		ID:               wallet.ID,
+		Currency:         wallet.Currency,
+		Balance:          wallet.Balance,
+		HeldAmount:       wallet.HeldAmount,
+		AvailableBalance: wallet.AvailableBalance(),
+		UpdatedAt:        wallet.UpdatedAt,
+	}
+}
+
+// LedgerEntryToLedgerEntryResponse converts LedgerEntry model to LedgerEntryResponse DTO
+func LedgerEntryToLedgerEntryResponse(entry *models.LedgerEntry) *LedgerEntryResponse {
+	if entry == nil {
+		return nil
+	}
+
+	resp := &LedgerEntryResponse{
+		ID:            entry.ID,
+		TransactionID: entry.TransactionID,
+		Amount:        entry.Amount,
+		BalanceAfter:  entry.BalanceAfter,
+		CreatedAt:     entry.CreatedAt,
+	}
+
+	if entry.Transaction != nil {
+		resp.Type = entry.Transaction.Type
+		resp.Description = entry.Transaction.Description
+		resp.ReferenceType = entry.Transaction.ReferenceType
+		resp.ReferenceID = entry.Transaction.ReferenceID
+	}
+
+	return resp
+}
+
+// TopUpIntentToTopUpIntentResponse converts TopUpIntent model to TopUpIntentResponse DTO
+func TopUpIntentToTopUpIntentResponse(intent *models.TopUpIntent) *TopUpIntentResponse {
+	if intent == nil {
+		return nil
+	}
+
+	resp := &TopUpIntentResponse{
+		ID:         intent.ID,
+		Amount:     intent.Amount,
+		Currency:   intent.Currency,
+		Provider:   intent.Provider,
+		Status:     intent.Status,
+		ExpiresAt:  intent.ExpiresAt,
+		PaidAt:     intent.PaidAt,
+		CreditedAt: intent.CreditedAt,
+		CreatedAt:  intent.CreatedAt,
+	}
+
+	// The QR is only useful while it can still be paid
+	if intent.Status == models.TopUpStatusPending {
+		resp.QRPayload = intent.QRPayload
+	}
+
+	return resp
+}
+
+// ============================================================================
+// Subscription mappers
+// ============================================================================
+
+// SubscriptionTierToSubscriptionTierResponse converts SubscriptionTier model to SubscriptionTierResponse DTO
+func SubscriptionTierToSubscriptionTierResponse(tier *models.SubscriptionTier) *SubscriptionTierResponse {
+	if tier == nil {
+		return nil
+	}
+
+	resp := &SubscriptionTierResponse{
+		ID:           tier.ID,
+		CreatorID:    tier.CreatorID,
+		Name:         tier.Name,
+		Description:  tier.Description,
+		TierLevel:    tier.TierLevel,
+		PriceMonthly: tier.PriceMonthly,
+		PriceYearly:  tier.Price(models.BillingCycleYearly),
+		Perks:        []string{},
+		TrialDays:    tier.TrialDays,
+		IsActive:     tier.IsActive,
+		CreatedAt:    tier.CreatedAt,
+	}
+
+	if len(tier.Perks) > 0 {
+		_ = json.Unmarshal(tier.Perks, &resp.Perks)
+	}
+
+	return resp
+}
+
+// SubscriptionToSubscriptionResponse converts Subscription model to SubscriptionResponse DTO
+func SubscriptionToSubscriptionResponse(subscription *models.Subscription) *SubscriptionResponse {
+	if subscription == nil {
+		return nil
+	}
+
+	resp := &SubscriptionResponse{
+		ID:                 subscription.ID,
+		Tier:               SubscriptionTierToSubscriptionTierResponse(subscription.Tier),
+		PendingTierID:      subscription.PendingTierID,
+		Status:             subscription.Status,
+		BillingCycle:       subscription.BillingCycle,
+		Price:              subscription.Price,
+		CurrentPeriodStart: subscription.CurrentPeriodStart,
+		CurrentPeriodEnd:   subscription.CurrentPeriodEnd,
+		TrialEnd:           subscription.TrialEnd,
+		CancelAtPeriodEnd:  subscription.CancelAtPeriodEnd,
+		CancelledAt:        subscription.CancelledAt,
+		GraceUntil:         subscription.GraceUntil,
+		HasAccess:          subscription.HasAccess(time.Now()),
+		CreatedAt:          subscription.CreatedAt,
+	}
+
+	if subscription.Creator.ID != uuid.Nil {
+		resp.Creator = UserToUserResponse(&subscription.Creator)
+	}
+	if subscription.Subscriber.ID != uuid.Nil {
+		resp.Subscriber = UserToUserResponse(&subscription.Subscriber)
+	}
+
+	return resp
+}
+
+// ============================================================================
+// Reel mappers
+// ============================================================================
+
+// ReelToReelResponse converts Reel model to ReelResponse DTO
+func ReelToReelResponse(reel *models.Reel) *ReelResponse {
+	if reel == nil {
+		return nil
+	}
+
+	resp := &ReelResponse{
+		ID:         reel.ID,
+		Caption:    reel.Caption,
+		Status:     reel.Status,
+		Visibility: reel.Visibility,
+		ViewCount:  reel.ViewCount,
+		ExpiresAt:  reel.ExpiresAt,
+		CreatedAt:  reel.CreatedAt,
+	}
+
+	if reel.Creator.ID != uuid.Nil {
+		resp.Creator = UserToUserResponse(&reel.Creator)
+	}
+
+	if reel.Media != nil {
+		resp.Video = &ReelVideoResponse{
+			MediaID:          reel.Media.ID,
+			HLSURL:           reel.Media.HLSURL,
+			Thumbnail:        reel.Media.Thumbnail,
+			Width:            reel.Media.Width,
+			Height:           reel.Media.Height,
+			Duration:         reel.Media.Duration,
+			EncodingStatus:   reel.Media.EncodingStatus,
+			EncodingProgress: reel.Media.EncodingProgress,
+		}
+	}
+
+	return resp
+}
+
+// ReelViewToReelViewerResponse converts ReelView model to ReelViewerResponse DTO
+func ReelViewToReelViewerResponse(view *models.ReelView) *ReelViewerResponse {
+	if view == nil {
+		return nil
+	}
+
+	return &ReelViewerResponse{
+		User:          UserToUserResponse(&view.Viewer),
+		WatchDuration: view.WatchDuration,
+		Completed:     view.Completed,
+		ViewedAt:      view.ViewedAt,
+	}
+}
+
+// ============================================================================
+// Ad mappers
+// ============================================================================
+
+// AdPlacementToAdPlacementResponse converts AdPlacement model to AdPlacementResponse DTO
+func AdPlacementToAdPlacementResponse(placement *models.AdPlacement) *AdPlacementResponse {
+	if placement == nil {
+		return nil
+	}
+
+	return &AdPlacementResponse{
+		ID:           placement.ID,
+		Code:         placement.Code,
+		Name:         placement.Name,
+		Description:  placement.Description,
+		PricePerDay:  placement.PricePerDay,
+		MaxActiveAds: placement.MaxActiveAds,
+		FeedInterval: placement.FeedInterval,
+		IsActive:     placement.IsActive,
+	}
+}
+
+// AdToAdResponse converts Ad model to AdResponse DTO
+func AdToAdResponse(ad *models.Ad) *AdResponse {
+	if ad == nil {
+		return nil
+	}
+
+	resp := &AdResponse{
+		ID:             ad.ID,
+		Placement:      AdPlacementToAdPlacementResponse(ad.Placement),
+		CampaignName:   ad.CampaignName,
+		Description:    ad.Description,
+		DestinationURL: ad.DestinationURL,
+		Media:          MediaToMediaResponse(ad.Media),
+		Status:         ad.Status,
+		Days:           ad.Days,
+		PricePerDay:    ad.PricePerDay,
+		Discount:       ad.Discount,
+		TotalCost:      ad.TotalCost,
+		StartsAt:       ad.StartsAt,
+		EndsAt:         ad.EndsAt,
+		Impressions:    ad.ImpressionCount,
+		Clicks:         ad.ClickCount,
+		RemovalReason:  ad.RemovalReason,
+		CreatedAt:      ad.CreatedAt,
+	}
+
+	if ad.Advertiser.ID != uuid.Nil {
+		resp.Advertiser = UserToUserResponse(&ad.Advertiser)
+	}
+
+	return resp
+}
+
+// AdPaymentToAdPaymentResponse converts AdPayment model to AdPaymentResponse DTO
+func AdPaymentToAdPaymentResponse(payment *models.AdPayment) *AdPaymentResponse {
+	if payment == nil {
+		return nil
+	}
+
+	resp := &AdPaymentResponse{
+		ID:              payment.ID,
+		AdID:            payment.AdID,
+		Ad:              AdToAdResponse(payment.Ad),
+		Amount:          payment.Amount,
+		Method:          payment.Method,
+		SlipMedia:       MediaToMediaResponse(payment.SlipMedia),
+		BankReference:   payment.BankReference,
+		TransferredAt:   payment.TransferredAt,
+		Status:          payment.Status,
+		ReviewedAt:      payment.ReviewedAt,
+		RejectionReason: payment.RejectionReason,
+		CreatedAt:       payment.CreatedAt,
+	}
+
+	if payment.Advertiser.ID != uuid.Nil {
+		resp.Advertiser = UserToUserResponse(&payment.Advertiser)
+	}
+
+	return resp
+}
+
+// AdToServedAdResponse converts Ad model to the public ServedAdResponse DTO
+func AdToServedAdResponse(ad *models.Ad, placement string) *ServedAdResponse {
+	if ad == nil {
+		return nil
+	}
+
+	resp := &ServedAdResponse{
+		ID:             ad.ID,
+		Placement:      placement,
+		CampaignName:   ad.CampaignName,
+		Description:    ad.Description,
+		DestinationURL: ad.DestinationURL,
+		AdvertiserName: ad.Advertiser.DisplayName,
+	}
+
+	if ad.Media != nil {
+		resp.ImageURL = ad.Media.URL
+		resp.Width = ad.Media.Width
+		resp.Height = ad.Media.Height
+	}
+
+	return resp
+}
+
+// ============================================================================
+// Payout mappers
+// ============================================================================
+
+// PayoutToPayoutResponse converts Payout model to PayoutResponse DTO
+func PayoutToPayoutResponse(payout *models.Payout) *PayoutResponse {
+	if payout == nil {
+		return nil
+	}
+
+	resp := &PayoutResponse{
+		ID:              payout.ID,
+		Amount:          payout.Amount,
+		Fee:             payout.Fee,
+		NetAmount:       payout.NetAmount,
+		DestinationType: payout.DestinationType,
+		BankCode:        payout.BankCode,
+		AccountNumber:   payout.AccountNumber,
+		AccountName:     payout.AccountName,
+		Status:          payout.Status,
+		ReviewedAt:      payout.ReviewedAt,
+		RejectionReason: payout.RejectionReason,
+		BankReference:   payout.BankReference,
+		PaidAt:          payout.PaidAt,
+		FailureReason:   payout.FailureReason,
+		CreatedAt:       payout.CreatedAt,
+	}
+
+	if payout.User.ID != uuid.Nil {
+		resp.User = UserToUserResponse(&payout.User)
+	}
+
+	return resp
+}
+
+// ============================================================================
+// Community mappers
+// ============================================================================
+
+// CommunityToCommunityResponse converts Community model to CommunityResponse DTO
+func CommunityToCommunityResponse(community *models.Community) *CommunityResponse {
+	if community == nil {
+		return nil
+	}
+
+	resp := &CommunityResponse{
+		ID:            community.ID,
+		Slug:          community.Slug,
+		Name:          community.Name,
+		Description:   community.Description,
+		Rules:         []CommunityRule{},
+		Visibility:    community.Visibility,
+		PostingPolicy: community.PostingPolicy,
+		MemberCount:   community.MemberCount,
+		PostCount:     community.PostCount,
+		CreatedAt:     community.CreatedAt,
+	}
+
+	if len(community.Rules) > 0 {
+		_ = json.Unmarshal(community.Rules, &resp.Rules)
+	}
+	if community.IconMedia != nil {
+		resp.IconURL = community.IconMedia.URL
+	}
+	if community.BannerMedia != nil {
+		resp.BannerURL = community.BannerMedia.URL
+	}
+	if community.Owner.ID != uuid.Nil {
+		resp.Owner = UserToUserResponse(&community.Owner)
+	}
+
+	return resp
+}
+
+// CommunityToCommunitySummaryResponse converts Community model to CommunitySummaryResponse DTO
+func CommunityToCommunitySummaryResponse(community *models.Community) *CommunitySummaryResponse {
+	if community == nil {
+		return nil
+	}
+
+	resp := &CommunitySummaryResponse{
+		ID:         community.ID,
+		Slug:       community.Slug,
+		Name:       community.Name,
+		Visibility: community.Visibility,
+	}
+	if community.IconMedia != nil {
+		resp.IconURL = community.IconMedia.URL
+	}
+
+	return resp
+}
+
+// CommunityMemberToCommunityMemberResponse converts CommunityMember model to CommunityMemberResponse DTO
+func CommunityMemberToCommunityMemberResponse(member *models.CommunityMember) *CommunityMemberResponse {
+	if member == nil {
+		return nil
+	}
+
+	return &CommunityMemberResponse{
+		User:     *UserToUserResponse(&member.User),
+		Role:     member.Role,
+		Status:   member.Status,
+		JoinedAt: member.JoinedAt,
+	}
+}
+
+// ============================================================================
+// Report mappers
+// ============================================================================
+
+// ReportToReportResponse converts Report model to ReportResponse DTO
+func ReportToReportResponse(report *models.Report) *ReportResponse {
+	if report == nil {
+		return nil
+	}
+
+	resp := &ReportResponse{
+		ID:             report.ID,
+		TargetType:     report.TargetType,
+		TargetID:       report.TargetID,
+		Snapshot:       report.Snapshot,
+		ReasonCode:     report.ReasonCode,
+		Details:        report.Details,
+		Status:         report.Status,
+		Action:         report.Action,
+		ResolvedAt:     report.ResolvedAt,
+		ResolutionNote: report.ResolutionNote,
+		CreatedAt:      report.CreatedAt,
+	}
+
+	if report.Reporter.ID != uuid.Nil {
+		resp.Reporter = UserToUserResponse(&report.Reporter)
+	}
+	if report.TargetUser.ID != uuid.Nil {
+		resp.TargetUser = UserToUserResponse(&report.TargetUser)
+	}
+
+	return resp
+}
+
+// ============================================================================
+// Session mappers
+// ============================================================================
+
+// SessionToSessionResponse converts Session model to SessionResponse DTO
+func SessionToSessionResponse(session *models.Session) *SessionResponse {
+	if session == nil {
+		return nil
+	}
+
+	return &SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+		CreatedAt:  session.CreatedAt,
+	}
+}
@@ -37,6 +37,11 @@ type MediaResponse struct {
 	SourceType *string    `json:"sourceType,omitempty"` // "post", "message", "reel", "comment"
 	SourceID   *uuid.UUID `json:"sourceId,omitempty"`   // ID of source entity
 	CreatedAt  time.Time  `json:"createdAt"`
+
+	// Bunny Stream videos only (reels)
+	HLSURL           string `json:"hlsUrl,omitempty"`
+	EncodingStatus   string `json:"encodingStatus,omitempty"` // processing, completed, failed
+	EncodingProgress int    `json:"encodingProgress,omitempty"`
 }
 
 // MediaListResponse - Response for listing media
@@ -11,7 +11,7 @@ type NotificationResponse struct {
 	ID        uuid.UUID    `json:"id"`
 	User      UserResponse `json:"user"`
 	Sender    UserResponse `json:"sender"`
-	Type      string       `json:"type"` // "reply", "vote", "mention", "follow"
+	Type      string       `json:"type"` // "reply", "vote", "mention", "follow", "follow_request", "follow_accepted"
 	Message   string       `json:"message"`
 	PostID    *uuid.UUID   `json:"postId,omitempty"`
 	CommentID *uuid.UUID   `json:"commentId,omitempty"`
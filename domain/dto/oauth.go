@@ -1,29 +1,26 @@
 package dto
 
+import (
+	"time"
+
+	"gofiber-template/domain/models"
+)
+
 // GoogleOAuthRequest - Request for Google OAuth callback
 type GoogleOAuthRequest struct {
 	Code  string `json:"code" validate:"required"`
 	State string `json:"state"`
 }
 
-// OAuthUserInfo - User information from OAuth provider
-type OAuthUserInfo struct {
-	Provider   string `json:"provider"`
-	OAuthID    string `json:"oauthId"`
-	Email      string `json:"email"`
-	Name       string `json:"name"`
-	Picture    string `json:"picture"`
-	GivenName  string `json:"givenName"`
-	FamilyName string `json:"familyName"`
-	Verified   bool   `json:"verified"`
-}
-
 // OAuthLoginResponse - Response after successful OAuth login
 type OAuthLoginResponse struct {
-	Token        string       `json:"token"`
+	AuthTokens
 	User         UserResponse `json:"user"`
 	IsNewUser    bool         `json:"isNewUser"`
 	NeedsProfile bool         `json:"needsProfile"` // True if user needs to complete profile (e.g., choose username)
+
+	// Set instead of tokens when the account has two-factor enabled
+	MFA *MFAChallengeResponse `json:"mfa,omitempty"`
 }
 
 // OAuthURLResponse - Response containing OAuth authorization URL
@@ -39,7 +36,45 @@ type ExchangeCodeRequest struct {
 
 // ExchangeCodeResponse - Response after exchanging code for token
 type ExchangeCodeResponse struct {
-	Token     string       `json:"token"`
+	AuthTokens
 	User      UserResponse `json:"user"`
 	IsNewUser bool         `json:"isNewUser"`
 }
+
+// OAuthProviderResponse - A login provider enabled on this server
+type OAuthProviderResponse struct {
+	Name        string `json:"name"`        // used in /auth/oauth/:provider
+	DisplayName string `json:"displayName"` // login button label
+}
+
+// UserIdentityResponse - An external login linked to the current user
+type UserIdentityResponse struct {
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
+}
+
+// UserIdentityListResponse - Linked logins, plus whether a password can be used instead
+type UserIdentityListResponse struct {
+	Identities  []UserIdentityResponse `json:"identities"`
+	HasPassword bool                   `json:"hasPassword"`
+}
+
+// OAuthCallbackResult - Outcome of a provider callback: a login, or an identity linked to a signed-in user
+type OAuthCallbackResult struct {
+	Login  *OAuthLoginResponse
+	Linked *UserIdentityResponse
+}
+
+func UserIdentityToResponse(identity *models.UserIdentity) *UserIdentityResponse {
+	if identity == nil {
+		return nil
+	}
+	return &UserIdentityResponse{
+		Provider:    identity.Provider,
+		Email:       identity.Email,
+		CreatedAt:   identity.CreatedAt,
+		LastLoginAt: identity.LastLoginAt,
+	}
+}
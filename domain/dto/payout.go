@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Payout requests
+// All amounts are in satang (1 THB = 100 satang)
+// ============================================================================
+
+// RequestPayoutRequest - Withdraw earned balance (min 500 THB)
+type RequestPayoutRequest struct {
+	Amount          int64  `json:"amount" validate:"required,min=50000"`
+	DestinationType string `json:"destinationType" validate:"required,oneof=bank_account promptpay"`
+	BankCode        string `json:"bankCode" validate:"required_if=DestinationType bank_account,omitempty,oneof=BBL KBANK KTB SCB BAY TTB GSB BAAC GHB CIMBT UOBT LHBANK KKP TISCO ICBCT"`
+	AccountNumber   string `json:"accountNumber" validate:"required,max=30"`
+	AccountName     string `json:"accountName" validate:"required,min=2,max=100"`
+}
+
+// RejectPayoutRequest - Admin rejects a pending payout
+type RejectPayoutRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+}
+
+// FailPayoutRequest - Admin reports a bank transfer that bounced
+type FailPayoutRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+}
+
+// MarkPayoutsPaidRequest - Admin confirms a bank batch transfer
+type MarkPayoutsPaidRequest struct {
+	PayoutIDs     []uuid.UUID `json:"payoutIds" validate:"required,min=1,max=500"`
+	BankReference string      `json:"bankReference" validate:"required,max=100"`
+}
+
+// ============================================================================
+// Payout responses
+// ============================================================================
+
+// PayoutSummaryResponse - What the current user can withdraw
+type PayoutSummaryResponse struct {
+	AvailableBalance   int64      `json:"availableBalance"`   // satang
+	WithdrawableAmount int64      `json:"withdrawableAmount"` // satang, earnings only (top-ups can't be withdrawn)
+	MinAmount          int64      `json:"minAmount"`          // satang
+	FeePercent         int        `json:"feePercent"`
+	PendingPayoutID    *uuid.UUID `json:"pendingPayoutId,omitempty"`
+}
+
+// PayoutResponse - Payout request
+type PayoutResponse struct {
+	ID              uuid.UUID     `json:"id"`
+	User            *UserResponse `json:"user,omitempty"` // admin queue
+	Amount          int64         `json:"amount"`
+	Fee             int64         `json:"fee"`
+	NetAmount       int64         `json:"netAmount"`
+	DestinationType string        `json:"destinationType"`
+	BankCode        *string       `json:"bankCode,omitempty"`
+	AccountNumber   string        `json:"accountNumber"`
+	AccountName     string        `json:"accountName"`
+	Status          string        `json:"status"`
+	ReviewedAt      *time.Time    `json:"reviewedAt,omitempty"`
+	RejectionReason *string       `json:"rejectionReason,omitempty"`
+	BankReference   *string       `json:"bankReference,omitempty"`
+	PaidAt          *time.Time    `json:"paidAt,omitempty"`
+	FailureReason   *string       `json:"failureReason,omitempty"`
+	CreatedAt       time.Time     `json:"createdAt"`
+}
+
+// PayoutListResponse - Payouts with pagination
+type PayoutListResponse struct {
+	Payouts []PayoutResponse `json:"payouts"`
+	Meta    PaginationMeta   `json:"meta"`
+}
+
+// MarkPayoutsPaidResponse - Result of a bank batch confirmation
+type MarkPayoutsPaidResponse struct {
+	Paid    int         `json:"paid"`
+	Skipped []uuid.UUID `json:"skipped,omitempty"` // not approved (already paid, failed, ...)
+}
@@ -16,6 +16,14 @@ type CreatePostRequest struct {
 	Tags           []string    `json:"tags" validate:"omitempty,max=5,dive,min=1,max=50"`
 	SourcePostID   *uuid.UUID  `json:"sourcePostId" validate:"omitempty,uuid"` // For crossposting
 	IsDraft        bool        `json:"isDraft"`                                // true = save as draft (for video encoding)
+	CommunityID    *uuid.UUID  `json:"communityId" validate:"omitempty"`       // Post into a community (must be an active member)
+
+	// Premium unlock (ค่าเสือก) - content stays hidden until contributions reach the target
+	UnlockTargetAmount *int64     `json:"unlockTargetAmount" validate:"omitempty,min=1000,max=10000000"` // satang (10 - 100,000 THB)
+	UnlockDeadline     *time.Time `json:"unlockDeadline" validate:"omitempty"`                           // refund contributors if not reached
+
+	// Subscriber-only content - nil = public
+	MinTierLevel *int `json:"minTierLevel" validate:"omitempty,min=1,max=5"` // minimum subscription tier level of the author
 }
 
 // UpdatePostRequest - Request for updating a post
@@ -62,6 +70,15 @@ type PostResponse struct {
 	CreatedAt    time.Time       `json:"createdAt"`
 	UpdatedAt    time.Time       `json:"updatedAt"`
 
+	// Community (nil for personal posts)
+	Community *CommunitySummaryResponse `json:"community,omitempty"`
+
+	// Premium unlock (only set for premium unlock posts)
+	Unlock *PostUnlockInfo `json:"unlock,omitempty"`
+
+	// Subscriber-only access (nil for public posts)
+	Subscription *PostSubscriptionInfo `json:"subscription,omitempty"`
+
 	// User-specific fields (when authenticated)
 	UserVote *string  `json:"userVote,omitempty"` // "up", "down", or null
 	IsSaved  *bool    `json:"isSaved,omitempty"`  // true/false
@@ -79,6 +96,9 @@ type PostListResponse struct {
 type PostListCursorResponse struct {
 	Posts []PostResponse       `json:"posts"`
 	Meta  CursorPaginationMeta `json:"meta"`
+
+	// Sponsored items interleaved by the client (feed ad placement)
+	Ads []FeedAdSlot `json:"ads,omitempty"`
 }
 
 // PostFeedResponse - Response for feed with mixed content types (offset-based, deprecated)
@@ -92,6 +112,9 @@ type PostFeedResponse struct {
 type PostFeedCursorResponse struct {
 	Posts []PostResponse       `json:"posts"`
 	Meta  CursorPaginationMeta `json:"meta"`
+
+	// Sponsored items interleaved by the client (feed ad placement)
+	Ads []FeedAdSlot `json:"ads,omitempty"`
 }
 
 // PostSummaryResponse - Lightweight post info for nested responses (comments, etc.)
@@ -101,3 +124,9 @@ type PostSummaryResponse struct {
 	Author    UserResponse `json:"author"`
 	CreatedAt time.Time    `json:"createdAt"`
 }
+
+// PostViewerAccess - Gated content the viewer is entitled to see (used by post mappers)
+type PostViewerAccess struct {
+	Premium    bool // premium unlock content (author / contributors after unlock)
+	Subscriber bool // subscriber-only content (author / subscribers of the required tier)
+}
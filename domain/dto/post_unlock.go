@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Premium unlock (ค่าเสือก) - crowdfunded posts
+// All amounts are in satang (1 THB = 100 satang)
+// ============================================================================
+
+// ContributeUnlockRequest - Chip in towards unlocking a premium post
+type ContributeUnlockRequest struct {
+	Amount         int64  `json:"amount" validate:"required,min=500,max=100000"`    // 5 - 1,000 THB
+	IdempotencyKey string `json:"idempotencyKey" validate:"required,min=1,max=255"` // Client-generated, safe to retry
+}
+
+// PostUnlockInfo - Unlock progress embedded in PostResponse
+type PostUnlockInfo struct {
+	Status           string     `json:"status"` // locked, unlocked, failed
+	IsLocked         bool       `json:"isLocked"`
+	TargetAmount     int64      `json:"targetAmount"`
+	CurrentAmount    int64      `json:"currentAmount"`
+	ContributorCount int        `json:"contributorCount"`
+	Progress         int        `json:"progress"` // 0-100
+	Deadline         *time.Time `json:"deadline,omitempty"`
+	UnlockedAt       *time.Time `json:"unlockedAt,omitempty"`
+	BlurredThumbnail string     `json:"blurredThumbnail,omitempty"`
+	MediaCount       int        `json:"mediaCount"`
+}
+
+// PostUnlockStatusResponse - Unlock progress plus the current user's contribution state
+type PostUnlockStatusResponse struct {
+	PostID uuid.UUID       `json:"postId"`
+	Unlock *PostUnlockInfo `json:"unlock"`
+
+	// User-specific fields (when authenticated)
+	MyContribution      *int64 `json:"myContribution,omitempty"`      // satang
+	MyContributionCount *int   `json:"myContributionCount,omitempty"` // max 3 per post
+	CanContribute       bool   `json:"canContribute"`
+}
+
+// ContributeUnlockResponse - Result of a contribution
+type ContributeUnlockResponse struct {
+	ContributionID uuid.UUID       `json:"contributionId"`
+	Amount         int64           `json:"amount"`
+	Unlock         *PostUnlockInfo `json:"unlock"`
+	JustUnlocked   bool            `json:"justUnlocked"` // this contribution reached the target
+}
+
+// UnlockContributorResponse - One contributor of a premium post
+type UnlockContributorResponse struct {
+	User              UserResponse `json:"user"`
+	TotalAmount       int64        `json:"totalAmount"`
+	ContributionCount int          `json:"contributionCount"`
+	FirstContributed  time.Time    `json:"firstContributed"`
+}
+
+// UnlockContributorListResponse - Contributors with pagination
+type UnlockContributorListResponse struct {
+	Contributors []UnlockContributorResponse `json:"contributors"`
+	Meta         PaginationMeta              `json:"meta"`
+}
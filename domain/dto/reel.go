@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateReelRequest - Reel settings (multipart form, video is sent as "video")
+type CreateReelRequest struct {
+	Caption    string `form:"caption" validate:"omitempty,max=150"`
+	Visibility string `form:"visibility" validate:"omitempty,oneof=public followers"` // default public
+}
+
+// RecordReelViewRequest - Sent by the player when a viewer watches a reel
+type RecordReelViewRequest struct {
+	WatchDuration float64 `json:"watchDuration" validate:"omitempty,min=0"` // seconds
+	Completed     bool    `json:"completed"`
+}
+
+// ReelVideoResponse - Streaming info of a reel video
+type ReelVideoResponse struct {
+	MediaID          uuid.UUID `json:"mediaId"`
+	HLSURL           string    `json:"hlsUrl"`
+	Thumbnail        string    `json:"thumbnail,omitempty"`
+	Width            int       `json:"width,omitempty"`
+	Height           int       `json:"height,omitempty"`
+	Duration         float64   `json:"duration,omitempty"`
+	EncodingStatus   string    `json:"encodingStatus"` // processing, completed, failed
+	EncodingProgress int       `json:"encodingProgress"`
+}
+
+// ReelResponse - A single reel
+type ReelResponse struct {
+	ID         uuid.UUID          `json:"id"`
+	Creator    *UserResponse      `json:"creator,omitempty"`
+	Video      *ReelVideoResponse `json:"video,omitempty"`
+	Caption    string             `json:"caption"`
+	Status     string             `json:"status"` // processing, active, failed, expired, deleted
+	Visibility string             `json:"visibility"`
+	ViewCount  int                `json:"viewCount"`
+	ExpiresAt  time.Time          `json:"expiresAt"`
+	CreatedAt  time.Time          `json:"createdAt"`
+
+	// User-specific fields (when authenticated)
+	HasViewed *bool `json:"hasViewed,omitempty"`
+}
+
+// ReelListResponse - Reels of one creator (oldest first, like a story)
+type ReelListResponse struct {
+	Reels []ReelResponse `json:"reels"`
+}
+
+// ReelCreatorGroup - Story ring of one followed creator
+type ReelCreatorGroup struct {
+	Creator     *UserResponse  `json:"creator"`
+	Reels       []ReelResponse `json:"reels"`       // oldest first
+	HasUnviewed bool           `json:"hasUnviewed"` // ring highlighted
+	LatestAt    time.Time      `json:"latestAt"`
+}
+
+// ReelFeedResponse - Reels from followed users grouped by creator (unviewed rings first)
+type ReelFeedResponse struct {
+	Creators []ReelCreatorGroup `json:"creators"`
+}
+
+// ReelViewerResponse - A viewer of a reel (shown to the creator)
+type ReelViewerResponse struct {
+	User          *UserResponse `json:"user"`
+	WatchDuration float64       `json:"watchDuration"`
+	Completed     bool          `json:"completed"`
+	ViewedAt      time.Time     `json:"viewedAt"`
+}
+
+// ReelViewerListResponse - Viewers of a reel with pagination
+type ReelViewerListResponse struct {
+	Viewers []ReelViewerResponse `json:"viewers"`
+	Meta    PaginationMeta       `json:"meta"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Report requests
+// ============================================================================
+
+// CreateReportRequest - Flag a post, comment, message or user for moderator review
+type CreateReportRequest struct {
+	TargetType string    `json:"targetType" validate:"required,oneof=post comment message user"`
+	TargetID   uuid.UUID `json:"targetId" validate:"required"`
+	ReasonCode string    `json:"reasonCode" validate:"required,oneof=spam harassment hate_speech violence sexual_content misinformation self_harm illegal impersonation other"`
+	Details    string    `json:"details" validate:"omitempty,max=1000"`
+}
+
+// ResolveReportRequest - Moderator decision on a report (applies to every open report on the same target)
+type ResolveReportRequest struct {
+	Action        string `json:"action" validate:"required,oneof=dismiss remove_content warn suspend"`
+	Note          string `json:"note" validate:"omitempty,max=500"`                 // shown to the reported user on remove/warn/suspend
+	DurationHours int    `json:"durationHours" validate:"omitempty,min=1,max=8760"` // suspend only (default 7 days)
+}
+
+// ============================================================================
+// Report responses
+// ============================================================================
+
+// ReportResponse - Report with its resolution
+type ReportResponse struct {
+	ID             uuid.UUID     `json:"id"`
+	Reporter       *UserResponse `json:"reporter,omitempty"` // moderation queue
+	TargetType     string        `json:"targetType"`
+	TargetID       uuid.UUID     `json:"targetId"`
+	TargetUser     *UserResponse `json:"targetUser,omitempty"`
+	Snapshot       string        `json:"snapshot,omitempty"` // moderation queue
+	ReasonCode     string        `json:"reasonCode"`
+	Details        string        `json:"details,omitempty"`
+	Status         string        `json:"status"`
+	Action         *string       `json:"action,omitempty"`
+	ResolvedAt     *time.Time    `json:"resolvedAt,omitempty"`
+	ResolutionNote *string       `json:"resolutionNote,omitempty"`
+	OpenReports    int64         `json:"openReports,omitempty"` // moderation queue: open reports on the same target
+	CreatedAt      time.Time     `json:"createdAt"`
+}
+
+// ReportListResponse - Reports with pagination
+type ReportListResponse struct {
+	Reports []ReportResponse `json:"reports"`
+	Meta    PaginationMeta   `json:"meta"`
+}
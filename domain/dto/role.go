@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// PermissionResponse - A permission that roles can grant
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// RoleResponse - A role and the permissions it grants
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Scope       string   `json:"scope"` // global, community
+	Permissions []string `json:"permissions"`
+}
+
+// UserRoleResponse - A role granted to a user
+type UserRoleResponse struct {
+	Role        string     `json:"role"`
+	Scope       string     `json:"scope"`
+	CommunityID *uuid.UUID `json:"communityId,omitempty"`
+	GrantedBy   *uuid.UUID `json:"grantedBy,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// UserPermissionsResponse - Roles and effective permissions of the current user
+type UserPermissionsResponse struct {
+	Roles                []UserRoleResponse  `json:"roles"`
+	Permissions          []string            `json:"permissions"`                    // site-wide
+	CommunityPermissions map[string][]string `json:"communityPermissions,omitempty"` // community ID -> permissions
+}
+
+// AssignRoleRequest - Grant a role (communityId is required for community roles)
+type AssignRoleRequest struct {
+	Role        string     `json:"role" validate:"required,max=50"`
+	CommunityID *uuid.UUID `json:"communityId"`
+}
+
+func RoleToResponse(role *models.Role) *RoleResponse {
+	if role == nil {
+		return nil
+	}
+	return &RoleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		Scope:       role.Scope,
+		Permissions: role.PermissionNames(),
+	}
+}
+
+func UserRoleToResponse(userRole *models.UserRole) *UserRoleResponse {
+	if userRole == nil {
+		return nil
+	}
+	return &UserRoleResponse{
+		Role:        userRole.Role.Name,
+		Scope:       userRole.Role.Scope,
+		CommunityID: userRole.CommunityID,
+		GrantedBy:   userRole.GrantedBy,
+		CreatedAt:   userRole.CreatedAt,
+	}
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// ============================================================================
+// Sanction requests
+// ============================================================================
+
+// ApplySanctionRequest - Suspend (durationHours required), ban or shadowban a user
+type ApplySanctionRequest struct {
+	Type          string `json:"type" validate:"required,oneof=suspension ban shadowban"`
+	Reason        string `json:"reason" validate:"required,min=3,max=500"` // shown to the user on suspensions and bans
+	DurationHours int    `json:"durationHours" validate:"omitempty,min=1,max=8760"`
+}
+
+// LiftSanctionRequest - End a sanction early
+type LiftSanctionRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+}
+
+// ============================================================================
+// Sanction responses
+// ============================================================================
+
+// UserSanctionResponse - A sanction in the user's moderation history
+type UserSanctionResponse struct {
+	ID         uuid.UUID     `json:"id"`
+	UserID     uuid.UUID     `json:"userId"`
+	Type       string        `json:"type"`
+	Reason     string        `json:"reason"`
+	ExpiresAt  *time.Time    `json:"expiresAt,omitempty"`
+	IssuedBy   *UserResponse `json:"issuedBy,omitempty"`
+	IsActive   bool          `json:"isActive"`
+	LiftedAt   *time.Time    `json:"liftedAt,omitempty"`
+	LiftedByID *uuid.UUID    `json:"liftedById,omitempty"`
+	LiftReason *string       `json:"liftReason,omitempty"`
+	CreatedAt  time.Time     `json:"createdAt"`
+}
+
+// UserSanctionListResponse - A user's current moderation state and sanction history
+type UserSanctionListResponse struct {
+	SuspendedUntil *time.Time             `json:"suspendedUntil,omitempty"`
+	IsBanned       bool                   `json:"isBanned"`
+	IsShadowbanned bool                   `json:"isShadowbanned"`
+	Sanctions      []UserSanctionResponse `json:"sanctions"`
+	Meta           PaginationMeta         `json:"meta"`
+}
+
+func UserSanctionToResponse(sanction *models.UserSanction) *UserSanctionResponse {
+	if sanction == nil {
+		return nil
+	}
+	resp := &UserSanctionResponse{
+		ID:         sanction.ID,
+		UserID:     sanction.UserID,
+		Type:       sanction.Type,
+		Reason:     sanction.Reason,
+		ExpiresAt:  sanction.ExpiresAt,
+		IsActive:   sanction.IsActive(time.Now()),
+		LiftedAt:   sanction.LiftedAt,
+		LiftedByID: sanction.LiftedByID,
+		LiftReason: sanction.LiftReason,
+		CreatedAt:  sanction.CreatedAt,
+	}
+	if sanction.IssuedBy.ID != uuid.Nil {
+		resp.IssuedBy = UserToUserResponse(&sanction.IssuedBy)
+	}
+	return resp
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionClient - Device details recorded when a session signs in or refreshes
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// SessionResponse - Signed-in device
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Current    bool      `json:"current"` // session of the calling access token
+}
+
+// SessionListResponse - Active sessions of the current user
+type SessionListResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
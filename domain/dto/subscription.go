@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateSubscriptionTierRequest - Creator defines a paid monthly tier
+type CreateSubscriptionTierRequest struct {
+	Name         string   `json:"name" validate:"required,min=1,max=100"`
+	Description  string   `json:"description" validate:"omitempty,max=1000"`
+	TierLevel    int      `json:"tierLevel" validate:"required,min=1,max=5"`               // higher tiers include lower tier content
+	PriceMonthly int64    `json:"priceMonthly" validate:"required,min=2000,max=10000000"`  // satang (20 - 100,000 THB)
+	PriceYearly  *int64   `json:"priceYearly" validate:"omitempty,min=2000,max=100000000"` // satang, default 12x monthly
+	Perks        []string `json:"perks" validate:"omitempty,max=10,dive,min=1,max=100"`
+	TrialDays    int      `json:"trialDays" validate:"omitempty,min=0,max=30"`
+}
+
+// UpdateSubscriptionTierRequest - Price changes only apply to new subscribers (grandfather pricing)
+type UpdateSubscriptionTierRequest struct {
+	Name         *string  `json:"name" validate:"omitempty,min=1,max=100"`
+	Description  *string  `json:"description" validate:"omitempty,max=1000"`
+	PriceMonthly *int64   `json:"priceMonthly" validate:"omitempty,min=2000,max=10000000"`
+	PriceYearly  *int64   `json:"priceYearly" validate:"omitempty,min=2000,max=100000000"`
+	Perks        []string `json:"perks" validate:"omitempty,max=10,dive,min=1,max=100"`
+	TrialDays    *int     `json:"trialDays" validate:"omitempty,min=0,max=30"`
+	IsActive     *bool    `json:"isActive"`
+}
+
+// SubscriptionTierResponse - A creator's subscription tier
+type SubscriptionTierResponse struct {
+	ID           uuid.UUID `json:"id"`
+	CreatorID    uuid.UUID `json:"creatorId"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	TierLevel    int       `json:"tierLevel"`
+	PriceMonthly int64     `json:"priceMonthly"` // satang
+	PriceYearly  int64     `json:"priceYearly"`  // satang
+	Perks        []string  `json:"perks"`
+	TrialDays    int       `json:"trialDays"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// SubscriptionTierListResponse - Tiers of a creator (lowest level first)
+type SubscriptionTierListResponse struct {
+	Tiers []SubscriptionTierResponse `json:"tiers"`
+
+	// User-specific fields (when authenticated)
+	MySubscription *SubscriptionResponse `json:"mySubscription,omitempty"`
+}
+
+// SubscribeRequest - Subscribe to a creator's tier (paid from the wallet)
+type SubscribeRequest struct {
+	TierID         uuid.UUID `json:"tierId" validate:"required"`
+	BillingCycle   string    `json:"billingCycle" validate:"omitempty,oneof=monthly yearly"` // default monthly
+	IdempotencyKey string    `json:"idempotencyKey" validate:"required,max=255"`
+}
+
+// ChangeSubscriptionTierRequest - Upgrade (prorated, immediate) or downgrade (next period)
+type ChangeSubscriptionTierRequest struct {
+	TierID uuid.UUID `json:"tierId" validate:"required"`
+}
+
+// CancelSubscriptionRequest - Cancel at period end (optional feedback for the creator)
+type CancelSubscriptionRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
+}
+
+// SubscriptionResponse - A subscription as seen by the subscriber or the creator
+type SubscriptionResponse struct {
+	ID                 uuid.UUID                 `json:"id"`
+	Creator            *UserResponse             `json:"creator,omitempty"`
+	Subscriber         *UserResponse             `json:"subscriber,omitempty"`
+	Tier               *SubscriptionTierResponse `json:"tier,omitempty"`
+	PendingTierID      *uuid.UUID                `json:"pendingTierId,omitempty"` // scheduled downgrade
+	Status             string                    `json:"status"`                  // trial, active, cancelled, payment_failed, expired
+	BillingCycle       string                    `json:"billingCycle"`
+	Price              int64                     `json:"price"` // satang per billing cycle (locked in)
+	CurrentPeriodStart time.Time                 `json:"currentPeriodStart"`
+	CurrentPeriodEnd   time.Time                 `json:"currentPeriodEnd"`
+	TrialEnd           *time.Time                `json:"trialEnd,omitempty"`
+	CancelAtPeriodEnd  bool                      `json:"cancelAtPeriodEnd"`
+	CancelledAt        *time.Time                `json:"cancelledAt,omitempty"`
+	GraceUntil         *time.Time                `json:"graceUntil,omitempty"`
+	HasAccess          bool                      `json:"hasAccess"`
+	CreatedAt          time.Time                 `json:"createdAt"`
+}
+
+// SubscriptionListResponse - Subscriptions with pagination
+type SubscriptionListResponse struct {
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+	Meta          PaginationMeta         `json:"meta"`
+}
+
+// SubscriptionTierStatsResponse - Revenue breakdown of one tier
+type SubscriptionTierStatsResponse struct {
+	TierID         uuid.UUID `json:"tierId"`
+	Name           string    `json:"name"`
+	TierLevel      int       `json:"tierLevel"`
+	Subscribers    int64     `json:"subscribers"`    // paying subscribers
+	MonthlyRevenue int64     `json:"monthlyRevenue"` // satang
+}
+
+// CreatorSubscriptionStatsResponse - Creator dashboard overview
+type CreatorSubscriptionStatsResponse struct {
+	ActiveSubscribers       int64                           `json:"activeSubscribers"`       // including trials
+	MonthlyRecurringRevenue int64                           `json:"monthlyRecurringRevenue"` // satang
+	PlatformFee             int64                           `json:"platformFee"`             // satang
+	NetMonthlyRevenue       int64                           `json:"netMonthlyRevenue"`       // satang
+	Tiers                   []SubscriptionTierStatsResponse `json:"tiers"`
+}
+
+// PostSubscriptionInfo - Subscriber-only access of a post
+type PostSubscriptionInfo struct {
+	MinTierLevel int  `json:"minTierLevel"`
+	MediaCount   int  `json:"mediaCount"` // shown on the teaser while locked
+	IsLocked     bool `json:"isLocked"`   // viewer cannot see content/media
+}
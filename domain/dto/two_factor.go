@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// TwoFactorCodeRequest - Authenticator code (6 digits) or a recovery code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required,min=6,max=20"`
+}
+
+// TwoFactorSetupResponse - Pending secret to add to an authenticator app
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`     // base32, for manual entry
+	OTPAuthURI string `json:"otpauthUri"` // otpauth://totp/...
+	QRCodePNG  string `json:"qrCodePng"`  // data:image/png;base64,...
+}
+
+// TwoFactorStatusResponse - Two-factor state of the current user
+type TwoFactorStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabledAt,omitempty"`
+	RecoveryCodesRemaining int64      `json:"recoveryCodesRemaining"`
+}
+
+// RecoveryCodesResponse - Recovery codes, shown once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// MFAChallengeResponse - Returned by login instead of tokens when two-factor is enabled
+type MFAChallengeResponse struct {
+	MFARequired    bool      `json:"mfaRequired"`
+	ChallengeToken string    `json:"challengeToken"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
+// MFAVerifyRequest - Exchanges a login challenge and a code for tokens
+type MFAVerifyRequest struct {
+	ChallengeToken string `json:"challengeToken" validate:"required"`
+	Code           string `json:"code" validate:"required,min=6,max=20"`
+}
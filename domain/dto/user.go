@@ -14,11 +14,13 @@ type CreateUserRequest struct {
 }
 
 type UpdateUserRequest struct {
-	DisplayName string `json:"displayName" validate:"omitempty,min=1,max=100"`
-	Bio         string `json:"bio" validate:"omitempty,max=500"`
-	Location    string `json:"location" validate:"omitempty,max=100"`
-	Website     string `json:"website" validate:"omitempty,url,max=255"`
-	Avatar      string `json:"avatar" validate:"omitempty,max=500"`
+	DisplayName string  `json:"displayName" validate:"omitempty,min=1,max=100"`
+	Bio         string  `json:"bio" validate:"omitempty,max=500"`
+	Location    string  `json:"location" validate:"omitempty,max=100"`
+	Website     string  `json:"website" validate:"omitempty,url,max=255"`
+	Avatar      string  `json:"avatar" validate:"omitempty,max=500"`
+	IsPrivate   *bool   `json:"isPrivate"`                                                        // followers need approval
+	ChatPrivacy *string `json:"chatPrivacy" validate:"omitempty,oneof=everyone followers nobody"` // who may start a chat
 }
 
 type UserResponse struct {
@@ -33,11 +35,14 @@ type UserResponse struct {
 	Karma          int       `json:"karma"`
 	FollowersCount int       `json:"followersCount"`
 	FollowingCount int       `json:"followingCount"`
-	Role           string    `json:"role,omitempty"`
 	IsActive       bool      `json:"isActive"`
+	IsPrivate      bool      `json:"isPrivate"`
+	ChatPrivacy    string    `json:"chatPrivacy"`
+	EmailVerified  bool      `json:"emailVerified"`
 	CreatedAt      time.Time `json:"createdAt"`
 	UpdatedAt      time.Time `json:"updatedAt"`
 	IsFollowing    *bool     `json:"isFollowing,omitempty"` // Only when authenticated
+	IsRequested    *bool     `json:"isRequested,omitempty"` // Only for private accounts the viewer does not follow
 }
 
 type UserListResponse struct {
@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Internal wallet requests (used by other services, not bound to HTTP)
+// All amounts are in satang (1 THB = 100 satang)
+// ============================================================================
+
+// LedgerPosting - One leg of a ledger transaction (negative = debit, positive = credit)
+type LedgerPosting struct {
+	WalletID uuid.UUID
+	Amount   int64
+}
+
+// LedgerTransactionRequest - Balanced multi-leg posting (sum of amounts must be zero)
+type LedgerTransactionRequest struct {
+	IdempotencyKey string // Same key = same transaction, never applied twice
+	Type           string // transfer, topup, payout, hold_capture, refund
+	Description    string
+	ReferenceType  *string
+	ReferenceID    *uuid.UUID
+	Postings       []LedgerPosting
+}
+
+// WalletTransferRequest - Move money from one wallet to another
+type WalletTransferRequest struct {
+	IdempotencyKey string
+	FromWalletID   uuid.UUID
+	ToWalletID     uuid.UUID
+	Amount         int64 // Must be positive
+	Type           string
+	Description    string
+	ReferenceType  *string
+	ReferenceID    *uuid.UUID
+}
+
+// WalletHoldRequest - Reserve part of a wallet balance
+type WalletHoldRequest struct {
+	IdempotencyKey string
+	WalletID       uuid.UUID
+	Amount         int64 // Must be positive
+	Reason         string
+	ReferenceType  *string
+	ReferenceID    *uuid.UUID
+	ExpiresAt      *time.Time // Released automatically after this time
+}
+
+// ============================================================================
+// Wallet responses
+// ============================================================================
+
+// WalletResponse - Current user's wallet balance
+type WalletResponse struct {
+	ID               uuid.UUID `json:"id"`
+	Currency         string    `json:"currency"`
+	Balance          int64     `json:"balance"`          // satang
+	HeldAmount       int64     `json:"heldAmount"`       // satang
+	AvailableBalance int64     `json:"availableBalance"` // satang
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// LedgerEntryResponse - Single ledger entry in wallet history
+type LedgerEntryResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	TransactionID uuid.UUID  `json:"transactionId"`
+	Type          string     `json:"type"`
+	Description   string     `json:"description,omitempty"`
+	Amount        int64      `json:"amount"`       // satang, negative = debit
+	BalanceAfter  int64      `json:"balanceAfter"` // satang
+	ReferenceType *string    `json:"referenceType,omitempty"`
+	ReferenceID   *uuid.UUID `json:"referenceId,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// LedgerEntryListResponse - Wallet history with pagination
+type LedgerEntryListResponse struct {
+	Entries []LedgerEntryResponse `json:"entries"`
+	Meta    PaginationMeta        `json:"meta"`
+}
+
+// ============================================================================
+// Top-up (payment gateway)
+// ============================================================================
+
+// CreateTopUpRequest - Start a top-up, amount in satang (20 - 50,000 THB)
+type CreateTopUpRequest struct {
+	Amount int64 `json:"amount" validate:"required,min=2000,max=5000000"`
+}
+
+// TopUpIntentResponse - Top-up intent with the PromptPay QR payload to render
+type TopUpIntentResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Amount     int64      `json:"amount"` // satang
+	Currency   string     `json:"currency"`
+	Provider   string     `json:"provider"`
+	QRPayload  string     `json:"qrPayload,omitempty"` // only while pending
+	Status     string     `json:"status"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	PaidAt     *time.Time `json:"paidAt,omitempty"`
+	CreditedAt *time.Time `json:"creditedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// TopUpIntentListResponse - Top-up history with pagination
+type TopUpIntentListResponse struct {
+	TopUps []TopUpIntentResponse `json:"topUps"`
+	Meta   PaginationMeta        `json:"meta"`
+}
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Ad placement codes (seeded by migration 028)
+const (
+	AdPlacementFeed       = "feed"        // between posts in feeds
+	AdPlacementSidebar    = "sidebar"     // right sidebar
+	AdPlacementPostDetail = "post_detail" // below the post on the post page
+)
+
+// Ad statuses
+const (
+	AdStatusPendingPayment = "pending_payment" // created, waiting for the bank slip
+	AdStatusPaymentReview  = "payment_review"  // slip submitted, waiting for verification
+	AdStatusScheduled      = "scheduled"       // paid, goes live at StartsAt
+	AdStatusActive         = "active"          // served until EndsAt
+	AdStatusPaused         = "paused"          // paused by the advertiser (the end date does not move)
+	AdStatusCompleted      = "completed"       // past EndsAt
+	AdStatusCancelled      = "cancelled"       // cancelled by the advertiser before payment
+	AdStatusRemoved        = "removed"         // taken down by an admin
+)
+
+// Ad payment statuses
+const (
+	AdPaymentPending  = "pending"  // slip waiting for verification
+	AdPaymentVerified = "verified" // money received, ad scheduled
+	AdPaymentRejected = "rejected" // slip invalid, advertiser may submit a new one
+)
+
+// AdPaymentMethodBankTransfer - Bank transfer with an uploaded slip
+const AdPaymentMethodBankTransfer = "bank_transfer"
+
+// AdPlacement - Where ads are shown, with price and capacity
+type AdPlacement struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	Code        string    `gorm:"type:varchar(30);not null;uniqueIndex"` // feed, sidebar, post_detail
+	Name        string    `gorm:"type:varchar(100);not null"`
+	Description string    `gorm:"type:varchar(500)"`
+	PricePerDay int64     `gorm:"not null"` // satang
+
+	// Capacity and serving
+	MaxActiveAds int  `gorm:"not null;default:10"` // ads running at the same time
+	FeedInterval int  `gorm:"not null;default:0"`  // feed placements: one ad every N posts (0 = not interleaved)
+	IsActive     bool `gorm:"default:true"`
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (AdPlacement) TableName() string {
+	return "ad_placements"
+}
+
+// BeforeCreate hook to generate UUID before creating ad placement
+func (p *AdPlacement) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// Ad - Self-service ad campaign paid by bank transfer
+type Ad struct {
+	ID           uuid.UUID    `gorm:"primaryKey;type:uuid"`
+	AdvertiserID uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Advertiser   User         `gorm:"foreignKey:AdvertiserID"`
+	PlacementID  uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Placement    *AdPlacement `gorm:"foreignKey:PlacementID"`
+
+	// Creative (image uploaded through the presigned upload flow)
+	CampaignName   string    `gorm:"type:varchar(100);not null"`
+	Description    string    `gorm:"type:varchar(300)"`
+	DestinationURL string    `gorm:"type:varchar(2048);not null"`
+	MediaID        uuid.UUID `gorm:"type:uuid;not null"`
+	Media          *Media    `gorm:"foreignKey:MediaID"`
+
+	// Schedule and pricing (satang, locked at creation)
+	Status      string    `gorm:"type:varchar(20);not null;default:'pending_payment';index"`
+	Days        int       `gorm:"not null"`
+	PricePerDay int64     `gorm:"not null"`
+	Discount    int64     `gorm:"not null;default:0"`
+	TotalCost   int64     `gorm:"not null"`
+	StartsAt    time.Time `gorm:"not null;index"`
+	EndsAt      time.Time `gorm:"not null;index"`
+
+	// Stats
+	ImpressionCount int64 `gorm:"default:0"`
+	ClickCount      int64 `gorm:"default:0"`
+
+	RemovalReason *string    `gorm:"type:varchar(500)"`
+	ActivatedAt   *time.Time // first time the ad went live
+
+	// Timestamps
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+func (Ad) TableName() string {
+	return "ads"
+}
+
+// BeforeCreate hook to generate UUID before creating ad
+func (a *Ad) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsServable reports whether the ad can be shown right now
+func (a *Ad) IsServable(now time.Time) bool {
+	return a.Status == AdStatusActive && !now.Before(a.StartsAt) && now.Before(a.EndsAt)
+}
+
+// AdPayment - Bank slip submitted for an ad (one pending slip per ad at a time)
+type AdPayment struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid"`
+	AdID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	Ad           *Ad       `gorm:"foreignKey:AdID"`
+	AdvertiserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Advertiser   User      `gorm:"foreignKey:AdvertiserID"`
+
+	Amount        int64     `gorm:"not null"` // satang, must match the ad total cost
+	Method        string    `gorm:"type:varchar(20);not null;default:'bank_transfer'"`
+	SlipMediaID   uuid.UUID `gorm:"type:uuid;not null"`
+	SlipMedia     *Media    `gorm:"foreignKey:SlipMediaID"`
+	BankReference string    `gorm:"type:varchar(100)"`
+	TransferredAt time.Time `gorm:"not null"`
+
+	// Verification
+	Status          string     `gorm:"type:varchar(20);not null;default:'pending';index"`
+	ReviewedBy      *uuid.UUID `gorm:"type:uuid"`
+	ReviewedAt      *time.Time
+	RejectionReason *string    `gorm:"type:varchar(500)"`
+	LedgerTxID      *uuid.UUID `gorm:"type:uuid"` // platform revenue posting
+
+	// Timestamps
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+func (AdPayment) TableName() string {
+	return "ad_payments"
+}
+
+// BeforeCreate hook to generate UUID before creating ad payment
+func (p *AdPayment) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// AdImpression - An ad shown to a viewer (reported by the client after it was on screen)
+type AdImpression struct {
+	ID             uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	AdID           uuid.UUID  `gorm:"type:uuid;not null;index:idx_ad_impressions_ad_viewed,priority:1"`
+	UserID         *uuid.UUID `gorm:"type:uuid;index"` // nil for guests
+	Placement      string     `gorm:"type:varchar(30);not null"`
+	ViewDurationMs int        `gorm:"default:0"`
+	DeviceType     string     `gorm:"type:varchar(20)"` // mobile, desktop
+	IPAddress      string     `gorm:"type:varchar(45)"`
+	UserAgent      string     `gorm:"type:varchar(500)"`
+	ViewedAt       time.Time  `gorm:"not null;index:idx_ad_impressions_ad_viewed,priority:2"`
+}
+
+func (AdImpression) TableName() string {
+	return "ad_impressions"
+}
+
+// BeforeCreate hook to generate UUID before creating ad impression
+func (i *AdImpression) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// AdClick - A click on an ad (redirects to the destination URL)
+type AdClick struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	AdID         uuid.UUID  `gorm:"type:uuid;not null;index:idx_ad_clicks_ad_clicked,priority:1"`
+	UserID       *uuid.UUID `gorm:"type:uuid;index"`
+	ImpressionID *uuid.UUID `gorm:"type:uuid"`
+	Placement    string     `gorm:"type:varchar(30);not null"`
+	ReferrerURL  string     `gorm:"type:varchar(2048)"`
+	DeviceType   string     `gorm:"type:varchar(20)"`
+	IPAddress    string     `gorm:"type:varchar(45)"`
+	ClickedAt    time.Time  `gorm:"not null;index:idx_ad_clicks_ad_clicked,priority:2"`
+}
+
+func (AdClick) TableName() string {
+	return "ad_clicks"
+}
+
+// BeforeCreate hook to generate UUID before creating ad click
+func (c *AdClick) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// API token kinds (the kind is also the visible prefix of the token, e.g. "pat_…")
+const (
+	APITokenKindPersonal = "pat" // created by a user for their own account
+	APITokenKindBot      = "bot" // issued by staff to a bot account (e.g. an auto-post bot)
+)
+
+// API token scopes (reads are GET requests, writes everything else; see middleware.Protected)
+const (
+	ScopePostsRead         = "posts:read" // posts, comments, communities, tags and search
+	ScopePostsWrite        = "posts:write"
+	ScopeCommentsWrite     = "comments:write"
+	ScopeVotesWrite        = "votes:write"
+	ScopeMediaWrite        = "media:write"
+	ScopeChatRead          = "chat:read"
+	ScopeChatWrite         = "chat:write"
+	ScopeNotificationsRead = "notifications:read"
+	ScopeProfileRead       = "profile:read"
+)
+
+// APITokenScopes lists every scope a token can be granted
+var APITokenScopes = []string{
+	ScopePostsRead, ScopePostsWrite, ScopeCommentsWrite, ScopeVotesWrite, ScopeMediaWrite,
+	ScopeChatRead, ScopeChatWrite, ScopeNotificationsRead, ScopeProfileRead,
+}
+
+// APIToken - A personal access token or bot key; authenticates as its user, limited to its scopes
+type APIToken struct {
+	ID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+
+	Kind        string     `gorm:"type:varchar(10);not null;default:'pat'"`
+	Name        string     `gorm:"type:varchar(100);not null"`
+	CreatedByID *uuid.UUID `gorm:"type:uuid"` // staff member who issued a bot key
+
+	// The raw token is shown once; only its SHA-256 is stored. Prefix identifies it in token lists.
+	Prefix    string `gorm:"type:varchar(16);not null"`
+	TokenHash string `gorm:"type:varchar(64);not null;uniqueIndex"`
+
+	Scopes             datatypes.JSON `gorm:"type:jsonb;not null"` // []string
+	RateLimitPerMinute int            `gorm:"not null;default:60"`
+
+	// Lifetime
+	ExpiresAt  *time.Time // nil = never (bot keys only)
+	LastUsedAt *time.Time
+	LastUsedIP string `gorm:"type:varchar(45)"`
+	RevokedAt  *time.Time
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+// BeforeCreate hook to generate UUID before creating API token
+func (t *APIToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive reports whether the token can still authenticate
+func (t *APIToken) IsActive() bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || time.Now().Before(*t.ExpiresAt))
+}
+
+// ScopeList decodes the granted scopes
+func (t *APIToken) ScopeList() []string {
+	var scopes []string
+	if len(t.Scopes) > 0 {
+		_ = json.Unmarshal(t.Scopes, &scopes)
+	}
+	return scopes
+}
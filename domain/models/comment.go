@@ -26,6 +26,13 @@ type Comment struct {
 	// Status
 	IsDeleted bool `gorm:"default:false"`
 
+	// Moderator removal (nil = deleted by the author or still live)
+	RemovedByID   *uuid.UUID `gorm:"type:uuid"`
+	RemovalReason *string    `gorm:"type:varchar(500)"`
+
+	// Written while the author was shadowbanned (only the author sees it)
+	IsShadowed bool `gorm:"default:false"`
+
 	// Timestamps
 	CreatedAt time.Time `gorm:"index"`
 	UpdatedAt time.Time
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Community visibility modes
+const (
+	CommunityVisibilityPublic     = "public"     // anyone can view and join
+	CommunityVisibilityRestricted = "restricted" // anyone can view, joining needs moderator approval
+	CommunityVisibilityPrivate    = "private"    // only members can view, joining needs moderator approval
+)
+
+// Community posting policies
+const (
+	CommunityPostingMembers    = "members"    // any active member can post
+	CommunityPostingModerators = "moderators" // only owners and moderators can post (announcements)
+)
+
+// Community member roles
+const (
+	CommunityRoleOwner     = "owner"
+	CommunityRoleModerator = "moderator"
+	CommunityRoleMember    = "member"
+)
+
+// Community member statuses
+const (
+	CommunityMemberStatusPending = "pending" // join request awaiting moderator approval
+	CommunityMemberStatusActive  = "active"
+	CommunityMemberStatusBanned  = "banned" // removed by a moderator, can't rejoin
+)
+
+// Community - A user-created group with its own feed, rules and moderators
+type Community struct {
+	ID          uuid.UUID      `gorm:"primaryKey;type:uuid"`
+	Slug        string         `gorm:"type:varchar(50);uniqueIndex;not null"` // lowercase URL name, immutable
+	Name        string         `gorm:"type:varchar(100);not null"`
+	Description string         `gorm:"type:text"`
+	Rules       datatypes.JSON `gorm:"type:jsonb"` // []CommunityRule shown in the sidebar
+
+	// Branding
+	IconMediaID   *uuid.UUID `gorm:"type:uuid"`
+	IconMedia     *Media     `gorm:"foreignKey:IconMediaID"`
+	BannerMediaID *uuid.UUID `gorm:"type:uuid"`
+	BannerMedia   *Media     `gorm:"foreignKey:BannerMediaID"`
+
+	// Access
+	Visibility    string `gorm:"type:varchar(20);not null;default:'public';index"`
+	PostingPolicy string `gorm:"type:varchar(20);not null;default:'members'"`
+
+	// Owner (also stored as a member with the owner role)
+	OwnerID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Owner   User      `gorm:"foreignKey:OwnerID"`
+
+	// Stats
+	MemberCount int `gorm:"default:0;index"` // active members
+	PostCount   int `gorm:"default:0"`
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Community) TableName() string {
+	return "communities"
+}
+
+// BeforeCreate hook to generate UUID before creating community
+func (c *Community) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// RequiresApproval reports whether join requests have to be approved by a moderator
+func (c *Community) RequiresApproval() bool {
+	return c.Visibility == CommunityVisibilityRestricted || c.Visibility == CommunityVisibilityPrivate
+}
+
+// CommunityMember - Membership of a user in a community (one row per community/user pair)
+type CommunityMember struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	CommunityID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_community_members_community_user"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_community_members_community_user;index"`
+	User        User       `gorm:"foreignKey:UserID"`
+	Role        string     `gorm:"type:varchar(20);not null;default:'member'"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'active';index"`
+	JoinedAt    *time.Time // set when the membership becomes active
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (CommunityMember) TableName() string {
+	return "community_members"
+}
+
+// BeforeCreate hook to generate UUID before creating community member
+func (m *CommunityMember) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive reports whether the member can view and take part in the community
+func (m *CommunityMember) IsActive() bool {
+	return m.Status == CommunityMemberStatusActive
+}
+
+// IsModerator reports whether the member can moderate the community (owners included)
+func (m *CommunityMember) IsModerator() bool {
+	return m.IsActive() && (m.Role == CommunityRoleOwner || m.Role == CommunityRoleModerator)
+}
@@ -7,15 +7,41 @@ import (
 	"gorm.io/gorm"
 )
 
+// Conversation types
+const (
+	ConversationTypeDirect = "direct" // 1-on-1, identified by the User1ID/User2ID pair
+	ConversationTypeGroup  = "group"  // named chat managed by its owner and admins
+)
+
+// Participant roles (only meaningful in group conversations)
+const (
+	ConversationRoleOwner  = "owner"
+	ConversationRoleAdmin  = "admin"
+	ConversationRoleMember = "member"
+)
+
 type Conversation struct {
 	ID uuid.UUID `gorm:"primaryKey;type:uuid"`
 
-	// Participants (ordered by UUID for consistency)
-	User1ID uuid.UUID `gorm:"not null;index:idx_conversation_users"`
-	User1   User      `gorm:"foreignKey:User1ID"`
+	Type string `gorm:"type:varchar(20);not null;default:'direct';index"`
+
+	// Participants of direct conversations (ordered by UUID for consistency, NULL for groups)
+	User1ID *uuid.UUID `gorm:"type:uuid;index:idx_conversation_users"`
+	User1   *User      `gorm:"foreignKey:User1ID"`
 
-	User2ID uuid.UUID `gorm:"not null;index:idx_conversation_users"`
-	User2   User      `gorm:"foreignKey:User2ID"`
+	User2ID *uuid.UUID `gorm:"type:uuid;index:idx_conversation_users"`
+	User2   *User      `gorm:"foreignKey:User2ID"`
+
+	// Group details
+	Name        *string    `gorm:"type:varchar(100)"`
+	AvatarURL   *string    `gorm:"type:varchar(500)"`
+	CreatedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// Message request: the participant who started a direct chat the other one has not accepted yet
+	RequestedByID *uuid.UUID `gorm:"type:uuid"`
+
+	// Every member, with their own unread counter and read pointer (both types)
+	Participants []ConversationParticipant `gorm:"foreignKey:ConversationID"`
 
 	// Last Message (denormalized for performance)
 	// Note: No FK constraint to avoid circular dependency with Message table
@@ -24,9 +50,8 @@ type Conversation struct {
 	LastMessage   *Message   `gorm:"-"` // Skip this field during migration
 	LastMessageAt time.Time  `gorm:"index"`
 
-	// Unread Counts (denormalized for performance)
-	User1UnreadCount int `gorm:"default:0"`
-	User2UnreadCount int `gorm:"default:0"`
+	// Sequence of the latest ConversationEvent (assigned by the database)
+	LastSeq int64 `gorm:"->"`
 
 	// Timestamps
 	CreatedAt time.Time `gorm:"index"`
@@ -44,3 +69,94 @@ func (c *Conversation) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// IsGroup reports whether this is a group conversation
+func (c *Conversation) IsGroup() bool {
+	return c.Type == ConversationTypeGroup
+}
+
+// IsPendingRequest reports whether this is a message request that has not been accepted
+func (c *Conversation) IsPendingRequest() bool {
+	return c.RequestedByID != nil
+}
+
+// IsRequestFor reports whether the user received this pending message request (and may accept it)
+func (c *Conversation) IsRequestFor(userID uuid.UUID) bool {
+	return c.RequestedByID != nil && *c.RequestedByID != userID
+}
+
+// OtherUserID returns the other participant of a direct conversation (uuid.Nil for groups)
+func (c *Conversation) OtherUserID(userID uuid.UUID) uuid.UUID {
+	if c.IsGroup() || c.User1ID == nil || c.User2ID == nil {
+		return uuid.Nil
+	}
+	if *c.User1ID == userID {
+		return *c.User2ID
+	}
+	return *c.User1ID
+}
+
+// ConversationParticipant - Membership of a conversation.
+// Unread counts and read pointers live here so groups of any size work the same way as direct chats.
+type ConversationParticipant struct {
+	ConversationID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID         uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	User           User      `gorm:"foreignKey:UserID"`
+
+	Role string `gorm:"type:varchar(20);not null;default:'member'"`
+
+	// Unread counter and read pointer
+	UnreadCount       int `gorm:"not null;default:0"`
+	LastReadMessageID *uuid.UUID
+	LastReadAt        *time.Time
+
+	// Receipts: messages up to these sequences reached one of the user's devices / were read
+	LastDeliveredSeq int64 `gorm:"not null;default:0"`
+	LastReadSeq      int64 `gorm:"not null;default:0"`
+
+	JoinedAt time.Time `gorm:"not null"`
+}
+
+func (ConversationParticipant) TableName() string {
+	return "conversation_participants"
+}
+
+// HasRead reports whether the participant has read the message with this sequence
+func (p *ConversationParticipant) HasRead(seq int64) bool {
+	return p.LastReadSeq >= seq
+}
+
+// HasReceived reports whether the message with this sequence reached one of the participant's devices
+func (p *ConversationParticipant) HasReceived(seq int64) bool {
+	return p.LastDeliveredSeq >= seq || p.LastReadSeq >= seq
+}
+
+// CanManage reports whether the participant may rename the group and add or remove members
+func (p *ConversationParticipant) CanManage() bool {
+	return p.Role == ConversationRoleOwner || p.Role == ConversationRoleAdmin
+}
+
+// Conversation event types (replayed to reconnecting clients in this order)
+const (
+	ConversationEventMessageNew    = "message.new"
+	ConversationEventMessageEdited = "message.edited"
+	ConversationEventMessageUnsent = "message.unsent"
+	ConversationEventDelivered     = "receipt.delivered"
+	ConversationEventRead          = "receipt.read"
+)
+
+// ConversationEvent - A change to a conversation under its sequence number.
+// Messages get theirs from their message.new event (see migration 045).
+type ConversationEvent struct {
+	ConversationID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Seq            int64      `gorm:"primaryKey;autoIncrement:false"`
+	Type           string     `gorm:"type:varchar(30);not null"`
+	MessageID      *uuid.UUID `gorm:"type:uuid"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null"`
+	UpToSeq        *int64     // Receipts only
+	CreatedAt      time.Time
+}
+
+func (ConversationEvent) TableName() string {
+	return "conversation_events"
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Data export statuses
+// pending -> processing -> ready -> expired (file deleted) / failed
+const (
+	DataExportStatusPending    = "pending"
+	DataExportStatusProcessing = "processing"
+	DataExportStatusReady      = "ready"
+	DataExportStatusFailed     = "failed"
+	DataExportStatusExpired    = "expired"
+)
+
+// DataExport - A personal data export (ZIP of the user's profile and content) built in the background
+type DataExport struct {
+	ID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+
+	Status string `gorm:"type:varchar(20);not null;default:'pending';index"`
+
+	// Archive in R2 (private, downloaded through a presigned URL)
+	FileKey  *string `gorm:"type:varchar(500)"`
+	FileSize int64   `gorm:"not null;default:0"` // bytes
+
+	Error       *string `gorm:"type:varchar(500)"`
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time `gorm:"index"` // file is deleted after this
+
+	// Timestamps
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+func (DataExport) TableName() string {
+	return "data_exports"
+}
+
+// BeforeCreate hook to generate UUID before creating data export
+func (e *DataExport) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
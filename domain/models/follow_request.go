@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Follow states returned when following a user
+const (
+	FollowStatusFollowing = "following" // public account, followed right away
+	FollowStatusRequested = "requested" // private account, waiting for the owner's approval
+)
+
+// FollowRequest - A pending follow of a private account.
+// Accepting it creates the Follow and deletes the request; rejecting only deletes it.
+type FollowRequest struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	RequesterID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_follow_requests_pair"`
+	Requester   User      `gorm:"foreignKey:RequesterID"`
+
+	TargetID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_follow_requests_pair;index"`
+	Target   User      `gorm:"foreignKey:TargetID"`
+
+	// Timestamps
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (FollowRequest) TableName() string {
+	return "follow_requests"
+}
+
+// BeforeCreate hook to generate UUID before creating follow request
+func (r *FollowRequest) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Gift statuses
+const (
+	GiftStatusPending  = "pending"  // sealed, waiting for receiver to open
+	GiftStatusOpened   = "opened"   // receiver opened it and got paid
+	GiftStatusExpired  = "expired"  // not opened in time, sender partially refunded
+	GiftStatusRefunded = "refunded" // fully refunded (e.g. inappropriate content)
+)
+
+// GiftPackage - Gift catalog entry (Bronze, Silver, Gold, Diamond)
+type GiftPackage struct {
+	ID               uuid.UUID `gorm:"type:uuid;primary_key"`
+	Code             string    `gorm:"type:varchar(30);uniqueIndex;not null"`
+	Name             string    `gorm:"type:varchar(100);not null"`
+	Price            int64     `gorm:"not null"`           // satang
+	ReceiverPercent  int       `gorm:"not null"`           // share credited to the receiver on open
+	MaxImages        int       `gorm:"not null;default:0"` // 0 = images not allowed
+	MaxVideos        int       `gorm:"not null;default:0"` // 0 = videos not allowed
+	MaxMessageLength int       `gorm:"not null;default:500"`
+	Icon             string    `gorm:"type:varchar(50)"`
+	Color            string    `gorm:"type:varchar(20)"`
+	SortOrder        int       `gorm:"default:0"`
+	IsActive         bool      `gorm:"default:true;index"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook to generate UUID before creating gift package
+func (p *GiftPackage) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+func (GiftPackage) TableName() string {
+	return "gift_packages"
+}
+
+// ReceiverAmount returns how much the receiver gets when opening a gift of this package
+func (p *GiftPackage) ReceiverAmount() int64 {
+	return p.Price * int64(p.ReceiverPercent) / 100
+}
+
+// Gift - Paid gift box sent as a chat message, money is kept in escrow until opened or expired
+type Gift struct {
+	ID             uuid.UUID    `gorm:"type:uuid;primary_key"`
+	MessageID      uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex"`
+	ConversationID uuid.UUID    `gorm:"type:uuid;not null;index"`
+	SenderID       uuid.UUID    `gorm:"type:uuid;not null;index"`
+	ReceiverID     uuid.UUID    `gorm:"type:uuid;not null;index"`
+	PackageID      uuid.UUID    `gorm:"type:uuid;not null"`
+	Package        *GiftPackage `gorm:"foreignKey:PackageID"`
+
+	// Amounts (satang)
+	AmountPaid     int64 `gorm:"not null"`
+	ReceiverAmount int64 `gorm:"not null"`
+	PlatformFee    int64 `gorm:"not null"`
+
+	// Lifecycle
+	Status    string    `gorm:"type:varchar(20);not null;default:'pending';index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	OpenedAt  *time.Time
+	SettledAt *time.Time // escrow paid out (open) or refunded (expiry)
+
+	CreatedAt time.Time `gorm:"autoCreateTime;index"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook to generate UUID before creating gift
+func (g *Gift) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+func (Gift) TableName() string {
+	return "gifts"
+}
+
+// IsSealed reports whether the gift content is still hidden
+func (g *Gift) IsSealed() bool {
+	return g.Status != GiftStatusOpened
+}
@@ -30,6 +30,12 @@ type Media struct {
 	// Video specific
 	Duration float64 // seconds (for videos)
 
+	// Bunny Stream encoding (only for videos uploaded through CreateVideo, e.g. reels)
+	VideoID          *string `gorm:"type:varchar(255);index"`                // Bunny Stream video GUID
+	HLSURL           string  `gorm:"column:hls_url"`                         // HLS playlist (m3u8)
+	EncodingStatus   string  `gorm:"type:varchar(20);default:pending;index"` // pending, processing, completed, failed
+	EncodingProgress int     `gorm:"default:0"`                              // 0-100
+
 	// Polymorphic source tracking (for videos in different features)
 	SourceType *string    `gorm:"type:varchar(50);index:idx_media_source"` // "post", "message", "reel", "comment", etc.
 	SourceID   *uuid.UUID `gorm:"type:uuid;index:idx_media_source"`        // ID of the source entity
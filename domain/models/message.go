@@ -16,6 +16,7 @@ const (
 	MessageTypeImage MessageType = "image"
 	MessageTypeVideo MessageType = "video"
 	MessageTypeFile  MessageType = "file"
+	MessageTypeGift  MessageType = "gift" // Paid gift box, content hidden until opened
 )
 
 // MessageMedia represents media attached to a message
@@ -42,13 +43,16 @@ type Message struct {
 	ConversationID uuid.UUID    `gorm:"not null;index:idx_conversation_messages"`
 	Conversation   Conversation `gorm:"foreignKey:ConversationID"`
 
-	// Sender & Receiver
-	SenderID   uuid.UUID `gorm:"not null;index"`
-	Sender     User      `gorm:"foreignKey:SenderID"`
-	ReceiverID uuid.UUID `gorm:"not null;index"`
-	Receiver   User      `gorm:"foreignKey:ReceiverID"`
+	// Per-conversation sequence (assigned by the database on insert)
+	Seq int64 `gorm:"->"`
 
-	// Message Type (text, image, video, file)
+	// Sender & Receiver (no receiver in group conversations)
+	SenderID   uuid.UUID  `gorm:"not null;index"`
+	Sender     User       `gorm:"foreignKey:SenderID"`
+	ReceiverID *uuid.UUID `gorm:"type:uuid;index"`
+	Receiver   *User      `gorm:"foreignKey:ReceiverID"`
+
+	// Message Type (text, image, video, file, gift)
 	Type MessageType `gorm:"type:varchar(20);not null;default:'text';index:idx_messages_type"`
 
 	// Content (nullable - for media-only messages)
@@ -57,10 +61,26 @@ type Message struct {
 	// Media (JSONB array of MessageMedia)
 	Media datatypes.JSON `gorm:"type:jsonb"`
 
-	// Read Status
+	// Gift box (only for gift messages)
+	Gift *Gift `gorm:"foreignKey:MessageID"`
+
+	// Quoted reply (the original is shown as a snippet)
+	ReplyToMessageID *uuid.UUID `gorm:"type:uuid"`
+	ReplyTo          *Message   `gorm:"foreignKey:ReplyToMessageID"`
+
+	// Emoji reactions
+	Reactions []MessageReaction `gorm:"foreignKey:MessageID"`
+
+	// Read Status (direct conversations; groups use the participants' read pointers)
 	IsRead bool `gorm:"default:false;index"`
 	ReadAt *time.Time
 
+	// Last edit (earlier versions are kept as MessageRevisions)
+	EditedAt *time.Time
+
+	// Removed by a moderator (content and media are cleared, the row stays for gifts and history)
+	RemovedAt *time.Time
+
 	// Timestamps (for cursor pagination)
 	CreatedAt time.Time `gorm:"index:idx_conversation_messages"`
 	UpdatedAt time.Time
@@ -77,3 +97,52 @@ func (m *Message) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// MessageRevision - The text of a message before one of its edits
+type MessageRevision struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid"`
+	MessageID uuid.UUID `gorm:"type:uuid;not null;index:idx_message_revisions_message_created"`
+
+	// Content as it was before the edit
+	Content *string `gorm:"type:text"`
+
+	// When this version was replaced
+	CreatedAt time.Time `gorm:"index:idx_message_revisions_message_created"`
+}
+
+func (MessageRevision) TableName() string {
+	return "message_revisions"
+}
+
+// BeforeCreate hook to generate UUID before creating revision
+func (r *MessageRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// MessageReactionEmojis - The emojis users can react with
+var MessageReactionEmojis = []string{"❤️", "😂", "😮", "😢", "😡", "👍", "🔥", "🙏"}
+
+// IsMessageReactionEmoji reports whether the emoji is in MessageReactionEmojis
+func IsMessageReactionEmoji(emoji string) bool {
+	for _, allowed := range MessageReactionEmojis {
+		if allowed == emoji {
+			return true
+		}
+	}
+	return false
+}
+
+// MessageReaction - An emoji reaction of a user to a message
+type MessageReaction struct {
+	MessageID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID    uuid.UUID `gorm:"primaryKey;type:uuid;index"`
+	Emoji     string    `gorm:"primaryKey;type:varchar(16)"`
+	CreatedAt time.Time
+}
+
+func (MessageReaction) TableName() string {
+	return "message_reactions"
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Payout statuses
+// pending -> approved -> paid, pending -> rejected/cancelled, approved -> failed (refunded to the wallet)
+const (
+	PayoutStatusPending   = "pending"   // amount held on the wallet, waiting for admin review
+	PayoutStatusApproved  = "approved"  // amount debited, waiting for the bank batch transfer
+	PayoutStatusPaid      = "paid"      // transferred (final)
+	PayoutStatusRejected  = "rejected"  // hold released (final)
+	PayoutStatusCancelled = "cancelled" // cancelled by the creator, hold released (final)
+	PayoutStatusFailed    = "failed"    // bank transfer failed, amount refunded (final)
+)
+
+// Payout destination types
+const (
+	PayoutDestinationBankAccount = "bank_account"
+	PayoutDestinationPromptPay   = "promptpay"
+)
+
+// Payout is a creator's request to withdraw earned wallet balance
+type Payout struct {
+	ID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+
+	// Amounts (satang)
+	Amount    int64 `gorm:"not null"` // debited from the wallet
+	Fee       int64 `gorm:"not null;default:0"`
+	NetAmount int64 `gorm:"not null"` // transferred to the creator (Amount - Fee)
+
+	// Destination (snapshot at request time)
+	DestinationType string  `gorm:"type:varchar(20);not null"` // bank_account, promptpay
+	BankCode        *string `gorm:"type:varchar(10)"`          // bank accounts only (KBANK, SCB, ...)
+	AccountNumber   string  `gorm:"type:varchar(30);not null"` // bank account number or PromptPay ID
+	AccountName     string  `gorm:"type:varchar(100);not null"`
+
+	// Lifecycle
+	Status     string     `gorm:"type:varchar(20);not null;default:'pending';index"`
+	HoldID     *uuid.UUID `gorm:"type:uuid"` // wallet hold while pending
+	LedgerTxID *uuid.UUID `gorm:"type:uuid"` // debit posting on approval
+	RefundTxID *uuid.UUID `gorm:"type:uuid"` // refund posting when the transfer failed
+
+	// Review
+	ReviewedBy      *uuid.UUID `gorm:"type:uuid"`
+	ReviewedAt      *time.Time
+	RejectionReason *string `gorm:"type:varchar(500)"`
+
+	// Transfer
+	BankReference *string `gorm:"type:varchar(100)"`
+	PaidAt        *time.Time
+	FailureReason *string `gorm:"type:varchar(500)"`
+
+	// Timestamps
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+func (Payout) TableName() string {
+	return "payouts"
+}
+
+// BeforeCreate hook to generate UUID before creating payout
+func (p *Payout) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
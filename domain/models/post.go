@@ -26,6 +26,10 @@ type Post struct {
 	SourcePostID *uuid.UUID `gorm:"index"`
 	SourcePost   *Post      `gorm:"foreignKey:SourcePostID"`
 
+	// Community (optional) - nil = personal post
+	CommunityID *uuid.UUID `gorm:"type:uuid;index"`
+	Community   *Community `gorm:"foreignKey:CommunityID"`
+
 	// Media & Tags (relationships)
 	Media []Media `gorm:"many2many:post_media;"`
 	Tags  []Tag   `gorm:"many2many:post_tags;"`
@@ -33,10 +37,29 @@ type Post struct {
 	// Idempotency (for preventing duplicate posts)
 	ClientPostID *string `gorm:"type:varchar(255);uniqueIndex:idx_posts_client_post_id"` // client-generated unique ID
 
+	// Premium unlock (ค่าเสือก) - crowdfunded content, nil target = normal post
+	UnlockTargetAmount     *int64     `gorm:"index"`                  // satang
+	UnlockCurrentAmount    int64      `gorm:"default:0"`              // satang contributed so far
+	UnlockContributorCount int        `gorm:"default:0"`              // distinct contributors
+	UnlockStatus           *string    `gorm:"type:varchar(20);index"` // locked, unlocked, failed
+	UnlockDeadline         *time.Time `gorm:"index"`                  // optional, refund if not reached
+	UnlockPreviewURL       string     `gorm:"type:varchar(500)"`      // blurred teaser thumbnail
+	UnlockedAt             *time.Time
+
+	// Subscriber-only content - nil = public, otherwise the minimum subscription tier level required
+	MinTierLevel *int `gorm:"index"`
+
 	// Status
 	Status    string `gorm:"type:varchar(20);default:'published';index"` // draft, published
 	IsDeleted bool   `gorm:"default:false;index"`
 
+	// Moderator removal (nil = deleted by the author or still live)
+	RemovedByID   *uuid.UUID `gorm:"type:uuid"`
+	RemovalReason *string    `gorm:"type:varchar(500)"`
+
+	// Written while the author was shadowbanned (only the author sees it)
+	IsShadowed bool `gorm:"default:false"`
+
 	// Timestamps
 	CreatedAt time.Time `gorm:"index"`
 	UpdatedAt time.Time
@@ -46,3 +69,37 @@ type Post struct {
 func (Post) TableName() string {
 	return "posts"
 }
+
+// Premium unlock statuses
+const (
+	PostUnlockStatusLocked   = "locked"
+	PostUnlockStatusUnlocked = "unlocked"
+	PostUnlockStatusFailed   = "failed"
+)
+
+// IsPremiumUnlock reports whether the post is a crowdfunded premium unlock post
+func (p *Post) IsPremiumUnlock() bool {
+	return p.UnlockTargetAmount != nil && *p.UnlockTargetAmount > 0
+}
+
+// IsSubscriberOnly reports whether the post is restricted to subscribers of its author
+func (p *Post) IsSubscriberOnly() bool {
+	return p.MinTierLevel != nil && *p.MinTierLevel > 0
+}
+
+// IsUnlocked reports whether the premium unlock goal has been reached
+func (p *Post) IsUnlocked() bool {
+	return p.UnlockStatus != nil && *p.UnlockStatus == PostUnlockStatusUnlocked
+}
+
+// UnlockProgress returns the unlock progress in percent (0-100)
+func (p *Post) UnlockProgress() int {
+	if !p.IsPremiumUnlock() {
+		return 0
+	}
+	progress := int(p.UnlockCurrentAmount * 100 / *p.UnlockTargetAmount)
+	if progress > 100 {
+		return 100
+	}
+	return progress
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Contribution statuses
+const (
+	PostUnlockContributionHeld     = "held"     // money reserved in contributor's wallet
+	PostUnlockContributionCaptured = "captured" // goal reached, paid out to creator
+	PostUnlockContributionReleased = "released" // goal failed, hold released (refunded)
+)
+
+// PostUnlockContribution - One contribution towards unlocking a premium post
+type PostUnlockContribution struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key"`
+	PostID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	Post           *Post     `gorm:"foreignKey:PostID"`
+	UserID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	User           *User     `gorm:"foreignKey:UserID"`
+	Amount         int64     `gorm:"not null"` // satang
+	Status         string    `gorm:"type:varchar(20);not null;default:'held';index"`
+	HoldID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	IdempotencyKey string    `gorm:"type:varchar(255);not null;uniqueIndex"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate hook to generate UUID before creating contribution
+func (c *PostUnlockContribution) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+func (PostUnlockContribution) TableName() string {
+	return "post_unlock_contributions"
+}
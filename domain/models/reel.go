@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reel statuses
+const (
+	ReelStatusProcessing = "processing" // video is encoding on Bunny Stream
+	ReelStatusActive     = "active"     // viewable until expires_at
+	ReelStatusFailed     = "failed"     // encoding failed
+	ReelStatusExpired    = "expired"    // past 24h, video deleted (row kept for analytics)
+	ReelStatusDeleted    = "deleted"    // removed by the creator before expiry
+)
+
+// Reel visibility
+const (
+	ReelVisibilityPublic    = "public"
+	ReelVisibilityFollowers = "followers"
+)
+
+// ReelTTL - How long a reel stays viewable
+const ReelTTL = 24 * time.Hour
+
+// ReelMediaSourceType - models.Media.SourceType of reel videos
+const ReelMediaSourceType = "reel"
+
+// Reel - Ephemeral vertical video (24h), streamed from Bunny Stream
+type Reel struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primary_key"`
+	CreatorID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Creator   User       `gorm:"foreignKey:CreatorID"`
+	MediaID   *uuid.UUID `gorm:"type:uuid;index"` // nil once the video has been deleted
+	Media     *Media     `gorm:"foreignKey:MediaID"`
+
+	Caption    string `gorm:"type:varchar(150)"`
+	Status     string `gorm:"type:varchar(20);not null;default:'processing';index"`
+	Visibility string `gorm:"type:varchar(20);not null;default:'public'"`
+
+	// Stats
+	ViewCount int `gorm:"default:0"` // unique viewers
+
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+// BeforeCreate hook to generate UUID before creating reel
+func (r *Reel) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+func (Reel) TableName() string {
+	return "reels"
+}
+
+// IsViewable reports whether the reel can be watched right now
+func (r *Reel) IsViewable(now time.Time) bool {
+	return r.Status == ReelStatusActive && now.Before(r.ExpiresAt)
+}
+
+// ReelView - One row per viewer per reel (viewer list for the creator)
+type ReelView struct {
+	ID            uuid.UUID `gorm:"type:uuid;primary_key"`
+	ReelID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reel_views_reel_viewer"`
+	ViewerID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reel_views_reel_viewer;index"`
+	Viewer        User      `gorm:"foreignKey:ViewerID"`
+	WatchDuration float64   `gorm:"default:0"` // seconds, longest watch
+	Completed     bool      `gorm:"default:false"`
+	ViewedAt      time.Time `gorm:"not null;index"`
+}
+
+// BeforeCreate hook to generate UUID before creating reel view
+func (v *ReelView) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+func (ReelView) TableName() string {
+	return "reel_views"
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Report target types
+const (
+	ReportTargetPost    = "post"
+	ReportTargetComment = "comment"
+	ReportTargetMessage = "message"
+	ReportTargetUser    = "user"
+)
+
+// Report reason codes
+const (
+	ReportReasonSpam          = "spam"
+	ReportReasonHarassment    = "harassment"
+	ReportReasonHateSpeech    = "hate_speech"
+	ReportReasonViolence      = "violence"
+	ReportReasonSexualContent = "sexual_content"
+	ReportReasonMisinfo       = "misinformation"
+	ReportReasonSelfHarm      = "self_harm"
+	ReportReasonIllegal       = "illegal"
+	ReportReasonImpersonation = "impersonation"
+	ReportReasonOther         = "other"
+)
+
+// Report statuses
+// open -> resolved (action taken) / dismissed (no violation)
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// Report moderation actions
+const (
+	ReportActionDismiss = "dismiss"        // no violation found
+	ReportActionRemove  = "remove_content" // post/comment/message removed
+	ReportActionWarn    = "warn"           // target user notified
+	ReportActionSuspend = "suspend"        // target user's account suspended (UserSanction)
+)
+
+// Report - A user flagging a post, comment, message or user for moderator review
+type Report struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid"`
+	ReporterID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Reporter   User      `gorm:"foreignKey:ReporterID"`
+
+	// Target
+	TargetType   string    `gorm:"type:varchar(20);not null;index:idx_reports_target"`
+	TargetID     uuid.UUID `gorm:"type:uuid;not null;index:idx_reports_target"`
+	TargetUserID uuid.UUID `gorm:"type:uuid;not null;index"` // author/sender of the content, or the reported user
+	TargetUser   User      `gorm:"foreignKey:TargetUserID"`
+	Snapshot     string    `gorm:"type:text"` // content at report time (kept as evidence after removal)
+
+	// Reason
+	ReasonCode string `gorm:"type:varchar(30);not null"`
+	Details    string `gorm:"type:text"`
+
+	// Resolution
+	Status         string     `gorm:"type:varchar(20);not null;default:'open';index"`
+	Action         *string    `gorm:"type:varchar(20)"`
+	ResolvedBy     *uuid.UUID `gorm:"type:uuid"`
+	ResolvedAt     *time.Time
+	ResolutionNote *string `gorm:"type:varchar(500)"`
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Report) TableName() string {
+	return "reports"
+}
+
+// BeforeCreate hook to generate UUID before creating report
+func (r *Report) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsOpen reports whether the report still waits in the moderation queue
+func (r *Report) IsOpen() bool {
+	return r.Status == ReportStatusOpen
+}
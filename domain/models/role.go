@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Permissions (seeded by migration 037, checked with middleware.RequirePermission)
+const (
+	PermPostRemoveAny     = "post.remove.any"
+	PermCommentRemoveAny  = "comment.remove.any"
+	PermCommunityModerate = "community.moderate" // approve, ban and remove content in communities
+	PermUserList          = "user.list"
+	PermUserSuspend       = "user.suspend"
+	PermReportsReview     = "reports.review"
+	PermAdsApprove        = "ads.approve"
+	PermPayoutsManage     = "payouts.manage"
+	PermTasksViewAny      = "tasks.view.any"
+	PermFilesViewAny      = "files.view.any"
+	PermJobsManage        = "jobs.manage"
+	PermRolesManage       = "roles.manage"
+	PermBotTokensManage   = "bot_tokens.manage"
+)
+
+// Built-in roles
+const (
+	RoleAdmin              = "admin"               // every permission
+	RoleModerator          = "moderator"           // site-wide content moderation
+	RoleCommunityModerator = "community_moderator" // content moderation inside one community
+)
+
+// Role scopes
+const (
+	RoleScopeGlobal    = "global"
+	RoleScopeCommunity = "community" // granted per community (UserRole.CommunityID is required)
+)
+
+// Permission - A named action that roles can grant
+type Permission struct {
+	Name        string `gorm:"primaryKey;type:varchar(50)"`
+	Description string `gorm:"type:varchar(255)"`
+}
+
+func (Permission) TableName() string {
+	return "permissions"
+}
+
+// Role - A named set of permissions
+type Role struct {
+	ID          uuid.UUID    `gorm:"primaryKey;type:uuid"`
+	Name        string       `gorm:"type:varchar(50);uniqueIndex;not null"`
+	Description string       `gorm:"type:varchar(255)"`
+	Scope       string       `gorm:"type:varchar(20);not null;default:'global'"`
+	Permissions []Permission `gorm:"many2many:role_permissions;joinForeignKey:RoleID;joinReferences:PermissionName"`
+
+	CreatedAt time.Time
+}
+
+func (Role) TableName() string {
+	return "roles"
+}
+
+// BeforeCreate hook to generate UUID before creating role
+func (r *Role) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// PermissionNames returns the names of the permissions granted by the role
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// UserRole - A role granted to a user, site-wide or inside one community
+type UserRole struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	RoleID      uuid.UUID  `gorm:"type:uuid;not null"`
+	Role        Role       `gorm:"foreignKey:RoleID"`
+	CommunityID *uuid.UUID `gorm:"type:uuid"` // set only for community-scoped roles
+	GrantedBy   *uuid.UUID `gorm:"type:uuid"`
+
+	CreatedAt time.Time
+}
+
+func (UserRole) TableName() string {
+	return "user_roles"
+}
+
+// BeforeCreate hook to generate UUID before creating user role
+func (r *UserRole) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Session revoke reasons
+const (
+	SessionRevokeLogout        = "logout"
+	SessionRevokeUser          = "revoked_by_user"      // from the session list
+	SessionRevokeTokenReuse    = "refresh_token_reused" // rotated refresh token presented again (likely stolen)
+	SessionRevokeAccountState  = "account_disabled"
+	SessionRevokePasswordReset = "password_reset"
+	SessionRevokeAccountDelete = "account_deleted"
+)
+
+// Session - One signed-in device; holds the hash of its current refresh token
+type Session struct {
+	ID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+
+	// Rotating refresh token (SHA-256 of the secret part, the raw token is never stored)
+	RefreshTokenHash string `gorm:"type:varchar(64);not null"`
+
+	// Device
+	UserAgent  string `gorm:"type:varchar(500)"`
+	IPAddress  string `gorm:"type:varchar(45)"`
+	LastSeenAt time.Time
+
+	// Signed in with a second factor (kept across refreshes, access tokens carry amr "mfa")
+	MFAVerifiedAt *time.Time
+
+	// Lifetime
+	ExpiresAt     time.Time `gorm:"not null"`
+	RevokedAt     *time.Time
+	RevokedReason *string `gorm:"type:varchar(30)"`
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// BeforeCreate hook to generate UUID before creating session
+func (s *Session) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive reports whether the session can still be refreshed
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Subscription statuses
+const (
+	SubscriptionStatusTrial         = "trial"          // free trial, first charge at trial end
+	SubscriptionStatusActive        = "active"         // paid for the current period
+	SubscriptionStatusCancelled     = "cancelled"      // no renewal, access until the period ends
+	SubscriptionStatusPaymentFailed = "payment_failed" // renewal failed, access during the grace period
+	SubscriptionStatusExpired       = "expired"        // no access
+)
+
+// Billing cycles
+const (
+	BillingCycleMonthly = "monthly"
+	BillingCycleYearly  = "yearly"
+)
+
+// Subscription payment statuses
+const (
+	SubscriptionPaymentSuccess  = "success"
+	SubscriptionPaymentFailed   = "failed"
+	SubscriptionPaymentRefunded = "refunded"
+)
+
+// Subscription billing reasons
+const (
+	BillingReasonCreate = "subscription_create" // first charge (or trial conversion)
+	BillingReasonCycle  = "subscription_cycle"  // renewal
+	BillingReasonUpdate = "subscription_update" // prorated upgrade
+)
+
+// SubscriptionTier - Paid tier defined by a creator (e.g. Bronze 99฿/mo, Silver 199฿/mo)
+type SubscriptionTier struct {
+	ID           uuid.UUID      `gorm:"primaryKey;type:uuid"`
+	CreatorID    uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Creator      User           `gorm:"foreignKey:CreatorID"`
+	Name         string         `gorm:"type:varchar(100);not null"`
+	Description  string         `gorm:"type:text"`
+	TierLevel    int            `gorm:"not null"` // 1 = lowest, higher tiers include lower tier content
+	PriceMonthly int64          `gorm:"not null"` // satang
+	PriceYearly  *int64         // satang, nil = 12x monthly
+	Perks        datatypes.JSON `gorm:"type:jsonb"`         // []string shown on the plan card
+	TrialDays    int            `gorm:"not null;default:0"` // 0 = no free trial
+	IsActive     bool           `gorm:"default:true;index"`
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (SubscriptionTier) TableName() string {
+	return "subscription_tiers"
+}
+
+// BeforeCreate hook to generate UUID before creating subscription tier
+func (t *SubscriptionTier) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// Price returns the tier price for a billing cycle (yearly falls back to 12x monthly)
+func (t *SubscriptionTier) Price(billingCycle string) int64 {
+	if billingCycle == BillingCycleYearly {
+		if t.PriceYearly != nil {
+			return *t.PriceYearly
+		}
+		return t.PriceMonthly * 12
+	}
+	return t.PriceMonthly
+}
+
+// Subscription - A subscriber's subscription to a creator (one row per subscriber/creator pair)
+type Subscription struct {
+	ID           uuid.UUID         `gorm:"primaryKey;type:uuid"`
+	SubscriberID uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex:idx_subscriptions_subscriber_creator"`
+	Subscriber   User              `gorm:"foreignKey:SubscriberID"`
+	CreatorID    uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex:idx_subscriptions_subscriber_creator;index"`
+	Creator      User              `gorm:"foreignKey:CreatorID"`
+	TierID       uuid.UUID         `gorm:"type:uuid;not null;index"`
+	Tier         *SubscriptionTier `gorm:"foreignKey:TierID"`
+
+	// Billing (price is locked in at subscribe time - grandfather pricing)
+	Status       string `gorm:"type:varchar(20);not null;index"`
+	BillingCycle string `gorm:"type:varchar(10);not null;default:'monthly'"`
+	Price        int64  `gorm:"not null"` // satang per billing cycle
+
+	// Current period
+	CurrentPeriodStart time.Time `gorm:"not null"`
+	CurrentPeriodEnd   time.Time `gorm:"not null;index"`
+	TrialEnd           *time.Time
+	HasUsedTrial       bool `gorm:"default:false"` // one trial per creator
+
+	// Cancellation
+	CancelAtPeriodEnd bool `gorm:"default:false"`
+	CancelledAt       *time.Time
+	CancelReason      string `gorm:"type:varchar(500)"`
+
+	// Scheduled downgrade, applied at the next renewal
+	PendingTierID *uuid.UUID `gorm:"type:uuid"`
+
+	// Renewal retries
+	FailedPaymentCount int        `gorm:"default:0"`
+	NextRetryAt        *time.Time `gorm:"index"`
+	GraceUntil         *time.Time // access after failed renewal
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Subscription) TableName() string {
+	return "subscriptions"
+}
+
+// BeforeCreate hook to generate UUID before creating subscription
+func (s *Subscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// HasAccess reports whether the subscriber can currently see subscriber-only content
+func (s *Subscription) HasAccess(now time.Time) bool {
+	switch s.Status {
+	case SubscriptionStatusTrial, SubscriptionStatusActive, SubscriptionStatusCancelled:
+		return now.Before(s.CurrentPeriodEnd)
+	case SubscriptionStatusPaymentFailed:
+		return s.GraceUntil != nil && now.Before(*s.GraceUntil)
+	}
+	return false
+}
+
+// SubscriptionPayment - One charge (or refund) of a subscription, paid through the wallet ledger
+type SubscriptionPayment struct {
+	ID             uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	SubscriptionID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	SubscriberID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CreatorID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TierID         uuid.UUID  `gorm:"type:uuid;not null"`
+	Amount         int64      `gorm:"not null"` // satang
+	CreatorAmount  int64      `gorm:"not null"` // satang
+	PlatformFee    int64      `gorm:"not null"` // satang
+	Status         string     `gorm:"type:varchar(20);not null"`
+	BillingReason  string     `gorm:"type:varchar(30);not null"`
+	LedgerTxID     *uuid.UUID `gorm:"type:uuid;uniqueIndex"` // one payment row per ledger transaction
+	FailureReason  string     `gorm:"type:varchar(255)"`
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+
+	// Timestamps
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+func (SubscriptionPayment) TableName() string {
+	return "subscription_payments"
+}
+
+// BeforeCreate hook to generate UUID before creating subscription payment
+func (p *SubscriptionPayment) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Top-up intent statuses
+// pending -> paid -> credited, or pending -> expired (a late payment still moves expired -> paid)
+const (
+	TopUpStatusPending  = "pending"  // QR issued, waiting for the customer to pay
+	TopUpStatusPaid     = "paid"     // gateway confirmed the payment, wallet not credited yet
+	TopUpStatusCredited = "credited" // wallet credited (final)
+	TopUpStatusExpired  = "expired"  // QR expired unpaid
+)
+
+// TopUpIntent is one attempt to add money to a wallet through a payment gateway
+type TopUpIntent struct {
+	ID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+
+	// Amount (satang)
+	Amount   int64  `gorm:"not null"`
+	Currency string `gorm:"type:varchar(3);not null;default:'THB'"`
+
+	// Gateway
+	Provider         string `gorm:"type:varchar(30);not null;uniqueIndex:idx_topup_intents_provider_charge,priority:1"`
+	ProviderChargeID string `gorm:"type:varchar(100);not null;uniqueIndex:idx_topup_intents_provider_charge,priority:2"`
+	QRPayload        string `gorm:"type:text;not null"` // EMVCo PromptPay payload
+
+	// Lifecycle
+	Status     string    `gorm:"type:varchar(20);not null;default:'pending';index"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+	PaidAt     *time.Time
+	CreditedAt *time.Time
+	LedgerTxID *uuid.UUID `gorm:"type:uuid"`
+
+	// Last webhook event applied (audit)
+	ProviderEventID *string `gorm:"type:varchar(100)"`
+
+	// Timestamps
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+func (TopUpIntent) TableName() string {
+	return "topup_intents"
+}
+
+// BeforeCreate hook to generate UUID before creating top-up intent
+func (t *TopUpIntent) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
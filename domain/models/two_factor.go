@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserTOTP - Authenticator app secret of a user (one per user)
+type UserTOTP struct {
+	UserID          uuid.UUID `gorm:"primaryKey;type:uuid"`
+	User            User      `gorm:"foreignKey:UserID"`
+	SecretEncrypted string    `gorm:"type:text;not null"` // AES-GCM, see utils.EncryptSecret
+
+	// Enrollment (nil = setup started but not confirmed with a code)
+	EnabledAt *time.Time
+
+	// Replay protection: codes of this 30s step or older are rejected
+	LastUsedStep int64 `gorm:"not null;default:0"`
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (UserTOTP) TableName() string {
+	return "user_totp"
+}
+
+// IsEnabled reports whether the setup was confirmed
+func (t *UserTOTP) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
+
+// RecoveryCode - One-time backup code for two-factor login (only the hash is stored)
+type RecoveryCode struct {
+	ID       uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	CodeHash string    `gorm:"type:varchar(64);not null"`
+	UsedAt   *time.Time
+
+	CreatedAt time.Time
+}
+
+func (RecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
+
+// BeforeCreate hook to generate UUID before creating recovery code
+func (c *RecoveryCode) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -13,10 +13,8 @@ type User struct {
 	Username string    `gorm:"uniqueIndex;not null"`
 	Password string    // Optional for OAuth users
 
-	// OAuth Fields
-	OAuthProvider string `gorm:"index"` // google, facebook, github, etc.
-	OAuthID       string `gorm:"index"` // OAuth provider's user ID
-	IsOAuthUser   bool   `gorm:"default:false"`
+	// OAuth Fields (external logins are stored as UserIdentity rows)
+	IsOAuthUser bool `gorm:"default:false"` // registered through a login provider
 
 	// Profile Fields
 	DisplayName string `gorm:"not null"`
@@ -25,20 +23,52 @@ type User struct {
 	Location    string
 	Website     string
 
+	// Privacy (followers need approval; posts and follower lists are hidden from everyone else)
+	IsPrivate bool `gorm:"default:false"`
+
+	// Who may start a chat (see ChatPrivacy constants)
+	ChatPrivacy string `gorm:"type:varchar(20);not null;default:'followers'"`
+
 	// Social Stats
 	Karma          int `gorm:"default:0;index"`
 	FollowersCount int `gorm:"default:0"`
 	FollowingCount int `gorm:"default:0"`
 
-	// Status
-	Role     string `gorm:"default:'user'"` // user, admin
-	IsActive bool   `gorm:"default:true"`
+	// Status (staff permissions come from UserRole grants)
+	IsActive bool `gorm:"default:true"`
+
+	// Automated account, flagged by staff (only bot accounts get bot keys)
+	IsBot bool `gorm:"default:false"`
+
+	// Moderation state (derived from active UserSanction rows)
+	SuspendedUntil *time.Time
+	IsBanned       bool `gorm:"default:false"`
+	IsShadowbanned bool `gorm:"default:false"`
+
+	// Email verification (OAuth users are verified by their provider)
+	EmailVerified   bool `gorm:"default:false"`
+	EmailVerifiedAt *time.Time
+
+	// Two-factor authentication (TOTP, asked after the password)
+	TwoFactorEnabled bool `gorm:"default:false"`
+
+	// Account deletion (requested -> anonymized after the cooling-off period, unless cancelled)
+	DeletionScheduledAt     *time.Time
+	DeletionForfeitsBalance bool       `gorm:"default:false"` // consented to give up the wallet balance instead of withdrawing it
+	DeletedAt               *time.Time // anonymized; the row is kept so content and ledgers stay consistent
 
 	// Timestamps
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// Chat privacy: accounts the user follows can always start a chat, this decides for everyone else
+const (
+	ChatPrivacyEveryone  = "everyone"  // straight to the inbox
+	ChatPrivacyFollowers = "followers" // lands in message requests
+	ChatPrivacyNobody    = "nobody"    // refused
+)
+
 func (User) TableName() string {
 	return "users"
 }
@@ -50,3 +80,8 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// IsRestricted reports whether the account is banned or suspended (no logins or writes)
+func (u *User) IsRestricted() bool {
+	return u.IsBanned || (u.SuspendedUntil != nil && u.SuspendedUntil.After(time.Now()))
+}
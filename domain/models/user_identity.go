@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserIdentity - An external login (Google, LINE, Facebook, any OIDC issuer) linked to a user
+// A user can link several providers, but only one identity per provider
+type UserIdentity struct {
+	ID       uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	User     User      `gorm:"foreignKey:UserID"`
+	Provider string    `gorm:"type:varchar(30);not null"`  // registry key, e.g. "google"
+	Subject  string    `gorm:"type:varchar(255);not null"` // stable user ID at the provider
+	Email    string    `gorm:"type:varchar(255)"`          // address reported by the provider when linked
+
+	CreatedAt   time.Time
+	LastLoginAt *time.Time
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// BeforeCreate hook to generate UUID before creating user identity
+func (i *UserIdentity) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Sanction types
+const (
+	SanctionTypeSuspension = "suspension" // no writes or logins until ExpiresAt
+	SanctionTypeBan        = "ban"        // no writes or logins until lifted
+	SanctionTypeShadowban  = "shadowban"  // new posts and comments are only visible to their author
+)
+
+// UserSanction - A moderator action on an account, kept after it is lifted as the audit trail.
+// The users table holds the resulting state (SuspendedUntil, IsBanned, IsShadowbanned).
+type UserSanction struct {
+	ID     uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;index"`
+	User   User      `gorm:"foreignKey:UserID"`
+
+	Type      string     `gorm:"type:varchar(20);not null"`
+	Reason    string     `gorm:"type:varchar(500);not null"`
+	ExpiresAt *time.Time // suspensions only
+
+	// Who applied it
+	IssuedByID uuid.UUID `gorm:"type:uuid;not null"`
+	IssuedBy   User      `gorm:"foreignKey:IssuedByID"`
+
+	// Lifted early by a moderator (nil = still in force until ExpiresAt)
+	LiftedAt   *time.Time
+	LiftedByID *uuid.UUID `gorm:"type:uuid"`
+	LiftReason *string    `gorm:"type:varchar(500)"`
+
+	// Timestamps
+	CreatedAt time.Time
+}
+
+func (UserSanction) TableName() string {
+	return "user_sanctions"
+}
+
+// BeforeCreate hook to generate UUID before creating sanction
+func (s *UserSanction) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive reports whether the sanction is still in force
+func (s *UserSanction) IsActive(now time.Time) bool {
+	return s.LiftedAt == nil && (s.ExpiresAt == nil || s.ExpiresAt.After(now))
+}
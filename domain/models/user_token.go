@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// User token purposes
+const (
+	UserTokenVerifyEmail   = "verify_email"
+	UserTokenResetPassword = "reset_password"
+	UserTokenMFAChallenge  = "mfa_challenge" // password accepted, waiting for the second factor
+)
+
+// UserToken - A single-use token (emailed links, two-factor login challenges)
+// The token itself is signed and never stored; this row makes it single-use and revocable
+type UserToken struct {
+	ID      uuid.UUID `gorm:"primaryKey;type:uuid"`
+	UserID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	User    User      `gorm:"foreignKey:UserID"`
+	Purpose string    `gorm:"type:varchar(20);not null"`
+	Email   string    `gorm:"type:varchar(255);not null"` // address the link was sent to
+
+	// Lifetime
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	Attempts  int `gorm:"not null;default:0"` // wrong codes entered (challenges only)
+
+	CreatedAt time.Time
+}
+
+func (UserToken) TableName() string {
+	return "user_tokens"
+}
+
+// BeforeCreate hook to generate UUID before creating user token
+func (t *UserToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsUsable reports whether the token can still be redeemed
+func (t *UserToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Wallet types
+const (
+	WalletTypeUser   = "user"
+	WalletTypeSystem = "system"
+)
+
+// System wallet codes (platform-owned accounts used as the other side of postings)
+const (
+	SystemWalletTopUpClearing  = "system:topup_clearing"  // Money entering the platform (payment gateway)
+	SystemWalletPayoutClearing = "system:payout_clearing" // Money leaving the platform (bank withdrawals)
+	SystemWalletPlatformFee    = "system:platform_fee"    // Platform revenue (fees, commissions)
+	SystemWalletEscrow         = "system:escrow"          // Funds parked until a feature settles them
+	SystemWalletForfeited      = "system:forfeited"       // Balances given up by deleted accounts
+)
+
+// Wallet holds a balance in satang (1 THB = 100 satang)
+// Balance only changes through balanced ledger postings
+type Wallet struct {
+	ID uuid.UUID `gorm:"primaryKey;type:uuid"`
+
+	// Owner (user wallets) or code (system wallets)
+	UserID *uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	User   *User      `gorm:"foreignKey:UserID"`
+	Code   *string    `gorm:"type:varchar(50);uniqueIndex"`
+	Type   string     `gorm:"type:varchar(20);not null;default:'user';index"` // user, system
+
+	// Balances (satang)
+	Currency   string `gorm:"type:varchar(3);not null;default:'THB'"`
+	Balance    int64  `gorm:"not null;default:0"` // Posted balance
+	HeldAmount int64  `gorm:"not null;default:0"` // Reserved by active holds
+
+	// System clearing wallets may go negative (they mirror external money)
+	AllowNegative bool `gorm:"default:false"`
+
+	// Timestamps
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Wallet) TableName() string {
+	return "wallets"
+}
+
+// BeforeCreate hook to generate UUID before creating wallet
+func (w *Wallet) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// AvailableBalance returns the spendable balance (posted balance minus active holds)
+func (w *Wallet) AvailableBalance() int64 {
+	return w.Balance - w.HeldAmount
+}
+
+// Ledger transaction types
+const (
+	LedgerTxTypeTransfer = "transfer"
+	LedgerTxTypeTopUp    = "topup"
+	LedgerTxTypePayout   = "payout"
+	LedgerTxTypeCapture  = "hold_capture"
+	LedgerTxTypeRefund   = "refund"
+)
+
+// LedgerTransaction groups balanced ledger entries (sum of entry amounts is always zero)
+type LedgerTransaction struct {
+	ID uuid.UUID `gorm:"primaryKey;type:uuid"`
+
+	// Idempotency (same key = same transaction, never applied twice)
+	IdempotencyKey string `gorm:"type:varchar(255);not null;uniqueIndex"`
+
+	Type        string `gorm:"type:varchar(30);not null;index"`
+	Description string `gorm:"type:varchar(500)"`
+
+	// Optional reference to the feature that caused the posting
+	ReferenceType *string    `gorm:"type:varchar(50);index:idx_ledger_tx_reference"` // "post_unlock", "gift", "subscription", ...
+	ReferenceID   *uuid.UUID `gorm:"type:uuid;index:idx_ledger_tx_reference"`
+
+	Entries []LedgerEntry `gorm:"foreignKey:TransactionID"`
+
+	CreatedAt time.Time `gorm:"index"`
+}
+
+func (LedgerTransaction) TableName() string {
+	return "ledger_transactions"
+}
+
+// BeforeCreate hook to generate UUID before creating ledger transaction
+func (t *LedgerTransaction) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// LedgerEntry is one side of a posting (negative = debit, positive = credit)
+type LedgerEntry struct {
+	ID            uuid.UUID          `gorm:"primaryKey;type:uuid"`
+	TransactionID uuid.UUID          `gorm:"type:uuid;not null;index"`
+	Transaction   *LedgerTransaction `gorm:"foreignKey:TransactionID"`
+	WalletID      uuid.UUID          `gorm:"type:uuid;not null;index:idx_ledger_entries_wallet_created,priority:1"`
+
+	Amount       int64 `gorm:"not null"` // satang, signed
+	BalanceAfter int64 `gorm:"not null"` // wallet balance after this entry
+
+	CreatedAt time.Time `gorm:"index:idx_ledger_entries_wallet_created,priority:2"`
+}
+
+func (LedgerEntry) TableName() string {
+	return "ledger_entries"
+}
+
+// BeforeCreate hook to generate UUID before creating ledger entry
+func (e *LedgerEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// Wallet hold statuses
+const (
+	WalletHoldStatusActive   = "active"
+	WalletHoldStatusReleased = "released"
+	WalletHoldStatusCaptured = "captured"
+)
+
+// WalletHold reserves part of a wallet balance until it is released or captured
+type WalletHold struct {
+	ID       uuid.UUID `gorm:"primaryKey;type:uuid"`
+	WalletID uuid.UUID `gorm:"type:uuid;not null;index"`
+
+	Amount int64  `gorm:"not null"` // satang
+	Status string `gorm:"type:varchar(20);not null;default:'active';index"`
+	Reason string `gorm:"type:varchar(255)"`
+
+	// Idempotency (same key = same hold)
+	IdempotencyKey string `gorm:"type:varchar(255);not null;uniqueIndex"`
+
+	ReferenceType *string    `gorm:"type:varchar(50)"`
+	ReferenceID   *uuid.UUID `gorm:"type:uuid"`
+
+	// Ledger transaction created when the hold is captured
+	CaptureTransactionID *uuid.UUID `gorm:"type:uuid"`
+
+	ExpiresAt  *time.Time `gorm:"index"`
+	ResolvedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (WalletHold) TableName() string {
+	return "wallet_holds"
+}
+
+// BeforeCreate hook to generate UUID before creating wallet hold
+func (h *WalletHold) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// AccountDataRepository stores personal data exports and runs the account anonymization
+type AccountDataRepository interface {
+	// Data exports
+	CreateExport(ctx context.Context, export *models.DataExport) error
+	GetLatestExport(ctx context.Context, userID uuid.UUID) (*models.DataExport, error)
+	ListExportsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*models.DataExport, error)
+	ListPendingExports(ctx context.Context, limit int) ([]*models.DataExport, error) // oldest first
+	MarkExportProcessing(ctx context.Context, id uuid.UUID) (bool, error)            // false = claimed by another worker
+	MarkExportReady(ctx context.Context, id uuid.UUID, fileKey string, fileSize int64, completedAt, expiresAt time.Time) error
+	MarkExportFailed(ctx context.Context, id uuid.UUID, reason string) error
+	ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]*models.DataExport, error)
+	MarkExportExpired(ctx context.Context, id uuid.UUID) error
+
+	// Export readers not covered by the content repositories (sent and received, oldest first)
+	ListMessagesByParticipant(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.Message, error)
+
+	// Account deletion
+	ScheduleDeletion(ctx context.Context, userID uuid.UUID, scheduledAt *time.Time, forfeitBalance bool) error // nil cancels
+	ListDueDeletions(ctx context.Context, now time.Time, limit int) ([]*models.User, error)
+	ListRemovableMedia(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.Media, error) // skips ad creatives and payment slips
+	// AnonymizeUser scrubs the profile, removes the user's content and relationships, ends their
+	// subscriptions and unlock campaigns and repairs the counters they contributed to, in one transaction
+	// (the wallet must be emptied beforehand; media files are deleted by the caller after it commits)
+	AnonymizeUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// AdDailyCount - Impressions and clicks of an ad on one day (Thai time)
+type AdDailyCount struct {
+	Date        time.Time
+	Impressions int64
+	Clicks      int64
+}
+
+type AdRepository interface {
+	// Placements
+	ListPlacements(ctx context.Context, activeOnly bool) ([]*models.AdPlacement, error)
+	GetPlacementByCode(ctx context.Context, code string) (*models.AdPlacement, error)
+	UpdatePlacement(ctx context.Context, placement *models.AdPlacement) error
+
+	// Ads
+	Create(ctx context.Context, ad *models.Ad) error
+	Update(ctx context.Context, ad *models.Ad) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Ad, error)
+	ListByAdvertiser(ctx context.Context, advertiserID uuid.UUID, status string, offset, limit int) ([]*models.Ad, error) // status "" = all
+	CountByAdvertiser(ctx context.Context, advertiserID uuid.UUID, status string) (int64, error)
+	CountPaidByAdvertiser(ctx context.Context, advertiserID uuid.UUID) (int64, error) // ads with a verified payment (first-time discount)
+
+	// Capacity (scheduled, active or paused ads of a placement overlapping the period)
+	CountBookedInPeriod(ctx context.Context, placementID uuid.UUID, startsAt, endsAt time.Time, excludeAdID *uuid.UUID) (int64, error)
+
+	// Serving
+	ListServable(ctx context.Context, placementID uuid.UUID, now time.Time) ([]*models.Ad, error)
+	GetFrequencyCappedAdIDs(ctx context.Context, userID uuid.UUID, since time.Time, maxImpressions int) ([]uuid.UUID, error)
+
+	// Payments
+	CreatePayment(ctx context.Context, payment *models.AdPayment) error
+	UpdatePayment(ctx context.Context, payment *models.AdPayment) error
+	GetPaymentByID(ctx context.Context, id uuid.UUID) (*models.AdPayment, error)
+	GetLatestPayment(ctx context.Context, adID uuid.UUID) (*models.AdPayment, error)
+	ListPaymentsByStatus(ctx context.Context, status string, offset, limit int) ([]*models.AdPayment, error) // oldest first
+	CountPaymentsByStatus(ctx context.Context, status string) (int64, error)
+
+	// Tracking (counters on the ad are incremented in the same transaction)
+	CreateImpression(ctx context.Context, impression *models.AdImpression) error
+	GetImpressionByID(ctx context.Context, id uuid.UUID) (*models.AdImpression, error)
+	CreateClick(ctx context.Context, click *models.AdClick) error
+	CountReach(ctx context.Context, adID uuid.UUID) (int64, error)
+	GetDailyCounts(ctx context.Context, adID uuid.UUID) ([]*AdDailyCount, error)
+
+	// Scheduler
+	ListDueForActivation(ctx context.Context, now time.Time, limit int) ([]*models.Ad, error)
+	ListDueForCompletion(ctx context.Context, now time.Time, limit int) ([]*models.Ad, error)
+}
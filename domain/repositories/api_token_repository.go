@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type APITokenRepository interface {
+	Create(ctx context.Context, token *models.APIToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.APIToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*models.APIToken, error)    // preloads User
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.APIToken, error) // not revoked, newest first
+	CountActiveByUser(ctx context.Context, userID uuid.UUID, kind string) (int64, error)
+
+	Revoke(ctx context.Context, id uuid.UUID) (bool, error) // false = already revoked
+	// TouchLastUsed records use at most once per interval (avoids a write on every request)
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time, ip string, interval time.Duration) error
+}
@@ -21,28 +21,33 @@ type CommentRepository interface {
 	Create(ctx context.Context, comment *models.Comment) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Comment, error)
 	Update(ctx context.Context, id uuid.UUID, comment *models.Comment) error
-	Delete(ctx context.Context, id uuid.UUID) error // Soft delete
+	Delete(ctx context.Context, id uuid.UUID) error                                       // Soft delete
+	Remove(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID, reason string) error // Soft delete by a moderator
 
 	// List & Filter (offset-based, deprecated)
-	ListByPost(ctx context.Context, postID uuid.UUID, offset, limit int, sortBy CommentSortBy) ([]*models.Comment, error)
-	ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int) ([]*models.Comment, error)
-	ListReplies(ctx context.Context, parentID uuid.UUID, offset, limit int, sortBy CommentSortBy) ([]*models.Comment, error)
+	// viewerID (nil = guest) decides whether shadowed comments are visible (only to their author)
+	ListByPost(ctx context.Context, postID uuid.UUID, offset, limit int, sortBy CommentSortBy, viewerID *uuid.UUID) ([]*models.Comment, error)
+	ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int, viewerID *uuid.UUID) ([]*models.Comment, error)
+	ListReplies(ctx context.Context, parentID uuid.UUID, offset, limit int, sortBy CommentSortBy, viewerID *uuid.UUID) ([]*models.Comment, error)
 
 	// List with Cursor (cursor-based pagination)
-	ListByPostWithCursor(ctx context.Context, postID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy CommentSortBy) ([]*models.Comment, error)
-	ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int) ([]*models.Comment, error)
-	ListRepliesWithCursor(ctx context.Context, parentID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy CommentSortBy) ([]*models.Comment, error)
+	ListByPostWithCursor(ctx context.Context, postID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy CommentSortBy, viewerID *uuid.UUID) ([]*models.Comment, error)
+	ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int, viewerID *uuid.UUID) ([]*models.Comment, error)
+	ListRepliesWithCursor(ctx context.Context, parentID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy CommentSortBy, viewerID *uuid.UUID) ([]*models.Comment, error)
 
 	// Tree structure
-	GetCommentTree(ctx context.Context, postID uuid.UUID, maxDepth int) ([]*models.Comment, error)
+	GetCommentTree(ctx context.Context, postID uuid.UUID, maxDepth int, viewerID *uuid.UUID) ([]*models.Comment, error)
 	GetParentChain(ctx context.Context, commentID uuid.UUID) ([]*models.Comment, error)
 
 	// Stats
 	Count(ctx context.Context) (int64, error)
-	CountByPost(ctx context.Context, postID uuid.UUID) (int64, error)
-	CountByAuthor(ctx context.Context, authorID uuid.UUID) (int64, error)
-	CountReplies(ctx context.Context, parentID uuid.UUID) (int64, error)
+	CountByPost(ctx context.Context, postID uuid.UUID, viewerID *uuid.UUID) (int64, error)
+	CountByAuthor(ctx context.Context, authorID uuid.UUID, viewerID *uuid.UUID) (int64, error)
+	CountReplies(ctx context.Context, parentID uuid.UUID, viewerID *uuid.UUID) (int64, error)
 
 	// Vote management
 	UpdateVoteCount(ctx context.Context, commentID uuid.UUID, voteChange int) error
+
+	// Shadowbans (comments written while shadowbanned are only visible to their author)
+	SetShadowedByAuthor(ctx context.Context, authorID uuid.UUID, shadowed bool) error
 }
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type CommunityRepository interface {
+	// Communities
+	Create(ctx context.Context, community *models.Community, owner *models.CommunityMember) error // community and owner membership in one transaction
+	Update(ctx context.Context, community *models.Community) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Community, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Community, error)
+	ExistsBySlug(ctx context.Context, slug string) (bool, error)
+
+	// Discovery (private communities are excluded), most members first
+	List(ctx context.Context, query string, offset, limit int) ([]*models.Community, error)
+	Count(ctx context.Context, query string) (int64, error)
+	ListByMember(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.Community, error) // active memberships
+	CountByMember(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// Members
+	GetMember(ctx context.Context, communityID, userID uuid.UUID) (*models.CommunityMember, error)
+	CreateMember(ctx context.Context, member *models.CommunityMember) error
+	UpdateMember(ctx context.Context, member *models.CommunityMember) error
+	DeleteMember(ctx context.Context, communityID, userID uuid.UUID) error
+	ListMembers(ctx context.Context, communityID uuid.UUID, status string, roles []string, offset, limit int) ([]*models.CommunityMember, error) // owner and moderators first
+	CountMembers(ctx context.Context, communityID uuid.UUID, status string, roles []string) (int64, error)
+
+	// Stats
+	SyncMemberCount(ctx context.Context, communityID uuid.UUID) error // recount active members
+	IncrementPostCount(ctx context.Context, communityID uuid.UUID, delta int) error
+}
@@ -10,28 +10,57 @@ import (
 type ConversationRepository interface {
 	// Basic CRUD
 	Create(ctx context.Context, conversation *models.Conversation) error
-	GetByID(ctx context.Context, id uuid.UUID) (*models.Conversation, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Conversation, error) // preloads participants with their users
 	Update(ctx context.Context, id uuid.UUID, conversation *models.Conversation) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	// Get or Create conversation between two users
-	GetOrCreateByUsers(ctx context.Context, user1ID, user2ID uuid.UUID) (*models.Conversation, bool, error) // returns conversation, isNew, error
+	// Get or Create conversation between two users (a new one is a message request when requestedByID is set)
+	GetOrCreateByUsers(ctx context.Context, user1ID, user2ID uuid.UUID, requestedByID *uuid.UUID) (*models.Conversation, bool, error) // returns conversation, isNew, error
 
 	// Get conversation by participants
 	GetByUsers(ctx context.Context, user1ID, user2ID uuid.UUID) (*models.Conversation, error)
 
-	// List conversations for a user (cursor-based pagination)
+	// Create a group conversation together with its participants
+	CreateGroup(ctx context.Context, conversation *models.Conversation, participants []*models.ConversationParticipant) error
+
+	// List conversations for a user (cursor-based pagination, message requests the user received are left out)
 	ListByUser(ctx context.Context, userID uuid.UUID, cursor *time.Time, limit int) ([]*models.Conversation, error)
 
+	// Message requests the user received (only those with a message)
+	ListRequests(ctx context.Context, userID uuid.UUID, cursor *time.Time, limit int) ([]*models.Conversation, error)
+	CountRequests(ctx context.Context, userID uuid.UUID) (int64, error)
+	AcceptRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) (bool, error) // false when there was nothing to accept
+
+	// Participants
+	GetParticipant(ctx context.Context, conversationID, userID uuid.UUID) (*models.ConversationParticipant, error)
+	ListParticipantIDs(ctx context.Context, conversationID uuid.UUID) ([]uuid.UUID, error)
+	CountParticipants(ctx context.Context, conversationID uuid.UUID) (int64, error)
+	AddParticipants(ctx context.Context, participants []*models.ConversationParticipant) error // existing members are skipped
+	RemoveParticipant(ctx context.Context, conversationID, userID uuid.UUID) (bool, error)
+	UpdateParticipantRole(ctx context.Context, conversationID, userID uuid.UUID, role string) (bool, error)
+	// NextOwnerCandidate returns the longest-standing admin, or member when there is none
+	NextOwnerCandidate(ctx context.Context, conversationID uuid.UUID) (*models.ConversationParticipant, error)
+
 	// Unread count
 	GetTotalUnreadCount(ctx context.Context, userID uuid.UUID) (int, error)
 
-	// Mark as read
+	// Mark as read (resets the unread counter and moves the read pointer to the last message)
 	ResetUnreadCount(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
 
+	// Receipts: move the pointers forward only (false when they were already there);
+	// marking read also recomputes the unread counter
+	MarkDeliveredUpTo(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, seq int64) (bool, error)
+	MarkReadUpTo(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, seq int64) (bool, error)
+
+	// Event log for sync (AppendEvent sets the event's Seq)
+	AppendEvent(ctx context.Context, event *models.ConversationEvent) error
+	ListEvents(ctx context.Context, conversationID uuid.UUID, afterSeq int64, limit int) ([]*models.ConversationEvent, error)
+
 	// Update conversation metadata
 	UpdateLastMessage(ctx context.Context, conversationID uuid.UUID, messageID uuid.UUID, timestamp time.Time) error
-	IncrementUnreadCount(ctx context.Context, conversationID uuid.UUID, receiverID uuid.UUID) error
+	IncrementUnreadCounts(ctx context.Context, conversationID uuid.UUID, senderID uuid.UUID) error // every participant but the sender
+	// Unsent message: participants who had not read up to seq get one unread less (their IDs are returned)
+	DecrementUnreadCounts(ctx context.Context, conversationID uuid.UUID, senderID uuid.UUID, seq int64) ([]uuid.UUID, error)
 
 	// Stats
 	Count(ctx context.Context) (int64, error)
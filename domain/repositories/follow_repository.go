@@ -36,4 +36,11 @@ type FollowRepository interface {
 	// Update user counts (followers_count, following_count in users table)
 	UpdateFollowerCount(ctx context.Context, userID uuid.UUID, delta int) error
 	UpdateFollowingCount(ctx context.Context, userID uuid.UUID, delta int) error
+
+	// Follow requests (private accounts)
+	CreateFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) error
+	DeleteFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) (bool, error) // false = no pending request
+	HasFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) (bool, error)
+	ListFollowRequests(ctx context.Context, targetID uuid.UUID, offset, limit int) ([]*models.FollowRequest, error)
+	CountFollowRequests(ctx context.Context, targetID uuid.UUID) (int64, error)
 }
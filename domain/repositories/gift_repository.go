@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type GiftRepository interface {
+	// Catalog
+	ListActivePackages(ctx context.Context) ([]*models.GiftPackage, error)
+	GetPackageByID(ctx context.Context, id uuid.UUID) (*models.GiftPackage, error)
+
+	// CreateWithMessage inserts the gift message and its gift record in one transaction
+	CreateWithMessage(ctx context.Context, message *models.Message, gift *models.Gift) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Gift, error)
+	GetByMessageID(ctx context.Context, messageID uuid.UUID) (*models.Gift, error)
+
+	// Spam prevention
+	CountSentSince(ctx context.Context, senderID uuid.UUID, since time.Time) (int64, error)
+
+	// Status transitions (conditional, return false if the gift was not in the expected status)
+	UpdateStatus(ctx context.Context, id uuid.UUID, fromStatus, toStatus string) (bool, error)
+	MarkSettled(ctx context.Context, id uuid.UUID) error
+
+	// Scheduler
+	ListExpiredPending(ctx context.Context, now time.Time, limit int) ([]*models.Gift, error)
+	ListUnsettled(ctx context.Context, limit int) ([]*models.Gift, error) // opened/expired/refunded gifts not paid out yet
+}
@@ -10,10 +10,26 @@ import (
 type MessageRepository interface {
 	// Basic CRUD
 	Create(ctx context.Context, message *models.Message) error
+	// CreateFirstMessage inserts the message only while the conversation has none (false = it already has one);
+	// the conversation row is locked so concurrent first messages cannot both get in
+	CreateFirstMessage(ctx context.Context, message *models.Message) (bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Message, error)
 	Update(ctx context.Context, id uuid.UUID, message *models.Message) error
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// Moderation: clear content, media and edit history but keep the row (reports whether anything changed)
+	Tombstone(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// Edit history (the revision keeps the text being replaced)
+	UpdateContentWithRevision(ctx context.Context, message *models.Message, revision *models.MessageRevision) error
+	ListRevisions(ctx context.Context, messageID uuid.UUID) ([]*models.MessageRevision, error)
+	GetLatestByConversation(ctx context.Context, conversationID uuid.UUID) (*models.Message, error)
+
+	// Reactions (add/remove report whether anything changed)
+	AddReaction(ctx context.Context, reaction *models.MessageReaction) (bool, error)
+	RemoveReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) (bool, error)
+	ListReactions(ctx context.Context, messageID uuid.UUID) ([]models.MessageReaction, error)
+
 	// List messages (cursor-based pagination)
 	// Cursor is based on created_at timestamp
 	ListByConversation(ctx context.Context, conversationID uuid.UUID, beforeCursor *time.Time, limit int) ([]*models.Message, error)
@@ -22,9 +38,17 @@ type MessageRepository interface {
 	GetMessagesBeforeTimestamp(ctx context.Context, conversationID uuid.UUID, timestamp time.Time, limit int) ([]*models.Message, error)
 	GetMessagesAfterTimestamp(ctx context.Context, conversationID uuid.UUID, timestamp time.Time, limit int) ([]*models.Message, error)
 
+	// Text search (newest first, cursor is the created_at and id of the last result; gift messages are never matched)
+	SearchInConversation(ctx context.Context, conversationID uuid.UUID, query string, beforeCursor *time.Time, beforeID uuid.UUID, limit int) ([]*models.Message, error)
+	SearchByParticipant(ctx context.Context, userID uuid.UUID, query string, beforeCursor *time.Time, beforeID uuid.UUID, limit int) ([]*models.Message, error)
+
 	// Mark messages as read
 	MarkAsRead(ctx context.Context, messageID uuid.UUID) error
 	MarkAllAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
+	MarkAsReadUpTo(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, seq int64) error
+
+	// Sync replay
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Message, error)
 
 	// Stats
 	Count(ctx context.Context) (int64, error)
@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"gofiber-template/domain/models"
+	"gofiber-template/pkg/utils"
 )
 
 // MockFollowRepository is a mock implementation of FollowRepository
@@ -79,3 +80,47 @@ func (m *MockFollowRepository) UpdateFollowingCount(ctx context.Context, userID
 	args := m.Called(ctx, userID, delta)
 	return args.Error(0)
 }
+
+func (m *MockFollowRepository) CreateFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) error {
+	args := m.Called(ctx, requesterID, targetID)
+	return args.Error(0)
+}
+
+func (m *MockFollowRepository) DeleteFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, requesterID, targetID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowRepository) HasFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, requesterID, targetID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowRepository) ListFollowRequests(ctx context.Context, targetID uuid.UUID, offset, limit int) ([]*models.FollowRequest, error) {
+	args := m.Called(ctx, targetID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.FollowRequest), args.Error(1)
+}
+
+func (m *MockFollowRepository) CountFollowRequests(ctx context.Context, targetID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, targetID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowersWithCursor(ctx context.Context, userID uuid.UUID, cursor *utils.PostCursor, limit int) ([]*models.User, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
+
+func (m *MockFollowRepository) GetFollowingWithCursor(ctx context.Context, userID uuid.UUID, cursor *utils.PostCursor, limit int) ([]*models.User, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.User), args.Error(1)
+}
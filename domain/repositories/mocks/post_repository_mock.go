@@ -48,6 +48,11 @@ func (m *MockPostRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockPostRepository) Remove(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID, reason string) error {
+	args := m.Called(ctx, id, moderatorID, reason)
+	return args.Error(0)
+}
+
 func (m *MockPostRepository) GetAll(ctx context.Context, limit, offset int) ([]*models.Post, int64, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
@@ -80,8 +85,8 @@ func (m *MockPostRepository) GetPopularPosts(ctx context.Context, duration time.
 	return args.Get(0).([]*models.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) Search(ctx context.Context, query string, offset, limit int) ([]*models.Post, error) {
-	args := m.Called(ctx, query, offset, limit)
+func (m *MockPostRepository) Search(ctx context.Context, query string, offset, limit int, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, query, offset, limit, viewerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -127,32 +132,40 @@ func (m *MockPostRepository) GetFeed(ctx context.Context, userID uuid.UUID, limi
 	return args.Get(0).([]*models.Post), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockPostRepository) List(ctx context.Context, offset, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
-	args := m.Called(ctx, offset, limit, sortBy)
+func (m *MockPostRepository) List(ctx context.Context, offset, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, offset, limit, sortBy, viewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, authorID, offset, limit, viewerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int) ([]*models.Post, error) {
-	args := m.Called(ctx, authorID, offset, limit)
+func (m *MockPostRepository) ListByTag(ctx context.Context, tagName string, offset, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, tagName, offset, limit, sortBy, viewerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) ListByTag(ctx context.Context, tagName string, offset, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
-	args := m.Called(ctx, tagName, offset, limit, sortBy)
+func (m *MockPostRepository) ListByTagID(ctx context.Context, tagID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, tagID, offset, limit, sortBy, viewerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) ListByTagID(ctx context.Context, tagID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
-	args := m.Called(ctx, tagID, offset, limit, sortBy)
+func (m *MockPostRepository) ListByCommunity(ctx context.Context, communityID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, communityID, offset, limit, sortBy, viewerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -172,11 +185,16 @@ func (m *MockPostRepository) Count(ctx context.Context) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockPostRepository) CountByAuthor(ctx context.Context, authorID uuid.UUID) (int64, error) {
-	args := m.Called(ctx, authorID)
+func (m *MockPostRepository) CountByAuthor(ctx context.Context, authorID uuid.UUID, viewerID *uuid.UUID) (int64, error) {
+	args := m.Called(ctx, authorID, viewerID)
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockPostRepository) SetShadowedByAuthor(ctx context.Context, authorID uuid.UUID, shadowed bool) error {
+	args := m.Called(ctx, authorID, shadowed)
+	return args.Error(0)
+}
+
 func (m *MockPostRepository) IncrementCommentCount(ctx context.Context, postID uuid.UUID) error {
 	args := m.Called(ctx, postID)
 	return args.Error(0)
@@ -216,24 +234,32 @@ func (m *MockPostRepository) SyncTags(ctx context.Context, postID uuid.UUID, tag
 }
 
 // Cursor-based pagination methods
-func (m *MockPostRepository) ListWithCursor(ctx context.Context, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
-	args := m.Called(ctx, cursor, limit, sortBy)
+func (m *MockPostRepository) ListWithCursor(ctx context.Context, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, cursor, limit, sortBy, viewerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int) ([]*models.Post, error) {
-	args := m.Called(ctx, authorID, cursor, limit)
+func (m *MockPostRepository) ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, authorID, cursor, limit, viewerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*models.Post), args.Error(1)
 }
 
-func (m *MockPostRepository) ListByTagWithCursor(ctx context.Context, tagName string, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
-	args := m.Called(ctx, tagName, cursor, limit, sortBy)
+func (m *MockPostRepository) ListByTagWithCursor(ctx context.Context, tagName string, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, tagName, cursor, limit, sortBy, viewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) ListByCommunityWithCursor(ctx context.Context, communityID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, communityID, cursor, limit, sortBy, viewerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -247,3 +273,11 @@ func (m *MockPostRepository) ListFollowingFeedWithCursor(ctx context.Context, us
 	}
 	return args.Get(0).([]*models.Post), args.Error(1)
 }
+
+func (m *MockPostRepository) SearchWithCursor(ctx context.Context, query string, cursor *utils.PostCursor, limit int, viewerID *uuid.UUID) ([]*models.Post, error) {
+	args := m.Called(ctx, query, cursor, limit, viewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Post), args.Error(1)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"gofiber-template/domain/models"
+)
+
+// MockSessionRepository is a mock implementation of SessionRepository
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) Create(ctx context.Context, session *models.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) Rotate(ctx context.Context, session *models.Session, oldHash string) (bool, error) {
+	args := m.Called(ctx, session, oldHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSessionRepository) Revoke(ctx context.Context, id uuid.UUID, reason string) error {
+	args := m.Called(ctx, id, reason)
+	return args.Error(0)
+}
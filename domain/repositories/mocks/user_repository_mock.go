@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -42,19 +43,31 @@ func (m *MockUserRepository) GetByUsername(ctx context.Context, username string)
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
-func (m *MockUserRepository) GetByOAuth(ctx context.Context, provider, oauthID string) (*models.User, error) {
-	args := m.Called(ctx, provider, oauthID)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*models.User), args.Error(1)
-}
-
 func (m *MockUserRepository) Update(ctx context.Context, id uuid.UUID, user *models.User) error {
 	args := m.Called(ctx, id, user)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) SetActive(ctx context.Context, id uuid.UUID, isActive bool) error {
+	args := m.Called(ctx, id, isActive)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetPrivate(ctx context.Context, id uuid.UUID, isPrivate bool) error {
+	args := m.Called(ctx, id, isPrivate)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetBot(ctx context.Context, id uuid.UUID, isBot bool) error {
+	args := m.Called(ctx, id, isBot)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetModerationStatus(ctx context.Context, id uuid.UUID, suspendedUntil *time.Time, isBanned, isShadowbanned bool) error {
+	args := m.Called(ctx, id, suspendedUntil, isBanned, isShadowbanned)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
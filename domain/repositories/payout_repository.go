@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type PayoutRepository interface {
+	Create(ctx context.Context, payout *models.Payout) error
+	Update(ctx context.Context, payout *models.Payout) error
+	UpdateStatus(ctx context.Context, payout *models.Payout, fromStatus string) (bool, error) // false when the payout already left fromStatus
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Payout, error)
+	GetPendingByUser(ctx context.Context, userID uuid.UUID) (*models.Payout, error)
+	ListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.Payout, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// Admin queue
+	ListByStatus(ctx context.Context, status string, offset, limit int) ([]*models.Payout, error) // oldest first
+	CountByStatus(ctx context.Context, status string) (int64, error)
+
+	// Withdrawable balance
+	SumEarnings(ctx context.Context, walletID uuid.UUID) (int64, error) // credits other than top-ups and refunds
+	SumWithdrawn(ctx context.Context, userID uuid.UUID) (int64, error)  // pending, approved and paid payouts
+}
@@ -22,31 +22,38 @@ type PostRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.Post, error)
 	GetByClientPostID(ctx context.Context, clientPostID string) (*models.Post, error) // For idempotency check
 	Update(ctx context.Context, id uuid.UUID, post *models.Post) error
-	Delete(ctx context.Context, id uuid.UUID) error // Soft delete
+	Delete(ctx context.Context, id uuid.UUID) error                                       // Soft delete
+	Remove(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID, reason string) error // Soft delete by a moderator
 
 	// List & Filter (offset-based, deprecated)
-	List(ctx context.Context, offset, limit int, sortBy PostSortBy) ([]*models.Post, error)
-	ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int) ([]*models.Post, error)
-	ListByTag(ctx context.Context, tagName string, offset, limit int, sortBy PostSortBy) ([]*models.Post, error)
-	ListByTagID(ctx context.Context, tagID uuid.UUID, offset, limit int, sortBy PostSortBy) ([]*models.Post, error)
+	// viewerID (nil = guest) decides which subscriber-only and shadowed posts are visible
+	List(ctx context.Context, offset, limit int, sortBy PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error)
+	ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int, viewerID *uuid.UUID) ([]*models.Post, error)
+	ListByTag(ctx context.Context, tagName string, offset, limit int, sortBy PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error)
+	ListByTagID(ctx context.Context, tagID uuid.UUID, offset, limit int, sortBy PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error)
+	ListByCommunity(ctx context.Context, communityID uuid.UUID, offset, limit int, sortBy PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error)
 
 	// List with Cursor (cursor-based pagination)
-	ListWithCursor(ctx context.Context, cursor *utils.PostCursor, limit int, sortBy PostSortBy) ([]*models.Post, error)
-	ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int) ([]*models.Post, error)
-	ListByTagWithCursor(ctx context.Context, tagName string, cursor *utils.PostCursor, limit int, sortBy PostSortBy) ([]*models.Post, error)
+	ListWithCursor(ctx context.Context, cursor *utils.PostCursor, limit int, sortBy PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error)
+	ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int, viewerID *uuid.UUID) ([]*models.Post, error)
+	ListByTagWithCursor(ctx context.Context, tagName string, cursor *utils.PostCursor, limit int, sortBy PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error)
+	ListByCommunityWithCursor(ctx context.Context, communityID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error)
 	ListFollowingFeedWithCursor(ctx context.Context, userID uuid.UUID, cursor *utils.PostCursor, limit int) ([]*models.Post, error)
 
 	// Search (offset-based, deprecated)
-	Search(ctx context.Context, query string, offset, limit int) ([]*models.Post, error)
+	Search(ctx context.Context, query string, offset, limit int, viewerID *uuid.UUID) ([]*models.Post, error)
 	// Search with cursor (recommended)
-	SearchWithCursor(ctx context.Context, query string, cursor *utils.PostCursor, limit int) ([]*models.Post, error)
+	SearchWithCursor(ctx context.Context, query string, cursor *utils.PostCursor, limit int, viewerID *uuid.UUID) ([]*models.Post, error)
 
 	// Crosspost
 	GetCrossposts(ctx context.Context, postID uuid.UUID, offset, limit int) ([]*models.Post, error)
 
 	// Stats
 	Count(ctx context.Context) (int64, error)
-	CountByAuthor(ctx context.Context, authorID uuid.UUID) (int64, error)
+	CountByAuthor(ctx context.Context, authorID uuid.UUID, viewerID *uuid.UUID) (int64, error)
+
+	// Shadowbans (posts written while shadowbanned are only visible to their author)
+	SetShadowedByAuthor(ctx context.Context, authorID uuid.UUID, shadowed bool) error
 
 	// Comment count management
 	IncrementCommentCount(ctx context.Context, postID uuid.UUID) error
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// UnlockContributorTotal - Aggregated contributions of one user towards a post
+type UnlockContributorTotal struct {
+	UserID            uuid.UUID
+	TotalAmount       int64
+	ContributionCount int
+	FirstContributed  time.Time
+}
+
+type PostUnlockRepository interface {
+	// Campaign row lock (SELECT ... FOR UPDATE), joins the transaction carried by ctx
+	GetPostForUpdate(ctx context.Context, postID uuid.UUID) (*models.Post, error)
+
+	// Contributions
+	// CreateContribution inserts the contribution and bumps the post's unlock counters atomically,
+	// returning the post with refreshed counters
+	CreateContribution(ctx context.Context, contribution *models.PostUnlockContribution) (*models.Post, error)
+	GetContributionByIdempotencyKey(ctx context.Context, key string) (*models.PostUnlockContribution, error)
+	UpdateContributionStatus(ctx context.Context, id uuid.UUID, status string) error
+	ListContributionsByStatus(ctx context.Context, postID uuid.UUID, status string) ([]*models.PostUnlockContribution, error)
+	CountUserContributions(ctx context.Context, postID, userID uuid.UUID) (int64, error)
+
+	// Access checks
+	HasContributed(ctx context.Context, postID, userID uuid.UUID) (bool, error)
+	GetContributedPostIDs(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+
+	// Contributor list (largest contributors first)
+	ListContributors(ctx context.Context, postID uuid.UUID, offset, limit int) ([]*UnlockContributorTotal, error)
+	GetContributorTotal(ctx context.Context, postID, userID uuid.UUID) (*UnlockContributorTotal, error) // zero totals if none
+
+	// Teaser
+	SetPreviewURL(ctx context.Context, postID uuid.UUID, previewURL string) error
+
+	// Status transitions (conditional, return false if the post was not locked anymore)
+	MarkUnlocked(ctx context.Context, postID uuid.UUID) (bool, error)
+	MarkFailed(ctx context.Context, postID uuid.UUID) (bool, error)
+
+	// Scheduler
+	ListExpiredLockedPosts(ctx context.Context, now time.Time, limit int) ([]*models.Post, error)
+	ListUnsettledPostIDs(ctx context.Context, limit int) ([]uuid.UUID, error) // unlocked/failed posts with held contributions
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type ReelRepository interface {
+	Create(ctx context.Context, reel *models.Reel) error
+	Update(ctx context.Context, reel *models.Reel) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Reel, error)
+	GetByMediaID(ctx context.Context, mediaID uuid.UUID) (*models.Reel, error)
+
+	// Viewable reels (active, not expired), oldest first
+	ListActiveByCreator(ctx context.Context, creatorID uuid.UUID, now time.Time) ([]*models.Reel, error)
+	ListActiveFromFollowing(ctx context.Context, userID uuid.UUID, now time.Time, limit int) ([]*models.Reel, error)
+
+	// Creator's own reels that are not expired yet (including processing/failed)
+	ListCurrentByCreator(ctx context.Context, creatorID uuid.UUID, now time.Time) ([]*models.Reel, error)
+
+	// Rate limiting
+	CountCreatedSince(ctx context.Context, creatorID uuid.UUID, since time.Time) (int64, error)
+
+	// Views
+	RecordView(ctx context.Context, view *models.ReelView) (bool, error) // returns true for the first view of this viewer
+	GetViewedReelIDs(ctx context.Context, viewerID uuid.UUID, reelIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	ListViewers(ctx context.Context, reelID uuid.UUID, offset, limit int) ([]*models.ReelView, error)
+	DeleteViews(ctx context.Context, reelID uuid.UUID) error
+
+	// Scheduler (viewable or processing reels past expires_at)
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.Reel, error)
+}
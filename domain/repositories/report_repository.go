@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type ReportRepository interface {
+	Create(ctx context.Context, report *models.Report) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Report, error)
+	HasOpenReport(ctx context.Context, reporterID uuid.UUID, targetType string, targetID uuid.UUID) (bool, error)
+	ListByReporter(ctx context.Context, reporterID uuid.UUID, offset, limit int) ([]*models.Report, error)
+	CountByReporter(ctx context.Context, reporterID uuid.UUID) (int64, error)
+
+	// Moderation queue
+	ListByStatus(ctx context.Context, status, targetType string, offset, limit int) ([]*models.Report, error) // oldest first, targetType "" = all
+	CountByStatus(ctx context.Context, status, targetType string) (int64, error)
+	ListOpenByTarget(ctx context.Context, targetType string, targetID uuid.UUID) ([]*models.Report, error)
+	CountOpenByTarget(ctx context.Context, targetType string, targetID uuid.UUID) (int64, error)
+
+	// ResolveOpenByTarget closes every open report on a target with the same outcome
+	ResolveOpenByTarget(ctx context.Context, targetType string, targetID uuid.UUID, status, action string, moderatorID uuid.UUID, note *string) error
+}
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type RoleRepository interface {
+	// Roles and permissions (with their permissions preloaded)
+	ListRoles(ctx context.Context) ([]*models.Role, error)
+	GetRoleByName(ctx context.Context, name string) (*models.Role, error)
+	ListPermissions(ctx context.Context) ([]*models.Permission, error)
+
+	// Grants
+	ListUserRoles(ctx context.Context, userID uuid.UUID) ([]*models.UserRole, error)
+	// AssignRole grants the role (false = the user already had it)
+	AssignRole(ctx context.Context, userRole *models.UserRole) (bool, error)
+	// RevokeRole removes a grant (false = the user did not have it)
+	RevokeRole(ctx context.Context, userID, roleID uuid.UUID, communityID *uuid.UUID) (bool, error)
+}
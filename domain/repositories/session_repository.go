@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Session, error)
+	ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) // most recently seen first
+
+	// Rotate swaps the refresh token hash only if oldHash is still current (false = token already rotated)
+	Rotate(ctx context.Context, session *models.Session, oldHash string) (bool, error)
+	Revoke(ctx context.Context, id uuid.UUID, reason string) error
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+// SubscriptionTierStats - Paying subscribers and normalized monthly revenue of one tier
+type SubscriptionTierStats struct {
+	TierID         uuid.UUID
+	Subscribers    int64
+	MonthlyRevenue int64 // satang, yearly plans counted as price / 12
+}
+
+type SubscriptionRepository interface {
+	// Tiers
+	CreateTier(ctx context.Context, tier *models.SubscriptionTier) error
+	UpdateTier(ctx context.Context, tier *models.SubscriptionTier) error
+	GetTierByID(ctx context.Context, id uuid.UUID) (*models.SubscriptionTier, error)
+	ListTiersByCreator(ctx context.Context, creatorID uuid.UUID, activeOnly bool) ([]*models.SubscriptionTier, error) // lowest level first
+
+	// Subscriptions
+	Create(ctx context.Context, subscription *models.Subscription) error
+	Update(ctx context.Context, subscription *models.Subscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error)
+	GetBySubscriberAndCreator(ctx context.Context, subscriberID, creatorID uuid.UUID) (*models.Subscription, error)
+	ListBySubscriber(ctx context.Context, subscriberID uuid.UUID, offset, limit int) ([]*models.Subscription, error)
+	CountBySubscriber(ctx context.Context, subscriberID uuid.UUID) (int64, error)
+	ListActiveByCreator(ctx context.Context, creatorID uuid.UUID, offset, limit int) ([]*models.Subscription, error)
+	CountActiveByCreator(ctx context.Context, creatorID uuid.UUID) (int64, error)
+
+	// Access checks (highest tier level the subscriber currently has access to, per creator)
+	GetAccessTierLevels(ctx context.Context, subscriberID uuid.UUID, creatorIDs []uuid.UUID) (map[uuid.UUID]int, error)
+
+	// Creator analytics
+	GetTierStats(ctx context.Context, creatorID uuid.UUID) ([]*SubscriptionTierStats, error)
+
+	// Payments
+	CreatePayment(ctx context.Context, payment *models.SubscriptionPayment) error
+	UpdatePaymentStatus(ctx context.Context, id uuid.UUID, status string) error
+	GetLatestPayment(ctx context.Context, subscriptionID uuid.UUID, billingReason string) (*models.SubscriptionPayment, error)
+
+	// Scheduler
+	ListDueForRenewal(ctx context.Context, now, renewBefore time.Time, limit int) ([]*models.Subscription, error)
+	ListToExpire(ctx context.Context, now time.Time, limit int) ([]*models.Subscription, error)
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type TopUpRepository interface {
+	Create(ctx context.Context, intent *models.TopUpIntent) error
+	Update(ctx context.Context, intent *models.TopUpIntent) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.TopUpIntent, error)
+	GetByProviderCharge(ctx context.Context, provider string, chargeID string) (*models.TopUpIntent, error)
+	ListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.TopUpIntent, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// Status transitions (conditional, so concurrent webhook deliveries apply once)
+	MarkPaid(ctx context.Context, id uuid.UUID, eventID string, paidAt time.Time) (bool, error)               // pending/expired -> paid
+	MarkCredited(ctx context.Context, id uuid.UUID, ledgerTxID uuid.UUID, creditedAt time.Time) (bool, error) // paid -> credited
+	MarkExpired(ctx context.Context, id uuid.UUID) (bool, error)                                              // pending -> expired
+	ExpirePending(ctx context.Context, before time.Time) (int64, error)                                       // pending -> expired (bulk)
+
+	// Scheduler
+	ListPaidUncredited(ctx context.Context, limit int) ([]*models.TopUpIntent, error)
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type TwoFactorRepository interface {
+	// TOTP secret
+	GetTOTP(ctx context.Context, userID uuid.UUID) (*models.UserTOTP, error)
+	SavePendingTOTP(ctx context.Context, totp *models.UserTOTP) error // replaces an unconfirmed setup
+
+	// UseStep records an accepted code's time step only if it is newer than the last one (false = replayed)
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+
+	// Enable confirms the setup, flags the user and stores the recovery codes in one transaction
+	Enable(ctx context.Context, userID uuid.UUID, step int64, codes []*models.RecoveryCode) error
+	// Disable removes the secret and recovery codes and clears the user flag
+	Disable(ctx context.Context, userID uuid.UUID) error
+
+	// Recovery codes
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []*models.RecoveryCode) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) // false = unknown or already used
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error)
+}
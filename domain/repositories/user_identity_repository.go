@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *models.UserIdentity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.UserIdentity, error)
+	Delete(ctx context.Context, userID uuid.UUID, provider string) error
+
+	// TouchLastLogin records a successful login through the identity
+	TouchLastLogin(ctx context.Context, id uuid.UUID) error
+}
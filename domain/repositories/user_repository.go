@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"gofiber-template/domain/models"
 )
@@ -11,8 +13,12 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	GetByUsername(ctx context.Context, username string) (*models.User, error)
-	GetByOAuth(ctx context.Context, provider, oauthID string) (*models.User, error)
 	Update(ctx context.Context, id uuid.UUID, user *models.User) error
+	SetActive(ctx context.Context, id uuid.UUID, isActive bool) error // Updates skips false, so (de)activation has its own method
+	SetPrivate(ctx context.Context, id uuid.UUID, isPrivate bool) error
+	SetBot(ctx context.Context, id uuid.UUID, isBot bool) error
+	// SetModerationStatus stores the state derived from the user's active sanctions
+	SetModerationStatus(ctx context.Context, id uuid.UUID, suspendedUntil *time.Time, isBanned, isShadowbanned bool) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, offset, limit int) ([]*models.User, error)
 	Count(ctx context.Context) (int64, error)
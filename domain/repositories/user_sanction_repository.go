@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type UserSanctionRepository interface {
+	Create(ctx context.Context, sanction *models.UserSanction) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.UserSanction, error)
+
+	// History (newest first, with IssuedBy preloaded)
+	ListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.UserSanction, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	// ListActiveByUser returns the sanctions still in force at now
+	ListActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*models.UserSanction, error)
+
+	// Lift ends a sanction early (false = it was already lifted)
+	Lift(ctx context.Context, id uuid.UUID, liftedByID uuid.UUID, reason string) (bool, error)
+}
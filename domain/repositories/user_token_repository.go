@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *models.UserToken) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.UserToken, error)
+
+	// MarkUsed redeems the token only if it is still unused (false = already used)
+	MarkUsed(ctx context.Context, id uuid.UUID) (bool, error)
+	// InvalidateByUser marks every unused token of a purpose as used (older links stop working)
+	InvalidateByUser(ctx context.Context, userID uuid.UUID, purpose string) error
+	// IncrementAttempts counts a wrong code against the token and returns the new total
+	IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error)
+
+	// CountCreatedSince backs the per-account send throttle
+	CountCreatedSince(ctx context.Context, userID uuid.UUID, purpose string, since time.Time) (int64, error)
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+)
+
+type WalletRepository interface {
+	// Transaction runs fn inside a single database transaction.
+	// The repository passed to fn is bound to that transaction.
+	Transaction(ctx context.Context, fn func(repo WalletRepository) error) error
+
+	// Wallets
+	Create(ctx context.Context, wallet *models.Wallet) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Wallet, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Wallet, error)
+	GetByCode(ctx context.Context, code string) (*models.Wallet, error)
+	GetForUpdate(ctx context.Context, id uuid.UUID) (*models.Wallet, error) // Row lock (SELECT ... FOR UPDATE)
+	UpdateBalances(ctx context.Context, id uuid.UUID, balance, heldAmount int64) error
+
+	// Ledger
+	CreateTransaction(ctx context.Context, tx *models.LedgerTransaction) error // Creates transaction with its entries
+	GetTransactionByID(ctx context.Context, id uuid.UUID) (*models.LedgerTransaction, error)
+	GetTransactionByIdempotencyKey(ctx context.Context, key string) (*models.LedgerTransaction, error)
+	ListEntriesByWallet(ctx context.Context, walletID uuid.UUID, offset, limit int) ([]*models.LedgerEntry, error)
+	CountEntriesByWallet(ctx context.Context, walletID uuid.UUID) (int64, error)
+
+	// Holds
+	CreateHold(ctx context.Context, hold *models.WalletHold) error
+	GetHoldByID(ctx context.Context, id uuid.UUID) (*models.WalletHold, error)
+	GetHoldForUpdate(ctx context.Context, id uuid.UUID) (*models.WalletHold, error)
+	GetHoldByIdempotencyKey(ctx context.Context, key string) (*models.WalletHold, error)
+	UpdateHold(ctx context.Context, hold *models.WalletHold) error
+	ListExpiredHolds(ctx context.Context, before time.Time, limit int) ([]*models.WalletHold, error)
+}
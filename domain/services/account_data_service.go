@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Account data errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrDataExportUnavailable   = errors.New("data exports are not available right now")
+	ErrDataExportInProgress    = errors.New("a data export is already being prepared")
+	ErrDataExportTooSoon       = errors.New("you can request one data export per day")
+	ErrDeletionPasswordInvalid = errors.New("password is incorrect")
+	ErrDeletionWalletNotEmpty  = errors.New("withdraw or spend your wallet balance, or agree to forfeit it, before deleting your account")
+	ErrDeletionWalletHeld      = errors.New("wait for your pending payout and unlock contributions to settle before deleting your account")
+	ErrDeletionAlreadyPending  = errors.New("account deletion is already scheduled")
+	ErrDeletionNotPending      = errors.New("account deletion is not scheduled")
+)
+
+// AccountDataService exports a user's personal data and deletes accounts.
+// Exports are built in the background into a ZIP in R2; deletion waits out a cooling-off
+// period, then media files are removed and the account is anonymized.
+type AccountDataService interface {
+	// Data export
+	RequestExport(ctx context.Context, userID uuid.UUID) (*dto.DataExportResponse, error)
+	ListExports(ctx context.Context, userID uuid.UUID) (*dto.DataExportListResponse, error)
+	ProcessExports(ctx context.Context) (int, error) // builds pending exports and deletes expired files
+
+	// Account deletion (the account keeps working until the cooling-off period ends)
+	RequestDeletion(ctx context.Context, userID uuid.UUID, req *dto.RequestAccountDeletionRequest) (*dto.AccountDeletionResponse, error)
+	GetDeletionStatus(ctx context.Context, userID uuid.UUID) (*dto.AccountDeletionResponse, error)
+	CancelDeletion(ctx context.Context, userID uuid.UUID) (*dto.AccountDeletionResponse, error)
+	ProcessDeletions(ctx context.Context) (int, error) // anonymizes accounts whose cooling-off period has passed
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"gofiber-template/domain/models"
+)
+
+// Account email errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrEmailNotVerified  = errors.New("email address has not been verified")
+	ErrInvalidEmailToken = errors.New("invalid or already used link")
+	ErrEmailTokenExpired = errors.New("link has expired, please request a new one")
+)
+
+// AccountEmailService sends and redeems the single-use links of the account lifecycle.
+// Request methods never reveal whether an email address is registered.
+type AccountEmailService interface {
+	// Email verification
+	SendVerificationEmail(ctx context.Context, user *models.User) error
+	ResendVerification(ctx context.Context, email string) error
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+
+	// Password reset (signs out every session on success)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, newPassword string) error
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Ad errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrAdNotFound           = errors.New("ad not found")
+	ErrAdPlacementNotFound  = errors.New("ad placement not found or inactive")
+	ErrAdPlacementFull      = errors.New("ad placement is fully booked for the selected dates")
+	ErrAdInvalidStartDate   = errors.New("start date must be today or later")
+	ErrAdInvalidCreative    = errors.New("creative must be an image you uploaded")
+	ErrAdInvalidSlip        = errors.New("payment slip must be an image you uploaded")
+	ErrAdInvalidStatus      = errors.New("ad cannot be changed in its current status")
+	ErrAdPaymentNotPending  = errors.New("ad payment is not pending")
+	ErrAdPaymentUnderReview = errors.New("a payment slip is already waiting for verification")
+	ErrAdImpressionMismatch = errors.New("impression does not belong to this ad")
+	ErrAdTrackingLimited    = errors.New("too many ad events, please slow down")
+)
+
+type AdService interface {
+	// Placements
+	ListPlacements(ctx context.Context) (*dto.AdPlacementListResponse, error)
+	UpdatePlacement(ctx context.Context, code string, req *dto.UpdateAdPlacementRequest) (*dto.AdPlacementResponse, error) // admin
+
+	// Advertiser side
+	CreateAd(ctx context.Context, userID uuid.UUID, req *dto.CreateAdRequest) (*dto.AdResponse, error)
+	UpdateAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID, req *dto.UpdateAdRequest) (*dto.AdResponse, error) // before payment only
+	GetMyAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID) (*dto.AdResponse, error)
+	ListMyAds(ctx context.Context, userID uuid.UUID, status string, offset, limit int) (*dto.AdListResponse, error)
+	GetAdStats(ctx context.Context, adID uuid.UUID, userID uuid.UUID) (*dto.AdStatsResponse, error)
+	CancelAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID) error // before payment only
+	PauseAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID) (*dto.AdResponse, error)
+	ResumeAd(ctx context.Context, adID uuid.UUID, userID uuid.UUID) (*dto.AdResponse, error)
+	SubmitPayment(ctx context.Context, adID uuid.UUID, userID uuid.UUID, req *dto.SubmitAdPaymentRequest) (*dto.AdPaymentResponse, error)
+
+	// Admin side (slip verification queue)
+	ListPayments(ctx context.Context, status string, offset, limit int) (*dto.AdPaymentListResponse, error)
+	VerifyPayment(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID) (*dto.AdPaymentResponse, error) // schedules the ad
+	RejectPayment(ctx context.Context, paymentID uuid.UUID, adminID uuid.UUID, reason string) (*dto.AdPaymentResponse, error)
+	RemoveAd(ctx context.Context, adID uuid.UUID, adminID uuid.UUID, reason string) error
+
+	// Serving
+	ServeAds(ctx context.Context, placement string, userID *uuid.UUID, limit int) (*dto.ServedAdListResponse, error)
+	// InterleaveFeedAds picks feed ads for a page of postCount posts (one every FeedInterval posts)
+	InterleaveFeedAds(ctx context.Context, userID *uuid.UUID, postCount int) []dto.FeedAdSlot
+	RecordImpression(ctx context.Context, adID uuid.UUID, userID *uuid.UUID, req *dto.RecordAdImpressionRequest, ipAddress, userAgent string) (*dto.AdImpressionResponse, error)
+	RecordClick(ctx context.Context, adID uuid.UUID, userID *uuid.UUID, req *dto.RecordAdClickRequest, ipAddress string) (*dto.AdClickResponse, error)
+
+	// Scheduler job
+	ProcessSchedule(ctx context.Context) (activated int, completed int, err error) // start scheduled ads and complete ended ones
+}
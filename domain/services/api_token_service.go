@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/pkg/utils"
+)
+
+// API token errors (checked by handlers and the auth middleware to map to proper HTTP responses)
+var (
+	ErrAPITokenNotFound     = errors.New("API token not found")
+	ErrAPITokenLimit        = errors.New("too many active API tokens, revoke one first")
+	ErrAPITokenUserNotFound = errors.New("user not found")
+	ErrAPITokenNotBot       = errors.New("bot keys can only be issued to accounts flagged as bots")
+	ErrAPITokenStaffAccount = errors.New("staff accounts cannot be bots")
+	ErrAPITokenInvalid      = errors.New("invalid API token")
+	ErrAPITokenExpired      = errors.New("API token has expired")
+	ErrAPITokenRateLimited  = errors.New("API token rate limit exceeded")
+)
+
+// APITokenService manages personal access tokens and bot keys and authenticates requests made with them
+type APITokenService interface {
+	// Personal access tokens (the user's own)
+	CreateToken(ctx context.Context, userID uuid.UUID, req *dto.CreateAPITokenRequest) (*dto.CreatedAPITokenResponse, error)
+	ListTokens(ctx context.Context, userID uuid.UUID) (*dto.APITokenListResponse, error)
+	RevokeToken(ctx context.Context, userID, tokenID uuid.UUID) error
+
+	// Bot accounts and their keys (staff; accounts holding staff roles are refused)
+	SetBotAccount(ctx context.Context, userID uuid.UUID, isBot bool) error
+	CreateBotToken(ctx context.Context, staffID, botUserID uuid.UUID, req *dto.CreateAPITokenRequest) (*dto.CreatedAPITokenResponse, error)
+	RevokeBotToken(ctx context.Context, tokenID uuid.UUID) error
+
+	// AuthenticateAPIToken resolves a raw token to its user and scopes and counts the request
+	// against the token's rate limit (used by the auth middleware)
+	AuthenticateAPIToken(ctx context.Context, token, ip string) (*utils.UserContext, error)
+}
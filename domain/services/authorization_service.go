@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Authorization errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrRoleNotFound       = errors.New("role not found")
+	ErrRoleUserNotFound   = errors.New("user not found")
+	ErrRoleScopeMismatch  = errors.New("community roles need a community, site-wide roles cannot have one")
+	ErrRoleAlreadyGranted = errors.New("the user already has this role")
+	ErrRoleNotGranted     = errors.New("the user does not have this role")
+	ErrRoleSelfChange     = errors.New("you cannot change your own roles")
+)
+
+// AuthorizationService resolves role-based permissions.
+// Effective permissions are cached per user and dropped whenever a grant changes.
+type AuthorizationService interface {
+	// HasPermission checks a site-wide grant, or a grant inside the community when communityID is set
+	HasPermission(ctx context.Context, userID uuid.UUID, permission string, communityID *uuid.UUID) (bool, error)
+	GetMyPermissions(ctx context.Context, userID uuid.UUID) (*dto.UserPermissionsResponse, error)
+
+	// Catalog
+	ListRoles(ctx context.Context) ([]dto.RoleResponse, error)
+	ListPermissions(ctx context.Context) ([]dto.PermissionResponse, error)
+
+	// Grants (actorID cannot change their own roles)
+	ListUserRoles(ctx context.Context, userID uuid.UUID) ([]dto.UserRoleResponse, error)
+	AssignRole(ctx context.Context, actorID, userID uuid.UUID, req *dto.AssignRoleRequest) (*dto.UserRoleResponse, error)
+	RevokeRole(ctx context.Context, actorID, userID uuid.UUID, role string, communityID *uuid.UUID) error
+}
@@ -13,6 +13,7 @@ type CommentService interface {
 	GetComment(ctx context.Context, commentID uuid.UUID, userID *uuid.UUID) (*dto.CommentResponse, error)
 	UpdateComment(ctx context.Context, commentID uuid.UUID, userID uuid.UUID, req *dto.UpdateCommentRequest) (*dto.CommentResponse, error)
 	DeleteComment(ctx context.Context, commentID uuid.UUID, userID uuid.UUID) error
+	RemoveComment(ctx context.Context, commentID uuid.UUID, moderatorID uuid.UUID, reason string) error // moderator removal (records moderator and reason)
 
 	// List comments (offset-based, deprecated)
 	ListCommentsByPost(ctx context.Context, postID uuid.UUID, offset, limit int, sortBy repositories.CommentSortBy, userID *uuid.UUID) (*dto.CommentListResponse, error)
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Community errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrCommunityNotFound          = errors.New("community not found")
+	ErrCommunitySlugInvalid       = errors.New("community slug may only contain lowercase letters, digits and underscores")
+	ErrCommunitySlugTaken         = errors.New("community slug is already taken")
+	ErrCommunityPrivate           = errors.New("this community is private")
+	ErrCommunityModeratorOnly     = errors.New("only community moderators can do this")
+	ErrCommunityOwnerOnly         = errors.New("only the community owner can do this")
+	ErrCommunityAlreadyMember     = errors.New("already a member or a join request is pending")
+	ErrCommunityNotMember         = errors.New("you are not a member of this community")
+	ErrCommunityBanned            = errors.New("you are banned from this community")
+	ErrCommunityOwnerCannotLeave  = errors.New("the owner cannot leave the community")
+	ErrCommunityMemberNotFound    = errors.New("community member not found")
+	ErrCommunityPostingRestricted = errors.New("only moderators can post in this community")
+)
+
+type CommunityService interface {
+	// Communities
+	CreateCommunity(ctx context.Context, userID uuid.UUID, req *dto.CreateCommunityRequest) (*dto.CommunityResponse, error)
+	UpdateCommunity(ctx context.Context, slug string, userID uuid.UUID, req *dto.UpdateCommunityRequest) (*dto.CommunityResponse, error)
+	GetCommunity(ctx context.Context, slug string, userID *uuid.UUID) (*dto.CommunityResponse, error) // private communities are still shown (without posts)
+	ListCommunities(ctx context.Context, query string, offset, limit int, userID *uuid.UUID) (*dto.CommunityListResponse, error)
+	ListMyCommunities(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.CommunityListResponse, error)
+
+	// Membership
+	Join(ctx context.Context, slug string, userID uuid.UUID) (*dto.CommunityResponse, error) // restricted/private = join request
+	Leave(ctx context.Context, slug string, userID uuid.UUID) error
+	ListMembers(ctx context.Context, slug string, status string, offset, limit int, userID *uuid.UUID) (*dto.CommunityMemberListResponse, error) // pending/banned lists are moderator only
+
+	// Moderation
+	ApproveMember(ctx context.Context, slug string, moderatorID, memberID uuid.UUID) (*dto.CommunityMemberResponse, error)
+	RejectMember(ctx context.Context, slug string, moderatorID, memberID uuid.UUID) error
+	BanMember(ctx context.Context, slug string, moderatorID, memberID uuid.UUID) (*dto.CommunityMemberResponse, error)
+	UnbanMember(ctx context.Context, slug string, moderatorID, memberID uuid.UUID) error
+	SetMemberRole(ctx context.Context, slug string, ownerID, memberID uuid.UUID, req *dto.SetCommunityMemberRoleRequest) (*dto.CommunityMemberResponse, error)
+}
@@ -2,10 +2,27 @@ package services
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
 )
 
+// Conversation errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrConversationNotFound       = errors.New("conversation not found")
+	ErrConversationNotParticipant = errors.New("access denied: not a participant")
+	ErrConversationNotGroup       = errors.New("this is not a group conversation")
+	ErrConversationNotManager     = errors.New("only the group owner and admins can do this")
+	ErrConversationNotOwner       = errors.New("only the group owner can do this")
+	ErrConversationGroupFull      = errors.New("the group has reached its member limit")
+	ErrConversationUserNotFound   = errors.New("user not found")
+	ErrConversationUserBlocked    = errors.New("cannot add a user you blocked or who blocked you")
+	ErrConversationMemberNotFound = errors.New("user is not a member of this group")
+	ErrConversationChatNotAllowed = errors.New("this user only accepts chats from people they follow")
+	ErrConversationNotRequest     = errors.New("this conversation is not a message request")
+	ErrConversationRequestPending = errors.New("the message request has not been accepted yet")
+)
+
 type ConversationService interface {
 	// Get or create conversation
 	GetOrCreateConversation(ctx context.Context, userID uuid.UUID, otherUsername string) (*dto.ConversationResponse, error)
@@ -17,9 +34,27 @@ type ConversationService interface {
 	// Unread counts
 	GetUnreadCount(ctx context.Context, userID uuid.UUID) (*dto.UnreadCountResponse, error)
 
-	// Mark as read
-	MarkAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
+	// Receipts (upToSeq 0 = up to the latest message)
+	MarkAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, upToSeq int64) (*dto.ReadStateResponse, error)
+	MarkAsDelivered(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, upToSeq int64) (*dto.ReadStateResponse, error)
+
+	// Sync: changes after the last sequence a reconnecting client saw
+	SyncConversation(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, afterSeq int64) (*dto.ConversationSyncResponse, error)
+
+	// Message requests (chats started by someone the user does not follow)
+	ListMessageRequests(ctx context.Context, userID uuid.UUID, cursor *string, limit int) (*dto.ConversationListResponse, error)
+	AcceptMessageRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) (*dto.ConversationResponse, error)
+	DeleteMessageRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
+	BlockMessageRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error // blocks the requester and deletes the request
 
 	// Search users for chat
 	SearchUsersForChat(ctx context.Context, userID uuid.UUID, query string, limit int) (*dto.ChatUserSearchResponse, error)
+
+	// Group conversations
+	CreateGroup(ctx context.Context, userID uuid.UUID, req *dto.CreateGroupConversationRequest) (*dto.ConversationResponse, error)
+	UpdateGroup(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, req *dto.UpdateGroupConversationRequest) (*dto.ConversationResponse, error)
+	AddParticipants(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, req *dto.AddParticipantsRequest) (*dto.ConversationResponse, error)
+	RemoveParticipant(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, targetUserID uuid.UUID) error
+	UpdateParticipantRole(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, targetUserID uuid.UUID, req *dto.UpdateParticipantRoleRequest) (*dto.ConversationResponse, error)
+	LeaveGroup(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
 }
@@ -2,19 +2,34 @@ package services
 
 import (
 	"context"
+	"errors"
+
 	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
 )
 
+// Follow errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrPrivateAccount        = errors.New("this account is private")
+	ErrFollowRequestNotFound = errors.New("follow request not found")
+	ErrFollowRequestPending  = errors.New("follow request already sent")
+)
+
 type FollowService interface {
-	// Follow/Unfollow
+	// Follow/Unfollow (following a private account sends a follow request, unfollowing cancels it)
 	Follow(ctx context.Context, followerID uuid.UUID, followingID uuid.UUID) (*dto.FollowResponse, error)
 	Unfollow(ctx context.Context, followerID uuid.UUID, followingID uuid.UUID) error
 
+	// Follow requests received by a private account
+	ListFollowRequests(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.FollowRequestListResponse, error)
+	AcceptFollowRequest(ctx context.Context, userID uuid.UUID, requesterID uuid.UUID) error
+	RejectFollowRequest(ctx context.Context, userID uuid.UUID, requesterID uuid.UUID) error
+
 	// Check relationship
 	IsFollowing(ctx context.Context, followerID uuid.UUID, followingID uuid.UUID) (*dto.FollowStatusResponse, error)
 
 	// Get followers/following (offset-based, deprecated)
+	// Lists of a private account are only shown to the owner and approved followers (ErrPrivateAccount)
 	GetFollowers(ctx context.Context, userID uuid.UUID, offset, limit int, currentUserID *uuid.UUID) (*dto.FollowerListResponse, error)
 	GetFollowing(ctx context.Context, userID uuid.UUID, offset, limit int, currentUserID *uuid.UUID) (*dto.FollowingListResponse, error)
 
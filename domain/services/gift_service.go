@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Gift errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrGiftPackageUnavailable = errors.New("gift package is not available")
+	ErrGiftMediaLimit         = errors.New("too many media files for this gift package")
+	ErrGiftDailyLimit         = errors.New("daily gift limit reached")
+	ErrGiftNotOpenable        = errors.New("gift can no longer be opened")
+	ErrGiftSenderRestricted   = errors.New("gifts cannot be sent from this account")
+)
+
+type GiftService interface {
+	// Catalog
+	ListPackages(ctx context.Context) (*dto.GiftPackageListResponse, error)
+
+	// Send (sender pays into escrow) and open (receiver gets paid)
+	SendGift(ctx context.Context, senderID uuid.UUID, req *dto.SendGiftRequest) (*dto.MessageResponse, error)
+	OpenGift(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.MessageResponse, error)
+
+	// Moderation: refund a still-sealed gift in full when its message is removed (no-op for other messages)
+	RefundRemovedGift(ctx context.Context, messageID uuid.UUID) error
+
+	// Scheduler jobs
+	ProcessExpiredGifts(ctx context.Context) (int, error) // expire unopened gifts and refund senders
+	SettlePendingGifts(ctx context.Context) (int, error)  // retry escrow payouts left behind by crashes
+}
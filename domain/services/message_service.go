@@ -2,10 +2,23 @@ package services
 
 import (
 	"context"
+	"errors"
 	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
 )
 
+// Message edit/unsend errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrMessageNotFound           = errors.New("message not found")
+	ErrMessageNotSender          = errors.New("you can only change messages you sent")
+	ErrMessageNotEditable        = errors.New("gift messages and removed messages cannot be edited or unsent")
+	ErrMessageEditWindowClosed   = errors.New("this message can no longer be edited")
+	ErrMessageUnsendWindowClosed = errors.New("this message can no longer be unsent")
+	ErrMessageReplyNotFound      = errors.New("the quoted message was not found in this conversation")
+	ErrReactionEmojiNotAllowed   = errors.New("this emoji cannot be used as a reaction")
+	ErrMessageSearchQueryInvalid = errors.New("search query must be between 2 and 100 characters")
+)
+
 type MessageService interface {
 	// Send message
 	SendMessage(ctx context.Context, userID uuid.UUID, req *dto.SendMessageRequest) (*dto.MessageResponse, error)
@@ -18,6 +31,23 @@ type MessageService interface {
 
 	// Jump to message with context (for search, media tabs, etc.)
 	GetMessageContext(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.MessageContextResponse, error)
+	GetQuotedMessageContext(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.MessageContextResponse, error) // context of the message a reply quotes
+
+	// Edit and unsend (sender only, within a time window after sending)
+	EditMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, req *dto.EditMessageRequest) (*dto.MessageResponse, error)
+	UnsendMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.UnsendMessageResponse, error)
+	ListMessageRevisions(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.MessageRevisionListResponse, error)
+
+	// Delivery/read state of a sent message for each other participant (sender only)
+	GetMessageReceipts(ctx context.Context, messageID uuid.UUID, userID uuid.UUID) (*dto.MessageReceiptsResponse, error)
+
+	// Reactions (the full set of reactions to the message is returned)
+	AddReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) (*dto.MessageReactionsResponse, error)
+	RemoveReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) (*dto.MessageReactionsResponse, error)
+
+	// Text search with highlighted snippets (in one conversation, or in every conversation of the user)
+	SearchConversationMessages(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, query string, cursor *string, limit int) (*dto.MessageSearchResponse, error)
+	SearchMessages(ctx context.Context, userID uuid.UUID, query string, cursor *string, limit int) (*dto.MessageSearchResponse, error)
 
 	// Mark as read
 	MarkAsRead(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error
@@ -2,16 +2,37 @@ package services
 
 import (
 	"context"
+	"errors"
+
+	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
 )
 
+// OAuth errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrOAuthProviderNotFound   = errors.New("login provider is not available")
+	ErrOAuthInvalidState       = errors.New("login request expired or was already used, please try again")
+	ErrOAuthEmailRequired      = errors.New("the provider did not share an email address")
+	ErrOAuthEmailInUse         = errors.New("an account with this email already exists, sign in and link this provider from your settings")
+	ErrIdentityLinkedElsewhere = errors.New("this login is already linked to another account")
+	ErrIdentityAlreadyLinked   = errors.New("a login from this provider is already linked to your account")
+	ErrIdentityNotFound        = errors.New("login provider is not linked to your account")
+	ErrLastLoginMethod         = errors.New("set a password or link another provider before unlinking your last login")
+)
+
+// OAuthService logs users in through the providers of the registry (Google, LINE, Facebook, OIDC issuers)
+// and links several external identities to one account
 type OAuthService interface {
-	// GetGoogleAuthURL generates Google OAuth authorization URL
-	GetGoogleAuthURL(state string) string
+	// ListProviders returns the providers enabled by configuration
+	ListProviders() []dto.OAuthProviderResponse
+
+	// GetAuthURL starts a login (linkUserID nil) or links the provider to a signed-in user
+	GetAuthURL(ctx context.Context, provider string, linkUserID *uuid.UUID) (url string, state string, err error)
 
-	// HandleGoogleCallback processes Google OAuth callback
-	HandleGoogleCallback(ctx context.Context, code string) (*dto.OAuthLoginResponse, error)
+	// HandleCallback verifies the state, redeems the code and logs in or links the identity
+	HandleCallback(ctx context.Context, provider, code, state string, client dto.SessionClient) (*dto.OAuthCallbackResult, error)
 
-	// GetUserInfoFromGoogle retrieves user info from Google OAuth token
-	GetUserInfoFromGoogle(ctx context.Context, accessToken string) (*dto.OAuthUserInfo, error)
+	// Linked identities
+	ListIdentities(ctx context.Context, userID uuid.UUID) (*dto.UserIdentityListResponse, error)
+	UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error
 }
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Payout errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrPayoutNotFound        = errors.New("payout not found")
+	ErrPayoutBelowMinimum    = errors.New("payout amount is below the minimum")
+	ErrPayoutExceedsEarnings = errors.New("payout amount exceeds your withdrawable earnings")
+	ErrPayoutAlreadyPending  = errors.New("you already have a pending payout request")
+	ErrPayoutInvalidStatus   = errors.New("payout cannot be changed in its current status")
+	ErrPayoutInvalidAccount  = errors.New("invalid bank account number or PromptPay ID")
+)
+
+type PayoutService interface {
+	// Creator side
+	GetPayoutSummary(ctx context.Context, userID uuid.UUID) (*dto.PayoutSummaryResponse, error)
+	RequestPayout(ctx context.Context, userID uuid.UUID, req *dto.RequestPayoutRequest) (*dto.PayoutResponse, error)
+	GetMyPayout(ctx context.Context, payoutID uuid.UUID, userID uuid.UUID) (*dto.PayoutResponse, error)
+	ListMyPayouts(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.PayoutListResponse, error)
+	CancelPayout(ctx context.Context, payoutID uuid.UUID, userID uuid.UUID) (*dto.PayoutResponse, error) // pending only
+
+	// Admin side (approval queue)
+	ListPayouts(ctx context.Context, status string, offset, limit int) (*dto.PayoutListResponse, error)
+	ApprovePayout(ctx context.Context, payoutID uuid.UUID, adminID uuid.UUID) (*dto.PayoutResponse, error)
+	RejectPayout(ctx context.Context, payoutID uuid.UUID, adminID uuid.UUID, reason string) (*dto.PayoutResponse, error)
+	MarkPayoutsPaid(ctx context.Context, adminID uuid.UUID, req *dto.MarkPayoutsPaidRequest) (*dto.MarkPayoutsPaidResponse, error)
+	FailPayout(ctx context.Context, payoutID uuid.UUID, adminID uuid.UUID, reason string) (*dto.PayoutResponse, error) // refunds the wallet
+
+	// ExportApprovedCSV renders approved payouts as CSV for the bank batch transfer
+	ExportApprovedCSV(ctx context.Context) ([]byte, error)
+}
@@ -13,17 +13,20 @@ type PostService interface {
 	GetPost(ctx context.Context, postID uuid.UUID, userID *uuid.UUID) (*dto.PostResponse, error)
 	UpdatePost(ctx context.Context, postID uuid.UUID, userID uuid.UUID, req *dto.UpdatePostRequest) (*dto.PostResponse, error)
 	DeletePost(ctx context.Context, postID uuid.UUID, userID uuid.UUID) error
+	RemovePost(ctx context.Context, postID uuid.UUID, moderatorID uuid.UUID, reason string) error // moderator removal (records moderator and reason)
 
 	// List and filter posts (offset-based, deprecated)
 	ListPosts(ctx context.Context, offset, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListResponse, error)
 	ListPostsByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int, userID *uuid.UUID) (*dto.PostListResponse, error)
 	ListPostsByTag(ctx context.Context, tagName string, offset, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListResponse, error)
 	ListPostsByTagID(ctx context.Context, tagID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListResponse, error)
+	ListPostsByCommunity(ctx context.Context, communityID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListResponse, error)
 
 	// List with Cursor (cursor-based pagination)
 	ListPostsWithCursor(ctx context.Context, cursor string, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListCursorResponse, error)
 	ListPostsByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor string, limit int, userID *uuid.UUID) (*dto.PostListCursorResponse, error)
 	ListPostsByTagWithCursor(ctx context.Context, tagName string, cursor string, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListCursorResponse, error)
+	ListPostsByCommunityWithCursor(ctx context.Context, communityID uuid.UUID, cursor string, limit int, sortBy repositories.PostSortBy, userID *uuid.UUID) (*dto.PostListCursorResponse, error) // private communities are members only
 	GetFollowingFeedWithCursor(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*dto.PostFeedCursorResponse, error)
 
 	// Search (offset-based, deprecated)
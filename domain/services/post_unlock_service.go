@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Premium unlock errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrPostNotPremium          = errors.New("post is not a premium unlock post")
+	ErrPostUnlockClosed        = errors.New("post is no longer accepting contributions")
+	ErrUnlockContributionLimit = errors.New("contribution limit reached for this post")
+	ErrCannotContributeOwnPost = errors.New("cannot contribute to your own post")
+)
+
+type PostUnlockService interface {
+	// Contributions (money is held in the contributor's wallet until the target is reached)
+	Contribute(ctx context.Context, postID uuid.UUID, userID uuid.UUID, req *dto.ContributeUnlockRequest) (*dto.ContributeUnlockResponse, error)
+
+	// Progress
+	GetUnlockStatus(ctx context.Context, postID uuid.UUID, userID *uuid.UUID) (*dto.PostUnlockStatusResponse, error)
+	ListContributors(ctx context.Context, postID uuid.UUID, offset, limit int) (*dto.UnlockContributorListResponse, error)
+
+	// Scheduler jobs
+	ProcessExpiredUnlocks(ctx context.Context) (int, error) // fail posts past deadline and refund contributors
+	SettlePendingUnlocks(ctx context.Context) (int, error)  // retry captures/releases left behind by crashes
+}
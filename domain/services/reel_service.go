@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Reel errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrReelNotFound    = errors.New("reel not found or expired")
+	ErrReelDailyLimit  = errors.New("daily reel limit reached")
+	ErrReelNotViewable = errors.New("reel is not available")
+)
+
+type ReelService interface {
+	// Creator side
+	CreateReel(ctx context.Context, userID uuid.UUID, req *dto.CreateReelRequest, file multipart.File, filename string) (*dto.ReelResponse, error)
+	ListMyReels(ctx context.Context, userID uuid.UUID) (*dto.ReelListResponse, error) // not expired yet, including processing
+	ListViewers(ctx context.Context, reelID uuid.UUID, userID uuid.UUID, offset, limit int) (*dto.ReelViewerListResponse, error)
+	DeleteReel(ctx context.Context, reelID uuid.UUID, userID uuid.UUID) error
+
+	// Viewer side
+	GetReel(ctx context.Context, reelID uuid.UUID, userID *uuid.UUID) (*dto.ReelResponse, error)
+	ListFollowingReels(ctx context.Context, userID uuid.UUID) (*dto.ReelFeedResponse, error)
+	ListUserReels(ctx context.Context, creatorID uuid.UUID, userID *uuid.UUID) (*dto.ReelListResponse, error)
+	RecordView(ctx context.Context, reelID uuid.UUID, userID uuid.UUID, req *dto.RecordReelViewRequest) (*dto.ReelResponse, error)
+
+	// Video encoding callback (VideoEncoderWorker / Bunny webhook)
+	HandleVideoEncoded(ctx context.Context, mediaID uuid.UUID, status string) error
+
+	// Scheduler job
+	ProcessExpiredReels(ctx context.Context) (int, error) // expire reels past 24h and delete their Bunny videos
+}
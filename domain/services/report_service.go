@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Report errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrReportNotFound       = errors.New("report not found")
+	ErrReportTargetNotFound = errors.New("reported content not found")
+	ErrReportOwnContent     = errors.New("you cannot report your own content")
+	ErrReportDuplicate      = errors.New("you already have an open report on this")
+	ErrReportClosed         = errors.New("report has already been resolved")
+	ErrReportInvalidAction  = errors.New("action does not apply to this report")
+)
+
+type ReportService interface {
+	// Reporter side
+	CreateReport(ctx context.Context, reporterID uuid.UUID, req *dto.CreateReportRequest) (*dto.ReportResponse, error)
+	ListMyReports(ctx context.Context, reporterID uuid.UUID, offset, limit int) (*dto.ReportListResponse, error)
+
+	// Moderator side (triage queue)
+	ListReports(ctx context.Context, status, targetType string, offset, limit int) (*dto.ReportListResponse, error)
+	GetReport(ctx context.Context, reportID uuid.UUID) (*dto.ReportResponse, error)
+	ResolveReport(ctx context.Context, reportID uuid.UUID, moderatorID uuid.UUID, req *dto.ResolveReportRequest) (*dto.ReportResponse, error) // closes every open report on the target and notifies the reporters
+}
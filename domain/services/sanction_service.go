@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Account restriction errors (returned by write paths, wrapped with the end date and reason)
+var (
+	ErrAccountSuspended = errors.New("your account is suspended")
+	ErrAccountBanned    = errors.New("your account is banned")
+)
+
+// Sanction errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrSanctionNotFound     = errors.New("sanction not found")
+	ErrSanctionUserNotFound = errors.New("user not found")
+	ErrSanctionSelf         = errors.New("you cannot sanction yourself")
+	ErrSanctionProtected    = errors.New("staff accounts cannot be sanctioned")
+	ErrSanctionDuration     = errors.New("suspensions need a duration, bans and shadowbans last until lifted")
+	ErrSanctionInactive     = errors.New("sanction has already ended")
+)
+
+// SanctionService applies suspensions, bans and shadowbans and enforces them.
+// The state of each account is cached and dropped whenever one of its sanctions changes.
+type SanctionService interface {
+	// Enforcement
+	// AccountRestriction returns why the account may not be used ("" = not suspended or banned)
+	AccountRestriction(ctx context.Context, userID uuid.UUID) (string, error)
+	// CheckWriteAccess rejects suspended and banned accounts and reports shadowbans (new content is hidden from others)
+	CheckWriteAccess(ctx context.Context, userID uuid.UUID) (shadowbanned bool, err error)
+
+	// Moderation (moderatorID cannot sanction themselves or staff)
+	ApplySanction(ctx context.Context, moderatorID, userID uuid.UUID, req *dto.ApplySanctionRequest) (*dto.UserSanctionResponse, error)
+	LiftSanction(ctx context.Context, moderatorID, sanctionID uuid.UUID, req *dto.LiftSanctionRequest) (*dto.UserSanctionResponse, error)
+	ListSanctions(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.UserSanctionListResponse, error)
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+)
+
+// Session errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionExpired      = errors.New("session has expired, please log in again")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, the session has been revoked")
+)
+
+type SessionService interface {
+	// CreateSession signs a user in on a device and issues the first token pair
+	CreateSession(ctx context.Context, user *models.User, client dto.SessionClient) (*dto.AuthTokens, error)
+
+	// CreateMFASession is CreateSession after a second factor was checked (required for payouts)
+	CreateMFASession(ctx context.Context, user *models.User, client dto.SessionClient) (*dto.AuthTokens, error)
+
+	// Refresh rotates the refresh token; presenting an already rotated token revokes the session
+	Refresh(ctx context.Context, refreshToken string, client dto.SessionClient) (*dto.AuthTokens, error)
+
+	// Session list and remote logout
+	ListSessions(ctx context.Context, userID uuid.UUID, currentSessionID uuid.UUID) (*dto.SessionListResponse, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID, reason string) error
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID, exceptSessionID *uuid.UUID, reason string) (int, error)
+}
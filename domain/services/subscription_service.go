@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Subscription errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrSubscriptionTierLimit      = errors.New("maximum number of subscription tiers reached")
+	ErrSubscriptionTierLevelTaken = errors.New("a tier with this level already exists")
+	ErrSubscriptionTierInactive   = errors.New("subscription tier is not available")
+	ErrCannotSubscribeSelf        = errors.New("cannot subscribe to yourself")
+	ErrAlreadySubscribed          = errors.New("already subscribed to this creator")
+	ErrSubscriptionNotActive      = errors.New("subscription is not active")
+)
+
+type SubscriptionService interface {
+	// Creator side
+	CreateTier(ctx context.Context, creatorID uuid.UUID, req *dto.CreateSubscriptionTierRequest) (*dto.SubscriptionTierResponse, error)
+	UpdateTier(ctx context.Context, tierID uuid.UUID, creatorID uuid.UUID, req *dto.UpdateSubscriptionTierRequest) (*dto.SubscriptionTierResponse, error)
+	ListSubscribers(ctx context.Context, creatorID uuid.UUID, offset, limit int) (*dto.SubscriptionListResponse, error)
+	GetCreatorStats(ctx context.Context, creatorID uuid.UUID) (*dto.CreatorSubscriptionStatsResponse, error)
+
+	// Subscriber side
+	ListTiers(ctx context.Context, creatorID uuid.UUID, userID *uuid.UUID) (*dto.SubscriptionTierListResponse, error)
+	Subscribe(ctx context.Context, subscriberID uuid.UUID, req *dto.SubscribeRequest) (*dto.SubscriptionResponse, error)
+	ChangeTier(ctx context.Context, subscriberID uuid.UUID, creatorID uuid.UUID, req *dto.ChangeSubscriptionTierRequest) (*dto.SubscriptionResponse, error)
+	Cancel(ctx context.Context, subscriberID uuid.UUID, creatorID uuid.UUID, req *dto.CancelSubscriptionRequest) (*dto.SubscriptionResponse, error)
+	ListMySubscriptions(ctx context.Context, subscriberID uuid.UUID, offset, limit int) (*dto.SubscriptionListResponse, error)
+
+	// Scheduler jobs
+	ProcessRenewals(ctx context.Context) (int, error)    // charge due renewals and trial conversions, retry failed payments
+	ProcessExpirations(ctx context.Context) (int, error) // expire cancelled subscriptions and lapsed grace periods
+}
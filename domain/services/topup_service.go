@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+)
+
+// Top-up errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrTopUpNotFound              = errors.New("top-up not found")
+	ErrTopUpNotPending            = errors.New("top-up is no longer pending")
+	ErrTopUpAmountMismatch        = errors.New("paid amount does not match the top-up amount")
+	ErrPaymentProviderUnknown     = errors.New("unknown payment provider")
+	ErrPaymentWebhookInvalid      = errors.New("invalid payment webhook")
+	ErrTopUpSimulationUnavailable = errors.New("payment simulation is not enabled")
+	ErrTopUpUnavailable           = errors.New("top-ups are temporarily unavailable")
+)
+
+type TopUpService interface {
+	// Current user's top-ups
+	CreateTopUp(ctx context.Context, userID uuid.UUID, req *dto.CreateTopUpRequest) (*dto.TopUpIntentResponse, error)
+	GetTopUp(ctx context.Context, intentID uuid.UUID, userID uuid.UUID) (*dto.TopUpIntentResponse, error)
+	ListMyTopUps(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.TopUpIntentListResponse, error)
+
+	// SimulatePayment pays a pending top-up through a signed fake webhook (fake provider only)
+	SimulatePayment(ctx context.Context, intentID uuid.UUID, userID uuid.UUID) (*dto.TopUpIntentResponse, error)
+
+	// HandleWebhook verifies and applies an inbound gateway webhook (idempotent)
+	HandleWebhook(ctx context.Context, provider string, payload []byte, signature string) error
+
+	// Scheduler job
+	ProcessTopUps(ctx context.Context) (expired int, credited int, err error) // expire unpaid QRs and retry failed credits
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+)
+
+// Two-factor errors (checked by handlers to map to proper HTTP responses)
+var (
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorSetupMissing   = errors.New("start the two-factor setup first")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrTwoFactorRequired       = errors.New("enable two-factor authentication to continue")
+	ErrInvalidMFAChallenge     = errors.New("invalid or expired login challenge, please log in again")
+	ErrMFARequired             = errors.New("two-factor code required")
+)
+
+// MFAChallengeError is returned by password login when the account has two-factor enabled.
+// errors.Is(err, ErrMFARequired) matches it; errors.As gives the challenge to return to the client.
+type MFAChallengeError struct {
+	Challenge *dto.MFAChallengeResponse
+}
+
+func (e *MFAChallengeError) Error() string { return ErrMFARequired.Error() }
+
+func (e *MFAChallengeError) Is(target error) bool { return target == ErrMFARequired }
+
+type TwoFactorService interface {
+	// Enrollment
+	GetStatus(ctx context.Context, userID uuid.UUID) (*dto.TwoFactorStatusResponse, error)
+	BeginSetup(ctx context.Context, userID uuid.UUID) (*dto.TwoFactorSetupResponse, error)
+	Enable(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error)
+	Disable(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*dto.RecoveryCodesResponse, error)
+
+	// Login: the password step creates a challenge, a valid code exchanges it for a session
+	CreateChallenge(ctx context.Context, user *models.User) (*dto.MFAChallengeResponse, error)
+	VerifyChallenge(ctx context.Context, challengeToken string, code string, client dto.SessionClient) (*dto.AuthTokens, *models.User, error)
+}
@@ -9,12 +9,12 @@ import (
 
 type UserService interface {
 	Register(ctx context.Context, req *dto.CreateUserRequest) (*models.User, error)
-	Login(ctx context.Context, req *dto.LoginRequest) (string, *models.User, error)
+	// Login returns an *MFAChallengeError (errors.Is ErrMFARequired) when the account has two-factor enabled
+	Login(ctx context.Context, req *dto.LoginRequest, client dto.SessionClient) (*dto.AuthTokens, *models.User, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	GetPublicProfile(ctx context.Context, username string, currentUserID *uuid.UUID) (*dto.UserResponse, error)
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req *dto.UpdateUserRequest) (*models.User, error)
 	DeleteUser(ctx context.Context, userID uuid.UUID) error
 	ListUsers(ctx context.Context, offset, limit int) ([]*models.User, int64, error)
-	GenerateJWT(user *models.User) (string, error)
 	ValidateJWT(token string) (*models.User, error)
 }
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+)
+
+// Wallet errors (checked by callers to map to proper HTTP responses)
+var (
+	ErrInsufficientBalance = errors.New("insufficient wallet balance")
+	ErrUnbalancedPosting   = errors.New("ledger postings do not balance")
+	ErrInvalidAmount       = errors.New("amount must be positive")
+	ErrHoldNotActive       = errors.New("wallet hold is not active")
+)
+
+type WalletService interface {
+	// Wallet lookup (user and system wallets are created lazily)
+	GetOrCreateUserWallet(ctx context.Context, userID uuid.UUID) (*models.Wallet, error)
+	GetSystemWallet(ctx context.Context, code string) (*models.Wallet, error)
+
+	// Current user's wallet
+	GetMyWallet(ctx context.Context, userID uuid.UUID) (*dto.WalletResponse, error)
+	ListMyLedgerEntries(ctx context.Context, userID uuid.UUID, offset, limit int) (*dto.LedgerEntryListResponse, error)
+
+	// Postings (idempotent by IdempotencyKey, always inside one DB transaction)
+	PostTransaction(ctx context.Context, req *dto.LedgerTransactionRequest) (*models.LedgerTransaction, error)
+	Transfer(ctx context.Context, req *dto.WalletTransferRequest) (*models.LedgerTransaction, error)
+
+	// Holds
+	PlaceHold(ctx context.Context, req *dto.WalletHoldRequest) (*models.WalletHold, error)
+	ReleaseHold(ctx context.Context, holdID uuid.UUID) error
+	// CaptureHold settles an active hold: req.Postings are the credit legs (must sum to the hold amount),
+	// the matching debit on the held wallet is added automatically
+	CaptureHold(ctx context.Context, holdID uuid.UUID, req *dto.LedgerTransactionRequest) (*models.LedgerTransaction, error)
+	ReleaseExpiredHolds(ctx context.Context) (int, error)
+}
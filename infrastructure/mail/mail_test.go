@@ -0,0 +1,71 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	netmail "net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkMailer_KeepsAndWritesMessages(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewSinkMailer("VOOBIZE <no-reply@voobize.com>", dir)
+
+	err := mailer.Send(context.Background(), &Message{To: "user@example.com", Subject: "ยืนยันอีเมล", Text: "hello", HTML: "<p>hello</p>"})
+	require.NoError(t, err)
+
+	messages := mailer.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "user@example.com", messages[0].To)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	raw, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+
+	parsed, err := netmail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "ยืนยันอีเมล", subject)
+}
+
+func TestSinkMailer_RejectsInvalidMessage(t *testing.T) {
+	mailer := NewSinkMailer("no-reply@voobize.com", "")
+
+	err := mailer.Send(context.Background(), &Message{Subject: "no recipient", Text: "hello"})
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+	assert.Empty(t, mailer.Messages())
+}
+
+func TestBuildMIME_Alternative(t *testing.T) {
+	raw := buildMIME("no-reply@voobize.com", &Message{To: "user@example.com", Subject: "s", Text: "plain", HTML: "<b>html</b>"}, time.Now())
+
+	parsed, err := netmail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var types []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		types = append(types, part.Header.Get("Content-Type"))
+	}
+	assert.Equal(t, []string{"text/plain; charset=utf-8", "text/html; charset=utf-8"}, types)
+}
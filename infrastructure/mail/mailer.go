@@ -0,0 +1,35 @@
+package mail
+
+import (
+	"context"
+	"errors"
+)
+
+// Mailer provider keys (MAIL_PROVIDER)
+const (
+	SinkMailerName = "sink" // local runs: keeps messages in memory and optionally writes .eml files
+	SMTPMailerName = "smtp"
+)
+
+var ErrInvalidMessage = errors.New("mail message needs a recipient, a subject and a body")
+
+// Mailer is implemented by every outbound email adapter
+type Mailer interface {
+	// Send delivers one message; callers decide whether a failure is fatal
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Message - Outbound email (Text is required, HTML is optional)
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+func (m *Message) validate() error {
+	if m.To == "" || m.Subject == "" || m.Text == "" {
+		return ErrInvalidMessage
+	}
+	return nil
+}
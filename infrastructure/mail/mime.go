@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// buildMIME renders a message as RFC 5322 text (multipart/alternative when HTML is set)
+func buildMIME(from string, msg *Message, at time.Time) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", at.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@mailer>\r\n", uuid.NewString())
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if msg.HTML == "" {
+		writePart(&buf, "text/plain", msg.Text)
+		return buf.Bytes()
+	}
+
+	boundary := "alt-" + uuid.NewString()
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	writePart(&buf, "text/plain", msg.Text)
+	fmt.Fprintf(&buf, "\r\n--%s\r\n", boundary)
+	writePart(&buf, "text/html", msg.HTML)
+	fmt.Fprintf(&buf, "\r\n--%s--\r\n", boundary)
+	return buf.Bytes()
+}
+
+func writePart(buf *bytes.Buffer, contentType string, body string) {
+	fmt.Fprintf(buf, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	w := quotedprintable.NewWriter(buf)
+	_, _ = w.Write([]byte(body))
+	_ = w.Close()
+}
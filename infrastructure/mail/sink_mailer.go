@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const sinkMailerCapacity = 100 // most recent messages kept in memory
+
+// SinkMailer is a local mailer: it never delivers, it keeps the latest messages in memory
+// and writes each one as an .eml file when a directory is configured
+type SinkMailer struct {
+	from string
+	dir  string
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewSinkMailer(from string, dir string) *SinkMailer {
+	return &SinkMailer{
+		from: from,
+		dir:  dir,
+	}
+}
+
+func (m *SinkMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.messages = append(m.messages, *msg)
+	if len(m.messages) > sinkMailerCapacity {
+		m.messages = m.messages[len(m.messages)-sinkMailerCapacity:]
+	}
+	m.mu.Unlock()
+
+	if m.dir == "" {
+		log.Printf("📧 [mail sink] to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Text)
+		return nil
+	}
+
+	now := time.Now()
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create mail sink directory: %w", err)
+	}
+	path := filepath.Join(m.dir, fmt.Sprintf("%s-%d.eml", now.Format("20060102-150405"), now.UnixNano()))
+	if err := os.WriteFile(path, buildMIME(m.from, msg, now), 0o644); err != nil {
+		return fmt.Errorf("failed to write mail sink file: %w", err)
+	}
+	log.Printf("📧 [mail sink] to=%s subject=%q -> %s", msg.To, msg.Subject, path)
+	return nil
+}
+
+// Messages returns the messages kept in memory, oldest first
+func (m *SinkMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := make([]Message, len(m.messages))
+	copy(messages, m.messages)
+	return messages
+}
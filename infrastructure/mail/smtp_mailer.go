@@ -0,0 +1,70 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTPConfig - Relay the SMTP mailer authenticates against (STARTTLS is used when offered)
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string // "Name <address>" or a bare address
+}
+
+// SMTPMailer sends mail through an SMTP relay
+type SMTPMailer struct {
+	config SMTPConfig
+}
+
+func NewSMTPMailer(config SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{config: config}
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	envelopeFrom, err := envelopeAddress(m.config.From)
+	if err != nil {
+		return err
+	}
+
+	// net/smtp has no context support, run it aside so a hung relay doesn't block the caller past its deadline
+	done := make(chan error, 1)
+	go func() {
+		addr := net.JoinHostPort(m.config.Host, m.config.Port)
+		done <- smtp.SendMail(addr, auth, envelopeFrom, []string{msg.To}, buildMIME(m.config.From, msg, time.Now()))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp send failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// envelopeAddress extracts the bare address from a "Name <address>" From header
+func envelopeAddress(from string) (string, error) {
+	addr, err := netmail.ParseAddress(from)
+	if err != nil {
+		return "", fmt.Errorf("invalid MAIL_FROM address: %w", err)
+	}
+	return addr.Address, nil
+}
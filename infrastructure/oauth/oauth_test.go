@@ -0,0 +1,167 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIssuer serves discovery, JWKS and a token endpoint that signs ID tokens with the claims set by the test
+type fakeIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+
+	gotVerifier string
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	f := &fakeIssuer{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 f.server.URL,
+			"authorization_endpoint": f.server.URL + "/authorize",
+			"token_endpoint":         f.server.URL + "/token",
+			"jwks_uri":               f.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "k1",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		f.gotVerifier = r.Form.Get("code_verifier")
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, f.claims)
+		token.Header["kid"] = "k1"
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     signed,
+		})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeIssuer) provider() *OIDCProvider {
+	return NewOIDCProvider(OIDCConfig{
+		Name:        "sso",
+		Issuer:      f.server.URL,
+		ClientID:    "client-1",
+		RedirectURL: "http://localhost/callback",
+	})
+}
+
+func (f *fakeIssuer) validClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            f.server.URL,
+		"aud":            "client-1",
+		"sub":            "user-42",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          nonce,
+		"email":          "somchai@example.com",
+		"email_verified": "true",
+		"name":           "Somchai",
+	}
+}
+
+func TestOIDCProvider_AuthCodeURLUsesPKCEAndNonce(t *testing.T) {
+	f := newFakeIssuer(t)
+	req := AuthRequest{State: "state-1", Nonce: "nonce-1", CodeVerifier: "verifier-1"}
+
+	raw, err := f.provider().AuthCodeURL(context.Background(), req)
+	require.NoError(t, err)
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+
+	challenge := sha256.Sum256([]byte("verifier-1"))
+	assert.Equal(t, "/authorize", u.Path)
+	assert.Equal(t, "state-1", u.Query().Get("state"))
+	assert.Equal(t, "nonce-1", u.Query().Get("nonce"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(challenge[:]), u.Query().Get("code_challenge"))
+	assert.Contains(t, u.Query().Get("scope"), "openid")
+}
+
+func TestOIDCProvider_ExchangeVerifiesIDToken(t *testing.T) {
+	f := newFakeIssuer(t)
+	f.claims = f.validClaims("nonce-1")
+
+	profile, err := f.provider().Exchange(context.Background(), "code", AuthRequest{Nonce: "nonce-1", CodeVerifier: "verifier-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "verifier-1", f.gotVerifier)
+	assert.Equal(t, "sso", profile.Provider)
+	assert.Equal(t, "user-42", profile.Subject)
+	assert.Equal(t, "somchai@example.com", profile.Email)
+	assert.True(t, profile.EmailVerified)
+	assert.Equal(t, "Somchai", profile.Name)
+}
+
+func TestOIDCProvider_ExchangeRejectsInvalidTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(claims jwt.MapClaims, f *fakeIssuer)
+	}{
+		{"nonce mismatch", func(c jwt.MapClaims, f *fakeIssuer) { c["nonce"] = "other" }},
+		{"wrong audience", func(c jwt.MapClaims, f *fakeIssuer) { c["aud"] = "client-2" }},
+		{"wrong issuer", func(c jwt.MapClaims, f *fakeIssuer) { c["iss"] = "https://evil.example.com" }},
+		{"expired", func(c jwt.MapClaims, f *fakeIssuer) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeIssuer(t)
+			f.claims = f.validClaims("nonce-1")
+			tt.mutate(f.claims, f)
+
+			_, err := f.provider().Exchange(context.Background(), "code", AuthRequest{Nonce: "nonce-1", CodeVerifier: "verifier-1"})
+			assert.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+}
+
+func TestRegistry_RejectsDuplicateAndInvalidNames(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.Register(NewLINEProvider("id", "secret", "http://localhost/callback")))
+
+	assert.Error(t, registry.Register(NewLINEProvider("id", "secret", "http://localhost/callback")))
+	assert.Error(t, registry.Register(NewOIDCProvider(OIDCConfig{Name: "Bad Name"})))
+
+	_, err := registry.Get("facebook")
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+	assert.Len(t, registry.List(), 1)
+}
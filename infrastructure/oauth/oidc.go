@@ -0,0 +1,345 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+const (
+	discoveryTTL      = 24 * time.Hour
+	jwksMinRefresh    = time.Minute // unknown key IDs refetch the JWKS at most this often
+	idTokenLeeway     = time.Minute
+	httpClientTimeout = 10 * time.Second
+)
+
+// OIDCConfig - Any OpenID Connect issuer with a discovery document
+type OIDCConfig struct {
+	Name         string
+	DisplayName  string
+	Issuer       string // discovery is read from {Issuer}/.well-known/openid-configuration
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string // "openid" is always requested
+
+	// ExtraIssuers are accepted in the iss claim besides the discovered issuer
+	ExtraIssuers []string
+}
+
+// OIDCProvider logs users in with the authorization code flow (PKCE) and verifies the ID token against the issuer JWKS
+type OIDCProvider struct {
+	config     OIDCConfig
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	discovery   *oidcDiscovery
+	discoveryAt time.Time
+	keys        *jwkSet
+	keysAt      time.Time
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+func NewOIDCProvider(config OIDCConfig) *OIDCProvider {
+	config.Issuer = strings.TrimRight(config.Issuer, "/")
+	if config.DisplayName == "" {
+		config.DisplayName = config.Name
+	}
+	return &OIDCProvider{
+		config:     config,
+		httpClient: &http.Client{Timeout: httpClientTimeout},
+	}
+}
+
+// NewGoogleProvider configures Google as a regular OIDC issuer
+func NewGoogleProvider(clientID, clientSecret, redirectURL string) *OIDCProvider {
+	return NewOIDCProvider(OIDCConfig{
+		Name:         GoogleProviderName,
+		DisplayName:  "Google",
+		Issuer:       "https://accounts.google.com",
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"email", "profile"},
+		ExtraIssuers: []string{"accounts.google.com"}, // Google still issues some tokens without the scheme
+	})
+}
+
+func (p *OIDCProvider) Name() string {
+	return p.config.Name
+}
+
+func (p *OIDCProvider) DisplayName() string {
+	return p.config.DisplayName
+}
+
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, req AuthRequest) (string, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+	return p.oauth2Config(discovery).AuthCodeURL(req.State,
+		oauth2.S256ChallengeOption(req.CodeVerifier),
+		oauth2.SetAuthURLParam("nonce", req.Nonce),
+	), nil
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code string, req AuthRequest) (*Profile, error) {
+	discovery, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.oauth2Config(discovery).Exchange(ctx, code, oauth2.VerifierOption(req.CodeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, fmt.Errorf("%w: no id_token in token response", ErrInvalidIDToken)
+	}
+
+	claims, err := p.verifyIDToken(ctx, discovery, rawIDToken, req.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	profile := claims.profile(p.config.Name)
+
+	// Some issuers keep email/profile claims out of the ID token
+	if profile.Email == "" && discovery.UserinfoEndpoint != "" {
+		if info, err := p.fetchUserinfo(ctx, discovery, token); err == nil && info.Subject == claims.Subject {
+			info.fill(profile)
+		}
+	}
+
+	return profile, nil
+}
+
+func (p *OIDCProvider) oauth2Config(discovery *oidcDiscovery) *oauth2.Config {
+	scopes := []string{"openid"}
+	for _, scope := range p.config.Scopes {
+		if scope != "openid" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+	}
+}
+
+// ==================== ID token ====================
+
+// idTokenClaims - Standard claims read from ID tokens and userinfo responses
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string      `json:"nonce"`
+	Email         string      `json:"email"`
+	EmailVerified interface{} `json:"email_verified"` // bool, or "true"/"false" for some issuers
+	Name          string      `json:"name"`
+	GivenName     string      `json:"given_name"`
+	FamilyName    string      `json:"family_name"`
+	Picture       string      `json:"picture"`
+}
+
+func (c *idTokenClaims) emailVerified() bool {
+	switch v := c.EmailVerified.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+func (c *idTokenClaims) profile(provider string) *Profile {
+	return &Profile{
+		Provider:      provider,
+		Subject:       c.Subject,
+		Email:         c.Email,
+		EmailVerified: c.Email != "" && c.emailVerified(),
+		Name:          c.Name,
+		GivenName:     c.GivenName,
+		FamilyName:    c.FamilyName,
+		Picture:       c.Picture,
+	}
+}
+
+// fill copies userinfo claims missing from the ID token
+func (c *idTokenClaims) fill(profile *Profile) {
+	if profile.Email == "" {
+		profile.Email = c.Email
+		profile.EmailVerified = c.Email != "" && c.emailVerified()
+	}
+	if profile.Name == "" {
+		profile.Name = c.Name
+	}
+	if profile.GivenName == "" {
+		profile.GivenName = c.GivenName
+	}
+	if profile.FamilyName == "" {
+		profile.FamilyName = c.FamilyName
+	}
+	if profile.Picture == "" {
+		profile.Picture = c.Picture
+	}
+}
+
+func (p *OIDCProvider) verifyIDToken(ctx context.Context, discovery *oidcDiscovery, rawIDToken, nonce string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.getKey(ctx, discovery, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(idTokenLeeway),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	if !p.acceptsIssuer(discovery, claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, claims.Issuer)
+	}
+	if claims.Nonce == "" || claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	return claims, nil
+}
+
+func (p *OIDCProvider) acceptsIssuer(discovery *oidcDiscovery, issuer string) bool {
+	if issuer == discovery.Issuer {
+		return true
+	}
+	for _, extra := range p.config.ExtraIssuers {
+		if issuer == extra {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *OIDCProvider) fetchUserinfo(ctx context.Context, discovery *oidcDiscovery, token *oauth2.Token) (*idTokenClaims, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.UserinfoEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	token.SetAuthHeader(req)
+
+	info := &idTokenClaims{}
+	if err := doJSON(p.httpClient, req, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// ==================== Discovery & keys ====================
+
+func (p *OIDCProvider) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil && time.Since(p.discoveryAt) < discoveryTTL {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	discovery := &oidcDiscovery{}
+	if err := doJSON(p.httpClient, req, discovery); err != nil {
+		if p.discovery != nil {
+			return p.discovery, nil // keep the stale document while the issuer is unreachable
+		}
+		return nil, fmt.Errorf("failed to load OIDC discovery for %s: %w", p.config.Name, err)
+	}
+	if discovery.Issuer != p.config.Issuer || discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: incomplete discovery document for %s", ErrProviderResponse, p.config.Name)
+	}
+
+	p.discovery = discovery
+	p.discoveryAt = time.Now()
+	return discovery, nil
+}
+
+func (p *OIDCProvider) getKey(ctx context.Context, discovery *oidcDiscovery, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.keys != nil {
+		if key, ok := p.keys.find(kid); ok {
+			return key, nil
+		}
+		if time.Since(p.keysAt) < jwksMinRefresh {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+	}
+
+	// First use, or the issuer rotated its keys
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	set := &jwkSet{}
+	if err := doJSON(p.httpClient, req, set); err != nil {
+		return nil, fmt.Errorf("failed to load JWKS: %w", err)
+	}
+	p.keys = set
+	p.keysAt = time.Now()
+
+	if key, ok := set.find(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// doJSON sends a request and decodes a 2xx JSON response
+func doJSON(client *http.Client, req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %s returned %d", ErrProviderResponse, req.URL.Host, resp.StatusCode)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("%w: %v", ErrProviderResponse, err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// Built-in provider keys (used in /auth/oauth/:provider URLs and stored on identities)
+const (
+	GoogleProviderName   = "google"
+	LINEProviderName     = "line"
+	FacebookProviderName = "facebook"
+)
+
+var (
+	ErrUnknownProvider  = errors.New("unknown login provider")
+	ErrInvalidIDToken   = errors.New("invalid ID token")
+	ErrProviderResponse = errors.New("unexpected response from login provider")
+)
+
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,29}$`)
+
+// Provider is implemented by every external login adapter
+type Provider interface {
+	// Name is the provider key (lowercase, URL safe)
+	Name() string
+	// DisplayName is shown on the login button
+	DisplayName() string
+
+	// AuthCodeURL returns the consent page URL (PKCE S256, nonce for OIDC providers)
+	AuthCodeURL(ctx context.Context, req AuthRequest) (string, error)
+
+	// Exchange redeems the authorization code and returns the verified profile
+	Exchange(ctx context.Context, code string, req AuthRequest) (*Profile, error)
+}
+
+// AuthRequest - Per-login values generated before the redirect and checked on the callback
+type AuthRequest struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// Profile - External identity normalized across providers
+type Profile struct {
+	Provider      string
+	Subject       string // stable user ID at the provider
+	Email         string
+	EmailVerified bool // only trusted when the provider asserts it (used for automatic account linking)
+	Name          string
+	GivenName     string
+	FamilyName    string
+	Picture       string
+}
+
+// Registry holds the providers enabled by configuration, in registration order
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+	order     []string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[string]Provider)}
+}
+
+func (r *Registry) Register(p Provider) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := p.Name()
+	if !providerNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid login provider name: %q", name)
+	}
+	if _, exists := r.providers[name]; exists {
+		return fmt.Errorf("login provider registered twice: %s", name)
+	}
+	r.providers[name] = p
+	r.order = append(r.order, name)
+	return nil
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+func (r *Registry) List() []Provider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	providers := make([]Provider, 0, len(r.order))
+	for _, name := range r.order {
+		providers = append(providers, r.providers[name])
+	}
+	return providers
+}
@@ -0,0 +1,83 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FakeProviderName - Provider key of the local fake gateway
+const FakeProviderName = "fake"
+
+// FakeProvider is a local gateway: it issues real PromptPay payloads but nobody confirms them
+// except SimulatePaid, so the top-up flow runs end to end without a live gateway
+type FakeProvider struct {
+	promptPayID   string
+	webhookSecret string
+	now           func() time.Time
+}
+
+func NewFakeProvider(promptPayID string, webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		promptPayID:   promptPayID,
+		webhookSecret: webhookSecret,
+		now:           time.Now,
+	}
+}
+
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+func (p *FakeProvider) CreateCharge(ctx context.Context, req *ChargeRequest) (*Charge, error) {
+	qrPayload, err := GeneratePromptPayPayload(p.promptPayID, req.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate promptpay payload: %w", err)
+	}
+
+	return &Charge{
+		ID:        "fake_chrg_" + uuid.New().String(),
+		QRPayload: qrPayload,
+		ExpiresAt: req.ExpiresAt,
+	}, nil
+}
+
+func (p *FakeProvider) ParseWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	if err := VerifyWebhookSignature(p.webhookSecret, signature, payload, p.now()); err != nil {
+		return nil, err
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, ErrInvalidPayload
+	}
+	if event.ID == "" || event.Type == "" || event.ChargeID == "" {
+		return nil, ErrInvalidPayload
+	}
+	return &event, nil
+}
+
+func (p *FakeProvider) SimulatePaid(chargeID string, amount int64) ([]byte, string, error) {
+	now := p.now()
+	payload, err := json.Marshal(&WebhookEvent{
+		ID:         "fake_evt_" + uuid.New().String(),
+		Type:       EventChargePaid,
+		ChargeID:   chargeID,
+		Amount:     amount,
+		Currency:   "THB",
+		OccurredAt: now,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, SignWebhook(p.webhookSecret, payload, now), nil
+}
+
+// Ensure interface compliance
+var (
+	_ PaymentProvider = (*FakeProvider)(nil)
+	_ Simulator       = (*FakeProvider)(nil)
+)
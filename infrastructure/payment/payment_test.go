@@ -0,0 +1,94 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCRC16CCITT(t *testing.T) {
+	// Standard CRC-16/CCITT-FALSE check value
+	assert.Equal(t, uint16(0x29B1), crc16CCITT([]byte("123456789")))
+}
+
+func TestGeneratePromptPayPayload(t *testing.T) {
+	t.Run("mobile number without amount", func(t *testing.T) {
+		payload, err := GeneratePromptPayPayload("081-234-5678", 0)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(payload, "000201010211"))
+		assert.Contains(t, payload, "29370016A00000067701011101130066812345678")
+		assert.Contains(t, payload, "5303764")
+		assert.Contains(t, payload, "5802TH")
+		assert.NotContains(t, payload, "5406")
+		assertValidCRC(t, payload)
+	})
+
+	t.Run("national ID with amount", func(t *testing.T) {
+		payload, err := GeneratePromptPayPayload("1234567890123", 12550)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(payload, "000201010212"))
+		assert.Contains(t, payload, "02131234567890123")
+		assert.Contains(t, payload, "5406125.50")
+		assertValidCRC(t, payload)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		_, err := GeneratePromptPayPayload("12345", 100)
+		assert.ErrorIs(t, err, ErrInvalidPromptPayID)
+	})
+}
+
+func assertValidCRC(t *testing.T, payload string) {
+	t.Helper()
+	require.True(t, len(payload) > 8)
+	body, crc := payload[:len(payload)-4], payload[len(payload)-4:]
+	assert.True(t, strings.HasSuffix(body, "6304"))
+	assert.Equal(t, fmt.Sprintf("%04X", crc16CCITT([]byte(body))), crc)
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	payload := []byte(`{"id":"evt_1"}`)
+	now := time.Unix(1700000000, 0)
+	signature := SignWebhook("secret", payload, now)
+
+	assert.NoError(t, VerifyWebhookSignature("secret", signature, payload, now.Add(time.Minute)))
+	assert.ErrorIs(t, VerifyWebhookSignature("other", signature, payload, now), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyWebhookSignature("secret", signature, []byte(`{"id":"evt_2"}`), now), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyWebhookSignature("secret", signature, payload, now.Add(10*time.Minute)), ErrSignatureExpired)
+	assert.ErrorIs(t, VerifyWebhookSignature("secret", "garbage", payload, now), ErrInvalidSignature)
+	assert.ErrorIs(t, VerifyWebhookSignature("", signature, payload, now), ErrInvalidSignature)
+}
+
+func TestFakeProviderTopUpFlow(t *testing.T) {
+	provider := NewFakeProvider("0812345678", "whsec_test")
+
+	charge, err := provider.CreateCharge(context.Background(), &ChargeRequest{
+		Reference: "intent-1",
+		Amount:    50000,
+		Currency:  "THB",
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(charge.ID, "fake_chrg_"))
+	assert.Contains(t, charge.QRPayload, "5406500.00")
+
+	payload, signature, err := provider.SimulatePaid(charge.ID, 50000)
+	require.NoError(t, err)
+
+	event, err := provider.ParseWebhook(payload, signature)
+	require.NoError(t, err)
+	assert.Equal(t, EventChargePaid, event.Type)
+	assert.Equal(t, charge.ID, event.ChargeID)
+	assert.Equal(t, int64(50000), event.Amount)
+
+	// Tampered body is rejected
+	_, err = provider.ParseWebhook([]byte(strings.Replace(string(payload), "50000", "90000", 1)), signature)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
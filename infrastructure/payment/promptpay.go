@@ -0,0 +1,103 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PromptPay EMVCo QR fields (Thai QR Payment standard)
+const (
+	promptPayAID           = "A000000677010111"
+	promptPayCurrencyTHB   = "764"
+	promptPayCountryCode   = "TH"
+	promptPayStaticQR      = "11" // reusable, customer types the amount
+	promptPayDynamicQR     = "12" // one-time, amount embedded
+	promptPayTagPhone      = "01"
+	promptPayTagNationalID = "02" // national ID or tax ID
+	promptPayTagEWallet    = "03"
+)
+
+var ErrInvalidPromptPayID = errors.New("promptpay ID must be a mobile number, national/tax ID or e-wallet ID")
+
+// GeneratePromptPayPayload builds the EMVCo payload for a PromptPay QR code
+// target is a mobile number (0812345678), a 13-digit national/tax ID or a 15-digit e-wallet ID
+// amount is in satang, 0 produces a static QR without amount
+func GeneratePromptPayPayload(target string, amount int64) (string, error) {
+	if amount < 0 {
+		return "", errors.New("amount must not be negative")
+	}
+
+	tag, account, err := promptPayAccount(target)
+	if err != nil {
+		return "", err
+	}
+
+	initMethod := promptPayStaticQR
+	if amount > 0 {
+		initMethod = promptPayDynamicQR
+	}
+
+	var b strings.Builder
+	b.WriteString(emvField("00", "01")) // payload format indicator
+	b.WriteString(emvField("01", initMethod))
+	b.WriteString(emvField("29", emvField("00", promptPayAID)+emvField(tag, account)))
+	b.WriteString(emvField("53", promptPayCurrencyTHB))
+	if amount > 0 {
+		b.WriteString(emvField("54", fmt.Sprintf("%d.%02d", amount/100, amount%100)))
+	}
+	b.WriteString(emvField("58", promptPayCountryCode))
+
+	// CRC covers everything up to and including the CRC tag and length
+	b.WriteString("6304")
+	b.WriteString(fmt.Sprintf("%04X", crc16CCITT([]byte(b.String()))))
+
+	return b.String(), nil
+}
+
+// ValidatePromptPayID checks that target can receive PromptPay transfers
+func ValidatePromptPayID(target string) error {
+	_, _, err := promptPayAccount(target)
+	return err
+}
+
+// promptPayAccount normalizes the target into its merchant account sub-tag and value
+func promptPayAccount(target string) (string, string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, target)
+
+	switch {
+	case len(digits) == 10 && digits[0] == '0':
+		// Mobile number: 0066 country prefix, zero-padded to 13 digits
+		return promptPayTagPhone, "0066" + digits[1:], nil
+	case len(digits) == 13:
+		return promptPayTagNationalID, digits, nil
+	case len(digits) == 15:
+		return promptPayTagEWallet, digits, nil
+	}
+	return "", "", ErrInvalidPromptPayID
+}
+
+func emvField(id string, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// crc16CCITT computes CRC-16/CCITT-FALSE (poly 0x1021, init 0xFFFF) as required by EMVCo
+func crc16CCITT(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
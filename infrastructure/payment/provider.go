@@ -0,0 +1,65 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Webhook event types (normalized across providers)
+const (
+	EventChargePaid    = "charge.paid"
+	EventChargeExpired = "charge.expired"
+	EventChargeFailed  = "charge.failed"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrSignatureExpired = errors.New("webhook signature timestamp outside tolerance")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+)
+
+// PaymentProvider is implemented by every payment gateway adapter
+// Amounts are in satang (1 THB = 100 satang)
+type PaymentProvider interface {
+	// Name is the provider key used in webhook URLs (/webhooks/payments/:provider)
+	Name() string
+
+	// CreateCharge opens a charge the customer pays by scanning Charge.QRPayload
+	CreateCharge(ctx context.Context, req *ChargeRequest) (*Charge, error)
+
+	// ParseWebhook verifies the signature of an inbound webhook and normalizes its event
+	ParseWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
+
+// Simulator is implemented by providers that can fake a customer payment (local development and tests)
+type Simulator interface {
+	// SimulatePaid returns a signed charge.paid webhook payload and its signature header value
+	SimulatePaid(chargeID string, amount int64) (payload []byte, signature string, err error)
+}
+
+// ChargeRequest - Charge to open with the provider
+type ChargeRequest struct {
+	Reference   string // our intent ID, echoed back by the provider
+	Amount      int64
+	Currency    string
+	Description string
+	ExpiresAt   time.Time
+}
+
+// Charge - Charge opened with the provider
+type Charge struct {
+	ID        string // provider charge ID
+	QRPayload string // EMVCo PromptPay payload, rendered as a QR code by the client
+	ExpiresAt time.Time
+}
+
+// WebhookEvent - Normalized inbound webhook event
+type WebhookEvent struct {
+	ID         string    `json:"id"` // provider event ID
+	Type       string    `json:"type"`
+	ChargeID   string    `json:"chargeId"`
+	Amount     int64     `json:"amount"`
+	Currency   string    `json:"currency"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
@@ -0,0 +1,71 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the webhook signature: "t=<unix seconds>,v1=<hex HMAC-SHA256>"
+// The HMAC is computed over "<t>.<raw body>" with the provider webhook secret
+const SignatureHeader = "X-Payment-Signature"
+
+// SignatureTolerance - Max age of a signed webhook (replay protection)
+const SignatureTolerance = 5 * time.Minute
+
+// SignWebhook signs a webhook payload at the given time
+func SignWebhook(secret string, payload []byte, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, computeSignature(secret, timestamp, payload))
+}
+
+// VerifyWebhookSignature checks a signature header against the raw payload
+func VerifyWebhookSignature(secret string, header string, payload []byte, now time.Time) error {
+	if secret == "" || header == "" {
+		return ErrInvalidSignature
+	}
+
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	if timestamp == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	age := now.Sub(time.Unix(unix, 0))
+	if age > SignatureTolerance || age < -SignatureTolerance {
+		return ErrSignatureExpired
+	}
+
+	expected := computeSignature(secret, timestamp, payload)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func computeSignature(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
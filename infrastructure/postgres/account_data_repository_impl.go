@@ -0,0 +1,332 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+)
+
+type AccountDataRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAccountDataRepository(db *gorm.DB) repositories.AccountDataRepository {
+	return &AccountDataRepositoryImpl{db: db}
+}
+
+// ==================== Data exports ====================
+
+func (r *AccountDataRepositoryImpl) CreateExport(ctx context.Context, export *models.DataExport) error {
+	return r.db.WithContext(ctx).Omit("User").Create(export).Error
+}
+
+func (r *AccountDataRepositoryImpl) GetLatestExport(ctx context.Context, userID uuid.UUID) (*models.DataExport, error) {
+	var export models.DataExport
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+func (r *AccountDataRepositoryImpl) ListExportsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*models.DataExport, error) {
+	var exports []*models.DataExport
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+func (r *AccountDataRepositoryImpl) ListPendingExports(ctx context.Context, limit int) ([]*models.DataExport, error) {
+	var exports []*models.DataExport
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.DataExportStatusPending).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+func (r *AccountDataRepositoryImpl) MarkExportProcessing(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.DataExport{}).
+		Where("id = ? AND status = ?", id, models.DataExportStatusPending).
+		Updates(map[string]interface{}{
+			"status":     models.DataExportStatusProcessing,
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *AccountDataRepositoryImpl) MarkExportReady(ctx context.Context, id uuid.UUID, fileKey string, fileSize int64, completedAt, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.DataExport{}).
+		Where("id = ? AND status = ?", id, models.DataExportStatusProcessing).
+		Updates(map[string]interface{}{
+			"status":       models.DataExportStatusReady,
+			"file_key":     fileKey,
+			"file_size":    fileSize,
+			"completed_at": completedAt,
+			"expires_at":   expiresAt,
+			"updated_at":   time.Now(),
+		}).Error
+}
+
+func (r *AccountDataRepositoryImpl) MarkExportFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	if len(reason) > 500 {
+		reason = reason[:500]
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.DataExport{}).
+		Where("id = ? AND status = ?", id, models.DataExportStatusProcessing).
+		Updates(map[string]interface{}{
+			"status":     models.DataExportStatusFailed,
+			"error":      reason,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *AccountDataRepositoryImpl) ListExpiredExports(ctx context.Context, now time.Time, limit int) ([]*models.DataExport, error) {
+	var exports []*models.DataExport
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at <= ?", models.DataExportStatusReady, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&exports).Error
+	return exports, err
+}
+
+func (r *AccountDataRepositoryImpl) MarkExportExpired(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.DataExport{}).
+		Where("id = ? AND status = ?", id, models.DataExportStatusReady).
+		Updates(map[string]interface{}{
+			"status":     models.DataExportStatusExpired,
+			"file_key":   nil,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *AccountDataRepositoryImpl) ListMessagesByParticipant(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.Message, error) {
+	var messages []*models.Message
+	err := r.db.WithContext(ctx).
+		Where("sender_id = ? OR conversation_id IN (?)", userID,
+			r.db.Model(&models.ConversationParticipant{}).Select("conversation_id").Where("user_id = ?", userID)).
+		Order("created_at ASC, id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&messages).Error
+	return messages, err
+}
+
+// ==================== Account deletion ====================
+
+// Ad creatives and payment slips stay with the booking records
+const removableMediaCondition = `id NOT IN (SELECT media_id FROM ads) AND id NOT IN (SELECT slip_media_id FROM ad_payments)`
+
+func (r *AccountDataRepositoryImpl) ListRemovableMedia(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.Media, error) {
+	var media []*models.Media
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND "+removableMediaCondition, userID).
+		Order("created_at ASC, id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&media).Error
+	return media, err
+}
+
+func (r *AccountDataRepositoryImpl) ScheduleDeletion(ctx context.Context, userID uuid.UUID, scheduledAt *time.Time, forfeitBalance bool) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ? AND deleted_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"deletion_scheduled_at":     scheduledAt,
+			"deletion_forfeits_balance": forfeitBalance,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *AccountDataRepositoryImpl) ListDueDeletions(ctx context.Context, now time.Time, limit int) ([]*models.User, error) {
+	var users []*models.User
+	err := r.db.WithContext(ctx).
+		Where("deletion_scheduled_at <= ? AND deleted_at IS NULL", now).
+		Order("deletion_scheduled_at ASC").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+func (r *AccountDataRepositoryImpl) AnonymizeUser(ctx context.Context, userID uuid.UUID, deletedAt time.Time) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		// Communities the user leaves, captured before their membership rows go
+		var communityIDs []uuid.UUID
+		if err := tx.Model(&models.CommunityMember{}).
+			Where("user_id = ? AND role <> ?", userID, models.CommunityRoleOwner).
+			Pluck("community_id", &communityIDs).Error; err != nil {
+			return err
+		}
+
+		steps := []struct {
+			sql  string
+			args []interface{}
+		}{
+			// Votes: take the user's score back out of posts and comments
+			{`UPDATE posts SET votes = posts.votes - v.delta
+				FROM (SELECT target_id, SUM(CASE vote_type WHEN 'up' THEN 1 WHEN 'down' THEN -1 ELSE 0 END) AS delta
+					FROM votes WHERE user_id = ? AND target_type = 'post' GROUP BY target_id) v
+				WHERE posts.id = v.target_id`, []interface{}{userID}},
+			{`UPDATE comments SET votes = comments.votes - v.delta
+				FROM (SELECT target_id, SUM(CASE vote_type WHEN 'up' THEN 1 WHEN 'down' THEN -1 ELSE 0 END) AS delta
+					FROM votes WHERE user_id = ? AND target_type = 'comment' GROUP BY target_id) v
+				WHERE comments.id = v.target_id`, []interface{}{userID}},
+			{`DELETE FROM votes WHERE user_id = ?`, []interface{}{userID}},
+
+			// Follows: fix the counters of the other side, then drop both directions
+			{`UPDATE users SET followers_count = GREATEST(followers_count - 1, 0)
+				WHERE id IN (SELECT following_id FROM follows WHERE follower_id = ?)`, []interface{}{userID}},
+			{`UPDATE users SET following_count = GREATEST(following_count - 1, 0)
+				WHERE id IN (SELECT follower_id FROM follows WHERE following_id = ?)`, []interface{}{userID}},
+			{`DELETE FROM follows WHERE follower_id = ? OR following_id = ?`, []interface{}{userID, userID}},
+			{`DELETE FROM follow_requests WHERE requester_id = ? OR target_id = ?`, []interface{}{userID, userID}},
+
+			// Comments: keep the threads, drop the text
+			{`UPDATE posts SET comment_count = GREATEST(posts.comment_count - c.cnt, 0)
+				FROM (SELECT post_id, COUNT(*) AS cnt FROM comments
+					WHERE author_id = ? AND is_deleted = false GROUP BY post_id) c
+				WHERE posts.id = c.post_id`, []interface{}{userID}},
+			{`UPDATE comments SET content = '[deleted]', is_deleted = true, deleted_at = COALESCE(deleted_at, ?)
+				WHERE author_id = ?`, []interface{}{deletedAt, userID}},
+
+			// Posts: soft delete with the text scrubbed
+			{`UPDATE communities SET post_count = GREATEST(communities.post_count - p.cnt, 0)
+				FROM (SELECT community_id, COUNT(*) AS cnt FROM posts
+					WHERE author_id = ? AND is_deleted = false AND community_id IS NOT NULL GROUP BY community_id) p
+				WHERE communities.id = p.community_id`, []interface{}{userID}},
+			{`UPDATE posts SET title = '[deleted]', content = '[deleted]', is_deleted = true, deleted_at = COALESCE(deleted_at, ?)
+				WHERE author_id = ?`, []interface{}{deletedAt, userID}},
+
+			// Subscriptions: end them both ways (no more renewals charged to or paid out for a deleted account)
+			{`UPDATE subscriptions SET status = ?, cancel_at_period_end = true, cancelled_at = COALESCE(cancelled_at, ?),
+					cancel_reason = ?, pending_tier_id = NULL, updated_at = ?
+				WHERE (subscriber_id = ? OR creator_id = ?) AND status <> ?`,
+				[]interface{}{models.SubscriptionStatusExpired, deletedAt, "account deleted", deletedAt, userID, userID, models.SubscriptionStatusExpired}},
+			{`UPDATE subscription_tiers SET is_active = false, updated_at = ? WHERE creator_id = ?`, []interface{}{deletedAt, userID}},
+
+			// Unlock campaigns: release the contributors' pending holds (the user's own wallet is empty by now)
+			{`UPDATE wallets SET held_amount = wallets.held_amount - h.amount, updated_at = ?
+				FROM (SELECT wallet_holds.wallet_id, SUM(wallet_holds.amount) AS amount FROM wallet_holds
+					JOIN post_unlock_contributions c ON c.hold_id = wallet_holds.id
+					JOIN posts ON posts.id = c.post_id
+					WHERE posts.author_id = ? AND c.status = ? AND wallet_holds.status = ?
+					GROUP BY wallet_holds.wallet_id) h
+				WHERE wallets.id = h.wallet_id`,
+				[]interface{}{deletedAt, userID, models.PostUnlockContributionHeld, models.WalletHoldStatusActive}},
+			{`UPDATE wallet_holds SET status = ?, resolved_at = ?, updated_at = ?
+				FROM post_unlock_contributions c JOIN posts ON posts.id = c.post_id
+				WHERE c.hold_id = wallet_holds.id AND posts.author_id = ? AND c.status = ? AND wallet_holds.status = ?`,
+				[]interface{}{models.WalletHoldStatusReleased, deletedAt, deletedAt, userID, models.PostUnlockContributionHeld, models.WalletHoldStatusActive}},
+			{`UPDATE post_unlock_contributions SET status = ?, updated_at = ?
+				WHERE status = ? AND post_id IN (SELECT id FROM posts WHERE author_id = ?)`,
+				[]interface{}{models.PostUnlockContributionReleased, deletedAt, models.PostUnlockContributionHeld, userID}},
+
+			// Messages: the other participant keeps the conversation, without the user's words or edit history
+			// (a message needs content or media, so the text becomes a placeholder like comments and posts)
+			{`DELETE FROM message_revisions WHERE message_id IN (SELECT id FROM messages WHERE sender_id = ?)`, []interface{}{userID}},
+			{`UPDATE messages SET content = '[deleted]', media = NULL, updated_at = ? WHERE sender_id = ?`, []interface{}{deletedAt, userID}},
+
+			// Group chats: leave them, passing ownership to the longest-standing admin (or member), and drop empty groups
+			{`DELETE FROM conversation_participants WHERE user_id = ?
+				AND conversation_id IN (SELECT id FROM conversations WHERE type = ?)`, []interface{}{userID, models.ConversationTypeGroup}},
+			{`UPDATE conversation_participants SET role = ?
+				FROM (SELECT DISTINCT ON (conversation_id) conversation_id, user_id FROM conversation_participants
+					WHERE conversation_id IN (SELECT id FROM conversations WHERE type = ?)
+					AND conversation_id NOT IN (SELECT conversation_id FROM conversation_participants WHERE role = ?)
+					ORDER BY conversation_id, CASE WHEN role = ? THEN 0 ELSE 1 END, joined_at) heir
+				WHERE conversation_participants.conversation_id = heir.conversation_id
+				AND conversation_participants.user_id = heir.user_id`, []interface{}{models.ConversationRoleOwner, models.ConversationTypeGroup, models.ConversationRoleOwner, models.ConversationRoleAdmin}},
+			{`DELETE FROM conversations WHERE type = ?
+				AND id NOT IN (SELECT conversation_id FROM conversation_participants)`, []interface{}{models.ConversationTypeGroup}},
+
+			// Personal rows
+			{`DELETE FROM notifications WHERE user_id = ? OR sender_id = ?`, []interface{}{userID, userID}},
+			{`DELETE FROM saved_posts WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM search_history WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM push_subscriptions WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM notification_settings WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM user_identities WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM user_recovery_codes WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM user_totp WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM user_tokens WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM api_tokens WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM user_roles WHERE user_id = ?`, []interface{}{userID}},
+			{`DELETE FROM blocks WHERE blocker_id = ? OR blocked_id = ?`, []interface{}{userID, userID}},
+
+			// Community memberships (owned communities keep their owner row until transferred)
+			{`DELETE FROM community_members WHERE user_id = ? AND role <> ?`, []interface{}{userID, models.CommunityRoleOwner}},
+			{`UPDATE communities SET member_count = (
+					SELECT COUNT(*) FROM community_members
+					WHERE community_members.community_id = communities.id AND community_members.status = ?
+				) WHERE communities.id IN ?`, []interface{}{models.CommunityMemberStatusActive, communityIDs}},
+
+			// Media rows (the caller deletes the files once this commits; post_media links cascade)
+			{`DELETE FROM media WHERE user_id = ? AND ` + removableMediaCondition, []interface{}{userID}},
+		}
+
+		for i, step := range steps {
+			if err := tx.Exec(step.sql, step.args...).Error; err != nil {
+				return fmt.Errorf("anonymize step %d: %w", i+1, err)
+			}
+		}
+
+		// Profile: the row stays (ledgers, messages and threads reference it) but identifies nobody
+		short := userID.String()[:8]
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND deleted_at IS NULL", userID).
+			Updates(map[string]interface{}{
+				"email":                     fmt.Sprintf("deleted+%s@users.invalid", userID),
+				"username":                  "deleted_" + short,
+				"password":                  "",
+				"display_name":              "Deleted user",
+				"avatar":                    "",
+				"bio":                       "",
+				"location":                  "",
+				"website":                   "",
+				"is_active":                 false,
+				"is_private":                false,
+				"email_verified":            false,
+				"two_factor_enabled":        false,
+				"followers_count":           0,
+				"following_count":           0,
+				"deletion_scheduled_at":     nil,
+				"deletion_forfeits_balance": false,
+				"deleted_at":                deletedAt,
+				"updated_at":                deletedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+var _ repositories.AccountDataRepository = (*AccountDataRepositoryImpl)(nil)
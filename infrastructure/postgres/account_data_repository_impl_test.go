@@ -0,0 +1,221 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofiber-template/application/serviceimpl"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/database"
+	"gofiber-template/pkg/testutil"
+	"gorm.io/datatypes"
+)
+
+// sessionStub revokes nothing (only RevokeAllSessions is used by the deletion job)
+type sessionStub struct {
+	services.SessionService
+}
+
+func (sessionStub) RevokeAllSessions(ctx context.Context, userID uuid.UUID, exceptSessionID *uuid.UUID, reason string) (int, error) {
+	return 0, nil
+}
+
+type accountDataTestEnv struct {
+	accountDataRepo    *AccountDataRepositoryImpl
+	userRepo           *UserRepositoryImpl
+	messageRepo        *MessageRepositoryImpl
+	conversationRepo   *ConversationRepositoryImpl
+	walletRepo         *WalletRepositoryImpl
+	walletService      services.WalletService
+	accountDataService services.AccountDataService
+}
+
+func setupAccountDataTest(t *testing.T) (*accountDataTestEnv, func()) {
+	// Get test database
+	db, err := config.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+
+	env := &accountDataTestEnv{
+		accountDataRepo:  &AccountDataRepositoryImpl{db: db},
+		userRepo:         &UserRepositoryImpl{db: db},
+		messageRepo:      &MessageRepositoryImpl{db: db},
+		conversationRepo: &ConversationRepositoryImpl{db: db},
+		walletRepo:       &WalletRepositoryImpl{db: db},
+	}
+	env.walletService = serviceimpl.NewWalletService(env.walletRepo)
+	env.accountDataService = serviceimpl.NewAccountDataService(
+		database.NewTransactionManager(db),
+		env.accountDataRepo,
+		env.userRepo,
+		&PostRepositoryImpl{db: db},
+		&CommentRepositoryImpl{db: db},
+		&VoteRepositoryImpl{db: db},
+		&MediaRepositoryImpl{db: db},
+		&NotificationRepositoryImpl{db: db},
+		env.walletRepo,
+		env.walletService,
+		sessionStub{},
+		silentNotifications{},
+		nil, // no R2
+		nil, // no Bunny Storage
+		nil, // no Bunny Stream
+	)
+
+	// Cleanup function
+	cleanup := func() {
+		err := config.CleanTestDB(db)
+		assert.NoError(t, err, "Failed to clean test database")
+	}
+
+	return env, cleanup
+}
+
+// testCommunity creates a community owned by ownerID with the given members and stored member count
+func testCommunity(t *testing.T, env *accountDataTestEnv, ownerID uuid.UUID, memberIDs []uuid.UUID, memberCount int) *models.Community {
+	community := &models.Community{
+		Slug:        "test-" + uuid.NewString()[:8],
+		Name:        "Test",
+		Visibility:  models.CommunityVisibilityPublic,
+		OwnerID:     ownerID,
+		MemberCount: memberCount,
+	}
+	require.NoError(t, env.userRepo.db.Omit("Owner", "IconMedia", "BannerMedia").Create(community).Error)
+
+	now := time.Now()
+	require.NoError(t, env.userRepo.db.Omit("User").Create(&models.CommunityMember{
+		CommunityID: community.ID,
+		UserID:      ownerID,
+		Role:        models.CommunityRoleOwner,
+		Status:      models.CommunityMemberStatusActive,
+		JoinedAt:    &now,
+	}).Error)
+	for _, memberID := range memberIDs {
+		require.NoError(t, env.userRepo.db.Omit("User").Create(&models.CommunityMember{
+			CommunityID: community.ID,
+			UserID:      memberID,
+			Role:        models.CommunityRoleMember,
+			Status:      models.CommunityMemberStatusActive,
+			JoinedAt:    &now,
+		}).Error)
+	}
+	return community
+}
+
+func TestAccountData_AnonymizeUserScrubsMessages(t *testing.T) {
+	env, cleanup := setupAccountDataTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	text := editedMessage(t, ctx, env.messageRepo, env.userRepo, env.conversationRepo)
+	userID, otherID := text.SenderID, *text.ReceiverID
+
+	// A photo without a caption and the other participant's reply
+	photo := testutil.CreateTestMessage(text.ConversationID, userID, otherID)
+	photo.Type = models.MessageTypeImage
+	photo.Content = nil
+	photo.Media = datatypes.JSON(`[{"url":"https://example.com/photo.jpg","type":"image"}]`)
+	require.NoError(t, env.messageRepo.Create(ctx, photo))
+
+	reply := testutil.CreateTestMessage(text.ConversationID, otherID, userID)
+	require.NoError(t, env.messageRepo.Create(ctx, reply))
+
+	// The user leaves one community; the other one's (stale) counter is not theirs to fix
+	joined := testCommunity(t, env, otherID, []uuid.UUID{userID}, 2)
+	unrelated := testCommunity(t, env, otherID, nil, 42)
+
+	// Act
+	err := env.accountDataRepo.AnonymizeUser(ctx, userID, time.Now())
+
+	// Assert
+	require.NoError(t, err)
+
+	for _, id := range []uuid.UUID{text.ID, photo.ID} {
+		stored, err := env.messageRepo.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, stored.Content)
+		assert.Equal(t, "[deleted]", *stored.Content)
+		assert.Empty(t, stored.Media)
+		assert.Nil(t, stored.RemovedAt)
+	}
+
+	revisions, err := env.messageRepo.ListRevisions(ctx, text.ID)
+	require.NoError(t, err)
+	assert.Empty(t, revisions, "the edit history holds the user's words too")
+
+	storedReply, err := env.messageRepo.GetByID(ctx, reply.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *reply.Content, *storedReply.Content)
+
+	var communities []models.Community
+	require.NoError(t, env.userRepo.db.Where("id IN ?", []uuid.UUID{joined.ID, unrelated.ID}).Find(&communities).Error)
+	for _, community := range communities {
+		if community.ID == joined.ID {
+			assert.Equal(t, 1, community.MemberCount)
+		} else {
+			assert.Equal(t, 42, community.MemberCount)
+		}
+	}
+
+	user, err := env.userRepo.GetByID(ctx, userID)
+	require.NoError(t, err)
+	assert.NotNil(t, user.DeletedAt)
+	assert.True(t, strings.HasPrefix(user.Username, "deleted_"))
+}
+
+func TestAccountData_ProcessDeletionsForfeitsConsentedBalance(t *testing.T) {
+	env, cleanup := setupAccountDataTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	forfeited, err := env.walletService.GetSystemWallet(ctx, models.SystemWalletForfeited)
+	require.NoError(t, err)
+	forfeitedBefore := forfeited.Balance
+
+	// Both balances are below the payout minimum; only the first user agreed to give theirs up
+	consenting := fundedWallet(t, ctx, env.walletService, env.userRepo, 30000)
+	keeping := fundedWallet(t, ctx, env.walletService, env.userRepo, 20000)
+
+	due := time.Now().Add(-time.Minute)
+	require.NoError(t, env.accountDataRepo.ScheduleDeletion(ctx, *consenting.UserID, &due, true))
+	require.NoError(t, env.accountDataRepo.ScheduleDeletion(ctx, *keeping.UserID, &due, false))
+
+	// Act
+	deleted, err := env.accountDataService.ProcessDeletions(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	consentingAfter, err := env.walletRepo.GetByID(ctx, consenting.ID)
+	require.NoError(t, err)
+	assert.Zero(t, consentingAfter.Balance)
+
+	forfeitedAfter, err := env.walletRepo.GetByID(ctx, forfeited.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(30000), forfeitedAfter.Balance-forfeitedBefore)
+
+	consentingUser, err := env.userRepo.GetByID(ctx, *consenting.UserID)
+	require.NoError(t, err)
+	assert.NotNil(t, consentingUser.DeletedAt)
+
+	// Without consent the deletion is cancelled and the money stays
+	keepingAfter, err := env.walletRepo.GetByID(ctx, keeping.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20000), keepingAfter.Balance)
+
+	keepingUser, err := env.userRepo.GetByID(ctx, *keeping.UserID)
+	require.NoError(t, err)
+	assert.Nil(t, keepingUser.DeletedAt)
+	assert.Nil(t, keepingUser.DeletionScheduledAt)
+	assert.Zero(t, sumAllEntries(t, env.walletRepo))
+}
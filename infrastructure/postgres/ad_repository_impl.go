@@ -0,0 +1,297 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+)
+
+// Ad statuses that hold a slot of their placement
+var adBookedStatuses = []string{models.AdStatusScheduled, models.AdStatusActive, models.AdStatusPaused}
+
+type AdRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAdRepository(db *gorm.DB) repositories.AdRepository {
+	return &AdRepositoryImpl{db: db}
+}
+
+// ==================== Placements ====================
+
+func (r *AdRepositoryImpl) ListPlacements(ctx context.Context, activeOnly bool) ([]*models.AdPlacement, error) {
+	var placements []*models.AdPlacement
+	query := r.db.WithContext(ctx)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("price_per_day ASC").Find(&placements).Error
+	return placements, err
+}
+
+func (r *AdRepositoryImpl) GetPlacementByCode(ctx context.Context, code string) (*models.AdPlacement, error) {
+	var placement models.AdPlacement
+	err := r.db.WithContext(ctx).First(&placement, "code = ?", code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &placement, nil
+}
+
+func (r *AdRepositoryImpl) UpdatePlacement(ctx context.Context, placement *models.AdPlacement) error {
+	return r.db.WithContext(ctx).Save(placement).Error
+}
+
+// ==================== Ads ====================
+
+func (r *AdRepositoryImpl) Create(ctx context.Context, ad *models.Ad) error {
+	return r.db.WithContext(ctx).Omit("Advertiser", "Placement", "Media").Create(ad).Error
+}
+
+func (r *AdRepositoryImpl) Update(ctx context.Context, ad *models.Ad) error {
+	// Counters are only changed by the tracking queries
+	return r.db.WithContext(ctx).
+		Omit("Advertiser", "Placement", "Media", "ImpressionCount", "ClickCount").
+		Save(ad).Error
+}
+
+func (r *AdRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Ad, error) {
+	var ad models.Ad
+	err := r.db.WithContext(ctx).
+		Preload("Advertiser").
+		Preload("Placement").
+		Preload("Media").
+		First(&ad, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ad, nil
+}
+
+func (r *AdRepositoryImpl) ListByAdvertiser(ctx context.Context, advertiserID uuid.UUID, status string, offset, limit int) ([]*models.Ad, error) {
+	var ads []*models.Ad
+	query := r.db.WithContext(ctx).
+		Preload("Placement").
+		Preload("Media").
+		Where("advertiser_id = ?", advertiserID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&ads).Error
+	return ads, err
+}
+
+func (r *AdRepositoryImpl) CountByAdvertiser(ctx context.Context, advertiserID uuid.UUID, status string) (int64, error) {
+	var count int64
+	query := r.db.WithContext(ctx).
+		Model(&models.Ad{}).
+		Where("advertiser_id = ?", advertiserID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+func (r *AdRepositoryImpl) CountPaidByAdvertiser(ctx context.Context, advertiserID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.AdPayment{}).
+		Where("advertiser_id = ? AND status = ?", advertiserID, models.AdPaymentVerified).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *AdRepositoryImpl) CountBookedInPeriod(ctx context.Context, placementID uuid.UUID, startsAt, endsAt time.Time, excludeAdID *uuid.UUID) (int64, error) {
+	var count int64
+	query := r.db.WithContext(ctx).
+		Model(&models.Ad{}).
+		Where("placement_id = ? AND status IN ?", placementID, adBookedStatuses).
+		Where("starts_at < ? AND ends_at > ?", endsAt, startsAt)
+	if excludeAdID != nil {
+		query = query.Where("id <> ?", *excludeAdID)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// ==================== Serving ====================
+
+func (r *AdRepositoryImpl) ListServable(ctx context.Context, placementID uuid.UUID, now time.Time) ([]*models.Ad, error) {
+	var ads []*models.Ad
+	err := r.db.WithContext(ctx).
+		Preload("Advertiser").
+		Preload("Media").
+		Where("placement_id = ? AND status = ?", placementID, models.AdStatusActive).
+		Where("starts_at <= ? AND ends_at > ?", now, now).
+		Find(&ads).Error
+	return ads, err
+}
+
+func (r *AdRepositoryImpl) GetFrequencyCappedAdIDs(ctx context.Context, userID uuid.UUID, since time.Time, maxImpressions int) ([]uuid.UUID, error) {
+	var adIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&models.AdImpression{}).
+		Where("user_id = ? AND viewed_at >= ?", userID, since).
+		Group("ad_id").
+		Having("COUNT(*) >= ?", maxImpressions).
+		Pluck("ad_id", &adIDs).Error
+	return adIDs, err
+}
+
+// ==================== Payments ====================
+
+func (r *AdRepositoryImpl) CreatePayment(ctx context.Context, payment *models.AdPayment) error {
+	return r.db.WithContext(ctx).Omit("Ad", "Advertiser", "SlipMedia").Create(payment).Error
+}
+
+func (r *AdRepositoryImpl) UpdatePayment(ctx context.Context, payment *models.AdPayment) error {
+	return r.db.WithContext(ctx).Omit("Ad", "Advertiser", "SlipMedia").Save(payment).Error
+}
+
+func (r *AdRepositoryImpl) GetPaymentByID(ctx context.Context, id uuid.UUID) (*models.AdPayment, error) {
+	var payment models.AdPayment
+	err := r.db.WithContext(ctx).
+		Preload("Ad").
+		Preload("Ad.Placement").
+		Preload("Ad.Media").
+		Preload("Advertiser").
+		Preload("SlipMedia").
+		First(&payment, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *AdRepositoryImpl) GetLatestPayment(ctx context.Context, adID uuid.UUID) (*models.AdPayment, error) {
+	var payment models.AdPayment
+	err := r.db.WithContext(ctx).
+		Preload("SlipMedia").
+		Where("ad_id = ?", adID).
+		Order("created_at DESC").
+		First(&payment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *AdRepositoryImpl) ListPaymentsByStatus(ctx context.Context, status string, offset, limit int) ([]*models.AdPayment, error) {
+	var payments []*models.AdPayment
+	err := r.db.WithContext(ctx).
+		Preload("Ad").
+		Preload("Ad.Placement").
+		Preload("Ad.Media").
+		Preload("Advertiser").
+		Preload("SlipMedia").
+		Where("status = ?", status).
+		Order("created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&payments).Error
+	return payments, err
+}
+
+func (r *AdRepositoryImpl) CountPaymentsByStatus(ctx context.Context, status string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.AdPayment{}).
+		Where("status = ?", status).
+		Count(&count).Error
+	return count, err
+}
+
+// ==================== Tracking ====================
+
+func (r *AdRepositoryImpl) CreateImpression(ctx context.Context, impression *models.AdImpression) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Create(impression).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Ad{}).
+			Where("id = ?", impression.AdID).
+			UpdateColumn("impression_count", gorm.Expr("impression_count + 1")).Error
+	})
+}
+
+func (r *AdRepositoryImpl) GetImpressionByID(ctx context.Context, id uuid.UUID) (*models.AdImpression, error) {
+	var impression models.AdImpression
+	err := r.db.WithContext(ctx).First(&impression, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &impression, nil
+}
+
+func (r *AdRepositoryImpl) CreateClick(ctx context.Context, click *models.AdClick) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Create(click).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Ad{}).
+			Where("id = ?", click.AdID).
+			UpdateColumn("click_count", gorm.Expr("click_count + 1")).Error
+	})
+}
+
+func (r *AdRepositoryImpl) CountReach(ctx context.Context, adID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.AdImpression{}).
+		Where("ad_id = ? AND user_id IS NOT NULL", adID).
+		Distinct("user_id").
+		Count(&count).Error
+	return count, err
+}
+
+func (r *AdRepositoryImpl) GetDailyCounts(ctx context.Context, adID uuid.UUID) ([]*repositories.AdDailyCount, error) {
+	var counts []*repositories.AdDailyCount
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT day AS date, SUM(impressions) AS impressions, SUM(clicks) AS clicks
+		FROM (
+			SELECT DATE(viewed_at AT TIME ZONE 'Asia/Bangkok') AS day, 1 AS impressions, 0 AS clicks
+			FROM ad_impressions WHERE ad_id = ?
+			UNION ALL
+			SELECT DATE(clicked_at AT TIME ZONE 'Asia/Bangkok') AS day, 0 AS impressions, 1 AS clicks
+			FROM ad_clicks WHERE ad_id = ?
+		) AS events
+		GROUP BY day
+		ORDER BY day ASC`, adID, adID).
+		Scan(&counts).Error
+	return counts, err
+}
+
+// ==================== Scheduler ====================
+
+func (r *AdRepositoryImpl) ListDueForActivation(ctx context.Context, now time.Time, limit int) ([]*models.Ad, error) {
+	var ads []*models.Ad
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND starts_at <= ? AND ends_at > ?", models.AdStatusScheduled, now, now).
+		Order("starts_at ASC").
+		Limit(limit).
+		Find(&ads).Error
+	return ads, err
+}
+
+func (r *AdRepositoryImpl) ListDueForCompletion(ctx context.Context, now time.Time, limit int) ([]*models.Ad, error) {
+	var ads []*models.Ad
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND ends_at <= ?", adBookedStatuses, now).
+		Order("ends_at ASC").
+		Limit(limit).
+		Find(&ads).Error
+	return ads, err
+}
+
+// Ensure interface compliance
+var _ repositories.AdRepository = (*AdRepositoryImpl)(nil)
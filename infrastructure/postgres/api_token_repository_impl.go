@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type APITokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) repositories.APITokenRepository {
+	return &APITokenRepositoryImpl{db: db}
+}
+
+func (r *APITokenRepositoryImpl) Create(ctx context.Context, token *models.APIToken) error {
+	return r.db.WithContext(ctx).Omit("User").Create(token).Error
+}
+
+func (r *APITokenRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.APIToken, error) {
+	var token models.APIToken
+	err := r.db.WithContext(ctx).First(&token, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *APITokenRepositoryImpl) GetByHash(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	var token models.APIToken
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		First(&token, "token_hash = ?", tokenHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *APITokenRepositoryImpl) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.APIToken, error) {
+	var tokens []*models.APIToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+func (r *APITokenRepositoryImpl) CountActiveByUser(ctx context.Context, userID uuid.UUID, kind string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.APIToken{}).
+		Where("user_id = ? AND kind = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, kind, time.Now()).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *APITokenRepositoryImpl) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&models.APIToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *APITokenRepositoryImpl) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time, ip string, interval time.Duration) error {
+	return r.db.WithContext(ctx).
+		Model(&models.APIToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-interval)).
+		UpdateColumns(map[string]interface{}{
+			"last_used_at": at,
+			"last_used_ip": ip,
+		}).Error
+}
+
+var _ repositories.APITokenRepository = (*APITokenRepositoryImpl)(nil)
@@ -52,11 +52,25 @@ func (r *CommentRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error
 		}).Error
 }
 
-func (r *CommentRepositoryImpl) ListByPost(ctx context.Context, postID uuid.UUID, offset, limit int, sortBy repositories.CommentSortBy) ([]*models.Comment, error) {
+func (r *CommentRepositoryImpl) Remove(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID, reason string) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&models.Comment{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"is_deleted":     true,
+			"deleted_at":     now,
+			"removed_by_id":  moderatorID,
+			"removal_reason": reason,
+		}).Error
+}
+
+func (r *CommentRepositoryImpl) ListByPost(ctx context.Context, postID uuid.UUID, offset, limit int, sortBy repositories.CommentSortBy, viewerID *uuid.UUID) ([]*models.Comment, error) {
 	var comments []*models.Comment
 	query := r.db.WithContext(ctx).
 		Preload("Author").
-		Where("post_id = ? AND parent_id IS NULL AND is_deleted = ?", postID, false)
+		Where("post_id = ? AND parent_id IS NULL AND is_deleted = ?", postID, false).
+		Scopes(commentShadowVisibility(viewerID))
 
 	switch sortBy {
 	case repositories.CommentSortByHot:
@@ -75,24 +89,26 @@ func (r *CommentRepositoryImpl) ListByPost(ctx context.Context, postID uuid.UUID
 	return comments, err
 }
 
-func (r *CommentRepositoryImpl) ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int) ([]*models.Comment, error) {
+func (r *CommentRepositoryImpl) ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int, viewerID *uuid.UUID) ([]*models.Comment, error) {
 	var comments []*models.Comment
 	err := r.db.WithContext(ctx).
 		Preload("Author").
 		Preload("Post").
 		Preload("Post.Author").
 		Where("author_id = ? AND is_deleted = ?", authorID, false).
+		Scopes(commentShadowVisibility(viewerID)).
 		Order("created_at DESC").
 		Offset(offset).Limit(limit).
 		Find(&comments).Error
 	return comments, err
 }
 
-func (r *CommentRepositoryImpl) ListReplies(ctx context.Context, parentID uuid.UUID, offset, limit int, sortBy repositories.CommentSortBy) ([]*models.Comment, error) {
+func (r *CommentRepositoryImpl) ListReplies(ctx context.Context, parentID uuid.UUID, offset, limit int, sortBy repositories.CommentSortBy, viewerID *uuid.UUID) ([]*models.Comment, error) {
 	var comments []*models.Comment
 	query := r.db.WithContext(ctx).
 		Preload("Author").
-		Where("parent_id = ? AND is_deleted = ?", parentID, false)
+		Where("parent_id = ? AND is_deleted = ?", parentID, false).
+		Scopes(commentShadowVisibility(viewerID))
 
 	switch sortBy {
 	case repositories.CommentSortByHot:
@@ -111,12 +127,13 @@ func (r *CommentRepositoryImpl) ListReplies(ctx context.Context, parentID uuid.U
 	return comments, err
 }
 
-func (r *CommentRepositoryImpl) GetCommentTree(ctx context.Context, postID uuid.UUID, maxDepth int) ([]*models.Comment, error) {
+func (r *CommentRepositoryImpl) GetCommentTree(ctx context.Context, postID uuid.UUID, maxDepth int, viewerID *uuid.UUID) ([]*models.Comment, error) {
 	var comments []*models.Comment
 	// Get all comments for the post up to maxDepth
 	err := r.db.WithContext(ctx).
 		Preload("Author").
 		Where("post_id = ? AND is_deleted = ? AND depth <= ?", postID, false, maxDepth).
+		Scopes(commentShadowVisibility(viewerID)).
 		Order("depth ASC, created_at ASC").
 		Find(&comments).Error
 	return comments, err
@@ -160,29 +177,32 @@ func (r *CommentRepositoryImpl) Count(ctx context.Context) (int64, error) {
 	return count, err
 }
 
-func (r *CommentRepositoryImpl) CountByPost(ctx context.Context, postID uuid.UUID) (int64, error) {
+func (r *CommentRepositoryImpl) CountByPost(ctx context.Context, postID uuid.UUID, viewerID *uuid.UUID) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&models.Comment{}).
 		Where("post_id = ? AND parent_id IS NULL AND is_deleted = ?", postID, false).
+		Scopes(commentShadowVisibility(viewerID)).
 		Count(&count).Error
 	return count, err
 }
 
-func (r *CommentRepositoryImpl) CountByAuthor(ctx context.Context, authorID uuid.UUID) (int64, error) {
+func (r *CommentRepositoryImpl) CountByAuthor(ctx context.Context, authorID uuid.UUID, viewerID *uuid.UUID) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&models.Comment{}).
 		Where("author_id = ? AND is_deleted = ?", authorID, false).
+		Scopes(commentShadowVisibility(viewerID)).
 		Count(&count).Error
 	return count, err
 }
 
-func (r *CommentRepositoryImpl) CountReplies(ctx context.Context, parentID uuid.UUID) (int64, error) {
+func (r *CommentRepositoryImpl) CountReplies(ctx context.Context, parentID uuid.UUID, viewerID *uuid.UUID) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&models.Comment{}).
 		Where("parent_id = ? AND is_deleted = ?", parentID, false).
+		Scopes(commentShadowVisibility(viewerID)).
 		Count(&count).Error
 	return count, err
 }
@@ -196,11 +216,12 @@ func (r *CommentRepositoryImpl) UpdateVoteCount(ctx context.Context, commentID u
 
 // hotScoreSQL generates SQL for hot score calculation: votes / (hours + 2)^1.5
 // Cursor-based pagination methods
-func (r *CommentRepositoryImpl) ListByPostWithCursor(ctx context.Context, postID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy repositories.CommentSortBy) ([]*models.Comment, error) {
+func (r *CommentRepositoryImpl) ListByPostWithCursor(ctx context.Context, postID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy repositories.CommentSortBy, viewerID *uuid.UUID) ([]*models.Comment, error) {
 	var comments []*models.Comment
 	query := r.db.WithContext(ctx).
 		Preload("Author").
-		Where("post_id = ? AND parent_id IS NULL AND is_deleted = ?", postID, false)
+		Where("post_id = ? AND parent_id IS NULL AND is_deleted = ?", postID, false).
+		Scopes(commentShadowVisibility(viewerID))
 
 	if cursor != nil {
 		switch sortBy {
@@ -234,13 +255,14 @@ func (r *CommentRepositoryImpl) ListByPostWithCursor(ctx context.Context, postID
 	return comments, err
 }
 
-func (r *CommentRepositoryImpl) ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int) ([]*models.Comment, error) {
+func (r *CommentRepositoryImpl) ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int, viewerID *uuid.UUID) ([]*models.Comment, error) {
 	var comments []*models.Comment
 	query := r.db.WithContext(ctx).
 		Preload("Author").
 		Preload("Post").
 		Preload("Post.Author").
-		Where("author_id = ? AND is_deleted = ?", authorID, false)
+		Where("author_id = ? AND is_deleted = ?", authorID, false).
+		Scopes(commentShadowVisibility(viewerID))
 
 	if cursor != nil {
 		query = query.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
@@ -250,11 +272,12 @@ func (r *CommentRepositoryImpl) ListByAuthorWithCursor(ctx context.Context, auth
 	return comments, err
 }
 
-func (r *CommentRepositoryImpl) ListRepliesWithCursor(ctx context.Context, parentID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy repositories.CommentSortBy) ([]*models.Comment, error) {
+func (r *CommentRepositoryImpl) ListRepliesWithCursor(ctx context.Context, parentID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy repositories.CommentSortBy, viewerID *uuid.UUID) ([]*models.Comment, error) {
 	var comments []*models.Comment
 	query := r.db.WithContext(ctx).
 		Preload("Author").
-		Where("parent_id = ? AND is_deleted = ?", parentID, false)
+		Where("parent_id = ? AND is_deleted = ?", parentID, false).
+		Scopes(commentShadowVisibility(viewerID))
 
 	if cursor != nil {
 		switch sortBy {
@@ -295,4 +318,21 @@ func (r *CommentRepositoryImpl) hotScoreSQL() string {
 	)
 }
 
+func (r *CommentRepositoryImpl) SetShadowedByAuthor(ctx context.Context, authorID uuid.UUID, shadowed bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Comment{}).
+		Where("author_id = ? AND is_shadowed = ?", authorID, !shadowed).
+		Update("is_shadowed", shadowed).Error
+}
+
+// commentShadowVisibility hides comments written while the author was shadowbanned from everyone but the author.
+func commentShadowVisibility(viewerID *uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == nil {
+			return db.Where("comments.is_shadowed = ?", false)
+		}
+		return db.Where("(comments.is_shadowed = ? OR comments.author_id = ?)", false, *viewerID)
+	}
+}
+
 var _ repositories.CommentRepository = (*CommentRepositoryImpl)(nil)
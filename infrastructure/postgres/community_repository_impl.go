@@ -0,0 +1,207 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+)
+
+// communityMemberRoleOrderSQL lists owners first, then moderators, then members
+const communityMemberRoleOrderSQL = "CASE community_members.role WHEN 'owner' THEN 0 WHEN 'moderator' THEN 1 ELSE 2 END"
+
+type CommunityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCommunityRepository(db *gorm.DB) repositories.CommunityRepository {
+	return &CommunityRepositoryImpl{db: db}
+}
+
+// ==================== Communities ====================
+
+func (r *CommunityRepositoryImpl) Create(ctx context.Context, community *models.Community, owner *models.CommunityMember) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Omit("Owner", "IconMedia", "BannerMedia").Create(community).Error; err != nil {
+			return err
+		}
+		owner.CommunityID = community.ID
+		return tx.Omit("User").Create(owner).Error
+	})
+}
+
+func (r *CommunityRepositoryImpl) Update(ctx context.Context, community *models.Community) error {
+	// Counters are maintained by SyncMemberCount / IncrementPostCount
+	return r.db.WithContext(ctx).
+		Omit("Owner", "IconMedia", "BannerMedia", "MemberCount", "PostCount").
+		Save(community).Error
+}
+
+func (r *CommunityRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Community, error) {
+	var community models.Community
+	err := r.db.WithContext(ctx).
+		Preload("Owner").
+		Preload("IconMedia").
+		Preload("BannerMedia").
+		First(&community, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &community, nil
+}
+
+func (r *CommunityRepositoryImpl) GetBySlug(ctx context.Context, slug string) (*models.Community, error) {
+	var community models.Community
+	err := r.db.WithContext(ctx).
+		Preload("Owner").
+		Preload("IconMedia").
+		Preload("BannerMedia").
+		First(&community, "slug = ?", slug).Error
+	if err != nil {
+		return nil, err
+	}
+	return &community, nil
+}
+
+func (r *CommunityRepositoryImpl) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Community{}).
+		Where("slug = ?", slug).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ==================== Discovery ====================
+
+func (r *CommunityRepositoryImpl) List(ctx context.Context, query string, offset, limit int) ([]*models.Community, error) {
+	var communities []*models.Community
+	err := r.discoveryQuery(ctx, query).
+		Preload("IconMedia").
+		Order("member_count DESC, created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&communities).Error
+	return communities, err
+}
+
+func (r *CommunityRepositoryImpl) Count(ctx context.Context, query string) (int64, error) {
+	var count int64
+	err := r.discoveryQuery(ctx, query).
+		Model(&models.Community{}).
+		Count(&count).Error
+	return count, err
+}
+
+// discoveryQuery matches listed (non-private) communities by slug or name
+func (r *CommunityRepositoryImpl) discoveryQuery(ctx context.Context, query string) *gorm.DB {
+	db := r.db.WithContext(ctx).Where("visibility <> ?", models.CommunityVisibilityPrivate)
+	if query != "" {
+		searchQuery := "%" + query + "%"
+		db = db.Where("(slug ILIKE ? OR name ILIKE ?)", searchQuery, searchQuery)
+	}
+	return db
+}
+
+func (r *CommunityRepositoryImpl) ListByMember(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.Community, error) {
+	var communities []*models.Community
+	err := r.db.WithContext(ctx).
+		Preload("IconMedia").
+		Joins("JOIN community_members ON community_members.community_id = communities.id").
+		Where("community_members.user_id = ? AND community_members.status = ?", userID, models.CommunityMemberStatusActive).
+		Order("community_members.joined_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&communities).Error
+	return communities, err
+}
+
+func (r *CommunityRepositoryImpl) CountByMember(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.CommunityMember{}).
+		Where("user_id = ? AND status = ?", userID, models.CommunityMemberStatusActive).
+		Count(&count).Error
+	return count, err
+}
+
+// ==================== Members ====================
+
+func (r *CommunityRepositoryImpl) GetMember(ctx context.Context, communityID, userID uuid.UUID) (*models.CommunityMember, error) {
+	var member models.CommunityMember
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("community_id = ? AND user_id = ?", communityID, userID).
+		First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *CommunityRepositoryImpl) CreateMember(ctx context.Context, member *models.CommunityMember) error {
+	return r.db.WithContext(ctx).Omit("User").Create(member).Error
+}
+
+func (r *CommunityRepositoryImpl) UpdateMember(ctx context.Context, member *models.CommunityMember) error {
+	return r.db.WithContext(ctx).Omit("User").Save(member).Error
+}
+
+func (r *CommunityRepositoryImpl) DeleteMember(ctx context.Context, communityID, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("community_id = ? AND user_id = ?", communityID, userID).
+		Delete(&models.CommunityMember{}).Error
+}
+
+func (r *CommunityRepositoryImpl) ListMembers(ctx context.Context, communityID uuid.UUID, status string, roles []string, offset, limit int) ([]*models.CommunityMember, error) {
+	var members []*models.CommunityMember
+	err := r.membersQuery(ctx, communityID, status, roles).
+		Preload("User").
+		Order(communityMemberRoleOrderSQL).
+		Order("community_members.created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&members).Error
+	return members, err
+}
+
+func (r *CommunityRepositoryImpl) CountMembers(ctx context.Context, communityID uuid.UUID, status string, roles []string) (int64, error) {
+	var count int64
+	err := r.membersQuery(ctx, communityID, status, roles).
+		Model(&models.CommunityMember{}).
+		Count(&count).Error
+	return count, err
+}
+
+// membersQuery filters the members of a community by status and roles (empty = any)
+func (r *CommunityRepositoryImpl) membersQuery(ctx context.Context, communityID uuid.UUID, status string, roles []string) *gorm.DB {
+	db := r.db.WithContext(ctx).Where("community_members.community_id = ?", communityID)
+	if status != "" {
+		db = db.Where("community_members.status = ?", status)
+	}
+	if len(roles) > 0 {
+		db = db.Where("community_members.role IN ?", roles)
+	}
+	return db
+}
+
+// ==================== Stats ====================
+
+func (r *CommunityRepositoryImpl) SyncMemberCount(ctx context.Context, communityID uuid.UUID) error {
+	return r.db.WithContext(ctx).Exec(`
+		UPDATE communities SET member_count = (
+			SELECT COUNT(*) FROM community_members
+			WHERE community_members.community_id = communities.id AND community_members.status = ?
+		)
+		WHERE id = ?`, models.CommunityMemberStatusActive, communityID).Error
+}
+
+func (r *CommunityRepositoryImpl) IncrementPostCount(ctx context.Context, communityID uuid.UUID, delta int) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Community{}).
+		Where("id = ?", communityID).
+		UpdateColumn("post_count", gorm.Expr("GREATEST(post_count + ?, 0)", delta)).Error
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/google/uuid"
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ConversationRepositoryImpl struct {
@@ -27,6 +29,10 @@ func (r *ConversationRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID)
 	err := r.db.WithContext(ctx).
 		Preload("User1").
 		Preload("User2").
+		Preload("Participants", func(db *gorm.DB) *gorm.DB {
+			return db.Order("joined_at ASC")
+		}).
+		Preload("Participants.User").
 		First(&conversation, "id = ?", id).Error
 	if err != nil {
 		return nil, err
@@ -45,7 +51,7 @@ func (r *ConversationRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) e
 	return r.db.WithContext(ctx).Delete(&models.Conversation{}, "id = ?", id).Error
 }
 
-func (r *ConversationRepositoryImpl) GetOrCreateByUsers(ctx context.Context, user1ID, user2ID uuid.UUID) (*models.Conversation, bool, error) {
+func (r *ConversationRepositoryImpl) GetOrCreateByUsers(ctx context.Context, user1ID, user2ID uuid.UUID, requestedByID *uuid.UUID) (*models.Conversation, bool, error) {
 	// Ensure consistent ordering (smaller UUID first)
 	if user1ID.String() > user2ID.String() {
 		user1ID, user2ID = user2ID, user1ID
@@ -56,6 +62,7 @@ func (r *ConversationRepositoryImpl) GetOrCreateByUsers(ctx context.Context, use
 	err := r.db.WithContext(ctx).
 		Preload("User1").
 		Preload("User2").
+		Preload("Participants").
 		Where("user1_id = ? AND user2_id = ?", user1ID, user2ID).
 		First(&conversation).Error
 
@@ -69,17 +76,27 @@ func (r *ConversationRepositoryImpl) GetOrCreateByUsers(ctx context.Context, use
 		return nil, false, err
 	}
 
-	// Create new conversation
+	// Create new conversation with both users as participants
 	now := time.Now()
 	conversation = models.Conversation{
-		User1ID:          user1ID,
-		User2ID:          user2ID,
-		LastMessageAt:    now,
-		User1UnreadCount: 0,
-		User2UnreadCount: 0,
+		Type:          models.ConversationTypeDirect,
+		User1ID:       &user1ID,
+		User2ID:       &user2ID,
+		RequestedByID: requestedByID,
+		LastMessageAt: now,
 	}
 
-	if err := r.db.WithContext(ctx).Create(&conversation).Error; err != nil {
+	err = database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Create(&conversation).Error; err != nil {
+			return err
+		}
+		participants := []*models.ConversationParticipant{
+			{ConversationID: conversation.ID, UserID: user1ID, Role: models.ConversationRoleMember, JoinedAt: now},
+			{ConversationID: conversation.ID, UserID: user2ID, Role: models.ConversationRoleMember, JoinedAt: now},
+		}
+		return tx.Create(&participants).Error
+	})
+	if err != nil {
 		return nil, false, err
 	}
 
@@ -87,6 +104,7 @@ func (r *ConversationRepositoryImpl) GetOrCreateByUsers(ctx context.Context, use
 	err = r.db.WithContext(ctx).
 		Preload("User1").
 		Preload("User2").
+		Preload("Participants").
 		First(&conversation, "id = ?", conversation.ID).Error
 	if err != nil {
 		return nil, false, err
@@ -105,6 +123,7 @@ func (r *ConversationRepositoryImpl) GetByUsers(ctx context.Context, user1ID, us
 	err := r.db.WithContext(ctx).
 		Preload("User1").
 		Preload("User2").
+		Preload("Participants").
 		Where("user1_id = ? AND user2_id = ?", user1ID, user2ID).
 		First(&conversation).Error
 
@@ -114,11 +133,26 @@ func (r *ConversationRepositoryImpl) GetByUsers(ctx context.Context, user1ID, us
 	return &conversation, nil
 }
 
+func (r *ConversationRepositoryImpl) CreateGroup(ctx context.Context, conversation *models.Conversation, participants []*models.ConversationParticipant) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Create(conversation).Error; err != nil {
+			return err
+		}
+		for _, participant := range participants {
+			participant.ConversationID = conversation.ID
+		}
+		return tx.Create(&participants).Error
+	})
+}
+
 func (r *ConversationRepositoryImpl) ListByUser(ctx context.Context, userID uuid.UUID, cursor *time.Time, limit int) ([]*models.Conversation, error) {
+	// Participants are loaded without their users (groups only need the count and the own row)
 	query := r.db.WithContext(ctx).
 		Preload("User1").
 		Preload("User2").
-		Where("user1_id = ? OR user2_id = ?", userID, userID).
+		Preload("Participants").
+		Where("id IN (?)", r.db.Model(&models.ConversationParticipant{}).Select("conversation_id").Where("user_id = ?", userID)).
+		Where("(requested_by_id IS NULL OR requested_by_id = ?)", userID).
 		Order("last_message_at DESC")
 
 	// Apply cursor pagination
@@ -131,57 +165,187 @@ func (r *ConversationRepositoryImpl) ListByUser(ctx context.Context, userID uuid
 	return conversations, err
 }
 
-func (r *ConversationRepositoryImpl) GetTotalUnreadCount(ctx context.Context, userID uuid.UUID) (int, error) {
-	var totalUnread int64
+// ==================== Message Requests ====================
+
+func (r *ConversationRepositoryImpl) ListRequests(ctx context.Context, userID uuid.UUID, cursor *time.Time, limit int) ([]*models.Conversation, error) {
+	query := r.db.WithContext(ctx).
+		Preload("User1").
+		Preload("User2").
+		Preload("Participants").
+		Scopes(receivedRequests(r.db, userID)).
+		Order("last_message_at DESC")
 
-	// Sum unread counts where user is user1
-	var unreadAsUser1 int64
+	// Apply cursor pagination
+	if cursor != nil {
+		query = query.Where("last_message_at < ?", *cursor)
+	}
+
+	var conversations []*models.Conversation
+	err := query.Limit(limit).Find(&conversations).Error
+	return conversations, err
+}
+
+func (r *ConversationRepositoryImpl) CountRequests(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&models.Conversation{}).
-		Where("user1_id = ?", userID).
-		Select("COALESCE(SUM(user1_unread_count), 0)").
-		Scan(&unreadAsUser1).Error
-	if err != nil {
-		return 0, err
-	}
+		Scopes(receivedRequests(r.db, userID)).
+		Count(&count).Error
+	return count, err
+}
 
-	// Sum unread counts where user is user2
-	var unreadAsUser2 int64
-	err = r.db.WithContext(ctx).
+func (r *ConversationRepositoryImpl) AcceptRequest(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
 		Model(&models.Conversation{}).
-		Where("user2_id = ?", userID).
-		Select("COALESCE(SUM(user2_unread_count), 0)").
-		Scan(&unreadAsUser2).Error
+		Where("id = ? AND requested_by_id IS NOT NULL AND requested_by_id <> ?", conversationID, userID).
+		Update("requested_by_id", nil)
+	return result.RowsAffected > 0, result.Error
+}
+
+// receivedRequests filters the pending requests sent to the user (empty ones are not shown until the first message)
+func receivedRequests(db *gorm.DB, userID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		return query.
+			Where("id IN (?)", db.Model(&models.ConversationParticipant{}).Select("conversation_id").Where("user_id = ?", userID)).
+			Where("requested_by_id IS NOT NULL AND requested_by_id <> ?", userID).
+			Where("last_message_id IS NOT NULL")
+	}
+}
+
+// ==================== Participants ====================
+
+func (r *ConversationRepositoryImpl) GetParticipant(ctx context.Context, conversationID, userID uuid.UUID) (*models.ConversationParticipant, error) {
+	var participant models.ConversationParticipant
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		First(&participant).Error
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	return &participant, nil
+}
 
-	totalUnread = unreadAsUser1 + unreadAsUser2
-	return int(totalUnread), nil
+func (r *ConversationRepositoryImpl) ListParticipantIDs(ctx context.Context, conversationID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ?", conversationID).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
 }
 
-func (r *ConversationRepositoryImpl) ResetUnreadCount(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
-	// Get conversation to determine which field to reset
-	var conversation models.Conversation
-	err := r.db.WithContext(ctx).First(&conversation, "id = ?", conversationID).Error
+func (r *ConversationRepositoryImpl) CountParticipants(ctx context.Context, conversationID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ?", conversationID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *ConversationRepositoryImpl) AddParticipants(ctx context.Context, participants []*models.ConversationParticipant) error {
+	if len(participants) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&participants).Error
+}
+
+func (r *ConversationRepositoryImpl) RemoveParticipant(ctx context.Context, conversationID, userID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Delete(&models.ConversationParticipant{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ConversationRepositoryImpl) UpdateParticipantRole(ctx context.Context, conversationID, userID uuid.UUID, role string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Update("role", role)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ConversationRepositoryImpl) NextOwnerCandidate(ctx context.Context, conversationID uuid.UUID) (*models.ConversationParticipant, error) {
+	var participant models.ConversationParticipant
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND role <> ?", conversationID, models.ConversationRoleOwner).
+		Order(clause.Expr{SQL: "CASE WHEN role = ? THEN 0 ELSE 1 END, joined_at ASC", Vars: []interface{}{models.ConversationRoleAdmin}}).
+		First(&participant).Error
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return &participant, nil
+}
+
+// ==================== Unread Counts ====================
 
-	// Reset appropriate unread count based on which user this is
-	if conversation.User1ID == userID {
-		return r.db.WithContext(ctx).
-			Model(&models.Conversation{}).
-			Where("id = ?", conversationID).
-			Update("user1_unread_count", 0).Error
-	} else if conversation.User2ID == userID {
-		return r.db.WithContext(ctx).
-			Model(&models.Conversation{}).
-			Where("id = ?", conversationID).
-			Update("user2_unread_count", 0).Error
+func (r *ConversationRepositoryImpl) GetTotalUnreadCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	var totalUnread int64
+	err := r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(unread_count), 0)").
+		Scan(&totalUnread).Error
+	if err != nil {
+		return 0, err
 	}
+	return int(totalUnread), nil
+}
 
-	return nil
+func (r *ConversationRepositoryImpl) ResetUnreadCount(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID) error {
+	// The read pointer moves to the conversation's current last message
+	return r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationID, userID).
+		Updates(map[string]interface{}{
+			"unread_count":         0,
+			"last_read_message_id": gorm.Expr("(SELECT last_message_id FROM conversations WHERE id = ?)", conversationID),
+			"last_read_at":         time.Now(),
+			"last_read_seq":        gorm.Expr("(SELECT last_seq FROM conversations WHERE id = ?)", conversationID),
+			"last_delivered_seq":   gorm.Expr("GREATEST(last_delivered_seq, (SELECT last_seq FROM conversations WHERE id = ?))", conversationID),
+		}).Error
+}
+
+func (r *ConversationRepositoryImpl) MarkDeliveredUpTo(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, seq int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ? AND last_delivered_seq < ?", conversationID, userID, seq).
+		UpdateColumn("last_delivered_seq", seq)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ConversationRepositoryImpl) MarkReadUpTo(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, seq int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ? AND last_read_seq < ?", conversationID, userID, seq).
+		Updates(map[string]interface{}{
+			"last_read_seq":        seq,
+			"last_delivered_seq":   gorm.Expr("GREATEST(last_delivered_seq, ?)", seq),
+			"last_read_message_id": gorm.Expr("(SELECT id FROM messages WHERE conversation_id = ? AND seq <= ? ORDER BY seq DESC LIMIT 1)", conversationID, seq),
+			"last_read_at":         time.Now(),
+			"unread_count":         gorm.Expr("(SELECT COUNT(*) FROM messages WHERE conversation_id = ? AND sender_id <> ? AND seq > ?)", conversationID, userID, seq),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *ConversationRepositoryImpl) AppendEvent(ctx context.Context, event *models.ConversationEvent) error {
+	// The database function takes the next sequence under the conversation's row lock
+	return r.db.WithContext(ctx).
+		Raw("SELECT append_conversation_event(?, ?, ?, ?, ?)",
+			event.ConversationID, event.Type, event.MessageID, event.UserID, event.UpToSeq).
+		Scan(&event.Seq).Error
+}
+
+func (r *ConversationRepositoryImpl) ListEvents(ctx context.Context, conversationID uuid.UUID, afterSeq int64, limit int) ([]*models.ConversationEvent, error) {
+	var events []*models.ConversationEvent
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ? AND seq > ?", conversationID, afterSeq).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
 }
 
 func (r *ConversationRepositoryImpl) UpdateLastMessage(ctx context.Context, conversationID uuid.UUID, messageID uuid.UUID, timestamp time.Time) error {
@@ -194,28 +358,23 @@ func (r *ConversationRepositoryImpl) UpdateLastMessage(ctx context.Context, conv
 		}).Error
 }
 
-func (r *ConversationRepositoryImpl) IncrementUnreadCount(ctx context.Context, conversationID uuid.UUID, receiverID uuid.UUID) error {
-	// Get conversation to determine which field to increment
-	var conversation models.Conversation
-	err := r.db.WithContext(ctx).First(&conversation, "id = ?", conversationID).Error
-	if err != nil {
-		return err
-	}
-
-	// Increment appropriate unread count
-	if conversation.User1ID == receiverID {
-		return r.db.WithContext(ctx).
-			Model(&models.Conversation{}).
-			Where("id = ?", conversationID).
-			UpdateColumn("user1_unread_count", gorm.Expr("user1_unread_count + ?", 1)).Error
-	} else if conversation.User2ID == receiverID {
-		return r.db.WithContext(ctx).
-			Model(&models.Conversation{}).
-			Where("id = ?", conversationID).
-			UpdateColumn("user2_unread_count", gorm.Expr("user2_unread_count + ?", 1)).Error
-	}
+func (r *ConversationRepositoryImpl) IncrementUnreadCounts(ctx context.Context, conversationID uuid.UUID, senderID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id <> ?", conversationID, senderID).
+		UpdateColumn("unread_count", gorm.Expr("unread_count + ?", 1)).Error
+}
 
-	return nil
+func (r *ConversationRepositoryImpl) DecrementUnreadCounts(ctx context.Context, conversationID uuid.UUID, senderID uuid.UUID, seq int64) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE conversation_participants
+		SET unread_count = unread_count - 1
+		WHERE conversation_id = ? AND user_id <> ? AND unread_count > 0
+		  AND last_read_seq < ?
+		RETURNING user_id`, conversationID, senderID, seq).
+		Scan(&userIDs).Error
+	return userIDs, err
 }
 
 func (r *ConversationRepositoryImpl) Count(ctx context.Context) (int64, error) {
@@ -227,8 +386,8 @@ func (r *ConversationRepositoryImpl) Count(ctx context.Context) (int64, error) {
 func (r *ConversationRepositoryImpl) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
-		Model(&models.Conversation{}).
-		Where("user1_id = ? OR user2_id = ?", userID, userID).
+		Model(&models.ConversationParticipant{}).
+		Where("user_id = ?", userID).
 		Count(&count).Error
 	return count, err
 }
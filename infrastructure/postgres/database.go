@@ -61,6 +61,35 @@ func RunSQLMigrations(db *gorm.DB) error {
 		"migrations/018_create_auto_post_tables.sql",
 		"migrations/019_update_auto_post_tables_v2.sql",
 		"migrations/020_create_simple_auto_post_queue.sql",
+		"migrations/023_create_wallet_tables.sql",
+		"migrations/024_add_post_unlock.sql",
+		"migrations/025_create_gift_tables.sql",
+		"migrations/026_create_subscription_tables.sql",
+		"migrations/027_create_reel_tables.sql",
+		"migrations/028_create_ad_tables.sql",
+		"migrations/029_create_topup_tables.sql",
+		"migrations/030_create_payout_tables.sql",
+		"migrations/031_create_community_tables.sql",
+		"migrations/032_create_report_tables.sql",
+		"migrations/033_create_sessions_table.sql",
+		"migrations/034_add_email_verification.sql",
+		"migrations/035_create_two_factor_tables.sql",
+		"migrations/036_create_user_identities.sql",
+		"migrations/037_create_rbac_tables.sql",
+		"migrations/038_create_user_sanctions.sql",
+		"migrations/039_create_follow_requests.sql",
+		"migrations/040_create_account_data_tables.sql",
+		"migrations/041_create_api_tokens.sql",
+		"migrations/042_create_group_conversations.sql",
+		"migrations/043_create_message_revisions.sql",
+		"migrations/044_create_message_reactions.sql",
+		"migrations/045_create_conversation_sequences.sql",
+		"migrations/046_create_message_search_index.sql",
+		"migrations/047_create_message_requests.sql",
+		"migrations/048_add_message_removed_at.sql",
+		"migrations/049_add_session_mfa_verified_at.sql",
+		"migrations/050_add_users_is_bot.sql",
+		"migrations/051_add_users_deletion_forfeits_balance.sql",
 		"migrations/add_push_subscriptions_unique_constraint.sql",
 	}
 
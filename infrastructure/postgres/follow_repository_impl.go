@@ -8,6 +8,7 @@ import (
 	"gofiber-template/domain/repositories"
 	"gofiber-template/pkg/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FollowRepositoryImpl struct {
@@ -126,6 +127,52 @@ func (r *FollowRepositoryImpl) UpdateFollowingCount(ctx context.Context, userID
 		UpdateColumn("following_count", gorm.Expr("following_count + ?", delta)).Error
 }
 
+func (r *FollowRepositoryImpl) CreateFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) error {
+	request := &models.FollowRequest{
+		RequesterID: requesterID,
+		TargetID:    targetID,
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(request).Error
+}
+
+func (r *FollowRepositoryImpl) DeleteFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("requester_id = ? AND target_id = ?", requesterID, targetID).
+		Delete(&models.FollowRequest{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *FollowRepositoryImpl) HasFollowRequest(ctx context.Context, requesterID uuid.UUID, targetID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.FollowRequest{}).
+		Where("requester_id = ? AND target_id = ?", requesterID, targetID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *FollowRepositoryImpl) ListFollowRequests(ctx context.Context, targetID uuid.UUID, offset, limit int) ([]*models.FollowRequest, error) {
+	var requests []*models.FollowRequest
+	err := r.db.WithContext(ctx).
+		Preload("Requester").
+		Where("target_id = ?", targetID).
+		Order("created_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *FollowRepositoryImpl) CountFollowRequests(ctx context.Context, targetID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.FollowRequest{}).
+		Where("target_id = ?", targetID).
+		Count(&count).Error
+	return count, err
+}
+
 var _ repositories.FollowRepository = (*FollowRepositoryImpl)(nil)
 
 // Cursor-based methods
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+)
+
+type GiftRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewGiftRepository(db *gorm.DB) repositories.GiftRepository {
+	return &GiftRepositoryImpl{db: db}
+}
+
+func (r *GiftRepositoryImpl) ListActivePackages(ctx context.Context) ([]*models.GiftPackage, error) {
+	var packages []*models.GiftPackage
+	err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Order("sort_order ASC, price ASC").
+		Find(&packages).Error
+	return packages, err
+}
+
+func (r *GiftRepositoryImpl) GetPackageByID(ctx context.Context, id uuid.UUID) (*models.GiftPackage, error) {
+	var pkg models.GiftPackage
+	err := r.db.WithContext(ctx).First(&pkg, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+func (r *GiftRepositoryImpl) CreateWithMessage(ctx context.Context, message *models.Message, gift *models.Gift) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		// Gift is created explicitly below, don't let GORM upsert the association
+		if err := tx.Omit("Gift").Create(message).Error; err != nil {
+			return err
+		}
+		gift.MessageID = message.ID
+		return tx.Omit("Package").Create(gift).Error
+	})
+}
+
+func (r *GiftRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Gift, error) {
+	var gift models.Gift
+	err := r.db.WithContext(ctx).
+		Preload("Package").
+		First(&gift, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &gift, nil
+}
+
+func (r *GiftRepositoryImpl) GetByMessageID(ctx context.Context, messageID uuid.UUID) (*models.Gift, error) {
+	var gift models.Gift
+	err := r.db.WithContext(ctx).
+		Preload("Package").
+		First(&gift, "message_id = ?", messageID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &gift, nil
+}
+
+func (r *GiftRepositoryImpl) CountSentSince(ctx context.Context, senderID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Gift{}).
+		Where("sender_id = ? AND created_at >= ?", senderID, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *GiftRepositoryImpl) UpdateStatus(ctx context.Context, id uuid.UUID, fromStatus, toStatus string) (bool, error) {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":     toStatus,
+		"updated_at": now,
+	}
+	if toStatus == models.GiftStatusOpened {
+		updates["opened_at"] = now
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&models.Gift{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *GiftRepositoryImpl) MarkSettled(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&models.Gift{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"settled_at": now,
+			"updated_at": now,
+		}).Error
+}
+
+func (r *GiftRepositoryImpl) ListExpiredPending(ctx context.Context, now time.Time, limit int) ([]*models.Gift, error) {
+	var gifts []*models.Gift
+	err := r.db.WithContext(ctx).
+		Preload("Package").
+		Where("status = ? AND expires_at < ?", models.GiftStatusPending, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&gifts).Error
+	return gifts, err
+}
+
+func (r *GiftRepositoryImpl) ListUnsettled(ctx context.Context, limit int) ([]*models.Gift, error) {
+	var gifts []*models.Gift
+	err := r.db.WithContext(ctx).
+		Preload("Package").
+		Where("status IN ? AND settled_at IS NULL",
+			[]string{models.GiftStatusOpened, models.GiftStatusExpired, models.GiftStatusRefunded}).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&gifts).Error
+	return gifts, err
+}
+
+// Ensure interface compliance
+var _ repositories.GiftRepository = (*GiftRepositoryImpl)(nil)
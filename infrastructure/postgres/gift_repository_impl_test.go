@@ -0,0 +1,230 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofiber-template/application/serviceimpl"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/redis"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/database"
+)
+
+// sanctionStub reports the sender's shadowban (only CheckWriteAccess is used by GiftService)
+type sanctionStub struct {
+	services.SanctionService
+	shadowbanned bool
+}
+
+func (s sanctionStub) CheckWriteAccess(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return s.shadowbanned, nil
+}
+
+type giftTestEnv struct {
+	walletRepo       *WalletRepositoryImpl
+	walletService    services.WalletService
+	userRepo         *UserRepositoryImpl
+	giftRepo         *GiftRepositoryImpl
+	conversationRepo *ConversationRepositoryImpl
+	newGiftService   func(sanctions services.SanctionService) services.GiftService
+}
+
+func setupGiftTest(t *testing.T) (*giftTestEnv, func()) {
+	// Get test database
+	db, err := config.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+
+	env := &giftTestEnv{
+		walletRepo:       &WalletRepositoryImpl{db: db},
+		userRepo:         &UserRepositoryImpl{db: db},
+		giftRepo:         &GiftRepositoryImpl{db: db},
+		conversationRepo: &ConversationRepositoryImpl{db: db},
+	}
+	env.walletService = serviceimpl.NewWalletService(env.walletRepo)
+
+	// Unread counters and the last-message cache are best effort, Redis may be down
+	redisService := redis.NewRedisService(redis.NewRedisClient(redis.RedisConfig{Host: "localhost", Port: "6379"}))
+	env.newGiftService = func(sanctions services.SanctionService) services.GiftService {
+		return serviceimpl.NewGiftService(
+			database.NewTransactionManager(db),
+			env.giftRepo,
+			&MessageRepositoryImpl{db: db},
+			env.conversationRepo,
+			&BlockRepositoryImpl{db: db},
+			&MediaRepositoryImpl{db: db},
+			env.walletService,
+			silentNotifications{},
+			sanctions,
+			redisService,
+		)
+	}
+
+	// Cleanup function
+	cleanup := func() {
+		err := config.CleanTestDB(db)
+		assert.NoError(t, err, "Failed to clean test database")
+		// Seeded packages stay, the ones created by giftSetup go
+		db.Where("code LIKE ?", "test-%").Delete(&models.GiftPackage{})
+	}
+
+	return env, cleanup
+}
+
+// giftSetup creates a funded sender, a receiver, their conversation and a 100 THB package paying the receiver 70%
+func giftSetup(t *testing.T, ctx context.Context, env *giftTestEnv) (sender, receiver *models.Wallet, conversation *models.Conversation, pkg *models.GiftPackage) {
+	sender = fundedWallet(t, ctx, env.walletService, env.userRepo, 50000)
+	receiver = fundedWallet(t, ctx, env.walletService, env.userRepo, 0)
+
+	conversation, _, err := env.conversationRepo.GetOrCreateByUsers(ctx, *sender.UserID, *receiver.UserID, nil)
+	require.NoError(t, err)
+
+	pkg = &models.GiftPackage{
+		Code:             "test-" + uuid.NewString()[:8],
+		Name:             "Test",
+		Price:            10000,
+		ReceiverPercent:  70,
+		MaxMessageLength: 500,
+		IsActive:         true,
+	}
+	require.NoError(t, env.giftRepo.db.Create(pkg).Error)
+
+	return sender, receiver, conversation, pkg
+}
+
+func sendTestGift(t *testing.T, ctx context.Context, giftService services.GiftService, sender *models.Wallet, conversation *models.Conversation, pkg *models.GiftPackage) *dto.MessageResponse {
+	content := "สุขสันต์วันเกิด"
+	message, err := giftService.SendGift(ctx, *sender.UserID, &dto.SendGiftRequest{
+		ConversationID: conversation.ID,
+		PackageID:      pkg.ID,
+		Content:        &content,
+	})
+	require.NoError(t, err)
+	return message
+}
+
+func TestGift_OpenPaysReceiver(t *testing.T) {
+	env, cleanup := setupGiftTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	giftService := env.newGiftService(sanctionStub{})
+	sender, receiver, conversation, pkg := giftSetup(t, ctx, env)
+
+	feeWallet, err := env.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+	require.NoError(t, err)
+	feeBefore := feeWallet.Balance
+
+	message := sendTestGift(t, ctx, giftService, sender, conversation, pkg)
+
+	// The price is paid into escrow as soon as the gift is sent
+	senderAfter, err := env.walletRepo.GetByID(ctx, sender.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(40000), senderAfter.Balance)
+
+	// Only the receiver can open it
+	_, err = giftService.OpenGift(ctx, message.ID, *sender.UserID)
+	assert.Error(t, err)
+
+	// Act
+	_, err = giftService.OpenGift(ctx, message.ID, *receiver.UserID)
+	require.NoError(t, err)
+	_, err = giftService.OpenGift(ctx, message.ID, *receiver.UserID)
+	require.NoError(t, err)
+
+	// Assert
+	receiverAfter, err := env.walletRepo.GetByID(ctx, receiver.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7000), receiverAfter.Balance, "opening twice must pay once")
+
+	feeAfter, err := env.walletRepo.GetByID(ctx, feeWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), feeAfter.Balance-feeBefore)
+
+	gift, err := env.giftRepo.GetByMessageID(ctx, message.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.GiftStatusOpened, gift.Status)
+	assert.NotNil(t, gift.SettledAt)
+	assert.Zero(t, sumAllEntries(t, env.walletRepo))
+
+	// An opened gift is never refunded
+	expired, err := giftService.ProcessExpiredGifts(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+}
+
+func TestGift_ExpiryRefundsSender(t *testing.T) {
+	env, cleanup := setupGiftTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	giftService := env.newGiftService(sanctionStub{})
+	sender, receiver, conversation, pkg := giftSetup(t, ctx, env)
+
+	message := sendTestGift(t, ctx, giftService, sender, conversation, pkg)
+
+	// The receiver does not open it in time
+	require.NoError(t, env.giftRepo.db.Model(&models.Gift{}).
+		Where("message_id = ?", message.ID).
+		UpdateColumn("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	// Act
+	expired, err := giftService.ProcessExpiredGifts(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	// 80% goes back to the sender
+	senderAfter, err := env.walletRepo.GetByID(ctx, sender.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(48000), senderAfter.Balance)
+
+	receiverAfter, err := env.walletRepo.GetByID(ctx, receiver.ID)
+	require.NoError(t, err)
+	assert.Zero(t, receiverAfter.Balance)
+
+	escrow, err := env.walletService.GetSystemWallet(ctx, models.SystemWalletEscrow)
+	require.NoError(t, err)
+	assert.Zero(t, escrow.Balance)
+
+	_, err = giftService.OpenGift(ctx, message.ID, *receiver.UserID)
+	assert.ErrorIs(t, err, services.ErrGiftNotOpenable)
+
+	// A second run finds nothing to expire
+	expired, err = giftService.ProcessExpiredGifts(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+	assert.Zero(t, sumAllEntries(t, env.walletRepo))
+}
+
+func TestGift_ShadowbannedSenderRefused(t *testing.T) {
+	env, cleanup := setupGiftTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	giftService := env.newGiftService(sanctionStub{shadowbanned: true})
+	sender, _, conversation, pkg := giftSetup(t, ctx, env)
+
+	// Act
+	_, err := giftService.SendGift(ctx, *sender.UserID, &dto.SendGiftRequest{
+		ConversationID: conversation.ID,
+		PackageID:      pkg.ID,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrGiftSenderRestricted)
+
+	senderAfter, err := env.walletRepo.GetByID(ctx, sender.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50000), senderAfter.Balance)
+}
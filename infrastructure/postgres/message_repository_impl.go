@@ -2,12 +2,15 @@ package postgres
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MessageRepositoryImpl struct {
@@ -22,11 +25,44 @@ func (r *MessageRepositoryImpl) Create(ctx context.Context, message *models.Mess
 	return r.db.WithContext(ctx).Create(message).Error
 }
 
+func (r *MessageRepositoryImpl) CreateFirstMessage(ctx context.Context, message *models.Message) (bool, error) {
+	var created bool
+	err := database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		var conversation models.Conversation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&conversation, "id = ?", message.ConversationID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Message{}).
+			Where("conversation_id = ?", message.ConversationID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Create(message).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	return created, err
+}
+
 func (r *MessageRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Message, error) {
 	var message models.Message
 	err := r.db.WithContext(ctx).
 		Preload("Sender").
 		Preload("Receiver").
+		Preload("Gift.Package").
+		Preload("ReplyTo.Sender").
+		Preload("ReplyTo.Gift").
+		Preload("Reactions", orderReactions).
 		First(&message, "id = ?", id).Error
 	if err != nil {
 		return nil, err
@@ -45,10 +81,103 @@ func (r *MessageRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error
 	return r.db.WithContext(ctx).Delete(&models.Message{}, "id = ?", id).Error
 }
 
+func (r *MessageRepositoryImpl) Tombstone(ctx context.Context, id uuid.UUID) (bool, error) {
+	var removed bool
+	err := database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.Message{}).
+			Where("id = ? AND removed_at IS NULL", id).
+			Updates(map[string]interface{}{
+				"content":    nil,
+				"media":      nil,
+				"removed_at": now,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		removed = true
+
+		return tx.Where("message_id = ?", id).Delete(&models.MessageRevision{}).Error
+	})
+	return removed, err
+}
+
+func (r *MessageRepositoryImpl) UpdateContentWithRevision(ctx context.Context, message *models.Message, revision *models.MessageRevision) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Create(revision).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Message{}).
+			Where("id = ?", message.ID).
+			Updates(map[string]interface{}{
+				"content":    message.Content,
+				"edited_at":  message.EditedAt,
+				"updated_at": message.UpdatedAt,
+			}).Error
+	})
+}
+
+func (r *MessageRepositoryImpl) ListRevisions(ctx context.Context, messageID uuid.UUID) ([]*models.MessageRevision, error) {
+	var revisions []*models.MessageRevision
+	err := r.db.WithContext(ctx).
+		Where("message_id = ?", messageID).
+		Order("created_at ASC").
+		Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *MessageRepositoryImpl) GetLatestByConversation(ctx context.Context, conversationID uuid.UUID) (*models.Message, error) {
+	var message models.Message
+	err := r.db.WithContext(ctx).
+		Where("conversation_id = ?", conversationID).
+		Order("created_at DESC").
+		First(&message).Error
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r *MessageRepositoryImpl) AddReaction(ctx context.Context, reaction *models.MessageReaction) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(reaction)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *MessageRepositoryImpl) RemoveReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, emoji string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("message_id = ? AND user_id = ? AND emoji = ?", messageID, userID, emoji).
+		Delete(&models.MessageReaction{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *MessageRepositoryImpl) ListReactions(ctx context.Context, messageID uuid.UUID) ([]models.MessageReaction, error) {
+	var reactions []models.MessageReaction
+	err := orderReactions(r.db.WithContext(ctx)).
+		Where("message_id = ?", messageID).
+		Find(&reactions).Error
+	return reactions, err
+}
+
+// orderReactions keeps reactions in the order they were added (emojis are grouped by first use)
+func orderReactions(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at ASC")
+}
+
 func (r *MessageRepositoryImpl) ListByConversation(ctx context.Context, conversationID uuid.UUID, beforeCursor *time.Time, limit int) ([]*models.Message, error) {
 	query := r.db.WithContext(ctx).
 		Preload("Sender").
 		Preload("Receiver").
+		Preload("Gift.Package").
+		Preload("ReplyTo.Sender").
+		Preload("ReplyTo.Gift").
+		Preload("Reactions", orderReactions).
 		Where("conversation_id = ?", conversationID).
 		Order("created_at DESC") // Most recent first
 
@@ -67,6 +196,10 @@ func (r *MessageRepositoryImpl) GetMessagesBeforeTimestamp(ctx context.Context,
 	err := r.db.WithContext(ctx).
 		Preload("Sender").
 		Preload("Receiver").
+		Preload("Gift.Package").
+		Preload("ReplyTo.Sender").
+		Preload("ReplyTo.Gift").
+		Preload("Reactions", orderReactions).
 		Where("conversation_id = ? AND created_at < ?", conversationID, timestamp).
 		Order("created_at DESC"). // Most recent first
 		Limit(limit).
@@ -79,6 +212,10 @@ func (r *MessageRepositoryImpl) GetMessagesAfterTimestamp(ctx context.Context, c
 	err := r.db.WithContext(ctx).
 		Preload("Sender").
 		Preload("Receiver").
+		Preload("Gift.Package").
+		Preload("ReplyTo.Sender").
+		Preload("ReplyTo.Gift").
+		Preload("Reactions", orderReactions).
 		Where("conversation_id = ? AND created_at > ?", conversationID, timestamp).
 		Order("created_at ASC"). // Oldest first (to get next messages)
 		Limit(limit).
@@ -86,6 +223,51 @@ func (r *MessageRepositoryImpl) GetMessagesAfterTimestamp(ctx context.Context, c
 	return messages, err
 }
 
+func (r *MessageRepositoryImpl) SearchInConversation(ctx context.Context, conversationID uuid.UUID, query string, beforeCursor *time.Time, beforeID uuid.UUID, limit int) ([]*models.Message, error) {
+	return r.searchMessages(ctx, r.db.Where("messages.conversation_id = ?", conversationID), query, beforeCursor, beforeID, limit)
+}
+
+func (r *MessageRepositoryImpl) SearchByParticipant(ctx context.Context, userID uuid.UUID, query string, beforeCursor *time.Time, beforeID uuid.UUID, limit int) ([]*models.Message, error) {
+	scope := r.db.Where(`messages.conversation_id IN (
+		SELECT conversation_id FROM conversation_participants WHERE user_id = ?
+	)`, userID)
+	return r.searchMessages(ctx, scope, query, beforeCursor, beforeID, limit)
+}
+
+// searchMessages matches the text of messages within scope (gift notes stay hidden, even once opened).
+// Pages on (created_at, id) so messages sharing a timestamp are neither skipped nor repeated.
+func (r *MessageRepositoryImpl) searchMessages(ctx context.Context, scope *gorm.DB, query string, beforeCursor *time.Time, beforeID uuid.UUID, limit int) ([]*models.Message, error) {
+	dbQuery := r.db.WithContext(ctx).
+		Preload("Sender").
+		Preload("Receiver").
+		Preload("Gift.Package").
+		Preload("ReplyTo.Sender").
+		Preload("ReplyTo.Gift").
+		Preload("Reactions", orderReactions).
+		Where(scope).
+		Where("messages.type <> ?", models.MessageTypeGift).
+		Where("messages.content ILIKE ?", "%"+escapeLikePattern(query)+"%").
+		Order("messages.created_at DESC, messages.id DESC")
+
+	// Apply cursor pagination (cursors issued before the id was added page on created_at alone)
+	if beforeCursor != nil {
+		if beforeID != uuid.Nil {
+			dbQuery = dbQuery.Where("(messages.created_at, messages.id) < (?, ?)", *beforeCursor, beforeID)
+		} else {
+			dbQuery = dbQuery.Where("messages.created_at < ?", *beforeCursor)
+		}
+	}
+
+	var messages []*models.Message
+	err := dbQuery.Limit(limit).Find(&messages).Error
+	return messages, err
+}
+
+// escapeLikePattern makes the LIKE wildcards in user input match literally
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (r *MessageRepositoryImpl) MarkAsRead(ctx context.Context, messageID uuid.UUID) error {
 	now := time.Now()
 	return r.db.WithContext(ctx).
@@ -108,6 +290,34 @@ func (r *MessageRepositoryImpl) MarkAllAsRead(ctx context.Context, conversationI
 		}).Error
 }
 
+func (r *MessageRepositoryImpl) MarkAsReadUpTo(ctx context.Context, conversationID uuid.UUID, userID uuid.UUID, seq int64) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&models.Message{}).
+		Where("conversation_id = ? AND receiver_id = ? AND is_read = ? AND seq <= ?", conversationID, userID, false, seq).
+		Updates(map[string]interface{}{
+			"is_read": true,
+			"read_at": now,
+		}).Error
+}
+
+func (r *MessageRepositoryImpl) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Message, error) {
+	var messages []*models.Message
+	if len(ids) == 0 {
+		return messages, nil
+	}
+	err := r.db.WithContext(ctx).
+		Preload("Sender").
+		Preload("Receiver").
+		Preload("Gift.Package").
+		Preload("ReplyTo.Sender").
+		Preload("ReplyTo.Gift").
+		Preload("Reactions", orderReactions).
+		Where("id IN ?", ids).
+		Find(&messages).Error
+	return messages, err
+}
+
 func (r *MessageRepositoryImpl) Count(ctx context.Context) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&models.Message{}).Count(&count).Error
@@ -138,6 +348,10 @@ func (r *MessageRepositoryImpl) ListMediaMessages(ctx context.Context, conversat
 	query := r.db.WithContext(ctx).
 		Preload("Sender").
 		Preload("Receiver").
+		Preload("Gift.Package").
+		Preload("ReplyTo.Sender").
+		Preload("ReplyTo.Gift").
+		Preload("Reactions", orderReactions).
 		Where("conversation_id = ?", conversationID).
 		Where("type IN (?)", []string{"image", "video"}). // Media messages only
 		Where("media IS NOT NULL AND media != '[]'").     // Has media content
@@ -162,6 +376,10 @@ func (r *MessageRepositoryImpl) ListMessagesWithLinks(ctx context.Context, conve
 	query := r.db.WithContext(ctx).
 		Preload("Sender").
 		Preload("Receiver").
+		Preload("Gift.Package").
+		Preload("ReplyTo.Sender").
+		Preload("ReplyTo.Gift").
+		Preload("Reactions", orderReactions).
 		Where("conversation_id = ?", conversationID).
 		Where("type = ?", "text").
 		Where("content IS NOT NULL").
@@ -183,6 +401,10 @@ func (r *MessageRepositoryImpl) ListFileMessages(ctx context.Context, conversati
 	query := r.db.WithContext(ctx).
 		Preload("Sender").
 		Preload("Receiver").
+		Preload("Gift.Package").
+		Preload("ReplyTo.Sender").
+		Preload("ReplyTo.Gift").
+		Preload("Reactions", orderReactions).
 		Where("conversation_id = ?", conversationID).
 		Where("type = ?", "file"). // File type messages
 		Where("media IS NOT NULL AND media != '[]'").
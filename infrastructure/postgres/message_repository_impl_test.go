@@ -0,0 +1,105 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofiber-template/domain/models"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/testutil"
+)
+
+func setupMessageRepoTest(t *testing.T) (*MessageRepositoryImpl, *UserRepositoryImpl, *ConversationRepositoryImpl, func()) {
+	// Get test database
+	db, err := config.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+
+	// Cleanup function
+	cleanup := func() {
+		err := config.CleanTestDB(db)
+		assert.NoError(t, err, "Failed to clean test database")
+	}
+
+	return &MessageRepositoryImpl{db: db}, &UserRepositoryImpl{db: db}, &ConversationRepositoryImpl{db: db}, cleanup
+}
+
+// editedMessage creates a text message between two new users and edits it once
+func editedMessage(t *testing.T, ctx context.Context, messageRepo *MessageRepositoryImpl, userRepo *UserRepositoryImpl, conversationRepo *ConversationRepositoryImpl) *models.Message {
+	sender := testutil.CreateTestUser()
+	require.NoError(t, userRepo.Create(ctx, sender))
+	receiver := testutil.CreateTestUser()
+	require.NoError(t, userRepo.Create(ctx, receiver))
+
+	conversation, _, err := conversationRepo.GetOrCreateByUsers(ctx, sender.ID, receiver.ID, nil)
+	require.NoError(t, err)
+
+	message := testutil.CreateTestMessage(conversation.ID, sender.ID, receiver.ID)
+	require.NoError(t, messageRepo.Create(ctx, message))
+
+	original := message.Content
+	edited := "edited"
+	now := time.Now()
+	message.Content = &edited
+	message.EditedAt = &now
+	message.UpdatedAt = now
+	require.NoError(t, messageRepo.UpdateContentWithRevision(ctx, message, &models.MessageRevision{
+		ID:        uuid.New(),
+		MessageID: message.ID,
+		Content:   original,
+	}))
+
+	return message
+}
+
+func TestMessageRepository_TombstoneClearsContent(t *testing.T) {
+	messageRepo, userRepo, conversationRepo, cleanup := setupMessageRepoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	message := editedMessage(t, ctx, messageRepo, userRepo, conversationRepo)
+
+	// Act
+	removed, err := messageRepo.Tombstone(ctx, message.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	stored, err := messageRepo.GetByID(ctx, message.ID)
+	require.NoError(t, err)
+	assert.Nil(t, stored.Content)
+	assert.Empty(t, stored.Media)
+	assert.NotNil(t, stored.RemovedAt)
+
+	revisions, err := messageRepo.ListRevisions(ctx, message.ID)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	// Removing twice is a no-op
+	removed, err = messageRepo.Tombstone(ctx, message.ID)
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
+
+func TestMessageRepository_EmptyMessageRequiresRemoval(t *testing.T) {
+	messageRepo, userRepo, conversationRepo, cleanup := setupMessageRepoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	message := editedMessage(t, ctx, messageRepo, userRepo, conversationRepo)
+
+	// Act
+	err := messageRepo.db.Model(&models.Message{}).
+		Where("id = ?", message.ID).
+		Updates(map[string]interface{}{"content": nil, "media": nil}).Error
+
+	// Assert
+	assert.Error(t, err, "only removed messages may have neither content nor media")
+}
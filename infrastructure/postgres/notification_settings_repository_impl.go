@@ -64,7 +64,7 @@ func (r *NotificationSettingsRepositoryImpl) ShouldNotify(ctx context.Context, u
 		return settings.Mentions, nil
 	case "vote", "votes":
 		return settings.Votes, nil
-	case "follow", "follows":
+	case "follow", "follows", "follow_request", "follow_accepted":
 		return settings.Follows, nil
 	default:
 		return true, nil
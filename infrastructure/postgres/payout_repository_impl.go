@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+)
+
+type PayoutRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPayoutRepository(db *gorm.DB) repositories.PayoutRepository {
+	return &PayoutRepositoryImpl{db: db}
+}
+
+func (r *PayoutRepositoryImpl) Create(ctx context.Context, payout *models.Payout) error {
+	return database.Conn(ctx, r.db).WithContext(ctx).Omit("User").Create(payout).Error
+}
+
+func (r *PayoutRepositoryImpl) Update(ctx context.Context, payout *models.Payout) error {
+	return r.db.WithContext(ctx).Omit("User").Save(payout).Error
+}
+
+// UpdateStatus writes the lifecycle and review fields only while the payout is still in fromStatus,
+// so two admins acting on the same payout cannot both move it
+func (r *PayoutRepositoryImpl) UpdateStatus(ctx context.Context, payout *models.Payout, fromStatus string) (bool, error) {
+	result := database.Conn(ctx, r.db).WithContext(ctx).
+		Model(payout).
+		Where("status = ?", fromStatus).
+		Select(
+			"status", "ledger_tx_id", "refund_tx_id",
+			"reviewed_by", "reviewed_at", "rejection_reason",
+			"bank_reference", "paid_at", "failure_reason", "updated_at",
+		).
+		Updates(payout)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *PayoutRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Payout, error) {
+	var payout models.Payout
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		First(&payout, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
+
+func (r *PayoutRepositoryImpl) GetPendingByUser(ctx context.Context, userID uuid.UUID) (*models.Payout, error) {
+	var payout models.Payout
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, models.PayoutStatusPending).
+		First(&payout).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payout, nil
+}
+
+func (r *PayoutRepositoryImpl) ListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.Payout, error) {
+	var payouts []*models.Payout
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&payouts).Error
+	return payouts, err
+}
+
+func (r *PayoutRepositoryImpl) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Payout{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *PayoutRepositoryImpl) ListByStatus(ctx context.Context, status string, offset, limit int) ([]*models.Payout, error) {
+	var payouts []*models.Payout
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("status = ?", status).
+		Order("created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&payouts).Error
+	return payouts, err
+}
+
+func (r *PayoutRepositoryImpl) CountByStatus(ctx context.Context, status string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Payout{}).
+		Where("status = ?", status).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *PayoutRepositoryImpl) SumEarnings(ctx context.Context, walletID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Table("ledger_entries AS e").
+		Joins("JOIN ledger_transactions AS t ON t.id = e.transaction_id").
+		Where("e.wallet_id = ? AND e.amount > 0", walletID).
+		Where("t.type NOT IN ?", []string{models.LedgerTxTypeTopUp, models.LedgerTxTypeRefund}).
+		Select("COALESCE(SUM(e.amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *PayoutRepositoryImpl) SumWithdrawn(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Payout{}).
+		Where("user_id = ? AND status IN ?", userID, []string{
+			models.PayoutStatusPending,
+			models.PayoutStatusApproved,
+			models.PayoutStatusPaid,
+		}).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// Ensure interface compliance
+var _ repositories.PayoutRepository = (*PayoutRepositoryImpl)(nil)
@@ -0,0 +1,294 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofiber-template/application/serviceimpl"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/database"
+	"gofiber-template/pkg/testutil"
+)
+
+type payoutTestEnv struct {
+	walletRepo    *WalletRepositoryImpl
+	walletService services.WalletService
+	userRepo      *UserRepositoryImpl
+	payoutRepo    *PayoutRepositoryImpl
+	payoutService services.PayoutService
+}
+
+func setupPayoutTest(t *testing.T) (*payoutTestEnv, func()) {
+	// Get test database
+	db, err := config.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+
+	env := &payoutTestEnv{
+		walletRepo: &WalletRepositoryImpl{db: db},
+		userRepo:   &UserRepositoryImpl{db: db},
+		payoutRepo: &PayoutRepositoryImpl{db: db},
+	}
+	env.walletService = serviceimpl.NewWalletService(env.walletRepo)
+	env.payoutService = serviceimpl.NewPayoutService(
+		database.NewTransactionManager(db),
+		env.payoutRepo,
+		env.userRepo,
+		env.walletService,
+		silentNotifications{},
+	)
+
+	// Cleanup function
+	cleanup := func() {
+		err := config.CleanTestDB(db)
+		assert.NoError(t, err, "Failed to clean test database")
+	}
+
+	return env, cleanup
+}
+
+// twoFactorUser creates a user with two-factor enabled (required for every payout step)
+func twoFactorUser(t *testing.T, ctx context.Context, env *payoutTestEnv) *models.User {
+	user := testutil.CreateTestUser()
+	user.TwoFactorEnabled = true
+	require.NoError(t, env.userRepo.Create(ctx, user))
+	return user
+}
+
+// requestedPayout gives a creator the earnings (satang) and requests a 600 THB payout
+func requestedPayout(t *testing.T, ctx context.Context, env *payoutTestEnv, earnings int64) (*models.User, *models.Wallet, *dto.PayoutResponse) {
+	creator := twoFactorUser(t, ctx, env)
+	creatorWallet, err := env.walletService.GetOrCreateUserWallet(ctx, creator.ID)
+	require.NoError(t, err)
+
+	// Earnings are any credit other than a top-up or a refund
+	source, err := env.walletService.GetSystemWallet(ctx, models.SystemWalletTopUpClearing)
+	require.NoError(t, err)
+	_, err = env.walletService.Transfer(ctx, &dto.WalletTransferRequest{
+		IdempotencyKey: "test-earning:" + uuid.NewString(),
+		Type:           models.LedgerTxTypeTransfer,
+		FromWalletID:   source.ID,
+		ToWalletID:     creatorWallet.ID,
+		Amount:         earnings,
+	})
+	require.NoError(t, err)
+
+	payout, err := env.payoutService.RequestPayout(ctx, creator.ID, &dto.RequestPayoutRequest{
+		Amount:          60000,
+		DestinationType: models.PayoutDestinationPromptPay,
+		AccountNumber:   "0812345678",
+		AccountName:     "Test Creator",
+	})
+	require.NoError(t, err)
+
+	return creator, creatorWallet, payout
+}
+
+// approvedPayout requests a 600 THB payout out of 1,000 THB of earnings and approves it
+func approvedPayout(t *testing.T, ctx context.Context, env *payoutTestEnv) (*models.Wallet, *models.User, *dto.PayoutResponse) {
+	_, creatorWallet, payout := requestedPayout(t, ctx, env, 100000)
+	admin := twoFactorUser(t, ctx, env)
+
+	payout, err := env.payoutService.ApprovePayout(ctx, payout.ID, admin.ID)
+	require.NoError(t, err)
+
+	return creatorWallet, admin, payout
+}
+
+func TestPayout_ApproveAndMarkPaid(t *testing.T) {
+	env, cleanup := setupPayoutTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	clearing, err := env.walletService.GetSystemWallet(ctx, models.SystemWalletPayoutClearing)
+	require.NoError(t, err)
+	feeWallet, err := env.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+	require.NoError(t, err)
+	clearingBefore, feeBefore := clearing.Balance, feeWallet.Balance
+
+	creatorWallet, admin, payout := approvedPayout(t, ctx, env)
+
+	// Approval captures the hold: 582 THB to payout clearing, the 3% fee to the platform
+	walletAfter, err := env.walletRepo.GetByID(ctx, creatorWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(40000), walletAfter.Balance)
+	assert.Zero(t, walletAfter.HeldAmount)
+
+	clearingAfter, err := env.walletRepo.GetByID(ctx, clearing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(58200), clearingAfter.Balance-clearingBefore)
+
+	feeAfter, err := env.walletRepo.GetByID(ctx, feeWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1800), feeAfter.Balance-feeBefore)
+
+	// An approved payout cannot be approved again
+	_, err = env.payoutService.ApprovePayout(ctx, payout.ID, admin.ID)
+	assert.ErrorIs(t, err, services.ErrPayoutInvalidStatus)
+
+	// Act
+	resp, err := env.payoutService.MarkPayoutsPaid(ctx, admin.ID, &dto.MarkPayoutsPaidRequest{
+		PayoutIDs:     []uuid.UUID{payout.ID},
+		BankReference: "BATCH-1",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Paid)
+	assert.Empty(t, resp.Skipped)
+
+	paid, err := env.payoutRepo.GetByID(ctx, payout.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PayoutStatusPaid, paid.Status)
+	require.NotNil(t, paid.BankReference)
+	assert.Equal(t, "BATCH-1", *paid.BankReference)
+	assert.NotNil(t, paid.PaidAt)
+
+	// A paid payout is neither paid twice nor refunded
+	resp, err = env.payoutService.MarkPayoutsPaid(ctx, admin.ID, &dto.MarkPayoutsPaidRequest{
+		PayoutIDs:     []uuid.UUID{payout.ID},
+		BankReference: "BATCH-2",
+	})
+	require.NoError(t, err)
+	assert.Zero(t, resp.Paid)
+	assert.Equal(t, []uuid.UUID{payout.ID}, resp.Skipped)
+
+	_, err = env.payoutService.FailPayout(ctx, payout.ID, admin.ID, "bounced")
+	assert.ErrorIs(t, err, services.ErrPayoutInvalidStatus)
+
+	walletAfter, err = env.walletRepo.GetByID(ctx, creatorWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(40000), walletAfter.Balance)
+	assert.Zero(t, sumAllEntries(t, env.walletRepo))
+}
+
+func TestPayout_FailRefundsCreator(t *testing.T) {
+	env, cleanup := setupPayoutTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	clearing, err := env.walletService.GetSystemWallet(ctx, models.SystemWalletPayoutClearing)
+	require.NoError(t, err)
+	clearingBefore := clearing.Balance
+
+	creatorWallet, admin, payout := approvedPayout(t, ctx, env)
+
+	// Act
+	failed, err := env.payoutService.FailPayout(ctx, payout.ID, admin.ID, "account closed")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, models.PayoutStatusFailed, failed.Status)
+
+	// The full amount comes back, fee included
+	walletAfter, err := env.walletRepo.GetByID(ctx, creatorWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100000), walletAfter.Balance)
+
+	clearingAfter, err := env.walletRepo.GetByID(ctx, clearing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, clearingBefore, clearingAfter.Balance)
+
+	stored, err := env.payoutRepo.GetByID(ctx, payout.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, stored.RefundTxID)
+
+	// A failed payout is neither refunded twice nor paid
+	_, err = env.payoutService.FailPayout(ctx, payout.ID, admin.ID, "account closed")
+	assert.ErrorIs(t, err, services.ErrPayoutInvalidStatus)
+
+	resp, err := env.payoutService.MarkPayoutsPaid(ctx, admin.ID, &dto.MarkPayoutsPaidRequest{
+		PayoutIDs:     []uuid.UUID{payout.ID},
+		BankReference: "BATCH-1",
+	})
+	require.NoError(t, err)
+	assert.Zero(t, resp.Paid)
+
+	walletAfter, err = env.walletRepo.GetByID(ctx, creatorWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100000), walletAfter.Balance)
+	assert.Zero(t, sumAllEntries(t, env.walletRepo))
+}
+
+func TestPayout_ConcurrentPaidAndFailSettleOnce(t *testing.T) {
+	env, cleanup := setupPayoutTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	creatorWallet, admin, payout := approvedPayout(t, ctx, env)
+
+	// Act
+	var (
+		wg      sync.WaitGroup
+		paidErr error
+		paid    *dto.MarkPayoutsPaidResponse
+		failErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		paid, paidErr = env.payoutService.MarkPayoutsPaid(ctx, admin.ID, &dto.MarkPayoutsPaidRequest{
+			PayoutIDs:     []uuid.UUID{payout.ID},
+			BankReference: "BATCH-1",
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		_, failErr = env.payoutService.FailPayout(ctx, payout.ID, admin.ID, "bounced")
+	}()
+	wg.Wait()
+
+	// Assert
+	require.NoError(t, paidErr)
+	stored, err := env.payoutRepo.GetByID(ctx, payout.ID)
+	require.NoError(t, err)
+	walletAfter, err := env.walletRepo.GetByID(ctx, creatorWallet.ID)
+	require.NoError(t, err)
+
+	// Exactly one transition wins, the creator is never both paid and refunded
+	if paid.Paid == 1 {
+		assert.ErrorIs(t, failErr, services.ErrPayoutInvalidStatus)
+		assert.Equal(t, models.PayoutStatusPaid, stored.Status)
+		assert.Nil(t, stored.RefundTxID)
+		assert.Equal(t, int64(40000), walletAfter.Balance)
+	} else {
+		assert.NoError(t, failErr)
+		assert.Equal(t, models.PayoutStatusFailed, stored.Status)
+		assert.Nil(t, stored.PaidAt)
+		assert.Equal(t, int64(100000), walletAfter.Balance)
+	}
+	assert.Zero(t, sumAllEntries(t, env.walletRepo))
+}
+
+func TestPayout_CancelledPayoutCannotBeApproved(t *testing.T) {
+	env, cleanup := setupPayoutTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	creator, creatorWallet, payout := requestedPayout(t, ctx, env, 60000)
+	admin := twoFactorUser(t, ctx, env)
+
+	_, err := env.payoutService.CancelPayout(ctx, payout.ID, creator.ID)
+	require.NoError(t, err)
+
+	// Act
+	_, err = env.payoutService.ApprovePayout(ctx, payout.ID, admin.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrPayoutInvalidStatus)
+
+	walletAfter, err := env.walletRepo.GetByID(ctx, creatorWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60000), walletAfter.Balance)
+	assert.Zero(t, walletAfter.HeldAmount)
+	assert.Zero(t, sumAllEntries(t, env.walletRepo))
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"gofiber-template/domain/models"
@@ -20,7 +21,8 @@ func setupBenchmarkDB(b *testing.B) *gorm.DB {
 		b.Fatalf("Failed to load config: %v", err)
 	}
 
-	dsn := config.GetPostgresDSN(cfg)
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
+		cfg.Database.Host, cfg.Database.User, cfg.Database.Password, cfg.Database.DBName, cfg.Database.Port, cfg.Database.SSLMode)
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent), // Disable logging for clean benchmarks
 	})
@@ -39,7 +41,7 @@ func BenchmarkList_Current(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := repo.List(ctx, 0, 20, repositories.SortByHot)
+		_, err := repo.List(ctx, 0, 20, repositories.SortByHot, nil)
 		if err != nil {
 			b.Fatalf("List failed: %v", err)
 		}
@@ -58,7 +60,7 @@ func BenchmarkListByAuthor_Current(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := repo.ListByAuthor(ctx, user.ID, 0, 20)
+		_, err := repo.ListByAuthor(ctx, user.ID, 0, 20, nil)
 		if err != nil {
 			b.Fatalf("ListByAuthor failed: %v", err)
 		}
@@ -73,7 +75,7 @@ func BenchmarkListByTag_Current(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := repo.ListByTag(ctx, "golang", 0, 20, repositories.SortByHot)
+		_, err := repo.ListByTag(ctx, "golang", 0, 20, repositories.SortByHot, nil)
 		if err != nil && err != gorm.ErrRecordNotFound {
 			b.Fatalf("ListByTag failed: %v", err)
 		}
@@ -97,7 +99,7 @@ func BenchmarkList_WithQueryCount(b *testing.B) {
 	ctx := context.Background()
 
 	queryCount = 0
-	_, err := repo.List(ctx, 0, 20, repositories.SortByHot)
+	_, err := repo.List(ctx, 0, 20, repositories.SortByHot, nil)
 	if err != nil {
 		b.Fatalf("List failed: %v", err)
 	}
@@ -32,6 +32,7 @@ func (r *PostRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models
 		Preload("Author").
 		Preload("Media").
 		Preload("Tags").
+		Preload("Community").
 		Preload("SourcePost").
 		Preload("SourcePost.Author").
 		Preload("SourcePost.Media").
@@ -50,6 +51,7 @@ func (r *PostRepositoryImpl) GetByClientPostID(ctx context.Context, clientPostID
 		Preload("Author").
 		Preload("Media").
 		Preload("Tags").
+		Preload("Community").
 		Preload("SourcePost").
 		Preload("SourcePost.Author").
 		Preload("SourcePost.Media").
@@ -77,55 +79,57 @@ func (r *PostRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 		}).Error
 }
 
-func (r *PostRepositoryImpl) List(ctx context.Context, offset, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
+func (r *PostRepositoryImpl) Remove(ctx context.Context, id uuid.UUID, moderatorID uuid.UUID, reason string) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&models.Post{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"is_deleted":     true,
+			"deleted_at":     now,
+			"removed_by_id":  moderatorID,
+			"removal_reason": reason,
+		}).Error
+}
+
+func (r *PostRepositoryImpl) List(ctx context.Context, offset, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
 	var posts []*models.Post
 	query := r.db.WithContext(ctx).
 		Preload("Author").
 		Preload("Media").
 		Preload("Tags").
+		Preload("Community").
 		Preload("SourcePost").
 		Preload("SourcePost.Author").
 		Preload("SourcePost.Media").
 		Preload("SourcePost.Tags").
-		Where("is_deleted = ? AND status = ?", false, "published")
-
-	switch sortBy {
-	case repositories.SortByHot:
-		// Hot score: votes / (hours + 2)^1.5
-		query = query.Order(r.hotScoreSQL() + " DESC")
-	case repositories.SortByNew:
-		query = query.Order("created_at DESC")
-	case repositories.SortByTop:
-		query = query.Order("votes DESC")
-	case repositories.SortByControversial:
-		// High comment count but mixed votes
-		query = query.Order("comment_count DESC, ABS(votes) DESC")
-	default:
-		query = query.Order("created_at DESC")
-	}
+		Where("is_deleted = ? AND status = ?", false, "published").
+		Scopes(shadowVisibility(viewerID), subscriberOnlyVisibility(viewerID), privateAccountVisibility(viewerID), communityVisibility(viewerID), r.sortOrder(sortBy))
 
 	err := query.Offset(offset).Limit(limit).Find(&posts).Error
 	return posts, err
 }
 
-func (r *PostRepositoryImpl) ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int) ([]*models.Post, error) {
+func (r *PostRepositoryImpl) ListByAuthor(ctx context.Context, authorID uuid.UUID, offset, limit int, viewerID *uuid.UUID) ([]*models.Post, error) {
 	var posts []*models.Post
 	err := r.db.WithContext(ctx).
 		Preload("Author").
 		Preload("Media").
 		Preload("Tags").
+		Preload("Community").
 		Preload("SourcePost").
 		Preload("SourcePost.Author").
 		Preload("SourcePost.Media").
 		Preload("SourcePost.Tags").
 		Where("author_id = ? AND is_deleted = ?", authorID, false).
+		Scopes(shadowVisibility(viewerID)).
 		Order("created_at DESC").
 		Offset(offset).Limit(limit).
 		Find(&posts).Error
 	return posts, err
 }
 
-func (r *PostRepositoryImpl) ListByTag(ctx context.Context, tagName string, offset, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
+func (r *PostRepositoryImpl) ListByTag(ctx context.Context, tagName string, offset, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
 	var posts []*models.Post
 
 	// Debug logging
@@ -135,58 +139,58 @@ func (r *PostRepositoryImpl) ListByTag(ctx context.Context, tagName string, offs
 		Preload("Author").
 		Preload("Media").
 		Preload("Tags").
+		Preload("Community").
 		Preload("SourcePost").
 		Preload("SourcePost.Author").
 		Preload("SourcePost.Media").
 		Preload("SourcePost.Tags").
 		Joins("JOIN post_tags ON post_tags.post_id = posts.id").
 		Joins("JOIN tags ON tags.id = post_tags.tag_id").
-		Where("LOWER(TRIM(tags.name)) = LOWER(TRIM(?)) AND posts.is_deleted = ? AND posts.status = ?", tagName, false, "published")
-
-	switch sortBy {
-	case repositories.SortByHot:
-		query = query.Order(r.hotScoreSQL() + " DESC")
-	case repositories.SortByNew:
-		query = query.Order("posts.created_at DESC")
-	case repositories.SortByTop:
-		query = query.Order("posts.votes DESC")
-	default:
-		query = query.Order("posts.created_at DESC")
-	}
+		Where("LOWER(TRIM(tags.name)) = LOWER(TRIM(?)) AND posts.is_deleted = ? AND posts.status = ?", tagName, false, "published").
+		Scopes(shadowVisibility(viewerID), subscriberOnlyVisibility(viewerID), privateAccountVisibility(viewerID), communityVisibility(viewerID), r.sortOrder(sortBy))
 
 	err := query.Offset(offset).Limit(limit).Find(&posts).Error
 	return posts, err
 }
 
-func (r *PostRepositoryImpl) ListByTagID(ctx context.Context, tagID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
+func (r *PostRepositoryImpl) ListByTagID(ctx context.Context, tagID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
 	var posts []*models.Post
 	query := r.db.WithContext(ctx).
 		Preload("Author").
 		Preload("Media").
 		Preload("Tags").
+		Preload("Community").
 		Preload("SourcePost").
 		Preload("SourcePost.Author").
 		Preload("SourcePost.Media").
 		Preload("SourcePost.Tags").
 		Joins("JOIN post_tags ON post_tags.post_id = posts.id").
-		Where("post_tags.tag_id = ? AND posts.is_deleted = ? AND posts.status = ?", tagID, false, "published")
-
-	switch sortBy {
-	case repositories.SortByHot:
-		query = query.Order(r.hotScoreSQL() + " DESC")
-	case repositories.SortByNew:
-		query = query.Order("posts.created_at DESC")
-	case repositories.SortByTop:
-		query = query.Order("posts.votes DESC")
-	default:
-		query = query.Order("posts.created_at DESC")
-	}
+		Where("post_tags.tag_id = ? AND posts.is_deleted = ? AND posts.status = ?", tagID, false, "published").
+		Scopes(shadowVisibility(viewerID), subscriberOnlyVisibility(viewerID), privateAccountVisibility(viewerID), communityVisibility(viewerID), r.sortOrder(sortBy))
+
+	err := query.Offset(offset).Limit(limit).Find(&posts).Error
+	return posts, err
+}
+
+func (r *PostRepositoryImpl) ListByCommunity(ctx context.Context, communityID uuid.UUID, offset, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
+	var posts []*models.Post
+	query := r.db.WithContext(ctx).
+		Preload("Author").
+		Preload("Media").
+		Preload("Tags").
+		Preload("Community").
+		Preload("SourcePost").
+		Preload("SourcePost.Author").
+		Preload("SourcePost.Media").
+		Preload("SourcePost.Tags").
+		Where("posts.community_id = ? AND posts.is_deleted = ? AND posts.status = ?", communityID, false, "published").
+		Scopes(shadowVisibility(viewerID), subscriberOnlyVisibility(viewerID), privateAccountVisibility(viewerID), r.sortOrder(sortBy))
 
 	err := query.Offset(offset).Limit(limit).Find(&posts).Error
 	return posts, err
 }
 
-func (r *PostRepositoryImpl) Search(ctx context.Context, query string, offset, limit int) ([]*models.Post, error) {
+func (r *PostRepositoryImpl) Search(ctx context.Context, query string, offset, limit int, viewerID *uuid.UUID) ([]*models.Post, error) {
 	var posts []*models.Post
 	searchQuery := "%" + query + "%"
 
@@ -194,6 +198,7 @@ func (r *PostRepositoryImpl) Search(ctx context.Context, query string, offset, l
 		Preload("Author").
 		Preload("Media").
 		Preload("Tags").
+		Preload("Community").
 		Preload("SourcePost").
 		Preload("SourcePost.Author").
 		Preload("SourcePost.Media").
@@ -208,13 +213,14 @@ func (r *PostRepositoryImpl) Search(ctx context.Context, query string, offset, l
 				AND tags.name ILIKE ?
 			)
 		)`, false, "published", searchQuery, searchQuery, searchQuery).
+		Scopes(shadowVisibility(viewerID), subscriberOnlyVisibility(viewerID), privateAccountVisibility(viewerID), communityVisibility(viewerID)).
 		Order("created_at DESC").
 		Offset(offset).Limit(limit).
 		Find(&posts).Error
 	return posts, err
 }
 
-func (r *PostRepositoryImpl) SearchWithCursor(ctx context.Context, query string, cursor *utils.PostCursor, limit int) ([]*models.Post, error) {
+func (r *PostRepositoryImpl) SearchWithCursor(ctx context.Context, query string, cursor *utils.PostCursor, limit int, viewerID *uuid.UUID) ([]*models.Post, error) {
 	var posts []*models.Post
 	searchQuery := "%" + query + "%"
 
@@ -222,6 +228,7 @@ func (r *PostRepositoryImpl) SearchWithCursor(ctx context.Context, query string,
 		Preload("Author").
 		Preload("Media").
 		Preload("Tags").
+		Preload("Community").
 		Preload("SourcePost").
 		Preload("SourcePost.Author").
 		Preload("SourcePost.Media").
@@ -235,7 +242,8 @@ func (r *PostRepositoryImpl) SearchWithCursor(ctx context.Context, query string,
 				WHERE post_tags.post_id = posts.id
 				AND tags.name ILIKE ?
 			)
-		)`, false, "published", searchQuery, searchQuery, searchQuery)
+		)`, false, "published", searchQuery, searchQuery, searchQuery).
+		Scopes(shadowVisibility(viewerID), subscriberOnlyVisibility(viewerID), privateAccountVisibility(viewerID), communityVisibility(viewerID))
 
 	// Apply cursor if provided (sort by created_at DESC, like feed)
 	if cursor != nil && !cursor.CreatedAt.IsZero() {
@@ -256,7 +264,7 @@ func (r *PostRepositoryImpl) GetCrossposts(ctx context.Context, postID uuid.UUID
 		Preload("Author").
 		Preload("Media").
 		Preload("Tags").
-		Where("source_post_id = ? AND is_deleted = ? AND status = ?", postID, false, "published").
+		Where("source_post_id = ? AND is_deleted = ? AND status = ? AND is_shadowed = ?", postID, false, "published", false).
 		Order("created_at DESC").
 		Offset(offset).Limit(limit).
 		Find(&posts).Error
@@ -265,19 +273,27 @@ func (r *PostRepositoryImpl) GetCrossposts(ctx context.Context, postID uuid.UUID
 
 func (r *PostRepositoryImpl) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&models.Post{}).Where("is_deleted = ? AND status = ?", false, "published").Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.Post{}).Where("is_deleted = ? AND status = ? AND is_shadowed = ?", false, "published", false).Count(&count).Error
 	return count, err
 }
 
-func (r *PostRepositoryImpl) CountByAuthor(ctx context.Context, authorID uuid.UUID) (int64, error) {
+func (r *PostRepositoryImpl) CountByAuthor(ctx context.Context, authorID uuid.UUID, viewerID *uuid.UUID) (int64, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&models.Post{}).
 		Where("author_id = ? AND is_deleted = ?", authorID, false).
+		Scopes(shadowVisibility(viewerID)).
 		Count(&count).Error
 	return count, err
 }
 
+func (r *PostRepositoryImpl) SetShadowedByAuthor(ctx context.Context, authorID uuid.UUID, shadowed bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Post{}).
+		Where("author_id = ? AND is_shadowed = ?", authorID, !shadowed).
+		Update("is_shadowed", shadowed).Error
+}
+
 func (r *PostRepositoryImpl) IncrementCommentCount(ctx context.Context, postID uuid.UUID) error {
 	return r.db.WithContext(ctx).
 		Model(&models.Post{}).
@@ -376,23 +392,158 @@ func (r *PostRepositoryImpl) hotScoreSQL() string {
 	)
 }
 
+// sortOrder orders posts by hot, new, top or controversial (default new)
+func (r *PostRepositoryImpl) sortOrder(sortBy repositories.PostSortBy) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch sortBy {
+		case repositories.SortByHot:
+			// Hot score: votes / (hours + 2)^1.5
+			return db.Order(r.hotScoreSQL() + " DESC")
+		case repositories.SortByTop:
+			return db.Order("posts.votes DESC")
+		case repositories.SortByControversial:
+			// High comment count but mixed votes
+			return db.Order("posts.comment_count DESC, ABS(posts.votes) DESC")
+		}
+		return db.Order("posts.created_at DESC")
+	}
+}
+
+// cursorPage orders posts for keyset pagination and skips everything up to the cursor.
+// Top and hot page on (sort value, created_at, id), every other sort on (created_at, id).
+// Hot scores decay between requests, so hot pages are approximate.
+func (r *PostRepositoryImpl) cursorPage(sortBy repositories.PostSortBy, cursor *utils.PostCursor) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		sortExpr := ""
+		switch sortBy {
+		case repositories.SortByTop:
+			sortExpr = "posts.votes"
+		case repositories.SortByHot:
+			sortExpr = r.hotScoreSQL()
+		}
+
+		if sortExpr == "" {
+			if cursor != nil {
+				db = db.Where("(posts.created_at, posts.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+			}
+			return db.Order("posts.created_at DESC, posts.id DESC")
+		}
+
+		if cursor != nil {
+			if cursor.SortValue != nil {
+				db = db.Where("("+sortExpr+", posts.created_at, posts.id) < (?, ?, ?)", *cursor.SortValue, cursor.CreatedAt, cursor.ID)
+			} else {
+				db = db.Where("(posts.created_at, posts.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+			}
+		}
+		return db.Order(sortExpr + " DESC, posts.created_at DESC, posts.id DESC")
+	}
+}
+
+// communityVisibility hides posts of private communities from lists outside the community
+// unless the viewer is an active member.
+func communityVisibility(viewerID *uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == nil {
+			return db.Where(`(posts.community_id IS NULL OR NOT EXISTS (
+				SELECT 1 FROM communities
+				WHERE communities.id = posts.community_id AND communities.visibility = ?
+			))`, models.CommunityVisibilityPrivate)
+		}
+		return db.Where(`(posts.community_id IS NULL OR NOT EXISTS (
+			SELECT 1 FROM communities
+			WHERE communities.id = posts.community_id AND communities.visibility = ?
+		) OR EXISTS (
+			SELECT 1 FROM community_members
+			WHERE community_members.community_id = posts.community_id
+			AND community_members.user_id = ?
+			AND community_members.status = ?
+		))`, models.CommunityVisibilityPrivate, *viewerID, models.CommunityMemberStatusActive)
+	}
+}
+
+// subscriberOnlyVisibility hides subscriber-only posts from discovery lists unless the viewer
+// is the author or holds an active subscription of at least the required tier.
+// Author profiles (ListByAuthor) keep them and the service shows a teaser instead.
+func subscriberOnlyVisibility(viewerID *uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == nil {
+			return db.Where("posts.min_tier_level IS NULL")
+		}
+		return db.Where(`(posts.min_tier_level IS NULL OR posts.author_id = ? OR EXISTS (
+			SELECT 1 FROM subscriptions
+			JOIN subscription_tiers ON subscription_tiers.id = subscriptions.tier_id
+			WHERE subscriptions.subscriber_id = ?
+			AND subscriptions.creator_id = posts.author_id
+			AND subscription_tiers.tier_level >= posts.min_tier_level
+			AND `+subscriptionAccessSQL+`
+		))`, *viewerID, *viewerID)
+	}
+}
+
+// privateAccountVisibility hides posts of private accounts from lists unless the viewer is the
+// author or follows them (author profiles are checked by the service before listing).
+func privateAccountVisibility(viewerID *uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == nil {
+			return db.Where(`NOT EXISTS (
+				SELECT 1 FROM users WHERE users.id = posts.author_id AND users.is_private = ?
+			)`, true)
+		}
+		return db.Where(`(posts.author_id = ? OR NOT EXISTS (
+			SELECT 1 FROM users WHERE users.id = posts.author_id AND users.is_private = ?
+		) OR EXISTS (
+			SELECT 1 FROM follows
+			WHERE follows.follower_id = ? AND follows.following_id = posts.author_id
+		))`, *viewerID, true, *viewerID)
+	}
+}
+
+// shadowVisibility hides posts written while the author was shadowbanned from everyone but the author.
+func shadowVisibility(viewerID *uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == nil {
+			return db.Where("posts.is_shadowed = ?", false)
+		}
+		return db.Where("(posts.is_shadowed = ? OR posts.author_id = ?)", false, *viewerID)
+	}
+}
+
 // Compiler check to ensure PostRepositoryImpl implements PostRepository
 var _ repositories.PostRepository = (*PostRepositoryImpl)(nil)
 
 // Cursor-based pagination methods (stub implementations)
-func (r *PostRepositoryImpl) ListWithCursor(ctx context.Context, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
+func (r *PostRepositoryImpl) ListWithCursor(ctx context.Context, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
 	// TODO: Implement cursor-based pagination
-	return r.List(ctx, 0, limit, sortBy)
+	return r.List(ctx, 0, limit, sortBy, viewerID)
 }
 
-func (r *PostRepositoryImpl) ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int) ([]*models.Post, error) {
+func (r *PostRepositoryImpl) ListByAuthorWithCursor(ctx context.Context, authorID uuid.UUID, cursor *utils.PostCursor, limit int, viewerID *uuid.UUID) ([]*models.Post, error) {
 	// TODO: Implement cursor-based pagination
-	return r.ListByAuthor(ctx, authorID, 0, limit)
+	return r.ListByAuthor(ctx, authorID, 0, limit, viewerID)
 }
 
-func (r *PostRepositoryImpl) ListByTagWithCursor(ctx context.Context, tagName string, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy) ([]*models.Post, error) {
+func (r *PostRepositoryImpl) ListByTagWithCursor(ctx context.Context, tagName string, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
 	// TODO: Implement cursor-based pagination
-	return r.ListByTag(ctx, tagName, 0, limit, sortBy)
+	return r.ListByTag(ctx, tagName, 0, limit, sortBy, viewerID)
+}
+
+func (r *PostRepositoryImpl) ListByCommunityWithCursor(ctx context.Context, communityID uuid.UUID, cursor *utils.PostCursor, limit int, sortBy repositories.PostSortBy, viewerID *uuid.UUID) ([]*models.Post, error) {
+	var posts []*models.Post
+	err := r.db.WithContext(ctx).
+		Preload("Author").
+		Preload("Media").
+		Preload("Tags").
+		Preload("Community").
+		Preload("SourcePost").
+		Preload("SourcePost.Author").
+		Preload("SourcePost.Media").
+		Preload("SourcePost.Tags").
+		Where("posts.community_id = ? AND posts.is_deleted = ? AND posts.status = ?", communityID, false, "published").
+		Scopes(shadowVisibility(viewerID), subscriberOnlyVisibility(viewerID), privateAccountVisibility(viewerID), r.cursorPage(sortBy, cursor)).
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
 }
 
 func (r *PostRepositoryImpl) ListFollowingFeedWithCursor(ctx context.Context, userID uuid.UUID, cursor *utils.PostCursor, limit int) ([]*models.Post, error) {
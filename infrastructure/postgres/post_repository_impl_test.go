@@ -6,13 +6,16 @@ package postgres
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/pkg/config"
 	"gofiber-template/pkg/testutil"
+	"gofiber-template/pkg/utils"
 )
 
 func setupPostRepoTest(t *testing.T) (*PostRepositoryImpl, *UserRepositoryImpl, func()) {
@@ -183,13 +186,50 @@ func TestPostRepository_List(t *testing.T) {
 	}
 
 	// Act
-	posts, err := postRepo.List(ctx, 0, 10, repositories.SortByNew)
+	posts, err := postRepo.List(ctx, 0, 10, repositories.SortByNew, nil)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.GreaterOrEqual(t, len(posts), 5)
 }
 
+func TestPostRepository_List_PrivateAccount(t *testing.T) {
+	postRepo, userRepo, cleanup := setupPostRepoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Private author with one post, plus a follower and a stranger
+	author := testutil.CreateTestUser()
+	author.IsPrivate = true
+	require.NoError(t, userRepo.Create(ctx, author))
+	follower := testutil.CreateTestUser()
+	require.NoError(t, userRepo.Create(ctx, follower))
+	stranger := testutil.CreateTestUser()
+	require.NoError(t, userRepo.Create(ctx, stranger))
+	require.NoError(t, postRepo.db.Create(&models.Follow{FollowerID: follower.ID, FollowingID: author.ID}).Error)
+
+	post := testutil.CreateTestPost(author.ID)
+	require.NoError(t, postRepo.Create(ctx, post))
+
+	listed := func(viewerID *uuid.UUID) bool {
+		posts, err := postRepo.List(ctx, 0, 10, repositories.SortByNew, viewerID)
+		require.NoError(t, err)
+		for _, p := range posts {
+			if p.ID == post.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Assert
+	assert.False(t, listed(nil), "anonymous viewers must not see private posts")
+	assert.False(t, listed(&stranger.ID), "non-followers must not see private posts")
+	assert.True(t, listed(&follower.ID), "followers see private posts")
+	assert.True(t, listed(&author.ID), "authors see their own posts")
+}
+
 func TestPostRepository_ListByAuthor(t *testing.T) {
 	postRepo, userRepo, cleanup := setupPostRepoTest(t)
 	defer cleanup()
@@ -220,7 +260,7 @@ func TestPostRepository_ListByAuthor(t *testing.T) {
 	}
 
 	// Act - Get posts by author1
-	posts, err := postRepo.ListByAuthor(ctx, author1.ID, 0, 10)
+	posts, err := postRepo.ListByAuthor(ctx, author1.ID, 0, 10, nil)
 
 	// Assert
 	assert.NoError(t, err)
@@ -275,7 +315,7 @@ func TestPostRepository_CountByAuthor(t *testing.T) {
 	}
 
 	// Act
-	count, err := postRepo.CountByAuthor(ctx, author.ID)
+	count, err := postRepo.CountByAuthor(ctx, author.ID, nil)
 
 	// Assert
 	assert.NoError(t, err)
@@ -339,7 +379,7 @@ func TestPostRepository_Search(t *testing.T) {
 	require.NoError(t, err)
 
 	// Act - Search for "Golang"
-	posts, err := postRepo.Search(ctx, "Golang", 0, 10)
+	posts, err := postRepo.Search(ctx, "Golang", 0, 10, nil)
 
 	// Assert
 	assert.NoError(t, err)
@@ -355,3 +395,71 @@ func TestPostRepository_Search(t *testing.T) {
 	}
 	assert.True(t, foundGolang, "Should find the Golang post")
 }
+
+func TestPostRepository_ListByCommunityWithCursor_SecondPage(t *testing.T) {
+	postRepo, userRepo, cleanup := setupPostRepoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Create author and community
+	author := testutil.CreateTestUser()
+	err := userRepo.Create(ctx, author)
+	require.NoError(t, err)
+
+	communityRepo := &CommunityRepositoryImpl{db: postRepo.db}
+	community := &models.Community{
+		Slug:    "cursor-" + uuid.NewString()[:8],
+		Name:    "Cursor Test",
+		OwnerID: author.ID,
+	}
+	err = communityRepo.Create(ctx, community, &models.CommunityMember{
+		UserID: author.ID,
+		Role:   models.CommunityRoleOwner,
+		Status: models.CommunityMemberStatusActive,
+	})
+	require.NoError(t, err)
+
+	// Five posts, two of them sharing a timestamp so the id tie-breaker matters
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	createdAt := []time.Time{base, base.Add(time.Minute), base.Add(time.Minute), base.Add(2 * time.Minute), base.Add(3 * time.Minute)}
+	for _, at := range createdAt {
+		post := testutil.CreateTestPost(author.ID)
+		post.CommunityID = &community.ID
+		post.CreatedAt = at
+		err := postRepo.Create(ctx, post)
+		require.NoError(t, err)
+	}
+
+	// Act - first page, then the page after its last post
+	page1, err := postRepo.ListByCommunityWithCursor(ctx, community.ID, nil, 2, repositories.SortByNew, nil)
+	require.NoError(t, err)
+	require.Len(t, page1, 2)
+
+	last := page1[len(page1)-1]
+	cursor := &utils.PostCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	page2, err := postRepo.ListByCommunityWithCursor(ctx, community.ID, cursor, 2, repositories.SortByNew, nil)
+	require.NoError(t, err)
+	require.Len(t, page2, 2)
+
+	last = page2[len(page2)-1]
+	cursor = &utils.PostCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	page3, err := postRepo.ListByCommunityWithCursor(ctx, community.ID, cursor, 2, repositories.SortByNew, nil)
+	require.NoError(t, err)
+
+	// Assert - pages continue where the previous one ended, without overlap
+	assert.Len(t, page3, 1)
+
+	seen := make(map[uuid.UUID]bool)
+	var previous *models.Post
+	for _, post := range append(append(page1, page2...), page3...) {
+		assert.False(t, seen[post.ID], "post %s returned twice", post.ID)
+		seen[post.ID] = true
+
+		if previous != nil {
+			assert.False(t, post.CreatedAt.After(previous.CreatedAt), "posts must be newest first")
+		}
+		previous = post
+	}
+	assert.Len(t, seen, 5)
+}
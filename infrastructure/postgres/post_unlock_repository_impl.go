@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostUnlockRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPostUnlockRepository(db *gorm.DB) repositories.PostUnlockRepository {
+	return &PostUnlockRepositoryImpl{db: db}
+}
+
+func (r *PostUnlockRepositoryImpl) GetPostForUpdate(ctx context.Context, postID uuid.UUID) (*models.Post, error) {
+	var post models.Post
+	err := database.Conn(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND is_deleted = ?", postID, false).
+		First(&post).Error
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func (r *PostUnlockRepositoryImpl) CreateContribution(ctx context.Context, contribution *models.PostUnlockContribution) (*models.Post, error) {
+	var post models.Post
+	err := database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		// Lock the post row so concurrent contributions serialize on the counters
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND is_deleted = ?", contribution.PostID, false).
+			First(&post).Error; err != nil {
+			return err
+		}
+
+		var previous int64
+		if err := tx.Model(&models.PostUnlockContribution{}).
+			Where("post_id = ? AND user_id = ?", contribution.PostID, contribution.UserID).
+			Count(&previous).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(contribution).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"unlock_current_amount": gorm.Expr("unlock_current_amount + ?", contribution.Amount),
+		}
+		if previous == 0 {
+			updates["unlock_contributor_count"] = gorm.Expr("unlock_contributor_count + 1")
+		}
+		if err := tx.Model(&models.Post{}).
+			Where("id = ?", contribution.PostID).
+			UpdateColumns(updates).Error; err != nil {
+			return err
+		}
+
+		return tx.First(&post, "id = ?", contribution.PostID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func (r *PostUnlockRepositoryImpl) GetContributionByIdempotencyKey(ctx context.Context, key string) (*models.PostUnlockContribution, error) {
+	var contribution models.PostUnlockContribution
+	err := r.db.WithContext(ctx).First(&contribution, "idempotency_key = ?", key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contribution, nil
+}
+
+func (r *PostUnlockRepositoryImpl) UpdateContributionStatus(ctx context.Context, id uuid.UUID, status string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.PostUnlockContribution{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *PostUnlockRepositoryImpl) ListContributionsByStatus(ctx context.Context, postID uuid.UUID, status string) ([]*models.PostUnlockContribution, error) {
+	var contributions []*models.PostUnlockContribution
+	err := r.db.WithContext(ctx).
+		Where("post_id = ? AND status = ?", postID, status).
+		Order("created_at ASC").
+		Find(&contributions).Error
+	return contributions, err
+}
+
+func (r *PostUnlockRepositoryImpl) CountUserContributions(ctx context.Context, postID, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).WithContext(ctx).
+		Model(&models.PostUnlockContribution{}).
+		Where("post_id = ? AND user_id = ?", postID, userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *PostUnlockRepositoryImpl) HasContributed(ctx context.Context, postID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.PostUnlockContribution{}).
+		Where("post_id = ? AND user_id = ? AND status <> ?", postID, userID, models.PostUnlockContributionReleased).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *PostUnlockRepositoryImpl) GetContributedPostIDs(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	result := make(map[uuid.UUID]bool)
+	if len(postIDs) == 0 {
+		return result, nil
+	}
+
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&models.PostUnlockContribution{}).
+		Distinct("post_id").
+		Where("user_id = ? AND post_id IN ? AND status <> ?", userID, postIDs, models.PostUnlockContributionReleased).
+		Pluck("post_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		result[id] = true
+	}
+	return result, nil
+}
+
+func (r *PostUnlockRepositoryImpl) ListContributors(ctx context.Context, postID uuid.UUID, offset, limit int) ([]*repositories.UnlockContributorTotal, error) {
+	var totals []*repositories.UnlockContributorTotal
+	err := r.db.WithContext(ctx).
+		Model(&models.PostUnlockContribution{}).
+		Select("user_id, SUM(amount) AS total_amount, COUNT(*) AS contribution_count, MIN(created_at) AS first_contributed").
+		Where("post_id = ? AND status <> ?", postID, models.PostUnlockContributionReleased).
+		Group("user_id").
+		Order("total_amount DESC, first_contributed ASC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&totals).Error
+	return totals, err
+}
+
+func (r *PostUnlockRepositoryImpl) GetContributorTotal(ctx context.Context, postID, userID uuid.UUID) (*repositories.UnlockContributorTotal, error) {
+	total := &repositories.UnlockContributorTotal{UserID: userID}
+	err := r.db.WithContext(ctx).
+		Model(&models.PostUnlockContribution{}).
+		Select("COALESCE(SUM(amount), 0) AS total_amount, COUNT(*) AS contribution_count, COALESCE(MIN(created_at), NOW()) AS first_contributed").
+		Where("post_id = ? AND user_id = ? AND status <> ?", postID, userID, models.PostUnlockContributionReleased).
+		Scan(total).Error
+	if err != nil {
+		return nil, err
+	}
+	total.UserID = userID
+	return total, nil
+}
+
+func (r *PostUnlockRepositoryImpl) SetPreviewURL(ctx context.Context, postID uuid.UUID, previewURL string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Post{}).
+		Where("id = ?", postID).
+		UpdateColumn("unlock_preview_url", previewURL).Error
+}
+
+func (r *PostUnlockRepositoryImpl) MarkUnlocked(ctx context.Context, postID uuid.UUID) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).
+		Model(&models.Post{}).
+		Where("id = ? AND unlock_status = ?", postID, models.PostUnlockStatusLocked).
+		UpdateColumns(map[string]interface{}{
+			"unlock_status": models.PostUnlockStatusUnlocked,
+			"unlocked_at":   now,
+			"updated_at":    now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *PostUnlockRepositoryImpl) MarkFailed(ctx context.Context, postID uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Post{}).
+		Where("id = ? AND unlock_status = ?", postID, models.PostUnlockStatusLocked).
+		UpdateColumns(map[string]interface{}{
+			"unlock_status": models.PostUnlockStatusFailed,
+			"updated_at":    time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *PostUnlockRepositoryImpl) ListExpiredLockedPosts(ctx context.Context, now time.Time, limit int) ([]*models.Post, error) {
+	var posts []*models.Post
+	err := r.db.WithContext(ctx).
+		Where("unlock_status = ? AND unlock_deadline IS NOT NULL AND unlock_deadline < ?", models.PostUnlockStatusLocked, now).
+		Order("unlock_deadline ASC").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
+
+func (r *PostUnlockRepositoryImpl) ListUnsettledPostIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&models.PostUnlockContribution{}).
+		Distinct("post_unlock_contributions.post_id").
+		Joins("JOIN posts ON posts.id = post_unlock_contributions.post_id").
+		Where("post_unlock_contributions.status = ? AND posts.unlock_status IN ?",
+			models.PostUnlockContributionHeld,
+			[]string{models.PostUnlockStatusUnlocked, models.PostUnlockStatusFailed}).
+		Limit(limit).
+		Pluck("post_unlock_contributions.post_id", &ids).Error
+	return ids, err
+}
+
+// Ensure interface compliance
+var _ repositories.PostUnlockRepository = (*PostUnlockRepositoryImpl)(nil)
@@ -0,0 +1,212 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofiber-template/application/serviceimpl"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/database"
+	"gofiber-template/pkg/testutil"
+)
+
+// silentNotifications drops the notifications money flows send (only CreateNotification is used by them)
+type silentNotifications struct {
+	services.NotificationService
+}
+
+func (silentNotifications) CreateNotification(ctx context.Context, userID uuid.UUID, senderID uuid.UUID, notifType string, message string, postID *uuid.UUID, commentID *uuid.UUID) error {
+	return nil
+}
+
+type postUnlockTestEnv struct {
+	walletRepo     *WalletRepositoryImpl
+	walletService  services.WalletService
+	userRepo       *UserRepositoryImpl
+	postRepo       *PostRepositoryImpl
+	postUnlockRepo *PostUnlockRepositoryImpl
+	unlockService  services.PostUnlockService
+}
+
+func setupPostUnlockTest(t *testing.T) (*postUnlockTestEnv, func()) {
+	// Get test database
+	db, err := config.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+
+	env := &postUnlockTestEnv{
+		walletRepo:     &WalletRepositoryImpl{db: db},
+		userRepo:       &UserRepositoryImpl{db: db},
+		postRepo:       &PostRepositoryImpl{db: db},
+		postUnlockRepo: &PostUnlockRepositoryImpl{db: db},
+	}
+	env.walletService = serviceimpl.NewWalletService(env.walletRepo)
+	env.unlockService = serviceimpl.NewPostUnlockService(
+		database.NewTransactionManager(db),
+		env.postUnlockRepo,
+		env.postRepo,
+		env.userRepo,
+		env.walletService,
+		silentNotifications{},
+	)
+
+	// Cleanup function
+	cleanup := func() {
+		err := config.CleanTestDB(db)
+		assert.NoError(t, err, "Failed to clean test database")
+	}
+
+	return env, cleanup
+}
+
+// premiumPost creates a locked premium unlock post with the given target (satang)
+func premiumPost(t *testing.T, ctx context.Context, env *postUnlockTestEnv, target int64) (*models.Post, *models.Wallet) {
+	author := testutil.CreateTestUser()
+	require.NoError(t, env.userRepo.Create(ctx, author))
+	authorWallet, err := env.walletService.GetOrCreateUserWallet(ctx, author.ID)
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(24 * time.Hour)
+	status := models.PostUnlockStatusLocked
+	post := testutil.CreateTestPost(author.ID)
+	post.UnlockTargetAmount = &target
+	post.UnlockStatus = &status
+	post.UnlockDeadline = &deadline
+	require.NoError(t, env.postRepo.Create(ctx, post))
+
+	return post, authorWallet
+}
+
+// contributor creates a funded user and returns the user with their wallet
+func contributor(t *testing.T, ctx context.Context, env *postUnlockTestEnv, amount int64) (uuid.UUID, *models.Wallet) {
+	wallet := fundedWallet(t, ctx, env.walletService, env.userRepo, amount)
+	return *wallet.UserID, wallet
+}
+
+func TestPostUnlock_ReachingTargetSettles(t *testing.T) {
+	env, cleanup := setupPostUnlockTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	post, authorWallet := premiumPost(t, ctx, env, 10000)
+	firstID, firstWallet := contributor(t, ctx, env, 20000)
+	secondID, secondWallet := contributor(t, ctx, env, 20000)
+
+	feeWallet, err := env.walletService.GetSystemWallet(ctx, models.SystemWalletPlatformFee)
+	require.NoError(t, err)
+	feeBefore := feeWallet.Balance
+
+	// Act
+	first, err := env.unlockService.Contribute(ctx, post.ID, firstID, &dto.ContributeUnlockRequest{Amount: 4000, IdempotencyKey: "first"})
+	require.NoError(t, err)
+	assert.False(t, first.JustUnlocked)
+
+	// Below the target the money is only held
+	held, err := env.walletRepo.GetByID(ctx, firstWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20000), held.Balance)
+	assert.Equal(t, int64(4000), held.HeldAmount)
+
+	second, err := env.unlockService.Contribute(ctx, post.ID, secondID, &dto.ContributeUnlockRequest{Amount: 6000, IdempotencyKey: "second"})
+	require.NoError(t, err)
+
+	// Assert
+	assert.True(t, second.JustUnlocked)
+
+	unlocked, err := env.postRepo.GetByID(ctx, post.ID)
+	require.NoError(t, err)
+	assert.True(t, unlocked.IsUnlocked())
+
+	firstAfter, err := env.walletRepo.GetByID(ctx, firstWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(16000), firstAfter.Balance)
+	assert.Zero(t, firstAfter.HeldAmount)
+
+	secondAfter, err := env.walletRepo.GetByID(ctx, secondWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(14000), secondAfter.Balance)
+	assert.Zero(t, secondAfter.HeldAmount)
+
+	// Creator gets 85%, the platform 15%
+	authorAfter, err := env.walletRepo.GetByID(ctx, authorWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8500), authorAfter.Balance)
+
+	feeAfter, err := env.walletRepo.GetByID(ctx, feeWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), feeAfter.Balance-feeBefore)
+
+	captured, err := env.postUnlockRepo.ListContributionsByStatus(ctx, post.ID, models.PostUnlockContributionCaptured)
+	require.NoError(t, err)
+	assert.Len(t, captured, 2)
+	assert.Zero(t, sumAllEntries(t, env.walletRepo))
+
+	// A replayed request returns the original contribution without charging again
+	replayed, err := env.unlockService.Contribute(ctx, post.ID, firstID, &dto.ContributeUnlockRequest{Amount: 4000, IdempotencyKey: "first"})
+	require.NoError(t, err)
+	assert.Equal(t, first.ContributionID, replayed.ContributionID)
+
+	firstReplayed, err := env.walletRepo.GetByID(ctx, firstWallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(16000), firstReplayed.Balance)
+}
+
+func TestPostUnlock_ExpiredCampaignRefunds(t *testing.T) {
+	env, cleanup := setupPostUnlockTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	post, authorWallet := premiumPost(t, ctx, env, 10000)
+	userID, wallet := contributor(t, ctx, env, 20000)
+
+	_, err := env.unlockService.Contribute(ctx, post.ID, userID, &dto.ContributeUnlockRequest{Amount: 3000, IdempotencyKey: "only"})
+	require.NoError(t, err)
+
+	// The campaign deadline passes before the target is reached
+	require.NoError(t, env.postRepo.db.Model(&models.Post{}).
+		Where("id = ?", post.ID).
+		UpdateColumn("unlock_deadline", time.Now().Add(-time.Minute)).Error)
+
+	// Act
+	failed, err := env.unlockService.ProcessExpiredUnlocks(ctx)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, failed)
+
+	closed, err := env.postRepo.GetByID(ctx, post.ID)
+	require.NoError(t, err)
+	require.NotNil(t, closed.UnlockStatus)
+	assert.Equal(t, models.PostUnlockStatusFailed, *closed.UnlockStatus)
+
+	walletAfter, err := env.walletRepo.GetByID(ctx, wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20000), walletAfter.Balance)
+	assert.Zero(t, walletAfter.HeldAmount)
+
+	authorAfter, err := env.walletRepo.GetByID(ctx, authorWallet.ID)
+	require.NoError(t, err)
+	assert.Zero(t, authorAfter.Balance)
+
+	released, err := env.postUnlockRepo.ListContributionsByStatus(ctx, post.ID, models.PostUnlockContributionReleased)
+	require.NoError(t, err)
+	assert.Len(t, released, 1)
+
+	// Nothing is left for the settlement job
+	settled, err := env.unlockService.SettlePendingUnlocks(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, settled)
+
+	// The closed campaign takes no more money
+	_, err = env.unlockService.Contribute(ctx, post.ID, userID, &dto.ContributeUnlockRequest{Amount: 3000, IdempotencyKey: "late"})
+	assert.ErrorIs(t, err, services.ErrPostUnlockClosed)
+}
@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReelRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReelRepository(db *gorm.DB) repositories.ReelRepository {
+	return &ReelRepositoryImpl{db: db}
+}
+
+func (r *ReelRepositoryImpl) Create(ctx context.Context, reel *models.Reel) error {
+	return r.db.WithContext(ctx).Omit("Creator", "Media").Create(reel).Error
+}
+
+func (r *ReelRepositoryImpl) Update(ctx context.Context, reel *models.Reel) error {
+	return r.db.WithContext(ctx).Omit("Creator", "Media").Save(reel).Error
+}
+
+func (r *ReelRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Reel, error) {
+	var reel models.Reel
+	err := r.db.WithContext(ctx).
+		Preload("Creator").
+		Preload("Media").
+		First(&reel, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reel, nil
+}
+
+func (r *ReelRepositoryImpl) GetByMediaID(ctx context.Context, mediaID uuid.UUID) (*models.Reel, error) {
+	var reel models.Reel
+	err := r.db.WithContext(ctx).
+		Preload("Creator").
+		Preload("Media").
+		First(&reel, "media_id = ?", mediaID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &reel, nil
+}
+
+func (r *ReelRepositoryImpl) ListActiveByCreator(ctx context.Context, creatorID uuid.UUID, now time.Time) ([]*models.Reel, error) {
+	var reels []*models.Reel
+	err := r.db.WithContext(ctx).
+		Preload("Creator").
+		Preload("Media").
+		Where("creator_id = ? AND status = ? AND expires_at > ?", creatorID, models.ReelStatusActive, now).
+		Order("created_at ASC").
+		Find(&reels).Error
+	return reels, err
+}
+
+func (r *ReelRepositoryImpl) ListActiveFromFollowing(ctx context.Context, userID uuid.UUID, now time.Time, limit int) ([]*models.Reel, error) {
+	var reels []*models.Reel
+	err := r.db.WithContext(ctx).
+		Preload("Creator").
+		Preload("Media").
+		Where("creator_id IN (?)", r.db.Table("follows").Select("following_id").Where("follower_id = ?", userID)).
+		Where("status = ? AND expires_at > ?", models.ReelStatusActive, now).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&reels).Error
+	return reels, err
+}
+
+func (r *ReelRepositoryImpl) ListCurrentByCreator(ctx context.Context, creatorID uuid.UUID, now time.Time) ([]*models.Reel, error) {
+	var reels []*models.Reel
+	err := r.db.WithContext(ctx).
+		Preload("Creator").
+		Preload("Media").
+		Where("creator_id = ? AND status IN ? AND expires_at > ?", creatorID,
+			[]string{models.ReelStatusProcessing, models.ReelStatusActive, models.ReelStatusFailed}, now).
+		Order("created_at DESC").
+		Find(&reels).Error
+	return reels, err
+}
+
+func (r *ReelRepositoryImpl) CountCreatedSince(ctx context.Context, creatorID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Reel{}).
+		Where("creator_id = ? AND created_at >= ?", creatorID, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *ReelRepositoryImpl) RecordView(ctx context.Context, view *models.ReelView) (bool, error) {
+	firstView := false
+	err := database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		result := tx.Omit("Viewer").
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "reel_id"}, {Name: "viewer_id"}},
+				DoNothing: true,
+			}).
+			Create(view)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected > 0 {
+			firstView = true
+			return tx.Model(&models.Reel{}).
+				Where("id = ?", view.ReelID).
+				UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
+		}
+
+		// Rewatch - keep the longest watch and remember completion
+		return tx.Model(&models.ReelView{}).
+			Where("reel_id = ? AND viewer_id = ?", view.ReelID, view.ViewerID).
+			Updates(map[string]interface{}{
+				"watch_duration": gorm.Expr("GREATEST(watch_duration, ?)", view.WatchDuration),
+				"completed":      gorm.Expr("completed OR ?", view.Completed),
+				"viewed_at":      view.ViewedAt,
+			}).Error
+	})
+	return firstView, err
+}
+
+func (r *ReelRepositoryImpl) GetViewedReelIDs(ctx context.Context, viewerID uuid.UUID, reelIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	result := make(map[uuid.UUID]bool)
+	if len(reelIDs) == 0 {
+		return result, nil
+	}
+
+	var viewed []uuid.UUID
+	err := r.db.WithContext(ctx).
+		Model(&models.ReelView{}).
+		Where("viewer_id = ? AND reel_id IN ?", viewerID, reelIDs).
+		Pluck("reel_id", &viewed).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range viewed {
+		result[id] = true
+	}
+	return result, nil
+}
+
+func (r *ReelRepositoryImpl) ListViewers(ctx context.Context, reelID uuid.UUID, offset, limit int) ([]*models.ReelView, error) {
+	var views []*models.ReelView
+	err := r.db.WithContext(ctx).
+		Preload("Viewer").
+		Where("reel_id = ?", reelID).
+		Order("viewed_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&views).Error
+	return views, err
+}
+
+func (r *ReelRepositoryImpl) DeleteViews(ctx context.Context, reelID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("reel_id = ?", reelID).
+		Delete(&models.ReelView{}).Error
+}
+
+func (r *ReelRepositoryImpl) ListExpired(ctx context.Context, now time.Time, limit int) ([]*models.Reel, error) {
+	var reels []*models.Reel
+	err := r.db.WithContext(ctx).
+		Preload("Media").
+		Where("status IN ? AND expires_at <= ?",
+			[]string{models.ReelStatusProcessing, models.ReelStatusActive, models.ReelStatusFailed}, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&reels).Error
+	return reels, err
+}
+
+// Ensure interface compliance
+var _ repositories.ReelRepository = (*ReelRepositoryImpl)(nil)
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type ReportRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) repositories.ReportRepository {
+	return &ReportRepositoryImpl{db: db}
+}
+
+func (r *ReportRepositoryImpl) Create(ctx context.Context, report *models.Report) error {
+	return r.db.WithContext(ctx).Omit("Reporter", "TargetUser").Create(report).Error
+}
+
+func (r *ReportRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Report, error) {
+	var report models.Report
+	err := r.db.WithContext(ctx).
+		Preload("Reporter").
+		Preload("TargetUser").
+		First(&report, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+func (r *ReportRepositoryImpl) HasOpenReport(ctx context.Context, reporterID uuid.UUID, targetType string, targetID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Report{}).
+		Where("reporter_id = ? AND target_type = ? AND target_id = ? AND status = ?", reporterID, targetType, targetID, models.ReportStatusOpen).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ReportRepositoryImpl) ListByReporter(ctx context.Context, reporterID uuid.UUID, offset, limit int) ([]*models.Report, error) {
+	var reports []*models.Report
+	err := r.db.WithContext(ctx).
+		Preload("TargetUser").
+		Where("reporter_id = ?", reporterID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&reports).Error
+	return reports, err
+}
+
+func (r *ReportRepositoryImpl) CountByReporter(ctx context.Context, reporterID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Report{}).
+		Where("reporter_id = ?", reporterID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *ReportRepositoryImpl) ListByStatus(ctx context.Context, status, targetType string, offset, limit int) ([]*models.Report, error) {
+	var reports []*models.Report
+	err := r.db.WithContext(ctx).
+		Preload("Reporter").
+		Preload("TargetUser").
+		Scopes(reportQueueFilter(status, targetType)).
+		Order("created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&reports).Error
+	return reports, err
+}
+
+func (r *ReportRepositoryImpl) CountByStatus(ctx context.Context, status, targetType string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Report{}).
+		Scopes(reportQueueFilter(status, targetType)).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *ReportRepositoryImpl) ListOpenByTarget(ctx context.Context, targetType string, targetID uuid.UUID) ([]*models.Report, error) {
+	var reports []*models.Report
+	err := r.db.WithContext(ctx).
+		Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, models.ReportStatusOpen).
+		Order("created_at ASC").
+		Find(&reports).Error
+	return reports, err
+}
+
+func (r *ReportRepositoryImpl) CountOpenByTarget(ctx context.Context, targetType string, targetID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Report{}).
+		Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, models.ReportStatusOpen).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *ReportRepositoryImpl) ResolveOpenByTarget(ctx context.Context, targetType string, targetID uuid.UUID, status, action string, moderatorID uuid.UUID, note *string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Report{}).
+		Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, models.ReportStatusOpen).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"action":          action,
+			"resolved_by":     moderatorID,
+			"resolved_at":     time.Now(),
+			"resolution_note": note,
+			"updated_at":      time.Now(),
+		}).Error
+}
+
+// reportQueueFilter narrows the moderation queue by status and target type ("" = all types)
+func reportQueueFilter(status, targetType string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("status = ?", status)
+		if targetType != "" {
+			db = db.Where("target_type = ?", targetType)
+		}
+		return db
+	}
+}
+
+// Ensure interface compliance
+var _ repositories.ReportRepository = (*ReportRepositoryImpl)(nil)
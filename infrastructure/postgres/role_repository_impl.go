@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) repositories.RoleRepository {
+	return &RoleRepositoryImpl{db: db}
+}
+
+// ==================== Roles ====================
+
+func (r *RoleRepositoryImpl) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	var roles []*models.Role
+	err := r.db.WithContext(ctx).
+		Preload("Permissions").
+		Order("name ASC").
+		Find(&roles).Error
+	return roles, err
+}
+
+func (r *RoleRepositoryImpl) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	err := r.db.WithContext(ctx).
+		Preload("Permissions").
+		Where("name = ?", name).
+		First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *RoleRepositoryImpl) ListPermissions(ctx context.Context) ([]*models.Permission, error) {
+	var permissions []*models.Permission
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&permissions).Error
+	return permissions, err
+}
+
+// ==================== Grants ====================
+
+func (r *RoleRepositoryImpl) ListUserRoles(ctx context.Context, userID uuid.UUID) ([]*models.UserRole, error) {
+	var userRoles []*models.UserRole
+	err := r.db.WithContext(ctx).
+		Preload("Role.Permissions").
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&userRoles).Error
+	return userRoles, err
+}
+
+func (r *RoleRepositoryImpl) AssignRole(ctx context.Context, userRole *models.UserRole) (bool, error) {
+	// Duplicates hit the partial unique indexes (global / per community)
+	result := r.db.WithContext(ctx).
+		Omit("Role").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(userRole)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *RoleRepositoryImpl) RevokeRole(ctx context.Context, userID, roleID uuid.UUID, communityID *uuid.UUID) (bool, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ? AND role_id = ?", userID, roleID)
+	if communityID != nil {
+		query = query.Where("community_id = ?", *communityID)
+	} else {
+		query = query.Where("community_id IS NULL")
+	}
+
+	result := query.Delete(&models.UserRole{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Ensure interface compliance
+var _ repositories.RoleRepository = (*RoleRepositoryImpl)(nil)
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type SessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) repositories.SessionRepository {
+	return &SessionRepositoryImpl{db: db}
+}
+
+func (r *SessionRepositoryImpl) Create(ctx context.Context, session *models.Session) error {
+	return r.db.WithContext(ctx).Omit("User").Create(session).Error
+}
+
+func (r *SessionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+	var session models.Session
+	err := r.db.WithContext(ctx).First(&session, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *SessionRepositoryImpl) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	var sessions []*models.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *SessionRepositoryImpl) Rotate(ctx context.Context, session *models.Session, oldHash string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash": session.RefreshTokenHash,
+			"user_agent":         session.UserAgent,
+			"ip_address":         session.IPAddress,
+			"last_seen_at":       session.LastSeenAt,
+			"expires_at":         session.ExpiresAt,
+			"updated_at":         time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *SessionRepositoryImpl) Revoke(ctx context.Context, id uuid.UUID, reason string) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at":     now,
+			"revoked_reason": reason,
+			"updated_at":     now,
+		}).Error
+}
+
+// Ensure interface compliance
+var _ repositories.SessionRepository = (*SessionRepositoryImpl)(nil)
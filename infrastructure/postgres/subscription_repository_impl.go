@@ -0,0 +1,241 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gorm.io/gorm"
+)
+
+// subscriptionAccessSQL matches subscriptions that currently grant access to subscriber-only content
+// (keep in sync with models.Subscription.HasAccess)
+const subscriptionAccessSQL = `((subscriptions.status IN ('trial', 'active', 'cancelled') AND subscriptions.current_period_end > NOW())
+	OR (subscriptions.status = 'payment_failed' AND subscriptions.grace_until > NOW()))`
+
+type SubscriptionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionRepository(db *gorm.DB) repositories.SubscriptionRepository {
+	return &SubscriptionRepositoryImpl{db: db}
+}
+
+// ==================== Tiers ====================
+
+func (r *SubscriptionRepositoryImpl) CreateTier(ctx context.Context, tier *models.SubscriptionTier) error {
+	return r.db.WithContext(ctx).Create(tier).Error
+}
+
+func (r *SubscriptionRepositoryImpl) UpdateTier(ctx context.Context, tier *models.SubscriptionTier) error {
+	return r.db.WithContext(ctx).Omit("Creator").Save(tier).Error
+}
+
+func (r *SubscriptionRepositoryImpl) GetTierByID(ctx context.Context, id uuid.UUID) (*models.SubscriptionTier, error) {
+	var tier models.SubscriptionTier
+	err := r.db.WithContext(ctx).First(&tier, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+func (r *SubscriptionRepositoryImpl) ListTiersByCreator(ctx context.Context, creatorID uuid.UUID, activeOnly bool) ([]*models.SubscriptionTier, error) {
+	var tiers []*models.SubscriptionTier
+	query := r.db.WithContext(ctx).Where("creator_id = ?", creatorID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("tier_level ASC, created_at ASC").Find(&tiers).Error
+	return tiers, err
+}
+
+// ==================== Subscriptions ====================
+
+func (r *SubscriptionRepositoryImpl) Create(ctx context.Context, subscription *models.Subscription) error {
+	return r.db.WithContext(ctx).Omit("Subscriber", "Creator", "Tier").Create(subscription).Error
+}
+
+func (r *SubscriptionRepositoryImpl) Update(ctx context.Context, subscription *models.Subscription) error {
+	return r.db.WithContext(ctx).Omit("Subscriber", "Creator", "Tier").Save(subscription).Error
+}
+
+func (r *SubscriptionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.WithContext(ctx).
+		Preload("Subscriber").
+		Preload("Creator").
+		Preload("Tier").
+		First(&subscription, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *SubscriptionRepositoryImpl) GetBySubscriberAndCreator(ctx context.Context, subscriberID, creatorID uuid.UUID) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := r.db.WithContext(ctx).
+		Preload("Subscriber").
+		Preload("Creator").
+		Preload("Tier").
+		Where("subscriber_id = ? AND creator_id = ?", subscriberID, creatorID).
+		First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *SubscriptionRepositoryImpl) ListBySubscriber(ctx context.Context, subscriberID uuid.UUID, offset, limit int) ([]*models.Subscription, error) {
+	var subscriptions []*models.Subscription
+	err := r.db.WithContext(ctx).
+		Preload("Creator").
+		Preload("Tier").
+		Where("subscriber_id = ?", subscriberID).
+		Order("updated_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *SubscriptionRepositoryImpl) CountBySubscriber(ctx context.Context, subscriberID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Subscription{}).
+		Where("subscriber_id = ?", subscriberID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *SubscriptionRepositoryImpl) ListActiveByCreator(ctx context.Context, creatorID uuid.UUID, offset, limit int) ([]*models.Subscription, error) {
+	var subscriptions []*models.Subscription
+	err := r.db.WithContext(ctx).
+		Preload("Subscriber").
+		Preload("Tier").
+		Where("creator_id = ?", creatorID).
+		Where(subscriptionAccessSQL).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *SubscriptionRepositoryImpl) CountActiveByCreator(ctx context.Context, creatorID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Subscription{}).
+		Where("creator_id = ?", creatorID).
+		Where(subscriptionAccessSQL).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *SubscriptionRepositoryImpl) GetAccessTierLevels(ctx context.Context, subscriberID uuid.UUID, creatorIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	result := make(map[uuid.UUID]int)
+	if len(creatorIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		CreatorID uuid.UUID
+		TierLevel int
+	}
+	err := r.db.WithContext(ctx).
+		Model(&models.Subscription{}).
+		Select("subscriptions.creator_id, subscription_tiers.tier_level").
+		Joins("JOIN subscription_tiers ON subscription_tiers.id = subscriptions.tier_id").
+		Where("subscriptions.subscriber_id = ? AND subscriptions.creator_id IN ?", subscriberID, creatorIDs).
+		Where(subscriptionAccessSQL).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.CreatorID] = row.TierLevel
+	}
+	return result, nil
+}
+
+func (r *SubscriptionRepositoryImpl) GetTierStats(ctx context.Context, creatorID uuid.UUID) ([]*repositories.SubscriptionTierStats, error) {
+	var stats []*repositories.SubscriptionTierStats
+	err := r.db.WithContext(ctx).
+		Model(&models.Subscription{}).
+		Select(`tier_id,
+			COUNT(*) AS subscribers,
+			COALESCE(SUM(CASE WHEN billing_cycle = ? THEN price / 12 ELSE price END), 0) AS monthly_revenue`,
+			models.BillingCycleYearly).
+		Where("creator_id = ? AND status IN ?", creatorID,
+			[]string{models.SubscriptionStatusActive, models.SubscriptionStatusPaymentFailed}).
+		Where(subscriptionAccessSQL).
+		Group("tier_id").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// ==================== Payments ====================
+
+func (r *SubscriptionRepositoryImpl) CreatePayment(ctx context.Context, payment *models.SubscriptionPayment) error {
+	return r.db.WithContext(ctx).Create(payment).Error
+}
+
+func (r *SubscriptionRepositoryImpl) UpdatePaymentStatus(ctx context.Context, id uuid.UUID, status string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.SubscriptionPayment{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *SubscriptionRepositoryImpl) GetLatestPayment(ctx context.Context, subscriptionID uuid.UUID, billingReason string) (*models.SubscriptionPayment, error) {
+	var payment models.SubscriptionPayment
+	err := r.db.WithContext(ctx).
+		Where("subscription_id = ? AND billing_reason = ?", subscriptionID, billingReason).
+		Order("created_at DESC").
+		First(&payment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// ==================== Scheduler ====================
+
+func (r *SubscriptionRepositoryImpl) ListDueForRenewal(ctx context.Context, now, renewBefore time.Time, limit int) ([]*models.Subscription, error) {
+	var subscriptions []*models.Subscription
+	err := r.db.WithContext(ctx).
+		Preload("Tier").
+		Where("cancel_at_period_end = ?", false).
+		// Trials convert at trial end, paid plans renew ahead of the period end
+		Where("(status = ? AND current_period_end <= ?) OR (status IN ? AND current_period_end <= ?)",
+			models.SubscriptionStatusTrial, now,
+			[]string{models.SubscriptionStatusActive, models.SubscriptionStatusPaymentFailed}, renewBefore).
+		Where("next_retry_at IS NULL OR next_retry_at <= ?", now).
+		Order("current_period_end ASC").
+		Limit(limit).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *SubscriptionRepositoryImpl) ListToExpire(ctx context.Context, now time.Time, limit int) ([]*models.Subscription, error) {
+	var subscriptions []*models.Subscription
+	err := r.db.WithContext(ctx).
+		Preload("Tier").
+		Where("(status = ? AND current_period_end <= ?) OR (status = ? AND grace_until <= ?)",
+			models.SubscriptionStatusCancelled, now,
+			models.SubscriptionStatusPaymentFailed, now).
+		Order("current_period_end ASC").
+		Limit(limit).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// Ensure interface compliance
+var _ repositories.SubscriptionRepository = (*SubscriptionRepositoryImpl)(nil)
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type TopUpRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTopUpRepository(db *gorm.DB) repositories.TopUpRepository {
+	return &TopUpRepositoryImpl{db: db}
+}
+
+func (r *TopUpRepositoryImpl) Create(ctx context.Context, intent *models.TopUpIntent) error {
+	return r.db.WithContext(ctx).Omit("User").Create(intent).Error
+}
+
+func (r *TopUpRepositoryImpl) Update(ctx context.Context, intent *models.TopUpIntent) error {
+	return r.db.WithContext(ctx).Omit("User").Save(intent).Error
+}
+
+func (r *TopUpRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.TopUpIntent, error) {
+	var intent models.TopUpIntent
+	err := r.db.WithContext(ctx).First(&intent, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &intent, nil
+}
+
+func (r *TopUpRepositoryImpl) GetByProviderCharge(ctx context.Context, provider string, chargeID string) (*models.TopUpIntent, error) {
+	var intent models.TopUpIntent
+	err := r.db.WithContext(ctx).
+		First(&intent, "provider = ? AND provider_charge_id = ?", provider, chargeID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &intent, nil
+}
+
+func (r *TopUpRepositoryImpl) ListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.TopUpIntent, error) {
+	var intents []*models.TopUpIntent
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&intents).Error
+	return intents, err
+}
+
+func (r *TopUpRepositoryImpl) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.TopUpIntent{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *TopUpRepositoryImpl) MarkPaid(ctx context.Context, id uuid.UUID, eventID string, paidAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.TopUpIntent{}).
+		Where("id = ? AND status IN ?", id, []string{models.TopUpStatusPending, models.TopUpStatusExpired}).
+		Updates(map[string]interface{}{
+			"status":            models.TopUpStatusPaid,
+			"paid_at":           paidAt,
+			"provider_event_id": eventID,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *TopUpRepositoryImpl) MarkCredited(ctx context.Context, id uuid.UUID, ledgerTxID uuid.UUID, creditedAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.TopUpIntent{}).
+		Where("id = ? AND status = ?", id, models.TopUpStatusPaid).
+		Updates(map[string]interface{}{
+			"status":       models.TopUpStatusCredited,
+			"ledger_tx_id": ledgerTxID,
+			"credited_at":  creditedAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *TopUpRepositoryImpl) MarkExpired(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.TopUpIntent{}).
+		Where("id = ? AND status = ?", id, models.TopUpStatusPending).
+		Update("status", models.TopUpStatusExpired)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *TopUpRepositoryImpl) ExpirePending(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.TopUpIntent{}).
+		Where("status = ? AND expires_at <= ?", models.TopUpStatusPending, before).
+		Update("status", models.TopUpStatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+func (r *TopUpRepositoryImpl) ListPaidUncredited(ctx context.Context, limit int) ([]*models.TopUpIntent, error) {
+	var intents []*models.TopUpIntent
+	err := r.db.WithContext(ctx).
+		Where("status = ?", models.TopUpStatusPaid).
+		Order("paid_at ASC").
+		Limit(limit).
+		Find(&intents).Error
+	return intents, err
+}
+
+// Ensure interface compliance
+var _ repositories.TopUpRepository = (*TopUpRepositoryImpl)(nil)
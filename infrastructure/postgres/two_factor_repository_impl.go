@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+)
+
+type TwoFactorRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) repositories.TwoFactorRepository {
+	return &TwoFactorRepositoryImpl{db: db}
+}
+
+// ==================== TOTP ====================
+
+func (r *TwoFactorRepositoryImpl) GetTOTP(ctx context.Context, userID uuid.UUID) (*models.UserTOTP, error) {
+	var totp models.UserTOTP
+	err := r.db.WithContext(ctx).First(&totp, "user_id = ?", userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &totp, nil
+}
+
+func (r *TwoFactorRepositoryImpl) SavePendingTOTP(ctx context.Context, totp *models.UserTOTP) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		// An enabled secret is never replaced here (the insert below conflicts instead)
+		if err := tx.Where("user_id = ? AND enabled_at IS NULL", totp.UserID).Delete(&models.UserTOTP{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("User").Create(totp).Error
+	})
+}
+
+func (r *TwoFactorRepositoryImpl) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.UserTOTP{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Updates(map[string]interface{}{
+			"last_used_step": step,
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *TwoFactorRepositoryImpl) Enable(ctx context.Context, userID uuid.UUID, step int64, codes []*models.RecoveryCode) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.UserTOTP{}).
+			Where("user_id = ? AND enabled_at IS NULL", userID).
+			Updates(map[string]interface{}{
+				"enabled_at":     now,
+				"last_used_step": step,
+				"updated_at":     now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).
+			Update("two_factor_enabled", true).Error; err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, codes)
+	})
+}
+
+func (r *TwoFactorRepositoryImpl) Disable(ctx context.Context, userID uuid.UUID) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserTOTP{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("id = ?", userID).
+			Update("two_factor_enabled", false).Error
+	})
+}
+
+// ==================== Recovery codes ====================
+
+func (r *TwoFactorRepositoryImpl) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []*models.RecoveryCode) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codes)
+	})
+}
+
+func (r *TwoFactorRepositoryImpl) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *TwoFactorRepositoryImpl) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// replaceRecoveryCodes drops every code of the user (used or not) and inserts the new set
+func replaceRecoveryCodes(tx *gorm.DB, userID uuid.UUID, codes []*models.RecoveryCode) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
+}
+
+// Ensure interface compliance
+var _ repositories.TwoFactorRepository = (*TwoFactorRepositoryImpl)(nil)
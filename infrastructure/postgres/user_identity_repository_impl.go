@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) repositories.UserIdentityRepository {
+	return &UserIdentityRepositoryImpl{db: db}
+}
+
+func (r *UserIdentityRepositoryImpl) Create(ctx context.Context, identity *models.UserIdentity) error {
+	return r.db.WithContext(ctx).Omit("User").Create(identity).Error
+}
+
+func (r *UserIdentityRepositoryImpl) GetByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := r.db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *UserIdentityRepositoryImpl) ListByUser(ctx context.Context, userID uuid.UUID) ([]*models.UserIdentity, error) {
+	var identities []*models.UserIdentity
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&identities).Error
+	return identities, err
+}
+
+func (r *UserIdentityRepositoryImpl) Delete(ctx context.Context, userID uuid.UUID, provider string) error {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND provider = ?", userID, provider).
+		Delete(&models.UserIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *UserIdentityRepositoryImpl) TouchLastLogin(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Model(&models.UserIdentity{}).
+		Where("id = ?", id).
+		Update("last_login_at", time.Now()).Error
+}
+
+// Ensure interface compliance
+var _ repositories.UserIdentityRepository = (*UserIdentityRepositoryImpl)(nil)
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"gofiber-template/domain/models"
 	"gofiber-template/domain/repositories"
@@ -47,21 +49,42 @@ func (r *UserRepositoryImpl) GetByUsername(ctx context.Context, username string)
 	return &user, nil
 }
 
-func (r *UserRepositoryImpl) GetByOAuth(ctx context.Context, provider, oauthID string) (*models.User, error) {
-	var user models.User
-	err := r.db.WithContext(ctx).
-		Where("oauth_provider = ? AND oauth_id = ?", provider, oauthID).
-		First(&user).Error
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
-}
-
 func (r *UserRepositoryImpl) Update(ctx context.Context, id uuid.UUID, user *models.User) error {
 	return r.db.WithContext(ctx).Where("id = ?", id).Updates(user).Error
 }
 
+func (r *UserRepositoryImpl) SetActive(ctx context.Context, id uuid.UUID, isActive bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Update("is_active", isActive).Error
+}
+
+func (r *UserRepositoryImpl) SetPrivate(ctx context.Context, id uuid.UUID, isPrivate bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Update("is_private", isPrivate).Error
+}
+
+func (r *UserRepositoryImpl) SetBot(ctx context.Context, id uuid.UUID, isBot bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Update("is_bot", isBot).Error
+}
+
+func (r *UserRepositoryImpl) SetModerationStatus(ctx context.Context, id uuid.UUID, suspendedUntil *time.Time, isBanned, isShadowbanned bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"suspended_until": suspendedUntil,
+			"is_banned":       isBanned,
+			"is_shadowbanned": isShadowbanned,
+		}).Error
+}
+
 func (r *UserRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.User{}).Error
 }
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type UserSanctionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserSanctionRepository(db *gorm.DB) repositories.UserSanctionRepository {
+	return &UserSanctionRepositoryImpl{db: db}
+}
+
+func (r *UserSanctionRepositoryImpl) Create(ctx context.Context, sanction *models.UserSanction) error {
+	return r.db.WithContext(ctx).Omit("User", "IssuedBy").Create(sanction).Error
+}
+
+func (r *UserSanctionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.UserSanction, error) {
+	var sanction models.UserSanction
+	err := r.db.WithContext(ctx).
+		Preload("IssuedBy").
+		First(&sanction, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sanction, nil
+}
+
+func (r *UserSanctionRepositoryImpl) ListByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]*models.UserSanction, error) {
+	var sanctions []*models.UserSanction
+	err := r.db.WithContext(ctx).
+		Preload("IssuedBy").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&sanctions).Error
+	return sanctions, err
+}
+
+func (r *UserSanctionRepositoryImpl) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.UserSanction{}).
+		Where("user_id = ?", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *UserSanctionRepositoryImpl) ListActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*models.UserSanction, error) {
+	var sanctions []*models.UserSanction
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Order("created_at DESC").
+		Find(&sanctions).Error
+	return sanctions, err
+}
+
+func (r *UserSanctionRepositoryImpl) Lift(ctx context.Context, id uuid.UUID, liftedByID uuid.UUID, reason string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.UserSanction{}).
+		Where("id = ? AND lifted_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"lifted_at":    time.Now(),
+			"lifted_by_id": liftedByID,
+			"lift_reason":  reason,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Ensure interface compliance
+var _ repositories.UserSanctionRepository = (*UserSanctionRepositoryImpl)(nil)
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gorm.io/gorm"
+)
+
+type UserTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserTokenRepository(db *gorm.DB) repositories.UserTokenRepository {
+	return &UserTokenRepositoryImpl{db: db}
+}
+
+func (r *UserTokenRepositoryImpl) Create(ctx context.Context, token *models.UserToken) error {
+	return r.db.WithContext(ctx).Omit("User").Create(token).Error
+}
+
+func (r *UserTokenRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.UserToken, error) {
+	var token models.UserToken
+	err := r.db.WithContext(ctx).First(&token, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *UserTokenRepositoryImpl) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *UserTokenRepositoryImpl) InvalidateByUser(ctx context.Context, userID uuid.UUID, purpose string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
+
+func (r *UserTokenRepositoryImpl) IncrementAttempts(ctx context.Context, id uuid.UUID) (int, error) {
+	var attempts int
+	err := r.db.WithContext(ctx).
+		Raw("UPDATE user_tokens SET attempts = attempts + 1 WHERE id = ? RETURNING attempts", id).
+		Scan(&attempts).Error
+	return attempts, err
+}
+
+func (r *UserTokenRepositoryImpl) CountCreatedSince(ctx context.Context, userID uuid.UUID, purpose string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND created_at >= ?", userID, purpose, since).
+		Count(&count).Error
+	return count, err
+}
+
+// Ensure interface compliance
+var _ repositories.UserTokenRepository = (*UserTokenRepositoryImpl)(nil)
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WalletRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWalletRepository(db *gorm.DB) repositories.WalletRepository {
+	return &WalletRepositoryImpl{db: db}
+}
+
+func (r *WalletRepositoryImpl) Transaction(ctx context.Context, fn func(repo repositories.WalletRepository) error) error {
+	return database.WithTransactionContext(ctx, r.db, func(tx *gorm.DB) error {
+		return fn(&WalletRepositoryImpl{db: tx})
+	})
+}
+
+func (r *WalletRepositoryImpl) Create(ctx context.Context, wallet *models.Wallet) error {
+	return r.db.WithContext(ctx).Create(wallet).Error
+}
+
+func (r *WalletRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
+	var wallet models.Wallet
+	err := r.db.WithContext(ctx).First(&wallet, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *WalletRepositoryImpl) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Wallet, error) {
+	var wallet models.Wallet
+	err := r.db.WithContext(ctx).First(&wallet, "user_id = ?", userID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *WalletRepositoryImpl) GetByCode(ctx context.Context, code string) (*models.Wallet, error) {
+	var wallet models.Wallet
+	err := r.db.WithContext(ctx).First(&wallet, "code = ?", code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *WalletRepositoryImpl) GetForUpdate(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
+	var wallet models.Wallet
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&wallet, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *WalletRepositoryImpl) UpdateBalances(ctx context.Context, id uuid.UUID, balance, heldAmount int64) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Wallet{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"balance":     balance,
+			"held_amount": heldAmount,
+			"updated_at":  time.Now(),
+		}).Error
+}
+
+func (r *WalletRepositoryImpl) CreateTransaction(ctx context.Context, tx *models.LedgerTransaction) error {
+	return r.db.WithContext(ctx).Create(tx).Error
+}
+
+func (r *WalletRepositoryImpl) GetTransactionByID(ctx context.Context, id uuid.UUID) (*models.LedgerTransaction, error) {
+	var tx models.LedgerTransaction
+	err := r.db.WithContext(ctx).
+		Preload("Entries").
+		First(&tx, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func (r *WalletRepositoryImpl) GetTransactionByIdempotencyKey(ctx context.Context, key string) (*models.LedgerTransaction, error) {
+	var tx models.LedgerTransaction
+	err := r.db.WithContext(ctx).
+		Preload("Entries").
+		First(&tx, "idempotency_key = ?", key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+func (r *WalletRepositoryImpl) ListEntriesByWallet(ctx context.Context, walletID uuid.UUID, offset, limit int) ([]*models.LedgerEntry, error) {
+	var entries []*models.LedgerEntry
+	err := r.db.WithContext(ctx).
+		Preload("Transaction").
+		Where("wallet_id = ?", walletID).
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *WalletRepositoryImpl) CountEntriesByWallet(ctx context.Context, walletID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.LedgerEntry{}).
+		Where("wallet_id = ?", walletID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *WalletRepositoryImpl) CreateHold(ctx context.Context, hold *models.WalletHold) error {
+	return r.db.WithContext(ctx).Create(hold).Error
+}
+
+func (r *WalletRepositoryImpl) GetHoldByID(ctx context.Context, id uuid.UUID) (*models.WalletHold, error) {
+	var hold models.WalletHold
+	err := r.db.WithContext(ctx).First(&hold, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *WalletRepositoryImpl) GetHoldForUpdate(ctx context.Context, id uuid.UUID) (*models.WalletHold, error) {
+	var hold models.WalletHold
+	err := r.db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&hold, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *WalletRepositoryImpl) GetHoldByIdempotencyKey(ctx context.Context, key string) (*models.WalletHold, error) {
+	var hold models.WalletHold
+	err := r.db.WithContext(ctx).First(&hold, "idempotency_key = ?", key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *WalletRepositoryImpl) UpdateHold(ctx context.Context, hold *models.WalletHold) error {
+	return r.db.WithContext(ctx).Save(hold).Error
+}
+
+func (r *WalletRepositoryImpl) ListExpiredHolds(ctx context.Context, before time.Time, limit int) ([]*models.WalletHold, error) {
+	var holds []*models.WalletHold
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at < ?", models.WalletHoldStatusActive, before).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&holds).Error
+	return holds, err
+}
+
+// Ensure interface compliance
+var _ repositories.WalletRepository = (*WalletRepositoryImpl)(nil)
@@ -0,0 +1,291 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gofiber-template/application/serviceimpl"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/services"
+	"gofiber-template/pkg/config"
+	"gofiber-template/pkg/testutil"
+)
+
+func setupWalletRepoTest(t *testing.T) (*WalletRepositoryImpl, services.WalletService, *UserRepositoryImpl, func()) {
+	// Get test database
+	db, err := config.GetTestDB()
+	require.NoError(t, err, "Failed to connect to test database")
+
+	// Ledger rules live in the wallet service, so drive the repository through it
+	walletRepo := &WalletRepositoryImpl{db: db}
+	walletService := serviceimpl.NewWalletService(walletRepo)
+	userRepo := &UserRepositoryImpl{db: db}
+
+	// Cleanup function
+	cleanup := func() {
+		err := config.CleanTestDB(db)
+		assert.NoError(t, err, "Failed to clean test database")
+	}
+
+	return walletRepo, walletService, userRepo, cleanup
+}
+
+// fundedWallet creates a user wallet topped up with amount satang
+func fundedWallet(t *testing.T, ctx context.Context, walletService services.WalletService, userRepo *UserRepositoryImpl, amount int64) *models.Wallet {
+	user := testutil.CreateTestUser()
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	wallet, err := walletService.GetOrCreateUserWallet(ctx, user.ID)
+	require.NoError(t, err)
+
+	if amount > 0 {
+		clearing, err := walletService.GetSystemWallet(ctx, models.SystemWalletTopUpClearing)
+		require.NoError(t, err)
+
+		_, err = walletService.Transfer(ctx, &dto.WalletTransferRequest{
+			IdempotencyKey: "test-topup:" + uuid.NewString(),
+			Type:           models.LedgerTxTypeTopUp,
+			FromWalletID:   clearing.ID,
+			ToWalletID:     wallet.ID,
+			Amount:         amount,
+		})
+		require.NoError(t, err)
+	}
+
+	return wallet
+}
+
+// sumAllEntries returns the sum of every ledger entry (must always be zero)
+func sumAllEntries(t *testing.T, repo *WalletRepositoryImpl) int64 {
+	var sum int64
+	err := repo.db.Model(&models.LedgerEntry{}).Select("COALESCE(SUM(amount), 0)").Scan(&sum).Error
+	require.NoError(t, err)
+	return sum
+}
+
+func TestWalletRepository_TransferBalancesAndSumsToZero(t *testing.T) {
+	walletRepo, walletService, userRepo, cleanup := setupWalletRepoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	sender := fundedWallet(t, ctx, walletService, userRepo, 10000)
+	receiver := fundedWallet(t, ctx, walletService, userRepo, 0)
+
+	// Act
+	tx, err := walletService.Transfer(ctx, &dto.WalletTransferRequest{
+		IdempotencyKey: "test-transfer:" + uuid.NewString(),
+		FromWalletID:   sender.ID,
+		ToWalletID:     receiver.ID,
+		Amount:         2500,
+	})
+
+	// Assert
+	require.NoError(t, err)
+
+	stored, err := walletRepo.GetTransactionByID(ctx, tx.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Entries, 2)
+
+	var txSum int64
+	for _, entry := range stored.Entries {
+		txSum += entry.Amount
+	}
+	assert.Zero(t, txSum)
+	assert.Zero(t, sumAllEntries(t, walletRepo))
+
+	senderAfter, err := walletRepo.GetByID(ctx, sender.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7500), senderAfter.Balance)
+
+	receiverAfter, err := walletRepo.GetByID(ctx, receiver.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), receiverAfter.Balance)
+
+	// Latest entry carries the running balance
+	entries, err := walletRepo.ListEntriesByWallet(ctx, sender.ID, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(-2500), entries[0].Amount)
+	assert.Equal(t, int64(7500), entries[0].BalanceAfter)
+}
+
+func TestWalletRepository_IdempotentReplay(t *testing.T) {
+	walletRepo, walletService, userRepo, cleanup := setupWalletRepoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	sender := fundedWallet(t, ctx, walletService, userRepo, 10000)
+	receiver := fundedWallet(t, ctx, walletService, userRepo, 0)
+
+	req := &dto.WalletTransferRequest{
+		IdempotencyKey: "test-replay:" + uuid.NewString(),
+		FromWalletID:   sender.ID,
+		ToWalletID:     receiver.ID,
+		Amount:         1000,
+	}
+
+	// Act
+	first, err := walletService.Transfer(ctx, req)
+	require.NoError(t, err)
+	second, err := walletService.Transfer(ctx, req)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, first.ID, second.ID)
+
+	count, err := walletRepo.CountEntriesByWallet(ctx, receiver.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	senderAfter, err := walletRepo.GetByID(ctx, sender.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9000), senderAfter.Balance)
+	assert.Zero(t, sumAllEntries(t, walletRepo))
+}
+
+func TestWalletRepository_InsufficientFunds(t *testing.T) {
+	walletRepo, walletService, userRepo, cleanup := setupWalletRepoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	sender := fundedWallet(t, ctx, walletService, userRepo, 1000)
+	receiver := fundedWallet(t, ctx, walletService, userRepo, 0)
+
+	// Act
+	_, err := walletService.Transfer(ctx, &dto.WalletTransferRequest{
+		IdempotencyKey: "test-overdraft:" + uuid.NewString(),
+		FromWalletID:   sender.ID,
+		ToWalletID:     receiver.ID,
+		Amount:         1001,
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrInsufficientBalance)
+
+	senderAfter, err := walletRepo.GetByID(ctx, sender.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), senderAfter.Balance)
+
+	count, err := walletRepo.CountEntriesByWallet(ctx, receiver.ID)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Held funds are not spendable either
+	_, err = walletService.PlaceHold(ctx, &dto.WalletHoldRequest{
+		IdempotencyKey: "test-hold:" + uuid.NewString(),
+		WalletID:       sender.ID,
+		Amount:         800,
+	})
+	require.NoError(t, err)
+
+	_, err = walletService.Transfer(ctx, &dto.WalletTransferRequest{
+		IdempotencyKey: "test-overdraft:" + uuid.NewString(),
+		FromWalletID:   sender.ID,
+		ToWalletID:     receiver.ID,
+		Amount:         201,
+	})
+	assert.ErrorIs(t, err, services.ErrInsufficientBalance)
+}
+
+func TestWalletRepository_HoldCapture(t *testing.T) {
+	walletRepo, walletService, userRepo, cleanup := setupWalletRepoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	payer := fundedWallet(t, ctx, walletService, userRepo, 5000)
+	payee := fundedWallet(t, ctx, walletService, userRepo, 0)
+
+	hold, err := walletService.PlaceHold(ctx, &dto.WalletHoldRequest{
+		IdempotencyKey: "test-hold:" + uuid.NewString(),
+		WalletID:       payer.ID,
+		Amount:         3000,
+	})
+	require.NoError(t, err)
+
+	held, err := walletRepo.GetByID(ctx, payer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), held.Balance)
+	assert.Equal(t, int64(3000), held.HeldAmount)
+
+	captureReq := &dto.LedgerTransactionRequest{
+		IdempotencyKey: "test-capture:" + uuid.NewString(),
+		Postings:       []dto.LedgerPosting{{WalletID: payee.ID, Amount: 3000}},
+	}
+
+	// Act
+	tx, err := walletService.CaptureHold(ctx, hold.ID, captureReq)
+	require.NoError(t, err)
+	replayed, err := walletService.CaptureHold(ctx, hold.ID, captureReq)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, tx.ID, replayed.ID)
+
+	captured, err := walletRepo.GetHoldByID(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WalletHoldStatusCaptured, captured.Status)
+	require.NotNil(t, captured.CaptureTransactionID)
+	assert.Equal(t, tx.ID, *captured.CaptureTransactionID)
+
+	payerAfter, err := walletRepo.GetByID(ctx, payer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), payerAfter.Balance)
+	assert.Zero(t, payerAfter.HeldAmount)
+
+	payeeAfter, err := walletRepo.GetByID(ctx, payee.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), payeeAfter.Balance)
+	assert.Zero(t, sumAllEntries(t, walletRepo))
+
+	// A captured hold cannot be released
+	assert.ErrorIs(t, walletService.ReleaseHold(ctx, hold.ID), services.ErrHoldNotActive)
+}
+
+func TestWalletRepository_HoldRelease(t *testing.T) {
+	walletRepo, walletService, userRepo, cleanup := setupWalletRepoTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	wallet := fundedWallet(t, ctx, walletService, userRepo, 5000)
+
+	hold, err := walletService.PlaceHold(ctx, &dto.WalletHoldRequest{
+		IdempotencyKey: "test-hold:" + uuid.NewString(),
+		WalletID:       wallet.ID,
+		Amount:         3000,
+	})
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, walletService.ReleaseHold(ctx, hold.ID))
+	require.NoError(t, walletService.ReleaseHold(ctx, hold.ID))
+
+	// Assert
+	released, err := walletRepo.GetHoldByID(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WalletHoldStatusReleased, released.Status)
+	assert.NotNil(t, released.ResolvedAt)
+
+	walletAfter, err := walletRepo.GetByID(ctx, wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), walletAfter.Balance)
+	assert.Zero(t, walletAfter.HeldAmount)
+
+	// Releasing posts nothing to the ledger
+	count, err := walletRepo.CountEntriesByWallet(ctx, wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	// A released hold cannot be captured
+	_, err = walletService.CaptureHold(ctx, hold.ID, &dto.LedgerTransactionRequest{
+		IdempotencyKey: "test-capture:" + uuid.NewString(),
+		Postings:       []dto.LedgerPosting{{WalletID: wallet.ID, Amount: 3000}},
+	})
+	assert.ErrorIs(t, err, services.ErrHoldNotActive)
+}
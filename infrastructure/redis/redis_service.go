@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -173,6 +174,40 @@ func (r *RedisService) IncrementConversationUnread(ctx context.Context, userID u
 	return r.client.Incr(ctx, key).Err()
 }
 
+// DecrementConversationUnread decrements unread count for a specific conversation (prevents negative values)
+func (r *RedisService) DecrementConversationUnread(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID) error {
+	key := fmt.Sprintf("unread:conv:%s:%s", userID.String(), conversationID.String())
+
+	val, err := r.client.Decr(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	if val <= 0 {
+		r.client.Del(ctx, key)
+	}
+
+	return nil
+}
+
+// SetConversationUnread sets unread count for a conversation (from the database after a partial read)
+// and moves the total by the difference
+func (r *RedisService) SetConversationUnread(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID, count int) error {
+	key := fmt.Sprintf("unread:conv:%s:%s", userID.String(), conversationID.String())
+
+	previous, err := r.client.GetSet(ctx, key, count).Int()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	delta := count - previous
+	if delta > 0 {
+		totalKey := fmt.Sprintf("unread:total:%s", userID.String())
+		return r.client.IncrBy(ctx, totalKey, int64(delta)).Err()
+	}
+	return r.DecrementTotalUnread(ctx, userID, -delta)
+}
+
 // ResetConversationUnread resets unread count for a conversation and returns the previous count
 func (r *RedisService) ResetConversationUnread(ctx context.Context, userID uuid.UUID, conversationID uuid.UUID) (int, error) {
 	key := fmt.Sprintf("unread:conv:%s:%s", userID.String(), conversationID.String())
@@ -278,9 +313,12 @@ func (r *RedisService) InvalidateLastMessage(ctx context.Context, conversationID
 
 // ========== Pub/Sub ==========
 
+// userChannelPrefix - Pub/Sub channel of a user is userChannelPrefix + userID
+const userChannelPrefix = "chat:user:"
+
 // PublishToUser publishes a message to a user's channel (for multi-server WebSocket)
 func (r *RedisService) PublishToUser(ctx context.Context, userID uuid.UUID, message interface{}) error {
-	channel := fmt.Sprintf("chat:user:%s", userID.String())
+	channel := userChannelPrefix + userID.String()
 
 	data, err := json.Marshal(message)
 	if err != nil {
@@ -292,10 +330,20 @@ func (r *RedisService) PublishToUser(ctx context.Context, userID uuid.UUID, mess
 
 // SubscribeToUser subscribes to a user's channel
 func (r *RedisService) SubscribeToUser(ctx context.Context, userID uuid.UUID) *redis.PubSub {
-	channel := fmt.Sprintf("chat:user:%s", userID.String())
+	channel := userChannelPrefix + userID.String()
 	return r.client.Subscribe(ctx, channel)
 }
 
+// SubscribeToAllUsers subscribes to every user's channel (each server delivers to the users connected to it)
+func (r *RedisService) SubscribeToAllUsers(ctx context.Context) *redis.PubSub {
+	return r.client.PSubscribe(ctx, userChannelPrefix+"*")
+}
+
+// UserIDFromChannel extracts the user ID from a user's channel name
+func UserIDFromChannel(channel string) (uuid.UUID, error) {
+	return uuid.Parse(strings.TrimPrefix(channel, userChannelPrefix))
+}
+
 // UnsubscribeUser closes a Pub/Sub subscription
 func (r *RedisService) UnsubscribeUser(ctx context.Context, pubsub *redis.PubSub) error {
 	return pubsub.Close()
@@ -333,3 +381,154 @@ func (r *RedisService) GetIdempotencyCache(ctx context.Context, idempotencyKey s
 
 	return data, nil
 }
+
+// ========== Session Denylist ==========
+
+// DenySession rejects the access tokens of a revoked session until they expire on their own
+func (r *RedisService) DenySession(ctx context.Context, sessionID uuid.UUID, ttl time.Duration) error {
+	key := fmt.Sprintf("auth:denied_session:%s", sessionID.String())
+	return r.client.Set(ctx, key, "1", ttl).Err()
+}
+
+// IsSessionDenied checks whether a session was revoked (checked on every authenticated request)
+func (r *RedisService) IsSessionDenied(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	key := fmt.Sprintf("auth:denied_session:%s", sessionID.String())
+	count, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ========== OAuth State ==========
+
+// SaveOAuthState stores the PKCE verifier and nonce of a login started at a provider
+func (r *RedisService) SaveOAuthState(ctx context.Context, state string, data interface{}, ttl time.Duration) error {
+	key := fmt.Sprintf("auth:oauth_state:%s", state)
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal oauth state: %w", err)
+	}
+
+	return r.client.Set(ctx, key, payload, ttl).Err()
+}
+
+// TakeOAuthState reads and deletes a login state (a state can only complete one callback)
+// Returns redis.Nil if not found or expired
+func (r *RedisService) TakeOAuthState(ctx context.Context, state string) ([]byte, error) {
+	key := fmt.Sprintf("auth:oauth_state:%s", state)
+	return r.client.GetDel(ctx, key).Bytes()
+}
+
+// ========== Permission Cache ==========
+
+// SetUserPermissions caches the effective permissions of a user
+func (r *RedisService) SetUserPermissions(ctx context.Context, userID uuid.UUID, data interface{}, ttl time.Duration) error {
+	key := fmt.Sprintf("auth:perms:%s", userID.String())
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal permissions: %w", err)
+	}
+
+	return r.client.Set(ctx, key, payload, ttl).Err()
+}
+
+// GetUserPermissions reads cached permissions
+// Returns redis.Nil if not cached
+func (r *RedisService) GetUserPermissions(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	key := fmt.Sprintf("auth:perms:%s", userID.String())
+	return r.client.Get(ctx, key).Bytes()
+}
+
+// InvalidateUserPermissions drops cached permissions after a role change
+func (r *RedisService) InvalidateUserPermissions(ctx context.Context, userID uuid.UUID) error {
+	key := fmt.Sprintf("auth:perms:%s", userID.String())
+	return r.client.Del(ctx, key).Err()
+}
+
+// ========== Account Status Cache ==========
+
+// SetAccountStatus caches the suspension, ban and shadowban state of a user
+func (r *RedisService) SetAccountStatus(ctx context.Context, userID uuid.UUID, data interface{}, ttl time.Duration) error {
+	key := fmt.Sprintf("auth:account_status:%s", userID.String())
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal account status: %w", err)
+	}
+
+	return r.client.Set(ctx, key, payload, ttl).Err()
+}
+
+// GetAccountStatus reads a cached account status
+// Returns redis.Nil if not cached
+func (r *RedisService) GetAccountStatus(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	key := fmt.Sprintf("auth:account_status:%s", userID.String())
+	return r.client.Get(ctx, key).Bytes()
+}
+
+// InvalidateAccountStatus drops a cached account status after a sanction changes
+func (r *RedisService) InvalidateAccountStatus(ctx context.Context, userID uuid.UUID) error {
+	key := fmt.Sprintf("auth:account_status:%s", userID.String())
+	return r.client.Del(ctx, key).Err()
+}
+
+// ========== API Token Rate Limit ==========
+
+// IncrementAPITokenRequests counts a request of an API token in the current fixed window
+func (r *RedisService) IncrementAPITokenRequests(ctx context.Context, tokenID uuid.UUID, window time.Duration) (int64, error) {
+	slot := time.Now().Unix() / int64(window.Seconds())
+	key := fmt.Sprintf("auth:api_token_rate:%s:%d", tokenID.String(), slot)
+
+	count, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		r.client.Expire(ctx, key, window)
+	}
+	return count, nil
+}
+
+// ========== Ad Tracking ==========
+
+// ClaimAdEvent reserves an ad event (impression or click) of a viewer for the dedup window.
+// When the viewer was already counted it returns false and the value stored by the first event.
+func (r *RedisService) ClaimAdEvent(ctx context.Context, kind string, adID uuid.UUID, viewerKey, value string, window time.Duration) (bool, string, error) {
+	key := fmt.Sprintf("ads:dedup:%s:%s:%s", kind, adID.String(), viewerKey)
+
+	claimed, err := r.client.SetNX(ctx, key, value, window).Result()
+	if err != nil || claimed {
+		return claimed, value, err
+	}
+
+	existing, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		// First event's claim expired in between
+		return false, "", nil
+	}
+	return false, existing, err
+}
+
+// ReleaseAdEvent drops a claimed ad event that could not be recorded
+func (r *RedisService) ReleaseAdEvent(ctx context.Context, kind string, adID uuid.UUID, viewerKey string) error {
+	key := fmt.Sprintf("ads:dedup:%s:%s:%s", kind, adID.String(), viewerKey)
+	return r.client.Del(ctx, key).Err()
+}
+
+// IncrementAdEvents counts the ad tracking events of a viewer in the current fixed window
+func (r *RedisService) IncrementAdEvents(ctx context.Context, viewerKey string, window time.Duration) (int64, error) {
+	slot := time.Now().Unix() / int64(window.Seconds())
+	key := fmt.Sprintf("ads:rate:%s:%d", viewerKey, slot)
+
+	count, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		r.client.Expire(ctx, key, window)
+	}
+	return count, nil
+}
@@ -13,7 +13,9 @@ import (
 	_ "image/png"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"path/filepath"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nfnt/resize"
@@ -132,6 +134,49 @@ func (s *MediaUploadService) encodeImage(w io.Writer, img image.Image, format st
 	}
 }
 
+// CreateBlurredPreview downloads an image and uploads a heavily blurred copy (teaser for locked content)
+func (s *MediaUploadService) CreateBlurredPreview(ctx context.Context, sourceURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download source image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download source image: status %d", resp.StatusCode)
+	}
+
+	// Limit to 20MB to avoid decoding huge files
+	img, _, err := image.Decode(io.LimitReader(resp.Body, 20<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	// Shrink to a few pixels then scale back up - cheap, irreversible blur
+	preview := resize.Thumbnail(300, 300, img, resize.Lanczos3)
+	tiny := resize.Thumbnail(16, 16, preview, resize.Bilinear)
+	blurred := resize.Resize(uint(preview.Bounds().Dx()), uint(preview.Bounds().Dy()), tiny, resize.Bilinear)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, blurred, &jpeg.Options{Quality: 70}); err != nil {
+		return "", fmt.Errorf("failed to encode preview: %w", err)
+	}
+
+	uploadPath := fmt.Sprintf("posts/previews/%s.jpg", uuid.New().String())
+	previewURL, err := s.bunnyStorage.UploadFile(bytes.NewReader(buf.Bytes()), uploadPath, "image/jpeg")
+	if err != nil {
+		return "", fmt.Errorf("failed to upload preview: %w", err)
+	}
+
+	return previewURL, nil
+}
+
 // UploadVideo uploads a video to Bunny Stream (HLS streaming)
 func (s *MediaUploadService) UploadVideo(ctx context.Context, file multipart.File, filename string) (*UploadResult, error) {
 	// Upload to Bunny Stream (pass file directly as it implements io.Reader)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gofiber/websocket/v2"
 	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
 	"gofiber-template/infrastructure/redis"
@@ -16,10 +17,13 @@ import (
 
 // ChatHub manages chat-specific WebSocket connections
 type ChatHub struct {
-	// Client management
-	clients      map[uuid.UUID]*ChatClient // userID -> client
+	// Client management (a user may be connected from several devices)
+	clients      map[uuid.UUID]map[*ChatClient]bool // userID -> connected devices
 	clientsMutex sync.RWMutex
 
+	// instanceID tags what this server publishes to Redis so it can skip its own messages
+	instanceID string
+
 	// Channels
 	register   chan *ChatClient
 	unregister chan *ChatClient
@@ -31,6 +35,7 @@ type ChatHub struct {
 	blockService        services.BlockService
 	redisService        *redis.RedisService
 	pushService         services.PushService
+	giftService         services.GiftService
 
 	// Repositories
 	conversationRepo repositories.ConversationRepository
@@ -57,6 +62,12 @@ type ChatMessage struct {
 	Error   *ChatError             `json:"error,omitempty"`
 }
 
+// pubSubEnvelope wraps messages published to Redis for the user's devices on other servers
+type pubSubEnvelope struct {
+	Origin  string          `json:"origin"`
+	Message json.RawMessage `json:"message"`
+}
+
 // ChatError represents an error message
 type ChatError struct {
 	Code    string `json:"code"`
@@ -76,7 +87,8 @@ func NewChatHub(
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &ChatHub{
-		clients:             make(map[uuid.UUID]*ChatClient),
+		clients:             make(map[uuid.UUID]map[*ChatClient]bool),
+		instanceID:          uuid.New().String(),
 		register:            make(chan *ChatClient, 10),
 		unregister:          make(chan *ChatClient, 10),
 		broadcast:           make(chan *ChatMessage, 256),
@@ -92,6 +104,11 @@ func NewChatHub(
 	}
 }
 
+// SetGiftService injects GiftService (created after the hub to avoid circular dependency)
+func (h *ChatHub) SetGiftService(giftService services.GiftService) {
+	h.giftService = giftService
+}
+
 // Run starts the hub's main loop
 func (h *ChatHub) Run() {
 	log.Println("🚀 ChatHub started")
@@ -127,10 +144,114 @@ func (h *ChatHub) SendToUser(userID uuid.UUID, message *ChatMessage) {
 	h.sendToUser(userID, message)
 }
 
+// SendToUsers sends message to each of the users (exported for group conversation events)
+func (h *ChatHub) SendToUsers(userIDs []uuid.UUID, message *ChatMessage) {
+	for _, userID := range userIDs {
+		h.sendToUser(userID, message)
+	}
+}
+
+// DeliverMessage sends a new message to every other participant of its conversation,
+// with a push notification for the ones who are offline (message requests arrive quietly)
+func (h *ChatHub) DeliverMessage(senderID uuid.UUID, message *dto.MessageResponse) {
+	conversation, err := h.conversationRepo.GetByID(h.ctx, message.ConversationID)
+	if err != nil {
+		log.Printf("Failed to get conversation for message delivery: %v", err)
+		return
+	}
+
+	newMessage := &ChatMessage{
+		Type: "message.new",
+		Payload: map[string]interface{}{
+			"message": message,
+		},
+	}
+
+	requestMessage := &ChatMessage{
+		Type:    "message.request",
+		Payload: newMessage.Payload,
+	}
+
+	for _, participant := range conversation.Participants {
+		if participant.UserID == senderID {
+			continue
+		}
+
+		if conversation.IsRequestFor(participant.UserID) {
+			h.sendToUser(participant.UserID, requestMessage)
+			continue
+		}
+
+		h.sendToUser(participant.UserID, newMessage)
+
+		// If receiver is offline, send push notification
+		if !h.IsUserOnline(participant.UserID) {
+			go h.sendPushNotification(h.ctx, participant.UserID, senderID, conversation.Name, message)
+		}
+	}
+}
+
+// sendToParticipants sends message to the other participants of a conversation.
+// Nothing is sent (and false returned) when the sender is not a participant.
+func (h *ChatHub) sendToParticipants(ctx context.Context, conversationID uuid.UUID, senderID uuid.UUID, message *ChatMessage) bool {
+	participantIDs, err := h.conversationRepo.ListParticipantIDs(ctx, conversationID)
+	if err != nil {
+		log.Printf("Failed to get conversation participants: %v", err)
+		return false
+	}
+
+	isParticipant := false
+	recipients := make([]uuid.UUID, 0, len(participantIDs))
+	for _, participantID := range participantIDs {
+		if participantID == senderID {
+			isParticipant = true
+			continue
+		}
+		recipients = append(recipients, participantID)
+	}
+	if !isParticipant {
+		return false
+	}
+
+	h.SendToUsers(recipients, message)
+	return true
+}
+
+// DisconnectUser closes every chat connection of a suspended or banned user
+// (WebSocketProtected keeps them from reconnecting)
+func (h *ChatHub) DisconnectUser(userID uuid.UUID, reason string) {
+	for _, client := range h.userClients(userID) {
+		// Queued before the close so the client knows why it was disconnected
+		h.sendToClient(client, &ChatMessage{
+			Type: "account.restricted",
+			Payload: map[string]interface{}{
+				"reason": reason,
+			},
+		})
+		h.unregister <- client
+	}
+}
+
+// userClients returns the devices of a user connected to this server
+func (h *ChatHub) userClients(userID uuid.UUID) []*ChatClient {
+	h.clientsMutex.RLock()
+	defer h.clientsMutex.RUnlock()
+
+	clients := make([]*ChatClient, 0, len(h.clients[userID]))
+	for client := range h.clients[userID] {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
 // registerClient is internal handler for client registration
 func (h *ChatHub) registerClient(client *ChatClient) {
 	h.clientsMutex.Lock()
-	h.clients[client.UserID] = client
+	if h.clients[client.UserID] == nil {
+		h.clients[client.UserID] = make(map[*ChatClient]bool)
+	}
+	h.clients[client.UserID][client] = true
+	devices := len(h.clients[client.UserID])
 	h.clientsMutex.Unlock()
 
 	// Set user online in Redis
@@ -138,7 +259,7 @@ func (h *ChatHub) registerClient(client *ChatClient) {
 		log.Printf("Failed to set user online in Redis: %v", err)
 	}
 
-	log.Printf("✅ Chat client registered: UserID=%s, Total clients=%d", client.UserID, len(h.clients))
+	log.Printf("✅ Chat client registered: UserID=%s, Devices=%d, Total users=%d", client.UserID, devices, h.GetOnlineCount())
 
 	// Send connection success message
 	h.sendToClient(client, &ChatMessage{
@@ -159,19 +280,32 @@ func (h *ChatHub) registerClient(client *ChatClient) {
 // unregisterClient handles client disconnection
 func (h *ChatHub) unregisterClient(client *ChatClient) {
 	h.clientsMutex.Lock()
-	if _, ok := h.clients[client.UserID]; ok {
+	devices, ok := h.clients[client.UserID]
+	if !ok || !devices[client] {
+		// Already unregistered
+		h.clientsMutex.Unlock()
+		return
+	}
+	delete(devices, client)
+	close(client.Send)
+	remaining := len(devices)
+	if remaining == 0 {
 		delete(h.clients, client.UserID)
-		close(client.Send)
 	}
 	h.clientsMutex.Unlock()
 
+	log.Printf("❌ Chat client unregistered: UserID=%s, Devices left=%d, Total users=%d", client.UserID, remaining, h.GetOnlineCount())
+
+	// The user stays online while another device is connected
+	if remaining > 0 {
+		return
+	}
+
 	// Set user offline in Redis
 	if err := h.redisService.SetUserOffline(h.ctx, client.UserID); err != nil {
 		log.Printf("Failed to set user offline in Redis: %v", err)
 	}
 
-	log.Printf("❌ Chat client unregistered: UserID=%s, Total clients=%d", client.UserID, len(h.clients))
-
 	// Broadcast offline status to friends
 	go h.broadcastOnlineStatus(client.UserID, false)
 }
@@ -191,25 +325,29 @@ func (h *ChatHub) broadcastMessage(message *ChatMessage) {
 	h.clientsMutex.RLock()
 	defer h.clientsMutex.RUnlock()
 
-	for _, client := range h.clients {
-		h.sendToClient(client, message)
+	for _, devices := range h.clients {
+		for client := range devices {
+			h.sendToClient(client, message)
+		}
 	}
 }
 
-// sendToUser sends message to specific user
+// sendToUser sends message to every device of a user
 func (h *ChatHub) sendToUser(userID uuid.UUID, message *ChatMessage) {
-	h.clientsMutex.RLock()
-	client, exists := h.clients[userID]
-	h.clientsMutex.RUnlock()
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Failed to marshal message: %v", err)
+		return
+	}
 
-	if exists {
-		// User connected to this server - send directly
-		h.sendToClient(client, message)
-	} else {
-		// User might be on another server - publish to Redis
-		if err := h.redisService.PublishToUser(h.ctx, userID, message); err != nil {
-			log.Printf("Failed to publish to Redis: %v", err)
-		}
+	// Devices connected to this server - send directly
+	for _, client := range h.userClients(userID) {
+		h.sendRaw(client, data)
+	}
+
+	// Other devices might be on other servers - publish to Redis
+	if err := h.redisService.PublishToUser(h.ctx, userID, pubSubEnvelope{Origin: h.instanceID, Message: data}); err != nil {
+		log.Printf("Failed to publish to Redis: %v", err)
 	}
 }
 
@@ -221,6 +359,11 @@ func (h *ChatHub) sendToClient(client *ChatClient, message *ChatMessage) {
 		return
 	}
 
+	h.sendRaw(client, data)
+}
+
+// sendRaw queues an already encoded message on the client's send channel
+func (h *ChatHub) sendRaw(client *ChatClient, data []byte) {
 	select {
 	case client.Send <- data:
 		// Message sent successfully
@@ -232,13 +375,41 @@ func (h *ChatHub) sendToClient(client *ChatClient, message *ChatMessage) {
 }
 
 // listenRedisPubSub listens for messages from Redis Pub/Sub
+// (messages published by other servers for users connected to this one)
 func (h *ChatHub) listenRedisPubSub() {
-	// Subscribe to all user channels for this server
-	// This is a simplified version - in production you'd subscribe to specific channels
+	pubsub := h.redisService.SubscribeToAllUsers(h.ctx)
+	defer pubsub.Close()
+
 	log.Println("🔴 Redis Pub/Sub listener started")
 
-	// For now, we'll implement user-specific subscriptions when clients connect
-	// Full implementation will be in Phase 2
+	messages := pubsub.Channel()
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			userID, err := redis.UserIDFromChannel(msg.Channel)
+			if err != nil {
+				continue
+			}
+
+			// Our own messages were already delivered locally
+			var envelope pubSubEnvelope
+			if err := json.Unmarshal([]byte(msg.Payload), &envelope); err != nil || envelope.Origin == h.instanceID {
+				continue
+			}
+
+			// Users connected elsewhere are delivered by their own server
+			for _, client := range h.userClients(userID) {
+				h.sendRaw(client, envelope.Message)
+			}
+
+		case <-h.ctx.Done():
+			return
+		}
+	}
 }
 
 // Stop gracefully shuts down the hub
@@ -247,8 +418,10 @@ func (h *ChatHub) Stop() {
 
 	// Close all client connections
 	h.clientsMutex.Lock()
-	for _, client := range h.clients {
-		close(client.Send)
+	for _, devices := range h.clients {
+		for client := range devices {
+			close(client.Send)
+		}
 	}
 	h.clientsMutex.Unlock()
 
@@ -264,7 +437,7 @@ func (h *ChatHub) IsUserOnline(userID uuid.UUID) bool {
 	return exists
 }
 
-// GetOnlineCount returns total online clients
+// GetOnlineCount returns the number of users connected to this server
 func (h *ChatHub) GetOnlineCount() int {
 	h.clientsMutex.RLock()
 	defer h.clientsMutex.RUnlock()
@@ -299,17 +472,15 @@ func (h *ChatHub) broadcastOnlineStatus(userID uuid.UUID, isOnline bool) {
 		}
 	}
 
-	// 3. Get conversation partners (people who have active conversations with this user)
+	// 3. Get conversation partners (people who have active 1-on-1 conversations with this user)
 	conversations, err := h.conversationRepo.ListByUser(ctx, userID, nil, 1000)
 	if err != nil {
 		log.Printf("Failed to get conversations for online status broadcast: %v", err)
 	} else {
 		for _, conv := range conversations {
-			// Add the other participant in the conversation
-			if conv.User1ID == userID {
-				recipientMap[conv.User2ID] = true
-			} else {
-				recipientMap[conv.User1ID] = true
+			// Add the other participant in the conversation (groups are skipped)
+			if otherUserID := conv.OtherUserID(userID); otherUserID != uuid.Nil {
+				recipientMap[otherUserID] = true
 			}
 		}
 	}
@@ -377,10 +548,8 @@ func (h *ChatHub) sendInitialOnlineStatus(client *ChatClient) {
 		log.Printf("Failed to get conversations for initial status: %v", err)
 	} else {
 		for _, conv := range conversations {
-			if conv.User1ID == client.UserID {
-				relevantUserMap[conv.User2ID] = true
-			} else {
-				relevantUserMap[conv.User1ID] = true
+			if otherUserID := conv.OtherUserID(client.UserID); otherUserID != uuid.Nil {
+				relevantUserMap[otherUserID] = true
 			}
 		}
 	}
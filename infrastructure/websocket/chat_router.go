@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
+	"gofiber-template/pkg/utils"
 )
 
 // routeMessage routes incoming messages to appropriate handlers
@@ -22,6 +23,29 @@ func (h *ChatHub) routeMessage(client *ChatClient, message *ChatMessage) {
 	case "message.read":
 		h.handleMessageRead(ctx, client, message)
 
+	case "message.delivered":
+		h.handleMessageDelivered(ctx, client, message)
+
+	case "message.edit":
+		h.handleMessageEdit(ctx, client, message)
+
+	case "message.unsend":
+		h.handleMessageUnsend(ctx, client, message)
+
+	// Reactions
+	case "reaction.add":
+		h.handleReaction(ctx, client, message, true)
+
+	case "reaction.remove":
+		h.handleReaction(ctx, client, message, false)
+
+	// Gift events
+	case "gift.send":
+		h.handleGiftSend(ctx, client, message)
+
+	case "gift.open":
+		h.handleGiftOpen(ctx, client, message)
+
 	// Typing indicators
 	case "typing.start":
 		h.handleTypingStart(ctx, client, message)
@@ -29,6 +53,10 @@ func (h *ChatHub) routeMessage(client *ChatClient, message *ChatMessage) {
 	case "typing.stop":
 		h.handleTypingStop(ctx, client, message)
 
+	// Catch up after reconnecting
+	case "sync":
+		h.handleSync(ctx, client, message)
+
 	// Online status (ping)
 	case "ping":
 		h.handlePing(ctx, client, message)
@@ -105,6 +133,17 @@ func (h *ChatHub) handleMessageSend(ctx context.Context, client *ChatClient, mes
 		}
 	}
 
+	// Parse replyToMessageId (optional quoted reply)
+	var replyToID *uuid.UUID
+	if replyToStr, ok := message.Payload["replyToMessageId"].(string); ok && replyToStr != "" {
+		id, err := uuid.Parse(replyToStr)
+		if err != nil {
+			client.sendError("validation_error", "Invalid replyToMessageId")
+			return
+		}
+		replyToID = &id
+	}
+
 	// Parse tempId (for client-side optimistic updates)
 	var tempID *string
 	if tempIDStr, ok := message.Payload["tempId"].(string); ok {
@@ -123,6 +162,7 @@ func (h *ChatHub) handleMessageSend(ctx context.Context, client *ChatClient, mes
 		Type:           messageType,
 		Content:        content,
 		Media:          media,
+		ReplyToID:      replyToID,
 		TempID:         tempID,
 	}
 
@@ -143,118 +183,479 @@ func (h *ChatHub) handleMessageSend(ctx context.Context, client *ChatClient, mes
 		},
 	})
 
-	// Send new message notification to receiver (message.new)
-	receiverID := msgResponse.Receiver.ID
-	h.sendToUser(receiverID, &ChatMessage{
-		Type: "message.new",
+	// Send new message notification to the other participants (message.new)
+	h.DeliverMessage(client.UserID, msgResponse)
+}
+
+// handleMessageRead handles mark as read request (up to "seq" when given, otherwise everything)
+func (h *ChatHub) handleMessageRead(ctx context.Context, client *ChatClient, message *ChatMessage) {
+	conversationID, seq, ok := parseReceipt(client, message)
+	if !ok {
+		return
+	}
+
+	state, err := h.conversationService.MarkAsRead(ctx, conversationID, client.UserID, seq)
+	if err != nil {
+		log.Printf("Failed to mark as read: %v", err)
+		client.sendError("mark_read_failed", err.Error())
+		return
+	}
+
+	// Send acknowledgment to reader (message.read_ack)
+	h.sendToClient(client, &ChatMessage{
+		Type: "message.read_ack",
 		Payload: map[string]interface{}{
-			"message": msgResponse,
+			"conversationId": conversationID.String(),
+			"lastReadSeq":    state.LastReadSeq,
+			"readAt":         time.Now().Format(time.RFC3339),
 		},
 	})
 
-	// If receiver is offline, send push notification
-	if !h.IsUserOnline(receiverID) {
-		go h.sendPushNotification(ctx, receiverID, client.UserID, msgResponse)
+	// Send read update to the other participants (message.read_update) and the reader's devices
+	h.SendReadUpdate(ctx, client.UserID, state)
+}
+
+// handleMessageDelivered handles a device confirming it received messages up to "seq"
+func (h *ChatHub) handleMessageDelivered(ctx context.Context, client *ChatClient, message *ChatMessage) {
+	conversationID, seq, ok := parseReceipt(client, message)
+	if !ok {
+		return
+	}
+
+	state, err := h.conversationService.MarkAsDelivered(ctx, conversationID, client.UserID, seq)
+	if err != nil {
+		log.Printf("Failed to mark as delivered: %v", err)
+		client.sendError("mark_delivered_failed", err.Error())
+		return
 	}
+
+	h.SendDeliveryUpdate(ctx, client.UserID, state)
 }
 
-// handleMessageRead handles mark as read request
-func (h *ChatHub) handleMessageRead(ctx context.Context, client *ChatClient, message *ChatMessage) {
-	// Parse conversationId
+// parseReceipt reads the conversationId and optional seq of a receipt
+func parseReceipt(client *ChatClient, message *ChatMessage) (uuid.UUID, int64, bool) {
 	conversationIDStr, ok := message.Payload["conversationId"].(string)
 	if !ok {
 		client.sendError("validation_error", "conversationId is required")
-		return
+		return uuid.Nil, 0, false
 	}
 
 	conversationID, err := uuid.Parse(conversationIDStr)
 	if err != nil {
 		client.sendError("validation_error", "Invalid conversationId")
-		return
+		return uuid.Nil, 0, false
 	}
 
-	// Mark all messages as read
-	if err := h.conversationService.MarkAsRead(ctx, conversationID, client.UserID); err != nil {
-		log.Printf("Failed to mark as read: %v", err)
-		client.sendError("mark_read_failed", err.Error())
+	// JSON numbers arrive as float64
+	var seq int64
+	if seqValue, ok := message.Payload["seq"].(float64); ok && seqValue > 0 {
+		seq = int64(seqValue)
+	}
+
+	return conversationID, seq, true
+}
+
+// SendReadUpdate tells the other participants how far the reader has read, and the reader's own
+// devices their new unread counts (exported for the REST handler)
+func (h *ChatHub) SendReadUpdate(ctx context.Context, readerID uuid.UUID, state *dto.ReadStateResponse) {
+	payload := map[string]interface{}{
+		"conversationId": state.ConversationID.String(),
+		"readBy":         readerID.String(),
+		"readAt":         time.Now().Format(time.RFC3339),
+		"lastReadSeq":    state.LastReadSeq,
+	}
+
+	// Read pointer, so group members can show who has seen which message
+	if state.LastReadMessageID != nil {
+		payload["lastReadMessageId"] = state.LastReadMessageID.String()
+	}
+
+	h.sendToParticipants(ctx, state.ConversationID, readerID, &ChatMessage{
+		Type:    "message.read_update",
+		Payload: payload,
+	})
+
+	h.sendUnreadUpdate(readerID, state)
+}
+
+// SendDeliveryUpdate tells the other participants which messages reached the user's devices
+// (exported for the REST handler)
+func (h *ChatHub) SendDeliveryUpdate(ctx context.Context, userID uuid.UUID, state *dto.ReadStateResponse) {
+	h.sendToParticipants(ctx, state.ConversationID, userID, &ChatMessage{
+		Type: "message.delivered_update",
+		Payload: map[string]interface{}{
+			"conversationId":   state.ConversationID.String(),
+			"deliveredTo":      userID.String(),
+			"lastDeliveredSeq": state.LastDeliveredSeq,
+		},
+	})
+}
+
+// sendUnreadUpdate keeps the unread counts the same on every device of the user
+func (h *ChatHub) sendUnreadUpdate(userID uuid.UUID, state *dto.ReadStateResponse) {
+	h.sendToUser(userID, &ChatMessage{
+		Type: "unread.updated",
+		Payload: map[string]interface{}{
+			"conversationId":   state.ConversationID.String(),
+			"unreadCount":      state.UnreadCount,
+			"totalUnread":      state.TotalUnread,
+			"lastReadSeq":      state.LastReadSeq,
+			"lastDeliveredSeq": state.LastDeliveredSeq,
+		},
+	})
+}
+
+// ==================== Sync ====================
+
+// maxSyncConversations caps the conversations a single sync request may ask for
+const maxSyncConversations = 200
+
+// handleSync replays what a reconnecting client missed. The payload maps conversation IDs to
+// the last sequence the client saw: {"conversations": {"<conversationId>": 42}}
+func (h *ChatHub) handleSync(ctx context.Context, client *ChatClient, message *ChatMessage) {
+	cursors, ok := message.Payload["conversations"].(map[string]interface{})
+	if !ok {
+		client.sendError("validation_error", "conversations is required")
+		return
+	}
+	if len(cursors) > maxSyncConversations {
+		client.sendError("validation_error", fmt.Sprintf("At most %d conversations can be synced at once", maxSyncConversations))
 		return
 	}
 
-	// Send acknowledgment to reader (message.read_ack)
+	synced := 0
+	for conversationIDStr, seqValue := range cursors {
+		conversationID, err := uuid.Parse(conversationIDStr)
+		if err != nil {
+			client.sendError("validation_error", "Invalid conversationId: "+conversationIDStr)
+			continue
+		}
+		afterSeq, _ := seqValue.(float64)
+
+		result, err := h.conversationService.SyncConversation(ctx, conversationID, client.UserID, int64(afterSeq))
+		if err != nil {
+			log.Printf("Failed to sync conversation %s: %v", conversationID, err)
+			client.sendError("sync_failed", conversationIDStr+": "+err.Error())
+			continue
+		}
+
+		// Only the conversations that changed are sent
+		if result.Reset || len(result.Events) > 0 {
+			h.sendToClient(client, &ChatMessage{
+				Type: "sync.conversation",
+				Payload: map[string]interface{}{
+					"conversationId": result.ConversationID.String(),
+					"events":         result.Events,
+					"lastSeq":        result.LastSeq,
+					"reset":          result.Reset,
+					"unreadCount":    result.UnreadCount,
+				},
+			})
+		}
+		synced++
+	}
+
+	payload := map[string]interface{}{
+		"conversations": synced,
+		"syncedAt":      time.Now().Format(time.RFC3339),
+	}
+	if unread, err := h.conversationService.GetUnreadCount(ctx, client.UserID); err == nil {
+		payload["totalUnread"] = unread.TotalUnread
+	}
+
 	h.sendToClient(client, &ChatMessage{
-		Type: "message.read_ack",
+		Type:    "sync.complete",
+		Payload: payload,
+	})
+}
+
+// handleMessageEdit handles the sender editing the text of a message
+func (h *ChatHub) handleMessageEdit(ctx context.Context, client *ChatClient, message *ChatMessage) {
+	// Parse messageId
+	messageIDStr, ok := message.Payload["messageId"].(string)
+	if !ok {
+		client.sendError("validation_error", "messageId is required")
+		return
+	}
+
+	messageID, err := uuid.Parse(messageIDStr)
+	if err != nil {
+		client.sendError("validation_error", "Invalid messageId")
+		return
+	}
+
+	// Parse content
+	content, ok := message.Payload["content"].(string)
+	if !ok || content == "" {
+		client.sendError("validation_error", "content is required")
+		return
+	}
+
+	req := &dto.EditMessageRequest{Content: content}
+	if err := utils.ValidateStruct(req); err != nil {
+		client.sendError("validation_error", "content must be at most 5000 characters")
+		return
+	}
+
+	msgResponse, err := h.messageService.EditMessage(ctx, messageID, client.UserID, req)
+	if err != nil {
+		log.Printf("Failed to edit message: %v", err)
+		client.sendError("edit_failed", err.Error())
+		return
+	}
+
+	// The sender gets the same event as acknowledgment
+	h.SendMessageEdited(ctx, client.UserID, msgResponse)
+}
+
+// handleMessageUnsend handles the sender unsending a message
+func (h *ChatHub) handleMessageUnsend(ctx context.Context, client *ChatClient, message *ChatMessage) {
+	// Parse messageId
+	messageIDStr, ok := message.Payload["messageId"].(string)
+	if !ok {
+		client.sendError("validation_error", "messageId is required")
+		return
+	}
+
+	messageID, err := uuid.Parse(messageIDStr)
+	if err != nil {
+		client.sendError("validation_error", "Invalid messageId")
+		return
+	}
+
+	result, err := h.messageService.UnsendMessage(ctx, messageID, client.UserID)
+	if err != nil {
+		log.Printf("Failed to unsend message: %v", err)
+		client.sendError("unsend_failed", err.Error())
+		return
+	}
+
+	// The sender gets the same event as acknowledgment
+	h.SendMessageUnsent(ctx, client.UserID, result)
+}
+
+// SendMessageEdited sends the edited message to every participant, the sender included
+// so their other sessions stay in sync (exported for the REST handler)
+func (h *ChatHub) SendMessageEdited(ctx context.Context, senderID uuid.UUID, message *dto.MessageResponse) {
+	edited := &ChatMessage{
+		Type: "message.edited",
 		Payload: map[string]interface{}{
-			"conversationId": conversationID.String(),
-			"readAt":         time.Now().Format(time.RFC3339),
+			"message": message,
 		},
-	})
+	}
+	h.sendToUser(senderID, edited)
+	h.sendToParticipants(ctx, message.ConversationID, senderID, edited)
+}
+
+// SendMessageUnsent tells every participant, the sender included, that a message was unsent
+// along with the conversation's new last message when it changed (exported for the REST handler)
+func (h *ChatHub) SendMessageUnsent(ctx context.Context, senderID uuid.UUID, result *dto.UnsendMessageResponse) {
+	payload := map[string]interface{}{
+		"messageId":      result.MessageID.String(),
+		"conversationId": result.ConversationID.String(),
+	}
+	if result.LastMessage != nil {
+		payload["lastMessage"] = result.LastMessage
+	}
+
+	unsent := &ChatMessage{
+		Type:    "message.unsent",
+		Payload: payload,
+	}
+	h.sendToUser(senderID, unsent)
+	h.sendToParticipants(ctx, result.ConversationID, senderID, unsent)
+}
+
+// ==================== Reactions ====================
+
+// handleReaction handles adding or removing an emoji reaction
+func (h *ChatHub) handleReaction(ctx context.Context, client *ChatClient, message *ChatMessage, add bool) {
+	// Parse messageId
+	messageIDStr, ok := message.Payload["messageId"].(string)
+	if !ok {
+		client.sendError("validation_error", "messageId is required")
+		return
+	}
 
-	// Get conversation to find other participant
-	conversation, err := h.conversationRepo.GetByID(ctx, conversationID)
+	messageID, err := uuid.Parse(messageIDStr)
 	if err != nil {
-		log.Printf("Failed to get conversation: %v", err)
+		client.sendError("validation_error", "Invalid messageId")
+		return
+	}
+
+	// Parse emoji
+	emoji, ok := message.Payload["emoji"].(string)
+	if !ok || emoji == "" {
+		client.sendError("validation_error", "emoji is required")
 		return
 	}
 
-	// Determine other participant
-	var otherUserID uuid.UUID
-	if conversation.User1ID == client.UserID {
-		otherUserID = conversation.User2ID
+	var result *dto.MessageReactionsResponse
+	if add {
+		result, err = h.messageService.AddReaction(ctx, messageID, client.UserID, emoji)
 	} else {
-		otherUserID = conversation.User1ID
+		result, err = h.messageService.RemoveReaction(ctx, messageID, client.UserID, emoji)
+	}
+	if err != nil {
+		log.Printf("Failed to update reaction: %v", err)
+		client.sendError("reaction_failed", err.Error())
+		return
 	}
 
-	// Send read update to sender (message.read_update)
-	h.sendToUser(otherUserID, &ChatMessage{
-		Type: "message.read_update",
+	// The reacting user gets the same event as acknowledgment
+	h.SendReactionUpdate(ctx, client.UserID, result)
+}
+
+// SendReactionUpdate sends the reactions of a message to every participant, the reacting user
+// included (exported for the REST handler)
+func (h *ChatHub) SendReactionUpdate(ctx context.Context, userID uuid.UUID, result *dto.MessageReactionsResponse) {
+	updated := &ChatMessage{
+		Type: "reaction.updated",
 		Payload: map[string]interface{}{
-			"conversationId": conversationID.String(),
-			"readBy":         client.UserID.String(),
-			"readAt":         time.Now().Format(time.RFC3339),
+			"messageId":      result.MessageID.String(),
+			"conversationId": result.ConversationID.String(),
+			"reactions":      result.Reactions,
+			"userId":         userID.String(),
 		},
-	})
+	}
+	h.sendToUser(userID, updated)
+	h.sendToParticipants(ctx, result.ConversationID, userID, updated)
 }
 
-// ==================== Typing Indicators ====================
+// ==================== Gift Events ====================
 
-// handleTypingStart broadcasts typing indicator
-func (h *ChatHub) handleTypingStart(ctx context.Context, client *ChatClient, message *ChatMessage) {
+// handleGiftSend handles a paid gift box sent by the client
+func (h *ChatHub) handleGiftSend(ctx context.Context, client *ChatClient, message *ChatMessage) {
+	if h.giftService == nil {
+		client.sendError("unavailable", "Gifts are not available")
+		return
+	}
+
+	// Parse conversationId
 	conversationIDStr, ok := message.Payload["conversationId"].(string)
 	if !ok {
+		client.sendError("validation_error", "conversationId is required")
 		return
 	}
 
 	conversationID, err := uuid.Parse(conversationIDStr)
 	if err != nil {
+		client.sendError("validation_error", "Invalid conversationId")
 		return
 	}
 
-	// Get conversation to find other participant
-	conversation, err := h.conversationRepo.GetByID(ctx, conversationID)
+	// Parse packageId
+	packageIDStr, ok := message.Payload["packageId"].(string)
+	if !ok {
+		client.sendError("validation_error", "packageId is required")
+		return
+	}
+
+	packageID, err := uuid.Parse(packageIDStr)
 	if err != nil {
+		client.sendError("validation_error", "Invalid packageId")
 		return
 	}
 
-	// Determine other participant
-	var otherUserID uuid.UUID
-	if conversation.User1ID == client.UserID {
-		otherUserID = conversation.User2ID
-	} else {
-		otherUserID = conversation.User1ID
+	// Parse content (optional)
+	var content *string
+	if contentStr, ok := message.Payload["content"].(string); ok && contentStr != "" {
+		content = &contentStr
 	}
 
-	// Broadcast typing indicator to other user
-	h.sendToUser(otherUserID, &ChatMessage{
-		Type: "typing.start",
+	// Parse mediaIds (optional, uploaded beforehand)
+	var mediaIDs []uuid.UUID
+	if mediaData, ok := message.Payload["mediaIds"].([]interface{}); ok {
+		for _, m := range mediaData {
+			idStr, ok := m.(string)
+			if !ok {
+				continue
+			}
+			mediaID, err := uuid.Parse(idStr)
+			if err != nil {
+				client.sendError("validation_error", "Invalid mediaIds")
+				return
+			}
+			mediaIDs = append(mediaIDs, mediaID)
+		}
+	}
+
+	// Parse tempId (for client-side optimistic updates)
+	var tempID *string
+	if tempIDStr, ok := message.Payload["tempId"].(string); ok {
+		tempID = &tempIDStr
+	}
+
+	req := &dto.SendGiftRequest{
+		ConversationID: conversationID,
+		PackageID:      packageID,
+		Content:        content,
+		MediaIDs:       mediaIDs,
+		TempID:         tempID,
+	}
+
+	msgResponse, err := h.giftService.SendGift(ctx, client.UserID, req)
+	if err != nil {
+		log.Printf("Failed to send gift: %v", err)
+		client.sendError("send_failed", err.Error())
+		return
+	}
+
+	// Send acknowledgment to sender (gift.sent)
+	h.sendToClient(client, &ChatMessage{
+		Type: "gift.sent",
 		Payload: map[string]interface{}{
-			"conversationId": conversationID.String(),
-			"userId":         client.UserID.String(),
+			"message": msgResponse,
+			"tempId":  tempID, // Echo back tempId for client matching
 		},
 	})
+
+	// Gift boxes arrive like any other message (content stays sealed until opened)
+	h.DeliverMessage(client.UserID, msgResponse)
 }
 
-// handleTypingStop broadcasts stop typing indicator
-func (h *ChatHub) handleTypingStop(ctx context.Context, client *ChatClient, message *ChatMessage) {
+// handleGiftOpen handles the receiver opening a gift box
+func (h *ChatHub) handleGiftOpen(ctx context.Context, client *ChatClient, message *ChatMessage) {
+	if h.giftService == nil {
+		client.sendError("unavailable", "Gifts are not available")
+		return
+	}
+
+	// Parse messageId
+	messageIDStr, ok := message.Payload["messageId"].(string)
+	if !ok {
+		client.sendError("validation_error", "messageId is required")
+		return
+	}
+
+	messageID, err := uuid.Parse(messageIDStr)
+	if err != nil {
+		client.sendError("validation_error", "Invalid messageId")
+		return
+	}
+
+	msgResponse, err := h.giftService.OpenGift(ctx, messageID, client.UserID)
+	if err != nil {
+		log.Printf("Failed to open gift: %v", err)
+		client.sendError("open_failed", err.Error())
+		return
+	}
+
+	// Notify both sides so the revealed content replaces the sealed box
+	opened := &ChatMessage{
+		Type: "gift.opened",
+		Payload: map[string]interface{}{
+			"message": msgResponse,
+		},
+	}
+	h.sendToClient(client, opened)
+	h.sendToUser(msgResponse.Sender.ID, opened)
+}
+
+// ==================== Typing Indicators ====================
+
+// handleTypingStart broadcasts typing indicator
+func (h *ChatHub) handleTypingStart(ctx context.Context, client *ChatClient, message *ChatMessage) {
 	conversationIDStr, ok := message.Payload["conversationId"].(string)
 	if !ok {
 		return
@@ -265,22 +666,30 @@ func (h *ChatHub) handleTypingStop(ctx context.Context, client *ChatClient, mess
 		return
 	}
 
-	// Get conversation to find other participant
-	conversation, err := h.conversationRepo.GetByID(ctx, conversationID)
-	if err != nil {
+	// Broadcast typing indicator to the other participants
+	h.sendToParticipants(ctx, conversationID, client.UserID, &ChatMessage{
+		Type: "typing.start",
+		Payload: map[string]interface{}{
+			"conversationId": conversationID.String(),
+			"userId":         client.UserID.String(),
+		},
+	})
+}
+
+// handleTypingStop broadcasts stop typing indicator
+func (h *ChatHub) handleTypingStop(ctx context.Context, client *ChatClient, message *ChatMessage) {
+	conversationIDStr, ok := message.Payload["conversationId"].(string)
+	if !ok {
 		return
 	}
 
-	// Determine other participant
-	var otherUserID uuid.UUID
-	if conversation.User1ID == client.UserID {
-		otherUserID = conversation.User2ID
-	} else {
-		otherUserID = conversation.User1ID
+	conversationID, err := uuid.Parse(conversationIDStr)
+	if err != nil {
+		return
 	}
 
-	// Broadcast stop typing to other user
-	h.sendToUser(otherUserID, &ChatMessage{
+	// Broadcast stop typing to the other participants
+	h.sendToParticipants(ctx, conversationID, client.UserID, &ChatMessage{
 		Type: "typing.stop",
 		Payload: map[string]interface{}{
 			"conversationId": conversationID.String(),
@@ -360,12 +769,16 @@ func (h *ChatHub) handleBlockRemove(ctx context.Context, client *ChatClient, mes
 // ==================== Push Notifications ====================
 
 // sendPushNotification sends push notification to offline user
-func (h *ChatHub) sendPushNotification(ctx context.Context, receiverID uuid.UUID, senderID uuid.UUID, message *dto.MessageResponse) {
+// (groupName is set for group conversations)
+func (h *ChatHub) sendPushNotification(ctx context.Context, receiverID uuid.UUID, senderID uuid.UUID, groupName *string, message *dto.MessageResponse) {
 	// Get sender info for notification
 	sender := message.Sender
 
 	// Prepare notification title and body
 	title := fmt.Sprintf("New message from %s", sender.Username)
+	if groupName != nil {
+		title = fmt.Sprintf("%s in %s", sender.Username, *groupName)
+	}
 	body := ""
 
 	// Format body based on message type
@@ -386,6 +799,8 @@ func (h *ChatHub) sendPushNotification(ctx context.Context, receiverID uuid.UUID
 		body = "🎥 Sent a video"
 	case "file":
 		body = "📎 Sent a file"
+	case "gift":
+		body = "🎁 Sent you a gift"
 	default:
 		body = "Sent a message"
 	}
@@ -23,6 +23,7 @@ type VideoEncoderWorker struct {
 	mediaRepo       repositories.MediaRepository
 	notifService    services.NotificationService
 	postService     services.PostService
+	reelService     services.ReelService
 	notificationHub *websocket.NotificationHub
 	running         bool
 	stopChan        chan struct{}
@@ -34,6 +35,7 @@ func NewVideoEncoderWorker(
 	mediaRepo repositories.MediaRepository,
 	notifService services.NotificationService,
 	postService services.PostService,
+	reelService services.ReelService,
 	notificationHub *websocket.NotificationHub,
 ) *VideoEncoderWorker {
 	return &VideoEncoderWorker{
@@ -42,6 +44,7 @@ func NewVideoEncoderWorker(
 		mediaRepo:       mediaRepo,
 		notifService:    notifService,
 		postService:     postService,
+		reelService:     reelService,
 		notificationHub: notificationHub,
 		stopChan:        make(chan struct{}),
 	}
@@ -173,10 +176,24 @@ func (w *VideoEncoderWorker) processNextJob() {
 }
 
 // updateMediaStatus updates the media record in the database
-// NOTE: DEPRECATED - No longer used as we migrated from Bunny Stream to R2
+// NOTE: Only videos uploaded through MediaService.CreateVideo (reels) are encoded by Bunny Stream
 func (w *VideoEncoderWorker) updateMediaStatus(ctx context.Context, mediaID uuid.UUID, status string, progress int, hlsURL string) {
-	log.Printf("⚠️ updateMediaStatus is deprecated - Bunny Stream encoding is no longer used")
-	// No-op - this worker is deprecated
+	media, err := w.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		log.Printf("❌ Failed to get media %s: %v", mediaID, err)
+		return
+	}
+
+	media.EncodingStatus = status
+	media.EncodingProgress = progress
+	if hlsURL != "" {
+		media.HLSURL = hlsURL
+		media.URL = hlsURL
+	}
+
+	if err := w.mediaRepo.Update(ctx, media); err != nil {
+		log.Printf("❌ Failed to update media %s: %v", mediaID, err)
+	}
 }
 
 // sendEncodingNotification sends a notification to the user when encoding completes
@@ -218,6 +235,16 @@ func (w *VideoEncoderWorker) sendEncodingNotification(ctx context.Context, job *
 		log.Printf("📢 Video encoding notification sent: user=%s, status=%s, progress=%d%%", media.UserID, status, progress)
 	}
 
+	// Reels go live (or fail) instead of publishing draft posts
+	if media.SourceType != nil && *media.SourceType == "reel" {
+		if w.reelService != nil {
+			if err := w.reelService.HandleVideoEncoded(ctx, job.MediaID, status); err != nil {
+				log.Printf("❌ Failed to update reel for media %s: %v", job.MediaID, err)
+			}
+		}
+		return
+	}
+
 	// Auto-publish draft posts when video encoding completes
 	if status == "completed" && w.postService != nil {
 		err := w.postService.PublishDraftPostsWithMedia(ctx, job.MediaID)
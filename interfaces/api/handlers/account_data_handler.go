@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type AccountDataHandler struct {
+	accountDataService services.AccountDataService
+}
+
+func NewAccountDataHandler(accountDataService services.AccountDataService) *AccountDataHandler {
+	return &AccountDataHandler{
+		accountDataService: accountDataService,
+	}
+}
+
+// accountDataErrorResponse maps account data service errors to HTTP responses
+func accountDataErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrDataExportInProgress),
+		errors.Is(err, services.ErrDataExportTooSoon),
+		errors.Is(err, services.ErrDeletionAlreadyPending),
+		errors.Is(err, services.ErrDeletionNotPending),
+		errors.Is(err, services.ErrDeletionWalletNotEmpty),
+		errors.Is(err, services.ErrDeletionWalletHeld):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrDeletionPasswordInvalid):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrDataExportUnavailable):
+		return utils.ErrorResponse(c, apperrors.ErrStorageError.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// RequestExport queues a ZIP of the user's data (a notification is sent when it is ready)
+// POST /users/profile/exports
+func (h *AccountDataHandler) RequestExport(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	export, err := h.accountDataService.RequestExport(c.Context(), userID)
+	if err != nil {
+		return accountDataErrorResponse(c, err, "Failed to request data export")
+	}
+
+	return utils.SuccessResponse(c, export, "Data export requested, you will be notified when it is ready")
+}
+
+// ListExports lists recent exports with download links for the ready ones
+// GET /users/profile/exports
+func (h *AccountDataHandler) ListExports(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	exports, err := h.accountDataService.ListExports(c.Context(), userID)
+	if err != nil {
+		return accountDataErrorResponse(c, err, "Failed to retrieve data exports")
+	}
+
+	return utils.SuccessResponse(c, exports, "Data exports retrieved successfully")
+}
+
+// RequestDeletion schedules the account for deletion after the cooling-off period
+// DELETE /users/profile
+func (h *AccountDataHandler) RequestDeletion(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Accounts without a password may send no body at all
+	var req dto.RequestAccountDeletionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ValidationErrorResponse(c, "Invalid request body")
+		}
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	deletion, err := h.accountDataService.RequestDeletion(c.Context(), userID, &req)
+	if err != nil {
+		return accountDataErrorResponse(c, err, "Failed to request account deletion")
+	}
+
+	return utils.SuccessResponse(c, deletion, "Account deletion scheduled")
+}
+
+// GetDeletionStatus reports whether the account is scheduled for deletion
+// GET /users/profile/deletion
+func (h *AccountDataHandler) GetDeletionStatus(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	deletion, err := h.accountDataService.GetDeletionStatus(c.Context(), userID)
+	if err != nil {
+		return accountDataErrorResponse(c, err, "Failed to retrieve account deletion status")
+	}
+
+	return utils.SuccessResponse(c, deletion, "Account deletion status retrieved successfully")
+}
+
+// CancelDeletion keeps the account (only before the cooling-off period ends)
+// DELETE /users/profile/deletion
+func (h *AccountDataHandler) CancelDeletion(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	deletion, err := h.accountDataService.CancelDeletion(c.Context(), userID)
+	if err != nil {
+		return accountDataErrorResponse(c, err, "Failed to cancel account deletion")
+	}
+
+	return utils.SuccessResponse(c, deletion, "Account deletion cancelled")
+}
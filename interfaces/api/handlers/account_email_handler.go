@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type AccountEmailHandler struct {
+	accountEmailService services.AccountEmailService
+}
+
+func NewAccountEmailHandler(accountEmailService services.AccountEmailService) *AccountEmailHandler {
+	return &AccountEmailHandler{
+		accountEmailService: accountEmailService,
+	}
+}
+
+// accountEmailErrorResponse maps account email service errors to HTTP responses
+func accountEmailErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidEmailToken),
+		errors.Is(err, services.ErrEmailTokenExpired):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// VerifyEmail redeems a verification link
+// POST /auth/email/verify
+func (h *AccountEmailHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req dto.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	user, err := h.accountEmailService.VerifyEmail(c.Context(), req.Token)
+	if err != nil {
+		return accountEmailErrorResponse(c, err, "Failed to verify email")
+	}
+
+	return utils.SuccessResponse(c, dto.UserToUserResponse(user), "Email verified successfully")
+}
+
+// ResendVerification sends a new verification link (same response whether or not the address is registered)
+// POST /auth/email/resend
+func (h *AccountEmailHandler) ResendVerification(c *fiber.Ctx) error {
+	var req dto.ResendVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.accountEmailService.ResendVerification(c.Context(), req.Email); err != nil {
+		return accountEmailErrorResponse(c, err, "Failed to send verification email")
+	}
+
+	return utils.SuccessResponse(c, nil, "If the address needs verification, a new link has been sent")
+}
+
+// ForgotPassword sends a password reset link (same response whether or not the address is registered)
+// POST /auth/password/forgot
+func (h *AccountEmailHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.accountEmailService.RequestPasswordReset(c.Context(), req.Email); err != nil {
+		return accountEmailErrorResponse(c, err, "Failed to send password reset email")
+	}
+
+	return utils.SuccessResponse(c, nil, "If the address is registered, a password reset link has been sent")
+}
+
+// ResetPassword sets a new password from a reset link and signs out every session
+// POST /auth/password/reset
+func (h *AccountEmailHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.accountEmailService.ResetPassword(c.Context(), req.Token, req.NewPassword); err != nil {
+		return accountEmailErrorResponse(c, err, "Failed to reset password")
+	}
+
+	return utils.SuccessResponse(c, nil, "Password reset successfully, please log in again")
+}
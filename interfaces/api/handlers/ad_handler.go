@@ -0,0 +1,484 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type AdHandler struct {
+	adService services.AdService
+}
+
+func NewAdHandler(adService services.AdService) *AdHandler {
+	return &AdHandler{
+		adService: adService,
+	}
+}
+
+// adErrorResponse maps ad service errors to HTTP responses
+func adErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrAdNotFound),
+		errors.Is(err, services.ErrAdPlacementNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrAdPlacementFull),
+		errors.Is(err, services.ErrAdPaymentUnderReview),
+		errors.Is(err, services.ErrAdPaymentNotPending):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrAdInvalidStartDate),
+		errors.Is(err, services.ErrAdInvalidCreative),
+		errors.Is(err, services.ErrAdInvalidSlip),
+		errors.Is(err, services.ErrAdInvalidStatus),
+		errors.Is(err, services.ErrAdImpressionMismatch):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrAdTrackingLimited):
+		return utils.ErrorResponse(c, apperrors.ErrTooManyRequests.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(fallback).WithInternal(err))
+}
+
+// parseAdID parses the :id route param
+func parseAdID(c *fiber.Ctx) (uuid.UUID, error) {
+	return uuid.Parse(c.Params("id"))
+}
+
+// ==================== Public ====================
+
+// ListPlacements lists the bookable ad placements with prices
+// GET /ads/placements
+func (h *AdHandler) ListPlacements(c *fiber.Ctx) error {
+	placements, err := h.adService.ListPlacements(c.Context())
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, placements, "Ad placements retrieved successfully")
+}
+
+// ServeAds returns ads for a sidebar or post-detail slot (feed ads come with the feed)
+// GET /ads/serve?placement=sidebar&limit=1
+func (h *AdHandler) ServeAds(c *fiber.Ctx) error {
+	placement := c.Query("placement")
+	if placement == "" {
+		return utils.ValidationErrorResponse(c, "Placement is required")
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "1"))
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	ads, err := h.adService.ServeAds(c.Context(), placement, userIDPtr, limit)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to get ads")
+	}
+
+	return utils.SuccessResponse(c, ads, "Ads retrieved successfully")
+}
+
+// RecordImpression records that an ad was on screen
+// POST /ads/:id/impression
+func (h *AdHandler) RecordImpression(c *fiber.Ctx) error {
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	var req dto.RecordAdImpressionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	impression, err := h.adService.RecordImpression(c.Context(), adID, userIDPtr, &req, c.IP(), c.Get("User-Agent"))
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to record impression")
+	}
+
+	return utils.SuccessResponse(c, impression, "Impression recorded successfully")
+}
+
+// RecordClick records a click and returns the destination URL
+// POST /ads/:id/click
+func (h *AdHandler) RecordClick(c *fiber.Ctx) error {
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	var req dto.RecordAdClickRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	click, err := h.adService.RecordClick(c.Context(), adID, userIDPtr, &req, c.IP())
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to record click")
+	}
+
+	return utils.SuccessResponse(c, click, "Click recorded successfully")
+}
+
+// ==================== Advertiser ====================
+
+// CreateAd books a placement for the current user
+// POST /ads
+func (h *AdHandler) CreateAd(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateAdRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	ad, err := h.adService.CreateAd(c.Context(), userID, &req)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to create ad")
+	}
+
+	return utils.SuccessResponse(c, ad, "Ad created successfully")
+}
+
+// ListMyAds lists the current user's ads
+// GET /ads/me?status=active&offset=0&limit=20
+func (h *AdHandler) ListMyAds(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	ads, err := h.adService.ListMyAds(c.Context(), userID, c.Query("status"), offset, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, ads, "Ads retrieved successfully")
+}
+
+// GetMyAd retrieves one of the current user's ads with its latest payment
+// GET /ads/:id
+func (h *AdHandler) GetMyAd(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	ad, err := h.adService.GetMyAd(c.Context(), adID, userID)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to get ad")
+	}
+
+	return utils.SuccessResponse(c, ad, "Ad retrieved successfully")
+}
+
+// UpdateAd edits an ad before it is paid
+// PUT /ads/:id
+func (h *AdHandler) UpdateAd(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	var req dto.UpdateAdRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	ad, err := h.adService.UpdateAd(c.Context(), adID, userID, &req)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to update ad")
+	}
+
+	return utils.SuccessResponse(c, ad, "Ad updated successfully")
+}
+
+// GetAdStats retrieves the performance of one of the current user's ads
+// GET /ads/:id/stats
+func (h *AdHandler) GetAdStats(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	stats, err := h.adService.GetAdStats(c.Context(), adID, userID)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to get ad stats")
+	}
+
+	return utils.SuccessResponse(c, stats, "Ad stats retrieved successfully")
+}
+
+// CancelAd cancels an ad that has not been paid
+// POST /ads/:id/cancel
+func (h *AdHandler) CancelAd(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	if err := h.adService.CancelAd(c.Context(), adID, userID); err != nil {
+		return adErrorResponse(c, err, "Failed to cancel ad")
+	}
+
+	return utils.SuccessResponse(c, nil, "Ad cancelled successfully")
+}
+
+// PauseAd pauses a running ad
+// POST /ads/:id/pause
+func (h *AdHandler) PauseAd(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	ad, err := h.adService.PauseAd(c.Context(), adID, userID)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to pause ad")
+	}
+
+	return utils.SuccessResponse(c, ad, "Ad paused successfully")
+}
+
+// ResumeAd resumes a paused ad
+// POST /ads/:id/resume
+func (h *AdHandler) ResumeAd(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	ad, err := h.adService.ResumeAd(c.Context(), adID, userID)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to resume ad")
+	}
+
+	return utils.SuccessResponse(c, ad, "Ad resumed successfully")
+}
+
+// SubmitPayment attaches a bank slip to an ad
+// POST /ads/:id/payments
+func (h *AdHandler) SubmitPayment(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	var req dto.SubmitAdPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	payment, err := h.adService.SubmitPayment(c.Context(), adID, userID, &req)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to submit payment")
+	}
+
+	return utils.SuccessResponse(c, payment, "Payment submitted successfully")
+}
+
+// ==================== Admin ====================
+
+// ListPayments lists bank slips by status (pending = verification queue)
+// GET /ads/admin/payments?status=pending&offset=0&limit=20
+func (h *AdHandler) ListPayments(c *fiber.Ctx) error {
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	payments, err := h.adService.ListPayments(c.Context(), c.Query("status"), offset, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, payments, "Ad payments retrieved successfully")
+}
+
+// VerifyPayment confirms a bank slip, the ad then goes live on its start date
+// POST /ads/admin/payments/:paymentId/verify
+func (h *AdHandler) VerifyPayment(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	paymentID, err := uuid.Parse(c.Params("paymentId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid payment ID")
+	}
+
+	payment, err := h.adService.VerifyPayment(c.Context(), paymentID, adminID)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to verify payment")
+	}
+
+	return utils.SuccessResponse(c, payment, "Payment verified successfully")
+}
+
+// RejectPayment rejects a bank slip
+// POST /ads/admin/payments/:paymentId/reject
+func (h *AdHandler) RejectPayment(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	paymentID, err := uuid.Parse(c.Params("paymentId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid payment ID")
+	}
+
+	var req dto.RejectAdPaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	payment, err := h.adService.RejectPayment(c.Context(), paymentID, adminID, req.Reason)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to reject payment")
+	}
+
+	return utils.SuccessResponse(c, payment, "Payment rejected successfully")
+}
+
+// RemoveAd takes down an ad that breaks the ad policy
+// POST /ads/admin/:id/remove
+func (h *AdHandler) RemoveAd(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	adID, err := parseAdID(c)
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid ad ID")
+	}
+
+	var req dto.RemoveAdRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.adService.RemoveAd(c.Context(), adID, adminID, req.Reason); err != nil {
+		return adErrorResponse(c, err, "Failed to remove ad")
+	}
+
+	return utils.SuccessResponse(c, nil, "Ad removed successfully")
+}
+
+// UpdatePlacement changes price, capacity or feed interval of a placement
+// PUT /ads/admin/placements/:code
+func (h *AdHandler) UpdatePlacement(c *fiber.Ctx) error {
+	var req dto.UpdateAdPlacementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	placement, err := h.adService.UpdatePlacement(c.Context(), c.Params("code"), &req)
+	if err != nil {
+		return adErrorResponse(c, err, "Failed to update placement")
+	}
+
+	return utils.SuccessResponse(c, placement, "Ad placement updated successfully")
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type APITokenHandler struct {
+	apiTokenService services.APITokenService
+}
+
+func NewAPITokenHandler(apiTokenService services.APITokenService) *APITokenHandler {
+	return &APITokenHandler{
+		apiTokenService: apiTokenService,
+	}
+}
+
+// apiTokenErrorResponse maps API token service errors to HTTP responses
+func apiTokenErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrAPITokenNotFound),
+		errors.Is(err, services.ErrAPITokenUserNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrAPITokenLimit):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrAPITokenNotBot):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrAPITokenStaffAccount):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// ListTokens lists the current user's personal access tokens
+// GET /auth/tokens
+func (h *APITokenHandler) ListTokens(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	tokens, err := h.apiTokenService.ListTokens(c.Context(), userID)
+	if err != nil {
+		return apiTokenErrorResponse(c, err, "Failed to retrieve API tokens")
+	}
+
+	return utils.SuccessResponse(c, tokens, "API tokens retrieved successfully")
+}
+
+// CreateToken creates a personal access token (the token is only returned in this response)
+// POST /auth/tokens
+func (h *APITokenHandler) CreateToken(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateAPITokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	token, err := h.apiTokenService.CreateToken(c.Context(), userID, &req)
+	if err != nil {
+		return apiTokenErrorResponse(c, err, "Failed to create API token")
+	}
+
+	return utils.SuccessResponse(c, token, "API token created, copy it now as it will not be shown again")
+}
+
+// RevokeToken revokes one of the current user's personal access tokens
+// DELETE /auth/tokens/:id
+func (h *APITokenHandler) RevokeToken(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	tokenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid token ID")
+	}
+
+	if err := h.apiTokenService.RevokeToken(c.Context(), userID, tokenID); err != nil {
+		return apiTokenErrorResponse(c, err, "Failed to revoke API token")
+	}
+
+	return utils.SuccessResponse(c, nil, "API token revoked successfully")
+}
+
+// ListBotTokens lists the API tokens of any account (staff)
+// GET /bot-tokens/users/:userId
+func (h *APITokenHandler) ListBotTokens(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	tokens, err := h.apiTokenService.ListTokens(c.Context(), userID)
+	if err != nil {
+		return apiTokenErrorResponse(c, err, "Failed to retrieve API tokens")
+	}
+
+	return utils.SuccessResponse(c, tokens, "API tokens retrieved successfully")
+}
+
+// SetBotAccount flags or unflags an account as a bot (staff)
+// PUT /bot-tokens/users/:userId/bot
+func (h *APITokenHandler) SetBotAccount(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.SetBotAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.apiTokenService.SetBotAccount(c.Context(), userID, *req.IsBot); err != nil {
+		return apiTokenErrorResponse(c, err, "Failed to update bot account")
+	}
+
+	return utils.SuccessResponse(c, nil, "Bot account updated successfully")
+}
+
+// CreateBotToken issues a bot key for an account, e.g. an auto-post bot (staff)
+// POST /bot-tokens/users/:userId
+func (h *APITokenHandler) CreateBotToken(c *fiber.Ctx) error {
+	staffID := c.Locals("userID").(uuid.UUID)
+
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.CreateAPITokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	token, err := h.apiTokenService.CreateBotToken(c.Context(), staffID, userID, &req)
+	if err != nil {
+		return apiTokenErrorResponse(c, err, "Failed to create bot key")
+	}
+
+	return utils.SuccessResponse(c, token, "Bot key created, copy it now as it will not be shown again")
+}
+
+// RevokeBotToken revokes any API token (staff)
+// DELETE /bot-tokens/:id
+func (h *APITokenHandler) RevokeBotToken(c *fiber.Ctx) error {
+	tokenID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid token ID")
+	}
+
+	if err := h.apiTokenService.RevokeBotToken(c.Context(), tokenID); err != nil {
+		return apiTokenErrorResponse(c, err, "Failed to revoke API token")
+	}
+
+	return utils.SuccessResponse(c, nil, "API token revoked successfully")
+}
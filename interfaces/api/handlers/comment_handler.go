@@ -42,6 +42,9 @@ func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
 
 	comment, err := h.commentService.CreateComment(c.Context(), userID, &req)
 	if err != nil {
+		if isAccountRestricted(err) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to create comment").WithInternal(err))
 	}
 
@@ -94,6 +97,9 @@ func (h *CommentHandler) UpdateComment(c *fiber.Ctx) error {
 
 	comment, err := h.commentService.UpdateComment(c.Context(), commentID, userID, &req)
 	if err != nil {
+		if isAccountRestricted(err) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to update comment").WithInternal(err))
 	}
 
@@ -0,0 +1,374 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/repositories"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type CommunityHandler struct {
+	communityService services.CommunityService
+	postService      services.PostService
+}
+
+func NewCommunityHandler(communityService services.CommunityService, postService services.PostService) *CommunityHandler {
+	return &CommunityHandler{
+		communityService: communityService,
+		postService:      postService,
+	}
+}
+
+// communityErrorResponse maps community service errors to HTTP responses
+func communityErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrCommunityNotFound),
+		errors.Is(err, services.ErrCommunityMemberNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrCommunityPrivate),
+		errors.Is(err, services.ErrCommunityModeratorOnly),
+		errors.Is(err, services.ErrCommunityOwnerOnly),
+		errors.Is(err, services.ErrCommunityNotMember),
+		errors.Is(err, services.ErrCommunityBanned),
+		errors.Is(err, services.ErrCommunityPostingRestricted):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrCommunitySlugTaken),
+		errors.Is(err, services.ErrCommunityAlreadyMember):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrCommunitySlugInvalid),
+		errors.Is(err, services.ErrCommunityOwnerCannotLeave):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(fallback).WithInternal(err))
+}
+
+// ==================== Communities ====================
+
+// CreateCommunity creates a community owned by the current user
+// POST /communities
+func (h *CommunityHandler) CreateCommunity(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateCommunityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	community, err := h.communityService.CreateCommunity(c.Context(), userID, &req)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to create community")
+	}
+
+	return utils.SuccessResponse(c, community, "Community created successfully")
+}
+
+// UpdateCommunity updates a community (moderators; access settings owner only)
+// PUT /communities/:slug
+func (h *CommunityHandler) UpdateCommunity(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.UpdateCommunityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	community, err := h.communityService.UpdateCommunity(c.Context(), c.Params("slug"), userID, &req)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to update community")
+	}
+
+	return utils.SuccessResponse(c, community, "Community updated successfully")
+}
+
+// GetCommunity retrieves a community by slug
+// GET /communities/:slug
+func (h *CommunityHandler) GetCommunity(c *fiber.Ctx) error {
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	community, err := h.communityService.GetCommunity(c.Context(), c.Params("slug"), userIDPtr)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to retrieve community")
+	}
+
+	return utils.SuccessResponse(c, community, "Community retrieved successfully")
+}
+
+// ListCommunities lists public and restricted communities (most members first)
+// GET /communities?q=&offset=0&limit=20
+func (h *CommunityHandler) ListCommunities(c *fiber.Ctx) error {
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	communities, err := h.communityService.ListCommunities(c.Context(), c.Query("q"), offset, limit, userIDPtr)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve communities").WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, communities, "Communities retrieved successfully")
+}
+
+// ListMyCommunities lists the communities the current user is an active member of
+// GET /communities/me?offset=0&limit=20
+func (h *CommunityHandler) ListMyCommunities(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	communities, err := h.communityService.ListMyCommunities(c.Context(), userID, offset, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve communities").WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, communities, "Communities retrieved successfully")
+}
+
+// ListCommunityPosts retrieves the feed of a community
+// GET /communities/:slug/posts?sort=hot|new|top|controversial&cursor=&limit=20
+func (h *CommunityHandler) ListCommunityPosts(c *fiber.Ctx) error {
+	cursor := c.Query("cursor", "")
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	var sortByEnum repositories.PostSortBy
+	switch c.Query("sort", "hot") {
+	case "new":
+		sortByEnum = repositories.SortByNew
+	case "top":
+		sortByEnum = repositories.SortByTop
+	case "controversial":
+		sortByEnum = repositories.SortByControversial
+	default:
+		sortByEnum = repositories.SortByHot
+	}
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	community, err := h.communityService.GetCommunity(c.Context(), c.Params("slug"), userIDPtr)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to retrieve community")
+	}
+
+	// Check if using cursor-based pagination
+	if cursor != "" || c.Query("offset") == "" {
+		posts, err := h.postService.ListPostsByCommunityWithCursor(c.Context(), community.ID, cursor, limit, sortByEnum, userIDPtr)
+		if err != nil {
+			return communityErrorResponse(c, err, "Failed to retrieve posts")
+		}
+		return utils.SuccessResponse(c, posts, "Posts retrieved successfully")
+	}
+
+	// Fallback to offset-based pagination (deprecated)
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	posts, err := h.postService.ListPostsByCommunity(c.Context(), community.ID, offset, limit, sortByEnum, userIDPtr)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to retrieve posts")
+	}
+
+	return utils.SuccessResponse(c, posts, "Posts retrieved successfully")
+}
+
+// ==================== Membership ====================
+
+// Join joins a community (restricted and private communities create a join request)
+// POST /communities/:slug/join
+func (h *CommunityHandler) Join(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	community, err := h.communityService.Join(c.Context(), c.Params("slug"), userID)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to join community")
+	}
+
+	return utils.SuccessResponse(c, community, "Joined community successfully")
+}
+
+// Leave leaves a community (or withdraws a pending join request)
+// POST /communities/:slug/leave
+func (h *CommunityHandler) Leave(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	if err := h.communityService.Leave(c.Context(), c.Params("slug"), userID); err != nil {
+		return communityErrorResponse(c, err, "Failed to leave community")
+	}
+
+	return utils.SuccessResponse(c, nil, "Left community successfully")
+}
+
+// ListMembers lists members of a community (status=pending|banned for moderators)
+// GET /communities/:slug/members?status=active&offset=0&limit=20
+func (h *CommunityHandler) ListMembers(c *fiber.Ctx) error {
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	status := c.Query("status")
+	if status != "" && status != "active" && status != "pending" && status != "banned" {
+		return utils.ValidationErrorResponse(c, "Invalid status")
+	}
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	members, err := h.communityService.ListMembers(c.Context(), c.Params("slug"), status, offset, limit, userIDPtr)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to retrieve members")
+	}
+
+	return utils.SuccessResponse(c, members, "Members retrieved successfully")
+}
+
+// ==================== Moderation ====================
+
+// ApproveMember approves a pending join request
+// POST /communities/:slug/members/:userId/approve
+func (h *CommunityHandler) ApproveMember(c *fiber.Ctx) error {
+	moderatorID := c.Locals("userID").(uuid.UUID)
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	member, err := h.communityService.ApproveMember(c.Context(), c.Params("slug"), moderatorID, memberID)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to approve member")
+	}
+
+	return utils.SuccessResponse(c, member, "Member approved successfully")
+}
+
+// RejectMember rejects a pending join request
+// POST /communities/:slug/members/:userId/reject
+func (h *CommunityHandler) RejectMember(c *fiber.Ctx) error {
+	moderatorID := c.Locals("userID").(uuid.UUID)
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	if err := h.communityService.RejectMember(c.Context(), c.Params("slug"), moderatorID, memberID); err != nil {
+		return communityErrorResponse(c, err, "Failed to reject member")
+	}
+
+	return utils.SuccessResponse(c, nil, "Join request rejected successfully")
+}
+
+// BanMember bans a user from a community
+// POST /communities/:slug/members/:userId/ban
+func (h *CommunityHandler) BanMember(c *fiber.Ctx) error {
+	moderatorID := c.Locals("userID").(uuid.UUID)
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	member, err := h.communityService.BanMember(c.Context(), c.Params("slug"), moderatorID, memberID)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to ban member")
+	}
+
+	return utils.SuccessResponse(c, member, "Member banned successfully")
+}
+
+// UnbanMember lifts a ban (the user can join again)
+// DELETE /communities/:slug/members/:userId/ban
+func (h *CommunityHandler) UnbanMember(c *fiber.Ctx) error {
+	moderatorID := c.Locals("userID").(uuid.UUID)
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	if err := h.communityService.UnbanMember(c.Context(), c.Params("slug"), moderatorID, memberID); err != nil {
+		return communityErrorResponse(c, err, "Failed to unban member")
+	}
+
+	return utils.SuccessResponse(c, nil, "Member unbanned successfully")
+}
+
+// SetMemberRole promotes a member to moderator or demotes a moderator (owner only)
+// PUT /communities/:slug/members/:userId/role
+func (h *CommunityHandler) SetMemberRole(c *fiber.Ctx) error {
+	ownerID := c.Locals("userID").(uuid.UUID)
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.SetCommunityMemberRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	member, err := h.communityService.SetMemberRole(c.Context(), c.Params("slug"), ownerID, memberID, &req)
+	if err != nil {
+		return communityErrorResponse(c, err, "Failed to update member role")
+	}
+
+	return utils.SuccessResponse(c, member, "Member role updated successfully")
+}
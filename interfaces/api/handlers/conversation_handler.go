@@ -1,13 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	apperrors "gofiber-template/pkg/errors"
 	"log"
 	"strconv"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
 	chatWebsocket "gofiber-template/infrastructure/websocket"
@@ -41,6 +42,9 @@ func (h *ConversationHandler) GetOrCreateConversation(c *fiber.Ctx) error {
 
 	conversation, err := h.conversationService.GetOrCreateConversation(c.Context(), userID, username)
 	if err != nil {
+		if errors.Is(err, services.ErrConversationChatNotAllowed) {
+			return conversationErrorResponse(c, err, "Failed to create conversation")
+		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to create conversation").WithInternal(err))
 	}
 
@@ -77,6 +81,98 @@ func (h *ConversationHandler) ListConversations(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, conversations, "Conversations retrieved successfully")
 }
 
+// ListMessageRequests retrieves the message requests the current user received
+// GET /conversations/requests?cursor=xxx&limit=20
+func (h *ConversationHandler) ListMessageRequests(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	// Get cursor from query params
+	cursor := c.Query("cursor")
+	var cursorPtr *string
+	if cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	// Get limit from query params (default: 20, max: 50)
+	limit := 20
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil {
+			if parsedLimit > 0 && parsedLimit <= 50 {
+				limit = parsedLimit
+			}
+		}
+	}
+
+	requests, err := h.conversationService.ListMessageRequests(c.Context(), userID, cursorPtr, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve message requests").WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, requests, "Message requests retrieved successfully")
+}
+
+// AcceptMessageRequest moves a message request to the inbox (the requester can chat freely from now on)
+// POST /conversations/:conversationId/request/accept
+func (h *ConversationHandler) AcceptMessageRequest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	conversation, err := h.conversationService.AcceptMessageRequest(c.Context(), conversationID, userID)
+	if err != nil {
+		return conversationErrorResponse(c, err, "Failed to accept message request")
+	}
+
+	h.sendConversationEvent(c, conversationID, "conversation.updated", map[string]interface{}{
+		"action": "request_accepted",
+		"userId": userID.String(),
+	})
+
+	return utils.SuccessResponse(c, conversation, "Message request accepted")
+}
+
+// DeleteMessageRequest deletes a message request with its messages (the requester is not notified)
+// DELETE /conversations/:conversationId/request
+func (h *ConversationHandler) DeleteMessageRequest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	if err := h.conversationService.DeleteMessageRequest(c.Context(), conversationID, userID); err != nil {
+		return conversationErrorResponse(c, err, "Failed to delete message request")
+	}
+
+	// Only the user's other devices drop it
+	h.sendRemovedEvent(conversationID, userID)
+
+	return utils.SuccessResponse(c, nil, "Message request deleted")
+}
+
+// BlockMessageRequest blocks the requester and deletes their message request
+// POST /conversations/:conversationId/request/block
+func (h *ConversationHandler) BlockMessageRequest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	if err := h.conversationService.BlockMessageRequest(c.Context(), conversationID, userID); err != nil {
+		return conversationErrorResponse(c, err, "Failed to block message request")
+	}
+
+	h.sendRemovedEvent(conversationID, userID)
+
+	return utils.SuccessResponse(c, nil, "User blocked and message request deleted")
+}
+
 // GetUnreadCount retrieves total unread message count
 // GET /conversations/unread-count
 func (h *ConversationHandler) GetUnreadCount(c *fiber.Ctx) error {
@@ -90,7 +186,7 @@ func (h *ConversationHandler) GetUnreadCount(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, unreadCount, "Unread count retrieved successfully")
 }
 
-// MarkAsRead marks all messages in a conversation as read
+// MarkAsRead marks the messages in a conversation as read (up to "seq" when given, otherwise all)
 // POST /conversations/:conversationId/read
 func (h *ConversationHandler) MarkAsRead(c *fiber.Ctx) error {
 	userID := c.Locals("userID").(uuid.UUID)
@@ -100,50 +196,107 @@ func (h *ConversationHandler) MarkAsRead(c *fiber.Ctx) error {
 		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
 	}
 
+	// The body is optional, without "seq" everything received so far is acknowledged
+	var req dto.UpdateReceiptRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ValidationErrorResponse(c, "Invalid request body")
+		}
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
 	// Mark as read in database
-	if err := h.conversationService.MarkAsRead(c.Context(), conversationID, userID); err != nil {
-		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to mark as read").WithInternal(err))
+	state, err := h.conversationService.MarkAsRead(c.Context(), conversationID, userID, req.Seq)
+	if err != nil {
+		return conversationErrorResponse(c, err, "Failed to mark as read")
 	}
 
-	// Send WebSocket notification to the other user (sender)
-	h.sendReadNotification(c, conversationID, userID)
+	// Send WebSocket notification to the other participants and the reader's devices
+	h.sendReadNotification(c, userID, state)
 
-	return utils.SuccessResponse(c, nil, "Conversation marked as read")
+	return utils.SuccessResponse(c, state, "Conversation marked as read")
 }
 
-// sendReadNotification sends WebSocket notification to sender when receiver reads messages
-func (h *ConversationHandler) sendReadNotification(c *fiber.Ctx, conversationID uuid.UUID, readerID uuid.UUID) {
-	if h.chatHub == nil {
-		log.Printf("⚠️ ChatHub is nil, skipping read notification")
-		return
+// MarkAsDelivered confirms that messages up to "seq" (all when omitted) reached this device
+// POST /conversations/:conversationId/delivered
+func (h *ConversationHandler) MarkAsDelivered(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
 	}
 
-	// Get conversation to find the other participant (sender)
-	conversation, err := h.conversationRepo.GetByID(c.Context(), conversationID)
+	// The body is optional, without "seq" everything received so far is acknowledged
+	var req dto.UpdateReceiptRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ValidationErrorResponse(c, "Invalid request body")
+		}
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	state, err := h.conversationService.MarkAsDelivered(c.Context(), conversationID, userID, req.Seq)
 	if err != nil {
-		log.Printf("⚠️ Failed to get conversation for read notification: %v", err)
-		return
+		return conversationErrorResponse(c, err, "Failed to mark as delivered")
 	}
 
-	// Determine the other user (sender who should receive the notification)
-	var senderID uuid.UUID
-	if conversation.User1ID == readerID {
-		senderID = conversation.User2ID
-	} else {
-		senderID = conversation.User1ID
+	if h.chatHub != nil {
+		h.chatHub.SendDeliveryUpdate(c.Context(), userID, state)
 	}
 
-	// Send read update notification to sender
-	h.chatHub.SendToUser(senderID, &chatWebsocket.ChatMessage{
-		Type: "message.read_update",
-		Payload: map[string]interface{}{
-			"conversationId": conversationID.String(),
-			"readBy":         readerID.String(),
-			"readAt":         time.Now().Format(time.RFC3339),
-		},
-	})
+	return utils.SuccessResponse(c, state, "Conversation marked as delivered")
+}
 
-	log.Printf("📤 Read notification sent to sender: %s (conversation: %s, read by: %s)", senderID, conversationID, readerID)
+// SyncConversation returns the changes after the last sequence the client saw
+// GET /conversations/:conversationId/sync?afterSeq=42
+func (h *ConversationHandler) SyncConversation(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	afterSeq, err := strconv.ParseInt(c.Query("afterSeq", "0"), 10, 64)
+	if err != nil || afterSeq < 0 {
+		return utils.ValidationErrorResponse(c, "Invalid afterSeq")
+	}
+
+	result, err := h.conversationService.SyncConversation(c.Context(), conversationID, userID, afterSeq)
+	if err != nil {
+		return conversationErrorResponse(c, err, "Failed to sync conversation")
+	}
+
+	return utils.SuccessResponse(c, result, "Conversation synced successfully")
+}
+
+// sendReadNotification sends WebSocket notification to the other participants when a user reads messages
+func (h *ConversationHandler) sendReadNotification(c *fiber.Ctx, readerID uuid.UUID, state *dto.ReadStateResponse) {
+	if h.chatHub == nil {
+		log.Printf("⚠️ ChatHub is nil, skipping read notification")
+		return
+	}
+
+	h.chatHub.SendReadUpdate(c.Context(), readerID, state)
+
+	log.Printf("📤 Read notification sent (conversation: %s, read by: %s)", state.ConversationID, readerID)
 }
 
 // SearchUsersForChat searches users for starting a new chat
@@ -170,3 +323,278 @@ func (h *ConversationHandler) SearchUsersForChat(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, results, "Users retrieved successfully")
 }
+
+// conversationErrorResponse maps conversation service errors to HTTP responses
+func conversationErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrConversationNotFound),
+		errors.Is(err, services.ErrConversationUserNotFound),
+		errors.Is(err, services.ErrConversationMemberNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrConversationNotParticipant),
+		errors.Is(err, services.ErrConversationNotManager),
+		errors.Is(err, services.ErrConversationNotOwner),
+		errors.Is(err, services.ErrConversationUserBlocked),
+		errors.Is(err, services.ErrConversationChatNotAllowed):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrConversationNotGroup):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrConversationGroupFull),
+		errors.Is(err, services.ErrConversationNotRequest):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// GetConversation retrieves a conversation (groups include their members)
+// GET /conversations/:conversationId
+func (h *ConversationHandler) GetConversation(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	conversation, err := h.conversationService.GetConversation(c.Context(), conversationID, userID)
+	if err != nil {
+		return conversationErrorResponse(c, err, "Failed to retrieve conversation")
+	}
+
+	return utils.SuccessResponse(c, conversation, "Conversation retrieved successfully")
+}
+
+// CreateGroup creates a group conversation owned by the current user
+// POST /conversations/groups
+func (h *ConversationHandler) CreateGroup(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateGroupConversationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	conversation, err := h.conversationService.CreateGroup(c.Context(), userID, &req)
+	if err != nil {
+		return conversationErrorResponse(c, err, "Failed to create group")
+	}
+
+	h.sendConversationEvent(c, conversation.ID, "conversation.created", map[string]interface{}{
+		"createdBy": userID.String(),
+	})
+
+	return utils.SuccessResponse(c, conversation, "Group created successfully")
+}
+
+// UpdateGroup renames a group or changes its avatar (owner and admins)
+// PATCH /conversations/:conversationId
+func (h *ConversationHandler) UpdateGroup(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	var req dto.UpdateGroupConversationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	conversation, err := h.conversationService.UpdateGroup(c.Context(), conversationID, userID, &req)
+	if err != nil {
+		return conversationErrorResponse(c, err, "Failed to update group")
+	}
+
+	h.sendConversationEvent(c, conversationID, "conversation.updated", map[string]interface{}{
+		"action":  "details_changed",
+		"actorId": userID.String(),
+	})
+
+	return utils.SuccessResponse(c, conversation, "Group updated successfully")
+}
+
+// AddParticipants adds members to a group (owner and admins)
+// POST /conversations/:conversationId/participants
+func (h *ConversationHandler) AddParticipants(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	var req dto.AddParticipantsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	conversation, err := h.conversationService.AddParticipants(c.Context(), conversationID, userID, &req)
+	if err != nil {
+		return conversationErrorResponse(c, err, "Failed to add members")
+	}
+
+	h.sendConversationEvent(c, conversationID, "conversation.updated", map[string]interface{}{
+		"action":  "participants_added",
+		"actorId": userID.String(),
+	})
+
+	return utils.SuccessResponse(c, conversation, "Members added successfully")
+}
+
+// RemoveParticipant removes a member from a group (owner and admins; removing yourself leaves the group)
+// DELETE /conversations/:conversationId/participants/:userId
+func (h *ConversationHandler) RemoveParticipant(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	targetUserID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	if err := h.conversationService.RemoveParticipant(c.Context(), conversationID, userID, targetUserID); err != nil {
+		return conversationErrorResponse(c, err, "Failed to remove member")
+	}
+
+	h.sendConversationEvent(c, conversationID, "conversation.updated", map[string]interface{}{
+		"action":  "participant_removed",
+		"actorId": userID.String(),
+		"userId":  targetUserID.String(),
+	})
+	h.sendRemovedEvent(conversationID, targetUserID)
+
+	return utils.SuccessResponse(c, nil, "Member removed successfully")
+}
+
+// UpdateParticipantRole changes a member's role (owner only; "owner" transfers ownership)
+// PATCH /conversations/:conversationId/participants/:userId
+func (h *ConversationHandler) UpdateParticipantRole(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	targetUserID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.UpdateParticipantRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	conversation, err := h.conversationService.UpdateParticipantRole(c.Context(), conversationID, userID, targetUserID, &req)
+	if err != nil {
+		return conversationErrorResponse(c, err, "Failed to change member role")
+	}
+
+	h.sendConversationEvent(c, conversationID, "conversation.updated", map[string]interface{}{
+		"action":  "role_changed",
+		"actorId": userID.String(),
+		"userId":  targetUserID.String(),
+		"role":    req.Role,
+	})
+
+	return utils.SuccessResponse(c, conversation, "Member role updated successfully")
+}
+
+// LeaveGroup removes the current user from a group (ownership passes to the longest-standing admin or member)
+// POST /conversations/:conversationId/leave
+func (h *ConversationHandler) LeaveGroup(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	if err := h.conversationService.LeaveGroup(c.Context(), conversationID, userID); err != nil {
+		return conversationErrorResponse(c, err, "Failed to leave group")
+	}
+
+	h.sendConversationEvent(c, conversationID, "conversation.updated", map[string]interface{}{
+		"action": "participant_left",
+		"userId": userID.String(),
+	})
+	h.sendRemovedEvent(conversationID, userID)
+
+	return utils.SuccessResponse(c, nil, "Left group successfully")
+}
+
+// sendConversationEvent notifies every member of a group change (clients refetch the conversation)
+func (h *ConversationHandler) sendConversationEvent(c *fiber.Ctx, conversationID uuid.UUID, eventType string, payload map[string]interface{}) {
+	if h.chatHub == nil {
+		log.Printf("⚠️ ChatHub is nil, skipping %s event", eventType)
+		return
+	}
+
+	participantIDs, err := h.conversationRepo.ListParticipantIDs(c.Context(), conversationID)
+	if err != nil {
+		log.Printf("⚠️ Failed to get participants for %s event: %v", eventType, err)
+		return
+	}
+
+	payload["conversationId"] = conversationID.String()
+	h.chatHub.SendToUsers(participantIDs, &chatWebsocket.ChatMessage{
+		Type:    eventType,
+		Payload: payload,
+	})
+}
+
+// sendRemovedEvent tells a former member to drop the conversation
+func (h *ConversationHandler) sendRemovedEvent(conversationID uuid.UUID, userID uuid.UUID) {
+	if h.chatHub == nil {
+		return
+	}
+
+	h.chatHub.SendToUser(userID, &chatWebsocket.ChatMessage{
+		Type: "conversation.removed",
+		Payload: map[string]interface{}{
+			"conversationId": conversationID.String(),
+		},
+	})
+}
@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gofiber-template/domain/models"
 	"gofiber-template/domain/services"
 	apperrors "gofiber-template/pkg/errors"
 	"gofiber-template/pkg/utils"
@@ -20,7 +22,20 @@ func NewFollowHandler(followService services.FollowService) *FollowHandler {
 	}
 }
 
-// Follow follows a user
+// followErrorResponse maps follow service errors to HTTP responses
+func followErrorResponse(c *fiber.Ctx, err error, fallback *apperrors.AppError) error {
+	switch {
+	case errors.Is(err, services.ErrPrivateAccount):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrFollowRequestNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrFollowRequestPending):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, fallback.WithInternal(err))
+}
+
+// Follow follows a user (sends a follow request when the account is private)
 func (h *FollowHandler) Follow(c *fiber.Ctx) error {
 	followerID := c.Locals("userID").(uuid.UUID)
 
@@ -31,13 +46,16 @@ func (h *FollowHandler) Follow(c *fiber.Ctx) error {
 
 	follow, err := h.followService.Follow(c.Context(), followerID, followingID)
 	if err != nil {
-		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to follow user").WithInternal(err))
+		return followErrorResponse(c, err, apperrors.ErrBadRequest.WithMessage("Failed to follow user"))
 	}
 
+	if follow.Status == models.FollowStatusRequested {
+		return utils.SuccessResponse(c, follow, "Follow request sent successfully")
+	}
 	return utils.SuccessResponse(c, follow, "Followed successfully")
 }
 
-// Unfollow unfollows a user
+// Unfollow unfollows a user (or cancels a pending follow request)
 func (h *FollowHandler) Unfollow(c *fiber.Ctx) error {
 	followerID := c.Locals("userID").(uuid.UUID)
 
@@ -89,7 +107,7 @@ func (h *FollowHandler) GetFollowers(c *fiber.Ctx) error {
 
 	followers, err := h.followService.GetFollowers(c.Context(), userID, offset, limit, currentUserIDPtr)
 	if err != nil {
-		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve followers").WithInternal(err))
+		return followErrorResponse(c, err, apperrors.ErrInternal.WithMessage("Failed to retrieve followers"))
 	}
 
 	return utils.SuccessResponse(c, followers, "Followers retrieved successfully")
@@ -113,7 +131,7 @@ func (h *FollowHandler) GetFollowing(c *fiber.Ctx) error {
 
 	following, err := h.followService.GetFollowing(c.Context(), userID, offset, limit, currentUserIDPtr)
 	if err != nil {
-		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve following").WithInternal(err))
+		return followErrorResponse(c, err, apperrors.ErrInternal.WithMessage("Failed to retrieve following"))
 	}
 
 	return utils.SuccessResponse(c, following, "Following retrieved successfully")
@@ -133,3 +151,56 @@ func (h *FollowHandler) GetMutualFollows(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, mutuals, "Mutual follows retrieved successfully")
 }
+
+// ListFollowRequests lists pending follow requests received by the current user
+// GET /follows/requests?offset=0&limit=20
+func (h *FollowHandler) ListFollowRequests(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	requests, err := h.followService.ListFollowRequests(c.Context(), userID, offset, limit)
+	if err != nil {
+		return followErrorResponse(c, err, apperrors.ErrInternal.WithMessage("Failed to retrieve follow requests"))
+	}
+
+	return utils.SuccessResponse(c, requests, "Follow requests retrieved successfully")
+}
+
+// AcceptFollowRequest approves a follow request (the requester becomes a follower)
+// POST /follows/requests/:userId/accept
+func (h *FollowHandler) AcceptFollowRequest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	requesterID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	if err := h.followService.AcceptFollowRequest(c.Context(), userID, requesterID); err != nil {
+		return followErrorResponse(c, err, apperrors.ErrInternal.WithMessage("Failed to accept follow request"))
+	}
+
+	return utils.SuccessResponse(c, nil, "Follow request accepted successfully")
+}
+
+// RejectFollowRequest declines a follow request
+// POST /follows/requests/:userId/reject
+func (h *FollowHandler) RejectFollowRequest(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	requesterID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	if err := h.followService.RejectFollowRequest(c.Context(), userID, requesterID); err != nil {
+		return followErrorResponse(c, err, apperrors.ErrInternal.WithMessage("Failed to reject follow request"))
+	}
+
+	return utils.SuccessResponse(c, nil, "Follow request rejected successfully")
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	chatWebsocket "gofiber-template/infrastructure/websocket"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type GiftHandler struct {
+	giftService services.GiftService
+	chatHub     *chatWebsocket.ChatHub
+}
+
+func NewGiftHandler(giftService services.GiftService, chatHub *chatWebsocket.ChatHub) *GiftHandler {
+	return &GiftHandler{
+		giftService: giftService,
+		chatHub:     chatHub,
+	}
+}
+
+// ListPackages lists the gift packages available for purchase
+// GET /chat/gifts/packages
+func (h *GiftHandler) ListPackages(c *fiber.Ctx) error {
+	packages, err := h.giftService.ListPackages(c.Context())
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, packages, "Gift packages retrieved successfully")
+}
+
+// SendGift sends a paid gift box in a conversation
+// POST /chat/conversations/:conversationId/gifts
+func (h *GiftHandler) SendGift(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	var req dto.SendGiftRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+	req.ConversationID = conversationID
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	message, err := h.giftService.SendGift(c.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case isAccountRestricted(err),
+			errors.Is(err, services.ErrGiftSenderRestricted):
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		case errors.Is(err, services.ErrInsufficientBalance):
+			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Insufficient wallet balance").WithInternal(err))
+		case errors.Is(err, services.ErrGiftPackageUnavailable),
+			errors.Is(err, services.ErrGiftMediaLimit),
+			errors.Is(err, services.ErrGiftDailyLimit),
+			errors.Is(err, services.ErrConversationRequestPending):
+			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+		}
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to send gift").WithInternal(err))
+	}
+
+	// Deliver the sealed gift box to the receiver in real time
+	if h.chatHub != nil {
+		h.chatHub.DeliverMessage(userID, message)
+	} else {
+		log.Printf("⚠️ ChatHub is nil, skipping WebSocket notification")
+	}
+
+	return utils.SuccessResponse(c, message, "Gift sent successfully")
+}
+
+// OpenGift opens a received gift box, revealing its content and paying the receiver
+// POST /chat/messages/:id/open-gift
+func (h *GiftHandler) OpenGift(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid message ID")
+	}
+
+	message, err := h.giftService.OpenGift(c.Context(), messageID, userID)
+	if err != nil {
+		if errors.Is(err, services.ErrGiftNotOpenable) {
+			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+		}
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage("Gift not found").WithInternal(err))
+	}
+
+	// Let the sender (and the receiver's other tabs) see the revealed gift
+	if h.chatHub != nil {
+		opened := &chatWebsocket.ChatMessage{
+			Type: "gift.opened",
+			Payload: map[string]interface{}{
+				"message": message,
+			},
+		}
+		h.chatHub.SendToUser(message.Sender.ID, opened)
+		h.chatHub.SendToUser(userID, opened)
+	}
+
+	return utils.SuccessResponse(c, message, "Gift opened successfully")
+}
@@ -35,6 +35,23 @@ type Services struct {
 	BlockService        services.BlockService
 	FileUploadService   services.FileUploadService
 	AutoPostService     services.AutoPostService
+	WalletService       services.WalletService
+	PostUnlockService   services.PostUnlockService
+	GiftService         services.GiftService
+	SubscriptionService services.SubscriptionService
+	ReelService         services.ReelService
+	AdService           services.AdService
+	TopUpService        services.TopUpService
+	PayoutService       services.PayoutService
+	CommunityService    services.CommunityService
+	ReportService       services.ReportService
+	SessionService      services.SessionService
+	AccountEmailService services.AccountEmailService
+	TwoFactorService    services.TwoFactorService
+	AuthorizationService services.AuthorizationService
+	SanctionService     services.SanctionService
+	AccountDataService  services.AccountDataService
+	APITokenService     services.APITokenService
 }
 
 // Handlers contains all HTTP handlers
@@ -67,12 +84,29 @@ type Handlers struct {
 	CacheHandler           *CacheHandler
 	AutoPostHandler        *AutoPostHandler
 	SimpleAutoPostHandler  *SimpleAutoPostHandler
+	WalletHandler          *WalletHandler
+	PostUnlockHandler      *PostUnlockHandler
+	GiftHandler            *GiftHandler
+	SubscriptionHandler    *SubscriptionHandler
+	ReelHandler            *ReelHandler
+	AdHandler              *AdHandler
+	TopUpHandler           *TopUpHandler
+	PayoutHandler          *PayoutHandler
+	CommunityHandler       *CommunityHandler
+	ReportHandler          *ReportHandler
+	SessionHandler         *SessionHandler
+	AccountEmailHandler    *AccountEmailHandler
+	TwoFactorHandler       *TwoFactorHandler
+	RoleHandler            *RoleHandler
+	SanctionHandler        *SanctionHandler
+	AccountDataHandler     *AccountDataHandler
+	APITokenHandler        *APITokenHandler
 }
 
 // NewHandlers creates a new instance of Handlers with all dependencies
 func NewHandlers(services *Services, cfg *config.Config, chatWSHandler *websocketHandler.ChatWebSocketHandler, notificationWSHandler *websocketHandler.NotificationWebSocketHandler, chatHub *chatWebsocket.ChatHub, notificationHub *chatWebsocket.NotificationHub, conversationRepo repositories.ConversationRepository, mediaUploadService *storage.MediaUploadService, r2Storage storage.R2Storage, mediaRepo repositories.MediaRepository, redisService interface{}, feedCacheService *redis.FeedCacheService, db *gorm.DB) *Handlers {
 	return &Handlers{
-		UserHandler:            NewUserHandler(services.UserService),
+		UserHandler:            NewUserHandler(services.UserService, services.AccountEmailService),
 		ProfileHandler:         NewProfileHandler(services.UserService),
 		TaskHandler:            NewTaskHandler(services.TaskService),
 		FileHandler:            NewFileHandler(services.FileService),
@@ -101,10 +135,27 @@ func NewHandlers(services *Services, cfg *config.Config, chatWSHandler *websocke
 			}
 			return nil
 		}(),
-		WebhookHandler:        NewWebhookHandler(services.MediaService, services.PostService, services.MessageService, redisService.(*redis.RedisService), notificationHub),
+		WebhookHandler:        NewWebhookHandler(services.MediaService, services.PostService, services.MessageService, services.ReelService, services.TopUpService, redisService.(*redis.RedisService), notificationHub),
 		CacheHandler:          NewCacheHandler(feedCacheService),
 		AutoPostHandler:       NewAutoPostHandler(services.AutoPostService),
 		SimpleAutoPostHandler: NewSimpleAutoPostHandler(db),
+		WalletHandler:         NewWalletHandler(services.WalletService),
+		PostUnlockHandler:     NewPostUnlockHandler(services.PostUnlockService),
+		GiftHandler:           NewGiftHandler(services.GiftService, chatHub),
+		SubscriptionHandler:   NewSubscriptionHandler(services.SubscriptionService),
+		ReelHandler:           NewReelHandler(services.ReelService),
+		AdHandler:             NewAdHandler(services.AdService),
+		TopUpHandler:          NewTopUpHandler(services.TopUpService),
+		PayoutHandler:         NewPayoutHandler(services.PayoutService),
+		CommunityHandler:      NewCommunityHandler(services.CommunityService, services.PostService),
+		ReportHandler:         NewReportHandler(services.ReportService),
+		SessionHandler:        NewSessionHandler(services.SessionService),
+		AccountEmailHandler:   NewAccountEmailHandler(services.AccountEmailService),
+		TwoFactorHandler:      NewTwoFactorHandler(services.TwoFactorService),
+		RoleHandler:           NewRoleHandler(services.AuthorizationService),
+		SanctionHandler:       NewSanctionHandler(services.SanctionService),
+		AccountDataHandler:    NewAccountDataHandler(services.AccountDataService),
+		APITokenHandler:       NewAPITokenHandler(services.APITokenService),
 	}
 }
 
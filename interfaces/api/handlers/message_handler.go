@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	apperrors "gofiber-template/pkg/errors"
 	"log"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -19,6 +21,31 @@ import (
 	"gofiber-template/pkg/utils"
 )
 
+// messageErrorResponse maps message edit/unsend/reaction errors to HTTP responses
+func messageErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrMessageNotFound),
+		errors.Is(err, services.ErrMessageReplyNotFound),
+		errors.Is(err, services.ErrConversationNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrMessageNotSender),
+		errors.Is(err, services.ErrConversationNotParticipant):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrMessageNotEditable),
+		errors.Is(err, services.ErrReactionEmojiNotAllowed),
+		errors.Is(err, services.ErrMessageSearchQueryInvalid):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrMessageEditWindowClosed),
+		errors.Is(err, services.ErrMessageUnsendWindowClosed),
+		errors.Is(err, services.ErrConversationRequestPending):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	}
+	if isAccountRestricted(err) {
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
 type MessageHandler struct {
 	messageService     services.MessageService
 	mediaService       services.MediaService
@@ -95,6 +122,9 @@ func (h *MessageHandler) sendTextMessage(c *fiber.Ctx, userID uuid.UUID, convers
 
 	message, err := h.messageService.SendMessage(c.Context(), userID, &req)
 	if err != nil {
+		if isAccountRestricted(err) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		// Log the actual error for debugging
 		log.Printf("[SendMessage] Error for user %s in conversation %s: %v", userID, conversationID, err)
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
@@ -112,6 +142,16 @@ func (h *MessageHandler) sendMediaMessage(c *fiber.Ctx, userID uuid.UUID, conver
 	messageType := c.FormValue("type") // "image", "video", "file"
 	content := c.FormValue("content")  // Optional caption
 
+	// Optional quoted reply
+	var replyToID *uuid.UUID
+	if replyTo := c.FormValue("replyToMessageId"); replyTo != "" {
+		id, err := uuid.Parse(replyTo)
+		if err != nil {
+			return utils.ValidationErrorResponse(c, "Invalid replyToMessageId")
+		}
+		replyToID = &id
+	}
+
 	// 2. Get uploaded files
 	form, err := c.MultipartForm()
 	if err != nil {
@@ -132,10 +172,14 @@ func (h *MessageHandler) sendMediaMessage(c *fiber.Ctx, userID uuid.UUID, conver
 			ConversationID: conversationID,
 			Type:           "text",
 			Content:        &content,
+			ReplyToID:      replyToID,
 		}
 
 		message, err := h.messageService.SendMessage(c.Context(), userID, req)
 		if err != nil {
+			if isAccountRestricted(err) {
+				return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+			}
 			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to send message").WithInternal(err))
 		}
 
@@ -193,10 +237,14 @@ func (h *MessageHandler) sendMediaMessage(c *fiber.Ctx, userID uuid.UUID, conver
 		Type:           messageType,
 		Content:        contentPtr,
 		Media:          mediaItems,
+		ReplyToID:      replyToID,
 	}
 
 	message, err := h.messageService.SendMessage(c.Context(), userID, req)
 	if err != nil {
+		if isAccountRestricted(err) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to send message").WithInternal(err))
 	}
 
@@ -289,6 +337,181 @@ func (h *MessageHandler) GetMessageContext(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, context, "Message context retrieved successfully")
 }
 
+// EditMessage replaces the text of a message the user sent (the previous text is kept as a revision)
+// PATCH /messages/:id
+func (h *MessageHandler) EditMessage(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid message ID")
+	}
+
+	var req dto.EditMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	message, err := h.messageService.EditMessage(c.Context(), messageID, userID, &req)
+	if err != nil {
+		return messageErrorResponse(c, err, "Failed to edit message")
+	}
+
+	if h.chatHub != nil {
+		h.chatHub.SendMessageEdited(c.Context(), userID, message)
+	}
+
+	return utils.SuccessResponse(c, message, "Message edited successfully")
+}
+
+// UnsendMessage removes a message the user sent for everyone in the conversation
+// DELETE /messages/:id
+func (h *MessageHandler) UnsendMessage(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid message ID")
+	}
+
+	result, err := h.messageService.UnsendMessage(c.Context(), messageID, userID)
+	if err != nil {
+		return messageErrorResponse(c, err, "Failed to unsend message")
+	}
+
+	if h.chatHub != nil {
+		h.chatHub.SendMessageUnsent(c.Context(), userID, result)
+	}
+
+	return utils.SuccessResponse(c, result, "Message unsent successfully")
+}
+
+// GetMessageRevisions retrieves the edit history of a message
+// GET /messages/:id/revisions
+func (h *MessageHandler) GetMessageRevisions(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid message ID")
+	}
+
+	revisions, err := h.messageService.ListMessageRevisions(c.Context(), messageID, userID)
+	if err != nil {
+		return messageErrorResponse(c, err, "Failed to retrieve message revisions")
+	}
+
+	return utils.SuccessResponse(c, revisions, "Message revisions retrieved successfully")
+}
+
+// GetMessageReceipts lists who received and read a message (sender only)
+// GET /messages/:id/receipts
+func (h *MessageHandler) GetMessageReceipts(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid message ID")
+	}
+
+	receipts, err := h.messageService.GetMessageReceipts(c.Context(), messageID, userID)
+	if err != nil {
+		return messageErrorResponse(c, err, "Failed to retrieve message receipts")
+	}
+
+	return utils.SuccessResponse(c, receipts, "Message receipts retrieved successfully")
+}
+
+// GetQuotedMessageContext jumps from a reply to the message it quotes, with surrounding context
+// GET /messages/:id/quoted/context
+func (h *MessageHandler) GetQuotedMessageContext(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid message ID")
+	}
+
+	context, err := h.messageService.GetQuotedMessageContext(c.Context(), messageID, userID)
+	if err != nil {
+		return messageErrorResponse(c, err, "Failed to retrieve quoted message")
+	}
+
+	return utils.SuccessResponse(c, context, "Quoted message context retrieved successfully")
+}
+
+// AddReaction reacts to a message with an emoji
+// POST /messages/:id/reactions
+func (h *MessageHandler) AddReaction(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid message ID")
+	}
+
+	var req dto.AddReactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	reactions, err := h.messageService.AddReaction(c.Context(), messageID, userID, req.Emoji)
+	if err != nil {
+		return messageErrorResponse(c, err, "Failed to add reaction")
+	}
+
+	if h.chatHub != nil {
+		h.chatHub.SendReactionUpdate(c.Context(), userID, reactions)
+	}
+
+	return utils.SuccessResponse(c, reactions, "Reaction added successfully")
+}
+
+// RemoveReaction removes the user's emoji reaction from a message
+// DELETE /messages/:id/reactions/:emoji (URL-encoded emoji)
+func (h *MessageHandler) RemoveReaction(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	messageID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid message ID")
+	}
+
+	emoji, err := url.PathUnescape(c.Params("emoji"))
+	if err != nil || emoji == "" {
+		return utils.ValidationErrorResponse(c, "Invalid emoji")
+	}
+
+	reactions, err := h.messageService.RemoveReaction(c.Context(), messageID, userID, emoji)
+	if err != nil {
+		return messageErrorResponse(c, err, "Failed to remove reaction")
+	}
+
+	if h.chatHub != nil {
+		h.chatHub.SendReactionUpdate(c.Context(), userID, reactions)
+	}
+
+	return utils.SuccessResponse(c, reactions, "Reaction removed successfully")
+}
+
 // Note: MarkAsRead is handled by ConversationHandler
 // POST /conversations/:conversationId/read
 
@@ -335,6 +558,56 @@ func (h *MessageHandler) GetConversationMedia(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, messages, "Media messages retrieved successfully")
 }
 
+// SearchConversationMessages searches the text of the messages in a conversation
+// GET /conversations/:conversationId/search?q=xxx&cursor=xxx&limit=20
+func (h *MessageHandler) SearchConversationMessages(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	conversationID, err := uuid.Parse(c.Params("conversationId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid conversation ID")
+	}
+
+	cursor, limit := searchPageParams(c)
+
+	results, err := h.messageService.SearchConversationMessages(c.Context(), conversationID, userID, c.Query("q"), cursor, limit)
+	if err != nil {
+		return messageErrorResponse(c, err, "Failed to search messages")
+	}
+
+	return utils.SuccessResponse(c, results, "Messages searched successfully")
+}
+
+// SearchMessages searches the text of the messages in all of the user's conversations
+// GET /messages/search?q=xxx&cursor=xxx&limit=20
+func (h *MessageHandler) SearchMessages(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	cursor, limit := searchPageParams(c)
+
+	results, err := h.messageService.SearchMessages(c.Context(), userID, c.Query("q"), cursor, limit)
+	if err != nil {
+		return messageErrorResponse(c, err, "Failed to search messages")
+	}
+
+	return utils.SuccessResponse(c, results, "Messages searched successfully")
+}
+
+// searchPageParams reads the cursor and limit (default: 20, max: 50) of a search
+func searchPageParams(c *fiber.Ctx) (*string, int) {
+	var cursorPtr *string
+	if cursor := c.Query("cursor"); cursor != "" {
+		cursorPtr = &cursor
+	}
+
+	limit := 20
+	if parsedLimit, err := strconv.Atoi(c.Query("limit")); err == nil && parsedLimit > 0 && parsedLimit <= 50 {
+		limit = parsedLimit
+	}
+
+	return cursorPtr, limit
+}
+
 // GetConversationLinks retrieves messages containing links in a conversation
 // GET /conversations/:conversationId/links?cursor=xxx&limit=50
 func (h *MessageHandler) GetConversationLinks(c *fiber.Ctx) error {
@@ -550,22 +823,15 @@ func (h *MessageHandler) uploadFile(ctx context.Context, userID uuid.UUID, file
 	}
 }
 
-// sendWebSocketNotification sends WebSocket notification to receiver
+// sendWebSocketNotification sends WebSocket notification to the other participants
 func (h *MessageHandler) sendWebSocketNotification(message *dto.MessageResponse) {
 	if h.chatHub == nil {
 		log.Printf("⚠️ ChatHub is nil, skipping WebSocket notification")
 		return
 	}
 
-	receiverID := message.Receiver.ID
-
-	// Send new message notification to receiver (message.new)
-	h.chatHub.SendToUser(receiverID, &chatWebsocket.ChatMessage{
-		Type: "message.new",
-		Payload: map[string]interface{}{
-			"message": message,
-		},
-	})
+	// Send new message notification to the other participants (message.new)
+	h.chatHub.DeliverMessage(message.SenderId, message)
 
-	log.Printf("📤 WebSocket notification sent to receiver: %s (message: %s)", receiverID, message.ID)
+	log.Printf("📤 WebSocket notification sent for message %s (conversation: %s)", message.ID, message.ConversationID)
 }
@@ -1,14 +1,16 @@
 package handlers
 
 import (
-	"fmt"
-	apperrors "gofiber-template/pkg/errors"
+	"errors"
+	"net/url"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/services"
 	"gofiber-template/pkg/auth_code_store"
 	"gofiber-template/pkg/config"
+	apperrors "gofiber-template/pkg/errors"
 	"gofiber-template/pkg/utils"
 )
 
@@ -24,19 +26,79 @@ func NewOAuthHandler(oauthService services.OAuthService, cfg *config.Config) *OA
 	}
 }
 
-// GetGoogleAuthURL generates Google OAuth authorization URL
-// @Summary Get Google OAuth URL
-// @Description Get Google OAuth authorization URL to start OAuth flow
+// oauthErrorResponse maps OAuth service errors to HTTP responses
+func oauthErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrOAuthProviderNotFound),
+		errors.Is(err, services.ErrIdentityNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrOAuthEmailInUse),
+		errors.Is(err, services.ErrIdentityLinkedElsewhere),
+		errors.Is(err, services.ErrIdentityAlreadyLinked),
+		errors.Is(err, services.ErrLastLoginMethod):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrOAuthInvalidState),
+		errors.Is(err, services.ErrOAuthEmailRequired):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	case isAccountRestricted(err):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(fallback).WithInternal(err))
+}
+
+// ListProviders lists the login providers enabled on this server
+// @Summary List login providers
+// @Tags OAuth
+// @Produce json
+// @Success 200 {array} dto.OAuthProviderResponse
+// @Router /auth/providers [get]
+func (h *OAuthHandler) ListProviders(c *fiber.Ctx) error {
+	return utils.SuccessResponse(c, h.oauthService.ListProviders(), "Login providers retrieved successfully")
+}
+
+// GetAuthURL generates the authorization URL of a provider
+// @Summary Get OAuth URL
+// @Description Get the provider authorization URL to start the OAuth flow (PKCE)
 // @Tags OAuth
 // @Accept json
 // @Produce json
+// @Param provider path string true "Provider name (google, line, facebook or a configured OIDC issuer)"
 // @Success 200 {object} dto.OAuthURLResponse
+// @Router /auth/oauth/{provider} [get]
+func (h *OAuthHandler) GetAuthURL(c *fiber.Ctx) error {
+	return h.startAuth(c, c.Params("provider"), nil)
+}
+
+// GetGoogleAuthURL keeps the original Google endpoint working
 // @Router /auth/google [get]
 func (h *OAuthHandler) GetGoogleAuthURL(c *fiber.Ctx) error {
-	// Generate random state for CSRF protection
-	state := utils.GenerateRandomString(32)
+	return h.startAuth(c, "google", nil)
+}
+
+// GetLinkURL generates the authorization URL that links a provider to the current user
+// @Summary Link a login provider
+// @Tags OAuth
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Provider name"
+// @Success 200 {object} dto.OAuthURLResponse
+// @Router /auth/oauth/{provider}/link [get]
+func (h *OAuthHandler) GetLinkURL(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	return h.startAuth(c, c.Params("provider"), &user.ID)
+}
+
+func (h *OAuthHandler) startAuth(c *fiber.Ctx, provider string, linkUserID *uuid.UUID) error {
+	authURL, state, err := h.oauthService.GetAuthURL(c.Context(), provider, linkUserID)
+	if err != nil {
+		return oauthErrorResponse(c, err, "Failed to start login")
+	}
 
-	// Store state in session or cookie for validation in callback
+	// Store state in a cookie as well (the service keeps the PKCE verifier and nonce)
 	c.Cookie(&fiber.Cookie{
 		Name:     "oauth_state",
 		Value:    state,
@@ -46,102 +108,110 @@ func (h *OAuthHandler) GetGoogleAuthURL(c *fiber.Ctx) error {
 		Path:     "/",
 	})
 
-	url := h.oauthService.GetGoogleAuthURL(state)
-
 	return utils.SuccessResponse(c, dto.OAuthURLResponse{
-		URL: url,
-	}, "Google OAuth URL generated")
+		URL: authURL,
+	}, "OAuth URL generated")
 }
 
-// GoogleCallback handles Google OAuth callback
-// @Summary Handle Google OAuth Callback
-// @Description Process Google OAuth callback and login/register user
+// Callback handles the provider redirect
+// Logins continue on the frontend with a one-time code, links go back to the account settings
+// @Summary Handle OAuth Callback
+// @Description Process the provider callback and login/register or link the identity
 // @Tags OAuth
 // @Accept json
 // @Produce json
-// @Param code query string true "Authorization code from Google"
+// @Param provider path string true "Provider name"
+// @Param code query string true "Authorization code from the provider"
 // @Param state query string true "State parameter for CSRF protection"
-// @Success 200 {object} dto.OAuthLoginResponse
 // @Failure 400 {object} map[string]interface{}
+// @Router /auth/oauth/{provider}/callback [get]
+func (h *OAuthHandler) Callback(c *fiber.Ctx) error {
+	return h.handleCallback(c, c.Params("provider"))
+}
+
+// GoogleCallback keeps the original Google redirect URL working
 // @Router /auth/google/callback [get]
 func (h *OAuthHandler) GoogleCallback(c *fiber.Ctx) error {
+	return h.handleCallback(c, "google")
+}
+
+func (h *OAuthHandler) handleCallback(c *fiber.Ctx, provider string) error {
 	code := c.Query("code")
 	state := c.Query("state")
 
-	// Debug logging
-	fmt.Printf("\n=== Google OAuth Callback Debug ===\n")
-	fmt.Printf("Received code: %s\n", code)
-	fmt.Printf("Code length: %d\n", len(code))
-	fmt.Printf("Received state: %s\n", state)
-	fmt.Printf("Redirect URL from config: %s\n", h.config.OAuth.Google.RedirectURL)
-	fmt.Printf("===================================\n\n")
-
 	if code == "" {
-		// Redirect to frontend with error if redirect URL is provided
-		redirectURL := c.Query("redirect_url")
-		if redirectURL != "" {
-			return c.Redirect(redirectURL + "?error=missing_code")
-		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Authorization code is required"))
 	}
 
-	// Validate state parameter for CSRF protection
-	storedState := c.Cookies("oauth_state")
-
-	// Debug logging
-	c.Locals("debug_received_state", state)
-	c.Locals("debug_stored_state", storedState)
-
-	// Skip state validation if cookie is not present (direct Google redirect)
-	// In production, this should be strict
-	if storedState == "" {
-		// No cookie found - user likely came directly from Google without calling /auth/google first
-		// For now, we'll allow this in development
-		c.Locals("debug_state_validation", "skipped_no_cookie")
-	} else if storedState != state {
-		// Cookie exists but doesn't match
-		redirectURL := c.Query("redirect_url")
-		if redirectURL != "" {
-			return c.Redirect(redirectURL + "?error=invalid_state")
+	// The cookie may be missing when the browser drops it on the cross-site redirect;
+	// the single-use state stored by the service is the actual check
+	if storedState := c.Cookies("oauth_state"); storedState != "" {
+		if storedState != state {
+			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Invalid state parameter"))
 		}
-		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Invalid state parameter"))
-	} else {
-		// Clear state cookie only if validation passed
 		c.ClearCookie("oauth_state")
-		c.Locals("debug_state_validation", "passed")
 	}
 
-	// Handle OAuth callback
-	response, err := h.oauthService.HandleGoogleCallback(c.Context(), code)
+	result, err := h.oauthService.HandleCallback(c.Context(), provider, code, state, sessionClient(c))
 	if err != nil {
-		// Redirect to frontend with error if redirect URL is provided
-		redirectURL := c.Query("redirect_url")
-		if redirectURL != "" {
-			return c.Redirect(redirectURL + "?error=oauth_failed")
-		}
-		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("OAuth authentication failed").WithInternal(err))
+		return oauthErrorResponse(c, err, "OAuth authentication failed")
+	}
+
+	if result.Linked != nil {
+		return c.Redirect(h.config.App.FrontendURL + "/settings/accounts?linked=" + url.QueryEscape(result.Linked.Provider))
 	}
 
 	// Generate authorization code
 	store := auth_code_store.GetInstance()
-	authCode, err := store.GenerateCode(response.Token, response.User, response.IsNewUser, state)
+	authCode, err := store.GenerateCode(result.Login.AuthTokens, result.Login.User, result.Login.IsNewUser, result.Login.MFA, state)
 	if err != nil {
-		redirectURL := c.Query("redirect_url")
-		if redirectURL != "" {
-			return c.Redirect(redirectURL + "?error=code_generation_failed")
-		}
 		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to generate authorization code").WithInternal(err))
 	}
 
-	// Get redirect URL from query or use default frontend URL
-	redirectURL := c.Query("redirect_url")
-	if redirectURL == "" {
-		// Use default frontend URL from config
-		redirectURL = h.config.App.FrontendURL + "/auth/callback"
+	// Redirect to frontend with authorization code and state
+	return c.Redirect(h.config.App.FrontendURL + "/auth/callback?code=" + url.QueryEscape(authCode) + "&state=" + url.QueryEscape(state))
+}
+
+// ListIdentities lists the login providers linked to the current user
+// @Summary List linked logins
+// @Tags OAuth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.UserIdentityListResponse
+// @Router /auth/identities [get]
+func (h *OAuthHandler) ListIdentities(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
 	}
 
-	// Redirect to frontend with authorization code and state
-	return c.Redirect(redirectURL + "?code=" + authCode + "&state=" + state)
+	identities, err := h.oauthService.ListIdentities(c.Context(), user.ID)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve linked logins").WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, identities, "Linked logins retrieved successfully")
+}
+
+// UnlinkIdentity removes a linked login provider (the last one is kept when the account has no password)
+// @Summary Unlink a login provider
+// @Tags OAuth
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Provider name"
+// @Success 200 {object} map[string]interface{}
+// @Router /auth/identities/{provider} [delete]
+func (h *OAuthHandler) UnlinkIdentity(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	if err := h.oauthService.UnlinkIdentity(c.Context(), user.ID, c.Params("provider")); err != nil {
+		return oauthErrorResponse(c, err, "Failed to unlink login provider")
+	}
+
+	return utils.SuccessResponse(c, nil, "Login provider unlinked successfully")
 }
 
 // ExchangeCodeForToken exchanges authorization code for JWT token
@@ -176,11 +246,16 @@ func (h *OAuthHandler) ExchangeCodeForToken(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Invalid or expired authorization code"))
 	}
 
+	// Two-factor accounts get a login challenge, finished with POST /auth/2fa/verify
+	if data.MFA != nil {
+		return utils.SuccessResponse(c, data.MFA, "Two-factor code required")
+	}
+
 	// Return token and user info
 	return utils.SuccessResponse(c, dto.ExchangeCodeResponse{
-		Token:     data.Token,
-		IsNewUser: data.IsNewUser,
-		User:      data.User,
+		AuthTokens: data.Tokens,
+		IsNewUser:  data.IsNewUser,
+		User:       data.User,
 	}, "Token exchanged successfully")
 }
 
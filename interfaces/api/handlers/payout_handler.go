@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type PayoutHandler struct {
+	payoutService services.PayoutService
+}
+
+func NewPayoutHandler(payoutService services.PayoutService) *PayoutHandler {
+	return &PayoutHandler{
+		payoutService: payoutService,
+	}
+}
+
+// payoutErrorResponse maps payout service errors to HTTP responses
+func payoutErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrPayoutNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrTwoFactorRequired):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrPayoutAlreadyPending),
+		errors.Is(err, services.ErrPayoutInvalidStatus):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrPayoutBelowMinimum),
+		errors.Is(err, services.ErrPayoutExceedsEarnings),
+		errors.Is(err, services.ErrPayoutInvalidAccount),
+		errors.Is(err, services.ErrInsufficientBalance):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// ==================== Creator ====================
+
+// GetPayoutSummary retrieves the withdrawable balance of the current user
+// GET /payouts/summary
+func (h *PayoutHandler) GetPayoutSummary(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	summary, err := h.payoutService.GetPayoutSummary(c.Context(), userID)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to retrieve payout summary")
+	}
+
+	return utils.SuccessResponse(c, summary, "Payout summary retrieved successfully")
+}
+
+// RequestPayout requests a withdrawal to a bank account or PromptPay ID
+// POST /payouts
+func (h *PayoutHandler) RequestPayout(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.RequestPayoutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	payout, err := h.payoutService.RequestPayout(c.Context(), userID, &req)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to request payout")
+	}
+
+	return utils.SuccessResponse(c, payout, "Payout requested successfully")
+}
+
+// ListMyPayouts retrieves the current user's payouts
+// GET /payouts?offset=0&limit=20
+func (h *PayoutHandler) ListMyPayouts(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	payouts, err := h.payoutService.ListMyPayouts(c.Context(), userID, offset, limit)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to retrieve payouts")
+	}
+
+	return utils.SuccessResponse(c, payouts, "Payouts retrieved successfully")
+}
+
+// GetMyPayout retrieves one of the current user's payouts
+// GET /payouts/:id
+func (h *PayoutHandler) GetMyPayout(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	payoutID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid payout ID")
+	}
+
+	payout, err := h.payoutService.GetMyPayout(c.Context(), payoutID, userID)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to retrieve payout")
+	}
+
+	return utils.SuccessResponse(c, payout, "Payout retrieved successfully")
+}
+
+// CancelPayout cancels a pending payout and releases the held amount
+// POST /payouts/:id/cancel
+func (h *PayoutHandler) CancelPayout(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	payoutID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid payout ID")
+	}
+
+	payout, err := h.payoutService.CancelPayout(c.Context(), payoutID, userID)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to cancel payout")
+	}
+
+	return utils.SuccessResponse(c, payout, "Payout cancelled successfully")
+}
+
+// ==================== Admin ====================
+
+// ListPayouts lists payouts by status (pending = approval queue)
+// GET /payouts/admin/requests?status=pending&offset=0&limit=20
+func (h *PayoutHandler) ListPayouts(c *fiber.Ctx) error {
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	payouts, err := h.payoutService.ListPayouts(c.Context(), c.Query("status"), offset, limit)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to retrieve payouts")
+	}
+
+	return utils.SuccessResponse(c, payouts, "Payouts retrieved successfully")
+}
+
+// ApprovePayout debits the held amount, the payout then shows up in the CSV export
+// POST /payouts/admin/:id/approve
+func (h *PayoutHandler) ApprovePayout(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	payoutID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid payout ID")
+	}
+
+	payout, err := h.payoutService.ApprovePayout(c.Context(), payoutID, adminID)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to approve payout")
+	}
+
+	return utils.SuccessResponse(c, payout, "Payout approved successfully")
+}
+
+// RejectPayout rejects a pending payout and releases the held amount
+// POST /payouts/admin/:id/reject
+func (h *PayoutHandler) RejectPayout(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	payoutID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid payout ID")
+	}
+
+	var req dto.RejectPayoutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	payout, err := h.payoutService.RejectPayout(c.Context(), payoutID, adminID, req.Reason)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to reject payout")
+	}
+
+	return utils.SuccessResponse(c, payout, "Payout rejected successfully")
+}
+
+// FailPayout reports a bounced bank transfer and refunds the creator
+// POST /payouts/admin/:id/fail
+func (h *PayoutHandler) FailPayout(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	payoutID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid payout ID")
+	}
+
+	var req dto.FailPayoutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	payout, err := h.payoutService.FailPayout(c.Context(), payoutID, adminID, req.Reason)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to mark payout as failed")
+	}
+
+	return utils.SuccessResponse(c, payout, "Payout marked as failed")
+}
+
+// MarkPayoutsPaid confirms the bank batch transfer of approved payouts
+// POST /payouts/admin/mark-paid
+func (h *PayoutHandler) MarkPayoutsPaid(c *fiber.Ctx) error {
+	adminID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.MarkPayoutsPaidRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	result, err := h.payoutService.MarkPayoutsPaid(c.Context(), adminID, &req)
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to mark payouts as paid")
+	}
+
+	return utils.SuccessResponse(c, result, "Payouts marked as paid")
+}
+
+// ExportApprovedPayouts downloads approved payouts as CSV for the bank batch transfer
+// GET /payouts/admin/export
+func (h *PayoutHandler) ExportApprovedPayouts(c *fiber.Ctx) error {
+	data, err := h.payoutService.ExportApprovedCSV(c.Context())
+	if err != nil {
+		return payoutErrorResponse(c, err, "Failed to export payouts")
+	}
+
+	filename := fmt.Sprintf("payouts-%s.csv", time.Now().Format("20060102-150405"))
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	return c.Send(data)
+}
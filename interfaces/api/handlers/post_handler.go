@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"strconv"
 
@@ -54,6 +55,9 @@ func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 
 	post, err := h.postService.CreatePost(c.Context(), userID, &req)
 	if err != nil {
+		if isAccountRestricted(err) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to create post").WithInternal(err))
 	}
 
@@ -129,6 +133,9 @@ func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 
 	post, err := h.postService.UpdatePost(c.Context(), postID, userID, &req)
 	if err != nil {
+		if isAccountRestricted(err) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to update post").WithInternal(err))
 	}
 
@@ -271,6 +278,9 @@ func (h *PostHandler) ListPostsByAuthor(c *fiber.Ctx) error {
 		// Use cursor-based pagination
 		posts, err := h.postService.ListPostsByAuthorWithCursor(c.Context(), authorID, cursor, limit, userIDPtr)
 		if err != nil {
+			if errors.Is(err, services.ErrPrivateAccount) {
+				return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+			}
 			return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve posts").WithInternal(err))
 		}
 		return utils.SuccessResponse(c, posts, "Posts retrieved successfully")
@@ -281,6 +291,9 @@ func (h *PostHandler) ListPostsByAuthor(c *fiber.Ctx) error {
 	log.Printf("⚠️  Using deprecated offset-based pagination for author posts. Please migrate to cursor-based pagination.")
 	posts, err := h.postService.ListPostsByAuthor(c.Context(), authorID, offset, limit, userIDPtr)
 	if err != nil {
+		if errors.Is(err, services.ErrPrivateAccount) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve posts").WithInternal(err))
 	}
 
@@ -442,6 +455,9 @@ func (h *PostHandler) CreateCrosspost(c *fiber.Ctx) error {
 
 	post, err := h.postService.CreateCrosspost(c.Context(), userID, sourcePostID, &req)
 	if err != nil {
+		if isAccountRestricted(err) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to create crosspost").WithInternal(err))
 	}
 
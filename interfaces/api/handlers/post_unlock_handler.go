@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type PostUnlockHandler struct {
+	postUnlockService services.PostUnlockService
+}
+
+func NewPostUnlockHandler(postUnlockService services.PostUnlockService) *PostUnlockHandler {
+	return &PostUnlockHandler{
+		postUnlockService: postUnlockService,
+	}
+}
+
+// Contribute chips in towards unlocking a premium post
+// POST /posts/:id/unlock/contributions
+func (h *PostUnlockHandler) Contribute(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	postID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid post ID")
+	}
+
+	var req dto.ContributeUnlockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	result, err := h.postUnlockService.Contribute(c.Context(), postID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInsufficientBalance):
+			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Insufficient wallet balance").WithInternal(err))
+		case errors.Is(err, services.ErrPostNotPremium),
+			errors.Is(err, services.ErrPostUnlockClosed),
+			errors.Is(err, services.ErrUnlockContributionLimit),
+			errors.Is(err, services.ErrCannotContributeOwnPost):
+			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+		}
+		return utils.ErrorResponse(c, apperrors.ErrPostNotFound.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, result, "Contribution added successfully")
+}
+
+// GetUnlockStatus retrieves the unlock progress of a premium post
+// GET /posts/:id/unlock
+func (h *PostUnlockHandler) GetUnlockStatus(c *fiber.Ctx) error {
+	postID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid post ID")
+	}
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	status, err := h.postUnlockService.GetUnlockStatus(c.Context(), postID, userIDPtr)
+	if err != nil {
+		if errors.Is(err, services.ErrPostNotPremium) {
+			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()))
+		}
+		return utils.ErrorResponse(c, apperrors.ErrPostNotFound.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, status, "Unlock status retrieved successfully")
+}
+
+// ListContributors lists contributors of a premium post (largest first)
+// GET /posts/:id/unlock/contributors?offset=0&limit=20
+func (h *PostUnlockHandler) ListContributors(c *fiber.Ctx) error {
+	postID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid post ID")
+	}
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	contributors, err := h.postUnlockService.ListContributors(c.Context(), postID, offset, limit)
+	if err != nil {
+		if errors.Is(err, services.ErrPostNotPremium) {
+			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()))
+		}
+		return utils.ErrorResponse(c, apperrors.ErrPostNotFound.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, contributors, "Contributors retrieved successfully")
+}
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type ReelHandler struct {
+	reelService services.ReelService
+}
+
+func NewReelHandler(reelService services.ReelService) *ReelHandler {
+	return &ReelHandler{
+		reelService: reelService,
+	}
+}
+
+// reelErrorResponse maps reel service errors to HTTP responses
+func reelErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrReelNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage("Reel not found").WithInternal(err))
+	case errors.Is(err, services.ErrReelDailyLimit),
+		errors.Is(err, services.ErrReelNotViewable):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(fallback).WithInternal(err))
+}
+
+// CreateReel uploads a vertical video as a 24-hour reel
+// POST /reels (multipart: video, caption, visibility)
+func (h *ReelHandler) CreateReel(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateReelRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	// Get file from form
+	fileHeader, err := c.FormFile("video")
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Video file is required")
+	}
+
+	// Validate file type
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType != "video/mp4" && contentType != "video/webm" && contentType != "video/quicktime" {
+		return utils.ValidationErrorResponse(c, "Invalid video type. Supported: mp4, webm, mov")
+	}
+
+	// Validate file size (100MB max)
+	if fileHeader.Size > 100*1024*1024 {
+		return utils.ValidationErrorResponse(c, "Video size must be less than 100MB")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrFileUpload.WithInternal(err))
+	}
+	defer file.Close()
+
+	reel, err := h.reelService.CreateReel(c.Context(), userID, &req, file, fileHeader.Filename)
+	if err != nil {
+		return reelErrorResponse(c, err, "Failed to create reel")
+	}
+
+	return utils.SuccessResponse(c, reel, "Reel created successfully")
+}
+
+// ListFollowingReels lists reels from followed users grouped by creator
+// GET /reels/following
+func (h *ReelHandler) ListFollowingReels(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	feed, err := h.reelService.ListFollowingReels(c.Context(), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, feed, "Reels retrieved successfully")
+}
+
+// ListMyReels lists the current user's reels that are not expired yet
+// GET /reels/me
+func (h *ReelHandler) ListMyReels(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	reels, err := h.reelService.ListMyReels(c.Context(), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, reels, "Reels retrieved successfully")
+}
+
+// ListUserReels lists the active reels of a user
+// GET /reels/user/:userId
+func (h *ReelHandler) ListUserReels(c *fiber.Ctx) error {
+	creatorID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	reels, err := h.reelService.ListUserReels(c.Context(), creatorID, userIDPtr)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, reels, "Reels retrieved successfully")
+}
+
+// GetReel retrieves a single reel
+// GET /reels/:id
+func (h *ReelHandler) GetReel(c *fiber.Ctx) error {
+	reelID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid reel ID")
+	}
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	reel, err := h.reelService.GetReel(c.Context(), reelID, userIDPtr)
+	if err != nil {
+		return reelErrorResponse(c, err, "Failed to get reel")
+	}
+
+	return utils.SuccessResponse(c, reel, "Reel retrieved successfully")
+}
+
+// RecordView records that the current user watched a reel
+// POST /reels/:id/view
+func (h *ReelHandler) RecordView(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	reelID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid reel ID")
+	}
+
+	var req dto.RecordReelViewRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ValidationErrorResponse(c, "Invalid request body")
+		}
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	reel, err := h.reelService.RecordView(c.Context(), reelID, userID, &req)
+	if err != nil {
+		return reelErrorResponse(c, err, "Failed to record view")
+	}
+
+	return utils.SuccessResponse(c, reel, "View recorded successfully")
+}
+
+// ListViewers lists the viewers of the current user's reel
+// GET /reels/:id/viewers?offset=0&limit=20
+func (h *ReelHandler) ListViewers(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	reelID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid reel ID")
+	}
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	viewers, err := h.reelService.ListViewers(c.Context(), reelID, userID, offset, limit)
+	if err != nil {
+		return reelErrorResponse(c, err, "Failed to get reel viewers")
+	}
+
+	return utils.SuccessResponse(c, viewers, "Reel viewers retrieved successfully")
+}
+
+// DeleteReel deletes the current user's reel and its video
+// DELETE /reels/:id
+func (h *ReelHandler) DeleteReel(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	reelID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid reel ID")
+	}
+
+	if err := h.reelService.DeleteReel(c.Context(), reelID, userID); err != nil {
+		return reelErrorResponse(c, err, "Failed to delete reel")
+	}
+
+	return utils.SuccessResponse(c, nil, "Reel deleted successfully")
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type ReportHandler struct {
+	reportService services.ReportService
+}
+
+func NewReportHandler(reportService services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// reportErrorResponse maps report service errors to HTTP responses
+func reportErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrReportNotFound),
+		errors.Is(err, services.ErrReportTargetNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrReportDuplicate),
+		errors.Is(err, services.ErrReportClosed):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrReportOwnContent),
+		errors.Is(err, services.ErrReportInvalidAction):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// ==================== Reporter ====================
+
+// CreateReport flags a post, comment, message or user for moderator review
+// POST /reports
+func (h *ReportHandler) CreateReport(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	report, err := h.reportService.CreateReport(c.Context(), userID, &req)
+	if err != nil {
+		return reportErrorResponse(c, err, "Failed to submit report")
+	}
+
+	return utils.SuccessResponse(c, report, "Report submitted successfully")
+}
+
+// ListMyReports retrieves the current user's reports and their outcome
+// GET /reports/me?offset=0&limit=20
+func (h *ReportHandler) ListMyReports(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	reports, err := h.reportService.ListMyReports(c.Context(), userID, offset, limit)
+	if err != nil {
+		return reportErrorResponse(c, err, "Failed to retrieve reports")
+	}
+
+	return utils.SuccessResponse(c, reports, "Reports retrieved successfully")
+}
+
+// ==================== Moderator ====================
+
+// ListReports lists reports by status (open = moderation queue, oldest first)
+// GET /reports/admin?status=open&targetType=post&offset=0&limit=20
+func (h *ReportHandler) ListReports(c *fiber.Ctx) error {
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	reports, err := h.reportService.ListReports(c.Context(), c.Query("status"), c.Query("targetType"), offset, limit)
+	if err != nil {
+		return reportErrorResponse(c, err, "Failed to retrieve reports")
+	}
+
+	return utils.SuccessResponse(c, reports, "Reports retrieved successfully")
+}
+
+// GetReport retrieves a report with the content snapshot
+// GET /reports/admin/:id
+func (h *ReportHandler) GetReport(c *fiber.Ctx) error {
+	reportID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid report ID")
+	}
+
+	report, err := h.reportService.GetReport(c.Context(), reportID)
+	if err != nil {
+		return reportErrorResponse(c, err, "Failed to retrieve report")
+	}
+
+	return utils.SuccessResponse(c, report, "Report retrieved successfully")
+}
+
+// ResolveReport dismisses a report or acts on its target (remove content, warn, suspend)
+// POST /reports/admin/:id/resolve
+func (h *ReportHandler) ResolveReport(c *fiber.Ctx) error {
+	moderatorID := c.Locals("userID").(uuid.UUID)
+
+	reportID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid report ID")
+	}
+
+	var req dto.ResolveReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	report, err := h.reportService.ResolveReport(c.Context(), reportID, moderatorID, &req)
+	if err != nil {
+		return reportErrorResponse(c, err, "Failed to resolve report")
+	}
+
+	return utils.SuccessResponse(c, report, "Report resolved successfully")
+}
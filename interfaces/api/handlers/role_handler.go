@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type RoleHandler struct {
+	authzService services.AuthorizationService
+}
+
+func NewRoleHandler(authzService services.AuthorizationService) *RoleHandler {
+	return &RoleHandler{
+		authzService: authzService,
+	}
+}
+
+// roleErrorResponse maps authorization service errors to HTTP responses
+func roleErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrRoleNotFound),
+		errors.Is(err, services.ErrRoleUserNotFound),
+		errors.Is(err, services.ErrCommunityNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrRoleAlreadyGranted),
+		errors.Is(err, services.ErrRoleNotGranted):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrRoleScopeMismatch):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrRoleSelfChange):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// GetMyPermissions returns the roles and effective permissions of the current user
+// GET /roles/me
+func (h *RoleHandler) GetMyPermissions(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	permissions, err := h.authzService.GetMyPermissions(c.Context(), userID)
+	if err != nil {
+		return roleErrorResponse(c, err, "Failed to retrieve permissions")
+	}
+
+	return utils.SuccessResponse(c, permissions, "Permissions retrieved successfully")
+}
+
+// ==================== Admin ====================
+
+// ListRoles lists every role with its permissions
+// GET /roles
+func (h *RoleHandler) ListRoles(c *fiber.Ctx) error {
+	roles, err := h.authzService.ListRoles(c.Context())
+	if err != nil {
+		return roleErrorResponse(c, err, "Failed to retrieve roles")
+	}
+
+	return utils.SuccessResponse(c, roles, "Roles retrieved successfully")
+}
+
+// ListPermissions lists every permission roles can grant
+// GET /roles/permissions
+func (h *RoleHandler) ListPermissions(c *fiber.Ctx) error {
+	permissions, err := h.authzService.ListPermissions(c.Context())
+	if err != nil {
+		return roleErrorResponse(c, err, "Failed to retrieve permissions")
+	}
+
+	return utils.SuccessResponse(c, permissions, "Permissions retrieved successfully")
+}
+
+// ListUserRoles lists the roles granted to a user
+// GET /roles/users/:userId
+func (h *RoleHandler) ListUserRoles(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	roles, err := h.authzService.ListUserRoles(c.Context(), userID)
+	if err != nil {
+		return roleErrorResponse(c, err, "Failed to retrieve user roles")
+	}
+
+	return utils.SuccessResponse(c, roles, "User roles retrieved successfully")
+}
+
+// AssignRole grants a role to a user (communityId for community roles)
+// POST /roles/users/:userId
+func (h *RoleHandler) AssignRole(c *fiber.Ctx) error {
+	actorID := c.Locals("userID").(uuid.UUID)
+
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	userRole, err := h.authzService.AssignRole(c.Context(), actorID, userID, &req)
+	if err != nil {
+		return roleErrorResponse(c, err, "Failed to assign role")
+	}
+
+	return utils.SuccessResponse(c, userRole, "Role assigned successfully")
+}
+
+// RevokeRole removes a role from a user (?communityId= for community roles)
+// DELETE /roles/users/:userId/:role
+func (h *RoleHandler) RevokeRole(c *fiber.Ctx) error {
+	actorID := c.Locals("userID").(uuid.UUID)
+
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var communityID *uuid.UUID
+	if raw := c.Query("communityId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return utils.ValidationErrorResponse(c, "Invalid community ID")
+		}
+		communityID = &id
+	}
+
+	if err := h.authzService.RevokeRole(c.Context(), actorID, userID, c.Params("role"), communityID); err != nil {
+		return roleErrorResponse(c, err, "Failed to revoke role")
+	}
+
+	return utils.SuccessResponse(c, nil, "Role revoked successfully")
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type SanctionHandler struct {
+	sanctionService services.SanctionService
+}
+
+func NewSanctionHandler(sanctionService services.SanctionService) *SanctionHandler {
+	return &SanctionHandler{
+		sanctionService: sanctionService,
+	}
+}
+
+// isAccountRestricted reports the error write paths return for suspended and banned accounts
+func isAccountRestricted(err error) bool {
+	return errors.Is(err, services.ErrAccountSuspended) || errors.Is(err, services.ErrAccountBanned)
+}
+
+// sanctionErrorResponse maps sanction service errors to HTTP responses
+func sanctionErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrSanctionNotFound),
+		errors.Is(err, services.ErrSanctionUserNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrSanctionSelf),
+		errors.Is(err, services.ErrSanctionProtected):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrSanctionDuration):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrSanctionInactive):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// ListSanctions returns the moderation state of a user with every sanction ever applied (audit trail)
+// GET /sanctions/users/:userId?offset=0&limit=20
+func (h *SanctionHandler) ListSanctions(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	sanctions, err := h.sanctionService.ListSanctions(c.Context(), userID, offset, limit)
+	if err != nil {
+		return sanctionErrorResponse(c, err, "Failed to retrieve sanctions")
+	}
+
+	return utils.SuccessResponse(c, sanctions, "Sanctions retrieved successfully")
+}
+
+// ApplySanction suspends (durationHours), bans or shadowbans a user
+// POST /sanctions/users/:userId
+func (h *SanctionHandler) ApplySanction(c *fiber.Ctx) error {
+	moderatorID := c.Locals("userID").(uuid.UUID)
+
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid user ID")
+	}
+
+	var req dto.ApplySanctionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	sanction, err := h.sanctionService.ApplySanction(c.Context(), moderatorID, userID, &req)
+	if err != nil {
+		return sanctionErrorResponse(c, err, "Failed to apply sanction")
+	}
+
+	return utils.SuccessResponse(c, sanction, "Sanction applied successfully")
+}
+
+// LiftSanction ends a sanction early (the row is kept with who lifted it and why)
+// POST /sanctions/:id/lift
+func (h *SanctionHandler) LiftSanction(c *fiber.Ctx) error {
+	moderatorID := c.Locals("userID").(uuid.UUID)
+
+	sanctionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid sanction ID")
+	}
+
+	var req dto.LiftSanctionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	sanction, err := h.sanctionService.LiftSanction(c.Context(), moderatorID, sanctionID, &req)
+	if err != nil {
+		return sanctionErrorResponse(c, err, "Failed to lift sanction")
+	}
+
+	return utils.SuccessResponse(c, sanction, "Sanction lifted successfully")
+}
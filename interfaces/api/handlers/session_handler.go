@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type SessionHandler struct {
+	sessionService services.SessionService
+}
+
+func NewSessionHandler(sessionService services.SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+// sessionErrorResponse maps session service errors to HTTP responses
+func sessionErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrSessionNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrInvalidRefreshToken),
+		errors.Is(err, services.ErrRefreshTokenReused),
+		errors.Is(err, services.ErrSessionExpired):
+		return utils.ErrorResponse(c, apperrors.ErrInvalidToken.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// sessionClient collects the device details stored on a session
+func sessionClient(c *fiber.Ctx) dto.SessionClient {
+	return dto.SessionClient{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IPAddress: c.IP(),
+	}
+}
+
+// Refresh exchanges a refresh token for a new token pair (the old refresh token stops working)
+// POST /auth/refresh
+func (h *SessionHandler) Refresh(c *fiber.Ctx) error {
+	var req dto.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	tokens, err := h.sessionService.Refresh(c.Context(), req.RefreshToken, sessionClient(c))
+	if err != nil {
+		return sessionErrorResponse(c, err, "Failed to refresh token")
+	}
+
+	return utils.SuccessResponse(c, tokens, "Token refreshed successfully")
+}
+
+// Logout revokes the session of the calling access token
+// POST /auth/logout
+func (h *SessionHandler) Logout(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	if err := h.sessionService.RevokeSession(c.Context(), user.ID, user.SessionID, models.SessionRevokeLogout); err != nil {
+		return sessionErrorResponse(c, err, "Failed to log out")
+	}
+
+	return utils.SuccessResponse(c, dto.LogoutResponse{Message: "Logged out"}, "Logged out successfully")
+}
+
+// ListSessions lists the devices the current user is signed in on
+// GET /auth/sessions
+func (h *SessionHandler) ListSessions(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	sessions, err := h.sessionService.ListSessions(c.Context(), user.ID, user.SessionID)
+	if err != nil {
+		return sessionErrorResponse(c, err, "Failed to retrieve sessions")
+	}
+
+	return utils.SuccessResponse(c, sessions, "Sessions retrieved successfully")
+}
+
+// RevokeSession signs out one device
+// DELETE /auth/sessions/:id
+func (h *SessionHandler) RevokeSession(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid session ID")
+	}
+
+	if err := h.sessionService.RevokeSession(c.Context(), user.ID, sessionID, models.SessionRevokeUser); err != nil {
+		return sessionErrorResponse(c, err, "Failed to revoke session")
+	}
+
+	return utils.SuccessResponse(c, nil, "Session revoked successfully")
+}
+
+// RevokeOtherSessions signs out every device except the current one
+// DELETE /auth/sessions
+func (h *SessionHandler) RevokeOtherSessions(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	revoked, err := h.sessionService.RevokeAllSessions(c.Context(), user.ID, &user.SessionID, models.SessionRevokeUser)
+	if err != nil {
+		return sessionErrorResponse(c, err, "Failed to revoke sessions")
+	}
+
+	return utils.SuccessResponse(c, fiber.Map{"revoked": revoked}, "Sessions revoked successfully")
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type SubscriptionHandler struct {
+	subscriptionService services.SubscriptionService
+}
+
+func NewSubscriptionHandler(subscriptionService services.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+	}
+}
+
+// subscriptionErrorResponse maps subscription service errors to HTTP responses
+func subscriptionErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrInsufficientBalance):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Insufficient wallet balance").WithInternal(err))
+	case errors.Is(err, services.ErrAlreadySubscribed),
+		errors.Is(err, services.ErrSubscriptionTierLevelTaken):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrSubscriptionTierLimit),
+		errors.Is(err, services.ErrSubscriptionTierInactive),
+		errors.Is(err, services.ErrCannotSubscribeSelf),
+		errors.Is(err, services.ErrSubscriptionNotActive):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(fallback).WithInternal(err))
+}
+
+// ListTiers lists the subscription tiers of a creator
+// GET /subscriptions/creators/:creatorId/tiers
+func (h *SubscriptionHandler) ListTiers(c *fiber.Ctx) error {
+	creatorID, err := uuid.Parse(c.Params("creatorId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid creator ID")
+	}
+
+	// Get userID if authenticated (optional)
+	var userIDPtr *uuid.UUID
+	if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+		userIDPtr = &userID
+	}
+
+	tiers, err := h.subscriptionService.ListTiers(c.Context(), creatorID, userIDPtr)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrUserNotFound.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, tiers, "Subscription tiers retrieved successfully")
+}
+
+// CreateTier creates a subscription tier for the current user
+// POST /subscriptions/tiers
+func (h *SubscriptionHandler) CreateTier(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateSubscriptionTierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	tier, err := h.subscriptionService.CreateTier(c.Context(), userID, &req)
+	if err != nil {
+		return subscriptionErrorResponse(c, err, "Failed to create subscription tier")
+	}
+
+	return utils.SuccessResponse(c, tier, "Subscription tier created successfully")
+}
+
+// UpdateTier updates a subscription tier (price changes only apply to new subscribers)
+// PUT /subscriptions/tiers/:id
+func (h *SubscriptionHandler) UpdateTier(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	tierID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid tier ID")
+	}
+
+	var req dto.UpdateSubscriptionTierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	tier, err := h.subscriptionService.UpdateTier(c.Context(), tierID, userID, &req)
+	if err != nil {
+		return subscriptionErrorResponse(c, err, "Failed to update subscription tier")
+	}
+
+	return utils.SuccessResponse(c, tier, "Subscription tier updated successfully")
+}
+
+// ListSubscribers lists the current user's subscribers
+// GET /subscriptions/subscribers?offset=0&limit=20
+func (h *SubscriptionHandler) ListSubscribers(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	subscribers, err := h.subscriptionService.ListSubscribers(c.Context(), userID, offset, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, subscribers, "Subscribers retrieved successfully")
+}
+
+// GetCreatorStats retrieves subscriber and revenue stats of the current user
+// GET /subscriptions/stats
+func (h *SubscriptionHandler) GetCreatorStats(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	stats, err := h.subscriptionService.GetCreatorStats(c.Context(), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, stats, "Subscription stats retrieved successfully")
+}
+
+// Subscribe subscribes the current user to a creator's tier
+// POST /subscriptions
+func (h *SubscriptionHandler) Subscribe(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.SubscribeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	subscription, err := h.subscriptionService.Subscribe(c.Context(), userID, &req)
+	if err != nil {
+		return subscriptionErrorResponse(c, err, "Failed to subscribe")
+	}
+
+	return utils.SuccessResponse(c, subscription, "Subscribed successfully")
+}
+
+// ListMySubscriptions lists the current user's subscriptions
+// GET /subscriptions/me?offset=0&limit=20
+func (h *SubscriptionHandler) ListMySubscriptions(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	subscriptions, err := h.subscriptionService.ListMySubscriptions(c.Context(), userID, offset, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, subscriptions, "Subscriptions retrieved successfully")
+}
+
+// ChangeTier upgrades (prorated, immediate) or downgrades (next period) a subscription
+// PUT /subscriptions/:creatorId/tier
+func (h *SubscriptionHandler) ChangeTier(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	creatorID, err := uuid.Parse(c.Params("creatorId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid creator ID")
+	}
+
+	var req dto.ChangeSubscriptionTierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	subscription, err := h.subscriptionService.ChangeTier(c.Context(), userID, creatorID, &req)
+	if err != nil {
+		return subscriptionErrorResponse(c, err, "Failed to change subscription tier")
+	}
+
+	return utils.SuccessResponse(c, subscription, "Subscription tier changed successfully")
+}
+
+// Cancel cancels a subscription (access continues until the period ends)
+// POST /subscriptions/:creatorId/cancel
+func (h *SubscriptionHandler) Cancel(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	creatorID, err := uuid.Parse(c.Params("creatorId"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid creator ID")
+	}
+
+	// Body is optional
+	var req dto.CancelSubscriptionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.ValidationErrorResponse(c, "Invalid request body")
+		}
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	subscription, err := h.subscriptionService.Cancel(c.Context(), userID, creatorID, &req)
+	if err != nil {
+		return subscriptionErrorResponse(c, err, "Failed to cancel subscription")
+	}
+
+	return utils.SuccessResponse(c, subscription, "Subscription cancelled successfully")
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type TopUpHandler struct {
+	topUpService services.TopUpService
+}
+
+func NewTopUpHandler(topUpService services.TopUpService) *TopUpHandler {
+	return &TopUpHandler{
+		topUpService: topUpService,
+	}
+}
+
+// topUpErrorResponse maps top-up service errors to HTTP responses
+func topUpErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrTopUpNotFound):
+		return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrTopUpNotPending):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrTopUpSimulationUnavailable):
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrTopUpUnavailable):
+		return utils.ErrorResponse(c, apperrors.ErrServiceUnavailable.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// CreateTopUp starts a top-up and returns the PromptPay QR payload to pay
+// POST /wallet/topups
+func (h *TopUpHandler) CreateTopUp(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateTopUpRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	topUp, err := h.topUpService.CreateTopUp(c.Context(), userID, &req)
+	if err != nil {
+		return topUpErrorResponse(c, err, "Failed to create top-up")
+	}
+
+	return utils.SuccessResponse(c, topUp, "Top-up created successfully")
+}
+
+// ListMyTopUps retrieves the current user's top-ups
+// GET /wallet/topups?offset=0&limit=20
+func (h *TopUpHandler) ListMyTopUps(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	topUps, err := h.topUpService.ListMyTopUps(c.Context(), userID, offset, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve top-ups").WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, topUps, "Top-ups retrieved successfully")
+}
+
+// GetTopUp retrieves a top-up (polled by the client while the QR is shown)
+// GET /wallet/topups/:id
+func (h *TopUpHandler) GetTopUp(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	intentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid top-up ID")
+	}
+
+	topUp, err := h.topUpService.GetTopUp(c.Context(), intentID, userID)
+	if err != nil {
+		return topUpErrorResponse(c, err, "Failed to retrieve top-up")
+	}
+
+	return utils.SuccessResponse(c, topUp, "Top-up retrieved successfully")
+}
+
+// SimulatePayment pays a pending top-up without a gateway (fake provider only)
+// POST /wallet/topups/:id/simulate-payment
+func (h *TopUpHandler) SimulatePayment(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	intentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid top-up ID")
+	}
+
+	topUp, err := h.topUpService.SimulatePayment(c.Context(), intentID, userID)
+	if err != nil {
+		return topUpErrorResponse(c, err, "Failed to simulate payment")
+	}
+
+	return utils.SuccessResponse(c, topUp, "Payment simulated successfully")
+}
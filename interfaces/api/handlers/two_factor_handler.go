@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/dto"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService services.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorService services.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+	}
+}
+
+// twoFactorErrorResponse maps two-factor service errors to HTTP responses
+func twoFactorErrorResponse(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, services.ErrTwoFactorAlreadyEnabled):
+		return utils.ErrorResponse(c, apperrors.ErrConflict.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrTwoFactorNotEnabled),
+		errors.Is(err, services.ErrTwoFactorSetupMissing),
+		errors.Is(err, services.ErrInvalidTwoFactorCode):
+		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+	case errors.Is(err, services.ErrInvalidMFAChallenge):
+		return utils.ErrorResponse(c, apperrors.ErrInvalidToken.WithMessage(err.Error()).WithInternal(err))
+	}
+	return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage(fallback).WithInternal(err))
+}
+
+// GetStatus retrieves the two-factor state of the current user
+// GET /auth/2fa
+func (h *TwoFactorHandler) GetStatus(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	status, err := h.twoFactorService.GetStatus(c.Context(), user.ID)
+	if err != nil {
+		return twoFactorErrorResponse(c, err, "Failed to retrieve two-factor status")
+	}
+
+	return utils.SuccessResponse(c, status, "Two-factor status retrieved successfully")
+}
+
+// Setup creates a pending secret and returns it with the otpauth URI and QR code
+// POST /auth/2fa/setup
+func (h *TwoFactorHandler) Setup(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	setup, err := h.twoFactorService.BeginSetup(c.Context(), user.ID)
+	if err != nil {
+		return twoFactorErrorResponse(c, err, "Failed to start two-factor setup")
+	}
+
+	return utils.SuccessResponse(c, setup, "Scan the QR code with your authenticator app, then confirm with a code")
+}
+
+// Enable confirms the setup with an authenticator code and returns the recovery codes (shown once)
+// POST /auth/2fa/enable
+func (h *TwoFactorHandler) Enable(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	codes, err := h.twoFactorService.Enable(c.Context(), user.ID, req.Code)
+	if err != nil {
+		return twoFactorErrorResponse(c, err, "Failed to enable two-factor authentication")
+	}
+
+	return utils.SuccessResponse(c, codes, "Two-factor authentication enabled, store your recovery codes safely")
+}
+
+// Disable turns two-factor off (authenticator or recovery code required)
+// POST /auth/2fa/disable
+func (h *TwoFactorHandler) Disable(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	if err := h.twoFactorService.Disable(c.Context(), user.ID, req.Code); err != nil {
+		return twoFactorErrorResponse(c, err, "Failed to disable two-factor authentication")
+	}
+
+	return utils.SuccessResponse(c, nil, "Two-factor authentication disabled")
+}
+
+// RegenerateRecoveryCodes replaces every recovery code (authenticator or recovery code required)
+// POST /auth/2fa/recovery-codes
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	user, err := utils.GetUserFromContext(c)
+	if err != nil {
+		return utils.UnauthorizedResponse(c, "User not authenticated")
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(c.Context(), user.ID, req.Code)
+	if err != nil {
+		return twoFactorErrorResponse(c, err, "Failed to regenerate recovery codes")
+	}
+
+	return utils.SuccessResponse(c, codes, "Recovery codes regenerated, the previous codes no longer work")
+}
+
+// Verify exchanges a login challenge and a code for a session (second step of password login)
+// POST /auth/2fa/verify
+func (h *TwoFactorHandler) Verify(c *fiber.Ctx) error {
+	var req dto.MFAVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.ValidationErrorResponse(c, "Invalid request body")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		errors := utils.GetValidationErrors(err)
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"success": false,
+			"message": "Validation failed",
+			"errors":  errors,
+		})
+	}
+
+	tokens, user, err := h.twoFactorService.VerifyChallenge(c.Context(), req.ChallengeToken, req.Code, sessionClient(c))
+	if err != nil {
+		return twoFactorErrorResponse(c, err, "Failed to verify two-factor code")
+	}
+
+	loginResponse := &dto.LoginResponse{
+		AuthTokens: *tokens,
+		User:       *dto.UserToUserResponse(user),
+	}
+	return utils.SuccessResponse(c, loginResponse, "Login successful")
+}
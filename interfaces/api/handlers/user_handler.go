@@ -1,21 +1,25 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/services"
 	apperrors "gofiber-template/pkg/errors"
 	"gofiber-template/pkg/utils"
+	"log"
 	"strconv"
 )
 
 type UserHandler struct {
-	userService services.UserService
+	userService         services.UserService
+	accountEmailService services.AccountEmailService
 }
 
-func NewUserHandler(userService services.UserService) *UserHandler {
+func NewUserHandler(userService services.UserService, accountEmailService services.AccountEmailService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:         userService,
+		accountEmailService: accountEmailService,
 	}
 }
 
@@ -49,8 +53,13 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Registration failed").WithInternal(err))
 	}
 
+	// The account exists either way; the user can ask for a new link
+	if err := h.accountEmailService.SendVerificationEmail(c.Context(), user); err != nil {
+		log.Printf("⚠️  Failed to send verification email to user %s: %v", user.ID, err)
+	}
+
 	userResponse := dto.UserToUserResponse(user)
-	return utils.SuccessResponse(c, userResponse, "User registered successfully")
+	return utils.SuccessResponse(c, userResponse, "User registered successfully, please check your email to verify your account")
 }
 
 // Login godoc
@@ -78,14 +87,24 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	token, user, err := h.userService.Login(c.Context(), &req)
+	tokens, user, err := h.userService.Login(c.Context(), &req, sessionClient(c))
+	var mfaErr *services.MFAChallengeError
+	if errors.As(err, &mfaErr) {
+		return utils.SuccessResponse(c, mfaErr.Challenge, "Two-factor code required")
+	}
+	if errors.Is(err, services.ErrEmailNotVerified) {
+		return utils.ErrorResponse(c, apperrors.ErrEmailNotVerified.WithInternal(err))
+	}
+	if isAccountRestricted(err) {
+		return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+	}
 	if err != nil {
 		return utils.ErrorResponse(c, apperrors.ErrInvalidCredentials.WithInternal(err))
 	}
 
 	loginResponse := &dto.LoginResponse{
-		Token: token,
-		User:  *dto.UserToUserResponse(user),
+		AuthTokens: *tokens,
+		User:       *dto.UserToUserResponse(user),
 	}
 	return utils.SuccessResponse(c, loginResponse, "Login successful")
 }
@@ -147,20 +166,6 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, userResponse, "Profile updated successfully")
 }
 
-func (h *UserHandler) DeleteUser(c *fiber.Ctx) error {
-	user, err := utils.GetUserFromContext(c)
-	if err != nil {
-		return utils.UnauthorizedResponse(c, "User not authenticated")
-	}
-
-	err = h.userService.DeleteUser(c.Context(), user.ID)
-	if err != nil {
-		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("User deletion failed").WithInternal(err))
-	}
-
-	return utils.SuccessResponse(c, nil, "User deleted successfully")
-}
-
 func (h *UserHandler) ListUsers(c *fiber.Ctx) error {
 	offsetStr := c.Query("offset", "0")
 	limitStr := c.Query("limit", "10")
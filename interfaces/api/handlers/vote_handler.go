@@ -41,6 +41,9 @@ func (h *VoteHandler) Vote(c *fiber.Ctx) error {
 
 	vote, err := h.voteService.Vote(c.Context(), userID, &req)
 	if err != nil {
+		if isAccountRestricted(err) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to vote").WithInternal(err))
 	}
 
@@ -70,6 +73,9 @@ func (h *VoteHandler) Unvote(c *fiber.Ctx) error {
 
 	err = h.voteService.Unvote(c.Context(), userID, req)
 	if err != nil {
+		if isAccountRestricted(err) {
+			return utils.ErrorResponse(c, apperrors.ErrForbidden.WithMessage(err.Error()).WithInternal(err))
+		}
 		return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage("Failed to unvote").WithInternal(err))
 	}
 
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gofiber-template/domain/services"
+	apperrors "gofiber-template/pkg/errors"
+	"gofiber-template/pkg/utils"
+)
+
+type WalletHandler struct {
+	walletService services.WalletService
+}
+
+func NewWalletHandler(walletService services.WalletService) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+	}
+}
+
+// GetMyWallet retrieves the current user's wallet balance
+// GET /wallet
+func (h *WalletHandler) GetMyWallet(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	wallet, err := h.walletService.GetMyWallet(c.Context(), userID)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve wallet").WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, wallet, "Wallet retrieved successfully")
+}
+
+// ListMyLedgerEntries retrieves the current user's wallet history
+// GET /wallet/ledger?offset=0&limit=20
+func (h *WalletHandler) ListMyLedgerEntries(c *fiber.Ctx) error {
+	userID := c.Locals("userID").(uuid.UUID)
+
+	offset, _ := strconv.Atoi(c.Query("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+	limit := normalizeLimit(c.Query("limit", "20"))
+
+	entries, err := h.walletService.ListMyLedgerEntries(c.Context(), userID, offset, limit)
+	if err != nil {
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithMessage("Failed to retrieve wallet history").WithInternal(err))
+	}
+
+	return utils.SuccessResponse(c, entries, "Wallet history retrieved successfully")
+}
@@ -2,13 +2,16 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"github.com/gofiber/fiber/v2"
 	"gofiber-template/domain/dto"
 	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/payment"
 	"gofiber-template/infrastructure/redis"
 	"gofiber-template/infrastructure/websocket"
+	apperrors "gofiber-template/pkg/errors"
 	"gofiber-template/pkg/utils"
 )
 
@@ -16,15 +19,19 @@ type WebhookHandler struct {
 	mediaService    services.MediaService
 	postService     services.PostService
 	messageService  services.MessageService
+	reelService     services.ReelService
+	topUpService    services.TopUpService
 	redisService    *redis.RedisService
 	notificationHub *websocket.NotificationHub
 }
 
-func NewWebhookHandler(mediaService services.MediaService, postService services.PostService, messageService services.MessageService, redisService *redis.RedisService, notificationHub *websocket.NotificationHub) *WebhookHandler {
+func NewWebhookHandler(mediaService services.MediaService, postService services.PostService, messageService services.MessageService, reelService services.ReelService, topUpService services.TopUpService, redisService *redis.RedisService, notificationHub *websocket.NotificationHub) *WebhookHandler {
 	return &WebhookHandler{
 		mediaService:    mediaService,
 		postService:     postService,
 		messageService:  messageService,
+		reelService:     reelService,
+		topUpService:    topUpService,
 		redisService:    redisService,
 		notificationHub: notificationHub,
 	}
@@ -54,6 +61,36 @@ func (h *WebhookHandler) BunnyStreamWebhook(c *fiber.Ctx) error {
 	})
 }
 
+// PaymentWebhook handles signed charge events from the payment gateway
+// Unlike Bunny, it is processed synchronously: a non-2xx response makes the gateway retry
+// POST /webhooks/payments/:provider
+func (h *WebhookHandler) PaymentWebhook(c *fiber.Ctx) error {
+	provider := c.Params("provider")
+
+	err := h.topUpService.HandleWebhook(c.Context(), provider, c.Body(), c.Get(payment.SignatureHeader))
+	if err != nil {
+		log.Printf("Payment webhook from %s rejected: %v", provider, err)
+
+		switch {
+		case errors.Is(err, services.ErrPaymentWebhookInvalid):
+			return utils.ErrorResponse(c, apperrors.ErrUnauthorized.WithMessage("Invalid webhook signature").WithInternal(err))
+		case errors.Is(err, services.ErrPaymentProviderUnknown),
+			errors.Is(err, services.ErrTopUpNotFound):
+			return utils.ErrorResponse(c, apperrors.ErrNotFound.WithMessage(err.Error()).WithInternal(err))
+		case errors.Is(err, services.ErrTopUpAmountMismatch):
+			return utils.ErrorResponse(c, apperrors.ErrBadRequest.WithMessage(err.Error()).WithInternal(err))
+		case errors.Is(err, services.ErrTopUpUnavailable):
+			return utils.ErrorResponse(c, apperrors.ErrServiceUnavailable.WithMessage(err.Error()).WithInternal(err))
+		}
+		return utils.ErrorResponse(c, apperrors.ErrInternal.WithInternal(err))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"message": "Webhook received",
+	})
+}
+
 // BunnyStreamWebhookPayload represents the payload sent by Bunny Stream
 type BunnyStreamWebhookPayload struct {
 	VideoLibraryID int64  `json:"VideoLibraryId"` // PascalCase from Bunny!
@@ -178,8 +215,10 @@ func (h *WebhookHandler) processBunnyWebhook(ctx context.Context, payload BunnyS
 				}
 			}
 		case "reel":
-			// TODO: Handle reel video completion
-			log.Printf("Reel video encoding completed: mediaID=%s", media.ID)
+			// Reel goes live (its 24 hours start now)
+			if err := h.reelService.HandleVideoEncoded(ctx, media.ID, encodingStatus); err != nil {
+				log.Printf("Failed to activate reel for media %s: %v", media.ID, err)
+			}
 		case "comment":
 			// TODO: Handle comment video completion
 			log.Printf("Comment video encoding completed: mediaID=%s", media.ID)
@@ -195,6 +234,13 @@ func (h *WebhookHandler) processBunnyWebhook(ctx context.Context, payload BunnyS
 		}
 	}
 
+	// Failed reels are marked so they don't stay in processing until expiry
+	if encodingStatus == "failed" && media != nil && media.SourceType != nil && *media.SourceType == "reel" {
+		if err := h.reelService.HandleVideoEncoded(ctx, media.ID, encodingStatus); err != nil {
+			log.Printf("Failed to mark reel as failed for media %s: %v", media.ID, err)
+		}
+	}
+
 	log.Printf("Successfully updated video encoding status - VideoID: %s, Status: %s, Progress: %d%%",
 		videoID, encodingStatus, payload.EncodeProgress)
 }
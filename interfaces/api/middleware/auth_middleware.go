@@ -1,13 +1,202 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"gofiber-template/domain/models"
+	"gofiber-template/domain/services"
 	"gofiber-template/pkg/utils"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
+// SessionDenylist reports sessions revoked before their access tokens expire (logout, remote logout, token reuse)
+type SessionDenylist interface {
+	IsSessionDenied(ctx context.Context, sessionID uuid.UUID) (bool, error)
+}
+
+var sessionDenylist SessionDenylist
+
+// UseSessionDenylist makes the auth middlewares reject access tokens of revoked sessions
+func UseSessionDenylist(denylist SessionDenylist) {
+	sessionDenylist = denylist
+}
+
+// isSessionDenied checks the denylist; a Redis outage lets tokens through until they expire
+func isSessionDenied(c *fiber.Ctx, sessionID uuid.UUID) bool {
+	if sessionDenylist == nil {
+		return false
+	}
+	denied, err := sessionDenylist.IsSessionDenied(c.Context(), sessionID)
+	if err != nil {
+		log.Printf("⚠️  Session denylist check failed: %v", err)
+		return false
+	}
+	return denied
+}
+
+// AccountStatusChecker reports suspended and banned accounts (message is empty when the account may act)
+type AccountStatusChecker interface {
+	AccountRestriction(ctx context.Context, userID uuid.UUID) (string, error)
+}
+
+var accountStatusChecker AccountStatusChecker
+
+// UseAccountStatusChecker makes the auth middlewares reject suspended and banned accounts
+func UseAccountStatusChecker(checker AccountStatusChecker) {
+	accountStatusChecker = checker
+}
+
+// accountRestriction checks the account status; like the session denylist it fails open,
+// the services check write access again before changing anything
+func accountRestriction(c *fiber.Ctx, userID uuid.UUID) string {
+	if accountStatusChecker == nil {
+		return ""
+	}
+	msg, err := accountStatusChecker.AccountRestriction(c.Context(), userID)
+	if err != nil {
+		log.Printf("⚠️  Account status check failed: %v", err)
+		return ""
+	}
+	return msg
+}
+
+func accountRestrictedResponse(c *fiber.Ctx, msg string) error {
+	return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+		"success": false,
+		"message": msg,
+		"error":   "Account restricted",
+	})
+}
+
+// PermissionChecker resolves role-based permissions (cached, so checks do not hit the database)
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, userID uuid.UUID, permission string, communityID *uuid.UUID) (bool, error)
+}
+
+var permissionChecker PermissionChecker
+
+// UsePermissionChecker enables RequirePermission (without it every permission check is denied)
+func UsePermissionChecker(checker PermissionChecker) {
+	permissionChecker = checker
+}
+
+// hasPermission denies on lookup errors (unlike the session denylist, failing open would grant access)
+func hasPermission(c *fiber.Ctx, userID uuid.UUID, permission string) bool {
+	if permissionChecker == nil {
+		return false
+	}
+	allowed, err := permissionChecker.HasPermission(c.Context(), userID, permission, nil)
+	if err != nil {
+		log.Printf("⚠️  Permission check failed: %v", err)
+		return false
+	}
+	return allowed
+}
+
+// APITokenAuthenticator resolves personal access tokens and bot keys (and applies their rate limits)
+type APITokenAuthenticator interface {
+	AuthenticateAPIToken(ctx context.Context, token, ip string) (*utils.UserContext, error)
+}
+
+var apiTokenAuthenticator APITokenAuthenticator
+
+// UseAPITokenAuthenticator makes Protected and Optional accept API tokens alongside JWTs
+func UseAPITokenAuthenticator(authenticator APITokenAuthenticator) {
+	apiTokenAuthenticator = authenticator
+}
+
+// apiTokenRoutes - Scopes API tokens need per route prefix (read = GET/HEAD, write = anything else).
+// Routes that are not listed, or have no scope for the method, are closed to tokens:
+// auth, account, wallet and staff endpoints need a signed-in user.
+var apiTokenRoutes = []struct {
+	prefix      string
+	read, write string
+}{
+	{"/api/v1/posts", models.ScopePostsRead, models.ScopePostsWrite},
+	{"/api/v1/comments", models.ScopePostsRead, models.ScopeCommentsWrite},
+	{"/api/v1/votes", models.ScopePostsRead, models.ScopeVotesWrite},
+	{"/api/v1/communities", models.ScopePostsRead, ""},
+	{"/api/v1/tags", models.ScopePostsRead, ""},
+	{"/api/v1/search", models.ScopePostsRead, ""},
+	{"/api/v1/media", models.ScopePostsRead, models.ScopeMediaWrite},
+	{"/api/v1/upload", "", models.ScopeMediaWrite},
+	{"/api/v1/chat", models.ScopeChatRead, models.ScopeChatWrite},
+	{"/api/v1/notifications", models.ScopeNotificationsRead, ""},
+	{"/api/v1/profiles", models.ScopeProfileRead, ""},
+	{"/api/v1/users/profile", models.ScopeProfileRead, ""},
+}
+
+// apiTokenScope returns the scope a token needs for the request ("" = closed to tokens)
+func apiTokenScope(c *fiber.Ctx) string {
+	path := c.Path()
+	for _, route := range apiTokenRoutes {
+		if path != route.prefix && !strings.HasPrefix(path, route.prefix+"/") {
+			continue
+		}
+		if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+			return route.read
+		}
+		return route.write
+	}
+	return ""
+}
+
+// isAPIToken tells API tokens ("pat_…", "bot_…") apart from JWTs
+func isAPIToken(token string) bool {
+	return strings.HasPrefix(token, models.APITokenKindPersonal+"_") || strings.HasPrefix(token, models.APITokenKindBot+"_")
+}
+
+// authenticateAPIToken checks an API token and the scope the route needs.
+// On failure it writes the error response; the user is nil and the error is the result of writing it.
+func authenticateAPIToken(c *fiber.Ctx, token string) (*utils.UserContext, error) {
+	if apiTokenAuthenticator == nil {
+		return nil, utils.UnauthorizedResponse(c, "API tokens are not accepted")
+	}
+
+	userCtx, err := apiTokenAuthenticator.AuthenticateAPIToken(c.Context(), token, c.IP())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAPITokenRateLimited):
+			return nil, c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"success": false,
+				"error": fiber.Map{
+					"code":    "RATE_LIMIT_EXCEEDED",
+					"message": "API token rate limit exceeded, please try again later",
+				},
+			})
+		case errors.Is(err, services.ErrAPITokenExpired):
+			return nil, utils.UnauthorizedResponse(c, "API token has expired")
+		case errors.Is(err, services.ErrAPITokenInvalid):
+			return nil, utils.UnauthorizedResponse(c, "Invalid API token")
+		default:
+			log.Printf("❌ API token validation failed: %v", err)
+			return nil, utils.UnauthorizedResponse(c, "Token validation failed")
+		}
+	}
+
+	if scope := apiTokenScope(c); scope == "" || !userCtx.HasScope(scope) {
+		return nil, c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "This API token is not allowed to access this endpoint" + requiredScopeHint(scope),
+			"error":   "Insufficient scope",
+		})
+	}
+
+	return userCtx, nil
+}
+
+func requiredScopeHint(scope string) string {
+	if scope == "" {
+		return ""
+	}
+	return " (requires " + scope + ")"
+}
+
 // Protected middleware validates JWT tokens and sets user context
 func Protected() fiber.Handler {
 	jwtSecret := os.Getenv("JWT_SECRET")
@@ -27,6 +216,21 @@ func Protected() fiber.Handler {
 			return utils.UnauthorizedResponse(c, "Invalid authorization header format")
 		}
 
+		// Personal access tokens and bot keys
+		if isAPIToken(token) {
+			userCtx, resp := authenticateAPIToken(c, token)
+			if userCtx == nil {
+				return resp
+			}
+			if msg := accountRestriction(c, userCtx.ID); msg != "" {
+				return accountRestrictedResponse(c, msg)
+			}
+
+			c.Locals("user", userCtx)
+			c.Locals("userID", userCtx.ID)
+			return c.Next()
+		}
+
 		// Validate token and get user context
 		userCtx, err := utils.ValidateTokenStringToUUID(token, jwtSecret)
 		if err != nil {
@@ -43,6 +247,14 @@ func Protected() fiber.Handler {
 			}
 		}
 
+		if isSessionDenied(c, userCtx.SessionID) {
+			return utils.UnauthorizedResponse(c, "Session has been revoked")
+		}
+
+		if msg := accountRestriction(c, userCtx.ID); msg != "" {
+			return accountRestrictedResponse(c, msg)
+		}
+
 		log.Printf("✅ Token validated for user: %s (%s)", userCtx.Email, userCtx.ID)
 
 		// Set user context in fiber locals
@@ -53,15 +265,16 @@ func Protected() fiber.Handler {
 	}
 }
 
-// RequireRole middleware checks if user has specific role
-func RequireRole(role string) fiber.Handler {
+// RequirePermission middleware checks a site-wide permission (see models.Perm*)
+func RequirePermission(permission string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		user, err := utils.GetUserFromContext(c)
 		if err != nil {
 			return utils.UnauthorizedResponse(c, "User not authenticated")
 		}
 
-		if user.Role != role {
+		// Staff permissions are never exercised through API tokens
+		if user.IsAPIToken() || !hasPermission(c, user.ID, permission) {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 				"success": false,
 				"message": "Insufficient permissions",
@@ -73,9 +286,25 @@ func RequireRole(role string) fiber.Handler {
 	}
 }
 
-// AdminOnly middleware ensures only admin users can access
-func AdminOnly() fiber.Handler {
-	return RequireRole("admin")
+// RequireMFA middleware only lets through sessions signed in with a second factor (amr "mfa");
+// API tokens never qualify
+func RequireMFA() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, err := utils.GetUserFromContext(c)
+		if err != nil {
+			return utils.UnauthorizedResponse(c, "User not authenticated")
+		}
+
+		if user.IsAPIToken() || !user.MFAVerified {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"success": false,
+				"message": "Sign in with your two-factor code to continue",
+				"error":   "Two-factor verification required",
+			})
+		}
+
+		return c.Next()
+	}
 }
 
 // OwnerOnly middleware checks if user is the owner of the resource
@@ -106,9 +335,20 @@ func Optional() fiber.Handler {
 			return c.Next()
 		}
 
+		// A token the route does not accept is rejected rather than ignored (the caller expects to be authenticated)
+		if isAPIToken(token) {
+			userCtx, resp := authenticateAPIToken(c, token)
+			if userCtx == nil {
+				return resp
+			}
+			c.Locals("user", userCtx)
+			c.Locals("userID", userCtx.ID)
+			return c.Next()
+		}
+
 		jwtSecret := os.Getenv("JWT_SECRET")
 		userCtx, err := utils.ValidateTokenStringToUUID(token, jwtSecret)
-		if err != nil {
+		if err != nil || isSessionDenied(c, userCtx.SessionID) {
 			return c.Next()
 		}
 
@@ -165,6 +405,14 @@ func WebSocketProtected() fiber.Handler {
 			}
 		}
 
+		if isSessionDenied(c, userCtx.SessionID) {
+			return utils.UnauthorizedResponse(c, "Session has been revoked")
+		}
+
+		if msg := accountRestriction(c, userCtx.ID); msg != "" {
+			return accountRestrictedResponse(c, msg)
+		}
+
 		log.Printf("✅ WebSocket: Token validated from query param for user: %s (%s)", userCtx.Email, userCtx.ID)
 
 		// Set user context in fiber locals
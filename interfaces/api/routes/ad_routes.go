@@ -0,0 +1,40 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupAdRoutes(api fiber.Router, h *handlers.Handlers) {
+	ads := api.Group("/ads")
+
+	// Public routes (with optional auth)
+	ads.Get("/placements", middleware.Optional(), h.AdHandler.ListPlacements)
+	ads.Get("/serve", middleware.Optional(), h.AdHandler.ServeAds)
+	ads.Post("/:id/impression", middleware.Optional(), h.AdHandler.RecordImpression)
+	ads.Post("/:id/click", middleware.Optional(), h.AdHandler.RecordClick)
+
+	// Protected routes
+	ads.Use(middleware.Protected())
+
+	// Admin (slip verification queue)
+	admin := ads.Group("/admin", middleware.RequirePermission(models.PermAdsApprove))
+	admin.Get("/payments", h.AdHandler.ListPayments)
+	admin.Post("/payments/:paymentId/verify", h.AdHandler.VerifyPayment)
+	admin.Post("/payments/:paymentId/reject", h.AdHandler.RejectPayment)
+	admin.Post("/:id/remove", h.AdHandler.RemoveAd)
+	admin.Put("/placements/:code", h.AdHandler.UpdatePlacement)
+
+	// Advertiser side
+	ads.Post("/", h.AdHandler.CreateAd)
+	ads.Get("/me", h.AdHandler.ListMyAds)
+	ads.Get("/:id", h.AdHandler.GetMyAd)
+	ads.Put("/:id", h.AdHandler.UpdateAd)
+	ads.Get("/:id/stats", h.AdHandler.GetAdStats)
+	ads.Post("/:id/cancel", h.AdHandler.CancelAd)
+	ads.Post("/:id/pause", h.AdHandler.PauseAd)
+	ads.Post("/:id/resume", h.AdHandler.ResumeAd)
+	ads.Post("/:id/payments", h.AdHandler.SubmitPayment)
+}
@@ -3,6 +3,8 @@ package routes
 import (
 	"github.com/gofiber/fiber/v2"
 	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+	pkgMiddleware "gofiber-template/pkg/middleware"
 )
 
 func SetupAuthRoutes(api fiber.Router, h *handlers.Handlers) {
@@ -11,9 +13,43 @@ func SetupAuthRoutes(api fiber.Router, h *handlers.Handlers) {
 	// Standard authentication
 	auth.Post("/register", h.UserHandler.Register)
 	auth.Post("/login", h.UserHandler.Login)
+	auth.Post("/refresh", h.SessionHandler.Refresh)
 
-	// OAuth authentication
+	// Email verification and password reset (strict limit: each request may send an email)
+	auth.Post("/email/verify", pkgMiddleware.NewStrictRateLimiter(), h.AccountEmailHandler.VerifyEmail)
+	auth.Post("/email/resend", pkgMiddleware.NewStrictRateLimiter(), h.AccountEmailHandler.ResendVerification)
+	auth.Post("/password/forgot", pkgMiddleware.NewStrictRateLimiter(), h.AccountEmailHandler.ForgotPassword)
+	auth.Post("/password/reset", pkgMiddleware.NewStrictRateLimiter(), h.AccountEmailHandler.ResetPassword)
+
+	// Two-factor authentication (TOTP)
+	auth.Post("/2fa/verify", pkgMiddleware.NewAuthRateLimiter(), h.TwoFactorHandler.Verify)
+	auth.Get("/2fa", middleware.Protected(), h.TwoFactorHandler.GetStatus)
+	auth.Post("/2fa/setup", middleware.Protected(), h.TwoFactorHandler.Setup)
+	auth.Post("/2fa/enable", middleware.Protected(), pkgMiddleware.NewAuthRateLimiter(), h.TwoFactorHandler.Enable)
+	auth.Post("/2fa/disable", middleware.Protected(), pkgMiddleware.NewAuthRateLimiter(), h.TwoFactorHandler.Disable)
+	auth.Post("/2fa/recovery-codes", middleware.Protected(), pkgMiddleware.NewAuthRateLimiter(), h.TwoFactorHandler.RegenerateRecoveryCodes)
+
+	// Sessions (signed-in devices)
+	auth.Post("/logout", middleware.Protected(), h.SessionHandler.Logout)
+	auth.Get("/sessions", middleware.Protected(), h.SessionHandler.ListSessions)
+	auth.Delete("/sessions", middleware.Protected(), h.SessionHandler.RevokeOtherSessions)
+	auth.Delete("/sessions/:id", middleware.Protected(), h.SessionHandler.RevokeSession)
+
+	// Personal access tokens (created with a signed-in session, never with another token)
+	auth.Get("/tokens", middleware.Protected(), h.APITokenHandler.ListTokens)
+	auth.Post("/tokens", middleware.Protected(), pkgMiddleware.NewAuthRateLimiter(), h.APITokenHandler.CreateToken)
+	auth.Delete("/tokens/:id", middleware.Protected(), h.APITokenHandler.RevokeToken)
+
+	// OAuth authentication (Google, LINE, Facebook, configured OIDC issuers)
+	auth.Get("/providers", h.OAuthHandler.ListProviders)
+	auth.Get("/oauth/:provider", h.OAuthHandler.GetAuthURL)
+	auth.Get("/oauth/:provider/callback", h.OAuthHandler.Callback)
 	auth.Get("/google", h.OAuthHandler.GetGoogleAuthURL)
 	auth.Get("/google/callback", h.OAuthHandler.GoogleCallback)
 	auth.Post("/exchange", h.OAuthHandler.ExchangeCodeForToken)
+
+	// Linked logins
+	auth.Get("/identities", middleware.Protected(), h.OAuthHandler.ListIdentities)
+	auth.Get("/oauth/:provider/link", middleware.Protected(), h.OAuthHandler.GetLinkURL)
+	auth.Delete("/identities/:provider", middleware.Protected(), h.OAuthHandler.UnlinkIdentity)
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupBotTokenRoutes(api fiber.Router, h *handlers.Handlers) {
+	botTokens := api.Group("/bot-tokens", middleware.Protected(), middleware.RequirePermission(models.PermBotTokensManage))
+
+	botTokens.Put("/users/:userId/bot", h.APITokenHandler.SetBotAccount)
+	botTokens.Get("/users/:userId", h.APITokenHandler.ListBotTokens)
+	botTokens.Post("/users/:userId", h.APITokenHandler.CreateBotToken)
+	botTokens.Delete("/:id", h.APITokenHandler.RevokeBotToken)
+}
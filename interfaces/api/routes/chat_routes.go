@@ -18,11 +18,30 @@ func SetupChatRoutes(api fiber.Router, h *handlers.Handlers) {
 	conversations.Get("/with/:username", h.ConversationHandler.GetOrCreateConversation)
 	conversations.Get("/", h.ConversationHandler.ListConversations)
 	conversations.Get("/unread-count", h.ConversationHandler.GetUnreadCount)
+	conversations.Get("/requests", h.ConversationHandler.ListMessageRequests)
+	conversations.Post("/groups", h.ConversationHandler.CreateGroup)
+	conversations.Get("/:conversationId", h.ConversationHandler.GetConversation)
+
+	// Group management
+	conversations.Patch("/:conversationId", h.ConversationHandler.UpdateGroup)
+	conversations.Post("/:conversationId/participants", h.ConversationHandler.AddParticipants)
+	conversations.Patch("/:conversationId/participants/:userId", h.ConversationHandler.UpdateParticipantRole)
+	conversations.Delete("/:conversationId/participants/:userId", h.ConversationHandler.RemoveParticipant)
+	conversations.Post("/:conversationId/leave", h.ConversationHandler.LeaveGroup)
+
+	// Message requests (chats from people the user does not follow)
+	conversations.Post("/:conversationId/request/accept", h.ConversationHandler.AcceptMessageRequest)
+	conversations.Delete("/:conversationId/request", h.ConversationHandler.DeleteMessageRequest)
+	conversations.Post("/:conversationId/request/block", h.ConversationHandler.BlockMessageRequest)
 
 	// Nested message routes under conversations
 	conversations.Get("/:conversationId/messages", h.MessageHandler.ListMessages)
 	conversations.Post("/:conversationId/messages", h.MessageHandler.SendMessage)
 	conversations.Post("/:conversationId/read", h.ConversationHandler.MarkAsRead)
+	conversations.Post("/:conversationId/delivered", h.ConversationHandler.MarkAsDelivered)
+	conversations.Get("/:conversationId/sync", h.ConversationHandler.SyncConversation)
+	conversations.Get("/:conversationId/search", h.MessageHandler.SearchConversationMessages)
+	conversations.Post("/:conversationId/gifts", h.GiftHandler.SendGift)
 
 	// Phase 2: Media/Links/Files filtering
 	conversations.Get("/:conversationId/media", h.MessageHandler.GetConversationMedia)
@@ -31,8 +50,21 @@ func SetupChatRoutes(api fiber.Router, h *handlers.Handlers) {
 
 	// Message routes (for message-specific operations)
 	messages := chat.Group("/messages")
+	messages.Get("/search", h.MessageHandler.SearchMessages)
 	messages.Get("/:id/context", h.MessageHandler.GetMessageContext)
+	messages.Get("/:id/revisions", h.MessageHandler.GetMessageRevisions)
+	messages.Get("/:id/receipts", h.MessageHandler.GetMessageReceipts)
+	messages.Get("/:id/quoted/context", h.MessageHandler.GetQuotedMessageContext)
+	messages.Post("/:id/reactions", h.MessageHandler.AddReaction)
+	messages.Delete("/:id/reactions/:emoji", h.MessageHandler.RemoveReaction)
 	messages.Get("/:id", h.MessageHandler.GetMessage)
+	messages.Patch("/:id", h.MessageHandler.EditMessage)
+	messages.Delete("/:id", h.MessageHandler.UnsendMessage)
+	messages.Post("/:id/open-gift", h.GiftHandler.OpenGift)
+
+	// Gift routes
+	gifts := chat.Group("/gifts")
+	gifts.Get("/packages", h.GiftHandler.ListPackages)
 
 	// Block routes
 	blocks := chat.Group("/blocks")
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupCommunityRoutes(api fiber.Router, h *handlers.Handlers) {
+	communities := api.Group("/communities")
+
+	// Public routes (with optional auth)
+	communities.Get("/", middleware.Optional(), h.CommunityHandler.ListCommunities)
+	communities.Get("/me", middleware.Protected(), h.CommunityHandler.ListMyCommunities)
+	communities.Get("/:slug", middleware.Optional(), h.CommunityHandler.GetCommunity)
+	communities.Get("/:slug/posts", middleware.Optional(), h.CommunityHandler.ListCommunityPosts)
+	communities.Get("/:slug/members", middleware.Optional(), h.CommunityHandler.ListMembers)
+
+	// Protected routes
+	communities.Use(middleware.Protected())
+	communities.Post("/", h.CommunityHandler.CreateCommunity)
+	communities.Put("/:slug", h.CommunityHandler.UpdateCommunity)
+	communities.Post("/:slug/join", h.CommunityHandler.Join)
+	communities.Post("/:slug/leave", h.CommunityHandler.Leave)
+
+	// Moderation
+	communities.Post("/:slug/members/:userId/approve", h.CommunityHandler.ApproveMember)
+	communities.Post("/:slug/members/:userId/reject", h.CommunityHandler.RejectMember)
+	communities.Post("/:slug/members/:userId/ban", h.CommunityHandler.BanMember)
+	communities.Delete("/:slug/members/:userId/ban", h.CommunityHandler.UnbanMember)
+	communities.Put("/:slug/members/:userId/role", h.CommunityHandler.SetMemberRole)
+}
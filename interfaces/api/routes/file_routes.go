@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
 	"gofiber-template/interfaces/api/handlers"
 	"gofiber-template/interfaces/api/middleware"
 )
@@ -10,7 +11,7 @@ func SetupFileRoutes(api fiber.Router, h *handlers.Handlers) {
 	files := api.Group("/files")
 	files.Use(middleware.Protected())
 	files.Post("/upload", h.FileHandler.UploadFile)
-	files.Get("/", middleware.AdminOnly(), h.FileHandler.ListFiles)
+	files.Get("/", middleware.RequirePermission(models.PermFilesViewAny), h.FileHandler.ListFiles)
 	files.Get("/my", h.FileHandler.GetUserFiles)
 	files.Get("/:id", h.FileHandler.GetFile)
 	files.Delete("/:id", middleware.OwnerOnly(), h.FileHandler.DeleteFile)
@@ -10,8 +10,8 @@ func SetupFollowRoutes(api fiber.Router, h *handlers.Handlers) {
 	follows := api.Group("/follows")
 
 	// Public routes (with optional authentication)
-	follows.Get("/user/:userId/followers", h.FollowHandler.GetFollowers)
-	follows.Get("/user/:userId/following", h.FollowHandler.GetFollowing)
+	follows.Get("/user/:userId/followers", middleware.Optional(), h.FollowHandler.GetFollowers)
+	follows.Get("/user/:userId/following", middleware.Optional(), h.FollowHandler.GetFollowing)
 	follows.Get("/user/:userId/status", h.FollowHandler.IsFollowing)
 
 	// Protected routes (require authentication)
@@ -19,4 +19,9 @@ func SetupFollowRoutes(api fiber.Router, h *handlers.Handlers) {
 	follows.Post("/user/:userId", h.FollowHandler.Follow)
 	follows.Delete("/user/:userId", h.FollowHandler.Unfollow)
 	follows.Get("/mutuals", h.FollowHandler.GetMutualFollows)
+
+	// Follow requests (private accounts)
+	follows.Get("/requests", h.FollowHandler.ListFollowRequests)
+	follows.Post("/requests/:userId/accept", h.FollowHandler.AcceptFollowRequest)
+	follows.Post("/requests/:userId/reject", h.FollowHandler.RejectFollowRequest)
 }
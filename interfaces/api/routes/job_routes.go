@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
 	"gofiber-template/interfaces/api/handlers"
 	"gofiber-template/interfaces/api/middleware"
 )
@@ -9,7 +10,7 @@ import (
 func SetupJobRoutes(api fiber.Router, h *handlers.Handlers) {
 	jobs := api.Group("/jobs")
 	jobs.Use(middleware.Protected())
-	jobs.Use(middleware.AdminOnly()) // All job operations require admin access
+	jobs.Use(middleware.RequirePermission(models.PermJobsManage)) // All job operations require the jobs permission
 	jobs.Post("/", h.JobHandler.CreateJob)
 	jobs.Get("/", h.JobHandler.ListJobs)
 	jobs.Get("/:id", h.JobHandler.GetJob)
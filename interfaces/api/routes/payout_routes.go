@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupPayoutRoutes(api fiber.Router, h *handlers.Handlers) {
+	payouts := api.Group("/payouts", middleware.Protected())
+
+	// Steps that move money need a session signed in with a second factor
+	mfa := middleware.RequireMFA()
+
+	// Admin (approval queue and bank batch)
+	admin := payouts.Group("/admin", middleware.RequirePermission(models.PermPayoutsManage))
+	admin.Get("/requests", h.PayoutHandler.ListPayouts)
+	admin.Get("/export", h.PayoutHandler.ExportApprovedPayouts)
+	admin.Post("/mark-paid", mfa, h.PayoutHandler.MarkPayoutsPaid)
+	admin.Post("/:id/approve", mfa, h.PayoutHandler.ApprovePayout)
+	admin.Post("/:id/reject", mfa, h.PayoutHandler.RejectPayout)
+	admin.Post("/:id/fail", mfa, h.PayoutHandler.FailPayout)
+
+	// Creator side
+	payouts.Get("/summary", h.PayoutHandler.GetPayoutSummary)
+	payouts.Post("/", mfa, h.PayoutHandler.RequestPayout)
+	payouts.Get("/", h.PayoutHandler.ListMyPayouts)
+	payouts.Get("/:id", h.PayoutHandler.GetMyPayout)
+	payouts.Post("/:id/cancel", h.PayoutHandler.CancelPayout)
+}
@@ -17,6 +17,8 @@ func SetupPostRoutes(api fiber.Router, h *handlers.Handlers) {
 	posts.Get("/tag-id/:tagId", middleware.Optional(), h.PostHandler.ListPostsByTagID)
 	// Search moved to /search (unified search with history & popular)
 	posts.Get("/:id/crossposts", middleware.Optional(), h.PostHandler.GetCrossposts)
+	posts.Get("/:id/unlock", middleware.Optional(), h.PostUnlockHandler.GetUnlockStatus)
+	posts.Get("/:id/unlock/contributors", middleware.Optional(), h.PostUnlockHandler.ListContributors)
 
 	// Protected routes (require authentication)
 	posts.Use(middleware.Protected())
@@ -24,5 +26,6 @@ func SetupPostRoutes(api fiber.Router, h *handlers.Handlers) {
 	posts.Put("/:id", h.PostHandler.UpdatePost)
 	posts.Delete("/:id", h.PostHandler.DeletePost)
 	posts.Post("/:id/crosspost", h.PostHandler.CreateCrosspost)
+	posts.Post("/:id/unlock/contributions", h.PostUnlockHandler.Contribute)
 	posts.Get("/feed", h.PostHandler.GetFeed)
 }
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupReelRoutes(api fiber.Router, h *handlers.Handlers) {
+	reels := api.Group("/reels")
+
+	// Protected routes (registered before /:id so they are not shadowed)
+	reels.Post("/", middleware.Protected(), h.ReelHandler.CreateReel)
+	reels.Get("/following", middleware.Protected(), h.ReelHandler.ListFollowingReels)
+	reels.Get("/me", middleware.Protected(), h.ReelHandler.ListMyReels)
+
+	// Public routes (with optional auth)
+	reels.Get("/user/:userId", middleware.Optional(), h.ReelHandler.ListUserReels)
+	reels.Get("/:id", middleware.Optional(), h.ReelHandler.GetReel)
+
+	// Protected routes
+	reels.Use(middleware.Protected())
+	reels.Post("/:id/view", h.ReelHandler.RecordView)
+	reels.Get("/:id/viewers", h.ReelHandler.ListViewers)
+	reels.Delete("/:id", h.ReelHandler.DeleteReel)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupReportRoutes(api fiber.Router, h *handlers.Handlers) {
+	reports := api.Group("/reports", middleware.Protected())
+
+	// Moderation queue
+	admin := reports.Group("/admin", middleware.RequirePermission(models.PermReportsReview))
+	admin.Get("/", h.ReportHandler.ListReports)
+	admin.Get("/:id", h.ReportHandler.GetReport)
+	admin.Post("/:id/resolve", h.ReportHandler.ResolveReport)
+
+	// Reporter side
+	reports.Post("/", h.ReportHandler.CreateReport)
+	reports.Get("/me", h.ReportHandler.ListMyReports)
+}
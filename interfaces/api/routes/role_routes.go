@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupRoleRoutes(api fiber.Router, h *handlers.Handlers) {
+	roles := api.Group("/roles", middleware.Protected())
+
+	// Current user
+	roles.Get("/me", h.RoleHandler.GetMyPermissions)
+
+	// Role management
+	admin := roles.Group("", middleware.RequirePermission(models.PermRolesManage))
+	admin.Get("/", h.RoleHandler.ListRoles)
+	admin.Get("/permissions", h.RoleHandler.ListPermissions)
+	admin.Get("/users/:userId", h.RoleHandler.ListUserRoles)
+	admin.Post("/users/:userId", h.RoleHandler.AssignRole)
+	admin.Delete("/users/:userId/:role", h.RoleHandler.RevokeRole)
+}
@@ -20,10 +20,15 @@ func SetupRoutes(app *fiber.App, h *handlers.Handlers, cfg ...*config.Config) {
 	SetupAuthRoutes(api, h)
 	SetupUserRoutes(api, h)
 	SetupProfileRoutes(api, h)
+	SetupRoleRoutes(api, h)
 
 	// Setup social media routes
 	SetupPostRoutes(api, h)
+	SetupCommunityRoutes(api, h)
 	SetupCommentRoutes(api, h)
+	SetupReportRoutes(api, h)
+	SetupSanctionRoutes(api, h)
+	SetupBotTokenRoutes(api, h)
 	SetupVoteRoutes(api, h)
 	SetupFollowRoutes(api, h)
 	SetupSavedPostRoutes(api, h)
@@ -37,6 +42,21 @@ func SetupRoutes(app *fiber.App, h *handlers.Handlers, cfg ...*config.Config) {
 	// Setup chat routes
 	SetupChatRoutes(api, h)
 
+	// Setup wallet routes
+	SetupWalletRoutes(api, h)
+
+	// Setup payout routes
+	SetupPayoutRoutes(api, h)
+
+	// Setup subscription routes
+	SetupSubscriptionRoutes(api, h)
+
+	// Setup reel routes
+	SetupReelRoutes(api, h)
+
+	// Setup ad routes
+	SetupAdRoutes(api, h)
+
 	// Setup upload routes
 	SetupUploadRoutes(api, h)
 
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupSanctionRoutes(api fiber.Router, h *handlers.Handlers) {
+	sanctions := api.Group("/sanctions", middleware.Protected(), middleware.RequirePermission(models.PermUserSuspend))
+
+	sanctions.Get("/users/:userId", h.SanctionHandler.ListSanctions)
+	sanctions.Post("/users/:userId", h.SanctionHandler.ApplySanction)
+	sanctions.Post("/:id/lift", h.SanctionHandler.LiftSanction)
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupSubscriptionRoutes(api fiber.Router, h *handlers.Handlers) {
+	subscriptions := api.Group("/subscriptions")
+
+	// Public routes (with optional auth)
+	subscriptions.Get("/creators/:creatorId/tiers", middleware.Optional(), h.SubscriptionHandler.ListTiers)
+
+	// Protected routes
+	subscriptions.Use(middleware.Protected())
+
+	// Creator side
+	subscriptions.Post("/tiers", h.SubscriptionHandler.CreateTier)
+	subscriptions.Put("/tiers/:id", h.SubscriptionHandler.UpdateTier)
+	subscriptions.Get("/subscribers", h.SubscriptionHandler.ListSubscribers)
+	subscriptions.Get("/stats", h.SubscriptionHandler.GetCreatorStats)
+
+	// Subscriber side
+	subscriptions.Post("/", h.SubscriptionHandler.Subscribe)
+	subscriptions.Get("/me", h.SubscriptionHandler.ListMySubscriptions)
+	subscriptions.Put("/:creatorId/tier", h.SubscriptionHandler.ChangeTier)
+	subscriptions.Post("/:creatorId/cancel", h.SubscriptionHandler.Cancel)
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
 	"gofiber-template/interfaces/api/handlers"
 	"gofiber-template/interfaces/api/middleware"
 )
@@ -10,7 +11,7 @@ func SetupTaskRoutes(api fiber.Router, h *handlers.Handlers) {
 	tasks := api.Group("/tasks")
 	tasks.Use(middleware.Protected())
 	tasks.Post("/", h.TaskHandler.CreateTask)
-	tasks.Get("/", middleware.AdminOnly(), h.TaskHandler.ListTasks)
+	tasks.Get("/", middleware.RequirePermission(models.PermTasksViewAny), h.TaskHandler.ListTasks)
 	tasks.Get("/my", h.TaskHandler.GetUserTasks)
 	tasks.Get("/:id", h.TaskHandler.GetTask)
 	tasks.Put("/:id", middleware.OwnerOnly(), h.TaskHandler.UpdateTask)
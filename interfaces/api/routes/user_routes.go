@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"gofiber-template/domain/models"
 	"gofiber-template/interfaces/api/handlers"
 	"gofiber-template/interfaces/api/middleware"
 )
@@ -11,6 +12,13 @@ func SetupUserRoutes(api fiber.Router, h *handlers.Handlers) {
 	users.Use(middleware.Protected())
 	users.Get("/profile", h.UserHandler.GetProfile)
 	users.Put("/profile", h.UserHandler.UpdateProfile)
-	users.Delete("/profile", h.UserHandler.DeleteUser)
-	users.Get("/", middleware.AdminOnly(), h.UserHandler.ListUsers)
+	users.Delete("/profile", h.AccountDataHandler.RequestDeletion)
+
+	// Personal data export and account deletion (cooling-off period)
+	users.Get("/profile/exports", h.AccountDataHandler.ListExports)
+	users.Post("/profile/exports", h.AccountDataHandler.RequestExport)
+	users.Get("/profile/deletion", h.AccountDataHandler.GetDeletionStatus)
+	users.Delete("/profile/deletion", h.AccountDataHandler.CancelDeletion)
+
+	users.Get("/", middleware.RequirePermission(models.PermUserList), h.UserHandler.ListUsers)
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"gofiber-template/interfaces/api/handlers"
+	"gofiber-template/interfaces/api/middleware"
+)
+
+func SetupWalletRoutes(api fiber.Router, h *handlers.Handlers) {
+	wallet := api.Group("/wallet", middleware.Protected())
+
+	wallet.Get("/", h.WalletHandler.GetMyWallet)
+	wallet.Get("/ledger", h.WalletHandler.ListMyLedgerEntries)
+
+	// Top-ups (PromptPay QR through the payment gateway)
+	wallet.Post("/topups", h.TopUpHandler.CreateTopUp)
+	wallet.Get("/topups", h.TopUpHandler.ListMyTopUps)
+	wallet.Get("/topups/:id", h.TopUpHandler.GetTopUp)
+	wallet.Post("/topups/:id/simulate-payment", h.TopUpHandler.SimulatePayment)
+}
@@ -11,4 +11,7 @@ func SetupWebhookRoutes(api fiber.Router, h *handlers.Handlers) {
 	// Bunny Stream webhook (no authentication - Bunny doesn't support auth headers well)
 	// Security: Validate source IP or use signed webhooks in production
 	webhooks.Post("/bunny/video-status", h.WebhookHandler.BunnyStreamWebhook)
+
+	// Payment gateway webhooks (HMAC-signed, see payment.SignatureHeader)
+	webhooks.Post("/payments/:provider", h.WebhookHandler.PaymentWebhook)
 }
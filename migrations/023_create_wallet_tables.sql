@@ -0,0 +1,108 @@
+-- Migration 023: Create Wallet & Ledger Tables
+-- Purpose: Double-entry wallet system for monetized features (unlocks, gifts, subscriptions, ads)
+-- All amounts are stored in satang (1 THB = 100 satang)
+
+-- =============================================================================
+-- Table: wallets
+-- Purpose: One wallet per user plus platform-owned system wallets
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS wallets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Owner (user wallets) or code (system wallets)
+    user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    code VARCHAR(50),
+    type VARCHAR(20) NOT NULL DEFAULT 'user',
+
+    -- Balances (satang)
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+    balance BIGINT NOT NULL DEFAULT 0,
+    held_amount BIGINT NOT NULL DEFAULT 0,
+    allow_negative BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT wallets_owner_required CHECK (user_id IS NOT NULL OR code IS NOT NULL),
+    CONSTRAINT wallets_held_not_negative CHECK (held_amount >= 0),
+    CONSTRAINT wallets_balance_not_negative CHECK (allow_negative OR balance >= held_amount)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_user_id ON wallets(user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_code ON wallets(code) WHERE code IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_wallets_type ON wallets(type);
+
+-- =============================================================================
+-- Table: ledger_transactions
+-- Purpose: Balanced group of ledger entries (one per money movement)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    idempotency_key VARCHAR(255) NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    description VARCHAR(500),
+    reference_type VARCHAR(50),
+    reference_id UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_transactions_idempotency_key ON ledger_transactions(idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_ledger_transactions_type ON ledger_transactions(type);
+CREATE INDEX IF NOT EXISTS idx_ledger_tx_reference ON ledger_transactions(reference_type, reference_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_transactions_created_at ON ledger_transactions(created_at);
+
+-- =============================================================================
+-- Table: ledger_entries
+-- Purpose: Individual debit/credit lines (negative = debit, positive = credit)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id UUID NOT NULL REFERENCES ledger_transactions(id) ON DELETE RESTRICT,
+    wallet_id UUID NOT NULL REFERENCES wallets(id) ON DELETE RESTRICT,
+    amount BIGINT NOT NULL,
+    balance_after BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT ledger_entries_amount_not_zero CHECK (amount != 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_wallet_created ON ledger_entries(wallet_id, created_at DESC);
+
+-- =============================================================================
+-- Table: wallet_holds
+-- Purpose: Reserved funds that are later released or captured
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS wallet_holds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_id UUID NOT NULL REFERENCES wallets(id) ON DELETE RESTRICT,
+    amount BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    reason VARCHAR(255),
+    idempotency_key VARCHAR(255) NOT NULL,
+    reference_type VARCHAR(50),
+    reference_id UUID,
+    capture_transaction_id UUID REFERENCES ledger_transactions(id) ON DELETE RESTRICT,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT wallet_holds_amount_positive CHECK (amount > 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_holds_idempotency_key ON wallet_holds(idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_wallet_holds_wallet_id ON wallet_holds(wallet_id);
+CREATE INDEX IF NOT EXISTS idx_wallet_holds_status ON wallet_holds(status);
+CREATE INDEX IF NOT EXISTS idx_wallet_holds_expires_at ON wallet_holds(expires_at) WHERE status = 'active';
+
+COMMENT ON TABLE wallets IS 'User and system wallets (balances in satang)';
+COMMENT ON TABLE ledger_transactions IS 'Balanced double-entry transactions; idempotency_key prevents double application';
+COMMENT ON TABLE ledger_entries IS 'Ledger lines; entries of one transaction always sum to zero';
+COMMENT ON TABLE wallet_holds IS 'Reserved wallet funds (active, released, captured)';
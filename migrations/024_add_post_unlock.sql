@@ -0,0 +1,60 @@
+-- Migration 024: Premium Unlock Posts (ค่าเสือก)
+-- Purpose: Crowdfunded posts - content is hidden until contributions reach the target
+-- All amounts are stored in satang (1 THB = 100 satang)
+
+-- =============================================================================
+-- Posts: unlock columns (NULL target = normal post)
+-- =============================================================================
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS unlock_target_amount BIGINT;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS unlock_current_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS unlock_contributor_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS unlock_status VARCHAR(20);
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS unlock_deadline TIMESTAMP WITH TIME ZONE;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS unlock_preview_url VARCHAR(500);
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS unlocked_at TIMESTAMP WITH TIME ZONE;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'posts_unlock_status_check') THEN
+        ALTER TABLE posts ADD CONSTRAINT posts_unlock_status_check
+            CHECK (unlock_status IS NULL OR unlock_status IN ('locked', 'unlocked', 'failed'));
+    END IF;
+END $$;
+
+-- Deadline scan for the expiry job
+CREATE INDEX IF NOT EXISTS idx_posts_unlock_deadline ON posts(unlock_deadline)
+    WHERE unlock_status = 'locked' AND unlock_deadline IS NOT NULL;
+
+-- =============================================================================
+-- Table: post_unlock_contributions
+-- Purpose: Each contribution is a wallet hold, captured on unlock or released on failure
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS post_unlock_contributions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'held',
+    hold_id UUID NOT NULL REFERENCES wallet_holds(id) ON DELETE RESTRICT,
+    idempotency_key VARCHAR(255) NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT post_unlock_contributions_amount_positive CHECK (amount > 0),
+    CONSTRAINT post_unlock_contributions_status_check CHECK (status IN ('held', 'captured', 'released'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_post_unlock_contributions_hold_id ON post_unlock_contributions(hold_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_post_unlock_contributions_idempotency_key ON post_unlock_contributions(idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_post_unlock_contributions_post_user ON post_unlock_contributions(post_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_post_unlock_contributions_user_id ON post_unlock_contributions(user_id);
+CREATE INDEX IF NOT EXISTS idx_post_unlock_contributions_held ON post_unlock_contributions(post_id)
+    WHERE status = 'held';
+
+COMMENT ON TABLE post_unlock_contributions IS 'Contributions towards unlocking premium posts (ค่าเสือก)';
+COMMENT ON COLUMN post_unlock_contributions.hold_id IS 'Wallet hold reserving the contribution until the post unlocks or fails';
@@ -0,0 +1,91 @@
+-- Migration 025: Gift Box Messages
+-- Purpose: Paid gift boxes in chat - content is sealed until the receiver opens it
+-- All amounts are stored in satang (1 THB = 100 satang)
+
+-- =============================================================================
+-- Table: gift_packages
+-- Purpose: Gift catalog (price, receiver share and media limits)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS gift_packages (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(30) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    price BIGINT NOT NULL,
+    receiver_percent INTEGER NOT NULL,
+    max_images INTEGER NOT NULL DEFAULT 0,
+    max_videos INTEGER NOT NULL DEFAULT 0,
+    max_message_length INTEGER NOT NULL DEFAULT 500,
+    icon VARCHAR(50),
+    color VARCHAR(20),
+    sort_order INTEGER DEFAULT 0,
+    is_active BOOLEAN DEFAULT TRUE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT gift_packages_price_positive CHECK (price > 0),
+    CONSTRAINT gift_packages_receiver_percent_range CHECK (receiver_percent BETWEEN 0 AND 100)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gift_packages_code ON gift_packages(code);
+CREATE INDEX IF NOT EXISTS idx_gift_packages_is_active ON gift_packages(is_active);
+
+-- Default packages
+INSERT INTO gift_packages (code, name, price, receiver_percent, max_images, max_videos, max_message_length, icon, color, sort_order)
+VALUES
+    ('BRONZE', 'Bronze', 2000, 75, 1, 0, 500, '🥉', '#CD7F32', 1),
+    ('SILVER', 'Silver', 5000, 80, 3, 1, 500, '🥈', '#C0C0C0', 2),
+    ('GOLD', 'Gold', 10000, 85, 5, 1, 500, '🥇', '#FFD700', 3),
+    ('DIAMOND', 'Diamond', 50000, 90, 10, 3, 500, '💎', '#B9F2FF', 4)
+ON CONFLICT (code) DO NOTHING;
+
+-- =============================================================================
+-- Table: gifts
+-- Purpose: One row per gift message, payment is held in the escrow wallet until settled
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS gifts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    sender_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    receiver_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    package_id UUID NOT NULL REFERENCES gift_packages(id) ON DELETE RESTRICT,
+
+    -- Amounts (satang)
+    amount_paid BIGINT NOT NULL,
+    receiver_amount BIGINT NOT NULL,
+    platform_fee BIGINT NOT NULL,
+
+    -- Lifecycle
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    opened_at TIMESTAMP WITH TIME ZONE,
+    settled_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT gifts_amounts_valid CHECK (amount_paid > 0 AND receiver_amount >= 0 AND platform_fee >= 0
+        AND receiver_amount + platform_fee = amount_paid),
+    CONSTRAINT gifts_status_check CHECK (status IN ('pending', 'opened', 'expired', 'refunded')),
+    CONSTRAINT gifts_no_self_gift CHECK (sender_id <> receiver_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gifts_message_id ON gifts(message_id);
+CREATE INDEX IF NOT EXISTS idx_gifts_conversation_id ON gifts(conversation_id);
+CREATE INDEX IF NOT EXISTS idx_gifts_receiver_id ON gifts(receiver_id);
+CREATE INDEX IF NOT EXISTS idx_gifts_sender_created ON gifts(sender_id, created_at DESC);
+
+-- Expiry scan and settlement retry
+CREATE INDEX IF NOT EXISTS idx_gifts_pending_expires_at ON gifts(expires_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_gifts_unsettled ON gifts(status) WHERE settled_at IS NULL AND status <> 'pending';
+
+COMMENT ON TABLE gift_packages IS 'Gift box packages available in chat';
+COMMENT ON TABLE gifts IS 'Paid gift box messages - escrowed until opened (receiver paid) or expired (sender refunded)';
+COMMENT ON COLUMN gifts.settled_at IS 'When the escrowed payment was paid out or refunded';
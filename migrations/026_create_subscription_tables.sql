@@ -0,0 +1,155 @@
+-- Migration 026: Creator Subscriptions
+-- Purpose: Paid monthly/yearly subscription tiers and subscriber-only posts
+-- All amounts are stored in satang (1 THB = 100 satang)
+
+-- =============================================================================
+-- Posts: minimum tier level required to see the content (NULL = public)
+-- =============================================================================
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS min_tier_level INTEGER;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'posts_min_tier_level_check') THEN
+        ALTER TABLE posts ADD CONSTRAINT posts_min_tier_level_check
+            CHECK (min_tier_level IS NULL OR min_tier_level BETWEEN 1 AND 5);
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_posts_min_tier_level ON posts(min_tier_level) WHERE min_tier_level IS NOT NULL;
+
+-- =============================================================================
+-- Table: subscription_tiers
+-- Purpose: Up to 5 active tiers per creator, higher levels include lower tier content
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS subscription_tiers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    creator_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    tier_level INTEGER NOT NULL,
+    price_monthly BIGINT NOT NULL,
+    price_yearly BIGINT,
+    perks JSONB,
+    trial_days INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN DEFAULT TRUE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT subscription_tiers_level_range CHECK (tier_level BETWEEN 1 AND 5),
+    CONSTRAINT subscription_tiers_price_positive CHECK (price_monthly > 0 AND (price_yearly IS NULL OR price_yearly > 0)),
+    CONSTRAINT subscription_tiers_trial_days_range CHECK (trial_days BETWEEN 0 AND 30)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_tiers_creator_id ON subscription_tiers(creator_id);
+CREATE INDEX IF NOT EXISTS idx_subscription_tiers_is_active ON subscription_tiers(is_active);
+
+-- One active tier per level per creator
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subscription_tiers_creator_level_active
+    ON subscription_tiers(creator_id, tier_level) WHERE is_active = TRUE;
+
+-- =============================================================================
+-- Table: subscriptions
+-- Purpose: One row per subscriber/creator pair, price is locked in (grandfather pricing)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscriber_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    creator_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tier_id UUID NOT NULL REFERENCES subscription_tiers(id) ON DELETE RESTRICT,
+
+    -- Billing
+    status VARCHAR(20) NOT NULL,
+    billing_cycle VARCHAR(10) NOT NULL DEFAULT 'monthly',
+    price BIGINT NOT NULL,
+    current_period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    current_period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    trial_end TIMESTAMP WITH TIME ZONE,
+    has_used_trial BOOLEAN DEFAULT FALSE,
+
+    -- Cancellation
+    cancel_at_period_end BOOLEAN DEFAULT FALSE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    cancel_reason VARCHAR(500),
+
+    -- Scheduled downgrade (applied at renewal)
+    pending_tier_id UUID REFERENCES subscription_tiers(id) ON DELETE SET NULL,
+
+    -- Failed payment handling (3 attempts, then 7 day grace period)
+    failed_payment_count INTEGER DEFAULT 0,
+    next_retry_at TIMESTAMP WITH TIME ZONE,
+    grace_until TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT subscriptions_status_check CHECK (status IN ('trial', 'active', 'cancelled', 'payment_failed', 'expired')),
+    CONSTRAINT subscriptions_billing_cycle_check CHECK (billing_cycle IN ('monthly', 'yearly')),
+    CONSTRAINT subscriptions_price_non_negative CHECK (price >= 0),
+    CONSTRAINT subscriptions_no_self_subscription CHECK (subscriber_id <> creator_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subscriptions_subscriber_creator ON subscriptions(subscriber_id, creator_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_creator_id ON subscriptions(creator_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_tier_id ON subscriptions(tier_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_status ON subscriptions(status);
+
+-- Renewal and expiry scans
+CREATE INDEX IF NOT EXISTS idx_subscriptions_current_period_end ON subscriptions(current_period_end)
+    WHERE status IN ('trial', 'active', 'cancelled', 'payment_failed');
+CREATE INDEX IF NOT EXISTS idx_subscriptions_next_retry_at ON subscriptions(next_retry_at) WHERE next_retry_at IS NOT NULL;
+
+-- =============================================================================
+-- Table: subscription_payments
+-- Purpose: Charge history (80% creator / 20% platform), linked to the wallet ledger
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS subscription_payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES subscriptions(id) ON DELETE CASCADE,
+    subscriber_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    creator_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tier_id UUID NOT NULL REFERENCES subscription_tiers(id) ON DELETE RESTRICT,
+
+    -- Amounts (satang)
+    amount BIGINT NOT NULL,
+    creator_amount BIGINT NOT NULL,
+    platform_fee BIGINT NOT NULL,
+
+    status VARCHAR(20) NOT NULL,
+    billing_reason VARCHAR(30) NOT NULL,
+    ledger_tx_id UUID REFERENCES ledger_transactions(id) ON DELETE RESTRICT,
+    failure_reason VARCHAR(255),
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT subscription_payments_amounts_valid CHECK (amount >= 0 AND creator_amount >= 0 AND platform_fee >= 0
+        AND creator_amount + platform_fee = amount),
+    CONSTRAINT subscription_payments_status_check CHECK (status IN ('success', 'failed', 'refunded')),
+    CONSTRAINT subscription_payments_billing_reason_check
+        CHECK (billing_reason IN ('subscription_create', 'subscription_cycle', 'subscription_update'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subscription_payments_ledger_tx_id ON subscription_payments(ledger_tx_id)
+    WHERE ledger_tx_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_subscription_payments_subscription_created
+    ON subscription_payments(subscription_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_subscription_payments_creator_id ON subscription_payments(creator_id);
+CREATE INDEX IF NOT EXISTS idx_subscription_payments_subscriber_id ON subscription_payments(subscriber_id);
+
+COMMENT ON TABLE subscription_tiers IS 'Paid subscription tiers defined by creators';
+COMMENT ON TABLE subscriptions IS 'Creator subscriptions - renewed by the scheduler, cancelled ones keep access until the period ends';
+COMMENT ON TABLE subscription_payments IS 'Subscription charges, refunds and failed renewal attempts';
+COMMENT ON COLUMN posts.min_tier_level IS 'Minimum subscription tier level required to see the post (NULL = public)';
@@ -0,0 +1,62 @@
+-- Migration 027: Reels
+-- Purpose: Ephemeral vertical videos (24h) streamed from Bunny Stream, with per-viewer lists
+-- Reel videos are uploaded through MediaService.CreateVideo (media.source_type = 'reel')
+
+-- =============================================================================
+-- Table: reels
+-- Purpose: One row per reel, kept after expiry for analytics (video is deleted)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS reels (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    creator_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    media_id UUID REFERENCES media(id) ON DELETE SET NULL,
+
+    caption VARCHAR(150),
+    status VARCHAR(20) NOT NULL DEFAULT 'processing',
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+
+    -- Stats
+    view_count INTEGER NOT NULL DEFAULT 0,
+
+    -- Lifetime (24 hours from going live)
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT reels_status_check CHECK (status IN ('processing', 'active', 'failed', 'expired', 'deleted')),
+    CONSTRAINT reels_visibility_check CHECK (visibility IN ('public', 'followers')),
+    CONSTRAINT reels_view_count_positive CHECK (view_count >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reels_creator_created ON reels(creator_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_reels_media_id ON reels(media_id) WHERE media_id IS NOT NULL;
+
+-- Feed and expiry scheduler only look at reels that still have a video
+CREATE INDEX IF NOT EXISTS idx_reels_status_expires_at ON reels(status, expires_at)
+    WHERE status IN ('processing', 'active', 'failed');
+
+-- =============================================================================
+-- Table: reel_views
+-- Purpose: One row per viewer per reel (viewer list for the creator), removed at expiry
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS reel_views (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reel_id UUID NOT NULL REFERENCES reels(id) ON DELETE CASCADE,
+    viewer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    watch_duration DOUBLE PRECISION NOT NULL DEFAULT 0,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    viewed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reel_views_reel_viewer ON reel_views(reel_id, viewer_id);
+CREATE INDEX IF NOT EXISTS idx_reel_views_reel_viewed_at ON reel_views(reel_id, viewed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_reel_views_viewer_id ON reel_views(viewer_id);
+
+COMMENT ON TABLE reels IS 'Reels - vertical videos that expire 24 hours after going live';
+COMMENT ON TABLE reel_views IS 'Unique viewers of a reel - deleted when the reel expires';
+COMMENT ON COLUMN reels.view_count IS 'Unique viewers (creator views are not counted)';
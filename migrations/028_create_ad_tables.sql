@@ -0,0 +1,178 @@
+-- Migration 028: Self-service ads
+-- Purpose: Advertisers book a placement for a date range, pay by bank transfer slip,
+--          and the ad goes live automatically once an admin verifies the slip
+-- Creatives and slips are uploaded through the presigned upload flow (media table)
+-- Amounts are stored in satang (1 THB = 100 satang)
+
+-- =============================================================================
+-- Table: ad_placements
+-- Purpose: Bookable slots with daily price and capacity (edited by admins)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ad_placements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(30) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500),
+    price_per_day BIGINT NOT NULL,
+    max_active_ads INTEGER NOT NULL DEFAULT 10,
+    feed_interval INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN DEFAULT TRUE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT ad_placements_code_check CHECK (code IN ('feed', 'sidebar', 'post_detail')),
+    CONSTRAINT ad_placements_price_positive CHECK (price_per_day > 0),
+    CONSTRAINT ad_placements_max_active_positive CHECK (max_active_ads > 0),
+    CONSTRAINT ad_placements_feed_interval_positive CHECK (feed_interval >= 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ad_placements_code ON ad_placements(code);
+
+-- Default placements (1,000 THB/day in the feed, 500 THB/day elsewhere)
+INSERT INTO ad_placements (code, name, description, price_per_day, max_active_ads, feed_interval)
+VALUES
+    ('feed', 'Feed', 'Sponsored card shown between posts in the home and following feeds', 100000, 10, 5),
+    ('sidebar', 'Sidebar', 'Banner in the desktop sidebar', 50000, 5, 0),
+    ('post_detail', 'Post detail', 'Banner below the post on the post detail page', 50000, 5, 0)
+ON CONFLICT (code) DO NOTHING;
+
+-- =============================================================================
+-- Table: ads
+-- Purpose: One row per campaign (one placement, one creative, one date range)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ads (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    advertiser_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    placement_id UUID NOT NULL REFERENCES ad_placements(id) ON DELETE RESTRICT,
+
+    -- Creative
+    campaign_name VARCHAR(100) NOT NULL,
+    description VARCHAR(300),
+    destination_url VARCHAR(2048) NOT NULL,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE RESTRICT,
+
+    -- Booking
+    status VARCHAR(20) NOT NULL DEFAULT 'pending_payment',
+    days INTEGER NOT NULL,
+    price_per_day BIGINT NOT NULL,
+    discount BIGINT NOT NULL DEFAULT 0,
+    total_cost BIGINT NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- Stats
+    impression_count BIGINT DEFAULT 0,
+    click_count BIGINT DEFAULT 0,
+
+    removal_reason VARCHAR(500),
+    activated_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT ads_status_check CHECK (status IN ('pending_payment', 'payment_review', 'scheduled', 'active', 'paused', 'completed', 'cancelled', 'removed')),
+    CONSTRAINT ads_days_range CHECK (days BETWEEN 1 AND 90),
+    CONSTRAINT ads_total_cost_valid CHECK (total_cost = price_per_day * days - discount AND total_cost > 0),
+    CONSTRAINT ads_period_valid CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ads_advertiser_created ON ads(advertiser_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ads_status ON ads(status);
+CREATE INDEX IF NOT EXISTS idx_ads_media_id ON ads(media_id);
+
+-- Serving, capacity checks and the scheduler only look at booked ads
+CREATE INDEX IF NOT EXISTS idx_ads_placement_period ON ads(placement_id, starts_at, ends_at)
+    WHERE status IN ('scheduled', 'active', 'paused');
+
+-- =============================================================================
+-- Table: ad_payments
+-- Purpose: Bank transfer slips submitted by advertisers, reviewed by admins
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ad_payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ad_id UUID NOT NULL REFERENCES ads(id) ON DELETE CASCADE,
+    advertiser_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    amount BIGINT NOT NULL,
+    method VARCHAR(20) NOT NULL DEFAULT 'bank_transfer',
+    slip_media_id UUID NOT NULL REFERENCES media(id) ON DELETE RESTRICT,
+    bank_reference VARCHAR(100),
+    transferred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- Review
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    rejection_reason VARCHAR(500),
+    ledger_tx_id UUID REFERENCES ledger_transactions(id) ON DELETE RESTRICT,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT ad_payments_status_check CHECK (status IN ('pending', 'verified', 'rejected')),
+    CONSTRAINT ad_payments_method_check CHECK (method IN ('bank_transfer')),
+    CONSTRAINT ad_payments_amount_positive CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ad_payments_ad_created ON ad_payments(ad_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ad_payments_advertiser_id ON ad_payments(advertiser_id);
+CREATE INDEX IF NOT EXISTS idx_ad_payments_status_created ON ad_payments(status, created_at);
+
+-- =============================================================================
+-- Table: ad_impressions
+-- Purpose: One row per ad shown (frequency capping and daily stats)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ad_impressions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ad_id UUID NOT NULL REFERENCES ads(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    placement VARCHAR(30) NOT NULL,
+    view_duration_ms INTEGER DEFAULT 0,
+    device_type VARCHAR(20),
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(500),
+    viewed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ad_impressions_ad_viewed ON ad_impressions(ad_id, viewed_at);
+CREATE INDEX IF NOT EXISTS idx_ad_impressions_user_viewed ON ad_impressions(user_id, viewed_at)
+    WHERE user_id IS NOT NULL;
+
+-- =============================================================================
+-- Table: ad_clicks
+-- Purpose: One row per click through to the destination URL
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS ad_clicks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ad_id UUID NOT NULL REFERENCES ads(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    impression_id UUID REFERENCES ad_impressions(id) ON DELETE SET NULL,
+    placement VARCHAR(30) NOT NULL,
+    referrer_url VARCHAR(2048),
+    device_type VARCHAR(20),
+    ip_address VARCHAR(45),
+    clicked_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ad_clicks_ad_clicked ON ad_clicks(ad_id, clicked_at);
+CREATE INDEX IF NOT EXISTS idx_ad_clicks_user_id ON ad_clicks(user_id) WHERE user_id IS NOT NULL;
+
+COMMENT ON TABLE ad_placements IS 'Ad placements - bookable slots with daily price and capacity';
+COMMENT ON TABLE ads IS 'Ads - self-service campaigns booked per placement and date range';
+COMMENT ON TABLE ad_payments IS 'Ad payments - bank transfer slips verified by admins';
+COMMENT ON TABLE ad_impressions IS 'Ad impressions - one row per ad shown';
+COMMENT ON TABLE ad_clicks IS 'Ad clicks - one row per click through';
+COMMENT ON COLUMN ads.status IS 'pending_payment -> payment_review -> scheduled -> active <-> paused -> completed (or cancelled/removed)';
+COMMENT ON COLUMN ad_placements.feed_interval IS 'Feed placement only: one ad every N posts';
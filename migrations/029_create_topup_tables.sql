@@ -0,0 +1,50 @@
+-- Migration 029: Wallet top-ups
+-- Purpose: Top-up intents paid by PromptPay QR through a payment gateway adapter
+-- Lifecycle: pending -> paid -> credited, or pending -> expired (late payments still move expired -> paid)
+-- Amounts are stored in satang (1 THB = 100 satang)
+
+-- =============================================================================
+-- Table: topup_intents
+-- Purpose: One row per top-up attempt, credited to the wallet once the gateway confirms payment
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS topup_intents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Amount
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+
+    -- Gateway
+    provider VARCHAR(30) NOT NULL,
+    provider_charge_id VARCHAR(100) NOT NULL,
+    qr_payload TEXT NOT NULL,
+
+    -- Lifecycle
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    paid_at TIMESTAMP WITH TIME ZONE,
+    credited_at TIMESTAMP WITH TIME ZONE,
+    ledger_tx_id UUID REFERENCES ledger_transactions(id) ON DELETE RESTRICT,
+    provider_event_id VARCHAR(100),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT topup_intents_status_check CHECK (status IN ('pending', 'paid', 'credited', 'expired')),
+    CONSTRAINT topup_intents_amount_positive CHECK (amount > 0),
+    CONSTRAINT topup_intents_credited_has_ledger CHECK (status <> 'credited' OR ledger_tx_id IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_topup_intents_provider_charge ON topup_intents(provider, provider_charge_id);
+CREATE INDEX IF NOT EXISTS idx_topup_intents_user_created ON topup_intents(user_id, created_at DESC);
+
+-- Scheduler only looks at unfinished intents
+CREATE INDEX IF NOT EXISTS idx_topup_intents_status_expires_at ON topup_intents(status, expires_at)
+    WHERE status IN ('pending', 'paid');
+
+COMMENT ON TABLE topup_intents IS 'Top-up intents - PromptPay QR payments that credit the wallet once confirmed';
+COMMENT ON COLUMN topup_intents.provider_event_id IS 'Last gateway webhook event applied (audit)';
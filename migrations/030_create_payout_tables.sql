@@ -0,0 +1,63 @@
+-- Migration 030: Creator payouts
+-- Purpose: Withdrawals of earned wallet balance to a bank account or PromptPay ID
+-- Lifecycle: pending (wallet hold) -> approved (ledger debit) -> paid (bank batch transfer)
+--            pending -> rejected/cancelled (hold released), approved -> failed (ledger refund)
+-- Amounts are stored in satang (1 THB = 100 satang)
+
+-- =============================================================================
+-- Table: payouts
+-- Purpose: One row per withdrawal request, reviewed by admins
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS payouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+
+    -- Amounts
+    amount BIGINT NOT NULL,
+    fee BIGINT NOT NULL DEFAULT 0,
+    net_amount BIGINT NOT NULL,
+
+    -- Destination (snapshot at request time)
+    destination_type VARCHAR(20) NOT NULL,
+    bank_code VARCHAR(10),
+    account_number VARCHAR(30) NOT NULL,
+    account_name VARCHAR(100) NOT NULL,
+
+    -- Lifecycle
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    hold_id UUID REFERENCES wallet_holds(id) ON DELETE SET NULL,
+    ledger_tx_id UUID REFERENCES ledger_transactions(id) ON DELETE RESTRICT,
+    refund_tx_id UUID REFERENCES ledger_transactions(id) ON DELETE RESTRICT,
+
+    -- Review
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    rejection_reason VARCHAR(500),
+
+    -- Transfer
+    bank_reference VARCHAR(100),
+    paid_at TIMESTAMP WITH TIME ZONE,
+    failure_reason VARCHAR(500),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT payouts_status_check CHECK (status IN ('pending', 'approved', 'paid', 'rejected', 'cancelled', 'failed')),
+    CONSTRAINT payouts_destination_type_check CHECK (destination_type IN ('bank_account', 'promptpay')),
+    CONSTRAINT payouts_bank_code_required CHECK (destination_type <> 'bank_account' OR bank_code IS NOT NULL),
+    CONSTRAINT payouts_amount_positive CHECK (amount > 0 AND fee >= 0 AND net_amount > 0),
+    CONSTRAINT payouts_net_amount_valid CHECK (net_amount = amount - fee)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payouts_user_created ON payouts(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payouts_status_created ON payouts(status, created_at);
+
+-- One pending request per creator
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payouts_one_pending_per_user ON payouts(user_id)
+    WHERE status = 'pending';
+
+COMMENT ON TABLE payouts IS 'Payouts - creator withdrawals reviewed by admins and paid by bank batch transfer';
+COMMENT ON COLUMN payouts.net_amount IS 'Amount transferred to the creator (amount minus platform fee)';
@@ -0,0 +1,88 @@
+-- Migration 031: Communities
+-- Purpose: User-created groups with owners, moderators, members, rules and their own post feed
+-- Visibility: public (open), restricted (visible, approval to join), private (members only)
+
+-- =============================================================================
+-- Table: communities
+-- Purpose: One row per community, addressed by its immutable slug
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS communities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    slug VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    rules JSONB NOT NULL DEFAULT '[]'::jsonb,
+
+    -- Branding
+    icon_media_id UUID REFERENCES media(id) ON DELETE SET NULL,
+    banner_media_id UUID REFERENCES media(id) ON DELETE SET NULL,
+
+    -- Access
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    posting_policy VARCHAR(20) NOT NULL DEFAULT 'members',
+
+    -- Owner
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+
+    -- Stats
+    member_count INTEGER NOT NULL DEFAULT 0,
+    post_count INTEGER NOT NULL DEFAULT 0,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT communities_slug_format CHECK (slug ~ '^[a-z0-9_]{3,50}$'),
+    CONSTRAINT communities_visibility_check CHECK (visibility IN ('public', 'restricted', 'private')),
+    CONSTRAINT communities_posting_policy_check CHECK (posting_policy IN ('members', 'moderators')),
+    CONSTRAINT communities_counts_non_negative CHECK (member_count >= 0 AND post_count >= 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_communities_slug ON communities(slug);
+CREATE INDEX IF NOT EXISTS idx_communities_owner_id ON communities(owner_id);
+
+-- Discovery lists public and restricted communities by size
+CREATE INDEX IF NOT EXISTS idx_communities_visibility_members ON communities(visibility, member_count DESC);
+
+-- =============================================================================
+-- Table: community_members
+-- Purpose: One row per community/user pair (join requests and bans included)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS community_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    joined_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT community_members_role_check CHECK (role IN ('owner', 'moderator', 'member')),
+    CONSTRAINT community_members_status_check CHECK (status IN ('pending', 'active', 'banned'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_community_members_community_user ON community_members(community_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_community_members_user_status ON community_members(user_id, status);
+CREATE INDEX IF NOT EXISTS idx_community_members_community_status ON community_members(community_id, status);
+
+-- =============================================================================
+-- Posts: community column (NULL = personal post)
+-- =============================================================================
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS community_id UUID REFERENCES communities(id) ON DELETE SET NULL;
+
+-- Community feeds (hot/new/top/controversial all filter on published, non-deleted posts)
+CREATE INDEX IF NOT EXISTS idx_posts_community_created ON posts(community_id, created_at DESC)
+    WHERE community_id IS NOT NULL AND is_deleted = false AND status = 'published';
+
+COMMENT ON TABLE communities IS 'Communities - user-created groups with their own feed, rules and moderators';
+COMMENT ON TABLE community_members IS 'Community memberships - pending join requests, active members and bans';
+COMMENT ON COLUMN communities.rules IS 'Ordered list of {title, description} rules shown in the community sidebar';
+COMMENT ON COLUMN communities.member_count IS 'Active members (owner included)';
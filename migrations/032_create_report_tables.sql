@@ -0,0 +1,66 @@
+-- Migration 032: Content reports and moderation queue
+-- Purpose: Users flag posts, comments, messages and users; moderators triage the queue
+-- Lifecycle: open -> resolved (content removed, user warned or suspended) / dismissed
+
+-- =============================================================================
+-- Table: reports
+-- Purpose: One row per reporter per target (a new report is allowed once the old one is closed)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Target (no FK - messages can be hard deleted, the snapshot is kept as evidence)
+    target_type VARCHAR(20) NOT NULL,
+    target_id UUID NOT NULL,
+    target_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    snapshot TEXT,
+
+    -- Reason
+    reason_code VARCHAR(30) NOT NULL,
+    details TEXT,
+
+    -- Resolution
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    action VARCHAR(20),
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    resolution_note VARCHAR(500),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT reports_target_type_check CHECK (target_type IN ('post', 'comment', 'message', 'user')),
+    CONSTRAINT reports_reason_code_check CHECK (reason_code IN (
+        'spam', 'harassment', 'hate_speech', 'violence', 'sexual_content',
+        'misinformation', 'self_harm', 'illegal', 'impersonation', 'other'
+    )),
+    CONSTRAINT reports_status_check CHECK (status IN ('open', 'resolved', 'dismissed')),
+    CONSTRAINT reports_action_check CHECK (action IS NULL OR action IN ('dismiss', 'remove_content', 'warn', 'suspend'))
+);
+
+-- Moderation queue (oldest open reports first)
+CREATE INDEX IF NOT EXISTS idx_reports_status_created ON reports(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_reports_target ON reports(target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_reports_target_user_id ON reports(target_user_id);
+CREATE INDEX IF NOT EXISTS idx_reports_reporter_created ON reports(reporter_id, created_at DESC);
+
+-- One open report per reporter per target
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_one_open_per_reporter ON reports(reporter_id, target_type, target_id)
+    WHERE status = 'open';
+
+-- =============================================================================
+-- Posts & comments: moderator removal
+-- =============================================================================
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS removed_by_id UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS removal_reason VARCHAR(500);
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS removed_by_id UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS removal_reason VARCHAR(500);
+
+COMMENT ON TABLE reports IS 'Reports - user flags on posts, comments, messages and users for the moderation queue';
+COMMENT ON COLUMN reports.snapshot IS 'Reported content at report time, kept after the content is removed';
+COMMENT ON COLUMN posts.removed_by_id IS 'Moderator that removed the post (NULL when deleted by the author)';
@@ -0,0 +1,45 @@
+-- Migration 033: Sessions (refresh tokens per device)
+-- Purpose: Short-lived access tokens are renewed with a rotating refresh token bound to a session
+-- Lifecycle: active -> revoked (logout, remote logout, refresh token reuse) / expired
+
+-- =============================================================================
+-- Table: sessions
+-- Purpose: One row per signed-in device, stores only the hash of the current refresh token
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Rotating refresh token (SHA-256 hex)
+    refresh_token_hash VARCHAR(64) NOT NULL,
+
+    -- Device
+    user_agent VARCHAR(500),
+    ip_address VARCHAR(45),
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Lifetime
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_reason VARCHAR(30),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT sessions_revoked_reason_check CHECK (revoked_reason IS NULL OR revoked_reason IN (
+        'logout', 'revoked_by_user', 'refresh_token_reused', 'account_disabled'
+    ))
+);
+
+-- Session list (active sessions of a user, most recent first)
+CREATE INDEX IF NOT EXISTS idx_sessions_user_active ON sessions(user_id, last_seen_at DESC)
+    WHERE revoked_at IS NULL;
+
+-- Cleanup of expired sessions
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions(expires_at);
+
+COMMENT ON TABLE sessions IS 'Sessions - signed-in devices with a rotating refresh token';
+COMMENT ON COLUMN sessions.refresh_token_hash IS 'SHA-256 of the current refresh token secret, replaced on every refresh';
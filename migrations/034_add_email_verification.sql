@@ -0,0 +1,52 @@
+-- Migration 034: Email verification and password reset
+-- Purpose: New accounts confirm their email; forgotten passwords are reset through an emailed link
+-- Lifecycle: token issued -> used (single use) / expired / superseded by a newer token
+
+-- =============================================================================
+-- Users: email verification
+-- Existing accounts are grandfathered as verified, new rows default to unverified
+-- =============================================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE users ALTER COLUMN email_verified SET DEFAULT false;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- =============================================================================
+-- Table: user_tokens
+-- Purpose: One row per emailed link, the signed token only carries the row id
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(20) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+
+    -- Lifetime
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    -- Constraints
+    CONSTRAINT user_tokens_purpose_check CHECK (purpose IN ('verify_email', 'reset_password'))
+);
+
+-- Per-account send throttle and invalidation of older links
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user_purpose_created ON user_tokens(user_id, purpose, created_at DESC);
+
+-- Cleanup of expired tokens
+CREATE INDEX IF NOT EXISTS idx_user_tokens_expires_at ON user_tokens(expires_at);
+
+-- =============================================================================
+-- Sessions: password reset signs out every device
+-- =============================================================================
+
+ALTER TABLE sessions DROP CONSTRAINT IF EXISTS sessions_revoked_reason_check;
+ALTER TABLE sessions ADD CONSTRAINT sessions_revoked_reason_check CHECK (revoked_reason IS NULL OR revoked_reason IN (
+    'logout', 'revoked_by_user', 'refresh_token_reused', 'account_disabled', 'password_reset'
+));
+
+COMMENT ON TABLE user_tokens IS 'User tokens - single-use email verification and password reset links';
+COMMENT ON COLUMN users.email_verified IS 'Email confirmed through a verification link (or by the OAuth provider)';
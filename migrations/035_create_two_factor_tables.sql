@@ -0,0 +1,59 @@
+-- Migration 035: TOTP two-factor authentication
+-- Purpose: Optional second factor for password login, required for payouts
+-- Lifecycle: setup (pending secret) -> enabled (code confirmed, recovery codes issued) -> disabled (rows removed)
+
+-- =============================================================================
+-- Users: two-factor flag (checked on every password login)
+-- =============================================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS two_factor_enabled BOOLEAN NOT NULL DEFAULT false;
+
+-- =============================================================================
+-- Table: user_totp
+-- Purpose: One TOTP secret per user, encrypted at rest
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret_encrypted TEXT NOT NULL,
+
+    -- NULL while the setup is not confirmed with a code
+    enabled_at TIMESTAMP WITH TIME ZONE,
+
+    -- Last accepted 30s time step (a code cannot be replayed)
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+-- =============================================================================
+-- Table: user_recovery_codes
+-- Purpose: Ten one-time codes for when the authenticator is lost (SHA-256 hex, never stored raw)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_recovery_codes_user_hash ON user_recovery_codes(user_id, code_hash);
+
+-- =============================================================================
+-- User tokens: login challenges (password accepted, waiting for the second factor)
+-- =============================================================================
+
+ALTER TABLE user_tokens ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE user_tokens DROP CONSTRAINT IF EXISTS user_tokens_purpose_check;
+ALTER TABLE user_tokens ADD CONSTRAINT user_tokens_purpose_check CHECK (purpose IN (
+    'verify_email', 'reset_password', 'mfa_challenge'
+));
+
+COMMENT ON TABLE user_totp IS 'User TOTP - authenticator app secrets for two-factor login';
+COMMENT ON TABLE user_recovery_codes IS 'User recovery codes - one-time backup codes for two-factor login';
+COMMENT ON COLUMN user_tokens.attempts IS 'Wrong codes entered against an mfa_challenge token';
@@ -0,0 +1,36 @@
+-- Migration 036: Linked external identities
+-- Purpose: One user can log in with several providers (Google, LINE, Facebook, OIDC issuers)
+-- Replaces: users.o_auth_provider / users.o_auth_id (kept for now, no longer written)
+
+-- =============================================================================
+-- Table: user_identities
+-- Purpose: (provider, subject) -> user, at most one identity per provider per user
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(30) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    last_login_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_provider_subject ON user_identities(provider, subject);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_user_provider ON user_identities(user_id, provider);
+
+-- =============================================================================
+-- Backfill: single OAuth pair stored on users
+-- =============================================================================
+
+INSERT INTO user_identities (user_id, provider, subject, email, created_at)
+SELECT id, o_auth_provider, o_auth_id, email, created_at
+FROM users
+WHERE o_auth_provider IS NOT NULL AND o_auth_provider <> ''
+  AND o_auth_id IS NOT NULL AND o_auth_id <> ''
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE user_identities IS 'User identities - external logins linked to a user account';
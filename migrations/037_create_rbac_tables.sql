@@ -0,0 +1,110 @@
+-- Migration 037: Roles and permissions
+-- Purpose: Replace users.role ('user' / 'admin') with roles granting named permissions
+-- Scopes: global roles apply everywhere, community roles are granted per community
+
+-- =============================================================================
+-- Table: permissions
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255)
+);
+
+-- =============================================================================
+-- Table: roles
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255),
+    scope VARCHAR(20) NOT NULL DEFAULT 'global',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+ALTER TABLE roles DROP CONSTRAINT IF EXISTS roles_scope_check;
+ALTER TABLE roles ADD CONSTRAINT roles_scope_check CHECK (scope IN ('global', 'community'));
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_name VARCHAR(50) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_name)
+);
+
+-- =============================================================================
+-- Table: user_roles
+-- Purpose: Roles granted to users (community_id set only for community roles)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    community_id UUID REFERENCES communities(id) ON DELETE CASCADE,
+    granted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_user ON user_roles(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_roles_global ON user_roles(user_id, role_id) WHERE community_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_roles_community ON user_roles(user_id, role_id, community_id) WHERE community_id IS NOT NULL;
+
+-- =============================================================================
+-- Seed: permissions and built-in roles
+-- =============================================================================
+
+INSERT INTO permissions (name, description) VALUES
+    ('post.remove.any', 'Remove any post'),
+    ('comment.remove.any', 'Remove any comment'),
+    ('community.moderate', 'Approve and ban members, remove content in communities'),
+    ('user.list', 'List all users'),
+    ('user.suspend', 'Suspend user accounts'),
+    ('reports.review', 'Review and resolve content reports'),
+    ('ads.approve', 'Verify ad payments and remove ads'),
+    ('payouts.manage', 'Approve, export and settle creator payouts'),
+    ('tasks.view.any', 'List every task'),
+    ('files.view.any', 'List every file'),
+    ('jobs.manage', 'Manage scheduled jobs'),
+    ('roles.manage', 'Grant and revoke roles')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, description, scope) VALUES
+    ('admin', 'Full access', 'global'),
+    ('moderator', 'Site-wide content moderation', 'global'),
+    ('community_moderator', 'Content moderation inside one community', 'community')
+ON CONFLICT (name) DO NOTHING;
+
+-- Admins hold every permission, including ones added later
+INSERT INTO role_permissions (role_id, permission_name)
+SELECT r.id, p.name FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_name)
+SELECT r.id, p.name FROM roles r
+JOIN permissions p ON p.name IN ('post.remove.any', 'comment.remove.any', 'community.moderate', 'user.suspend', 'reports.review')
+WHERE r.name = 'moderator'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_name)
+SELECT r.id, p.name FROM roles r
+JOIN permissions p ON p.name IN ('post.remove.any', 'comment.remove.any', 'community.moderate')
+WHERE r.name = 'community_moderator'
+ON CONFLICT DO NOTHING;
+
+-- =============================================================================
+-- Backfill: users.role = 'admin' (the column is no longer read)
+-- =============================================================================
+
+INSERT INTO user_roles (user_id, role_id)
+SELECT u.id, r.id FROM users u JOIN roles r ON r.name = 'admin'
+WHERE u.role = 'admin'
+ON CONFLICT DO NOTHING;
+
+-- Migrations rerun on startup: clear the old flag so a revoked admin role is not granted again
+UPDATE users SET role = 'user' WHERE role = 'admin';
+
+COMMENT ON TABLE roles IS 'Roles - named permission sets, global or community-scoped';
+COMMENT ON TABLE user_roles IS 'User roles - roles granted to users (community_id for community roles)';
+COMMENT ON COLUMN users.role IS 'Deprecated: replaced by user_roles';
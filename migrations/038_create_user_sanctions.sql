@@ -0,0 +1,55 @@
+-- Migration 038: User suspensions, bans and shadowbans
+-- Purpose: Moderator sanctions with an audit trail, enforced on login, every authenticated request and write paths
+-- Lifecycle: applied -> expired (suspensions) / lifted by a moderator; rows are never deleted
+
+-- =============================================================================
+-- Users: current moderation state (recomputed from active sanctions on every change)
+-- =============================================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_banned BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_shadowbanned BOOLEAN NOT NULL DEFAULT false;
+
+-- =============================================================================
+-- Table: user_sanctions
+-- Purpose: Every suspension, ban and shadowban with who applied and who lifted it
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS user_sanctions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    type VARCHAR(20) NOT NULL CHECK (type IN ('suspension', 'ban', 'shadowban')),
+    reason VARCHAR(500) NOT NULL,
+
+    -- Suspensions only (bans and shadowbans last until lifted)
+    expires_at TIMESTAMP WITH TIME ZONE,
+
+    issued_by_id UUID NOT NULL REFERENCES users(id),
+
+    -- Lifted early by a moderator
+    lifted_at TIMESTAMP WITH TIME ZONE,
+    lifted_by_id UUID REFERENCES users(id),
+    lift_reason VARCHAR(500),
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT user_sanctions_expiry_check CHECK ((type = 'suspension') = (expires_at IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sanctions_user_created ON user_sanctions(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_sanctions_active ON user_sanctions(user_id) WHERE lifted_at IS NULL;
+
+-- =============================================================================
+-- Posts and comments: written while shadowbanned (visible to the author only)
+-- =============================================================================
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS is_shadowed BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS is_shadowed BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_posts_shadowed_author ON posts(author_id) WHERE is_shadowed = true;
+CREATE INDEX IF NOT EXISTS idx_comments_shadowed_author ON comments(author_id) WHERE is_shadowed = true;
+
+COMMENT ON TABLE user_sanctions IS 'User sanctions - suspensions, bans and shadowbans (audit trail, never deleted)';
+COMMENT ON COLUMN users.suspended_until IS 'End of the longest active suspension (NULL or past = not suspended)';
+COMMENT ON COLUMN posts.is_shadowed IS 'Created while the author was shadowbanned, cleared when the shadowban is lifted';
@@ -0,0 +1,30 @@
+-- Migration 039: Private accounts and follow requests
+-- Purpose: Private accounts approve their followers; posts and follower lists are hidden from everyone else
+-- Lifecycle: requested -> accepted (becomes a follows row) / rejected or cancelled (deleted)
+
+-- =============================================================================
+-- Users: private profile mode
+-- =============================================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_private BOOLEAN NOT NULL DEFAULT false;
+
+-- =============================================================================
+-- Table: follow_requests
+-- Purpose: Pending follows of private accounts
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS follow_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    requester_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT follow_requests_not_self CHECK (requester_id <> target_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_follow_requests_pair ON follow_requests(requester_id, target_id);
+CREATE INDEX IF NOT EXISTS idx_follow_requests_target_created ON follow_requests(target_id, created_at DESC);
+
+COMMENT ON TABLE follow_requests IS 'Follow requests - pending follows of private accounts (deleted when accepted, rejected or cancelled)';
+COMMENT ON COLUMN users.is_private IS 'Followers need approval; posts and follower lists are only shown to approved followers';
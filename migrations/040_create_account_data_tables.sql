@@ -0,0 +1,44 @@
+-- Migration 040: Personal data export and account deletion
+-- Purpose: Users download a ZIP of their data and delete their account after a cooling-off period
+-- Lifecycle (exports): pending -> processing -> ready -> expired (file deleted) / failed
+-- Lifecycle (deletion): requested -> cancelled / anonymized when deletion_scheduled_at passes
+
+-- =============================================================================
+-- Users: deletion schedule and anonymization time
+-- =============================================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+-- =============================================================================
+-- Table: data_exports
+-- Purpose: Background personal data exports stored in R2
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'ready', 'failed', 'expired')),
+
+    -- Archive in R2 (private bucket path)
+    file_key VARCHAR(500),
+    file_size BIGINT NOT NULL DEFAULT 0,
+
+    error VARCHAR(500),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_created ON data_exports(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_data_exports_pending ON data_exports(created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_data_exports_ready_expiry ON data_exports(expires_at) WHERE status = 'ready';
+
+COMMENT ON TABLE data_exports IS 'Personal data exports - ZIP archives in R2, deleted when they expire';
+COMMENT ON COLUMN users.deletion_scheduled_at IS 'Account is anonymized at this time unless the user cancels (cooling-off period)';
+COMMENT ON COLUMN users.deleted_at IS 'Account was anonymized (profile scrubbed, content removed, logins disabled)';
@@ -0,0 +1,48 @@
+-- Migration 041: Personal access tokens and bot keys
+-- Purpose: Scoped tokens for integrations and bot accounts, accepted by the API alongside JWTs
+-- Format: "<kind>_<secret>" (kind = pat or bot); only the SHA-256 of the token is stored
+
+-- =============================================================================
+-- Table: api_tokens
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    kind VARCHAR(10) NOT NULL DEFAULT 'pat' CHECK (kind IN ('pat', 'bot')),
+    name VARCHAR(100) NOT NULL,
+    created_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    prefix VARCHAR(16) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+
+    scopes JSONB NOT NULL DEFAULT '[]',
+    rate_limit_per_minute INTEGER NOT NULL DEFAULT 60 CHECK (rate_limit_per_minute > 0),
+
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    last_used_ip VARCHAR(45),
+    revoked_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_tokens_hash ON api_tokens(token_hash);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user ON api_tokens(user_id, created_at DESC);
+
+-- =============================================================================
+-- Seed: permission to issue bot keys (admins hold every permission)
+-- =============================================================================
+
+INSERT INTO permissions (name, description) VALUES
+    ('bot_tokens.manage', 'Issue and revoke API keys of bot accounts')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_name)
+SELECT r.id, 'bot_tokens.manage' FROM roles r
+WHERE r.name = 'admin'
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE api_tokens IS 'API tokens - personal access tokens and bot keys (hashed), limited to their scopes';
@@ -0,0 +1,87 @@
+-- Migration 042: Group conversations
+-- Purpose: Named group chats with owner/admin/member roles alongside 1-on-1 conversations
+-- Every conversation (direct or group) lists its members in conversation_participants,
+-- which holds the per-member unread counter and read pointer
+
+-- =============================================================================
+-- Conversations: type and group details
+-- =============================================================================
+
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS type VARCHAR(20) NOT NULL DEFAULT 'direct';
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS name VARCHAR(100);
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS avatar_url VARCHAR(500);
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS created_by_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Groups have no user pair
+ALTER TABLE conversations ALTER COLUMN user1_id DROP NOT NULL;
+ALTER TABLE conversations ALTER COLUMN user2_id DROP NOT NULL;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'conversations_type_check') THEN
+        ALTER TABLE conversations ADD CONSTRAINT conversations_type_check CHECK (
+            (type = 'direct' AND user1_id IS NOT NULL AND user2_id IS NOT NULL)
+            OR (type = 'group' AND name IS NOT NULL)
+        );
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_conversations_type ON conversations(type);
+
+-- Group messages are addressed to the conversation, not to one receiver
+ALTER TABLE messages ALTER COLUMN receiver_id DROP NOT NULL;
+
+-- =============================================================================
+-- Table: conversation_participants
+-- Purpose: Members of a conversation with their role, unread counter and read pointer
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS conversation_participants (
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    role VARCHAR(20) NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'admin', 'member')),
+
+    -- Unread counter and read pointer
+    unread_count INTEGER NOT NULL DEFAULT 0,
+    last_read_message_id UUID REFERENCES messages(id) ON DELETE SET NULL,
+    last_read_at TIMESTAMP WITH TIME ZONE,
+
+    joined_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_participants_user ON conversation_participants(user_id);
+
+-- Existing 1-on-1 conversations: both users become participants, keeping their unread counts
+INSERT INTO conversation_participants (conversation_id, user_id, role, unread_count, joined_at)
+SELECT id, user1_id, 'member', user1_unread_count, created_at FROM conversations WHERE user1_id IS NOT NULL
+ON CONFLICT (conversation_id, user_id) DO NOTHING;
+
+INSERT INTO conversation_participants (conversation_id, user_id, role, unread_count, joined_at)
+SELECT id, user2_id, 'member', user2_unread_count, created_at FROM conversations WHERE user2_id IS NOT NULL
+ON CONFLICT (conversation_id, user_id) DO NOTHING;
+
+-- =============================================================================
+-- Trigger: the conversation only tracks its last message now
+-- (unread counters are kept per participant by the application)
+-- =============================================================================
+
+CREATE OR REPLACE FUNCTION update_conversation_on_message()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE conversations
+    SET
+        last_message_id = NEW.id,
+        last_message_at = NEW.created_at,
+        updated_at = NEW.created_at
+    WHERE id = NEW.conversation_id;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMENT ON TABLE conversation_participants IS 'Conversation members - role, unread counter and read pointer per member (direct and group conversations)';
+COMMENT ON COLUMN conversations.user1_unread_count IS 'Deprecated - superseded by conversation_participants.unread_count';
+COMMENT ON COLUMN conversations.user2_unread_count IS 'Deprecated - superseded by conversation_participants.unread_count';
@@ -0,0 +1,26 @@
+-- Migration 043: Edit and unsend chat messages
+-- Purpose: Senders can edit a message for a short while (earlier versions are kept) or unsend it
+-- Unsending deletes the message (its revisions cascade)
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE;
+
+-- =============================================================================
+-- Table: message_revisions
+-- Purpose: Earlier versions of edited messages (one row per edit, holding the replaced text)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS message_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+
+    -- The text as it was before the edit
+    content TEXT,
+
+    -- When this version was replaced
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_revisions_message_created ON message_revisions(message_id, created_at);
+
+COMMENT ON TABLE message_revisions IS 'Message edit history - the replaced text of each edit (deleted with the message when it is unsent)';
+COMMENT ON COLUMN messages.edited_at IS 'Last edit time (NULL if never edited)';
@@ -0,0 +1,29 @@
+-- Migration 044: Message reactions and quoted replies
+-- Purpose: Participants react to a message with emojis from a fixed set and reply to a message
+-- with a quoted preview of the original
+
+-- A reply keeps pointing at its original until the original is unsent
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS reply_to_message_id UUID REFERENCES messages(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_messages_reply_to ON messages(reply_to_message_id) WHERE reply_to_message_id IS NOT NULL;
+
+-- =============================================================================
+-- Table: message_reactions
+-- Purpose: Emoji reactions (a user may add several different emojis to the same message)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS message_reactions (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(16) NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (message_id, user_id, emoji)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_reactions_message_created ON message_reactions(message_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_message_reactions_user ON message_reactions(user_id);
+
+COMMENT ON TABLE message_reactions IS 'Emoji reactions to chat messages (emojis are limited to models.MessageReactionEmojis)';
+COMMENT ON COLUMN messages.reply_to_message_id IS 'Quoted original message (NULL when not a reply or the original was unsent)';
@@ -0,0 +1,126 @@
+-- Migration 045: Conversation sequence numbers, delivery/read receipts and sync
+-- Purpose: Every change to a conversation (new message, edit, unsend, receipt) gets the next
+-- per-conversation sequence number and is logged in conversation_events, so a reconnecting
+-- client can send the last sequence it saw and have the missed changes replayed.
+-- Delivered/read states are per-participant sequence pointers: a message is read by a
+-- participant when its seq <= their last_read_seq.
+
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS last_seq BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS seq BIGINT;
+ALTER TABLE conversation_participants ADD COLUMN IF NOT EXISTS last_delivered_seq BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE conversation_participants ADD COLUMN IF NOT EXISTS last_read_seq BIGINT NOT NULL DEFAULT 0;
+
+-- =============================================================================
+-- Table: conversation_events
+-- Purpose: Replay log for sync (message_id has no FK: the events of unsent messages stay)
+-- =============================================================================
+
+CREATE TABLE IF NOT EXISTS conversation_events (
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    seq BIGINT NOT NULL,
+
+    -- message.new, message.edited, message.unsent, receipt.delivered, receipt.read
+    type VARCHAR(30) NOT NULL,
+    message_id UUID,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Receipts: the sequence the user's pointer moved to
+    up_to_seq BIGINT,
+
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (conversation_id, seq)
+);
+
+-- =============================================================================
+-- Backfill (first run only)
+-- =============================================================================
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM messages WHERE seq IS NULL) THEN
+        UPDATE messages m
+        SET seq = numbered.rn
+        FROM (
+            SELECT id, ROW_NUMBER() OVER (PARTITION BY conversation_id ORDER BY created_at, id) AS rn
+            FROM messages
+        ) numbered
+        WHERE m.id = numbered.id AND m.seq IS NULL;
+
+        UPDATE conversations c
+        SET last_seq = GREATEST(c.last_seq, COALESCE((SELECT MAX(seq) FROM messages WHERE conversation_id = c.id), 0));
+
+        INSERT INTO conversation_events (conversation_id, seq, type, message_id, user_id, created_at)
+        SELECT conversation_id, seq, 'message.new', id, sender_id, created_at
+        FROM messages
+        ON CONFLICT DO NOTHING;
+
+        -- Read pointer: just before the oldest of the unread messages
+        UPDATE conversation_participants p
+        SET last_read_seq = CASE
+            WHEN p.unread_count = 0 THEN c.last_seq
+            ELSE COALESCE((
+                SELECT m.seq - 1 FROM messages m
+                WHERE m.conversation_id = p.conversation_id AND m.sender_id <> p.user_id
+                ORDER BY m.seq DESC
+                OFFSET p.unread_count - 1 LIMIT 1
+            ), 0)
+        END
+        FROM conversations c
+        WHERE c.id = p.conversation_id;
+
+        UPDATE conversation_participants SET last_delivered_seq = last_read_seq;
+    END IF;
+END $$;
+
+ALTER TABLE messages ALTER COLUMN seq SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_conversation_seq ON messages(conversation_id, seq);
+
+-- =============================================================================
+-- Sequence assignment
+-- =============================================================================
+
+-- append_conversation_event takes the next sequence of the conversation (the row lock on the
+-- conversation keeps sequences gapless and in commit order) and logs the event under it
+CREATE OR REPLACE FUNCTION append_conversation_event(
+    p_conversation_id UUID,
+    p_type VARCHAR,
+    p_message_id UUID,
+    p_user_id UUID,
+    p_up_to_seq BIGINT
+) RETURNS BIGINT AS $$
+DECLARE
+    next_seq BIGINT;
+BEGIN
+    UPDATE conversations
+    SET last_seq = last_seq + 1
+    WHERE id = p_conversation_id
+    RETURNING last_seq INTO next_seq;
+
+    INSERT INTO conversation_events (conversation_id, seq, type, message_id, user_id, up_to_seq)
+    VALUES (p_conversation_id, next_seq, p_type, p_message_id, p_user_id, p_up_to_seq);
+
+    RETURN next_seq;
+END;
+$$ LANGUAGE plpgsql;
+
+-- New messages take their sequence from the event that announces them
+CREATE OR REPLACE FUNCTION assign_message_seq()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.seq := append_conversation_event(NEW.conversation_id, 'message.new', NEW.id, NEW.sender_id, NULL);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_assign_message_seq ON messages;
+CREATE TRIGGER trigger_assign_message_seq
+    BEFORE INSERT ON messages
+    FOR EACH ROW
+    EXECUTE FUNCTION assign_message_seq();
+
+COMMENT ON TABLE conversation_events IS 'Per-conversation change log replayed to reconnecting clients (sync)';
+COMMENT ON COLUMN conversations.last_seq IS 'Sequence of the latest conversation event';
+COMMENT ON COLUMN messages.seq IS 'Per-conversation sequence (assigned by trigger_assign_message_seq)';
+COMMENT ON COLUMN conversation_participants.last_delivered_seq IS 'Messages up to this sequence reached one of the user''s devices';
+COMMENT ON COLUMN conversation_participants.last_read_seq IS 'Messages up to this sequence were read by the user';
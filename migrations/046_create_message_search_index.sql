@@ -0,0 +1,7 @@
+-- Migration 046: Text search in chat history
+-- Purpose: Search uses ILIKE substring matching (it also works for languages written without
+-- spaces between words), a trigram index keeps it fast on long histories
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_messages_content_trgm ON messages USING gin (content gin_trgm_ops);
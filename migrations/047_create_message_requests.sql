@@ -0,0 +1,32 @@
+-- Migration 047: Message requests and chat privacy
+-- Purpose: Chats started by someone the recipient does not follow land in a separate requests inbox;
+-- the requester can send one message until the recipient accepts (or deletes / blocks)
+-- Lifecycle: requested (requested_by_id set) -> accepted (cleared) / deleted (conversation removed)
+
+-- =============================================================================
+-- Users: who may start a chat
+-- everyone  - anyone, straight to the inbox
+-- followers - accounts the user follows go to the inbox, everyone else to requests
+-- nobody    - accounts the user follows go to the inbox, everyone else is refused
+-- =============================================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS chat_privacy VARCHAR(20) NOT NULL DEFAULT 'followers';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'users_chat_privacy_check') THEN
+        ALTER TABLE users ADD CONSTRAINT users_chat_privacy_check
+            CHECK (chat_privacy IN ('everyone', 'followers', 'nobody'));
+    END IF;
+END $$;
+
+-- =============================================================================
+-- Conversations: pending message requests (existing conversations stay accepted)
+-- =============================================================================
+
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS requested_by_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_conversations_requested_by ON conversations(requested_by_id) WHERE requested_by_id IS NOT NULL;
+
+COMMENT ON COLUMN users.chat_privacy IS 'Who may start a chat: everyone, followers (others land in message requests) or nobody';
+COMMENT ON COLUMN conversations.requested_by_id IS 'Set while the conversation is a message request the other participant has not accepted';
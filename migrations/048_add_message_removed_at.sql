@@ -0,0 +1,10 @@
+-- Migration 048: Moderator-removed messages
+-- Purpose: A message removed from a report keeps its row (gift escrow, replies and sequence numbers
+-- still point at it); only its content, media and edit history are cleared
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS removed_at TIMESTAMP WITH TIME ZONE;
+
+-- A removed message is the only one allowed to have neither content nor media
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_content_or_media_required;
+ALTER TABLE messages ADD CONSTRAINT messages_content_or_media_required
+    CHECK (content IS NOT NULL OR media IS NOT NULL OR removed_at IS NOT NULL);
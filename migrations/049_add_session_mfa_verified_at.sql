@@ -0,0 +1,5 @@
+-- Migration 049: Two-factor sessions
+-- Purpose: Remember which sessions were signed in with a second factor; their access tokens carry
+-- amr ["mfa"] and only they may request, approve, reject or settle payouts
+
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS mfa_verified_at TIMESTAMP WITH TIME ZONE;
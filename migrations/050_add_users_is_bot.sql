@@ -0,0 +1,4 @@
+-- Migration 050: Bot accounts
+-- Purpose: Staff flag automated accounts; bot keys are only issued to (and accepted for) flagged accounts
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_bot BOOLEAN NOT NULL DEFAULT FALSE;
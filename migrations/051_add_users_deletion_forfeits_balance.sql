@@ -0,0 +1,5 @@
+-- Migration 051: Forfeiting the wallet on account deletion
+-- Purpose: A user whose balance cannot be withdrawn (below the payout minimum, or top-up money) may consent
+-- to give it up when asking for deletion; the balance then goes to a system wallet instead of blocking it
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_forfeits_balance BOOLEAN NOT NULL DEFAULT FALSE;
@@ -11,9 +11,10 @@ import (
 
 // AuthCodeData stores the data associated with an authorization code
 type AuthCodeData struct {
-	Token     string
+	Tokens    dto.AuthTokens
 	User      dto.UserResponse
 	IsNewUser bool
+	MFA       *dto.MFAChallengeResponse // set instead of tokens when a second factor is still required
 	State     string
 	ExpiresAt time.Time
 }
@@ -42,7 +43,7 @@ func GetInstance() *Store {
 }
 
 // GenerateCode creates a new authorization code and stores the data
-func (s *Store) GenerateCode(token string, user dto.UserResponse, isNewUser bool, state string) (string, error) {
+func (s *Store) GenerateCode(tokens dto.AuthTokens, user dto.UserResponse, isNewUser bool, mfa *dto.MFAChallengeResponse, state string) (string, error) {
 	// Generate random code
 	b := make([]byte, 32)
 	if _, err := rand.Read(b); err != nil {
@@ -55,9 +56,10 @@ func (s *Store) GenerateCode(token string, user dto.UserResponse, isNewUser bool
 
 	// Store code with 5 minute expiration
 	s.codes[code] = &AuthCodeData{
-		Token:     token,
+		Tokens:    tokens,
 		User:      user,
 		IsNewUser: isNewUser,
+		MFA:       mfa,
 		State:     state,
 		ExpiresAt: time.Now().Add(5 * time.Minute),
 	}
@@ -4,6 +4,8 @@ import (
 	"github.com/joho/godotenv"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -16,6 +18,9 @@ type Config struct {
 	OAuth    OAuthConfig
 	VAPID    VAPIDConfig
 	OpenAI   OpenAIConfig
+	Payment  PaymentConfig
+	Mail     MailConfig
+	MFA      MFAConfig
 }
 
 type AppConfig struct {
@@ -43,7 +48,9 @@ type RedisConfig struct {
 }
 
 type JWTConfig struct {
-	Secret string
+	Secret          string
+	AccessTokenTTL  time.Duration // short-lived, revocable through the session denylist
+	RefreshTokenTTL time.Duration // sliding, extended on every refresh
 }
 
 type BunnyConfig struct {
@@ -68,7 +75,12 @@ type R2Config struct {
 }
 
 type OAuthConfig struct {
-	Google GoogleOAuthConfig
+	CallbackBaseURL string // default redirect URLs are {CallbackBaseURL}/{provider}/callback
+
+	Google   GoogleOAuthConfig
+	LINE     LINEOAuthConfig
+	Facebook FacebookOAuthConfig
+	OIDC     []OIDCProviderConfig // extra OpenID Connect issuers (OIDC_PROVIDERS)
 }
 
 type GoogleOAuthConfig struct {
@@ -77,6 +89,28 @@ type GoogleOAuthConfig struct {
 	RedirectURL  string
 }
 
+type LINEOAuthConfig struct {
+	ChannelID     string
+	ChannelSecret string
+	RedirectURL   string
+}
+
+type FacebookOAuthConfig struct {
+	AppID       string
+	AppSecret   string
+	RedirectURL string
+}
+
+type OIDCProviderConfig struct {
+	Name         string // provider key in URLs, e.g. "keycloak"
+	DisplayName  string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
 type VAPIDConfig struct {
 	PublicKey  string
 	PrivateKey string
@@ -89,12 +123,37 @@ type OpenAIConfig struct {
 	BotUserID string
 }
 
+type PaymentConfig struct {
+	Provider      string // payment gateway adapter ("fake" = local, no real money)
+	WebhookSecret string // HMAC secret of inbound payment webhooks
+	PromptPayID   string // mobile number or tax ID receiving PromptPay transfers
+
+	// Confirms top-ups through the simulate endpoint (fake provider only, refused in production)
+	AllowSimulation bool
+}
+
+type MailConfig struct {
+	Provider     string // mailer adapter ("sink" = local, nothing is delivered)
+	From         string // "Name <address>"
+	SinkDir      string // sink only: write .eml files here (empty = log only)
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+}
+
+type MFAConfig struct {
+	Issuer        string // account label shown in authenticator apps
+	EncryptionKey string // encrypts TOTP secrets at rest (falls back to JWT_SECRET)
+}
+
 func LoadConfig() (*Config, error) {
 	// Load .env file if it exists (for local development)
 	// In production/Docker, environment variables are set by the container
 	_ = godotenv.Load()
 
 	redisDB, _ := strconv.Atoi(getEnv("REDIS_DB", "0"))
+	oauthCallbackBaseURL := strings.TrimRight(getEnv("OAUTH_CALLBACK_BASE_URL", "http://localhost:8080/api/v1/auth/oauth"), "/")
 
 	config := &Config{
 		App: AppConfig{
@@ -119,7 +178,9 @@ func LoadConfig() (*Config, error) {
 			DB:       redisDB,
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", "your-secret-key"),
+			Secret:          getEnv("JWT_SECRET", "your-secret-key"),
+			AccessTokenTTL:  getEnvDuration("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL: getEnvDuration("JWT_REFRESH_TOKEN_TTL", 30*24*time.Hour),
 		},
 		Bunny: BunnyConfig{
 			// Storage config
@@ -141,11 +202,23 @@ func LoadConfig() (*Config, error) {
 			PublicURL:       getEnv("R2_PUBLIC_URL", ""),
 		},
 		OAuth: OAuthConfig{
+			CallbackBaseURL: oauthCallbackBaseURL,
 			Google: GoogleOAuthConfig{
 				ClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 				ClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 				RedirectURL:  getEnv("GOOGLE_REDIRECT_URL", "http://localhost:8080/api/v1/auth/google/callback"),
 			},
+			LINE: LINEOAuthConfig{
+				ChannelID:     getEnv("LINE_CHANNEL_ID", ""),
+				ChannelSecret: getEnv("LINE_CHANNEL_SECRET", ""),
+				RedirectURL:   getEnv("LINE_REDIRECT_URL", oauthCallbackBaseURL+"/line/callback"),
+			},
+			Facebook: FacebookOAuthConfig{
+				AppID:       getEnv("FACEBOOK_APP_ID", ""),
+				AppSecret:   getEnv("FACEBOOK_APP_SECRET", ""),
+				RedirectURL: getEnv("FACEBOOK_REDIRECT_URL", oauthCallbackBaseURL+"/facebook/callback"),
+			},
+			OIDC: loadOIDCProviders(oauthCallbackBaseURL),
 		},
 		VAPID: VAPIDConfig{
 			PublicKey:  getEnv("VAPID_PUBLIC_KEY", ""),
@@ -157,11 +230,54 @@ func LoadConfig() (*Config, error) {
 			Model:     getEnv("OPENAI_MODEL", "gpt-4o-mini"),
 			BotUserID: getEnv("AUTO_POST_BOT_USER_ID", ""),
 		},
+		Payment: PaymentConfig{
+			Provider:      getEnv("PAYMENT_PROVIDER", "fake"),
+			WebhookSecret: getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+			PromptPayID:   getEnv("PROMPTPAY_ID", ""),
+
+			AllowSimulation: getEnvBool("PAYMENT_ALLOW_SIMULATION", false),
+		},
+		Mail: MailConfig{
+			Provider:     getEnv("MAIL_PROVIDER", "sink"),
+			From:         getEnv("MAIL_FROM", "VOOBIZE <no-reply@voobize.com>"),
+			SinkDir:      getEnv("MAIL_SINK_DIR", ""),
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUsername: getEnv("SMTP_USER", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		},
+		MFA: MFAConfig{
+			Issuer:        getEnv("MFA_ISSUER", "VOOBIZE"),
+			EncryptionKey: getEnv("MFA_ENCRYPTION_KEY", getEnv("JWT_SECRET", "your-secret-key")),
+		},
 	}
 
 	return config, nil
 }
 
+// loadOIDCProviders reads OIDC_PROVIDERS=name1,name2 and OIDC_<NAME>_* for each entry
+func loadOIDCProviders(callbackBaseURL string) []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		providers = append(providers, OIDCProviderConfig{
+			Name:         name,
+			DisplayName:  getEnv(prefix+"DISPLAY_NAME", name),
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", callbackBaseURL+"/"+name+"/callback"),
+			Scopes:       strings.Fields(strings.ReplaceAll(getEnv(prefix+"SCOPES", "openid email profile"), ",", " ")),
+		})
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -169,3 +285,19 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
@@ -36,6 +36,12 @@ func CleanTestDB(db *gorm.DB) error {
 
 	// Get all tables
 	tables := []string{
+		"gifts",
+		"payouts",
+		"ledger_entries",
+		"ledger_transactions",
+		"wallet_holds",
+		"wallets",
 		"messages",
 		"conversations",
 		"conversation_participants",
@@ -50,6 +56,8 @@ func CleanTestDB(db *gorm.DB) error {
 		"media",
 		"posts",
 		"follows",
+		"community_members",
+		"communities",
 		"blocks",
 		"search_histories",
 		"users",
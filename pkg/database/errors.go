@@ -0,0 +1,16 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the Postgres SQLSTATE for a unique constraint violation
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err comes from a unique constraint violation
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,21 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	unique := &pgconn.PgError{Code: "23505", Message: "duplicate key value violates unique constraint"}
+	check := &pgconn.PgError{Code: "23514", Message: "new row violates check constraint"}
+
+	assert.True(t, IsUniqueViolation(unique))
+	assert.True(t, IsUniqueViolation(fmt.Errorf("create contribution: %w", unique)))
+	assert.False(t, IsUniqueViolation(check))
+	// Only the SQLSTATE counts, not the driver's message text
+	assert.False(t, IsUniqueViolation(errors.New("duplicate key value violates unique constraint")))
+}
@@ -20,12 +20,29 @@ func WithTransaction(db *gorm.DB, fn TxFunc) error {
 }
 
 // WithTransactionContext executes a function within a transaction with context
+// If ctx already carries a transaction (see ContextWithTx), fn runs inside it as a savepoint
 func WithTransactionContext(ctx context.Context, db *gorm.DB, fn TxFunc) error {
-	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return Conn(ctx, db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		return fn(tx)
 	})
 }
 
+type txContextKey struct{}
+
+// ContextWithTx returns a context that carries an open transaction
+// Repositories resolving their connection with Conn will join it
+func ContextWithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+// Conn returns the transaction carried by ctx, or db when there is none
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok && tx != nil {
+		return tx
+	}
+	return db
+}
+
 // TransactionManager manages database transactions
 type TransactionManager struct {
 	db *gorm.DB
@@ -46,6 +63,14 @@ func (tm *TransactionManager) ExecuteWithContext(ctx context.Context, fn TxFunc)
 	return WithTransactionContext(ctx, tm.db, fn)
 }
 
+// RunInTx runs fn within a transaction carried by the context passed to fn
+// Use it when one unit of work spans several repositories
+func (tm *TransactionManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return WithTransactionContext(ctx, tm.db, func(tx *gorm.DB) error {
+		return fn(ContextWithTx(ctx, tx))
+	})
+}
+
 // BeginTx explicitly begins a new transaction and returns it
 // Useful when you need more control over the transaction
 func (tm *TransactionManager) BeginTx(ctx context.Context) *gorm.DB {
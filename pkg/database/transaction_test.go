@@ -159,6 +159,28 @@ func TestTransactionManager_BeginRollback(t *testing.T) {
 	assert.Equal(t, int64(0), count)
 }
 
+func TestTransactionManager_RunInTx_JoinsNestedTransactions(t *testing.T) {
+	db := setupTestDB(t)
+	tm := NewTransactionManager(db)
+
+	// Act - an inner repository-style transaction joins the outer one
+	err := tm.RunInTx(context.Background(), func(ctx context.Context) error {
+		if err := WithTransactionContext(ctx, db, func(tx *gorm.DB) error {
+			return tx.Create(&TestModel{Name: "Inner"}).Error
+		}); err != nil {
+			return err
+		}
+		return errors.New("rollback")
+	})
+
+	// Assert - the outer rollback also undoes the inner write
+	assert.Error(t, err)
+
+	var count int64
+	db.Model(&TestModel{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
 func TestTransactionError(t *testing.T) {
 	err := NewTransactionError("create", errors.New("test error"))
 
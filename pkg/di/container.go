@@ -2,9 +2,14 @@ package di
 
 import (
 	"context"
+	"fmt"
+	"github.com/google/uuid"
 	"gofiber-template/application/serviceimpl"
 	"gofiber-template/domain/repositories"
 	"gofiber-template/domain/services"
+	"gofiber-template/infrastructure/mail"
+	"gofiber-template/infrastructure/oauth"
+	"gofiber-template/infrastructure/payment"
 	"gofiber-template/infrastructure/postgres"
 	"gofiber-template/infrastructure/redis"
 	"gofiber-template/infrastructure/storage"
@@ -33,6 +38,7 @@ type Container struct {
 
 	// Infrastructure
 	DB                 *gorm.DB
+	TxManager          *database.TransactionManager
 	RedisClient        *redis.RedisClient
 	RedisService       *redis.RedisService
 	FeedCacheService   *redis.FeedCacheService
@@ -40,6 +46,9 @@ type Container struct {
 	BunnyStreamService *storage.BunnyStreamService
 	R2Storage          storage.R2Storage
 	MediaUploadService *storage.MediaUploadService
+	PaymentProvider    payment.PaymentProvider
+	Mailer             mail.Mailer
+	OAuthProviders     *oauth.Registry
 	EventScheduler     scheduler.EventScheduler
 	ChatHub            *websocket.ChatHub
 	NotificationHub    *websocket.NotificationHub
@@ -73,6 +82,60 @@ type Container struct {
 	AutoPostSettingRepository repositories.AutoPostSettingRepository
 	AutoPostLogRepository     repositories.AutoPostLogRepository
 
+	// Repositories - Wallet
+	WalletRepository repositories.WalletRepository
+
+	// Repositories - Premium Unlock
+	PostUnlockRepository repositories.PostUnlockRepository
+
+	// Repositories - Gifts
+	GiftRepository repositories.GiftRepository
+
+	// Repositories - Subscriptions
+	SubscriptionRepository repositories.SubscriptionRepository
+
+	// Repositories - Reels
+	ReelRepository repositories.ReelRepository
+
+	// Repositories - Ads
+	AdRepository repositories.AdRepository
+
+	// Repositories - Top-ups
+	TopUpRepository repositories.TopUpRepository
+
+	// Repositories - Payouts
+	PayoutRepository repositories.PayoutRepository
+
+	// Repositories - Communities
+	CommunityRepository repositories.CommunityRepository
+
+	// Repositories - Reports
+	ReportRepository repositories.ReportRepository
+
+	// Repositories - Sessions
+	SessionRepository repositories.SessionRepository
+
+	// Repositories - Account emails
+	UserTokenRepository repositories.UserTokenRepository
+
+	// Repositories - Two-factor
+	TwoFactorRepository repositories.TwoFactorRepository
+
+	// Repositories - Linked logins
+	UserIdentityRepository repositories.UserIdentityRepository
+
+	// Repositories - Roles and permissions
+	RoleRepository repositories.RoleRepository
+
+	// Repositories - Sanctions
+	UserSanctionRepository repositories.UserSanctionRepository
+
+	// Repositories - Data export and account deletion
+	AccountDataRepository repositories.AccountDataRepository
+
+	// Repositories - API tokens
+	APITokenRepository repositories.APITokenRepository
+
 	// Services - Legacy
 	UserService services.UserService
 	TaskService services.TaskService
@@ -103,6 +166,57 @@ type Container struct {
 	// Services - Auto-Post
 	AutoPostService       services.AutoPostService
 	SimpleAutoPostService services.SimpleAutoPostService
+
+	// Services - Wallet
+	WalletService services.WalletService
+
+	// Services - Premium Unlock
+	PostUnlockService services.PostUnlockService
+
+	// Services - Gifts
+	GiftService services.GiftService
+
+	// Services - Subscriptions
+	SubscriptionService services.SubscriptionService
+
+	// Services - Reels
+	ReelService services.ReelService
+
+	// Services - Ads
+	AdService services.AdService
+
+	// Services - Top-ups
+	TopUpService services.TopUpService
+
+	// Services - Payouts
+	PayoutService services.PayoutService
+
+	// Services - Communities
+	CommunityService services.CommunityService
+
+	// Services - Reports
+	ReportService services.ReportService
+
+	// Services - Sessions
+	SessionService services.SessionService
+
+	// Services - Account emails
+	AccountEmailService services.AccountEmailService
+
+	// Services - Two-factor
+	TwoFactorService services.TwoFactorService
+
+	// Services - Authorization
+	AuthorizationService services.AuthorizationService
+
+	// Services - Sanctions
+	SanctionService services.SanctionService
+
+	// Services - Data export and account deletion
+	AccountDataService services.AccountDataService
+
+	// Services - API tokens
+	APITokenService services.APITokenService
 }
 
 func NewContainer() *Container {
@@ -199,6 +313,7 @@ func (c *Container) initInfrastructure() error {
 		return err
 	}
 	c.DB = db
+	c.TxManager = database.NewTransactionManager(db)
 	log.Println("✓ Database connected")
 
 	// Configure connection pool
@@ -291,6 +406,95 @@ func (c *Container) initInfrastructure() error {
 	c.MediaUploadService = storage.NewMediaUploadService(c.BunnyStorage, c.BunnyStreamService)
 	log.Println("✓ MediaUploadService initialized")
 
+	// Initialize payment gateway
+	// Simulated payments mint real wallet balance, so neither the fake provider nor simulation may run in production.
+	// Without a real gateway the server still starts, with top-ups disabled (PaymentProvider stays nil).
+	switch {
+	case c.Config.App.Env == "production" && c.Config.Payment.Provider == payment.FakeProviderName:
+		if c.Config.Payment.AllowSimulation {
+			log.Println("⚠ PAYMENT_ALLOW_SIMULATION is ignored in production")
+		}
+		log.Println("⚠ Payment gateway not configured for production - top-ups are disabled")
+	case c.Config.Payment.Provider == payment.FakeProviderName:
+		webhookSecret := c.Config.Payment.WebhookSecret
+		if webhookSecret == "" {
+			// Only the simulator signs fake webhooks, so a per-process secret is enough
+			webhookSecret = uuid.NewString()
+		}
+		c.PaymentProvider = payment.NewFakeProvider(c.Config.Payment.PromptPayID, webhookSecret)
+		if c.Config.Payment.AllowSimulation {
+			log.Println("✓ Payment gateway initialized (fake provider - payments are only confirmed by simulation)")
+		} else {
+			log.Println("✓ Payment gateway initialized (fake provider - simulation disabled, payments are never confirmed)")
+		}
+	default:
+		return fmt.Errorf("unknown payment provider: %s", c.Config.Payment.Provider)
+	}
+
+	// Initialize mailer
+	switch c.Config.Mail.Provider {
+	case mail.SinkMailerName:
+		c.Mailer = mail.NewSinkMailer(c.Config.Mail.From, c.Config.Mail.SinkDir)
+		log.Println("✓ Mailer initialized (sink - emails are not delivered)")
+	case mail.SMTPMailerName:
+		if c.Config.Mail.SMTPHost == "" {
+			return fmt.Errorf("SMTP_HOST is required for the smtp mailer")
+		}
+		c.Mailer = mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     c.Config.Mail.SMTPHost,
+			Port:     c.Config.Mail.SMTPPort,
+			Username: c.Config.Mail.SMTPUsername,
+			Password: c.Config.Mail.SMTPPassword,
+			From:     c.Config.Mail.From,
+		})
+		log.Printf("✓ Mailer initialized (smtp via %s:%s)", c.Config.Mail.SMTPHost, c.Config.Mail.SMTPPort)
+	default:
+		return fmt.Errorf("unknown mail provider: %s", c.Config.Mail.Provider)
+	}
+
+	// Initialize login providers (only the configured ones are offered)
+	if err := c.initOAuthProviders(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Container) initOAuthProviders() error {
+	oauthCfg := c.Config.OAuth
+	c.OAuthProviders = oauth.NewRegistry()
+
+	var providers []oauth.Provider
+	if oauthCfg.Google.ClientID != "" {
+		providers = append(providers, oauth.NewGoogleProvider(oauthCfg.Google.ClientID, oauthCfg.Google.ClientSecret, oauthCfg.Google.RedirectURL))
+	}
+	if oauthCfg.LINE.ChannelID != "" {
+		providers = append(providers, oauth.NewLINEProvider(oauthCfg.LINE.ChannelID, oauthCfg.LINE.ChannelSecret, oauthCfg.LINE.RedirectURL))
+	}
+	if oauthCfg.Facebook.AppID != "" {
+		providers = append(providers, oauth.NewFacebookProvider(oauthCfg.Facebook.AppID, oauthCfg.Facebook.AppSecret, oauthCfg.Facebook.RedirectURL))
+	}
+	for _, p := range oauthCfg.OIDC {
+		if p.Issuer == "" || p.ClientID == "" {
+			return fmt.Errorf("OIDC provider %s needs an issuer and a client ID", p.Name)
+		}
+		providers = append(providers, oauth.NewOIDCProvider(oauth.OIDCConfig{
+			Name:         p.Name,
+			DisplayName:  p.DisplayName,
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+		}))
+	}
+
+	for _, p := range providers {
+		if err := c.OAuthProviders.Register(p); err != nil {
+			return err
+		}
+	}
+	log.Printf("✓ Login providers initialized (%d providers)", len(providers))
 	return nil
 }
 
@@ -323,31 +527,142 @@ func (c *Container) initRepositories() error {
 	c.AutoPostSettingRepository = postgres.NewAutoPostSettingRepository(c.DB)
 	c.AutoPostLogRepository = postgres.NewAutoPostLogRepository(c.DB)
 
-	log.Println("✓ Repositories initialized (20 repositories)")
+	// Wallet repositories
+	c.WalletRepository = postgres.NewWalletRepository(c.DB)
+
+	// Premium unlock repositories
+	c.PostUnlockRepository = postgres.NewPostUnlockRepository(c.DB)
+
+	// Gift repositories
+	c.GiftRepository = postgres.NewGiftRepository(c.DB)
+
+	// Subscription repositories
+	c.SubscriptionRepository = postgres.NewSubscriptionRepository(c.DB)
+
+	// Reel repositories
+	c.ReelRepository = postgres.NewReelRepository(c.DB)
+
+	// Ad repositories
+	c.AdRepository = postgres.NewAdRepository(c.DB)
+
+	// Top-up repositories
+	c.TopUpRepository = postgres.NewTopUpRepository(c.DB)
+
+	// Payout repositories
+	c.PayoutRepository = postgres.NewPayoutRepository(c.DB)
+
+	// Community repositories
+	c.CommunityRepository = postgres.NewCommunityRepository(c.DB)
+
+	// Report repositories
+	c.ReportRepository = postgres.NewReportRepository(c.DB)
+
+	// Session repositories
+	c.SessionRepository = postgres.NewSessionRepository(c.DB)
+	c.UserTokenRepository = postgres.NewUserTokenRepository(c.DB)
+	c.TwoFactorRepository = postgres.NewTwoFactorRepository(c.DB)
+	c.UserIdentityRepository = postgres.NewUserIdentityRepository(c.DB)
+	c.RoleRepository = postgres.NewRoleRepository(c.DB)
+	c.UserSanctionRepository = postgres.NewUserSanctionRepository(c.DB)
+	c.AccountDataRepository = postgres.NewAccountDataRepository(c.DB)
+	c.APITokenRepository = postgres.NewAPITokenRepository(c.DB)
+
+	log.Println("✓ Repositories initialized (38 repositories)")
 	return nil
 }
 
 func (c *Container) initServices() error {
+	// Authorization service (role-based permissions, checked by middleware and moderation paths)
+	c.AuthorizationService = serviceimpl.NewAuthorizationService(c.RoleRepository, c.UserRepository, c.CommunityRepository, c.RedisService)
+
+	// Session service (issues tokens for every login path)
+	c.SessionService = serviceimpl.NewSessionService(c.SessionRepository, c.UserRepository, c.RedisService, c.Config.JWT)
+
+	// Account email service (verification and password reset links)
+	c.AccountEmailService = serviceimpl.NewAccountEmailService(
+		c.UserRepository,
+		c.UserTokenRepository,
+		c.SessionService,
+		c.Mailer,
+		c.Config.JWT.Secret,
+		c.Config.App.FrontendURL,
+	)
+
+	// Two-factor service (login challenges end in a session)
+	c.TwoFactorService = serviceimpl.NewTwoFactorService(
+		c.TwoFactorRepository,
+		c.UserTokenRepository,
+		c.UserRepository,
+		c.SessionService,
+		c.Config.MFA,
+		c.Config.JWT.Secret,
+	)
+
 	// Legacy services
-	c.UserService = serviceimpl.NewUserService(c.UserRepository, c.FollowRepository, c.Config.JWT.Secret)
+	c.UserService = serviceimpl.NewUserService(c.UserRepository, c.FollowRepository, c.SessionService, c.TwoFactorService, c.Config.JWT.Secret)
 	c.TaskService = serviceimpl.NewTaskService(c.TaskRepository, c.UserRepository)
 	c.FileService = serviceimpl.NewFileService(c.FileRepository, c.UserRepository, c.BunnyStorage)
 
-	// OAuth service
-	c.OAuthService = serviceimpl.NewOAuthService(c.UserRepository, c.Config)
+	// OAuth service (login and account linking through the provider registry)
+	c.OAuthService = serviceimpl.NewOAuthService(
+		c.OAuthProviders,
+		c.UserIdentityRepository,
+		c.UserRepository,
+		c.SessionService,
+		c.TwoFactorService,
+		c.RedisService,
+		c.Config,
+	)
 
 	// Social media services (order matters due to dependencies)
 	// 1. No service dependencies
 	c.TagService = serviceimpl.NewTagService(c.TagRepository)
+	c.WalletService = serviceimpl.NewWalletService(c.WalletRepository)
 	c.NotificationService = serviceimpl.NewNotificationService(
 		c.NotificationRepository,
 		c.NotificationSettingsRepository,
 		c.UserRepository,
 	)
+	c.SanctionService = serviceimpl.NewSanctionService(
+		c.UserSanctionRepository,
+		c.UserRepository,
+		c.PostRepository,
+		c.CommentRepository,
+		c.AuthorizationService,
+		c.NotificationService,
+		c.RedisService,
+	)
 	c.PushService = serviceimpl.NewPushService(
 		c.PushSubscriptionRepository,
 		c.Config,
 	)
+	c.AdService = serviceimpl.NewAdService(
+		c.AdRepository,
+		c.MediaRepository,
+		c.WalletService,
+		c.NotificationService,
+		c.RedisService,
+	)
+	c.TopUpService = serviceimpl.NewTopUpService(
+		c.TopUpRepository,
+		c.WalletService,
+		c.NotificationService,
+		c.PaymentProvider,
+		c.Config.Payment.AllowSimulation,
+	)
+	c.PayoutService = serviceimpl.NewPayoutService(
+		c.TxManager,
+		c.PayoutRepository,
+		c.UserRepository,
+		c.WalletService,
+		c.NotificationService,
+	)
+	c.CommunityService = serviceimpl.NewCommunityService(
+		c.CommunityRepository,
+		c.MediaRepository,
+		c.NotificationService,
+		c.AuthorizationService,
+	)
 
 	// 2. Depends on TagService
 	c.PostService = serviceimpl.NewPostService(
@@ -360,6 +675,14 @@ func (c *Container) initServices() error {
 		c.NotificationHub,
 		c.RedisService,
 		c.FeedCacheService,
+		c.PostUnlockRepository,
+		c.MediaUploadService,
+		c.SubscriptionRepository,
+		c.AdService,
+		c.CommunityRepository,
+		c.AuthorizationService,
+		c.SanctionService,
+		c.FollowRepository,
 	)
 
 	// 3. Depends on NotificationService
@@ -368,6 +691,8 @@ func (c *Container) initServices() error {
 		c.PostRepository,
 		c.VoteRepository,
 		c.NotificationService,
+		c.AuthorizationService,
+		c.SanctionService,
 	)
 	c.VoteService = serviceimpl.NewVoteService(
 		c.VoteRepository,
@@ -375,18 +700,54 @@ func (c *Container) initServices() error {
 		c.CommentRepository,
 		c.UserRepository,
 		c.NotificationService,
+		c.SanctionService,
 	)
 	c.FollowService = serviceimpl.NewFollowService(
 		c.FollowRepository,
 		c.UserRepository,
 		c.NotificationService,
 	)
+	c.PostUnlockService = serviceimpl.NewPostUnlockService(
+		c.TxManager,
+		c.PostUnlockRepository,
+		c.PostRepository,
+		c.UserRepository,
+		c.WalletService,
+		c.NotificationService,
+	)
+	c.GiftService = serviceimpl.NewGiftService(
+		c.TxManager,
+		c.GiftRepository,
+		c.MessageRepository,
+		c.ConversationRepository,
+		c.BlockRepository,
+		c.MediaRepository,
+		c.WalletService,
+		c.NotificationService,
+		c.SanctionService,
+		c.RedisService,
+	)
+	c.ReportService = serviceimpl.NewReportService(
+		c.ReportRepository,
+		c.PostRepository,
+		c.CommentRepository,
+		c.MessageRepository,
+		c.ConversationRepository,
+		c.UserRepository,
+		c.PostService,
+		c.CommentService,
+		c.GiftService,
+		c.NotificationService,
+		c.SanctionService,
+	)
 
 	// 4. Independent services
 	c.SavedPostService = serviceimpl.NewSavedPostService(
 		c.SavedPostRepository,
 		c.PostRepository,
 		c.VoteRepository,
+		c.PostUnlockRepository,
+		c.SubscriptionRepository,
 	)
 	c.SearchService = serviceimpl.NewSearchService(
 		c.PostRepository,
@@ -403,7 +764,11 @@ func (c *Container) initServices() error {
 		c.RedisService,
 	)
 
-	// 5. Chat system services
+	// 5. Chat system services (message requests reuse BlockService)
+	c.BlockService = serviceimpl.NewBlockService(
+		c.BlockRepository,
+		c.UserRepository,
+	)
 	c.ConversationService = serviceimpl.NewConversationService(
 		c.ConversationRepository,
 		c.MessageRepository,
@@ -411,6 +776,7 @@ func (c *Container) initServices() error {
 		c.UserRepository,
 		c.FollowRepository,
 		c.RedisService,
+		c.BlockService,
 	)
 	c.MessageService = serviceimpl.NewMessageService(
 		c.MessageRepository,
@@ -418,10 +784,46 @@ func (c *Container) initServices() error {
 		c.BlockRepository,
 		c.UserRepository,
 		c.RedisService,
+		c.SanctionService,
 	)
-	c.BlockService = serviceimpl.NewBlockService(
-		c.BlockRepository,
+	c.SubscriptionService = serviceimpl.NewSubscriptionService(
+		c.SubscriptionRepository,
+		c.UserRepository,
+		c.WalletService,
+		c.NotificationService,
+	)
+	c.ReelService = serviceimpl.NewReelService(
+		c.ReelRepository,
+		c.MediaRepository,
+		c.FollowRepository,
+		c.MediaService,
+		c.BunnyStreamService,
+		c.NotificationService,
+	)
+	c.AccountDataService = serviceimpl.NewAccountDataService(
+		c.TxManager,
+		c.AccountDataRepository,
 		c.UserRepository,
+		c.PostRepository,
+		c.CommentRepository,
+		c.VoteRepository,
+		c.MediaRepository,
+		c.NotificationRepository,
+		c.WalletRepository,
+		c.WalletService,
+		c.SessionService,
+		c.NotificationService,
+		c.R2Storage,
+		c.BunnyStorage,
+		c.BunnyStreamService,
+	)
+
+	// API token service (personal access tokens and bot keys accepted by Protected)
+	c.APITokenService = serviceimpl.NewAPITokenService(
+		c.APITokenRepository,
+		c.UserRepository,
+		c.RoleRepository,
+		c.RedisService,
 	)
 
 	// 6. Upload services
@@ -450,9 +852,10 @@ func (c *Container) initServices() error {
 	// Set push service for notification service (to avoid circular dependency)
 	if notifService, ok := c.NotificationService.(*serviceimpl.NotificationServiceImpl); ok {
 		notifService.SetPushService(c.PushService)
+		notifService.SetMailer(c.Mailer, c.Config.App.FrontendURL)
 	}
 
-	log.Println("✓ Services initialized (20 services)")
+	log.Println("✓ Services initialized (21 services)")
 	return nil
 }
 
@@ -520,6 +923,162 @@ func (c *Container) initScheduler() error {
 		log.Println("✓ Simple auto-post processor scheduled (every hour)")
 	}
 
+	// Release expired wallet holds (runs every 5 minutes)
+	err = c.EventScheduler.AddJob("wallet-hold-expiry", "*/5 * * * *", func() {
+		released, err := c.WalletService.ReleaseExpiredHolds(ctx)
+		if err != nil {
+			log.Printf("❌ Wallet hold expiry error: %v", err)
+		} else if released > 0 {
+			log.Printf("✓ Released %d expired wallet holds", released)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to schedule wallet hold expiry: %v", err)
+	} else {
+		log.Println("✓ Wallet hold expiry scheduled (every 5 minutes)")
+	}
+
+	// Fail premium unlock posts past their deadline and retry pending settlements (runs every 5 minutes)
+	err = c.EventScheduler.AddJob("post-unlock-expiry", "*/5 * * * *", func() {
+		failed, err := c.PostUnlockService.ProcessExpiredUnlocks(ctx)
+		if err != nil {
+			log.Printf("❌ Post unlock expiry error: %v", err)
+		} else if failed > 0 {
+			log.Printf("✓ Closed %d expired premium unlock posts", failed)
+		}
+
+		settled, err := c.PostUnlockService.SettlePendingUnlocks(ctx)
+		if err != nil {
+			log.Printf("❌ Post unlock settlement error: %v", err)
+		} else if settled > 0 {
+			log.Printf("✓ Settled %d pending unlock contributions", settled)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to schedule post unlock expiry: %v", err)
+	} else {
+		log.Println("✓ Post unlock expiry scheduled (every 5 minutes)")
+	}
+
+	// Expire unopened gifts (partial refund to sender) and retry pending settlements (runs every 15 minutes)
+	err = c.EventScheduler.AddJob("gift-expiry", "*/15 * * * *", func() {
+		expired, err := c.GiftService.ProcessExpiredGifts(ctx)
+		if err != nil {
+			log.Printf("❌ Gift expiry error: %v", err)
+		} else if expired > 0 {
+			log.Printf("✓ Expired %d unopened gifts", expired)
+		}
+
+		settled, err := c.GiftService.SettlePendingGifts(ctx)
+		if err != nil {
+			log.Printf("❌ Gift settlement error: %v", err)
+		} else if settled > 0 {
+			log.Printf("✓ Settled %d pending gifts", settled)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to schedule gift expiry: %v", err)
+	} else {
+		log.Println("✓ Gift expiry scheduled (every 15 minutes)")
+	}
+
+	// Renew subscriptions (with payment retries and grace period) and expire lapsed ones (runs every hour)
+	err = c.EventScheduler.AddJob("subscription-renewal", "0 * * * *", func() {
+		renewed, err := c.SubscriptionService.ProcessRenewals(ctx)
+		if err != nil {
+			log.Printf("❌ Subscription renewal error: %v", err)
+		} else if renewed > 0 {
+			log.Printf("✓ Renewed %d subscriptions", renewed)
+		}
+
+		expired, err := c.SubscriptionService.ProcessExpirations(ctx)
+		if err != nil {
+			log.Printf("❌ Subscription expiry error: %v", err)
+		} else if expired > 0 {
+			log.Printf("✓ Expired %d subscriptions", expired)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to schedule subscription renewal: %v", err)
+	} else {
+		log.Println("✓ Subscription renewal scheduled (every hour)")
+	}
+
+	// Expire reels past 24 hours and delete their Bunny Stream videos (runs every 15 minutes)
+	err = c.EventScheduler.AddJob("reel-expiry", "*/15 * * * *", func() {
+		expired, err := c.ReelService.ProcessExpiredReels(ctx)
+		if err != nil {
+			log.Printf("❌ Reel expiry error: %v", err)
+		} else if expired > 0 {
+			log.Printf("✓ Expired %d reels", expired)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to schedule reel expiry: %v", err)
+	} else {
+		log.Println("✓ Reel expiry scheduled (every 15 minutes)")
+	}
+
+	// Start verified ads on their start date and complete ended ones (runs every 5 minutes)
+	err = c.EventScheduler.AddJob("ad-schedule", "*/5 * * * *", func() {
+		activated, completed, err := c.AdService.ProcessSchedule(ctx)
+		if err != nil {
+			log.Printf("❌ Ad schedule error: %v", err)
+		} else if activated > 0 || completed > 0 {
+			log.Printf("✓ Activated %d ads, completed %d ads", activated, completed)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to schedule ad activation: %v", err)
+	} else {
+		log.Println("✓ Ad activation scheduled (every 5 minutes)")
+	}
+
+	// Expire unpaid top-up QRs and retry failed wallet credits (runs every 5 minutes)
+	err = c.EventScheduler.AddJob("topup-processing", "*/5 * * * *", func() {
+		expired, credited, err := c.TopUpService.ProcessTopUps(ctx)
+		if err != nil {
+			log.Printf("❌ Top-up processing error: %v", err)
+		} else if expired > 0 || credited > 0 {
+			log.Printf("✓ Expired %d top-ups, credited %d top-ups", expired, credited)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to schedule top-up processing: %v", err)
+	} else {
+		log.Println("✓ Top-up processing scheduled (every 5 minutes)")
+	}
+
+	// Build requested data exports and delete expired archives (runs every 5 minutes)
+	err = c.EventScheduler.AddJob("data-exports", "*/5 * * * *", func() {
+		built, err := c.AccountDataService.ProcessExports(ctx)
+		if err != nil {
+			log.Printf("❌ Data export error: %v", err)
+		} else if built > 0 {
+			log.Printf("✓ Built %d data exports", built)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to schedule data exports: %v", err)
+	} else {
+		log.Println("✓ Data exports scheduled (every 5 minutes)")
+	}
+
+	// Anonymize accounts whose deletion cooling-off period has passed (runs every hour)
+	err = c.EventScheduler.AddJob("account-deletion", "0 * * * *", func() {
+		deleted, err := c.AccountDataService.ProcessDeletions(ctx)
+		if err != nil {
+			log.Printf("❌ Account deletion error: %v", err)
+		} else if deleted > 0 {
+			log.Printf("✓ Deleted %d accounts", deleted)
+		}
+	})
+	if err != nil {
+		log.Printf("Warning: Failed to schedule account deletion: %v", err)
+	} else {
+		log.Println("✓ Account deletion scheduled (every hour)")
+	}
+
 	return nil
 }
 
@@ -540,6 +1099,14 @@ func (c *Container) initChatHub() error {
 		log.Println("✓ ChatHub injected to MessageService")
 	}
 
+	// Suspensions and bans disconnect the user's open sockets
+	if sanctionServiceImpl, ok := c.SanctionService.(*serviceimpl.SanctionServiceImpl); ok {
+		sanctionServiceImpl.SetChatHub(c.ChatHub)
+	}
+
+	// Gift boxes are sent and opened over the chat socket as well
+	c.ChatHub.SetGiftService(c.GiftService)
+
 	// Start ChatHub in background
 	go c.ChatHub.Run()
 	log.Println("✓ ChatHub started")
@@ -564,6 +1131,7 @@ func (c *Container) initVideoEncoderWorker() error {
 		c.MediaRepository,
 		c.NotificationService,
 		c.PostService,
+		c.ReelService,
 		c.NotificationHub,
 	)
 
@@ -663,6 +1231,57 @@ func (c *Container) GetHandlerServices() *handlers.Services {
 
 		// Auto-Post services
 		AutoPostService: c.AutoPostService,
+
+		// Wallet services
+		WalletService: c.WalletService,
+
+		// Premium unlock services
+		PostUnlockService: c.PostUnlockService,
+
+		// Gift services
+		GiftService: c.GiftService,
+
+		// Subscription services
+		SubscriptionService: c.SubscriptionService,
+
+		// Reel services
+		ReelService: c.ReelService,
+
+		// Ad services
+		AdService: c.AdService,
+
+		// Top-up services
+		TopUpService: c.TopUpService,
+
+		// Payout services
+		PayoutService: c.PayoutService,
+
+		// Community services
+		CommunityService: c.CommunityService,
+
+		// Report services
+		ReportService: c.ReportService,
+
+		// Session services
+		SessionService: c.SessionService,
+
+		// Account email services
+		AccountEmailService: c.AccountEmailService,
+
+		// Two-factor services
+		TwoFactorService: c.TwoFactorService,
+
+		// Authorization services
+		AuthorizationService: c.AuthorizationService,
+
+		// Sanction services
+		SanctionService: c.SanctionService,
+
+		// Data export and account deletion services
+		AccountDataService: c.AccountDataService,
+
+		// API token services
+		APITokenService: c.APITokenService,
 	}
 }
 
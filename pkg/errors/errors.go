@@ -88,6 +88,12 @@ var (
 		Message:    "Access denied",
 		StatusCode: http.StatusForbidden,
 	}
+
+	ErrEmailNotVerified = &AppError{
+		Code:       "EMAIL_NOT_VERIFIED",
+		Message:    "Please verify your email address before signing in",
+		StatusCode: http.StatusForbidden,
+	}
 )
 
 // 404 Not Found
@@ -162,6 +168,15 @@ var (
 	}
 )
 
+// 429 Too Many Requests
+var (
+	ErrTooManyRequests = &AppError{
+		Code:       "RATE_LIMIT_EXCEEDED",
+		Message:    "Too many requests, please try again later",
+		StatusCode: http.StatusTooManyRequests,
+	}
+)
+
 // 500 Internal Server Error
 var (
 	ErrInternal = &AppError{
@@ -195,6 +210,15 @@ var (
 	}
 )
 
+// 503 Service Unavailable
+var (
+	ErrServiceUnavailable = &AppError{
+		Code:       "SERVICE_UNAVAILABLE",
+		Message:    "Service temporarily unavailable",
+		StatusCode: http.StatusServiceUnavailable,
+	}
+)
+
 // IsAppError checks if error is AppError
 func IsAppError(err error) (*AppError, bool) {
 	appErr, ok := err.(*AppError)
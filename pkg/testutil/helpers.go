@@ -23,7 +23,6 @@ func CreateTestUser() *models.User {
 		Bio:         faker.Sentence(),
 		Avatar:      "https://example.com/avatar.jpg",
 		IsActive:    true,
-		Role:        "user",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -42,7 +41,6 @@ func CreateTestUserWithData(email, username, password string) *models.User {
 		Bio:         faker.Sentence(),
 		Avatar:      "https://example.com/avatar.jpg",
 		IsActive:    true,
-		Role:        "user",
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -115,7 +113,7 @@ func CreateTestMessage(conversationID, senderID, receiverID uuid.UUID) *models.M
 		ID:             uuid.New(),
 		ConversationID: conversationID,
 		SenderID:       senderID,
-		ReceiverID:     receiverID,
+		ReceiverID:     &receiverID,
 		Type:           models.MessageTypeText,
 		Content:        &content,
 		IsRead:         false,
@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Cursor represents a pagination cursor
@@ -44,3 +46,39 @@ func DecodeCursor(cursorStr string) (*time.Time, error) {
 
 	return &cursor.Timestamp, nil
 }
+
+// EncodeCursorWithID encodes a timestamp plus the ID that orders rows sharing that timestamp
+func EncodeCursorWithID(timestamp time.Time, id uuid.UUID) (string, error) {
+	jsonBytes, err := json.Marshal(Cursor{Timestamp: timestamp, ID: id.String()})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(jsonBytes), nil
+}
+
+// DecodeCursorWithID decodes a cursor into its timestamp and ID (uuid.Nil for cursors made by EncodeCursor)
+func DecodeCursorWithID(cursorStr string) (*time.Time, uuid.UUID, error) {
+	if cursorStr == "" {
+		return nil, uuid.Nil, nil
+	}
+
+	jsonBytes, err := base64.StdEncoding.DecodeString(cursorStr)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(jsonBytes, &cursor); err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	id := uuid.Nil
+	if cursor.ID != "" {
+		if id, err = uuid.Parse(cursor.ID); err != nil {
+			return nil, uuid.Nil, err
+		}
+	}
+
+	return &cursor.Timestamp, id, nil
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorWithID_RoundTrip(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 123456000, time.UTC)
+	id := uuid.New()
+
+	encoded, err := EncodeCursorWithID(at, id)
+	assert.NoError(t, err)
+
+	timestamp, decodedID, err := DecodeCursorWithID(encoded)
+	assert.NoError(t, err)
+	assert.True(t, at.Equal(*timestamp))
+	assert.Equal(t, id, decodedID)
+}
+
+func TestDecodeCursorWithID_TimestampOnlyCursor(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	encoded, err := EncodeCursor(at)
+	assert.NoError(t, err)
+
+	timestamp, id, err := DecodeCursorWithID(encoded)
+	assert.NoError(t, err)
+	assert.True(t, at.Equal(*timestamp))
+	assert.Equal(t, uuid.Nil, id)
+}
@@ -18,18 +18,47 @@ var (
 )
 
 type JWTClaims struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     string `json:"role,omitempty"`
+	UserID    string   `json:"user_id"`
+	Username  string   `json:"username"`
+	Email     string   `json:"email"`
+	SessionID string   `json:"sid"`
+	AMR       []string `json:"amr,omitempty"` // authentication methods, "mfa" when the session passed a second factor
 	jwt.RegisteredClaims
 }
 
 type UserContext struct {
-	ID       uuid.UUID
-	Username string
-	Email    string
-	Role     string
+	ID        uuid.UUID
+	Username  string
+	Email     string
+	SessionID uuid.UUID
+
+	// Session signed in with a second factor (amr "mfa")
+	MFAVerified bool
+
+	// Set when the request authenticated with an API token instead of a session JWT
+	TokenID uuid.UUID
+	Scopes  []string
+}
+
+// amrMFA - amr claim value of access tokens whose session passed a second factor
+const amrMFA = "mfa"
+
+// IsAPIToken reports whether the request authenticated with a personal access token or bot key
+func (u *UserContext) IsAPIToken() bool {
+	return u.TokenID != uuid.Nil
+}
+
+// HasScope reports whether an API token was granted scope (session JWTs have every scope)
+func (u *UserContext) HasScope(scope string) bool {
+	if !u.IsAPIToken() {
+		return true
+	}
+	for _, s := range u.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
 }
 
 func ValidateTokenStringToUUID(tokenString, jwtSecret string) (*UserContext, error) {
@@ -66,11 +95,25 @@ func ValidateTokenStringToUUID(tokenString, jwtSecret string) (*UserContext, err
 		return nil, ErrInvalidToken
 	}
 
+	// Access tokens are bound to a session so they can be revoked
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	mfaVerified := false
+	for _, method := range claims.AMR {
+		if method == amrMFA {
+			mfaVerified = true
+		}
+	}
+
 	return &UserContext{
-		ID:       userID,
-		Username: claims.Username,
-		Email:    claims.Email,
-		Role:     claims.Role,
+		ID:          userID,
+		Username:    claims.Username,
+		Email:       claims.Email,
+		SessionID:   sessionID,
+		MFAVerified: mfaVerified,
 	}, nil
 }
 
@@ -109,19 +152,31 @@ func GetUserFromContext(c *fiber.Ctx) (*UserContext, error) {
 	return userCtx, nil
 }
 
-// GenerateToken generates a JWT token for the given user ID
-func GenerateToken(userID uuid.UUID, jwtSecret string) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": userID.String(),
-		"exp":     time.Now().Add(time.Hour * 24 * 7).Unix(), // 7 days
-		"iat":     time.Now().Unix(),
+// GenerateAccessToken generates a short-lived JWT access token bound to a session
+func GenerateAccessToken(user *UserContext, ttl time.Duration, jwtSecret string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims := JWTClaims{
+		UserID:    user.ID.String(),
+		Username:  user.Username,
+		Email:     user.Email,
+		SessionID: user.SessionID.String(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	if user.MFAVerified {
+		claims.AMR = []string{amrMFA}
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(jwtSecret))
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 
-	return tokenString, nil
+	return tokenString, expiresAt, nil
 }
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessToken_CarriesMFAClaim(t *testing.T) {
+	user := &UserContext{ID: uuid.New(), Username: "alice", Email: "alice@example.com", SessionID: uuid.New()}
+
+	// Password-only session
+	raw, _, err := GenerateAccessToken(user, time.Minute, "secret")
+	assert.NoError(t, err)
+	parsed, err := ValidateTokenStringToUUID(raw, "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, user.SessionID, parsed.SessionID)
+	assert.False(t, parsed.MFAVerified)
+
+	// Session signed in with a second factor
+	user.MFAVerified = true
+	raw, _, err = GenerateAccessToken(user, time.Minute, "secret")
+	assert.NoError(t, err)
+	parsed, err = ValidateTokenStringToUUID("Bearer "+raw, "secret")
+	assert.NoError(t, err)
+	assert.True(t, parsed.MFAVerified)
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+)
+
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// EncryptSecret seals a small secret (e.g. a TOTP key) with AES-256-GCM.
+// The key is any string; it is stretched to 32 bytes with SHA-256.
+// Output: base64(nonce || ciphertext)
+func EncryptSecret(plaintext, key string) (string, error) {
+	gcm, err := secretBoxCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// DecryptSecret opens a value produced by EncryptSecret
+func DecryptSecret(encoded, key string) (string, error) {
+	gcm, err := secretBoxCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", ErrInvalidCiphertext
+	}
+
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", ErrInvalidCiphertext
+	}
+	return string(plaintext), nil
+}
+
+func secretBoxCipher(key string) (cipher.AEAD, error) {
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignParseToken(t *testing.T) {
+	token := SignedToken{ID: uuid.New(), Purpose: "verify_email", ExpiresAt: time.Now().Add(time.Hour)}
+
+	raw := SignToken(token, "secret")
+	parsed, err := ParseSignedToken(raw, "verify_email", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, token.ID, parsed.ID)
+	assert.Equal(t, token.ExpiresAt.Unix(), parsed.ExpiresAt.Unix())
+}
+
+func TestParseSignedToken_Rejects(t *testing.T) {
+	token := SignedToken{ID: uuid.New(), Purpose: "verify_email", ExpiresAt: time.Now().Add(time.Hour)}
+	raw := SignToken(token, "secret")
+
+	// Wrong secret
+	_, err := ParseSignedToken(raw, "verify_email", "other-secret")
+	assert.ErrorIs(t, err, ErrInvalidSignedToken)
+
+	// Wrong purpose
+	_, err = ParseSignedToken(raw, "reset_password", "secret")
+	assert.ErrorIs(t, err, ErrInvalidSignedToken)
+
+	// Tampered payload
+	encoded, sig, _ := strings.Cut(raw, ".")
+	_, err = ParseSignedToken(encoded+"x."+sig, "verify_email", "secret")
+	assert.ErrorIs(t, err, ErrInvalidSignedToken)
+
+	// Malformed
+	_, err = ParseSignedToken("not-a-token", "verify_email", "secret")
+	assert.ErrorIs(t, err, ErrInvalidSignedToken)
+}
+
+func TestParseSignedToken_Expired(t *testing.T) {
+	token := SignedToken{ID: uuid.New(), Purpose: "reset_password", ExpiresAt: time.Now().Add(-time.Minute)}
+
+	_, err := ParseSignedToken(SignToken(token, "secret"), "reset_password", "secret")
+	assert.ErrorIs(t, err, ErrExpiredSignedToken)
+}